@@ -0,0 +1,308 @@
+package vulkan
+
+import (
+	"sort"
+	"sync"
+	"unsafe"
+)
+
+// DefaultAllocatorBlockSize is the size of the device memory blocks an Allocator
+// sub-allocates from. Requests larger than this get a block of their own.
+const DefaultAllocatorBlockSize = DeviceSize(64 * 1024 * 1024)
+
+// Allocator sub-allocates buffers from large device memory blocks. Drivers limit the
+// number of live allocations (see PhysicalDeviceLimits.MaxMemoryAllocationCount, often
+// 4096), so allocating one DeviceMemory per buffer fails once an application creates a
+// few thousand objects. The allocator keeps one list of blocks per memory type and
+// places buffers in them using a first-fit free list. Buffers that prefer or require a
+// dedicated allocation get a block of their own. Dedicated allocations need a Vulkan 1.1
+// device; on Vulkan 1.0 every buffer is sub-allocated.
+//
+// An Allocator is safe for concurrent use.
+type Allocator struct {
+	mu            sync.Mutex
+	device        Device
+	memProperties PhysicalDeviceMemoryProperties
+	blockSize     DeviceSize
+	blocks        map[uint32][]*memoryBlock
+	// requirements2 is set when the device supports the Vulkan 1.1 memory requirement queries
+	requirements2 bool
+}
+
+// Allocation is a buffer bound to a range of a memory block owned by an Allocator
+type Allocation struct {
+	Buffer Buffer
+	// Memory is the block the buffer is bound to; it may be shared with other allocations
+	Memory DeviceMemory
+	Offset DeviceSize
+	Size   DeviceSize
+	// Mapped points at the start of the allocation for host-visible memory and is nil otherwise.
+	// The block stays mapped for its whole lifetime, so do not call MapMemory on Memory.
+	Mapped unsafe.Pointer
+
+	block           *memoryBlock
+	memoryTypeIndex uint32
+}
+
+// memoryBlock is a single device memory allocation carved into sub-ranges
+type memoryBlock struct {
+	memory DeviceMemory
+	size   DeviceSize
+	mapped unsafe.Pointer
+	// dedicated blocks belong to a single buffer and are never shared
+	dedicated bool
+	// free holds the unused ranges sorted by offset, with adjacent ranges merged
+	free []memoryRange
+}
+
+type memoryRange struct {
+	offset DeviceSize
+	size   DeviceSize
+}
+
+// NewAllocator creates an allocator for a device
+func NewAllocator(device Device, physicalDevice PhysicalDevice) (*Allocator, error) {
+	if device == nil {
+		return nil, NewValidationError("device", "cannot be nil")
+	}
+	if physicalDevice == nil {
+		return nil, NewValidationError("physicalDevice", "cannot be nil")
+	}
+
+	return &Allocator{
+		device:        device,
+		memProperties: GetPhysicalDeviceMemoryProperties(physicalDevice),
+		blockSize:     DefaultAllocatorBlockSize,
+		blocks:        make(map[uint32][]*memoryBlock),
+		requirements2: GetPhysicalDeviceProperties(physicalDevice).APIVersion >= Version11,
+	}, nil
+}
+
+// bufferMemoryRequirements returns the memory requirements of buffer. Vulkan 1.0 devices
+// cannot report dedicated allocation preferences, so they get zero dedicated requirements.
+func (a *Allocator) bufferMemoryRequirements(buffer Buffer) (MemoryRequirements, MemoryDedicatedRequirements) {
+	if !a.requirements2 {
+		return GetBufferMemoryRequirements(a.device, buffer), MemoryDedicatedRequirements{}
+	}
+	return GetBufferMemoryRequirements2(a.device, buffer)
+}
+
+// AllocateBuffer creates a buffer and binds it to memory with the requested properties
+func (a *Allocator) AllocateBuffer(size DeviceSize, usage BufferUsageFlags, memoryProperties MemoryPropertyFlags) (*Allocation, error) {
+	buffer, err := CreateBuffer(a.device, &BufferCreateInfo{
+		Size:        size,
+		Usage:       usage,
+		SharingMode: SharingModeExclusive,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	reqs, dedicatedReqs := a.bufferMemoryRequirements(buffer)
+	memoryTypeIndex, found := FindMemoryType(a.memProperties, reqs.MemoryTypeBits, memoryProperties)
+	if !found {
+		DestroyBuffer(a.device, buffer)
+		return nil, NewVulkanError(ErrorFeatureNotPresent, "AllocateBuffer", "no memory type matches the requested properties")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var block *memoryBlock
+	var offset DeviceSize
+	if dedicatedReqs.RequiresDedicated || dedicatedReqs.PrefersDedicated {
+		block, err = a.newBlock(memoryTypeIndex, reqs.Size, buffer)
+	} else {
+		block, offset, err = a.allocateRange(memoryTypeIndex, reqs.Size, reqs.Alignment)
+	}
+	if err != nil {
+		DestroyBuffer(a.device, buffer)
+		return nil, err
+	}
+
+	if err := BindBufferMemory(a.device, buffer, block.memory, offset); err != nil {
+		a.releaseRange(memoryTypeIndex, block, offset, reqs.Size)
+		DestroyBuffer(a.device, buffer)
+		return nil, wrapError(err, ErrorOutOfDeviceMemory, "AllocateBuffer", "failed to bind buffer memory")
+	}
+
+	allocation := &Allocation{
+		Buffer:          buffer,
+		Memory:          block.memory,
+		Offset:          offset,
+		Size:            reqs.Size,
+		block:           block,
+		memoryTypeIndex: memoryTypeIndex,
+	}
+	if block.mapped != nil {
+		allocation.Mapped = unsafe.Add(block.mapped, offset)
+	}
+	return allocation, nil
+}
+
+// Free destroys the buffer of an allocation and returns its memory to the allocator
+func (a *Allocator) Free(allocation *Allocation) {
+	if allocation == nil || allocation.block == nil {
+		return
+	}
+
+	DestroyBuffer(a.device, allocation.Buffer)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.releaseRange(allocation.memoryTypeIndex, allocation.block, allocation.Offset, allocation.Size)
+	allocation.block = nil
+	allocation.Mapped = nil
+}
+
+// Destroy frees every memory block owned by the allocator. All allocations must
+// have been freed, or at least their buffers destroyed, beforehand.
+func (a *Allocator) Destroy() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for memoryTypeIndex, blocks := range a.blocks {
+		for _, block := range blocks {
+			a.freeBlock(block)
+		}
+		delete(a.blocks, memoryTypeIndex)
+	}
+}
+
+// allocateRange finds space in an existing block of the memory type or creates a new block
+func (a *Allocator) allocateRange(memoryTypeIndex uint32, size, alignment DeviceSize) (*memoryBlock, DeviceSize, error) {
+	for _, block := range a.blocks[memoryTypeIndex] {
+		if block.dedicated {
+			continue
+		}
+		if offset, ok := block.allocate(size, alignment); ok {
+			return block, offset, nil
+		}
+	}
+
+	blockSize := a.blockSize
+	if size > blockSize {
+		blockSize = size
+	}
+
+	block, err := a.newBlock(memoryTypeIndex, blockSize, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	offset, _ := block.allocate(size, alignment)
+	return block, offset, nil
+}
+
+// newBlock allocates and maps a memory block and adds it to the block list. If
+// dedicatedBuffer is set the block is a dedicated allocation for that buffer and is
+// returned with its whole range already allocated.
+func (a *Allocator) newBlock(memoryTypeIndex uint32, size DeviceSize, dedicatedBuffer Buffer) (*memoryBlock, error) {
+	memory, err := AllocateMemory(a.device, &MemoryAllocateInfo{
+		AllocationSize:  size,
+		MemoryTypeIndex: memoryTypeIndex,
+		DedicatedBuffer: dedicatedBuffer,
+	})
+	if err != nil {
+		return nil, wrapError(err, ErrorOutOfDeviceMemory, "AllocateBuffer", "failed to allocate memory block")
+	}
+
+	block := newMemoryBlock(memory, size)
+	if a.memProperties.MemoryTypes[memoryTypeIndex].PropertyFlags&MemoryPropertyHostVisibleBit != 0 {
+		mapped, err := MapMemory(a.device, memory, 0, DeviceSize(WholeSize), 0)
+		if err != nil {
+			FreeMemory(a.device, memory)
+			return nil, wrapError(err, ErrorMemoryMapFailed, "AllocateBuffer", "failed to map memory block")
+		}
+		block.mapped = mapped
+	}
+	if dedicatedBuffer != nil {
+		block.dedicated = true
+		block.allocate(size, 1)
+	}
+	a.blocks[memoryTypeIndex] = append(a.blocks[memoryTypeIndex], block)
+	return block, nil
+}
+
+// releaseRange returns a range to its block and frees the block once it is empty
+func (a *Allocator) releaseRange(memoryTypeIndex uint32, block *memoryBlock, offset, size DeviceSize) {
+	block.release(offset, size)
+	if !block.empty() {
+		return
+	}
+
+	blocks := a.blocks[memoryTypeIndex]
+	for i, b := range blocks {
+		if b == block {
+			a.blocks[memoryTypeIndex] = append(blocks[:i], blocks[i+1:]...)
+			break
+		}
+	}
+	a.freeBlock(block)
+}
+
+func (a *Allocator) freeBlock(block *memoryBlock) {
+	if block.mapped != nil {
+		UnmapMemory(a.device, block.memory)
+		block.mapped = nil
+	}
+	FreeMemory(a.device, block.memory)
+}
+
+func newMemoryBlock(memory DeviceMemory, size DeviceSize) *memoryBlock {
+	return &memoryBlock{
+		memory: memory,
+		size:   size,
+		free:   []memoryRange{{offset: 0, size: size}},
+	}
+}
+
+// allocate reserves size bytes at an offset aligned to alignment using first fit
+func (b *memoryBlock) allocate(size, alignment DeviceSize) (DeviceSize, bool) {
+	if alignment == 0 {
+		alignment = 1
+	}
+
+	for i, r := range b.free {
+		offset := (r.offset + alignment - 1) / alignment * alignment
+		padding := offset - r.offset
+		if padding+size > r.size {
+			continue
+		}
+
+		// Split the free range into the leading padding and the trailing remainder
+		var replacement []memoryRange
+		if padding > 0 {
+			replacement = append(replacement, memoryRange{offset: r.offset, size: padding})
+		}
+		if remainder := r.size - padding - size; remainder > 0 {
+			replacement = append(replacement, memoryRange{offset: offset + size, size: remainder})
+		}
+		b.free = append(b.free[:i], append(replacement, b.free[i+1:]...)...)
+		return offset, true
+	}
+	return 0, false
+}
+
+// release returns a range to the free list, merging it with its neighbours
+func (b *memoryBlock) release(offset, size DeviceSize) {
+	i := sort.Search(len(b.free), func(i int) bool { return b.free[i].offset > offset })
+	b.free = append(b.free, memoryRange{})
+	copy(b.free[i+1:], b.free[i:])
+	b.free[i] = memoryRange{offset: offset, size: size}
+
+	if i+1 < len(b.free) && b.free[i].offset+b.free[i].size == b.free[i+1].offset {
+		b.free[i].size += b.free[i+1].size
+		b.free = append(b.free[:i+1], b.free[i+2:]...)
+	}
+	if i > 0 && b.free[i-1].offset+b.free[i-1].size == b.free[i].offset {
+		b.free[i-1].size += b.free[i].size
+		b.free = append(b.free[:i], b.free[i+1:]...)
+	}
+}
+
+// empty reports whether the block has no live sub-allocations
+func (b *memoryBlock) empty() bool {
+	return len(b.free) == 1 && b.free[0].offset == 0 && b.free[0].size == b.size
+}
@@ -0,0 +1,61 @@
+package vulkan
+
+import (
+	"errors"
+	"testing"
+	"unsafe"
+)
+
+// TestMemoryBlockSubAllocation tests aligned first-fit placement and free range merging
+func TestMemoryBlockSubAllocation(t *testing.T) {
+	block := newMemoryBlock(nil, 1024)
+
+	first, ok := block.allocate(100, 1)
+	if !ok || first != 0 {
+		t.Fatalf("Expected first allocation at offset 0, got %d (ok=%v)", first, ok)
+	}
+
+	second, ok := block.allocate(100, 256)
+	if !ok || second != 256 {
+		t.Fatalf("Expected aligned allocation at offset 256, got %d (ok=%v)", second, ok)
+	}
+
+	// The padding between the two allocations stays available
+	third, ok := block.allocate(64, 4)
+	if !ok || third != 100 {
+		t.Fatalf("Expected allocation in padding at offset 100, got %d (ok=%v)", third, ok)
+	}
+
+	if _, ok := block.allocate(2048, 1); ok {
+		t.Error("Expected allocation larger than the block to fail")
+	}
+
+	block.release(second, 100)
+	block.release(first, 100)
+	if block.empty() {
+		t.Error("Expected block with a live allocation not to be empty")
+	}
+
+	block.release(third, 64)
+	if !block.empty() {
+		t.Errorf("Expected all ranges to merge back into one, got %v", block.free)
+	}
+}
+
+// TestNewAllocatorValidation tests input validation for NewAllocator
+func TestNewAllocatorValidation(t *testing.T) {
+	// fakeHandle gives the validation a non-nil handle without converting an integer to a
+	// pointer, which go vet rejects
+	var fakeHandle byte
+
+	_, err := NewAllocator(nil, PhysicalDevice(unsafe.Pointer(&fakeHandle)))
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Parameter != "device" {
+		t.Errorf("Expected ValidationError for device, got %v", err)
+	}
+
+	_, err = NewAllocator(Device(unsafe.Pointer(&fakeHandle)), nil)
+	if !errors.As(err, &validationErr) || validationErr.Parameter != "physicalDevice" {
+		t.Errorf("Expected ValidationError for physicalDevice, got %v", err)
+	}
+}
@@ -0,0 +1,161 @@
+package vulkan
+
+/*
+#include <vulkan/vulkan.h>
+#include <stdlib.h>
+
+// Function pointers for VK_EXT_calibrated_timestamps functions.
+// Both are loaded through the instance so they can be used before and after device creation.
+//
+// IMPORTANT: These are global static pointers and NOT thread-safe during loading.
+// LoadCalibratedTimestampsFunctions must be called from a single thread during
+// initialization before any concurrent calibrated timestamp API usage.
+static PFN_vkGetPhysicalDeviceCalibrateableTimeDomainsEXT pfn_vkGetPhysicalDeviceCalibrateableTimeDomainsEXT = NULL;
+static PFN_vkGetCalibratedTimestampsEXT pfn_vkGetCalibratedTimestampsEXT = NULL;
+
+static int loadCalibratedTimestampsInstanceFunctions(VkInstance instance) {
+    if (instance == VK_NULL_HANDLE) {
+        return 0;
+    }
+    pfn_vkGetPhysicalDeviceCalibrateableTimeDomainsEXT = (PFN_vkGetPhysicalDeviceCalibrateableTimeDomainsEXT)
+        vkGetInstanceProcAddr(instance, "vkGetPhysicalDeviceCalibrateableTimeDomainsEXT");
+    pfn_vkGetCalibratedTimestampsEXT = (PFN_vkGetCalibratedTimestampsEXT)
+        vkGetInstanceProcAddr(instance, "vkGetCalibratedTimestampsEXT");
+
+    return pfn_vkGetPhysicalDeviceCalibrateableTimeDomainsEXT != NULL &&
+           pfn_vkGetCalibratedTimestampsEXT != NULL;
+}
+
+// Wrappers return VK_ERROR_EXTENSION_NOT_PRESENT if the function pointer is NULL.
+static VkResult call_vkGetPhysicalDeviceCalibrateableTimeDomainsEXT(
+    VkPhysicalDevice physicalDevice,
+    uint32_t* pTimeDomainCount,
+    VkTimeDomainEXT* pTimeDomains) {
+    if (pfn_vkGetPhysicalDeviceCalibrateableTimeDomainsEXT == NULL) {
+        return VK_ERROR_EXTENSION_NOT_PRESENT;
+    }
+    return pfn_vkGetPhysicalDeviceCalibrateableTimeDomainsEXT(physicalDevice, pTimeDomainCount, pTimeDomains);
+}
+
+static VkResult call_vkGetCalibratedTimestampsEXT(
+    VkDevice device,
+    uint32_t timestampCount,
+    const VkCalibratedTimestampInfoEXT* pTimestampInfos,
+    uint64_t* pTimestamps,
+    uint64_t* pMaxDeviation) {
+    if (pfn_vkGetCalibratedTimestampsEXT == NULL) {
+        return VK_ERROR_EXTENSION_NOT_PRESENT;
+    }
+    return pfn_vkGetCalibratedTimestampsEXT(device, timestampCount, pTimestampInfos, pTimestamps, pMaxDeviation);
+}
+*/
+import "C"
+
+import "fmt"
+
+// ExtensionNameCalibratedTimestamps is the calibrated timestamps device extension name
+const ExtensionNameCalibratedTimestamps = "VK_EXT_calibrated_timestamps"
+
+// TimeDomain identifies a clock that timestamps can be sampled from
+type TimeDomain int32
+
+const (
+	// TimeDomainDevice is the clock used by timestamp queries, in ticks of
+	// PhysicalDeviceLimits.TimestampPeriod nanoseconds
+	TimeDomainDevice TimeDomain = C.VK_TIME_DOMAIN_DEVICE_EXT
+	// TimeDomainClockMonotonic is CLOCK_MONOTONIC in nanoseconds
+	TimeDomainClockMonotonic TimeDomain = C.VK_TIME_DOMAIN_CLOCK_MONOTONIC_EXT
+	// TimeDomainClockMonotonicRaw is CLOCK_MONOTONIC_RAW in nanoseconds
+	TimeDomainClockMonotonicRaw TimeDomain = C.VK_TIME_DOMAIN_CLOCK_MONOTONIC_RAW_EXT
+	// TimeDomainQueryPerformanceCounter is QueryPerformanceCounter on Windows
+	TimeDomainQueryPerformanceCounter TimeDomain = C.VK_TIME_DOMAIN_QUERY_PERFORMANCE_COUNTER_EXT
+)
+
+// CalibratedTimestampInfo selects the clock to sample in GetCalibratedTimestampsEXT
+type CalibratedTimestampInfo struct {
+	TimeDomain TimeDomain
+}
+
+// LoadCalibratedTimestampsFunctions loads VK_EXT_calibrated_timestamps functions for an instance.
+//
+// This function MUST be called after creating an instance and before querying time domains or
+// sampling timestamps. The device passed to GetCalibratedTimestampsEXT must have been created
+// with the VK_EXT_calibrated_timestamps extension enabled.
+//
+// IMPORTANT: This function is NOT thread-safe. Only one instance is supported at a time;
+// calling this function again will overwrite previously loaded function pointers.
+//
+// Returns false if any calibrated timestamps function could not be loaded.
+func LoadCalibratedTimestampsFunctions(instance Instance) bool {
+	return C.loadCalibratedTimestampsInstanceFunctions(C.VkInstance(instance)) != 0
+}
+
+// GetPhysicalDeviceCalibrateableTimeDomainsEXT lists the time domains that can be sampled
+// together with GetCalibratedTimestampsEXT
+func GetPhysicalDeviceCalibrateableTimeDomainsEXT(physicalDevice PhysicalDevice) ([]TimeDomain, error) {
+	if physicalDevice == nil {
+		return nil, NewValidationError("physicalDevice", "cannot be nil")
+	}
+
+	var count C.uint32_t
+	result := Result(C.call_vkGetPhysicalDeviceCalibrateableTimeDomainsEXT(C.VkPhysicalDevice(physicalDevice), &count, nil))
+	traceResult("vkGetPhysicalDeviceCalibrateableTimeDomainsEXT", result)
+	if result != Success {
+		return nil, NewVulkanError(result, "GetPhysicalDeviceCalibrateableTimeDomainsEXT", "failed to get time domain count")
+	}
+	if count == 0 {
+		return nil, nil
+	}
+
+	cDomains := make([]C.VkTimeDomainEXT, count)
+	result = Result(C.call_vkGetPhysicalDeviceCalibrateableTimeDomainsEXT(C.VkPhysicalDevice(physicalDevice), &count, &cDomains[0]))
+	traceResult("vkGetPhysicalDeviceCalibrateableTimeDomainsEXT", result)
+	if result != Success && result != Incomplete {
+		return nil, NewVulkanError(result, "GetPhysicalDeviceCalibrateableTimeDomainsEXT", "failed to get time domains")
+	}
+
+	domains := make([]TimeDomain, count)
+	for i := range domains {
+		domains[i] = TimeDomain(cDomains[i])
+	}
+	return domains, nil
+}
+
+// GetCalibratedTimestampsEXT samples the clocks in infos as close together as possible.
+// timestamps[i] is the value of infos[i].TimeDomain, and maxDeviation is the largest
+// possible difference in nanoseconds between the moments the clocks were sampled. Pairing a
+// TimeDomainDevice sample with a host clock sample maps GPU query timestamps onto the host
+// timeline, which is how GPU work should be aligned with CPU frame times.
+func GetCalibratedTimestampsEXT(device Device, infos []CalibratedTimestampInfo) (timestamps []uint64, maxDeviation uint64, err error) {
+	if device == nil {
+		return nil, 0, NewValidationError("device", "cannot be nil")
+	}
+	if len(infos) == 0 {
+		return nil, 0, NewValidationError("infos", "cannot be empty")
+	}
+
+	cInfos := make([]C.VkCalibratedTimestampInfoEXT, len(infos))
+	seen := make(map[TimeDomain]bool, len(infos))
+	for i, info := range infos {
+		if seen[info.TimeDomain] {
+			return nil, 0, NewValidationError(fmt.Sprintf("infos[%d].TimeDomain", i), "each time domain may only be sampled once")
+		}
+		seen[info.TimeDomain] = true
+		cInfos[i].sType = C.VK_STRUCTURE_TYPE_CALIBRATED_TIMESTAMP_INFO_EXT
+		cInfos[i].timeDomain = C.VkTimeDomainEXT(info.TimeDomain)
+	}
+
+	cTimestamps := make([]C.uint64_t, len(infos))
+	var cMaxDeviation C.uint64_t
+	result := Result(C.call_vkGetCalibratedTimestampsEXT(C.VkDevice(device), C.uint32_t(len(cInfos)), &cInfos[0], &cTimestamps[0], &cMaxDeviation))
+	traceResult("vkGetCalibratedTimestampsEXT", result)
+	if result != Success {
+		return nil, 0, NewVulkanError(result, "GetCalibratedTimestampsEXT", "failed to get calibrated timestamps")
+	}
+
+	timestamps = make([]uint64, len(infos))
+	for i := range timestamps {
+		timestamps[i] = uint64(cTimestamps[i])
+	}
+	return timestamps, uint64(cMaxDeviation), nil
+}
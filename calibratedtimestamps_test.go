@@ -0,0 +1,64 @@
+//go:build cgo
+
+package vulkan
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestCalibratedTimestampsValidation tests input validation and the unloaded-extension error
+func TestCalibratedTimestampsValidation(t *testing.T) {
+	fakeDevice := Device(uintptr(0x1234))
+
+	tests := []struct {
+		name       string
+		device     Device
+		infos      []CalibratedTimestampInfo
+		errorParam string
+	}{
+		{
+			name:       "nil device",
+			device:     nil,
+			infos:      []CalibratedTimestampInfo{{TimeDomain: TimeDomainDevice}},
+			errorParam: "device",
+		},
+		{
+			name:       "no infos",
+			device:     fakeDevice,
+			infos:      nil,
+			errorParam: "infos",
+		},
+		{
+			name:   "duplicate time domain",
+			device: fakeDevice,
+			infos: []CalibratedTimestampInfo{
+				{TimeDomain: TimeDomainDevice},
+				{TimeDomain: TimeDomainClockMonotonic},
+				{TimeDomain: TimeDomainDevice},
+			},
+			errorParam: "infos[2].TimeDomain",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := GetCalibratedTimestampsEXT(tt.device, tt.infos)
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+
+	// Without LoadCalibratedTimestampsFunctions the queries report the missing extension
+	_, _, err := GetCalibratedTimestampsEXT(fakeDevice, []CalibratedTimestampInfo{{TimeDomain: TimeDomainDevice}})
+	var vulkanErr *VulkanError
+	if !errors.As(err, &vulkanErr) || vulkanErr.Result != ErrorExtensionNotPresent {
+		t.Errorf("Expected ErrorExtensionNotPresent, got %v", err)
+	}
+}
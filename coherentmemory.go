@@ -0,0 +1,59 @@
+package vulkan
+
+/*
+#include <vulkan/vulkan.h>
+#include <stdlib.h>
+*/
+import "C"
+
+import "unsafe"
+
+// ExtensionNameDeviceCoherentMemory is the AMD device coherent memory extension name
+const ExtensionNameDeviceCoherentMemory = "VK_AMD_device_coherent_memory"
+
+// PhysicalDeviceCoherentMemoryFeatures contains the AMD device coherent memory feature
+type PhysicalDeviceCoherentMemoryFeatures struct {
+	// DeviceCoherentMemory allows allocating from memory types with
+	// MemoryPropertyDeviceCoherentBit. Writes to such memory are visible to the host and
+	// other queues without explicit flushes, so markers written before a crash survive a
+	// device loss.
+	DeviceCoherentMemory bool
+}
+
+// coherentMemoryFeaturesToC prepends a struct enabling the device coherent memory feature to
+// the pNext chain next. The struct is allocated in C memory and appended to allocations,
+// which the caller must free.
+func coherentMemoryFeaturesToC(features *PhysicalDeviceCoherentMemoryFeatures, next unsafe.Pointer, allocations *[]unsafe.Pointer) (unsafe.Pointer, error) {
+	cCoherent := (*C.VkPhysicalDeviceCoherentMemoryFeaturesAMD)(C.calloc(1, C.sizeof_VkPhysicalDeviceCoherentMemoryFeaturesAMD))
+	if cCoherent == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "CreateDevice", "failed to allocate memory for coherent memory features")
+	}
+	*allocations = append(*allocations, unsafe.Pointer(cCoherent))
+
+	cCoherent.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_COHERENT_MEMORY_FEATURES_AMD
+	cCoherent.pNext = next
+	cCoherent.deviceCoherentMemory = boolToVkBool32(features.DeviceCoherentMemory)
+
+	return unsafe.Pointer(cCoherent), nil
+}
+
+// GetPhysicalDeviceCoherentMemoryFeaturesAMD queries device coherent memory support
+func GetPhysicalDeviceCoherentMemoryFeaturesAMD(physicalDevice PhysicalDevice) PhysicalDeviceCoherentMemoryFeatures {
+	cFeatures2 := (*C.VkPhysicalDeviceFeatures2)(C.calloc(1, C.sizeof_VkPhysicalDeviceFeatures2))
+	cCoherent := (*C.VkPhysicalDeviceCoherentMemoryFeaturesAMD)(C.calloc(1, C.sizeof_VkPhysicalDeviceCoherentMemoryFeaturesAMD))
+	defer C.free(unsafe.Pointer(cFeatures2))
+	defer C.free(unsafe.Pointer(cCoherent))
+	if cFeatures2 == nil || cCoherent == nil {
+		return PhysicalDeviceCoherentMemoryFeatures{}
+	}
+
+	cFeatures2.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_FEATURES_2
+	cFeatures2.pNext = unsafe.Pointer(cCoherent)
+	cCoherent.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_COHERENT_MEMORY_FEATURES_AMD
+
+	C.vkGetPhysicalDeviceFeatures2(C.VkPhysicalDevice(physicalDevice), cFeatures2)
+
+	return PhysicalDeviceCoherentMemoryFeatures{
+		DeviceCoherentMemory: vkBool32ToBool(cCoherent.deviceCoherentMemory),
+	}
+}
@@ -6,6 +6,13 @@ package vulkan
 */
 import "C"
 
+import (
+	"fmt"
+	"sync"
+	"time"
+	"unsafe"
+)
+
 // CommandPoolCreateInfo contains command pool creation information
 type CommandPoolCreateInfo struct {
 	Flags            CommandPoolCreateFlags
@@ -21,6 +28,13 @@ const (
 	CommandPoolCreateProtectedBit          CommandPoolCreateFlags = C.VK_COMMAND_POOL_CREATE_PROTECTED_BIT
 )
 
+// CommandPoolResetFlags represents command pool reset flags
+type CommandPoolResetFlags uint32
+
+const (
+	CommandPoolResetReleaseResourcesBit CommandPoolResetFlags = C.VK_COMMAND_POOL_RESET_RELEASE_RESOURCES_BIT
+)
+
 // CommandBufferAllocateInfo contains command buffer allocation information
 type CommandBufferAllocateInfo struct {
 	CommandPool        CommandPool
@@ -39,8 +53,60 @@ const (
 // CommandBufferBeginInfo contains command buffer begin information
 type CommandBufferBeginInfo struct {
 	Flags CommandBufferUsageFlags
+	// InheritanceInfo is required for secondary command buffers and ignored for primary ones
+	InheritanceInfo *CommandBufferInheritanceInfo
+}
+
+// CommandBufferInheritanceInfo describes the state a secondary command buffer inherits
+// from the primary command buffer that executes it
+type CommandBufferInheritanceInfo struct {
+	RenderPass           RenderPass
+	Subpass              uint32
+	Framebuffer          Framebuffer
+	OcclusionQueryEnable bool
+	QueryFlags           QueryControlFlags
+	PipelineStatistics   QueryPipelineStatisticFlags
+	// Rendering describes the dynamic rendering scope the secondary is executed in.
+	// Set it together with CommandBufferUsageRenderPassContinueBit to record a secondary
+	// for use inside CmdBeginRendering with RenderingContentsSecondaryCommandBuffers.
+	Rendering *CommandBufferInheritanceRenderingInfo
+}
+
+// CommandBufferInheritanceRenderingInfo describes the dynamic rendering attachments a
+// secondary command buffer is recorded against
+type CommandBufferInheritanceRenderingInfo struct {
+	Flags                   RenderingFlags
+	ViewMask                uint32
+	ColorAttachmentFormats  []Format
+	DepthAttachmentFormat   Format
+	StencilAttachmentFormat Format
+	RasterizationSamples    SampleCountFlags
 }
 
+// QueryControlFlags represents query control flags
+type QueryControlFlags uint32
+
+const (
+	QueryControlPreciseBit QueryControlFlags = C.VK_QUERY_CONTROL_PRECISE_BIT
+)
+
+// QueryPipelineStatisticFlags represents pipeline statistics query counters
+type QueryPipelineStatisticFlags uint32
+
+const (
+	QueryPipelineStatisticInputAssemblyVerticesBit                   QueryPipelineStatisticFlags = C.VK_QUERY_PIPELINE_STATISTIC_INPUT_ASSEMBLY_VERTICES_BIT
+	QueryPipelineStatisticInputAssemblyPrimitivesBit                 QueryPipelineStatisticFlags = C.VK_QUERY_PIPELINE_STATISTIC_INPUT_ASSEMBLY_PRIMITIVES_BIT
+	QueryPipelineStatisticVertexShaderInvocationsBit                 QueryPipelineStatisticFlags = C.VK_QUERY_PIPELINE_STATISTIC_VERTEX_SHADER_INVOCATIONS_BIT
+	QueryPipelineStatisticGeometryShaderInvocationsBit               QueryPipelineStatisticFlags = C.VK_QUERY_PIPELINE_STATISTIC_GEOMETRY_SHADER_INVOCATIONS_BIT
+	QueryPipelineStatisticGeometryShaderPrimitivesBit                QueryPipelineStatisticFlags = C.VK_QUERY_PIPELINE_STATISTIC_GEOMETRY_SHADER_PRIMITIVES_BIT
+	QueryPipelineStatisticClippingInvocationsBit                     QueryPipelineStatisticFlags = C.VK_QUERY_PIPELINE_STATISTIC_CLIPPING_INVOCATIONS_BIT
+	QueryPipelineStatisticClippingPrimitivesBit                      QueryPipelineStatisticFlags = C.VK_QUERY_PIPELINE_STATISTIC_CLIPPING_PRIMITIVES_BIT
+	QueryPipelineStatisticFragmentShaderInvocationsBit               QueryPipelineStatisticFlags = C.VK_QUERY_PIPELINE_STATISTIC_FRAGMENT_SHADER_INVOCATIONS_BIT
+	QueryPipelineStatisticTessellationControlShaderPatchesBit        QueryPipelineStatisticFlags = C.VK_QUERY_PIPELINE_STATISTIC_TESSELLATION_CONTROL_SHADER_PATCHES_BIT
+	QueryPipelineStatisticTessellationEvaluationShaderInvocationsBit QueryPipelineStatisticFlags = C.VK_QUERY_PIPELINE_STATISTIC_TESSELLATION_EVALUATION_SHADER_INVOCATIONS_BIT
+	QueryPipelineStatisticComputeShaderInvocationsBit                QueryPipelineStatisticFlags = C.VK_QUERY_PIPELINE_STATISTIC_COMPUTE_SHADER_INVOCATIONS_BIT
+)
+
 // CommandBufferUsageFlags represents command buffer usage flags
 type CommandBufferUsageFlags uint32
 
@@ -83,12 +149,22 @@ const (
 
 // SemaphoreCreateInfo contains semaphore creation information
 type SemaphoreCreateInfo struct {
-	// No fields needed for basic semaphore creation
+	// SemaphoreType defaults to SemaphoreTypeBinary; timeline semaphores require the
+	// TimelineSemaphore feature
+	SemaphoreType SemaphoreType
+	// InitialValue is the starting counter value of a timeline semaphore
+	InitialValue uint64
+	// ExportHandleTypes makes the semaphore exportable as these handle types, e.g. with
+	// GetSemaphoreFdKHR
+	ExportHandleTypes ExternalSemaphoreHandleTypeFlags
 }
 
 // FenceCreateInfo contains fence creation information
 type FenceCreateInfo struct {
 	Flags FenceCreateFlags
+	// ExportHandleTypes makes the fence exportable as these handle types, e.g. with
+	// GetFenceFdKHR
+	ExportHandleTypes ExternalFenceHandleTypeFlags
 }
 
 // FenceCreateFlags represents fence creation flags
@@ -108,6 +184,7 @@ func CreateCommandPool(device Device, createInfo *CommandPoolCreateInfo) (Comman
 
 	var commandPool C.VkCommandPool
 	result := Result(C.vkCreateCommandPool(C.VkDevice(device), &cCreateInfo, nil, &commandPool))
+	traceResult("vkCreateCommandPool", result)
 	if result != Success {
 		return nil, result
 	}
@@ -115,11 +192,31 @@ func CreateCommandPool(device Device, createInfo *CommandPoolCreateInfo) (Comman
 	return CommandPool(commandPool), nil
 }
 
-// DestroyCommandPool destroys a command pool
+// DestroyCommandPool destroys a command pool and frees the command buffers allocated from it
 func DestroyCommandPool(device Device, commandPool CommandPool) {
+	allocatedCommandBuffers.removePool(device, commandPool)
 	C.vkDestroyCommandPool(C.VkDevice(device), C.VkCommandPool(commandPool), nil)
 }
 
+// ResetCommandPool returns every command buffer allocated from a pool to the initial state.
+// None of them may be pending execution. CommandPoolResetReleaseResourcesBit also hands the
+// pool's memory back to the system.
+func ResetCommandPool(device Device, commandPool CommandPool, flags CommandPoolResetFlags) error {
+	if device == nil {
+		return NewValidationError("device", "cannot be nil")
+	}
+	if commandPool == nil {
+		return NewValidationError("commandPool", "cannot be nil")
+	}
+
+	result := Result(C.vkResetCommandPool(C.VkDevice(device), C.VkCommandPool(commandPool), C.VkCommandPoolResetFlags(flags)))
+	traceResult("vkResetCommandPool", result)
+	if result != Success {
+		return NewVulkanError(result, "ResetCommandPool", "failed to reset command pool")
+	}
+	return nil
+}
+
 // AllocateCommandBuffers allocates command buffers
 func AllocateCommandBuffers(device Device, allocateInfo *CommandBufferAllocateInfo) ([]CommandBuffer, error) {
 	var cAllocateInfo C.VkCommandBufferAllocateInfo
@@ -131,6 +228,7 @@ func AllocateCommandBuffers(device Device, allocateInfo *CommandBufferAllocateIn
 
 	cCommandBuffers := make([]C.VkCommandBuffer, allocateInfo.CommandBufferCount)
 	result := Result(C.vkAllocateCommandBuffers(C.VkDevice(device), &cAllocateInfo, &cCommandBuffers[0]))
+	traceResult("vkAllocateCommandBuffers", result)
 	if result != Success {
 		return nil, result
 	}
@@ -139,6 +237,7 @@ func AllocateCommandBuffers(device Device, allocateInfo *CommandBufferAllocateIn
 	for i := range commandBuffers {
 		commandBuffers[i] = CommandBuffer(cCommandBuffers[i])
 	}
+	allocatedCommandBuffers.add(device, allocateInfo.CommandPool, commandBuffers, allocateInfo.Level == CommandBufferLevelSecondary)
 
 	return commandBuffers, nil
 }
@@ -153,19 +252,201 @@ func FreeCommandBuffers(device Device, commandPool CommandPool, commandBuffers [
 	for i, cb := range commandBuffers {
 		cCommandBuffers[i] = C.VkCommandBuffer(cb)
 	}
+	allocatedCommandBuffers.remove(commandBuffers)
 
 	C.vkFreeCommandBuffers(C.VkDevice(device), C.VkCommandPool(commandPool), C.uint32_t(len(cCommandBuffers)), &cCommandBuffers[0])
 }
 
+// commandBufferOwner records the device and pool a command buffer was allocated from and its
+// level
+type commandBufferOwner struct {
+	device    Device
+	pool      CommandPool
+	secondary bool
+}
+
+// commandBufferRegistry tracks the command buffers allocated through AllocateCommandBuffers
+// by device and pool, so BeginCommandBuffer, QueueSubmit and CmdExecuteCommands know each
+// buffer's level. Drivers recycle command buffer handles, so entries are dropped when the
+// buffers are freed or when their pool or device is destroyed.
+type commandBufferRegistry struct {
+	mu     sync.RWMutex
+	owners map[CommandBuffer]commandBufferOwner
+	pools  map[Device]map[CommandPool]map[CommandBuffer]struct{}
+}
+
+var allocatedCommandBuffers = commandBufferRegistry{
+	owners: make(map[CommandBuffer]commandBufferOwner),
+	pools:  make(map[Device]map[CommandPool]map[CommandBuffer]struct{}),
+}
+
+// add records command buffers allocated from pool on device
+func (r *commandBufferRegistry) add(device Device, pool CommandPool, commandBuffers []CommandBuffer, secondary bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	pools, ok := r.pools[device]
+	if !ok {
+		pools = make(map[CommandPool]map[CommandBuffer]struct{})
+		r.pools[device] = pools
+	}
+	buffers, ok := pools[pool]
+	if !ok {
+		buffers = make(map[CommandBuffer]struct{}, len(commandBuffers))
+		pools[pool] = buffers
+	}
+	for _, cb := range commandBuffers {
+		r.owners[cb] = commandBufferOwner{device: device, pool: pool, secondary: secondary}
+		buffers[cb] = struct{}{}
+	}
+}
+
+// remove forgets freed command buffers
+func (r *commandBufferRegistry) remove(commandBuffers []CommandBuffer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, cb := range commandBuffers {
+		owner, ok := r.owners[cb]
+		if !ok {
+			continue
+		}
+		delete(r.owners, cb)
+		delete(r.pools[owner.device][owner.pool], cb)
+	}
+}
+
+// removePool forgets every command buffer allocated from pool on device
+func (r *commandBufferRegistry) removePool(device Device, pool CommandPool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	pools := r.pools[device]
+	for cb := range pools[pool] {
+		delete(r.owners, cb)
+	}
+	delete(pools, pool)
+}
+
+// removeDevice forgets every command buffer allocated on device
+func (r *commandBufferRegistry) removeDevice(device Device) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, buffers := range r.pools[device] {
+		for cb := range buffers {
+			delete(r.owners, cb)
+		}
+	}
+	delete(r.pools, device)
+}
+
+// owner returns where commandBuffer was allocated from, or false if it was not allocated
+// through AllocateCommandBuffers or has since been freed
+func (r *commandBufferRegistry) owner(commandBuffer CommandBuffer) (commandBufferOwner, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	owner, ok := r.owners[commandBuffer]
+	return owner, ok
+}
+
+// isSecondary reports whether commandBuffer was allocated with CommandBufferLevelSecondary
+func (r *commandBufferRegistry) isSecondary(commandBuffer CommandBuffer) bool {
+	owner, ok := r.owner(commandBuffer)
+	return ok && owner.secondary
+}
+
+// inheritanceInfoToC converts inheritance info, including the dynamic rendering
+// inheritance chain, into C memory
+func inheritanceInfoToC(info *CommandBufferInheritanceInfo, allocations *[]unsafe.Pointer) (*C.VkCommandBufferInheritanceInfo, error) {
+	cInfo := (*C.VkCommandBufferInheritanceInfo)(C.calloc(1, C.sizeof_VkCommandBufferInheritanceInfo))
+	if cInfo == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "BeginCommandBuffer", "failed to allocate memory for inheritance info")
+	}
+	*allocations = append(*allocations, unsafe.Pointer(cInfo))
+
+	cInfo.sType = C.VK_STRUCTURE_TYPE_COMMAND_BUFFER_INHERITANCE_INFO
+	cInfo.renderPass = C.VkRenderPass(info.RenderPass)
+	cInfo.subpass = C.uint32_t(info.Subpass)
+	cInfo.framebuffer = C.VkFramebuffer(info.Framebuffer)
+	cInfo.occlusionQueryEnable = boolToVkBool32(info.OcclusionQueryEnable)
+	cInfo.queryFlags = C.VkQueryControlFlags(info.QueryFlags)
+	cInfo.pipelineStatistics = C.VkQueryPipelineStatisticFlags(info.PipelineStatistics)
+
+	if info.Rendering != nil {
+		if info.RenderPass != nil {
+			return nil, NewValidationError("InheritanceInfo.Rendering", "cannot be combined with a render pass")
+		}
+
+		cRendering := (*C.VkCommandBufferInheritanceRenderingInfo)(C.calloc(1, C.sizeof_VkCommandBufferInheritanceRenderingInfo))
+		if cRendering == nil {
+			return nil, NewVulkanError(ErrorOutOfHostMemory, "BeginCommandBuffer", "failed to allocate memory for rendering inheritance info")
+		}
+		*allocations = append(*allocations, unsafe.Pointer(cRendering))
+
+		cRendering.sType = C.VK_STRUCTURE_TYPE_COMMAND_BUFFER_INHERITANCE_RENDERING_INFO
+		cRendering.flags = C.VkRenderingFlags(info.Rendering.Flags)
+		cRendering.viewMask = C.uint32_t(info.Rendering.ViewMask)
+		cRendering.depthAttachmentFormat = C.VkFormat(info.Rendering.DepthAttachmentFormat)
+		cRendering.stencilAttachmentFormat = C.VkFormat(info.Rendering.StencilAttachmentFormat)
+		cRendering.rasterizationSamples = C.VkSampleCountFlagBits(info.Rendering.RasterizationSamples)
+		if cRendering.rasterizationSamples == 0 {
+			cRendering.rasterizationSamples = C.VK_SAMPLE_COUNT_1_BIT
+		}
+
+		if len(info.Rendering.ColorAttachmentFormats) > 0 {
+			cFormatsPtr := (*C.VkFormat)(C.calloc(C.size_t(len(info.Rendering.ColorAttachmentFormats)), C.size_t(unsafe.Sizeof(C.VkFormat(0)))))
+			if cFormatsPtr == nil {
+				return nil, NewVulkanError(ErrorOutOfHostMemory, "BeginCommandBuffer", "failed to allocate memory for color attachment formats")
+			}
+			*allocations = append(*allocations, unsafe.Pointer(cFormatsPtr))
+
+			cFormats := unsafe.Slice(cFormatsPtr, len(info.Rendering.ColorAttachmentFormats))
+			for i, format := range info.Rendering.ColorAttachmentFormats {
+				cFormats[i] = C.VkFormat(format)
+			}
+			cRendering.colorAttachmentCount = C.uint32_t(len(info.Rendering.ColorAttachmentFormats))
+			cRendering.pColorAttachmentFormats = cFormatsPtr
+		}
+		cInfo.pNext = unsafe.Pointer(cRendering)
+	}
+
+	return cInfo, nil
+}
+
 // BeginCommandBuffer begins recording a command buffer
 func BeginCommandBuffer(commandBuffer CommandBuffer, beginInfo *CommandBufferBeginInfo) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+	if beginInfo == nil {
+		return NewValidationError("beginInfo", "cannot be nil")
+	}
+
 	var cBeginInfo C.VkCommandBufferBeginInfo
 	cBeginInfo.sType = C.VK_STRUCTURE_TYPE_COMMAND_BUFFER_BEGIN_INFO
 	cBeginInfo.pNext = nil
 	cBeginInfo.flags = C.VkCommandBufferUsageFlags(beginInfo.Flags)
 	cBeginInfo.pInheritanceInfo = nil
 
+	if allocatedCommandBuffers.isSecondary(commandBuffer) {
+		if beginInfo.InheritanceInfo == nil {
+			return NewValidationError("InheritanceInfo", "required for secondary command buffers")
+		}
+
+		var allocations []unsafe.Pointer
+		defer func() { freeAllocations(allocations) }()
+
+		cInheritanceInfo, err := inheritanceInfoToC(beginInfo.InheritanceInfo, &allocations)
+		if err != nil {
+			return err
+		}
+		cBeginInfo.pInheritanceInfo = cInheritanceInfo
+	}
+
 	result := Result(C.vkBeginCommandBuffer(C.VkCommandBuffer(commandBuffer), &cBeginInfo))
+	traceResult("vkBeginCommandBuffer", result)
 	if result != Success {
 		return result
 	}
@@ -174,7 +455,11 @@ func BeginCommandBuffer(commandBuffer CommandBuffer, beginInfo *CommandBufferBeg
 
 // EndCommandBuffer ends recording a command buffer
 func EndCommandBuffer(commandBuffer CommandBuffer) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
 	result := Result(C.vkEndCommandBuffer(C.VkCommandBuffer(commandBuffer)))
+	traceResult("vkEndCommandBuffer", result)
 	if result != Success {
 		return result
 	}
@@ -185,6 +470,7 @@ func EndCommandBuffer(commandBuffer CommandBuffer) error {
 func QueueSubmit(queue Queue, submitInfos []SubmitInfo, fence Fence) error {
 	if len(submitInfos) == 0 {
 		result := Result(C.vkQueueSubmit(C.VkQueue(queue), 0, nil, C.VkFence(fence)))
+		traceResult("vkQueueSubmit", result)
 		if result != Success {
 			return result
 		}
@@ -248,12 +534,61 @@ func QueueSubmit(queue Queue, submitInfos []SubmitInfo, fence Fence) error {
 	}
 
 	result := Result(C.vkQueueSubmit(C.VkQueue(queue), C.uint32_t(len(cSubmitInfos)), &cSubmitInfos[0], C.VkFence(fence)))
+	traceResult("vkQueueSubmit", result)
 	if result != Success {
 		return result
 	}
 	return nil
 }
 
+// QueueSubmitBatch submits independent primary command buffers to a queue as a single
+// VkSubmitInfo, which is cheaper than one submission per command buffer. The command
+// buffers start in order but may run concurrently; use QueueSubmit with semaphores when
+// they depend on each other. fence, if not nil, is signaled once all of them complete.
+// With no command buffers, only the fence is signaled.
+func QueueSubmitBatch(queue Queue, commandBuffers []CommandBuffer, fence Fence) error {
+	if queue == nil {
+		return NewValidationError("queue", "cannot be nil")
+	}
+	if len(commandBuffers) == 0 {
+		return QueueSubmit(queue, nil, fence)
+	}
+
+	cCommandBuffersPtr := (*C.VkCommandBuffer)(C.calloc(C.size_t(len(commandBuffers)), C.size_t(unsafe.Sizeof(C.VkCommandBuffer(nil)))))
+	if cCommandBuffersPtr == nil {
+		return NewVulkanError(ErrorOutOfHostMemory, "QueueSubmitBatch", "failed to allocate memory for command buffers")
+	}
+	defer C.free(unsafe.Pointer(cCommandBuffersPtr))
+
+	cCommandBuffers := unsafe.Slice(cCommandBuffersPtr, len(commandBuffers))
+	for i, commandBuffer := range commandBuffers {
+		if commandBuffer == nil {
+			return NewValidationError(fmt.Sprintf("commandBuffers[%d]", i), "cannot be nil")
+		}
+		if allocatedCommandBuffers.isSecondary(commandBuffer) {
+			return NewValidationError(fmt.Sprintf("commandBuffers[%d]", i), "secondary command buffers cannot be submitted; execute them with CmdExecuteCommands")
+		}
+		cCommandBuffers[i] = C.VkCommandBuffer(commandBuffer)
+	}
+
+	cSubmitInfo := (*C.VkSubmitInfo)(C.calloc(1, C.sizeof_VkSubmitInfo))
+	if cSubmitInfo == nil {
+		return NewVulkanError(ErrorOutOfHostMemory, "QueueSubmitBatch", "failed to allocate memory for submit info")
+	}
+	defer C.free(unsafe.Pointer(cSubmitInfo))
+
+	cSubmitInfo.sType = C.VK_STRUCTURE_TYPE_SUBMIT_INFO
+	cSubmitInfo.commandBufferCount = C.uint32_t(len(commandBuffers))
+	cSubmitInfo.pCommandBuffers = cCommandBuffersPtr
+
+	result := Result(C.vkQueueSubmit(C.VkQueue(queue), 1, cSubmitInfo, C.VkFence(fence)))
+	traceResult("vkQueueSubmit", result)
+	if result != Success {
+		return NewVulkanError(result, "QueueSubmitBatch", "failed to submit command buffers")
+	}
+	return nil
+}
+
 // CreateSemaphore creates a semaphore
 func CreateSemaphore(device Device, createInfo *SemaphoreCreateInfo) (Semaphore, error) {
 	var cCreateInfo C.VkSemaphoreCreateInfo
@@ -261,8 +596,32 @@ func CreateSemaphore(device Device, createInfo *SemaphoreCreateInfo) (Semaphore,
 	cCreateInfo.pNext = nil
 	cCreateInfo.flags = 0
 
+	if createInfo != nil && createInfo.SemaphoreType == SemaphoreTypeTimeline {
+		cTypeInfo := (*C.VkSemaphoreTypeCreateInfo)(C.calloc(1, C.sizeof_VkSemaphoreTypeCreateInfo))
+		if cTypeInfo == nil {
+			return nil, NewVulkanError(ErrorOutOfHostMemory, "CreateSemaphore", "failed to allocate semaphore type info")
+		}
+		defer C.free(unsafe.Pointer(cTypeInfo))
+		cTypeInfo.sType = C.VK_STRUCTURE_TYPE_SEMAPHORE_TYPE_CREATE_INFO
+		cTypeInfo.semaphoreType = C.VK_SEMAPHORE_TYPE_TIMELINE
+		cTypeInfo.initialValue = C.uint64_t(createInfo.InitialValue)
+		cCreateInfo.pNext = unsafe.Pointer(cTypeInfo)
+	}
+	if createInfo != nil && createInfo.ExportHandleTypes != 0 {
+		cExportInfo := (*C.VkExportSemaphoreCreateInfo)(C.calloc(1, C.sizeof_VkExportSemaphoreCreateInfo))
+		if cExportInfo == nil {
+			return nil, NewVulkanError(ErrorOutOfHostMemory, "CreateSemaphore", "failed to allocate export semaphore info")
+		}
+		defer C.free(unsafe.Pointer(cExportInfo))
+		cExportInfo.sType = C.VK_STRUCTURE_TYPE_EXPORT_SEMAPHORE_CREATE_INFO
+		cExportInfo.pNext = cCreateInfo.pNext
+		cExportInfo.handleTypes = C.VkExternalSemaphoreHandleTypeFlags(createInfo.ExportHandleTypes)
+		cCreateInfo.pNext = unsafe.Pointer(cExportInfo)
+	}
+
 	var semaphore C.VkSemaphore
 	result := Result(C.vkCreateSemaphore(C.VkDevice(device), &cCreateInfo, nil, &semaphore))
+	traceResult("vkCreateSemaphore", result)
 	if result != Success {
 		return nil, result
 	}
@@ -281,9 +640,20 @@ func CreateFence(device Device, createInfo *FenceCreateInfo) (Fence, error) {
 	cCreateInfo.sType = C.VK_STRUCTURE_TYPE_FENCE_CREATE_INFO
 	cCreateInfo.pNext = nil
 	cCreateInfo.flags = C.VkFenceCreateFlags(createInfo.Flags)
+	if createInfo.ExportHandleTypes != 0 {
+		cExportInfo := (*C.VkExportFenceCreateInfo)(C.calloc(1, C.sizeof_VkExportFenceCreateInfo))
+		if cExportInfo == nil {
+			return nil, NewVulkanError(ErrorOutOfHostMemory, "CreateFence", "failed to allocate export fence info")
+		}
+		defer C.free(unsafe.Pointer(cExportInfo))
+		cExportInfo.sType = C.VK_STRUCTURE_TYPE_EXPORT_FENCE_CREATE_INFO
+		cExportInfo.handleTypes = C.VkExternalFenceHandleTypeFlags(createInfo.ExportHandleTypes)
+		cCreateInfo.pNext = unsafe.Pointer(cExportInfo)
+	}
 
 	var fence C.VkFence
 	result := Result(C.vkCreateFence(C.VkDevice(device), &cCreateInfo, nil, &fence))
+	traceResult("vkCreateFence", result)
 	if result != Success {
 		return nil, result
 	}
@@ -315,6 +685,7 @@ func WaitForFences(device Device, fences []Fence, waitAll bool, timeout uint64)
 	}
 
 	result := Result(C.vkWaitForFences(C.VkDevice(device), C.uint32_t(len(cFences)), &cFences[0], cWaitAll, C.uint64_t(timeout)))
+	traceResult("vkWaitForFences", result)
 	if result != Success {
 		return result
 	}
@@ -333,13 +704,151 @@ func ResetFences(device Device, fences []Fence) error {
 	}
 
 	result := Result(C.vkResetFences(C.VkDevice(device), C.uint32_t(len(cFences)), &cFences[0]))
+	traceResult("vkResetFences", result)
 	if result != Success {
 		return result
 	}
 	return nil
 }
 
-// GetFenceStatus gets fence status
-func GetFenceStatus(device Device, fence Fence) Result {
-	return Result(C.vkGetFenceStatus(C.VkDevice(device), C.VkFence(fence)))
+// WaitForFencesTimeout waits for fences to be signaled for at most timeout. It returns
+// ErrTimeout if the fences were not signaled in time. A zero timeout polls the fences.
+func WaitForFencesTimeout(device Device, fences []Fence, waitAll bool, timeout time.Duration) error {
+	if device == nil {
+		return NewValidationError("device", "cannot be nil")
+	}
+	if timeout < 0 {
+		return NewValidationError("timeout", "cannot be negative")
+	}
+	if len(fences) == 0 {
+		return nil
+	}
+
+	cFences := make([]C.VkFence, len(fences))
+	for i, fence := range fences {
+		cFences[i] = C.VkFence(fence)
+	}
+
+	result := Result(C.vkWaitForFences(C.VkDevice(device), C.uint32_t(len(cFences)), &cFences[0], boolToVkBool32(waitAll), C.uint64_t(timeout.Nanoseconds())))
+	traceResult("vkWaitForFences", result)
+	switch result {
+	case Success:
+		return nil
+	case Timeout:
+		return ErrTimeout
+	default:
+		return NewVulkanError(result, "WaitForFencesTimeout", "failed to wait for fences")
+	}
+}
+
+// GetFenceStatus reports whether a fence is signaled
+func GetFenceStatus(device Device, fence Fence) (signaled bool, err error) {
+	if device == nil {
+		return false, NewValidationError("device", "cannot be nil")
+	}
+	if fence == nil {
+		return false, NewValidationError("fence", "cannot be nil")
+	}
+
+	result := Result(C.vkGetFenceStatus(C.VkDevice(device), C.VkFence(fence)))
+	traceResult("vkGetFenceStatus", result)
+	switch result {
+	case Success:
+		return true, nil
+	case NotReady:
+		return false, nil
+	default:
+		return false, NewVulkanError(result, "GetFenceStatus", "failed to query fence status")
+	}
+}
+
+// WaitAndReset waits without a timeout for a fence to be signaled and then resets it so it
+// can be passed to the next submission
+func WaitAndReset(device Device, fence Fence) error {
+	if device == nil {
+		return NewValidationError("device", "cannot be nil")
+	}
+	if fence == nil {
+		return NewValidationError("fence", "cannot be nil")
+	}
+
+	fences := []Fence{fence}
+	if err := WaitForFences(device, fences, true, ^uint64(0)); err != nil {
+		return wrapError(err, ErrorUnknown, "WaitAndReset", "failed to wait for fence")
+	}
+	if err := ResetFences(device, fences); err != nil {
+		return wrapError(err, ErrorUnknown, "WaitAndReset", "failed to reset fence")
+	}
+	return nil
+}
+
+// RunOneTimeCommands allocates a primary command buffer from pool, records it with
+// record, submits it to queue and waits for completion. The command buffer and fence
+// are released before returning, including when record or submission fails. If waiting
+// for the fence fails, the queue is idled before they are released; if that fails too,
+// they are left allocated, since the submission may still be executing, and the returned
+// error says so.
+func RunOneTimeCommands(device Device, pool CommandPool, queue Queue, record func(cb CommandBuffer) error) error {
+	if device == nil {
+		return NewValidationError("device", "cannot be nil")
+	}
+	if pool == nil {
+		return NewValidationError("pool", "cannot be nil")
+	}
+	if queue == nil {
+		return NewValidationError("queue", "cannot be nil")
+	}
+	if record == nil {
+		return NewValidationError("record", "cannot be nil")
+	}
+
+	commandBuffers, err := AllocateCommandBuffers(device, &CommandBufferAllocateInfo{
+		CommandPool:        pool,
+		Level:              CommandBufferLevelPrimary,
+		CommandBufferCount: 1,
+	})
+	if err != nil {
+		return err
+	}
+	pending := false
+	defer func() {
+		if !pending {
+			FreeCommandBuffers(device, pool, commandBuffers)
+		}
+	}()
+	commandBuffer := commandBuffers[0]
+
+	if err := BeginCommandBuffer(commandBuffer, &CommandBufferBeginInfo{Flags: CommandBufferUsageOneTimeSubmitBit}); err != nil {
+		return err
+	}
+	if err := record(commandBuffer); err != nil {
+		return err
+	}
+	if err := EndCommandBuffer(commandBuffer); err != nil {
+		return err
+	}
+
+	fence, err := CreateFence(device, &FenceCreateInfo{})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if !pending {
+			DestroyFence(device, fence)
+		}
+	}()
+
+	if err := QueueSubmit(queue, []SubmitInfo{{CommandBuffers: commandBuffers}}, fence); err != nil {
+		return err
+	}
+	if err := WaitForFences(device, []Fence{fence}, true, ^uint64(0)); err != nil {
+		// The submission may still be executing, and freeing a pending command buffer or
+		// destroying its fence is undefined behavior
+		if QueueWaitIdle(queue) != nil {
+			pending = true
+			return wrapError(err, ErrorUnknown, "RunOneTimeCommands", "failed to wait for submission; the command buffer and fence were not released")
+		}
+		return err
+	}
+	return nil
 }
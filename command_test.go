@@ -0,0 +1,536 @@
+//go:build cgo
+
+package vulkan
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"testing"
+	"time"
+	"unsafe"
+)
+
+// TestBeginSecondaryCommandBufferValidation tests inheritance info validation for secondary command buffers
+func TestBeginSecondaryCommandBufferValidation(t *testing.T) {
+	secondary := CommandBuffer(uintptr(0x1234))
+	fakeDevice := Device(uintptr(0x4321))
+	allocatedCommandBuffers.add(fakeDevice, CommandPool(uintptr(0x8765)), []CommandBuffer{secondary}, true)
+	defer allocatedCommandBuffers.removeDevice(fakeDevice)
+
+	tests := []struct {
+		name       string
+		beginInfo  *CommandBufferBeginInfo
+		errorParam string
+	}{
+		{
+			name:       "missing inheritance info",
+			beginInfo:  &CommandBufferBeginInfo{},
+			errorParam: "InheritanceInfo",
+		},
+		{
+			name: "rendering inheritance with render pass",
+			beginInfo: &CommandBufferBeginInfo{
+				Flags: CommandBufferUsageRenderPassContinueBit,
+				InheritanceInfo: &CommandBufferInheritanceInfo{
+					RenderPass: RenderPass(uintptr(0x5678)),
+					Rendering:  &CommandBufferInheritanceRenderingInfo{},
+				},
+			},
+			errorParam: "InheritanceInfo.Rendering",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := BeginCommandBuffer(secondary, tt.beginInfo)
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+}
+
+// TestCommandBufferRegistryLifecycle tests that command buffer levels are forgotten when the
+// buffers are freed or their pool or device is destroyed, so recycled handles start clean
+func TestCommandBufferRegistryLifecycle(t *testing.T) {
+	deviceA := Device(uintptr(0x1000))
+	deviceB := Device(uintptr(0x2000))
+	pool1 := CommandPool(uintptr(0x3000))
+	pool2 := CommandPool(uintptr(0x4000))
+	freed := CommandBuffer(uintptr(0x1001))
+	kept := CommandBuffer(uintptr(0x1002))
+	inPool2 := CommandBuffer(uintptr(0x1003))
+	onDeviceB := CommandBuffer(uintptr(0x2001))
+	defer allocatedCommandBuffers.removeDevice(deviceA)
+	defer allocatedCommandBuffers.removeDevice(deviceB)
+
+	allocatedCommandBuffers.add(deviceA, pool1, []CommandBuffer{freed, kept}, true)
+	allocatedCommandBuffers.add(deviceA, pool2, []CommandBuffer{inPool2}, true)
+	// deviceB reuses pool1's handle value, as non-dispatchable handle values may repeat
+	// across devices
+	allocatedCommandBuffers.add(deviceB, pool1, []CommandBuffer{onDeviceB}, true)
+
+	allocatedCommandBuffers.remove([]CommandBuffer{freed})
+	if allocatedCommandBuffers.isSecondary(freed) {
+		t.Error("Expected freed command buffer to be forgotten")
+	}
+	if !allocatedCommandBuffers.isSecondary(kept) {
+		t.Error("Expected command buffer still allocated from pool1 to remain secondary")
+	}
+
+	allocatedCommandBuffers.removePool(deviceA, pool1)
+	if allocatedCommandBuffers.isSecondary(kept) {
+		t.Error("Expected command buffers of a destroyed pool to be forgotten")
+	}
+	if !allocatedCommandBuffers.isSecondary(inPool2) || !allocatedCommandBuffers.isSecondary(onDeviceB) {
+		t.Error("Expected destroying a pool to leave other pools untouched")
+	}
+
+	allocatedCommandBuffers.removeDevice(deviceA)
+	if allocatedCommandBuffers.isSecondary(inPool2) {
+		t.Error("Expected command buffers of a destroyed device to be forgotten")
+	}
+	if !allocatedCommandBuffers.isSecondary(onDeviceB) {
+		t.Error("Expected destroying a device to leave other devices untouched")
+	}
+
+	// A recycled handle allocated as a primary must not inherit the old level
+	allocatedCommandBuffers.add(deviceA, pool1, []CommandBuffer{kept}, false)
+	if allocatedCommandBuffers.isSecondary(kept) {
+		t.Error("Expected recycled handle allocated as primary not to be secondary")
+	}
+}
+
+// TestCmdExecuteCommandsValidation tests primary and secondary command buffer validation
+func TestCmdExecuteCommandsValidation(t *testing.T) {
+	primary := CommandBuffer(uintptr(0x1234))
+	secondary := CommandBuffer(uintptr(0x5678))
+	fakeDevice := Device(uintptr(0x4321))
+	allocatedCommandBuffers.add(fakeDevice, CommandPool(uintptr(0x8765)), []CommandBuffer{secondary}, true)
+	defer allocatedCommandBuffers.removeDevice(fakeDevice)
+
+	tests := []struct {
+		name        string
+		primary     CommandBuffer
+		secondaries []CommandBuffer
+		errorParam  string
+	}{
+		{
+			name:        "nil primary",
+			primary:     nil,
+			secondaries: []CommandBuffer{secondary},
+			errorParam:  "primary",
+		},
+		{
+			name:        "no secondaries",
+			primary:     primary,
+			secondaries: nil,
+			errorParam:  "secondaries",
+		},
+		{
+			name:        "secondary as primary",
+			primary:     secondary,
+			secondaries: []CommandBuffer{secondary},
+			errorParam:  "primary",
+		},
+		{
+			name:        "nil secondary",
+			primary:     primary,
+			secondaries: []CommandBuffer{secondary, nil},
+			errorParam:  "secondaries[1]",
+		},
+		{
+			name:        "primary level buffer as secondary",
+			primary:     primary,
+			secondaries: []CommandBuffer{CommandBuffer(uintptr(0x9abc))},
+			errorParam:  "secondaries[0]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CmdExecuteCommands(tt.primary, tt.secondaries)
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+}
+
+// TestQueueSubmitBatchValidation tests queue and command buffer validation for batched submits
+func TestQueueSubmitBatchValidation(t *testing.T) {
+	fakeQueue := Queue(uintptr(0x1234))
+	primary := CommandBuffer(uintptr(0x5678))
+	secondary := CommandBuffer(uintptr(0x9abc))
+	fakeDevice := Device(uintptr(0x4321))
+	allocatedCommandBuffers.add(fakeDevice, CommandPool(uintptr(0x8765)), []CommandBuffer{secondary}, true)
+	defer allocatedCommandBuffers.removeDevice(fakeDevice)
+
+	tests := []struct {
+		name           string
+		queue          Queue
+		commandBuffers []CommandBuffer
+		errorParam     string
+	}{
+		{
+			name:           "nil queue",
+			queue:          nil,
+			commandBuffers: []CommandBuffer{primary},
+			errorParam:     "queue",
+		},
+		{
+			name:           "nil command buffer",
+			queue:          fakeQueue,
+			commandBuffers: []CommandBuffer{primary, nil},
+			errorParam:     "commandBuffers[1]",
+		},
+		{
+			name:           "secondary command buffer",
+			queue:          fakeQueue,
+			commandBuffers: []CommandBuffer{secondary, primary},
+			errorParam:     "commandBuffers[0]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := QueueSubmitBatch(tt.queue, tt.commandBuffers, nil)
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+}
+
+// TestFenceHelpersValidation tests input validation for the fence wait and status helpers
+func TestFenceHelpersValidation(t *testing.T) {
+	fakeDevice := Device(uintptr(0x1234))
+	fakeFence := Fence(uintptr(0x5678))
+
+	var validationErr *ValidationError
+
+	err := WaitForFencesTimeout(fakeDevice, []Fence{fakeFence}, true, -time.Second)
+	if !errors.As(err, &validationErr) || validationErr.Parameter != "timeout" {
+		t.Errorf("Expected ValidationError for timeout, got %v", err)
+	}
+
+	if err := WaitForFencesTimeout(fakeDevice, nil, true, time.Second); err != nil {
+		t.Errorf("Expected no error for empty fence list, got %v", err)
+	}
+
+	_, err = GetFenceStatus(fakeDevice, nil)
+	if !errors.As(err, &validationErr) || validationErr.Parameter != "fence" {
+		t.Errorf("Expected ValidationError for fence, got %v", err)
+	}
+}
+
+// TestCmdDrawIndirectCountValidation tests buffer, offset and stride validation for indirect count draws
+func TestCmdDrawIndirectCountValidation(t *testing.T) {
+	fakeCommandBuffer := CommandBuffer(uintptr(0x1234))
+	fakeBuffer := Buffer(uintptr(0x5678))
+	fakeCountBuffer := Buffer(uintptr(0x9abc))
+
+	tests := []struct {
+		name       string
+		call       func() error
+		errorParam string
+	}{
+		{
+			name: "nil count buffer",
+			call: func() error {
+				return CmdDrawIndirectCount(fakeCommandBuffer, fakeBuffer, 0, nil, 0, 1, DrawIndirectCommandSize)
+			},
+			errorParam: "countBuffer",
+		},
+		{
+			name: "unaligned count buffer offset",
+			call: func() error {
+				return CmdDrawIndirectCount(fakeCommandBuffer, fakeBuffer, 0, fakeCountBuffer, 2, 1, DrawIndirectCommandSize)
+			},
+			errorParam: "countBufferOffset",
+		},
+		{
+			name: "stride smaller than draw command",
+			call: func() error {
+				return CmdDrawIndirectCount(fakeCommandBuffer, fakeBuffer, 0, fakeCountBuffer, 0, 1, 12)
+			},
+			errorParam: "stride",
+		},
+		{
+			name: "stride smaller than indexed draw command",
+			call: func() error {
+				return CmdDrawIndexedIndirectCount(fakeCommandBuffer, fakeBuffer, 0, fakeCountBuffer, 0, 1, DrawIndirectCommandSize)
+			},
+			errorParam: "stride",
+		},
+		{
+			name: "unaligned indexed offset",
+			call: func() error {
+				return CmdDrawIndexedIndirectCount(fakeCommandBuffer, fakeBuffer, 6, fakeCountBuffer, 0, 1, DrawIndexedIndirectCommandSize)
+			},
+			errorParam: "offset",
+		},
+		{
+			name: "transition to undefined layout",
+			call: func() error {
+				return CmdTransitionImageLayout(fakeCommandBuffer, Image(uintptr(0xdef0)), FormatD32Sfloat, ImageLayoutGeneral, ImageLayoutUndefined)
+			},
+			errorParam: "newLayout",
+		},
+		{
+			name: "transition without image",
+			call: func() error {
+				return CmdTransitionImageLayout(fakeCommandBuffer, nil, FormatD32Sfloat, ImageLayoutUndefined, ImageLayoutDepthStencilAttachmentOptimal)
+			},
+			errorParam: "image",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+}
+
+// TestImageLayoutBarrierScope tests the stages and accesses inferred for image layouts
+func TestImageLayoutBarrierScope(t *testing.T) {
+	stage, access := imageLayoutBarrierScope(ImageLayoutUndefined)
+	if stage != PipelineStageTopOfPipeBit || access != 0 {
+		t.Errorf("Undefined: expected top of pipe without access, got stage %d access %d", stage, access)
+	}
+
+	stage, access = imageLayoutBarrierScope(ImageLayoutTransferDstOptimal)
+	if stage != PipelineStageTransferBit || access != AccessTransferWriteBit {
+		t.Errorf("TransferDst: expected transfer write, got stage %d access %d", stage, access)
+	}
+
+	stage, access = imageLayoutBarrierScope(ImageLayoutDepthStencilAttachmentOptimal)
+	if stage&PipelineStageEarlyFragmentTestsBit == 0 || access&AccessDepthStencilAttachmentWriteBit == 0 {
+		t.Errorf("DepthStencilAttachment: expected fragment tests with depth writes, got stage %d access %d", stage, access)
+	}
+
+	stage, access = imageLayoutBarrierScope(ImageLayoutGeneral)
+	if stage != PipelineStageAllCommandsBit || access != AccessMemoryReadBit|AccessMemoryWriteBit {
+		t.Errorf("General: expected all commands with memory access, got stage %d access %d", stage, access)
+	}
+}
+
+// TestMakeFlippedViewport tests that the flipped viewport maps GL's bottom-left origin
+func TestMakeFlippedViewport(t *testing.T) {
+	vp := MakeFlippedViewport(800, 600)
+	expected := Viewport{X: 0, Y: 600, Width: 800, Height: -600, MinDepth: 0, MaxDepth: 1}
+	if vp != expected {
+		t.Errorf("Expected %+v, got %+v", expected, vp)
+	}
+}
+
+// TestWriteDispatchIndirectCommand tests the indirect dispatch layout and write helper
+func TestWriteDispatchIndirectCommand(t *testing.T) {
+	if size := unsafe.Sizeof(DispatchIndirectCommand{}); size != DispatchIndirectCommandSize {
+		t.Fatalf("Expected DispatchIndirectCommand to be %d bytes, got %d", DispatchIndirectCommandSize, size)
+	}
+
+	mapped := make([]uint32, 6)
+	command := DispatchIndirectCommand{X: 64, Y: 2, Z: 1}
+	if err := WriteDispatchIndirectCommand(unsafe.Pointer(&mapped[0]), 8, command); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if mapped[2] != 64 || mapped[3] != 2 || mapped[4] != 1 || mapped[0] != 0 || mapped[5] != 0 {
+		t.Errorf("Unexpected buffer contents after write: %v", mapped)
+	}
+
+	var validationErr *ValidationError
+	err := WriteDispatchIndirectCommand(nil, 0, command)
+	if !errors.As(err, &validationErr) || validationErr.Parameter != "mapped" {
+		t.Errorf("Expected ValidationError for mapped, got %v", err)
+	}
+	err = WriteDispatchIndirectCommand(unsafe.Pointer(&mapped[0]), 2, command)
+	if !errors.As(err, &validationErr) || validationErr.Parameter != "offset" {
+		t.Errorf("Expected ValidationError for offset, got %v", err)
+	}
+}
+
+// TestClearValueEncoding tests that the set union member, whether built with a constructor or
+// as a literal, is encoded into the bytes Vulkan reads
+func TestClearValueEncoding(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    ClearValue
+		expected [4]uint32
+	}{
+		{
+			name:     "float color",
+			value:    ClearColorValueFloat(1, 0.5, 0, 1),
+			expected: [4]uint32{0x3f800000, 0x3f000000, 0, 0x3f800000},
+		},
+		{
+			name:     "int color",
+			value:    ClearColorValueInt(-1, 2, 0, 7),
+			expected: [4]uint32{0xffffffff, 2, 0, 7},
+		},
+		{
+			name:     "uint color",
+			value:    ClearColorValueUint(1, 2, 3, 0xffffffff),
+			expected: [4]uint32{1, 2, 3, 0xffffffff},
+		},
+		{
+			name:     "depth stencil",
+			value:    ClearDepthStencil(1, 0x80),
+			expected: [4]uint32{0x3f800000, 0x80, 0, 0},
+		},
+		{
+			name:     "int color literal",
+			value:    ClearValue{Color: ClearColorValue{Int32: [4]int32{-1, 2, 0, 7}}},
+			expected: [4]uint32{0xffffffff, 2, 0, 7},
+		},
+		{
+			name:     "uint color literal",
+			value:    ClearValue{Color: ClearColorValue{Uint32: [4]uint32{1, 2, 3, 4}}},
+			expected: [4]uint32{1, 2, 3, 4},
+		},
+		{
+			name:     "depth stencil literal",
+			value:    ClearValue{DepthStencil: ClearDepthStencilValue{Depth: 0.5, Stencil: 3}},
+			expected: [4]uint32{0x3f000000, 3, 0, 0},
+		},
+		{
+			name:     "stencil only literal",
+			value:    ClearValue{DepthStencil: ClearDepthStencilValue{Stencil: 1}},
+			expected: [4]uint32{0, 1, 0, 0},
+		},
+		{
+			name:     "zero value",
+			value:    ClearValue{},
+			expected: [4]uint32{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateClearValue(tt.value, "value"); err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+
+			cValue := clearValueToC(tt.value)
+			var converted [4]uint32
+			for i := range converted {
+				converted[i] = binary.NativeEndian.Uint32(cValue[i*4:])
+			}
+			if converted != tt.expected {
+				t.Errorf("Expected converted VkClearValue %#x, got %#x", tt.expected, converted)
+			}
+		})
+	}
+
+	depthStencil := ClearDepthStencil(0.25, 3)
+	if depthStencil.DepthStencil.Depth != 0.25 || depthStencil.DepthStencil.Stencil != 3 {
+		t.Errorf("Expected typed depth/stencil fields to be set, got %+v", depthStencil.DepthStencil)
+	}
+
+	// Only one member can reach the driver, so setting two is an error
+	ambiguous := ClearColorValueFloat(1, 1, 1, 1)
+	ambiguous.DepthStencil.Depth = 1
+	var validationErr *ValidationError
+	if err := validateClearValue(ambiguous, "value"); !errors.As(err, &validationErr) || validationErr.Parameter != "value" {
+		t.Errorf("Expected ValidationError for parameter 'value', got %v", err)
+	}
+}
+
+// TestCmdSetDepthBoundsValidation tests the [0, 1] range check and its relaxation for
+// command buffers of devices with an unrestricted depth range
+func TestCmdSetDepthBoundsValidation(t *testing.T) {
+	unrestrictedDevice := Device(uintptr(0x4321))
+	unrestricted := CommandBuffer(uintptr(0x5678))
+	depthRangeUnrestrictedDevices.Store(unrestrictedDevice, struct{}{})
+	defer depthRangeUnrestrictedDevices.Delete(unrestrictedDevice)
+	allocatedCommandBuffers.add(unrestrictedDevice, CommandPool(uintptr(0x8765)), []CommandBuffer{unrestricted}, false)
+	defer allocatedCommandBuffers.removeDevice(unrestrictedDevice)
+
+	nan := float32(math.NaN())
+
+	tests := []struct {
+		name          string
+		commandBuffer CommandBuffer
+		min, max      float32
+		errorParam    string
+	}{
+		{name: "nil command buffer", commandBuffer: nil, min: 0, max: 1, errorParam: "commandBuffer"},
+		{name: "negative min", commandBuffer: CommandBuffer(uintptr(0x1234)), min: -0.5, max: 1, errorParam: "minDepthBounds"},
+		{name: "max above one", commandBuffer: CommandBuffer(uintptr(0x1234)), min: 0, max: 1.5, errorParam: "maxDepthBounds"},
+		{name: "NaN min", commandBuffer: CommandBuffer(uintptr(0x1234)), min: nan, max: 1, errorParam: "minDepthBounds"},
+		{name: "NaN max unrestricted", commandBuffer: unrestricted, min: -10, max: nan, errorParam: "maxDepthBounds"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CmdSetDepthBounds(tt.commandBuffer, tt.min, tt.max)
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+
+	for _, bound := range []float32{-10, 10} {
+		if err := validateDepthBound("minDepthBounds", bound, true); err != nil {
+			t.Errorf("Expected %v to be accepted with an unrestricted depth range, got %v", bound, err)
+		}
+	}
+}
+
+// TestDepthRangeUnrestrictedLifetime tests that the unrestricted depth range follows the
+// owning device, so a recycled command buffer handle does not keep it
+func TestDepthRangeUnrestrictedLifetime(t *testing.T) {
+	unrestrictedDevice := Device(uintptr(0x4321))
+	restrictedDevice := Device(uintptr(0x9876))
+	commandBuffer := CommandBuffer(uintptr(0x5678))
+	defer allocatedCommandBuffers.removeDevice(restrictedDevice)
+
+	depthRangeUnrestrictedDevices.Store(unrestrictedDevice, struct{}{})
+	allocatedCommandBuffers.add(unrestrictedDevice, CommandPool(uintptr(0x8765)), []CommandBuffer{commandBuffer}, false)
+	if !depthRangeUnrestricted(commandBuffer) {
+		t.Fatal("Expected command buffer of an unrestricted device to allow any depth range")
+	}
+
+	// DestroyDevice drops both entries; the driver may then hand the same command buffer
+	// handle to another device
+	depthRangeUnrestrictedDevices.Delete(unrestrictedDevice)
+	allocatedCommandBuffers.removeDevice(unrestrictedDevice)
+	if depthRangeUnrestricted(commandBuffer) {
+		t.Error("Expected command buffer of a destroyed device to be restricted")
+	}
+
+	allocatedCommandBuffers.add(restrictedDevice, CommandPool(uintptr(0x8765)), []CommandBuffer{commandBuffer}, false)
+	if depthRangeUnrestricted(commandBuffer) {
+		t.Error("Expected recycled command buffer handle to follow its new device")
+	}
+}
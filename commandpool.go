@@ -0,0 +1,85 @@
+package vulkan
+
+import "sync"
+
+// SyncCommandPool is a command pool that can be shared between goroutines.
+//
+// Vulkan command pools are externally synchronized: allocating, freeing or resetting
+// command buffers, and recording into any command buffer allocated from the pool, must never
+// happen on two threads at once. The driver does not check this, so unsynchronized use
+// silently corrupts the pool instead of returning an error. SyncCommandPool serializes the
+// pool operations with a mutex.
+//
+// Recording is NOT covered by the mutex. Command buffers from one pool must still not be
+// recorded concurrently; for parallel recording, give each goroutine its own pool.
+type SyncCommandPool struct {
+	mu     sync.Mutex
+	device Device
+	pool   CommandPool
+}
+
+// NewSyncCommandPool creates a command pool guarded by a mutex
+func NewSyncCommandPool(device Device, createInfo *CommandPoolCreateInfo) (*SyncCommandPool, error) {
+	if device == nil {
+		return nil, NewValidationError("device", "cannot be nil")
+	}
+	if createInfo == nil {
+		return nil, NewValidationError("createInfo", "cannot be nil")
+	}
+
+	pool, err := CreateCommandPool(device, createInfo)
+	if err != nil {
+		return nil, err
+	}
+	return &SyncCommandPool{device: device, pool: pool}, nil
+}
+
+// Handle returns the underlying command pool. Using it directly bypasses the mutex.
+func (p *SyncCommandPool) Handle() CommandPool {
+	return p.pool
+}
+
+// Allocate allocates count command buffers of the given level from the pool
+func (p *SyncCommandPool) Allocate(level CommandBufferLevel, count uint32) ([]CommandBuffer, error) {
+	if count == 0 {
+		return nil, NewValidationError("count", "must be greater than 0")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return AllocateCommandBuffers(p.device, &CommandBufferAllocateInfo{
+		CommandPool:        p.pool,
+		Level:              level,
+		CommandBufferCount: count,
+	})
+}
+
+// Free frees command buffers allocated from the pool. They must not be pending execution.
+func (p *SyncCommandPool) Free(commandBuffers []CommandBuffer) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	FreeCommandBuffers(p.device, p.pool, commandBuffers)
+}
+
+// Reset resets every command buffer allocated from the pool. None of them may be pending
+// execution.
+func (p *SyncCommandPool) Reset(flags CommandPoolResetFlags) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return ResetCommandPool(p.device, p.pool, flags)
+}
+
+// Destroy destroys the pool, freeing every command buffer allocated from it. All submissions
+// using them must have completed.
+func (p *SyncCommandPool) Destroy() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.pool != nil {
+		DestroyCommandPool(p.device, p.pool)
+		p.pool = nil
+	}
+}
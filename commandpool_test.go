@@ -0,0 +1,69 @@
+//go:build cgo
+
+package vulkan
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestSyncCommandPoolValidation tests input validation of the synchronized command pool
+func TestSyncCommandPoolValidation(t *testing.T) {
+	fakeDevice := Device(uintptr(0x1234))
+	pool := &SyncCommandPool{device: fakeDevice, pool: CommandPool(uintptr(0x5678))}
+
+	tests := []struct {
+		name       string
+		call       func() error
+		errorParam string
+	}{
+		{
+			name: "nil device",
+			call: func() error {
+				_, err := NewSyncCommandPool(nil, &CommandPoolCreateInfo{})
+				return err
+			},
+			errorParam: "device",
+		},
+		{
+			name: "nil create info",
+			call: func() error {
+				_, err := NewSyncCommandPool(fakeDevice, nil)
+				return err
+			},
+			errorParam: "createInfo",
+		},
+		{
+			name: "zero command buffers",
+			call: func() error {
+				_, err := pool.Allocate(CommandBufferLevelPrimary, 0)
+				return err
+			},
+			errorParam: "count",
+		},
+		{
+			name:       "reset nil device",
+			call:       func() error { return ResetCommandPool(nil, pool.Handle(), 0) },
+			errorParam: "device",
+		},
+		{
+			name:       "reset nil pool",
+			call:       func() error { return ResetCommandPool(fakeDevice, nil, 0) },
+			errorParam: "commandPool",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+}
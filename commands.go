@@ -5,6 +5,13 @@ package vulkan
 */
 import "C"
 
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"unsafe"
+)
+
 // ClearColorValue represents a clear color value
 type ClearColorValue struct {
 	Float32 [4]float32
@@ -18,12 +25,91 @@ type ClearDepthStencilValue struct {
 	Stencil uint32
 }
 
-// ClearValue represents a clear value union
+// ClearValue represents a clear value union. Set exactly one of Color.Float32, Color.Int32,
+// Color.Uint32 and DepthStencil, directly or with the ClearColorValue* and ClearDepthStencil
+// constructors; the set member is encoded into the VkClearValue union. Setting more than one
+// is rejected by the commands that validate clear values.
 type ClearValue struct {
 	Color        ClearColorValue
 	DepthStencil ClearDepthStencilValue
 }
 
+// ClearColorValueFloat returns a clear value for float, UNORM, SNORM and SRGB color attachments
+func ClearColorValueFloat(r, g, b, a float32) ClearValue {
+	var value ClearValue
+	value.Color.Float32 = [4]float32{r, g, b, a}
+	return value
+}
+
+// ClearColorValueInt returns a clear value for SINT color attachments
+func ClearColorValueInt(r, g, b, a int32) ClearValue {
+	var value ClearValue
+	value.Color.Int32 = [4]int32{r, g, b, a}
+	return value
+}
+
+// ClearColorValueUint returns a clear value for UINT color attachments
+func ClearColorValueUint(r, g, b, a uint32) ClearValue {
+	var value ClearValue
+	value.Color.Uint32 = [4]uint32{r, g, b, a}
+	return value
+}
+
+// ClearDepthStencil returns a clear value for depth/stencil attachments
+func ClearDepthStencil(depth float32, stencil uint32) ClearValue {
+	var value ClearValue
+	value.DepthStencil = ClearDepthStencilValue{Depth: depth, Stencil: stencil}
+	return value
+}
+
+// clearValueWords returns the four 32-bit words of the VkClearValue union for value and how
+// many of its members are set. The words come from the first set member in the order
+// Color.Float32, Color.Int32, Color.Uint32, DepthStencil; a value with no member set encodes
+// as zeros.
+func clearValueWords(value ClearValue) ([4]uint32, int) {
+	var float32Words, int32Words, depthStencilWords [4]uint32
+	for i := range value.Color.Float32 {
+		float32Words[i] = math.Float32bits(value.Color.Float32[i])
+		int32Words[i] = uint32(value.Color.Int32[i])
+	}
+	depthStencilWords[0] = math.Float32bits(value.DepthStencil.Depth)
+	depthStencilWords[1] = value.DepthStencil.Stencil
+
+	var words [4]uint32
+	set := 0
+	for _, member := range [][4]uint32{float32Words, int32Words, value.Color.Uint32, depthStencilWords} {
+		if member == ([4]uint32{}) {
+			continue
+		}
+		if set == 0 {
+			words = member
+		}
+		set++
+	}
+	return words, set
+}
+
+// validateClearValue rejects a ClearValue that sets more than one union member, since only
+// one of them can reach the driver
+func validateClearValue(value ClearValue, parameter string) error {
+	if _, set := clearValueWords(value); set > 1 {
+		return NewValidationError(parameter, "sets more than one of Color.Float32, Color.Int32, Color.Uint32 and DepthStencil")
+	}
+	return nil
+}
+
+// clearValueToC writes the union bytes of value into a VkClearValue. cgo exposes the union as
+// a byte array, so each 32-bit word is stored in host byte order instead of copying the Go
+// struct, whose layout does not match the C union.
+func clearValueToC(value ClearValue) C.VkClearValue {
+	var cValue C.VkClearValue
+	words, _ := clearValueWords(value)
+	for i, word := range words {
+		binary.NativeEndian.PutUint32(cValue[i*4:], word)
+	}
+	return cValue
+}
+
 // RenderPassBeginInfo contains render pass begin information
 type RenderPassBeginInfo struct {
 	RenderPass  RenderPass
@@ -60,6 +146,24 @@ type Viewport struct {
 	MaxDepth float32
 }
 
+// MakeFlippedViewport returns a full-target viewport with a negative height, which flips
+// the Y axis so that +Y points up and the origin is at the bottom-left as in OpenGL. The
+// viewport starts at Y = height and has depth range [0, 1].
+//
+// Negative viewport heights require Vulkan 1.1 or VK_KHR_maintenance1; on a 1.0 device
+// without the extension the viewport is invalid. The flip also preserves GL's winding
+// convention, so counter-clockwise front faces cull the same way they do in OpenGL.
+func MakeFlippedViewport(width, height float32) Viewport {
+	return Viewport{
+		X:        0,
+		Y:        height,
+		Width:    width,
+		Height:   -height,
+		MinDepth: 0,
+		MaxDepth: 1,
+	}
+}
+
 // SubpassContents represents subpass contents
 type SubpassContents int32
 
@@ -92,6 +196,37 @@ func CmdEndRenderPass(commandBuffer CommandBuffer) {
 	C.vkCmdEndRenderPass(C.VkCommandBuffer(commandBuffer))
 }
 
+// CmdExecuteCommands records the execution of secondary command buffers in a primary
+// command buffer. The secondaries must have been allocated with CommandBufferLevelSecondary
+// and ended; inside a render pass, the primary's subpass must have been begun with
+// SubpassContentsSecondaryCommandBuffers. Secondaries may be recorded concurrently on
+// different goroutines as long as each uses its own command pool.
+func CmdExecuteCommands(primary CommandBuffer, secondaries []CommandBuffer) error {
+	if primary == nil {
+		return NewValidationError("primary", "cannot be nil")
+	}
+	if len(secondaries) == 0 {
+		return NewValidationError("secondaries", "cannot be empty")
+	}
+	if allocatedCommandBuffers.isSecondary(primary) {
+		return NewValidationError("primary", "must be a primary command buffer")
+	}
+
+	cSecondaries := make([]C.VkCommandBuffer, len(secondaries))
+	for i, cb := range secondaries {
+		if cb == nil {
+			return NewValidationError(fmt.Sprintf("secondaries[%d]", i), "cannot be nil")
+		}
+		if !allocatedCommandBuffers.isSecondary(cb) {
+			return NewValidationError(fmt.Sprintf("secondaries[%d]", i), "must be allocated with CommandBufferLevelSecondary")
+		}
+		cSecondaries[i] = C.VkCommandBuffer(cb)
+	}
+
+	C.vkCmdExecuteCommands(C.VkCommandBuffer(primary), C.uint32_t(len(cSecondaries)), &cSecondaries[0])
+	return nil
+}
+
 // CmdBindPipeline binds a pipeline
 func CmdBindPipeline(commandBuffer CommandBuffer, pipelineBindPoint PipelineBindPoint, pipeline Pipeline) {
 	C.vkCmdBindPipeline(C.VkCommandBuffer(commandBuffer), C.VkPipelineBindPoint(pipelineBindPoint), C.VkPipeline(pipeline))
@@ -133,6 +268,55 @@ func CmdSetScissor(commandBuffer CommandBuffer, firstScissor uint32, scissors []
 	C.vkCmdSetScissor(C.VkCommandBuffer(commandBuffer), C.uint32_t(firstScissor), C.uint32_t(len(cScissors)), &cScissors[0])
 }
 
+// ExtensionNameDepthRangeUnrestricted is the unrestricted depth range device extension name.
+// Command buffers allocated from a device created with it enabled accept depth bounds outside
+// [0, 1].
+const ExtensionNameDepthRangeUnrestricted = "VK_EXT_depth_range_unrestricted"
+
+// CmdSetDepthBounds sets the depth range the depth bounds test passes for. The test itself is
+// enabled in the pipeline or with CmdSetDepthBoundsTestEnable, and needs the DepthBounds
+// feature. Both bounds must lie in [0, 1] unless the command buffer's device was created with
+// ExtensionNameDepthRangeUnrestricted enabled.
+func CmdSetDepthBounds(commandBuffer CommandBuffer, minDepthBounds, maxDepthBounds float32) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+	unrestricted := depthRangeUnrestricted(commandBuffer)
+	if err := validateDepthBound("minDepthBounds", minDepthBounds, unrestricted); err != nil {
+		return err
+	}
+	if err := validateDepthBound("maxDepthBounds", maxDepthBounds, unrestricted); err != nil {
+		return err
+	}
+
+	C.vkCmdSetDepthBounds(C.VkCommandBuffer(commandBuffer), C.float(minDepthBounds), C.float(maxDepthBounds))
+	return nil
+}
+
+// depthRangeUnrestricted reports whether commandBuffer was allocated on a device created with
+// ExtensionNameDepthRangeUnrestricted enabled. It is looked up through the owning device at
+// check time, so it follows the device's lifetime rather than the recyclable handle's.
+func depthRangeUnrestricted(commandBuffer CommandBuffer) bool {
+	owner, ok := allocatedCommandBuffers.owner(commandBuffer)
+	if !ok {
+		return false
+	}
+	_, unrestricted := depthRangeUnrestrictedDevices.Load(owner.device)
+	return unrestricted
+}
+
+// validateDepthBound checks one depth bound. NaN is never valid; values outside [0, 1] are
+// only valid with an unrestricted depth range.
+func validateDepthBound(parameter string, bound float32, unrestricted bool) error {
+	if math.IsNaN(float64(bound)) {
+		return NewValidationError(parameter, "cannot be NaN")
+	}
+	if !unrestricted && (bound < 0 || bound > 1) {
+		return NewValidationError(parameter, fmt.Sprintf("must be between 0 and 1 without %s, got %v", ExtensionNameDepthRangeUnrestricted, bound))
+	}
+	return nil
+}
+
 // CmdBindVertexBuffers binds vertex buffers
 func CmdBindVertexBuffers(commandBuffer CommandBuffer, firstBinding uint32, buffers []Buffer, offsets []DeviceSize) {
 	// Input validation
@@ -206,6 +390,79 @@ func CmdDrawIndexed(commandBuffer CommandBuffer, indexCount, instanceCount, firs
 	C.vkCmdDrawIndexed(C.VkCommandBuffer(commandBuffer), C.uint32_t(indexCount), C.uint32_t(instanceCount), C.uint32_t(firstIndex), C.int32_t(vertexOffset), C.uint32_t(firstInstance))
 }
 
+// DrawIndirectCommandSize is the size in bytes of one indirect draw command
+const DrawIndirectCommandSize = 16
+
+// DrawIndexedIndirectCommandSize is the size in bytes of one indexed indirect draw command
+const DrawIndexedIndirectCommandSize = 20
+
+// DrawIndirectCommand is the layout of one indirect draw command
+type DrawIndirectCommand struct {
+	VertexCount   uint32
+	InstanceCount uint32
+	FirstVertex   uint32
+	FirstInstance uint32
+}
+
+// DrawIndexedIndirectCommand is the layout of one indexed indirect draw command
+type DrawIndexedIndirectCommand struct {
+	IndexCount    uint32
+	InstanceCount uint32
+	FirstIndex    uint32
+	VertexOffset  int32
+	FirstInstance uint32
+}
+
+// validateIndirectCountDraw checks the buffer arguments shared by the indirect count draws
+func validateIndirectCountDraw(commandBuffer CommandBuffer, buffer Buffer, offset DeviceSize, countBuffer Buffer, countBufferOffset DeviceSize, stride, commandSize uint32) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+	if buffer == nil {
+		return NewValidationError("buffer", "cannot be nil")
+	}
+	if countBuffer == nil {
+		return NewValidationError("countBuffer", "cannot be nil")
+	}
+	if offset%4 != 0 {
+		return NewValidationError("offset", "must be a multiple of 4")
+	}
+	if countBufferOffset%4 != 0 {
+		return NewValidationError("countBufferOffset", "must be a multiple of 4")
+	}
+	if stride%4 != 0 || stride < commandSize {
+		return NewValidationError("stride", fmt.Sprintf("must be a multiple of 4 and at least %d bytes", commandSize))
+	}
+	return nil
+}
+
+// CmdDrawIndirectCount records draws whose parameters are read from buffer as DrawIndirectCommand
+// entries and whose count is read as a uint32 from countBuffer, clamped to maxDrawCount.
+// Requires Vulkan 1.2 with the DrawIndirectCount feature enabled. maxDrawCount should not exceed
+// PhysicalDeviceLimits.MaxDrawIndirectCount.
+func CmdDrawIndirectCount(commandBuffer CommandBuffer, buffer Buffer, offset DeviceSize, countBuffer Buffer, countBufferOffset DeviceSize, maxDrawCount, stride uint32) error {
+	if err := validateIndirectCountDraw(commandBuffer, buffer, offset, countBuffer, countBufferOffset, stride, DrawIndirectCommandSize); err != nil {
+		return err
+	}
+
+	C.vkCmdDrawIndirectCount(C.VkCommandBuffer(commandBuffer), C.VkBuffer(buffer), C.VkDeviceSize(offset),
+		C.VkBuffer(countBuffer), C.VkDeviceSize(countBufferOffset), C.uint32_t(maxDrawCount), C.uint32_t(stride))
+	return nil
+}
+
+// CmdDrawIndexedIndirectCount records indexed draws whose parameters are read from buffer as
+// DrawIndexedIndirectCommand entries and whose count is read as a uint32 from countBuffer,
+// clamped to maxDrawCount. Requires Vulkan 1.2 with the DrawIndirectCount feature enabled.
+func CmdDrawIndexedIndirectCount(commandBuffer CommandBuffer, buffer Buffer, offset DeviceSize, countBuffer Buffer, countBufferOffset DeviceSize, maxDrawCount, stride uint32) error {
+	if err := validateIndirectCountDraw(commandBuffer, buffer, offset, countBuffer, countBufferOffset, stride, DrawIndexedIndirectCommandSize); err != nil {
+		return err
+	}
+
+	C.vkCmdDrawIndexedIndirectCount(C.VkCommandBuffer(commandBuffer), C.VkBuffer(buffer), C.VkDeviceSize(offset),
+		C.VkBuffer(countBuffer), C.VkDeviceSize(countBufferOffset), C.uint32_t(maxDrawCount), C.uint32_t(stride))
+	return nil
+}
+
 // CmdCopyBuffer copies data between buffers
 func CmdCopyBuffer(commandBuffer CommandBuffer, srcBuffer, dstBuffer Buffer, regions []BufferCopy) {
 	// Input validation
@@ -259,6 +516,76 @@ func CmdPipelineBarrier(commandBuffer CommandBuffer, srcStageMask, dstStageMask
 	C.vkCmdPipelineBarrier(C.VkCommandBuffer(commandBuffer), C.VkPipelineStageFlags(srcStageMask), C.VkPipelineStageFlags(dstStageMask), C.VkDependencyFlags(dependencyFlags), 0, nil, 0, nil, 0, nil)
 }
 
+// CmdTransitionImageLayout records a barrier moving every mip level and array layer of image
+// from oldLayout to newLayout. The aspect mask is derived from format with AspectMaskForFormat,
+// and the stages and accesses on each side are inferred from the layouts. Use
+// ImageLayoutUndefined as oldLayout when the previous contents can be discarded.
+func CmdTransitionImageLayout(commandBuffer CommandBuffer, image Image, format Format, oldLayout, newLayout ImageLayout) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+	if image == nil {
+		return NewValidationError("image", "cannot be nil")
+	}
+	if newLayout == ImageLayoutUndefined || newLayout == ImageLayoutPreinitialized {
+		return NewValidationError("newLayout", "cannot be ImageLayoutUndefined or ImageLayoutPreinitialized")
+	}
+
+	srcStage, srcAccess := imageLayoutBarrierScope(oldLayout)
+	dstStage, dstAccess := imageLayoutBarrierScope(newLayout)
+
+	barrier := C.VkImageMemoryBarrier{
+		sType:               C.VK_STRUCTURE_TYPE_IMAGE_MEMORY_BARRIER,
+		srcAccessMask:       C.VkAccessFlags(srcAccess),
+		dstAccessMask:       C.VkAccessFlags(dstAccess),
+		oldLayout:           C.VkImageLayout(oldLayout),
+		newLayout:           C.VkImageLayout(newLayout),
+		srcQueueFamilyIndex: C.VK_QUEUE_FAMILY_IGNORED,
+		dstQueueFamilyIndex: C.VK_QUEUE_FAMILY_IGNORED,
+		image:               C.VkImage(image),
+		subresourceRange: C.VkImageSubresourceRange{
+			aspectMask:     C.VkImageAspectFlags(AspectMaskForFormat(format)),
+			baseMipLevel:   0,
+			levelCount:     C.VK_REMAINING_MIP_LEVELS,
+			baseArrayLayer: 0,
+			layerCount:     C.VK_REMAINING_ARRAY_LAYERS,
+		},
+	}
+	C.vkCmdPipelineBarrier(C.VkCommandBuffer(commandBuffer), C.VkPipelineStageFlags(srcStage), C.VkPipelineStageFlags(dstStage), 0,
+		0, nil, 0, nil, 1, &barrier)
+	return nil
+}
+
+// imageLayoutBarrierScope returns the stages and accesses that use an image in layout, which
+// a barrier waits on when leaving the layout and makes the image visible to when entering it
+func imageLayoutBarrierScope(layout ImageLayout) (PipelineStageFlags, AccessFlags) {
+	switch layout {
+	case ImageLayoutUndefined:
+		return PipelineStageTopOfPipeBit, 0
+	case ImageLayoutPreinitialized:
+		return PipelineStageHostBit, AccessHostWriteBit
+	case ImageLayoutTransferSrcOptimal:
+		return PipelineStageTransferBit, AccessTransferReadBit
+	case ImageLayoutTransferDstOptimal:
+		return PipelineStageTransferBit, AccessTransferWriteBit
+	case ImageLayoutColorAttachmentOptimal:
+		return PipelineStageColorAttachmentOutputBit, AccessColorAttachmentReadBit | AccessColorAttachmentWriteBit
+	case ImageLayoutDepthStencilAttachmentOptimal:
+		return PipelineStageEarlyFragmentTestsBit | PipelineStageLateFragmentTestsBit,
+			AccessDepthStencilAttachmentReadBit | AccessDepthStencilAttachmentWriteBit
+	case ImageLayoutDepthStencilReadOnlyOptimal:
+		return PipelineStageEarlyFragmentTestsBit | PipelineStageLateFragmentTestsBit | PipelineStageFragmentShaderBit,
+			AccessDepthStencilAttachmentReadBit | AccessShaderReadBit
+	case ImageLayoutShaderReadOnlyOptimal:
+		return PipelineStageVertexShaderBit | PipelineStageFragmentShaderBit | PipelineStageComputeShaderBit, AccessShaderReadBit
+	case ImageLayoutPresentSrcKHR:
+		// Presentation is ordered by semaphores, so there is nothing to wait on or make visible
+		return PipelineStageBottomOfPipeBit, 0
+	default:
+		return PipelineStageAllCommandsBit, AccessMemoryReadBit | AccessMemoryWriteBit
+	}
+}
+
 // Compute dispatch commands
 
 // CmdDispatch dispatches compute work
@@ -266,15 +593,55 @@ func CmdDispatch(commandBuffer CommandBuffer, groupCountX, groupCountY, groupCou
 	C.vkCmdDispatch(C.VkCommandBuffer(commandBuffer), C.uint32_t(groupCountX), C.uint32_t(groupCountY), C.uint32_t(groupCountZ))
 }
 
-// CmdDispatchIndirect dispatches compute work with parameters from a buffer
+// CmdDispatchIndirect dispatches compute work with parameters read from buffer as a
+// DispatchIndirectCommand. The buffer needs BufferUsageIndirectBufferBit and offset must
+// be a multiple of 4.
 func CmdDispatchIndirect(commandBuffer CommandBuffer, buffer Buffer, offset DeviceSize) {
 	C.vkCmdDispatchIndirect(C.VkCommandBuffer(commandBuffer), C.VkBuffer(buffer), C.VkDeviceSize(offset))
 }
 
-// CmdBindDescriptorSets binds descriptor sets to a command buffer
-func CmdBindDescriptorSets(commandBuffer CommandBuffer, pipelineBindPoint PipelineBindPoint, layout PipelineLayout, firstSet uint32, descriptorSets []DescriptorSet, dynamicOffsets []uint32) {
+// DispatchIndirectCommandSize is the size in bytes of one indirect dispatch command
+const DispatchIndirectCommandSize = 12
+
+// DispatchIndirectCommand is the layout of one indirect dispatch command: the number of
+// work groups in each dimension
+type DispatchIndirectCommand struct {
+	X uint32
+	Y uint32
+	Z uint32
+}
+
+// WriteDispatchIndirectCommand writes command at offset bytes into mapped buffer memory,
+// for example Allocation.Mapped. GPU-generated dispatches write the same layout from a shader.
+func WriteDispatchIndirectCommand(mapped unsafe.Pointer, offset DeviceSize, command DispatchIndirectCommand) error {
+	if mapped == nil {
+		return NewValidationError("mapped", "cannot be nil")
+	}
+	if offset%4 != 0 {
+		return NewValidationError("offset", "must be a multiple of 4")
+	}
+
+	*(*DispatchIndirectCommand)(unsafe.Add(mapped, uintptr(offset))) = command
+	return nil
+}
+
+// CmdBindDescriptorSets binds descriptor sets to a command buffer.
+//
+// dynamicOffsets holds one offset per DescriptorTypeUniformBufferDynamic or
+// DescriptorTypeStorageBufferDynamic descriptor in the bound sets, ordered by set, then by
+// binding number, then by array element. Each offset is added to the descriptor's
+// DescriptorBufferInfo.Offset and must be a multiple of MinUniformBufferOffsetAlignment or
+// MinStorageBufferOffsetAlignment. Pass nil when the sets have no dynamic descriptors.
+//
+// Returns a validation error if the number of offsets does not match the dynamic descriptors
+// of sets allocated through AllocateDescriptorSets, when commandBuffer was allocated through
+// AllocateCommandBuffers.
+func CmdBindDescriptorSets(commandBuffer CommandBuffer, pipelineBindPoint PipelineBindPoint, layout PipelineLayout, firstSet uint32, descriptorSets []DescriptorSet, dynamicOffsets []uint32) error {
 	if len(descriptorSets) == 0 {
-		return
+		return nil
+	}
+	if err := validateDynamicOffsets(commandBuffer, descriptorSets, dynamicOffsets); err != nil {
+		return err
 	}
 
 	cDescriptorSets := make([]C.VkDescriptorSet, len(descriptorSets))
@@ -305,4 +672,31 @@ func CmdBindDescriptorSets(commandBuffer CommandBuffer, pipelineBindPoint Pipeli
 		C.uint32_t(len(cDynamicOffsets)),
 		pDynamicOffsets,
 	)
+	return nil
+}
+
+// validateDynamicOffsets checks that one dynamic offset is given per dynamic descriptor of
+// the sets. The sets are looked up on the device commandBuffer was allocated from, so the
+// check is skipped if commandBuffer was not allocated through AllocateCommandBuffers or any
+// set was not allocated through AllocateDescriptorSets.
+func validateDynamicOffsets(commandBuffer CommandBuffer, descriptorSets []DescriptorSet, dynamicOffsets []uint32) error {
+	owner, known := allocatedCommandBuffers.owner(commandBuffer)
+	var expected uint32
+	for i, set := range descriptorSets {
+		if set == nil {
+			return NewValidationError(fmt.Sprintf("descriptorSets[%d]", i), "cannot be nil")
+		}
+		if !known {
+			continue
+		}
+		if count, ok := dynamicDescriptors.setCount(owner.device, set); ok {
+			expected += count
+		} else {
+			known = false
+		}
+	}
+	if known && uint32(len(dynamicOffsets)) != expected {
+		return NewValidationError("dynamicOffsets", fmt.Sprintf("expected %d offsets for the dynamic descriptors of the bound sets, got %d", expected, len(dynamicOffsets)))
+	}
+	return nil
 }
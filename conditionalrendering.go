@@ -0,0 +1,186 @@
+package vulkan
+
+/*
+#include <vulkan/vulkan.h>
+#include <stdlib.h>
+
+// Function pointers for conditional rendering EXT extension functions
+// These need to be loaded dynamically at runtime.
+//
+// IMPORTANT: These are global static pointers and NOT thread-safe during loading.
+// LoadConditionalRenderingFunctions must be called from a single thread during
+// initialization before any concurrent conditional rendering API usage.
+static PFN_vkCmdBeginConditionalRenderingEXT pfn_vkCmdBeginConditionalRenderingEXT = NULL;
+static PFN_vkCmdEndConditionalRenderingEXT pfn_vkCmdEndConditionalRenderingEXT = NULL;
+
+static int loadConditionalRenderingDeviceFunctions(VkDevice device) {
+    if (device == VK_NULL_HANDLE) {
+        return 0;
+    }
+    pfn_vkCmdBeginConditionalRenderingEXT = (PFN_vkCmdBeginConditionalRenderingEXT)
+        vkGetDeviceProcAddr(device, "vkCmdBeginConditionalRenderingEXT");
+    pfn_vkCmdEndConditionalRenderingEXT = (PFN_vkCmdEndConditionalRenderingEXT)
+        vkGetDeviceProcAddr(device, "vkCmdEndConditionalRenderingEXT");
+
+    return pfn_vkCmdBeginConditionalRenderingEXT != NULL &&
+           pfn_vkCmdEndConditionalRenderingEXT != NULL;
+}
+
+// Command buffer wrapper functions return 1 on success, 0 if function pointer is NULL.
+static int call_vkCmdBeginConditionalRenderingEXT(
+    VkCommandBuffer commandBuffer,
+    VkBuffer buffer,
+    VkDeviceSize offset,
+    VkConditionalRenderingFlagsEXT flags) {
+    if (pfn_vkCmdBeginConditionalRenderingEXT == NULL) {
+        return 0;
+    }
+    VkConditionalRenderingBeginInfoEXT beginInfo = {
+        .sType = VK_STRUCTURE_TYPE_CONDITIONAL_RENDERING_BEGIN_INFO_EXT,
+        .pNext = NULL,
+        .buffer = buffer,
+        .offset = offset,
+        .flags = flags,
+    };
+    pfn_vkCmdBeginConditionalRenderingEXT(commandBuffer, &beginInfo);
+    return 1;
+}
+
+static int call_vkCmdEndConditionalRenderingEXT(VkCommandBuffer commandBuffer) {
+    if (pfn_vkCmdEndConditionalRenderingEXT == NULL) {
+        return 0;
+    }
+    pfn_vkCmdEndConditionalRenderingEXT(commandBuffer);
+    return 1;
+}
+*/
+import "C"
+
+import (
+	"unsafe"
+)
+
+// ExtensionNameConditionalRendering is the conditional rendering extension name
+const ExtensionNameConditionalRendering = "VK_EXT_conditional_rendering"
+
+// ConditionalRenderingFlags controls how the predicate of a conditional rendering block is evaluated
+type ConditionalRenderingFlags uint32
+
+const (
+	// ConditionalRenderingInvertedBit executes the commands when the predicate is zero instead of non-zero
+	ConditionalRenderingInvertedBit ConditionalRenderingFlags = C.VK_CONDITIONAL_RENDERING_INVERTED_BIT_EXT
+)
+
+// Conditional rendering synchronization flags, for barriers that make predicate writes visible
+const (
+	BufferUsageConditionalRenderingBitEXT   BufferUsageFlags   = C.VK_BUFFER_USAGE_CONDITIONAL_RENDERING_BIT_EXT
+	PipelineStageConditionalRenderingBitEXT PipelineStageFlags = C.VK_PIPELINE_STAGE_CONDITIONAL_RENDERING_BIT_EXT
+	AccessConditionalRenderingReadBitEXT    AccessFlags        = C.VK_ACCESS_CONDITIONAL_RENDERING_READ_BIT_EXT
+)
+
+// ConditionalRenderingBeginInfo contains the predicate of a conditional rendering block
+type ConditionalRenderingBeginInfo struct {
+	// Buffer holds the 32-bit predicate; it must have been created with
+	// BufferUsageConditionalRenderingBitEXT
+	Buffer Buffer
+	// Offset of the predicate in Buffer; must be a multiple of 4
+	Offset DeviceSize
+	Flags  ConditionalRenderingFlags
+}
+
+// PhysicalDeviceConditionalRenderingFeatures contains conditional rendering features
+type PhysicalDeviceConditionalRenderingFeatures struct {
+	ConditionalRendering          bool
+	InheritedConditionalRendering bool
+}
+
+// LoadConditionalRenderingFunctions loads conditional rendering extension functions for a device.
+//
+// This function MUST be called after creating a logical device with the
+// VK_EXT_conditional_rendering extension enabled and before recording any conditional
+// rendering commands.
+//
+// IMPORTANT: This function is NOT thread-safe. Only one device is supported at a time;
+// calling this function again will overwrite previously loaded function pointers.
+//
+// Returns false if any conditional rendering function could not be loaded.
+func LoadConditionalRenderingFunctions(device Device) bool {
+	return C.loadConditionalRenderingDeviceFunctions(C.VkDevice(device)) != 0
+}
+
+// conditionalRenderingFeaturesToC prepends a struct enabling the requested conditional
+// rendering features to the pNext chain next. The struct is allocated in C memory and
+// appended to allocations, which the caller must free.
+func conditionalRenderingFeaturesToC(features *PhysicalDeviceConditionalRenderingFeatures, next unsafe.Pointer, allocations *[]unsafe.Pointer) (unsafe.Pointer, error) {
+	cConditional := (*C.VkPhysicalDeviceConditionalRenderingFeaturesEXT)(C.calloc(1, C.sizeof_VkPhysicalDeviceConditionalRenderingFeaturesEXT))
+	if cConditional == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "CreateDevice", "failed to allocate memory for conditional rendering features")
+	}
+	*allocations = append(*allocations, unsafe.Pointer(cConditional))
+
+	cConditional.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_CONDITIONAL_RENDERING_FEATURES_EXT
+	cConditional.pNext = next
+	cConditional.conditionalRendering = boolToVkBool32(features.ConditionalRendering)
+	cConditional.inheritedConditionalRendering = boolToVkBool32(features.InheritedConditionalRendering)
+
+	return unsafe.Pointer(cConditional), nil
+}
+
+// GetPhysicalDeviceConditionalRenderingFeaturesEXT queries conditional rendering feature support
+func GetPhysicalDeviceConditionalRenderingFeaturesEXT(physicalDevice PhysicalDevice) PhysicalDeviceConditionalRenderingFeatures {
+	cFeatures2 := (*C.VkPhysicalDeviceFeatures2)(C.calloc(1, C.sizeof_VkPhysicalDeviceFeatures2))
+	cConditional := (*C.VkPhysicalDeviceConditionalRenderingFeaturesEXT)(C.calloc(1, C.sizeof_VkPhysicalDeviceConditionalRenderingFeaturesEXT))
+	defer C.free(unsafe.Pointer(cFeatures2))
+	defer C.free(unsafe.Pointer(cConditional))
+	if cFeatures2 == nil || cConditional == nil {
+		return PhysicalDeviceConditionalRenderingFeatures{}
+	}
+
+	cFeatures2.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_FEATURES_2
+	cFeatures2.pNext = unsafe.Pointer(cConditional)
+	cConditional.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_CONDITIONAL_RENDERING_FEATURES_EXT
+
+	C.vkGetPhysicalDeviceFeatures2(C.VkPhysicalDevice(physicalDevice), cFeatures2)
+
+	return PhysicalDeviceConditionalRenderingFeatures{
+		ConditionalRendering:          vkBool32ToBool(cConditional.conditionalRendering),
+		InheritedConditionalRendering: vkBool32ToBool(cConditional.inheritedConditionalRendering),
+	}
+}
+
+// CmdBeginConditionalRenderingEXT begins a block of commands that only execute when the
+// 32-bit predicate in the buffer is non-zero, or zero with ConditionalRenderingInvertedBit.
+// Returns an error if LoadConditionalRenderingFunctions was not called.
+func CmdBeginConditionalRenderingEXT(commandBuffer CommandBuffer, beginInfo *ConditionalRenderingBeginInfo) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+	if beginInfo == nil {
+		return NewValidationError("beginInfo", "cannot be nil")
+	}
+	if beginInfo.Buffer == nil {
+		return NewValidationError("beginInfo.Buffer", "cannot be nil")
+	}
+	if beginInfo.Offset%4 != 0 {
+		return NewValidationError("beginInfo.Offset", "must be a multiple of 4")
+	}
+
+	if C.call_vkCmdBeginConditionalRenderingEXT(C.VkCommandBuffer(commandBuffer), C.VkBuffer(beginInfo.Buffer),
+		C.VkDeviceSize(beginInfo.Offset), C.VkConditionalRenderingFlagsEXT(beginInfo.Flags)) == 0 {
+		return NewVulkanError(ErrorExtensionNotPresent, "CmdBeginConditionalRenderingEXT", "conditional rendering extension not loaded - call LoadConditionalRenderingFunctions first")
+	}
+	return nil
+}
+
+// CmdEndConditionalRenderingEXT ends the current conditional rendering block.
+// Returns an error if LoadConditionalRenderingFunctions was not called.
+func CmdEndConditionalRenderingEXT(commandBuffer CommandBuffer) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+
+	if C.call_vkCmdEndConditionalRenderingEXT(C.VkCommandBuffer(commandBuffer)) == 0 {
+		return NewVulkanError(ErrorExtensionNotPresent, "CmdEndConditionalRenderingEXT", "conditional rendering extension not loaded - call LoadConditionalRenderingFunctions first")
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+//go:build cgo
+
+package vulkan
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestConditionalRenderingValidation tests input validation and the unloaded-extension error
+func TestConditionalRenderingValidation(t *testing.T) {
+	fakeCommandBuffer := CommandBuffer(uintptr(0x1234))
+	fakeBuffer := Buffer(uintptr(0x5678))
+
+	tests := []struct {
+		name       string
+		beginInfo  *ConditionalRenderingBeginInfo
+		errorParam string
+	}{
+		{
+			name:       "nil begin info",
+			beginInfo:  nil,
+			errorParam: "beginInfo",
+		},
+		{
+			name:       "nil buffer",
+			beginInfo:  &ConditionalRenderingBeginInfo{},
+			errorParam: "beginInfo.Buffer",
+		},
+		{
+			name:       "unaligned offset",
+			beginInfo:  &ConditionalRenderingBeginInfo{Buffer: fakeBuffer, Offset: 2},
+			errorParam: "beginInfo.Offset",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CmdBeginConditionalRenderingEXT(fakeCommandBuffer, tt.beginInfo)
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+
+	// Without LoadConditionalRenderingFunctions the commands report the missing extension
+	err := CmdEndConditionalRenderingEXT(fakeCommandBuffer)
+	var vulkanErr *VulkanError
+	if !errors.As(err, &vulkanErr) || vulkanErr.Result != ErrorExtensionNotPresent {
+		t.Errorf("Expected ErrorExtensionNotPresent, got %v", err)
+	}
+}
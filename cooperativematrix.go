@@ -0,0 +1,200 @@
+package vulkan
+
+/*
+#include <vulkan/vulkan.h>
+#include <stdlib.h>
+
+// Function pointer for VK_KHR_cooperative_matrix.
+// It is loaded through the instance so support can be queried before device creation.
+//
+// IMPORTANT: This is a global static pointer and NOT thread-safe during loading.
+// LoadCooperativeMatrixFunctions must be called from a single thread during
+// initialization before any concurrent cooperative matrix API usage.
+static PFN_vkGetPhysicalDeviceCooperativeMatrixPropertiesKHR pfn_vkGetPhysicalDeviceCooperativeMatrixPropertiesKHR = NULL;
+
+static int loadCooperativeMatrixInstanceFunctions(VkInstance instance) {
+    if (instance == VK_NULL_HANDLE) {
+        return 0;
+    }
+    pfn_vkGetPhysicalDeviceCooperativeMatrixPropertiesKHR = (PFN_vkGetPhysicalDeviceCooperativeMatrixPropertiesKHR)
+        vkGetInstanceProcAddr(instance, "vkGetPhysicalDeviceCooperativeMatrixPropertiesKHR");
+
+    return pfn_vkGetPhysicalDeviceCooperativeMatrixPropertiesKHR != NULL;
+}
+
+// Wrappers return VK_ERROR_EXTENSION_NOT_PRESENT if the function pointer is NULL.
+static VkResult call_vkGetPhysicalDeviceCooperativeMatrixPropertiesKHR(
+    VkPhysicalDevice physicalDevice,
+    uint32_t* pPropertyCount,
+    VkCooperativeMatrixPropertiesKHR* pProperties) {
+    if (pfn_vkGetPhysicalDeviceCooperativeMatrixPropertiesKHR == NULL) {
+        return VK_ERROR_EXTENSION_NOT_PRESENT;
+    }
+    return pfn_vkGetPhysicalDeviceCooperativeMatrixPropertiesKHR(physicalDevice, pPropertyCount, pProperties);
+}
+*/
+import "C"
+
+import "unsafe"
+
+// ExtensionNameCooperativeMatrix is the cooperative matrix device extension name
+const ExtensionNameCooperativeMatrix = "VK_KHR_cooperative_matrix"
+
+// ComponentType is the element type of a cooperative matrix
+type ComponentType int32
+
+const (
+	ComponentTypeFloat16 ComponentType = C.VK_COMPONENT_TYPE_FLOAT16_KHR
+	ComponentTypeFloat32 ComponentType = C.VK_COMPONENT_TYPE_FLOAT32_KHR
+	ComponentTypeFloat64 ComponentType = C.VK_COMPONENT_TYPE_FLOAT64_KHR
+	ComponentTypeSint8   ComponentType = C.VK_COMPONENT_TYPE_SINT8_KHR
+	ComponentTypeSint16  ComponentType = C.VK_COMPONENT_TYPE_SINT16_KHR
+	ComponentTypeSint32  ComponentType = C.VK_COMPONENT_TYPE_SINT32_KHR
+	ComponentTypeSint64  ComponentType = C.VK_COMPONENT_TYPE_SINT64_KHR
+	ComponentTypeUint8   ComponentType = C.VK_COMPONENT_TYPE_UINT8_KHR
+	ComponentTypeUint16  ComponentType = C.VK_COMPONENT_TYPE_UINT16_KHR
+	ComponentTypeUint32  ComponentType = C.VK_COMPONENT_TYPE_UINT32_KHR
+	ComponentTypeUint64  ComponentType = C.VK_COMPONENT_TYPE_UINT64_KHR
+)
+
+// Scope is the set of invocations that cooperate on a matrix operation
+type Scope int32
+
+const (
+	ScopeDevice      Scope = C.VK_SCOPE_DEVICE_KHR
+	ScopeWorkgroup   Scope = C.VK_SCOPE_WORKGROUP_KHR
+	ScopeSubgroup    Scope = C.VK_SCOPE_SUBGROUP_KHR
+	ScopeQueueFamily Scope = C.VK_SCOPE_QUEUE_FAMILY_KHR
+)
+
+// PhysicalDeviceCooperativeMatrixFeatures contains the cooperative matrix features
+type PhysicalDeviceCooperativeMatrixFeatures struct {
+	// CooperativeMatrix allows shaders to use the CooperativeMatrixKHR SPIR-V capability
+	CooperativeMatrix bool
+	// CooperativeMatrixRobustBufferAccess extends robust buffer access to cooperative matrix
+	// loads and stores
+	CooperativeMatrixRobustBufferAccess bool
+}
+
+// CooperativeMatrixProperties describes one supported cooperative matrix multiply-add
+// Result = A * B + C, where A is MSize x KSize, B is KSize x NSize and C and Result are
+// MSize x NSize
+type CooperativeMatrixProperties struct {
+	MSize      uint32
+	NSize      uint32
+	KSize      uint32
+	AType      ComponentType
+	BType      ComponentType
+	CType      ComponentType
+	ResultType ComponentType
+	// SaturatingAccumulation means the multiply-add must use saturating integer addition
+	SaturatingAccumulation bool
+	Scope                  Scope
+}
+
+// LoadCooperativeMatrixFunctions loads VK_KHR_cooperative_matrix functions for an instance.
+//
+// This function MUST be called after creating an instance and before querying cooperative
+// matrix properties.
+//
+// IMPORTANT: This function is NOT thread-safe. Only one instance is supported at a time;
+// calling this function again will overwrite previously loaded function pointers.
+//
+// Returns false if any cooperative matrix function could not be loaded.
+func LoadCooperativeMatrixFunctions(instance Instance) bool {
+	return C.loadCooperativeMatrixInstanceFunctions(C.VkInstance(instance)) != 0
+}
+
+// cooperativeMatrixFeaturesToC prepends a struct enabling the requested cooperative matrix
+// features to the pNext chain next. The struct is allocated in C memory and appended to
+// allocations, which the caller must free.
+func cooperativeMatrixFeaturesToC(features *PhysicalDeviceCooperativeMatrixFeatures, next unsafe.Pointer, allocations *[]unsafe.Pointer) (unsafe.Pointer, error) {
+	cMatrix := (*C.VkPhysicalDeviceCooperativeMatrixFeaturesKHR)(C.calloc(1, C.sizeof_VkPhysicalDeviceCooperativeMatrixFeaturesKHR))
+	if cMatrix == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "CreateDevice", "failed to allocate memory for cooperative matrix features")
+	}
+	*allocations = append(*allocations, unsafe.Pointer(cMatrix))
+
+	cMatrix.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_COOPERATIVE_MATRIX_FEATURES_KHR
+	cMatrix.pNext = next
+	cMatrix.cooperativeMatrix = boolToVkBool32(features.CooperativeMatrix)
+	cMatrix.cooperativeMatrixRobustBufferAccess = boolToVkBool32(features.CooperativeMatrixRobustBufferAccess)
+
+	return unsafe.Pointer(cMatrix), nil
+}
+
+// GetPhysicalDeviceCooperativeMatrixFeaturesKHR queries cooperative matrix support
+func GetPhysicalDeviceCooperativeMatrixFeaturesKHR(physicalDevice PhysicalDevice) PhysicalDeviceCooperativeMatrixFeatures {
+	cFeatures2 := (*C.VkPhysicalDeviceFeatures2)(C.calloc(1, C.sizeof_VkPhysicalDeviceFeatures2))
+	cMatrix := (*C.VkPhysicalDeviceCooperativeMatrixFeaturesKHR)(C.calloc(1, C.sizeof_VkPhysicalDeviceCooperativeMatrixFeaturesKHR))
+	defer C.free(unsafe.Pointer(cFeatures2))
+	defer C.free(unsafe.Pointer(cMatrix))
+	if cFeatures2 == nil || cMatrix == nil {
+		return PhysicalDeviceCooperativeMatrixFeatures{}
+	}
+
+	cFeatures2.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_FEATURES_2
+	cFeatures2.pNext = unsafe.Pointer(cMatrix)
+	cMatrix.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_COOPERATIVE_MATRIX_FEATURES_KHR
+
+	C.vkGetPhysicalDeviceFeatures2(C.VkPhysicalDevice(physicalDevice), cFeatures2)
+
+	return PhysicalDeviceCooperativeMatrixFeatures{
+		CooperativeMatrix:                   vkBool32ToBool(cMatrix.cooperativeMatrix),
+		CooperativeMatrixRobustBufferAccess: vkBool32ToBool(cMatrix.cooperativeMatrixRobustBufferAccess),
+	}
+}
+
+// GetPhysicalDeviceCooperativeMatrixPropertiesKHR lists the matrix sizes, component types and
+// scopes the device supports for cooperative matrix multiply-add. Check it before
+// dispatching a shader that uses cooperative matrices.
+// Returns an error if LoadCooperativeMatrixFunctions was not called.
+func GetPhysicalDeviceCooperativeMatrixPropertiesKHR(physicalDevice PhysicalDevice) ([]CooperativeMatrixProperties, error) {
+	if physicalDevice == nil {
+		return nil, NewValidationError("physicalDevice", "cannot be nil")
+	}
+
+	var count C.uint32_t
+	result := Result(C.call_vkGetPhysicalDeviceCooperativeMatrixPropertiesKHR(C.VkPhysicalDevice(physicalDevice), &count, nil))
+	traceResult("vkGetPhysicalDeviceCooperativeMatrixPropertiesKHR", result)
+	if result != Success {
+		return nil, NewVulkanError(result, "GetPhysicalDeviceCooperativeMatrixPropertiesKHR", "failed to get cooperative matrix property count")
+	}
+	if count == 0 {
+		return nil, nil
+	}
+
+	cPropsPtr := (*C.VkCooperativeMatrixPropertiesKHR)(C.calloc(C.size_t(count), C.sizeof_VkCooperativeMatrixPropertiesKHR))
+	if cPropsPtr == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "GetPhysicalDeviceCooperativeMatrixPropertiesKHR", "failed to allocate memory for cooperative matrix properties")
+	}
+	defer C.free(unsafe.Pointer(cPropsPtr))
+
+	cProps := unsafe.Slice(cPropsPtr, count)
+	for i := range cProps {
+		cProps[i].sType = C.VK_STRUCTURE_TYPE_COOPERATIVE_MATRIX_PROPERTIES_KHR
+	}
+
+	result = Result(C.call_vkGetPhysicalDeviceCooperativeMatrixPropertiesKHR(C.VkPhysicalDevice(physicalDevice), &count, cPropsPtr))
+	traceResult("vkGetPhysicalDeviceCooperativeMatrixPropertiesKHR", result)
+	if result != Success && result != Incomplete {
+		return nil, NewVulkanError(result, "GetPhysicalDeviceCooperativeMatrixPropertiesKHR", "failed to get cooperative matrix properties")
+	}
+
+	properties := make([]CooperativeMatrixProperties, count)
+	for i := range properties {
+		cProp := &cProps[i]
+		properties[i] = CooperativeMatrixProperties{
+			MSize:                  uint32(cProp.MSize),
+			NSize:                  uint32(cProp.NSize),
+			KSize:                  uint32(cProp.KSize),
+			AType:                  ComponentType(cProp.AType),
+			BType:                  ComponentType(cProp.BType),
+			CType:                  ComponentType(cProp.CType),
+			ResultType:             ComponentType(cProp.ResultType),
+			SaturatingAccumulation: vkBool32ToBool(cProp.saturatingAccumulation),
+			Scope:                  Scope(cProp.scope),
+		}
+	}
+	return properties, nil
+}
@@ -0,0 +1,24 @@
+//go:build cgo
+
+package vulkan
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestCooperativeMatrixProperties tests validation and the unloaded-extension error of the cooperative matrix query
+func TestCooperativeMatrixProperties(t *testing.T) {
+	_, err := GetPhysicalDeviceCooperativeMatrixPropertiesKHR(nil)
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+	}
+	if validationErr.Parameter != "physicalDevice" {
+		t.Errorf("Expected error for parameter 'physicalDevice', got '%s'", validationErr.Parameter)
+	}
+
+	if _, err := GetPhysicalDeviceCooperativeMatrixPropertiesKHR(PhysicalDevice(uintptr(0x1234))); !errors.Is(err, ErrorExtensionNotPresent) {
+		t.Errorf("Expected ErrorExtensionNotPresent, got %v", err)
+	}
+}
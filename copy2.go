@@ -0,0 +1,434 @@
+package vulkan
+
+/*
+#include <vulkan/vulkan.h>
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"unsafe"
+)
+
+// Offset3D represents a 3D offset
+type Offset3D struct {
+	X int32
+	Y int32
+	Z int32
+}
+
+// ImageSubresourceLayers describes the mip level and array layers of an image used in a copy
+type ImageSubresourceLayers struct {
+	AspectMask     ImageAspectFlags
+	MipLevel       uint32
+	BaseArrayLayer uint32
+	LayerCount     uint32
+}
+
+// ImageCopy describes an image to image copy region
+type ImageCopy struct {
+	SrcSubresource ImageSubresourceLayers
+	SrcOffset      Offset3D
+	DstSubresource ImageSubresourceLayers
+	DstOffset      Offset3D
+	Extent         Extent3D
+}
+
+// BufferImageCopy describes a buffer to image or image to buffer copy region.
+// A BufferRowLength or BufferImageHeight of zero means the buffer is tightly packed.
+type BufferImageCopy struct {
+	BufferOffset      DeviceSize
+	BufferRowLength   uint32
+	BufferImageHeight uint32
+	ImageSubresource  ImageSubresourceLayers
+	ImageOffset       Offset3D
+	ImageExtent       Extent3D
+
+	// Transform rotates the image region when set. It requires ExtensionNameRotatedCopyCommands
+	// and is only honored by CmdCopyBufferToImage2.
+	Transform *CopyCommandTransformInfo
+}
+
+// ImageBlit describes an image blit region. Each offset pair bounds the source and
+// destination regions; the regions are scaled to fit if their sizes differ.
+type ImageBlit struct {
+	SrcSubresource ImageSubresourceLayers
+	SrcOffsets     [2]Offset3D
+	DstSubresource ImageSubresourceLayers
+	DstOffsets     [2]Offset3D
+
+	// Transform rotates the source region when set. It requires ExtensionNameRotatedCopyCommands
+	// and is only accepted by CmdBlitImage2; CmdBlitImage rejects it.
+	Transform *CopyCommandTransformInfo
+}
+
+// ExtensionNameRotatedCopyCommands is the rotated copy commands device extension name
+const ExtensionNameRotatedCopyCommands = "VK_QCOM_rotated_copy_commands"
+
+// CopyCommandTransformInfo is chained into a BufferImageCopy or ImageBlit region to rotate it.
+// Transform must be SurfaceTransformIdentityBit or one of the Rotate90, Rotate180 and
+// Rotate270 bits.
+type CopyCommandTransformInfo struct {
+	Transform SurfaceTransformFlags
+}
+
+// ImageResolve describes a multisample resolve region
+type ImageResolve struct {
+	SrcSubresource ImageSubresourceLayers
+	SrcOffset      Offset3D
+	DstSubresource ImageSubresourceLayers
+	DstOffset      Offset3D
+	Extent         Extent3D
+}
+
+// CopyBufferInfo2 contains the parameters for CmdCopyBuffer2
+type CopyBufferInfo2 struct {
+	SrcBuffer Buffer
+	DstBuffer Buffer
+	Regions   []BufferCopy
+}
+
+// CopyImageInfo2 contains the parameters for CmdCopyImage2
+type CopyImageInfo2 struct {
+	SrcImage       Image
+	SrcImageLayout ImageLayout
+	DstImage       Image
+	DstImageLayout ImageLayout
+	Regions        []ImageCopy
+}
+
+// BlitImageInfo2 contains the parameters for CmdBlitImage2
+type BlitImageInfo2 struct {
+	SrcImage       Image
+	SrcImageLayout ImageLayout
+	DstImage       Image
+	DstImageLayout ImageLayout
+	Regions        []ImageBlit
+	Filter         Filter
+}
+
+// CopyBufferToImageInfo2 contains the parameters for CmdCopyBufferToImage2
+type CopyBufferToImageInfo2 struct {
+	SrcBuffer      Buffer
+	DstImage       Image
+	DstImageLayout ImageLayout
+	Regions        []BufferImageCopy
+}
+
+// ResolveImageInfo2 contains the parameters for CmdResolveImage2
+type ResolveImageInfo2 struct {
+	SrcImage       Image
+	SrcImageLayout ImageLayout
+	DstImage       Image
+	DstImageLayout ImageLayout
+	Regions        []ImageResolve
+}
+
+func (s ImageSubresourceLayers) toC() C.VkImageSubresourceLayers {
+	return C.VkImageSubresourceLayers{
+		aspectMask:     C.VkImageAspectFlags(s.AspectMask),
+		mipLevel:       C.uint32_t(s.MipLevel),
+		baseArrayLayer: C.uint32_t(s.BaseArrayLayer),
+		layerCount:     C.uint32_t(s.LayerCount),
+	}
+}
+
+func (o Offset3D) toC() C.VkOffset3D {
+	return C.VkOffset3D{x: C.int32_t(o.X), y: C.int32_t(o.Y), z: C.int32_t(o.Z)}
+}
+
+func (e Extent3D) toC() C.VkExtent3D {
+	return C.VkExtent3D{width: C.uint32_t(e.Width), height: C.uint32_t(e.Height), depth: C.uint32_t(e.Depth)}
+}
+
+// validateCopyCommandTransform checks that transform, if set, is one of the rotations accepted
+// by VK_QCOM_rotated_copy_commands
+func validateCopyCommandTransform(transform *CopyCommandTransformInfo, parameter string) error {
+	if transform == nil {
+		return nil
+	}
+	switch transform.Transform {
+	case SurfaceTransformIdentityBit, SurfaceTransformRotate90Bit, SurfaceTransformRotate180Bit, SurfaceTransformRotate270Bit:
+		return nil
+	}
+	return NewValidationError(parameter, "transform must be the identity or a 90, 180 or 270 degree rotation")
+}
+
+// copyCommandTransformToC returns a region pNext chain for transform, or nil when it is not set.
+// The struct is allocated in C memory and appended to allocations, which the caller must free.
+func copyCommandTransformToC(operation string, transform *CopyCommandTransformInfo, allocations *[]unsafe.Pointer) (unsafe.Pointer, error) {
+	if transform == nil {
+		return nil, nil
+	}
+	cTransform := (*C.VkCopyCommandTransformInfoQCOM)(C.calloc(1, C.sizeof_VkCopyCommandTransformInfoQCOM))
+	if cTransform == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, operation, "failed to allocate memory for copy command transform")
+	}
+	*allocations = append(*allocations, unsafe.Pointer(cTransform))
+
+	cTransform.sType = C.VK_STRUCTURE_TYPE_COPY_COMMAND_TRANSFORM_INFO_QCOM
+	cTransform.transform = C.VkSurfaceTransformFlagBitsKHR(transform.Transform)
+
+	return unsafe.Pointer(cTransform), nil
+}
+
+// CmdCopyBuffer2 copies data between buffers using the extensible Vulkan 1.3 copy command
+func CmdCopyBuffer2(commandBuffer CommandBuffer, copyInfo *CopyBufferInfo2) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+	if copyInfo == nil {
+		return NewValidationError("copyInfo", "cannot be nil")
+	}
+	if copyInfo.SrcBuffer == nil || copyInfo.DstBuffer == nil {
+		return NewValidationError("copyInfo", "source and destination buffers cannot be nil")
+	}
+	if len(copyInfo.Regions) == 0 {
+		return NewValidationError("copyInfo.Regions", "must contain at least one region")
+	}
+
+	cRegionsPtr := (*C.VkBufferCopy2)(C.calloc(C.size_t(len(copyInfo.Regions)), C.sizeof_VkBufferCopy2))
+	if cRegionsPtr == nil {
+		return NewVulkanError(ErrorOutOfHostMemory, "CmdCopyBuffer2", "failed to allocate memory for copy regions")
+	}
+	defer C.free(unsafe.Pointer(cRegionsPtr))
+
+	cRegions := unsafe.Slice(cRegionsPtr, len(copyInfo.Regions))
+	for i, region := range copyInfo.Regions {
+		if region.Size == 0 {
+			return NewValidationError("copyInfo.Regions", "region size must be greater than zero")
+		}
+		cRegions[i] = C.VkBufferCopy2{
+			sType:     C.VK_STRUCTURE_TYPE_BUFFER_COPY_2,
+			srcOffset: C.VkDeviceSize(region.SrcOffset),
+			dstOffset: C.VkDeviceSize(region.DstOffset),
+			size:      C.VkDeviceSize(region.Size),
+		}
+	}
+
+	cInfo := C.VkCopyBufferInfo2{
+		sType:       C.VK_STRUCTURE_TYPE_COPY_BUFFER_INFO_2,
+		srcBuffer:   C.VkBuffer(copyInfo.SrcBuffer),
+		dstBuffer:   C.VkBuffer(copyInfo.DstBuffer),
+		regionCount: C.uint32_t(len(copyInfo.Regions)),
+		pRegions:    cRegionsPtr,
+	}
+	C.vkCmdCopyBuffer2(C.VkCommandBuffer(commandBuffer), &cInfo)
+	return nil
+}
+
+// CmdCopyImage2 copies data between images using the extensible Vulkan 1.3 copy command
+func CmdCopyImage2(commandBuffer CommandBuffer, copyInfo *CopyImageInfo2) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+	if copyInfo == nil {
+		return NewValidationError("copyInfo", "cannot be nil")
+	}
+	if copyInfo.SrcImage == nil || copyInfo.DstImage == nil {
+		return NewValidationError("copyInfo", "source and destination images cannot be nil")
+	}
+	if len(copyInfo.Regions) == 0 {
+		return NewValidationError("copyInfo.Regions", "must contain at least one region")
+	}
+
+	cRegionsPtr := (*C.VkImageCopy2)(C.calloc(C.size_t(len(copyInfo.Regions)), C.sizeof_VkImageCopy2))
+	if cRegionsPtr == nil {
+		return NewVulkanError(ErrorOutOfHostMemory, "CmdCopyImage2", "failed to allocate memory for copy regions")
+	}
+	defer C.free(unsafe.Pointer(cRegionsPtr))
+
+	cRegions := unsafe.Slice(cRegionsPtr, len(copyInfo.Regions))
+	for i, region := range copyInfo.Regions {
+		cRegions[i] = C.VkImageCopy2{
+			sType:          C.VK_STRUCTURE_TYPE_IMAGE_COPY_2,
+			srcSubresource: region.SrcSubresource.toC(),
+			srcOffset:      region.SrcOffset.toC(),
+			dstSubresource: region.DstSubresource.toC(),
+			dstOffset:      region.DstOffset.toC(),
+			extent:         region.Extent.toC(),
+		}
+	}
+
+	cInfo := C.VkCopyImageInfo2{
+		sType:          C.VK_STRUCTURE_TYPE_COPY_IMAGE_INFO_2,
+		srcImage:       C.VkImage(copyInfo.SrcImage),
+		srcImageLayout: C.VkImageLayout(copyInfo.SrcImageLayout),
+		dstImage:       C.VkImage(copyInfo.DstImage),
+		dstImageLayout: C.VkImageLayout(copyInfo.DstImageLayout),
+		regionCount:    C.uint32_t(len(copyInfo.Regions)),
+		pRegions:       cRegionsPtr,
+	}
+	C.vkCmdCopyImage2(C.VkCommandBuffer(commandBuffer), &cInfo)
+	return nil
+}
+
+// CmdBlitImage2 copies regions between images with scaling and format conversion
+// using the extensible Vulkan 1.3 blit command
+func CmdBlitImage2(commandBuffer CommandBuffer, blitInfo *BlitImageInfo2) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+	if blitInfo == nil {
+		return NewValidationError("blitInfo", "cannot be nil")
+	}
+	if blitInfo.SrcImage == nil || blitInfo.DstImage == nil {
+		return NewValidationError("blitInfo", "source and destination images cannot be nil")
+	}
+	if len(blitInfo.Regions) == 0 {
+		return NewValidationError("blitInfo.Regions", "must contain at least one region")
+	}
+	for _, region := range blitInfo.Regions {
+		if err := validateCopyCommandTransform(region.Transform, "blitInfo.Regions"); err != nil {
+			return err
+		}
+	}
+
+	var allocations []unsafe.Pointer
+	defer func() { freeAllocations(allocations) }()
+
+	cRegionsPtr := (*C.VkImageBlit2)(C.calloc(C.size_t(len(blitInfo.Regions)), C.sizeof_VkImageBlit2))
+	if cRegionsPtr == nil {
+		return NewVulkanError(ErrorOutOfHostMemory, "CmdBlitImage2", "failed to allocate memory for blit regions")
+	}
+	allocations = append(allocations, unsafe.Pointer(cRegionsPtr))
+
+	cRegions := unsafe.Slice(cRegionsPtr, len(blitInfo.Regions))
+	for i, region := range blitInfo.Regions {
+		next, err := copyCommandTransformToC("CmdBlitImage2", region.Transform, &allocations)
+		if err != nil {
+			return err
+		}
+		cRegions[i] = C.VkImageBlit2{
+			sType:          C.VK_STRUCTURE_TYPE_IMAGE_BLIT_2,
+			pNext:          next,
+			srcSubresource: region.SrcSubresource.toC(),
+			srcOffsets:     [2]C.VkOffset3D{region.SrcOffsets[0].toC(), region.SrcOffsets[1].toC()},
+			dstSubresource: region.DstSubresource.toC(),
+			dstOffsets:     [2]C.VkOffset3D{region.DstOffsets[0].toC(), region.DstOffsets[1].toC()},
+		}
+	}
+
+	cInfo := C.VkBlitImageInfo2{
+		sType:          C.VK_STRUCTURE_TYPE_BLIT_IMAGE_INFO_2,
+		srcImage:       C.VkImage(blitInfo.SrcImage),
+		srcImageLayout: C.VkImageLayout(blitInfo.SrcImageLayout),
+		dstImage:       C.VkImage(blitInfo.DstImage),
+		dstImageLayout: C.VkImageLayout(blitInfo.DstImageLayout),
+		regionCount:    C.uint32_t(len(blitInfo.Regions)),
+		pRegions:       cRegionsPtr,
+		filter:         C.VkFilter(blitInfo.Filter),
+	}
+	C.vkCmdBlitImage2(C.VkCommandBuffer(commandBuffer), &cInfo)
+	return nil
+}
+
+// CmdCopyBufferToImage2 copies data from a buffer into an image using the extensible
+// Vulkan 1.3 copy command
+func CmdCopyBufferToImage2(commandBuffer CommandBuffer, copyInfo *CopyBufferToImageInfo2) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+	if copyInfo == nil {
+		return NewValidationError("copyInfo", "cannot be nil")
+	}
+	if copyInfo.SrcBuffer == nil {
+		return NewValidationError("copyInfo.SrcBuffer", "cannot be nil")
+	}
+	if copyInfo.DstImage == nil {
+		return NewValidationError("copyInfo.DstImage", "cannot be nil")
+	}
+	if len(copyInfo.Regions) == 0 {
+		return NewValidationError("copyInfo.Regions", "must contain at least one region")
+	}
+	for _, region := range copyInfo.Regions {
+		if err := validateCopyCommandTransform(region.Transform, "copyInfo.Regions"); err != nil {
+			return err
+		}
+	}
+
+	var allocations []unsafe.Pointer
+	defer func() { freeAllocations(allocations) }()
+
+	cRegionsPtr := (*C.VkBufferImageCopy2)(C.calloc(C.size_t(len(copyInfo.Regions)), C.sizeof_VkBufferImageCopy2))
+	if cRegionsPtr == nil {
+		return NewVulkanError(ErrorOutOfHostMemory, "CmdCopyBufferToImage2", "failed to allocate memory for copy regions")
+	}
+	allocations = append(allocations, unsafe.Pointer(cRegionsPtr))
+
+	cRegions := unsafe.Slice(cRegionsPtr, len(copyInfo.Regions))
+	for i, region := range copyInfo.Regions {
+		next, err := copyCommandTransformToC("CmdCopyBufferToImage2", region.Transform, &allocations)
+		if err != nil {
+			return err
+		}
+		cRegions[i] = C.VkBufferImageCopy2{
+			sType:             C.VK_STRUCTURE_TYPE_BUFFER_IMAGE_COPY_2,
+			pNext:             next,
+			bufferOffset:      C.VkDeviceSize(region.BufferOffset),
+			bufferRowLength:   C.uint32_t(region.BufferRowLength),
+			bufferImageHeight: C.uint32_t(region.BufferImageHeight),
+			imageSubresource:  region.ImageSubresource.toC(),
+			imageOffset:       region.ImageOffset.toC(),
+			imageExtent:       region.ImageExtent.toC(),
+		}
+	}
+
+	cInfo := C.VkCopyBufferToImageInfo2{
+		sType:          C.VK_STRUCTURE_TYPE_COPY_BUFFER_TO_IMAGE_INFO_2,
+		srcBuffer:      C.VkBuffer(copyInfo.SrcBuffer),
+		dstImage:       C.VkImage(copyInfo.DstImage),
+		dstImageLayout: C.VkImageLayout(copyInfo.DstImageLayout),
+		regionCount:    C.uint32_t(len(copyInfo.Regions)),
+		pRegions:       cRegionsPtr,
+	}
+	C.vkCmdCopyBufferToImage2(C.VkCommandBuffer(commandBuffer), &cInfo)
+	return nil
+}
+
+// CmdResolveImage2 resolves a multisample image into a single-sample image using the
+// extensible Vulkan 1.3 resolve command
+func CmdResolveImage2(commandBuffer CommandBuffer, resolveInfo *ResolveImageInfo2) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+	if resolveInfo == nil {
+		return NewValidationError("resolveInfo", "cannot be nil")
+	}
+	if resolveInfo.SrcImage == nil || resolveInfo.DstImage == nil {
+		return NewValidationError("resolveInfo", "source and destination images cannot be nil")
+	}
+	if len(resolveInfo.Regions) == 0 {
+		return NewValidationError("resolveInfo.Regions", "must contain at least one region")
+	}
+
+	cRegionsPtr := (*C.VkImageResolve2)(C.calloc(C.size_t(len(resolveInfo.Regions)), C.sizeof_VkImageResolve2))
+	if cRegionsPtr == nil {
+		return NewVulkanError(ErrorOutOfHostMemory, "CmdResolveImage2", "failed to allocate memory for resolve regions")
+	}
+	defer C.free(unsafe.Pointer(cRegionsPtr))
+
+	cRegions := unsafe.Slice(cRegionsPtr, len(resolveInfo.Regions))
+	for i, region := range resolveInfo.Regions {
+		cRegions[i] = C.VkImageResolve2{
+			sType:          C.VK_STRUCTURE_TYPE_IMAGE_RESOLVE_2,
+			srcSubresource: region.SrcSubresource.toC(),
+			srcOffset:      region.SrcOffset.toC(),
+			dstSubresource: region.DstSubresource.toC(),
+			dstOffset:      region.DstOffset.toC(),
+			extent:         region.Extent.toC(),
+		}
+	}
+
+	cInfo := C.VkResolveImageInfo2{
+		sType:          C.VK_STRUCTURE_TYPE_RESOLVE_IMAGE_INFO_2,
+		srcImage:       C.VkImage(resolveInfo.SrcImage),
+		srcImageLayout: C.VkImageLayout(resolveInfo.SrcImageLayout),
+		dstImage:       C.VkImage(resolveInfo.DstImage),
+		dstImageLayout: C.VkImageLayout(resolveInfo.DstImageLayout),
+		regionCount:    C.uint32_t(len(resolveInfo.Regions)),
+		pRegions:       cRegionsPtr,
+	}
+	C.vkCmdResolveImage2(C.VkCommandBuffer(commandBuffer), &cInfo)
+	return nil
+}
@@ -0,0 +1,204 @@
+//go:build cgo
+
+package vulkan
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestCopyCommands2Validation tests input validation of the extensible Vulkan 1.3 copy commands
+func TestCopyCommands2Validation(t *testing.T) {
+	fakeCommandBuffer := CommandBuffer(uintptr(0x5678))
+	fakeBuffer := Buffer(uintptr(0x9abc))
+	fakeImage := Image(uintptr(0xdef0))
+	mirror := &CopyCommandTransformInfo{Transform: SurfaceTransformHorizontalMirrorBit}
+
+	tests := []struct {
+		name       string
+		call       func() error
+		errorParam string
+	}{
+		{
+			name: "copy buffer with nil command buffer",
+			call: func() error {
+				return CmdCopyBuffer2(nil, &CopyBufferInfo2{SrcBuffer: fakeBuffer, DstBuffer: fakeBuffer, Regions: []BufferCopy{{Size: 4}}})
+			},
+			errorParam: "commandBuffer",
+		},
+		{
+			name:       "copy buffer with nil info",
+			call:       func() error { return CmdCopyBuffer2(fakeCommandBuffer, nil) },
+			errorParam: "copyInfo",
+		},
+		{
+			name: "copy buffer with nil destination",
+			call: func() error {
+				return CmdCopyBuffer2(fakeCommandBuffer, &CopyBufferInfo2{SrcBuffer: fakeBuffer, Regions: []BufferCopy{{Size: 4}}})
+			},
+			errorParam: "copyInfo",
+		},
+		{
+			name: "copy buffer without regions",
+			call: func() error {
+				return CmdCopyBuffer2(fakeCommandBuffer, &CopyBufferInfo2{SrcBuffer: fakeBuffer, DstBuffer: fakeBuffer})
+			},
+			errorParam: "copyInfo.Regions",
+		},
+		{
+			name: "copy buffer with zero-size region",
+			call: func() error {
+				return CmdCopyBuffer2(fakeCommandBuffer, &CopyBufferInfo2{SrcBuffer: fakeBuffer, DstBuffer: fakeBuffer, Regions: []BufferCopy{{}}})
+			},
+			errorParam: "copyInfo.Regions",
+		},
+		{
+			name: "copy image with nil command buffer",
+			call: func() error {
+				return CmdCopyImage2(nil, &CopyImageInfo2{SrcImage: fakeImage, DstImage: fakeImage, Regions: []ImageCopy{{}}})
+			},
+			errorParam: "commandBuffer",
+		},
+		{
+			name:       "copy image with nil info",
+			call:       func() error { return CmdCopyImage2(fakeCommandBuffer, nil) },
+			errorParam: "copyInfo",
+		},
+		{
+			name: "copy image with nil source",
+			call: func() error {
+				return CmdCopyImage2(fakeCommandBuffer, &CopyImageInfo2{DstImage: fakeImage, Regions: []ImageCopy{{}}})
+			},
+			errorParam: "copyInfo",
+		},
+		{
+			name: "copy image without regions",
+			call: func() error {
+				return CmdCopyImage2(fakeCommandBuffer, &CopyImageInfo2{SrcImage: fakeImage, DstImage: fakeImage})
+			},
+			errorParam: "copyInfo.Regions",
+		},
+		{
+			name: "blit image with nil command buffer",
+			call: func() error {
+				return CmdBlitImage2(nil, &BlitImageInfo2{SrcImage: fakeImage, DstImage: fakeImage, Regions: []ImageBlit{{}}})
+			},
+			errorParam: "commandBuffer",
+		},
+		{
+			name:       "blit image with nil info",
+			call:       func() error { return CmdBlitImage2(fakeCommandBuffer, nil) },
+			errorParam: "blitInfo",
+		},
+		{
+			name: "blit image without regions",
+			call: func() error {
+				return CmdBlitImage2(fakeCommandBuffer, &BlitImageInfo2{SrcImage: fakeImage, DstImage: fakeImage})
+			},
+			errorParam: "blitInfo.Regions",
+		},
+		{
+			name: "blit image with mirroring transform",
+			call: func() error {
+				return CmdBlitImage2(fakeCommandBuffer, &BlitImageInfo2{SrcImage: fakeImage, DstImage: fakeImage, Regions: []ImageBlit{{Transform: mirror}}})
+			},
+			errorParam: "blitInfo.Regions",
+		},
+		{
+			name: "copy buffer to image with nil command buffer",
+			call: func() error {
+				return CmdCopyBufferToImage2(nil, &CopyBufferToImageInfo2{SrcBuffer: fakeBuffer, DstImage: fakeImage, Regions: []BufferImageCopy{{}}})
+			},
+			errorParam: "commandBuffer",
+		},
+		{
+			name:       "copy buffer to image with nil info",
+			call:       func() error { return CmdCopyBufferToImage2(fakeCommandBuffer, nil) },
+			errorParam: "copyInfo",
+		},
+		{
+			name: "copy buffer to image with nil buffer",
+			call: func() error {
+				return CmdCopyBufferToImage2(fakeCommandBuffer, &CopyBufferToImageInfo2{DstImage: fakeImage, Regions: []BufferImageCopy{{}}})
+			},
+			errorParam: "copyInfo.SrcBuffer",
+		},
+		{
+			name: "copy buffer to image with nil image",
+			call: func() error {
+				return CmdCopyBufferToImage2(fakeCommandBuffer, &CopyBufferToImageInfo2{SrcBuffer: fakeBuffer, Regions: []BufferImageCopy{{}}})
+			},
+			errorParam: "copyInfo.DstImage",
+		},
+		{
+			name: "copy buffer to image without regions",
+			call: func() error {
+				return CmdCopyBufferToImage2(fakeCommandBuffer, &CopyBufferToImageInfo2{SrcBuffer: fakeBuffer, DstImage: fakeImage})
+			},
+			errorParam: "copyInfo.Regions",
+		},
+		{
+			name: "copy buffer to image with mirroring transform",
+			call: func() error {
+				return CmdCopyBufferToImage2(fakeCommandBuffer, &CopyBufferToImageInfo2{SrcBuffer: fakeBuffer, DstImage: fakeImage, Regions: []BufferImageCopy{{Transform: mirror}}})
+			},
+			errorParam: "copyInfo.Regions",
+		},
+		{
+			name: "resolve image with nil command buffer",
+			call: func() error {
+				return CmdResolveImage2(nil, &ResolveImageInfo2{SrcImage: fakeImage, DstImage: fakeImage, Regions: []ImageResolve{{}}})
+			},
+			errorParam: "commandBuffer",
+		},
+		{
+			name:       "resolve image with nil info",
+			call:       func() error { return CmdResolveImage2(fakeCommandBuffer, nil) },
+			errorParam: "resolveInfo",
+		},
+		{
+			name: "resolve image without regions",
+			call: func() error {
+				return CmdResolveImage2(fakeCommandBuffer, &ResolveImageInfo2{SrcImage: fakeImage, DstImage: fakeImage})
+			},
+			errorParam: "resolveInfo.Regions",
+		},
+		{
+			name: "legacy blit with transform",
+			call: func() error {
+				return CmdBlitImage(fakeCommandBuffer, fakeImage, ImageLayoutTransferSrcOptimal, fakeImage, ImageLayoutTransferDstOptimal,
+					[]ImageBlit{{Transform: &CopyCommandTransformInfo{Transform: SurfaceTransformRotate90Bit}}}, FilterLinear)
+			},
+			errorParam: "regions",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %v", err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+}
+
+// TestValidateCopyCommandTransform tests which transforms the rotated copy commands accept
+func TestValidateCopyCommandTransform(t *testing.T) {
+	valid := []SurfaceTransformFlags{SurfaceTransformIdentityBit, SurfaceTransformRotate90Bit, SurfaceTransformRotate180Bit, SurfaceTransformRotate270Bit}
+	for _, transform := range valid {
+		if err := validateCopyCommandTransform(&CopyCommandTransformInfo{Transform: transform}, "regions"); err != nil {
+			t.Errorf("Expected transform %d to be accepted, got %v", transform, err)
+		}
+	}
+	if err := validateCopyCommandTransform(nil, "regions"); err != nil {
+		t.Errorf("Expected a missing transform to be accepted, got %v", err)
+	}
+	if err := validateCopyCommandTransform(&CopyCommandTransformInfo{Transform: SurfaceTransformInheritBit}, "regions"); err == nil {
+		t.Error("Expected SurfaceTransformInheritBit to be rejected")
+	}
+}
@@ -0,0 +1,342 @@
+package vulkan
+
+/*
+#include <vulkan/vulkan.h>
+#include <stdlib.h>
+
+// Function pointers for VK_EXT_debug_utils label and object naming functions.
+// These are instance-level functions and need to be loaded dynamically at runtime.
+//
+// IMPORTANT: These are global static pointers shared by every instance.
+// LoadDebugUtilsFunctions serializes loading on the Go side, but must not replace the
+// pointers while other goroutines record labels or name objects.
+static PFN_vkCmdBeginDebugUtilsLabelEXT pfn_vkCmdBeginDebugUtilsLabelEXT = NULL;
+static PFN_vkCmdEndDebugUtilsLabelEXT pfn_vkCmdEndDebugUtilsLabelEXT = NULL;
+static PFN_vkCmdInsertDebugUtilsLabelEXT pfn_vkCmdInsertDebugUtilsLabelEXT = NULL;
+static PFN_vkQueueBeginDebugUtilsLabelEXT pfn_vkQueueBeginDebugUtilsLabelEXT = NULL;
+static PFN_vkQueueEndDebugUtilsLabelEXT pfn_vkQueueEndDebugUtilsLabelEXT = NULL;
+static PFN_vkQueueInsertDebugUtilsLabelEXT pfn_vkQueueInsertDebugUtilsLabelEXT = NULL;
+static PFN_vkSetDebugUtilsObjectNameEXT pfn_vkSetDebugUtilsObjectNameEXT = NULL;
+static PFN_vkSetDebugUtilsObjectTagEXT pfn_vkSetDebugUtilsObjectTagEXT = NULL;
+
+static int loadDebugUtilsInstanceFunctions(VkInstance instance) {
+    if (instance == VK_NULL_HANDLE) {
+        return 0;
+    }
+    pfn_vkCmdBeginDebugUtilsLabelEXT = (PFN_vkCmdBeginDebugUtilsLabelEXT)
+        vkGetInstanceProcAddr(instance, "vkCmdBeginDebugUtilsLabelEXT");
+    pfn_vkCmdEndDebugUtilsLabelEXT = (PFN_vkCmdEndDebugUtilsLabelEXT)
+        vkGetInstanceProcAddr(instance, "vkCmdEndDebugUtilsLabelEXT");
+    pfn_vkCmdInsertDebugUtilsLabelEXT = (PFN_vkCmdInsertDebugUtilsLabelEXT)
+        vkGetInstanceProcAddr(instance, "vkCmdInsertDebugUtilsLabelEXT");
+    pfn_vkQueueBeginDebugUtilsLabelEXT = (PFN_vkQueueBeginDebugUtilsLabelEXT)
+        vkGetInstanceProcAddr(instance, "vkQueueBeginDebugUtilsLabelEXT");
+    pfn_vkQueueEndDebugUtilsLabelEXT = (PFN_vkQueueEndDebugUtilsLabelEXT)
+        vkGetInstanceProcAddr(instance, "vkQueueEndDebugUtilsLabelEXT");
+    pfn_vkQueueInsertDebugUtilsLabelEXT = (PFN_vkQueueInsertDebugUtilsLabelEXT)
+        vkGetInstanceProcAddr(instance, "vkQueueInsertDebugUtilsLabelEXT");
+    pfn_vkSetDebugUtilsObjectNameEXT = (PFN_vkSetDebugUtilsObjectNameEXT)
+        vkGetInstanceProcAddr(instance, "vkSetDebugUtilsObjectNameEXT");
+    pfn_vkSetDebugUtilsObjectTagEXT = (PFN_vkSetDebugUtilsObjectTagEXT)
+        vkGetInstanceProcAddr(instance, "vkSetDebugUtilsObjectTagEXT");
+
+    return pfn_vkCmdBeginDebugUtilsLabelEXT != NULL &&
+           pfn_vkCmdEndDebugUtilsLabelEXT != NULL &&
+           pfn_vkCmdInsertDebugUtilsLabelEXT != NULL &&
+           pfn_vkQueueBeginDebugUtilsLabelEXT != NULL &&
+           pfn_vkQueueEndDebugUtilsLabelEXT != NULL &&
+           pfn_vkQueueInsertDebugUtilsLabelEXT != NULL &&
+           pfn_vkSetDebugUtilsObjectNameEXT != NULL &&
+           pfn_vkSetDebugUtilsObjectTagEXT != NULL;
+}
+
+// Object naming wrappers return VK_ERROR_EXTENSION_NOT_PRESENT if function pointer is NULL.
+static VkResult call_vkSetDebugUtilsObjectNameEXT(VkDevice device, const VkDebugUtilsObjectNameInfoEXT* pNameInfo) {
+    if (pfn_vkSetDebugUtilsObjectNameEXT == NULL) {
+        return VK_ERROR_EXTENSION_NOT_PRESENT;
+    }
+    return pfn_vkSetDebugUtilsObjectNameEXT(device, pNameInfo);
+}
+
+static VkResult call_vkSetDebugUtilsObjectTagEXT(VkDevice device, const VkDebugUtilsObjectTagInfoEXT* pTagInfo) {
+    if (pfn_vkSetDebugUtilsObjectTagEXT == NULL) {
+        return VK_ERROR_EXTENSION_NOT_PRESENT;
+    }
+    return pfn_vkSetDebugUtilsObjectTagEXT(device, pTagInfo);
+}
+
+// Label wrapper functions return 1 on success, 0 if function pointer is NULL.
+static int call_vkCmdBeginDebugUtilsLabelEXT(VkCommandBuffer commandBuffer, const VkDebugUtilsLabelEXT* pLabelInfo) {
+    if (pfn_vkCmdBeginDebugUtilsLabelEXT == NULL) {
+        return 0;
+    }
+    pfn_vkCmdBeginDebugUtilsLabelEXT(commandBuffer, pLabelInfo);
+    return 1;
+}
+
+static int call_vkCmdEndDebugUtilsLabelEXT(VkCommandBuffer commandBuffer) {
+    if (pfn_vkCmdEndDebugUtilsLabelEXT == NULL) {
+        return 0;
+    }
+    pfn_vkCmdEndDebugUtilsLabelEXT(commandBuffer);
+    return 1;
+}
+
+static int call_vkCmdInsertDebugUtilsLabelEXT(VkCommandBuffer commandBuffer, const VkDebugUtilsLabelEXT* pLabelInfo) {
+    if (pfn_vkCmdInsertDebugUtilsLabelEXT == NULL) {
+        return 0;
+    }
+    pfn_vkCmdInsertDebugUtilsLabelEXT(commandBuffer, pLabelInfo);
+    return 1;
+}
+
+static int call_vkQueueBeginDebugUtilsLabelEXT(VkQueue queue, const VkDebugUtilsLabelEXT* pLabelInfo) {
+    if (pfn_vkQueueBeginDebugUtilsLabelEXT == NULL) {
+        return 0;
+    }
+    pfn_vkQueueBeginDebugUtilsLabelEXT(queue, pLabelInfo);
+    return 1;
+}
+
+static int call_vkQueueEndDebugUtilsLabelEXT(VkQueue queue) {
+    if (pfn_vkQueueEndDebugUtilsLabelEXT == NULL) {
+        return 0;
+    }
+    pfn_vkQueueEndDebugUtilsLabelEXT(queue);
+    return 1;
+}
+
+static int call_vkQueueInsertDebugUtilsLabelEXT(VkQueue queue, const VkDebugUtilsLabelEXT* pLabelInfo) {
+    if (pfn_vkQueueInsertDebugUtilsLabelEXT == NULL) {
+        return 0;
+    }
+    pfn_vkQueueInsertDebugUtilsLabelEXT(queue, pLabelInfo);
+    return 1;
+}
+*/
+import "C"
+
+import (
+	"sync"
+	"unsafe"
+)
+
+// ExtensionNameDebugUtils is the debug utils instance extension name
+const ExtensionNameDebugUtils = "VK_EXT_debug_utils"
+
+// debugUtilsLoadState serializes loading of the global debug utils function pointers and
+// caches the result per instance
+var debugUtilsLoadState struct {
+	mu       sync.Mutex
+	instance Instance
+	loaded   bool
+}
+
+// loadDebugUtilsInstance calls into the C loader. It is a variable so tests can count the loads.
+var loadDebugUtilsInstance = func(instance Instance) bool {
+	return C.loadDebugUtilsInstanceFunctions(C.VkInstance(instance)) != 0
+}
+
+// LoadDebugUtilsFunctions loads VK_EXT_debug_utils functions for an instance.
+//
+// This function MUST be called after creating an instance with the VK_EXT_debug_utils
+// extension enabled and before recording debug labels or naming objects.
+//
+// Loading is safe to call from multiple goroutines: calls are serialized and a repeated call
+// for the already loaded instance returns the cached result. Only one instance is supported at
+// a time; loading a different instance replaces the function pointers.
+//
+// Returns false if any debug utils function could not be loaded.
+func LoadDebugUtilsFunctions(instance Instance) bool {
+	debugUtilsLoadState.mu.Lock()
+	defer debugUtilsLoadState.mu.Unlock()
+
+	if instance != nil && instance == debugUtilsLoadState.instance {
+		return debugUtilsLoadState.loaded
+	}
+	debugUtilsLoadState.instance = instance
+	debugUtilsLoadState.loaded = loadDebugUtilsInstance(instance)
+	return debugUtilsLoadState.loaded
+}
+
+// debugUtilsLabel fills a VkDebugUtilsLabelEXT. The returned C string must be freed by the caller.
+func debugUtilsLabel(labelName string, color [4]float32) (C.VkDebugUtilsLabelEXT, *C.char) {
+	cName := C.CString(labelName)
+
+	var cLabel C.VkDebugUtilsLabelEXT
+	cLabel.sType = C.VK_STRUCTURE_TYPE_DEBUG_UTILS_LABEL_EXT
+	cLabel.pNext = nil
+	cLabel.pLabelName = cName
+	for i, c := range color {
+		cLabel.color[i] = C.float(c)
+	}
+	return cLabel, cName
+}
+
+// CmdBeginDebugUtilsLabelEXT opens a named, colored region in a command buffer for capture
+// tools such as RenderDoc and Nsight. An all-zero color lets the tool pick one.
+// Returns an error if LoadDebugUtilsFunctions was not called.
+func CmdBeginDebugUtilsLabelEXT(commandBuffer CommandBuffer, labelName string, color [4]float32) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+
+	cLabel, cName := debugUtilsLabel(labelName, color)
+	defer C.free(unsafe.Pointer(cName))
+
+	if C.call_vkCmdBeginDebugUtilsLabelEXT(C.VkCommandBuffer(commandBuffer), &cLabel) == 0 {
+		return NewVulkanError(ErrorExtensionNotPresent, "CmdBeginDebugUtilsLabelEXT", "debug utils extension not loaded - call LoadDebugUtilsFunctions first")
+	}
+	return nil
+}
+
+// CmdEndDebugUtilsLabelEXT closes the region opened by the most recent CmdBeginDebugUtilsLabelEXT.
+// Returns an error if LoadDebugUtilsFunctions was not called.
+func CmdEndDebugUtilsLabelEXT(commandBuffer CommandBuffer) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+
+	if C.call_vkCmdEndDebugUtilsLabelEXT(C.VkCommandBuffer(commandBuffer)) == 0 {
+		return NewVulkanError(ErrorExtensionNotPresent, "CmdEndDebugUtilsLabelEXT", "debug utils extension not loaded - call LoadDebugUtilsFunctions first")
+	}
+	return nil
+}
+
+// CmdInsertDebugUtilsLabelEXT inserts a single named marker into a command buffer.
+// Returns an error if LoadDebugUtilsFunctions was not called.
+func CmdInsertDebugUtilsLabelEXT(commandBuffer CommandBuffer, labelName string, color [4]float32) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+
+	cLabel, cName := debugUtilsLabel(labelName, color)
+	defer C.free(unsafe.Pointer(cName))
+
+	if C.call_vkCmdInsertDebugUtilsLabelEXT(C.VkCommandBuffer(commandBuffer), &cLabel) == 0 {
+		return NewVulkanError(ErrorExtensionNotPresent, "CmdInsertDebugUtilsLabelEXT", "debug utils extension not loaded - call LoadDebugUtilsFunctions first")
+	}
+	return nil
+}
+
+// QueueBeginDebugUtilsLabelEXT opens a named region covering subsequent submissions to a queue.
+// Returns an error if LoadDebugUtilsFunctions was not called.
+func QueueBeginDebugUtilsLabelEXT(queue Queue, labelName string, color [4]float32) error {
+	if queue == nil {
+		return NewValidationError("queue", "cannot be nil")
+	}
+
+	cLabel, cName := debugUtilsLabel(labelName, color)
+	defer C.free(unsafe.Pointer(cName))
+
+	if C.call_vkQueueBeginDebugUtilsLabelEXT(C.VkQueue(queue), &cLabel) == 0 {
+		return NewVulkanError(ErrorExtensionNotPresent, "QueueBeginDebugUtilsLabelEXT", "debug utils extension not loaded - call LoadDebugUtilsFunctions first")
+	}
+	return nil
+}
+
+// QueueEndDebugUtilsLabelEXT closes the region opened by the most recent QueueBeginDebugUtilsLabelEXT.
+// Returns an error if LoadDebugUtilsFunctions was not called.
+func QueueEndDebugUtilsLabelEXT(queue Queue) error {
+	if queue == nil {
+		return NewValidationError("queue", "cannot be nil")
+	}
+
+	if C.call_vkQueueEndDebugUtilsLabelEXT(C.VkQueue(queue)) == 0 {
+		return NewVulkanError(ErrorExtensionNotPresent, "QueueEndDebugUtilsLabelEXT", "debug utils extension not loaded - call LoadDebugUtilsFunctions first")
+	}
+	return nil
+}
+
+// QueueInsertDebugUtilsLabelEXT inserts a single named marker between submissions to a queue.
+// Returns an error if LoadDebugUtilsFunctions was not called.
+func QueueInsertDebugUtilsLabelEXT(queue Queue, labelName string, color [4]float32) error {
+	if queue == nil {
+		return NewValidationError("queue", "cannot be nil")
+	}
+
+	cLabel, cName := debugUtilsLabel(labelName, color)
+	defer C.free(unsafe.Pointer(cName))
+
+	if C.call_vkQueueInsertDebugUtilsLabelEXT(C.VkQueue(queue), &cLabel) == 0 {
+		return NewVulkanError(ErrorExtensionNotPresent, "QueueInsertDebugUtilsLabelEXT", "debug utils extension not loaded - call LoadDebugUtilsFunctions first")
+	}
+	return nil
+}
+
+// SetDebugUtilsObjectNameEXT attaches a name to a Vulkan object so that validation layer
+// messages and capture tools show it instead of a raw handle. objectHandle is the handle
+// converted with uint64(uintptr(handle)). An empty name removes a previously set name.
+// Returns an error if LoadDebugUtilsFunctions was not called.
+func SetDebugUtilsObjectNameEXT(device Device, objectType ObjectType, objectHandle uint64, name string) error {
+	if device == nil {
+		return NewValidationError("device", "cannot be nil")
+	}
+	if objectType == ObjectTypeUnknown {
+		return NewValidationError("objectType", "cannot be ObjectTypeUnknown")
+	}
+	if objectHandle == 0 {
+		return NewValidationError("objectHandle", "cannot be null")
+	}
+
+	var cNameInfo C.VkDebugUtilsObjectNameInfoEXT
+	cNameInfo.sType = C.VK_STRUCTURE_TYPE_DEBUG_UTILS_OBJECT_NAME_INFO_EXT
+	cNameInfo.pNext = nil
+	cNameInfo.objectType = C.VkObjectType(objectType)
+	cNameInfo.objectHandle = C.uint64_t(objectHandle)
+	if name != "" {
+		cName := C.CString(name)
+		defer C.free(unsafe.Pointer(cName))
+		cNameInfo.pObjectName = cName
+	}
+
+	result := Result(C.call_vkSetDebugUtilsObjectNameEXT(C.VkDevice(device), &cNameInfo))
+	traceResult("vkSetDebugUtilsObjectNameEXT", result)
+	if result == ErrorExtensionNotPresent {
+		return NewVulkanError(result, "SetDebugUtilsObjectNameEXT", "debug utils extension not loaded - call LoadDebugUtilsFunctions first")
+	}
+	if result != Success {
+		return NewVulkanError(result, "SetDebugUtilsObjectNameEXT", "failed to set object name")
+	}
+	return nil
+}
+
+// SetDebugUtilsObjectTagEXT attaches arbitrary binary data to a Vulkan object, identified
+// by tagName, for consumption by layers and tools.
+// Returns an error if LoadDebugUtilsFunctions was not called.
+func SetDebugUtilsObjectTagEXT(device Device, objectType ObjectType, objectHandle uint64, tagName uint64, tag []byte) error {
+	if device == nil {
+		return NewValidationError("device", "cannot be nil")
+	}
+	if objectType == ObjectTypeUnknown {
+		return NewValidationError("objectType", "cannot be ObjectTypeUnknown")
+	}
+	if objectHandle == 0 {
+		return NewValidationError("objectHandle", "cannot be null")
+	}
+	if len(tag) == 0 {
+		return NewValidationError("tag", "cannot be empty")
+	}
+
+	cTag := C.CBytes(tag)
+	defer C.free(cTag)
+
+	var cTagInfo C.VkDebugUtilsObjectTagInfoEXT
+	cTagInfo.sType = C.VK_STRUCTURE_TYPE_DEBUG_UTILS_OBJECT_TAG_INFO_EXT
+	cTagInfo.pNext = nil
+	cTagInfo.objectType = C.VkObjectType(objectType)
+	cTagInfo.objectHandle = C.uint64_t(objectHandle)
+	cTagInfo.tagName = C.uint64_t(tagName)
+	cTagInfo.tagSize = C.size_t(len(tag))
+	cTagInfo.pTag = cTag
+
+	result := Result(C.call_vkSetDebugUtilsObjectTagEXT(C.VkDevice(device), &cTagInfo))
+	traceResult("vkSetDebugUtilsObjectTagEXT", result)
+	if result == ErrorExtensionNotPresent {
+		return NewVulkanError(result, "SetDebugUtilsObjectTagEXT", "debug utils extension not loaded - call LoadDebugUtilsFunctions first")
+	}
+	if result != Success {
+		return NewVulkanError(result, "SetDebugUtilsObjectTagEXT", "failed to set object tag")
+	}
+	return nil
+}
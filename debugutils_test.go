@@ -0,0 +1,184 @@
+//go:build cgo
+
+package vulkan
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// TestSetDebugUtilsObjectNameValidation tests input validation for object naming and tagging
+func TestSetDebugUtilsObjectNameValidation(t *testing.T) {
+	fakeDevice := Device(uintptr(0x1234))
+
+	tests := []struct {
+		name       string
+		call       func() error
+		errorParam string
+	}{
+		{
+			name:       "nil device",
+			call:       func() error { return SetDebugUtilsObjectNameEXT(nil, ObjectTypeBuffer, 0x5678, "buffer") },
+			errorParam: "device",
+		},
+		{
+			name:       "unknown object type",
+			call:       func() error { return SetDebugUtilsObjectNameEXT(fakeDevice, ObjectTypeUnknown, 0x5678, "buffer") },
+			errorParam: "objectType",
+		},
+		{
+			name:       "null object handle",
+			call:       func() error { return SetDebugUtilsObjectNameEXT(fakeDevice, ObjectTypeBuffer, 0, "buffer") },
+			errorParam: "objectHandle",
+		},
+		{
+			name:       "empty tag",
+			call:       func() error { return SetDebugUtilsObjectTagEXT(fakeDevice, ObjectTypeBuffer, 0x5678, 1, nil) },
+			errorParam: "tag",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+}
+
+// TestDebugUtilsLabels tests that the label commands reject nil handles and report
+// ErrorExtensionNotPresent while the debug utils functions are not loaded
+func TestDebugUtilsLabels(t *testing.T) {
+	fakeCommandBuffer := CommandBuffer(uintptr(0x5678))
+	fakeQueue := Queue(uintptr(0x9abc))
+	color := [4]float32{1, 0, 0, 1}
+
+	tests := []struct {
+		name       string
+		call       func() error
+		errorParam string
+	}{
+		{
+			name:       "begin command buffer label with nil command buffer",
+			call:       func() error { return CmdBeginDebugUtilsLabelEXT(nil, "pass", color) },
+			errorParam: "commandBuffer",
+		},
+		{
+			name:       "end command buffer label with nil command buffer",
+			call:       func() error { return CmdEndDebugUtilsLabelEXT(nil) },
+			errorParam: "commandBuffer",
+		},
+		{
+			name:       "insert command buffer label with nil command buffer",
+			call:       func() error { return CmdInsertDebugUtilsLabelEXT(nil, "marker", color) },
+			errorParam: "commandBuffer",
+		},
+		{
+			name:       "begin queue label with nil queue",
+			call:       func() error { return QueueBeginDebugUtilsLabelEXT(nil, "frame", color) },
+			errorParam: "queue",
+		},
+		{
+			name:       "end queue label with nil queue",
+			call:       func() error { return QueueEndDebugUtilsLabelEXT(nil) },
+			errorParam: "queue",
+		},
+		{
+			name:       "insert queue label with nil queue",
+			call:       func() error { return QueueInsertDebugUtilsLabelEXT(nil, "marker", color) },
+			errorParam: "queue",
+		},
+		{
+			name: "begin command buffer label without loaded functions",
+			call: func() error { return CmdBeginDebugUtilsLabelEXT(fakeCommandBuffer, "pass", color) },
+		},
+		{
+			name: "end command buffer label without loaded functions",
+			call: func() error { return CmdEndDebugUtilsLabelEXT(fakeCommandBuffer) },
+		},
+		{
+			name: "insert command buffer label without loaded functions",
+			call: func() error { return CmdInsertDebugUtilsLabelEXT(fakeCommandBuffer, "marker", color) },
+		},
+		{
+			name: "begin queue label without loaded functions",
+			call: func() error { return QueueBeginDebugUtilsLabelEXT(fakeQueue, "frame", color) },
+		},
+		{
+			name: "end queue label without loaded functions",
+			call: func() error { return QueueEndDebugUtilsLabelEXT(fakeQueue) },
+		},
+		{
+			name: "insert queue label without loaded functions",
+			call: func() error { return QueueInsertDebugUtilsLabelEXT(fakeQueue, "marker", color) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+
+			if tt.errorParam == "" {
+				var vkErr *VulkanError
+				if !errors.As(err, &vkErr) || vkErr.Result != ErrorExtensionNotPresent {
+					t.Fatalf("Expected ErrorExtensionNotPresent, got %v", err)
+				}
+				return
+			}
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+}
+
+// TestLoadDebugUtilsFunctionsConcurrent tests that concurrent loads for the same instance
+// resolve the function pointers exactly once and agree on the result
+func TestLoadDebugUtilsFunctionsConcurrent(t *testing.T) {
+	fakeInstance := Instance(uintptr(0x4321))
+	// Reset the cached instance so a later instance reusing this handle value starts clean
+	t.Cleanup(func() { LoadDebugUtilsFunctions(nil) })
+
+	var loads atomic.Int32
+	origLoad := loadDebugUtilsInstance
+	loadDebugUtilsInstance = func(instance Instance) bool {
+		loads.Add(1)
+		return false
+	}
+	t.Cleanup(func() { loadDebugUtilsInstance = origLoad })
+
+	const goroutines = 16
+	results := make([]bool, goroutines)
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = LoadDebugUtilsFunctions(fakeInstance)
+		}(i)
+	}
+	wg.Wait()
+
+	if got := loads.Load(); got != 1 {
+		t.Errorf("Expected debug utils functions to be resolved once, got %d", got)
+	}
+	for i, loaded := range results {
+		if loaded {
+			t.Errorf("Expected goroutine %d to get the cached failure, got true", i)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package vulkan
+
+/*
+#include <vulkan/vulkan.h>
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// DescriptorBindingFlags control how the descriptors of a binding may be updated and used
+// (descriptor indexing, core in Vulkan 1.2)
+type DescriptorBindingFlags uint32
+
+const (
+	// DescriptorBindingUpdateAfterBindBit allows updating descriptors after the set is bound;
+	// the layout needs DescriptorSetLayoutCreateUpdateAfterBindPoolBit and the pool
+	// DescriptorPoolCreateUpdateAfterBindBit
+	DescriptorBindingUpdateAfterBindBit DescriptorBindingFlags = C.VK_DESCRIPTOR_BINDING_UPDATE_AFTER_BIND_BIT
+	// DescriptorBindingUpdateUnusedWhilePendingBit allows updating descriptors the pending
+	// command buffers do not use
+	DescriptorBindingUpdateUnusedWhilePendingBit DescriptorBindingFlags = C.VK_DESCRIPTOR_BINDING_UPDATE_UNUSED_WHILE_PENDING_BIT
+	// DescriptorBindingPartiallyBoundBit allows descriptors that shaders never access to stay unwritten
+	DescriptorBindingPartiallyBoundBit DescriptorBindingFlags = C.VK_DESCRIPTOR_BINDING_PARTIALLY_BOUND_BIT
+	// DescriptorBindingVariableDescriptorCountBit makes DescriptorCount an upper bound, with the
+	// actual count chosen per set in DescriptorSetAllocateInfo.VariableDescriptorCounts. Only
+	// the binding with the highest binding number may use it.
+	DescriptorBindingVariableDescriptorCountBit DescriptorBindingFlags = C.VK_DESCRIPTOR_BINDING_VARIABLE_DESCRIPTOR_COUNT_BIT
+)
+
+// PhysicalDeviceDescriptorIndexingFeatures contains descriptor indexing features
+type PhysicalDeviceDescriptorIndexingFeatures struct {
+	ShaderInputAttachmentArrayDynamicIndexing          bool
+	ShaderUniformTexelBufferArrayDynamicIndexing       bool
+	ShaderStorageTexelBufferArrayDynamicIndexing       bool
+	ShaderUniformBufferArrayNonUniformIndexing         bool
+	ShaderSampledImageArrayNonUniformIndexing          bool
+	ShaderStorageBufferArrayNonUniformIndexing         bool
+	ShaderStorageImageArrayNonUniformIndexing          bool
+	ShaderInputAttachmentArrayNonUniformIndexing       bool
+	ShaderUniformTexelBufferArrayNonUniformIndexing    bool
+	ShaderStorageTexelBufferArrayNonUniformIndexing    bool
+	DescriptorBindingUniformBufferUpdateAfterBind      bool
+	DescriptorBindingSampledImageUpdateAfterBind       bool
+	DescriptorBindingStorageImageUpdateAfterBind       bool
+	DescriptorBindingStorageBufferUpdateAfterBind      bool
+	DescriptorBindingUniformTexelBufferUpdateAfterBind bool
+	DescriptorBindingStorageTexelBufferUpdateAfterBind bool
+	DescriptorBindingUpdateUnusedWhilePending          bool
+	DescriptorBindingPartiallyBound                    bool
+	DescriptorBindingVariableDescriptorCount           bool
+	RuntimeDescriptorArray                             bool
+}
+
+// descriptorIndexingFeaturesToC prepends a struct enabling the requested descriptor indexing
+// features to the pNext chain next. The struct is allocated in C memory and appended to
+// allocations, which the caller must free.
+func descriptorIndexingFeaturesToC(features *PhysicalDeviceDescriptorIndexingFeatures, next unsafe.Pointer, allocations *[]unsafe.Pointer) (unsafe.Pointer, error) {
+	cIndexing := (*C.VkPhysicalDeviceDescriptorIndexingFeatures)(C.calloc(1, C.sizeof_VkPhysicalDeviceDescriptorIndexingFeatures))
+	if cIndexing == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "CreateDevice", "failed to allocate memory for descriptor indexing features")
+	}
+	*allocations = append(*allocations, unsafe.Pointer(cIndexing))
+
+	cIndexing.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_DESCRIPTOR_INDEXING_FEATURES
+	cIndexing.pNext = next
+	cIndexing.shaderInputAttachmentArrayDynamicIndexing = boolToVkBool32(features.ShaderInputAttachmentArrayDynamicIndexing)
+	cIndexing.shaderUniformTexelBufferArrayDynamicIndexing = boolToVkBool32(features.ShaderUniformTexelBufferArrayDynamicIndexing)
+	cIndexing.shaderStorageTexelBufferArrayDynamicIndexing = boolToVkBool32(features.ShaderStorageTexelBufferArrayDynamicIndexing)
+	cIndexing.shaderUniformBufferArrayNonUniformIndexing = boolToVkBool32(features.ShaderUniformBufferArrayNonUniformIndexing)
+	cIndexing.shaderSampledImageArrayNonUniformIndexing = boolToVkBool32(features.ShaderSampledImageArrayNonUniformIndexing)
+	cIndexing.shaderStorageBufferArrayNonUniformIndexing = boolToVkBool32(features.ShaderStorageBufferArrayNonUniformIndexing)
+	cIndexing.shaderStorageImageArrayNonUniformIndexing = boolToVkBool32(features.ShaderStorageImageArrayNonUniformIndexing)
+	cIndexing.shaderInputAttachmentArrayNonUniformIndexing = boolToVkBool32(features.ShaderInputAttachmentArrayNonUniformIndexing)
+	cIndexing.shaderUniformTexelBufferArrayNonUniformIndexing = boolToVkBool32(features.ShaderUniformTexelBufferArrayNonUniformIndexing)
+	cIndexing.shaderStorageTexelBufferArrayNonUniformIndexing = boolToVkBool32(features.ShaderStorageTexelBufferArrayNonUniformIndexing)
+	cIndexing.descriptorBindingUniformBufferUpdateAfterBind = boolToVkBool32(features.DescriptorBindingUniformBufferUpdateAfterBind)
+	cIndexing.descriptorBindingSampledImageUpdateAfterBind = boolToVkBool32(features.DescriptorBindingSampledImageUpdateAfterBind)
+	cIndexing.descriptorBindingStorageImageUpdateAfterBind = boolToVkBool32(features.DescriptorBindingStorageImageUpdateAfterBind)
+	cIndexing.descriptorBindingStorageBufferUpdateAfterBind = boolToVkBool32(features.DescriptorBindingStorageBufferUpdateAfterBind)
+	cIndexing.descriptorBindingUniformTexelBufferUpdateAfterBind = boolToVkBool32(features.DescriptorBindingUniformTexelBufferUpdateAfterBind)
+	cIndexing.descriptorBindingStorageTexelBufferUpdateAfterBind = boolToVkBool32(features.DescriptorBindingStorageTexelBufferUpdateAfterBind)
+	cIndexing.descriptorBindingUpdateUnusedWhilePending = boolToVkBool32(features.DescriptorBindingUpdateUnusedWhilePending)
+	cIndexing.descriptorBindingPartiallyBound = boolToVkBool32(features.DescriptorBindingPartiallyBound)
+	cIndexing.descriptorBindingVariableDescriptorCount = boolToVkBool32(features.DescriptorBindingVariableDescriptorCount)
+	cIndexing.runtimeDescriptorArray = boolToVkBool32(features.RuntimeDescriptorArray)
+
+	return unsafe.Pointer(cIndexing), nil
+}
+
+// GetPhysicalDeviceDescriptorIndexingFeatures queries descriptor indexing feature support
+func GetPhysicalDeviceDescriptorIndexingFeatures(physicalDevice PhysicalDevice) PhysicalDeviceDescriptorIndexingFeatures {
+	cFeatures2 := (*C.VkPhysicalDeviceFeatures2)(C.calloc(1, C.sizeof_VkPhysicalDeviceFeatures2))
+	cIndexing := (*C.VkPhysicalDeviceDescriptorIndexingFeatures)(C.calloc(1, C.sizeof_VkPhysicalDeviceDescriptorIndexingFeatures))
+	defer C.free(unsafe.Pointer(cFeatures2))
+	defer C.free(unsafe.Pointer(cIndexing))
+	if cFeatures2 == nil || cIndexing == nil {
+		return PhysicalDeviceDescriptorIndexingFeatures{}
+	}
+
+	cFeatures2.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_FEATURES_2
+	cFeatures2.pNext = unsafe.Pointer(cIndexing)
+	cIndexing.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_DESCRIPTOR_INDEXING_FEATURES
+
+	C.vkGetPhysicalDeviceFeatures2(C.VkPhysicalDevice(physicalDevice), cFeatures2)
+
+	return PhysicalDeviceDescriptorIndexingFeatures{
+		ShaderInputAttachmentArrayDynamicIndexing:          vkBool32ToBool(cIndexing.shaderInputAttachmentArrayDynamicIndexing),
+		ShaderUniformTexelBufferArrayDynamicIndexing:       vkBool32ToBool(cIndexing.shaderUniformTexelBufferArrayDynamicIndexing),
+		ShaderStorageTexelBufferArrayDynamicIndexing:       vkBool32ToBool(cIndexing.shaderStorageTexelBufferArrayDynamicIndexing),
+		ShaderUniformBufferArrayNonUniformIndexing:         vkBool32ToBool(cIndexing.shaderUniformBufferArrayNonUniformIndexing),
+		ShaderSampledImageArrayNonUniformIndexing:          vkBool32ToBool(cIndexing.shaderSampledImageArrayNonUniformIndexing),
+		ShaderStorageBufferArrayNonUniformIndexing:         vkBool32ToBool(cIndexing.shaderStorageBufferArrayNonUniformIndexing),
+		ShaderStorageImageArrayNonUniformIndexing:          vkBool32ToBool(cIndexing.shaderStorageImageArrayNonUniformIndexing),
+		ShaderInputAttachmentArrayNonUniformIndexing:       vkBool32ToBool(cIndexing.shaderInputAttachmentArrayNonUniformIndexing),
+		ShaderUniformTexelBufferArrayNonUniformIndexing:    vkBool32ToBool(cIndexing.shaderUniformTexelBufferArrayNonUniformIndexing),
+		ShaderStorageTexelBufferArrayNonUniformIndexing:    vkBool32ToBool(cIndexing.shaderStorageTexelBufferArrayNonUniformIndexing),
+		DescriptorBindingUniformBufferUpdateAfterBind:      vkBool32ToBool(cIndexing.descriptorBindingUniformBufferUpdateAfterBind),
+		DescriptorBindingSampledImageUpdateAfterBind:       vkBool32ToBool(cIndexing.descriptorBindingSampledImageUpdateAfterBind),
+		DescriptorBindingStorageImageUpdateAfterBind:       vkBool32ToBool(cIndexing.descriptorBindingStorageImageUpdateAfterBind),
+		DescriptorBindingStorageBufferUpdateAfterBind:      vkBool32ToBool(cIndexing.descriptorBindingStorageBufferUpdateAfterBind),
+		DescriptorBindingUniformTexelBufferUpdateAfterBind: vkBool32ToBool(cIndexing.descriptorBindingUniformTexelBufferUpdateAfterBind),
+		DescriptorBindingStorageTexelBufferUpdateAfterBind: vkBool32ToBool(cIndexing.descriptorBindingStorageTexelBufferUpdateAfterBind),
+		DescriptorBindingUpdateUnusedWhilePending:          vkBool32ToBool(cIndexing.descriptorBindingUpdateUnusedWhilePending),
+		DescriptorBindingPartiallyBound:                    vkBool32ToBool(cIndexing.descriptorBindingPartiallyBound),
+		DescriptorBindingVariableDescriptorCount:           vkBool32ToBool(cIndexing.descriptorBindingVariableDescriptorCount),
+		RuntimeDescriptorArray:                             vkBool32ToBool(cIndexing.runtimeDescriptorArray),
+	}
+}
+
+// validateBindingFlags checks DescriptorSetLayoutCreateInfo.BindingFlags against the bindings
+func validateBindingFlags(createInfo *DescriptorSetLayoutCreateInfo) error {
+	if len(createInfo.BindingFlags) == 0 {
+		return nil
+	}
+	if len(createInfo.BindingFlags) != len(createInfo.Bindings) {
+		return NewValidationError("createInfo.BindingFlags", fmt.Sprintf("must have one entry per binding (%d), got %d", len(createInfo.Bindings), len(createInfo.BindingFlags)))
+	}
+
+	for i, flags := range createInfo.BindingFlags {
+		if flags&DescriptorBindingUpdateAfterBindBit != 0 && createInfo.Flags&DescriptorSetLayoutCreateUpdateAfterBindPoolBit == 0 {
+			return NewValidationError(fmt.Sprintf("createInfo.BindingFlags[%d]", i), "update after bind requires DescriptorSetLayoutCreateUpdateAfterBindPoolBit")
+		}
+		if flags&DescriptorBindingVariableDescriptorCountBit != 0 {
+			for _, other := range createInfo.Bindings {
+				if other.Binding > createInfo.Bindings[i].Binding {
+					return NewValidationError(fmt.Sprintf("createInfo.BindingFlags[%d]", i), "variable descriptor count is only allowed on the binding with the highest binding number")
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// bindingFlagsToC creates a VkDescriptorSetLayoutBindingFlagsCreateInfo in C memory. The
+// struct and its flags array are appended to allocations, which the caller must free.
+func bindingFlagsToC(bindingFlags []DescriptorBindingFlags, allocations *[]unsafe.Pointer) (unsafe.Pointer, error) {
+	cInfo := (*C.VkDescriptorSetLayoutBindingFlagsCreateInfo)(C.calloc(1, C.sizeof_VkDescriptorSetLayoutBindingFlagsCreateInfo))
+	if cInfo == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "CreateDescriptorSetLayout", "failed to allocate memory for binding flags info")
+	}
+	*allocations = append(*allocations, unsafe.Pointer(cInfo))
+
+	cFlagsPtr := (*C.VkDescriptorBindingFlags)(C.calloc(C.size_t(len(bindingFlags)), C.size_t(unsafe.Sizeof(C.VkDescriptorBindingFlags(0)))))
+	if cFlagsPtr == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "CreateDescriptorSetLayout", "failed to allocate memory for binding flags")
+	}
+	*allocations = append(*allocations, unsafe.Pointer(cFlagsPtr))
+
+	cFlags := unsafe.Slice(cFlagsPtr, len(bindingFlags))
+	for i, flags := range bindingFlags {
+		cFlags[i] = C.VkDescriptorBindingFlags(flags)
+	}
+
+	cInfo.sType = C.VK_STRUCTURE_TYPE_DESCRIPTOR_SET_LAYOUT_BINDING_FLAGS_CREATE_INFO
+	cInfo.bindingCount = C.uint32_t(len(bindingFlags))
+	cInfo.pBindingFlags = cFlagsPtr
+	return unsafe.Pointer(cInfo), nil
+}
+
+// variableDescriptorCountsToC creates a VkDescriptorSetVariableDescriptorCountAllocateInfo in
+// C memory. The struct and its counts array are appended to allocations, which the caller must free.
+func variableDescriptorCountsToC(counts []uint32, allocations *[]unsafe.Pointer) (unsafe.Pointer, error) {
+	cInfo := (*C.VkDescriptorSetVariableDescriptorCountAllocateInfo)(C.calloc(1, C.sizeof_VkDescriptorSetVariableDescriptorCountAllocateInfo))
+	if cInfo == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "AllocateDescriptorSets", "failed to allocate memory for variable descriptor count info")
+	}
+	*allocations = append(*allocations, unsafe.Pointer(cInfo))
+
+	cCountsPtr := (*C.uint32_t)(C.calloc(C.size_t(len(counts)), C.size_t(unsafe.Sizeof(C.uint32_t(0)))))
+	if cCountsPtr == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "AllocateDescriptorSets", "failed to allocate memory for variable descriptor counts")
+	}
+	*allocations = append(*allocations, unsafe.Pointer(cCountsPtr))
+
+	cCounts := unsafe.Slice(cCountsPtr, len(counts))
+	for i, count := range counts {
+		cCounts[i] = C.uint32_t(count)
+	}
+
+	cInfo.sType = C.VK_STRUCTURE_TYPE_DESCRIPTOR_SET_VARIABLE_DESCRIPTOR_COUNT_ALLOCATE_INFO
+	cInfo.descriptorSetCount = C.uint32_t(len(counts))
+	cInfo.pDescriptorCounts = cCountsPtr
+	return unsafe.Pointer(cInfo), nil
+}
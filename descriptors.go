@@ -2,9 +2,16 @@ package vulkan
 
 /*
 #include <vulkan/vulkan.h>
+#include <stdlib.h>
 */
 import "C"
 
+import (
+	"fmt"
+	"sync"
+	"unsafe"
+)
+
 // ImageViewCreateInfo contains image view creation information
 type ImageViewCreateInfo struct {
 	Image            Image
@@ -26,6 +33,27 @@ const (
 	ImageViewTypeCubeArray ImageViewType = C.VK_IMAGE_VIEW_TYPE_CUBE_ARRAY
 )
 
+// ComponentSwizzle specifies how a component of an image view is sourced
+type ComponentSwizzle int32
+
+const (
+	ComponentSwizzleIdentity ComponentSwizzle = C.VK_COMPONENT_SWIZZLE_IDENTITY
+	ComponentSwizzleZero     ComponentSwizzle = C.VK_COMPONENT_SWIZZLE_ZERO
+	ComponentSwizzleOne      ComponentSwizzle = C.VK_COMPONENT_SWIZZLE_ONE
+	ComponentSwizzleR        ComponentSwizzle = C.VK_COMPONENT_SWIZZLE_R
+	ComponentSwizzleG        ComponentSwizzle = C.VK_COMPONENT_SWIZZLE_G
+	ComponentSwizzleB        ComponentSwizzle = C.VK_COMPONENT_SWIZZLE_B
+	ComponentSwizzleA        ComponentSwizzle = C.VK_COMPONENT_SWIZZLE_A
+)
+
+// ComponentMapping describes the swizzle of each color component
+type ComponentMapping struct {
+	R ComponentSwizzle
+	G ComponentSwizzle
+	B ComponentSwizzle
+	A ComponentSwizzle
+}
+
 // ImageSubresourceRange describes an image subresource range
 type ImageSubresourceRange struct {
 	AspectMask     ImageAspectFlags
@@ -74,9 +102,21 @@ const (
 
 // DescriptorSetLayoutCreateInfo contains descriptor set layout creation information
 type DescriptorSetLayoutCreateInfo struct {
+	Flags    DescriptorSetLayoutCreateFlags
 	Bindings []DescriptorSetLayoutBinding
+	// BindingFlags optionally holds descriptor indexing flags, one entry per element of
+	// Bindings. Requires the matching PhysicalDeviceDescriptorIndexingFeatures.
+	BindingFlags []DescriptorBindingFlags
 }
 
+// DescriptorSetLayoutCreateFlags represents descriptor set layout creation flags
+type DescriptorSetLayoutCreateFlags uint32
+
+const (
+	DescriptorSetLayoutCreateUpdateAfterBindPoolBit DescriptorSetLayoutCreateFlags = C.VK_DESCRIPTOR_SET_LAYOUT_CREATE_UPDATE_AFTER_BIND_POOL_BIT
+	DescriptorSetLayoutCreatePushDescriptorBitKHR   DescriptorSetLayoutCreateFlags = C.VK_DESCRIPTOR_SET_LAYOUT_CREATE_PUSH_DESCRIPTOR_BIT_KHR
+)
+
 // DescriptorSetLayoutBinding describes a descriptor set layout binding
 type DescriptorSetLayoutBinding struct {
 	Binding         uint32
@@ -102,12 +142,59 @@ const (
 	DescriptorTypeInputAttachment      DescriptorType = C.VK_DESCRIPTOR_TYPE_INPUT_ATTACHMENT
 )
 
+// DescriptorImageInfo describes an image descriptor
+type DescriptorImageInfo struct {
+	Sampler     Sampler
+	ImageView   ImageView
+	ImageLayout ImageLayout
+}
+
+// DescriptorBufferInfo describes a buffer descriptor
+type DescriptorBufferInfo struct {
+	Buffer Buffer
+	Offset DeviceSize
+	Range  DeviceSize
+}
+
+// WriteDescriptorSet describes a descriptor write. Exactly one of ImageInfo, BufferInfo
+// or TexelBufferViews must be set, matching DescriptorType; its length is the descriptor count.
+type WriteDescriptorSet struct {
+	DstSet           DescriptorSet
+	DstBinding       uint32
+	DstArrayElement  uint32
+	DescriptorType   DescriptorType
+	ImageInfo        []DescriptorImageInfo
+	BufferInfo       []DescriptorBufferInfo
+	TexelBufferViews []BufferView
+}
+
 // DescriptorPoolCreateInfo contains descriptor pool creation information
 type DescriptorPoolCreateInfo struct {
+	Flags     DescriptorPoolCreateFlags
 	MaxSets   uint32
 	PoolSizes []DescriptorPoolSize
 }
 
+// DescriptorPoolCreateFlags represents descriptor pool creation flags
+type DescriptorPoolCreateFlags uint32
+
+const (
+	DescriptorPoolCreateFreeDescriptorSetBit DescriptorPoolCreateFlags = C.VK_DESCRIPTOR_POOL_CREATE_FREE_DESCRIPTOR_SET_BIT
+	// DescriptorPoolCreateUpdateAfterBindBit is required to allocate sets whose layout uses
+	// DescriptorSetLayoutCreateUpdateAfterBindPoolBit
+	DescriptorPoolCreateUpdateAfterBindBit DescriptorPoolCreateFlags = C.VK_DESCRIPTOR_POOL_CREATE_UPDATE_AFTER_BIND_BIT
+)
+
+// DescriptorSetAllocateInfo contains descriptor set allocation information. One set is
+// allocated per layout.
+type DescriptorSetAllocateInfo struct {
+	DescriptorPool DescriptorPool
+	SetLayouts     []DescriptorSetLayout
+	// VariableDescriptorCounts optionally holds, per set, the descriptor count of the layout's
+	// DescriptorBindingVariableDescriptorCountBit binding. Empty means the maximum count.
+	VariableDescriptorCounts []uint32
+}
+
 // DescriptorPoolSize describes a descriptor pool size
 type DescriptorPoolSize struct {
 	Type            DescriptorType
@@ -139,6 +226,7 @@ func CreateImageView(device Device, createInfo *ImageViewCreateInfo) (ImageView,
 
 	var imageView C.VkImageView
 	result := Result(C.vkCreateImageView(C.VkDevice(device), &cCreateInfo, nil, &imageView))
+	traceResult("vkCreateImageView", result)
 	if result != Success {
 		return nil, result
 	}
@@ -151,6 +239,59 @@ func DestroyImageView(device Device, imageView ImageView) {
 	C.vkDestroyImageView(C.VkDevice(device), C.VkImageView(imageView), nil)
 }
 
+// BufferViewCreateInfo contains buffer view creation information. Range is in bytes and
+// may be WholeSize to view the rest of the buffer after Offset.
+type BufferViewCreateInfo struct {
+	Buffer Buffer
+	Format Format
+	Offset DeviceSize
+	Range  DeviceSize
+}
+
+// CreateBufferView creates a buffer view for use as a uniform or storage texel buffer. The buffer
+// must have been created with BufferUsageUniformTexelBufferBit or BufferUsageStorageTexelBufferBit,
+// and Offset must be a multiple of PhysicalDeviceLimits.MinTexelBufferOffsetAlignment.
+func CreateBufferView(device Device, createInfo *BufferViewCreateInfo) (BufferView, error) {
+	if device == nil {
+		return nil, NewValidationError("device", "cannot be nil")
+	}
+	if createInfo == nil {
+		return nil, NewValidationError("createInfo", "cannot be nil")
+	}
+	if createInfo.Buffer == nil {
+		return nil, NewValidationError("createInfo.Buffer", "cannot be nil")
+	}
+	if createInfo.Format == FormatUndefined {
+		return nil, NewValidationError("createInfo.Format", "cannot be FormatUndefined")
+	}
+	if createInfo.Range == 0 {
+		return nil, NewValidationError("createInfo.Range", "must be greater than 0")
+	}
+
+	var cCreateInfo C.VkBufferViewCreateInfo
+	cCreateInfo.sType = C.VK_STRUCTURE_TYPE_BUFFER_VIEW_CREATE_INFO
+	cCreateInfo.pNext = nil
+	cCreateInfo.flags = 0
+	cCreateInfo.buffer = C.VkBuffer(createInfo.Buffer)
+	cCreateInfo.format = C.VkFormat(createInfo.Format)
+	cCreateInfo.offset = C.VkDeviceSize(createInfo.Offset)
+	cCreateInfo._range = C.VkDeviceSize(createInfo.Range)
+
+	var bufferView C.VkBufferView
+	result := Result(C.vkCreateBufferView(C.VkDevice(device), &cCreateInfo, nil, &bufferView))
+	traceResult("vkCreateBufferView", result)
+	if result != Success {
+		return nil, NewVulkanError(result, "CreateBufferView", "failed to create buffer view")
+	}
+
+	return BufferView(bufferView), nil
+}
+
+// DestroyBufferView destroys a buffer view
+func DestroyBufferView(device Device, bufferView BufferView) {
+	C.vkDestroyBufferView(C.VkDevice(device), C.VkBufferView(bufferView), nil)
+}
+
 // CreateSampler creates a sampler
 func CreateSampler(device Device, createInfo *SamplerCreateInfo) (Sampler, error) {
 	var cCreateInfo C.VkSamplerCreateInfo
@@ -175,6 +316,7 @@ func CreateSampler(device Device, createInfo *SamplerCreateInfo) (Sampler, error
 
 	var sampler C.VkSampler
 	result := Result(C.vkCreateSampler(C.VkDevice(device), &cCreateInfo, nil, &sampler))
+	traceResult("vkCreateSampler", result)
 	if result != Success {
 		return nil, result
 	}
@@ -189,10 +331,25 @@ func DestroySampler(device Device, sampler Sampler) {
 
 // CreateDescriptorSetLayout creates a descriptor set layout
 func CreateDescriptorSetLayout(device Device, createInfo *DescriptorSetLayoutCreateInfo) (DescriptorSetLayout, error) {
+	if err := validateBindingFlags(createInfo); err != nil {
+		return nil, err
+	}
+
 	var cCreateInfo C.VkDescriptorSetLayoutCreateInfo
 	cCreateInfo.sType = C.VK_STRUCTURE_TYPE_DESCRIPTOR_SET_LAYOUT_CREATE_INFO
 	cCreateInfo.pNext = nil
-	cCreateInfo.flags = 0
+	cCreateInfo.flags = C.VkDescriptorSetLayoutCreateFlags(createInfo.Flags)
+
+	var allocations []unsafe.Pointer
+	defer func() { freeAllocations(allocations) }()
+
+	if len(createInfo.BindingFlags) > 0 {
+		pNext, err := bindingFlagsToC(createInfo.BindingFlags, &allocations)
+		if err != nil {
+			return nil, err
+		}
+		cCreateInfo.pNext = pNext
+	}
 
 	var cBindings []C.VkDescriptorSetLayoutBinding
 	if len(createInfo.Bindings) > 0 {
@@ -210,16 +367,160 @@ func CreateDescriptorSetLayout(device Device, createInfo *DescriptorSetLayoutCre
 
 	var layout C.VkDescriptorSetLayout
 	result := Result(C.vkCreateDescriptorSetLayout(C.VkDevice(device), &cCreateInfo, nil, &layout))
+	traceResult("vkCreateDescriptorSetLayout", result)
 	if result != Success {
 		return nil, result
 	}
 
+	var dynamicCount uint32
+	for _, binding := range createInfo.Bindings {
+		if binding.DescriptorType == DescriptorTypeUniformBufferDynamic || binding.DescriptorType == DescriptorTypeStorageBufferDynamic {
+			dynamicCount += binding.DescriptorCount
+		}
+	}
+	dynamicDescriptors.addLayout(device, DescriptorSetLayout(layout), dynamicCount)
+
 	return DescriptorSetLayout(layout), nil
 }
 
 // DestroyDescriptorSetLayout destroys a descriptor set layout
 func DestroyDescriptorSetLayout(device Device, layout DescriptorSetLayout) {
 	C.vkDestroyDescriptorSetLayout(C.VkDevice(device), C.VkDescriptorSetLayout(layout), nil)
+	dynamicDescriptors.removeLayout(device, layout)
+}
+
+// descriptorSetKey identifies a descriptor set. Handles are only unique per device, so the
+// device is part of the key.
+type descriptorSetKey struct {
+	device Device
+	set    DescriptorSet
+}
+
+// descriptorSetOwner records the pool a descriptor set was allocated from and the number of
+// dynamic offsets binding it takes
+type descriptorSetOwner struct {
+	pool  DescriptorPool
+	count uint32
+}
+
+// dynamicDescriptorRegistry records the number of dynamic uniform and storage buffer
+// descriptors of layouts created through CreateDescriptorSetLayout and of sets allocated
+// through AllocateDescriptorSets, so CmdBindDescriptorSets can check its dynamic offsets.
+// Drivers recycle handles, so entries are dropped when the sets are freed, their pool is
+// reset or destroyed, the layout is destroyed or the device is destroyed.
+type dynamicDescriptorRegistry struct {
+	mu      sync.RWMutex
+	layouts map[Device]map[DescriptorSetLayout]uint32
+	owners  map[descriptorSetKey]descriptorSetOwner
+	pools   map[Device]map[DescriptorPool]map[DescriptorSet]struct{}
+}
+
+var dynamicDescriptors = dynamicDescriptorRegistry{
+	layouts: make(map[Device]map[DescriptorSetLayout]uint32),
+	owners:  make(map[descriptorSetKey]descriptorSetOwner),
+	pools:   make(map[Device]map[DescriptorPool]map[DescriptorSet]struct{}),
+}
+
+// addLayout records the dynamic descriptor count of a layout created on device
+func (r *dynamicDescriptorRegistry) addLayout(device Device, layout DescriptorSetLayout, count uint32) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	layouts, ok := r.layouts[device]
+	if !ok {
+		layouts = make(map[DescriptorSetLayout]uint32)
+		r.layouts[device] = layouts
+	}
+	layouts[layout] = count
+}
+
+// removeLayout forgets a destroyed layout. Sets allocated with it keep their count.
+func (r *dynamicDescriptorRegistry) removeLayout(device Device, layout DescriptorSetLayout) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.layouts[device], layout)
+}
+
+// layoutCount returns the dynamic descriptor count of layout, or false if it was not created
+// through CreateDescriptorSetLayout
+func (r *dynamicDescriptorRegistry) layoutCount(device Device, layout DescriptorSetLayout) (uint32, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count, ok := r.layouts[device][layout]
+	return count, ok
+}
+
+// addSet records a set allocated from pool on device
+func (r *dynamicDescriptorRegistry) addSet(device Device, pool DescriptorPool, set DescriptorSet, count uint32) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	pools, ok := r.pools[device]
+	if !ok {
+		pools = make(map[DescriptorPool]map[DescriptorSet]struct{})
+		r.pools[device] = pools
+	}
+	sets, ok := pools[pool]
+	if !ok {
+		sets = make(map[DescriptorSet]struct{})
+		pools[pool] = sets
+	}
+	r.owners[descriptorSetKey{device: device, set: set}] = descriptorSetOwner{pool: pool, count: count}
+	sets[set] = struct{}{}
+}
+
+// removeSets forgets sets freed on device
+func (r *dynamicDescriptorRegistry) removeSets(device Device, sets []DescriptorSet) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, set := range sets {
+		key := descriptorSetKey{device: device, set: set}
+		owner, ok := r.owners[key]
+		if !ok {
+			continue
+		}
+		delete(r.owners, key)
+		delete(r.pools[device][owner.pool], set)
+	}
+}
+
+// removePool forgets every set allocated from pool on device
+func (r *dynamicDescriptorRegistry) removePool(device Device, pool DescriptorPool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	pools := r.pools[device]
+	for set := range pools[pool] {
+		delete(r.owners, descriptorSetKey{device: device, set: set})
+	}
+	delete(pools, pool)
+}
+
+// removeDevice forgets every layout and set created on device
+func (r *dynamicDescriptorRegistry) removeDevice(device Device) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, sets := range r.pools[device] {
+		for set := range sets {
+			delete(r.owners, descriptorSetKey{device: device, set: set})
+		}
+	}
+	delete(r.pools, device)
+	delete(r.layouts, device)
+}
+
+// setCount returns the number of dynamic offsets binding set of device takes, or false if it
+// was not allocated through AllocateDescriptorSets or has since been freed
+func (r *dynamicDescriptorRegistry) setCount(device Device, set DescriptorSet) (uint32, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	owner, ok := r.owners[descriptorSetKey{device: device, set: set}]
+	return owner.count, ok
 }
 
 // CreateDescriptorPool creates a descriptor pool
@@ -227,7 +528,7 @@ func CreateDescriptorPool(device Device, createInfo *DescriptorPoolCreateInfo) (
 	var cCreateInfo C.VkDescriptorPoolCreateInfo
 	cCreateInfo.sType = C.VK_STRUCTURE_TYPE_DESCRIPTOR_POOL_CREATE_INFO
 	cCreateInfo.pNext = nil
-	cCreateInfo.flags = 0
+	cCreateInfo.flags = C.VkDescriptorPoolCreateFlags(createInfo.Flags)
 	cCreateInfo.maxSets = C.uint32_t(createInfo.MaxSets)
 
 	var cPoolSizes []C.VkDescriptorPoolSize
@@ -243,6 +544,7 @@ func CreateDescriptorPool(device Device, createInfo *DescriptorPoolCreateInfo) (
 
 	var pool C.VkDescriptorPool
 	result := Result(C.vkCreateDescriptorPool(C.VkDevice(device), &cCreateInfo, nil, &pool))
+	traceResult("vkCreateDescriptorPool", result)
 	if result != Success {
 		return nil, result
 	}
@@ -253,4 +555,205 @@ func CreateDescriptorPool(device Device, createInfo *DescriptorPoolCreateInfo) (
 // DestroyDescriptorPool destroys a descriptor pool
 func DestroyDescriptorPool(device Device, pool DescriptorPool) {
 	C.vkDestroyDescriptorPool(C.VkDevice(device), C.VkDescriptorPool(pool), nil)
+	dynamicDescriptors.removePool(device, pool)
+}
+
+// ResetDescriptorPool returns every descriptor set allocated from a pool to it
+func ResetDescriptorPool(device Device, pool DescriptorPool) error {
+	if device == nil {
+		return NewValidationError("device", "cannot be nil")
+	}
+	if pool == nil {
+		return NewValidationError("pool", "cannot be nil")
+	}
+
+	result := Result(C.vkResetDescriptorPool(C.VkDevice(device), C.VkDescriptorPool(pool), 0))
+	traceResult("vkResetDescriptorPool", result)
+	if result != Success {
+		return NewVulkanError(result, "ResetDescriptorPool", "failed to reset descriptor pool")
+	}
+	dynamicDescriptors.removePool(device, pool)
+	return nil
+}
+
+// FreeDescriptorSets returns descriptor sets to the pool they were allocated from. The pool
+// must have been created with DescriptorPoolCreateFreeDescriptorSetBit.
+func FreeDescriptorSets(device Device, pool DescriptorPool, sets []DescriptorSet) error {
+	if device == nil {
+		return NewValidationError("device", "cannot be nil")
+	}
+	if pool == nil {
+		return NewValidationError("pool", "cannot be nil")
+	}
+	if len(sets) == 0 {
+		return nil
+	}
+
+	cSets := make([]C.VkDescriptorSet, len(sets))
+	for i, set := range sets {
+		cSets[i] = C.VkDescriptorSet(set)
+	}
+	dynamicDescriptors.removeSets(device, sets)
+
+	result := Result(C.vkFreeDescriptorSets(C.VkDevice(device), C.VkDescriptorPool(pool), C.uint32_t(len(cSets)), &cSets[0]))
+	traceResult("vkFreeDescriptorSets", result)
+	if result != Success {
+		return NewVulkanError(result, "FreeDescriptorSets", "failed to free descriptor sets")
+	}
+	return nil
+}
+
+// AllocateDescriptorSets allocates one descriptor set per layout from a pool. The sets are
+// released with FreeDescriptorSets, ResetDescriptorPool or when the pool is destroyed.
+func AllocateDescriptorSets(device Device, allocateInfo *DescriptorSetAllocateInfo) ([]DescriptorSet, error) {
+	if device == nil {
+		return nil, NewValidationError("device", "cannot be nil")
+	}
+	if allocateInfo == nil {
+		return nil, NewValidationError("allocateInfo", "cannot be nil")
+	}
+	if allocateInfo.DescriptorPool == nil {
+		return nil, NewValidationError("allocateInfo.DescriptorPool", "cannot be nil")
+	}
+	if len(allocateInfo.SetLayouts) == 0 {
+		return nil, NewValidationError("allocateInfo.SetLayouts", "cannot be empty")
+	}
+	if len(allocateInfo.VariableDescriptorCounts) > 0 && len(allocateInfo.VariableDescriptorCounts) != len(allocateInfo.SetLayouts) {
+		return nil, NewValidationError("allocateInfo.VariableDescriptorCounts", fmt.Sprintf("must have one entry per set layout (%d), got %d", len(allocateInfo.SetLayouts), len(allocateInfo.VariableDescriptorCounts)))
+	}
+
+	// The layouts are referenced from a struct that may carry a C pNext chain, so keep them in C memory
+	var allocations []unsafe.Pointer
+	defer func() { freeAllocations(allocations) }()
+
+	cLayoutsPtr := (*C.VkDescriptorSetLayout)(C.calloc(C.size_t(len(allocateInfo.SetLayouts)), C.size_t(unsafe.Sizeof(C.VkDescriptorSetLayout(nil)))))
+	if cLayoutsPtr == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "AllocateDescriptorSets", "failed to allocate memory for set layouts")
+	}
+	allocations = append(allocations, unsafe.Pointer(cLayoutsPtr))
+	cLayouts := unsafe.Slice(cLayoutsPtr, len(allocateInfo.SetLayouts))
+	for i, layout := range allocateInfo.SetLayouts {
+		if layout == nil {
+			return nil, NewValidationError(fmt.Sprintf("allocateInfo.SetLayouts[%d]", i), "cannot be nil")
+		}
+		cLayouts[i] = C.VkDescriptorSetLayout(layout)
+	}
+
+	var cAllocateInfo C.VkDescriptorSetAllocateInfo
+	cAllocateInfo.sType = C.VK_STRUCTURE_TYPE_DESCRIPTOR_SET_ALLOCATE_INFO
+	cAllocateInfo.descriptorPool = C.VkDescriptorPool(allocateInfo.DescriptorPool)
+	cAllocateInfo.descriptorSetCount = C.uint32_t(len(cLayouts))
+	cAllocateInfo.pSetLayouts = cLayoutsPtr
+
+	if len(allocateInfo.VariableDescriptorCounts) > 0 {
+		pNext, err := variableDescriptorCountsToC(allocateInfo.VariableDescriptorCounts, &allocations)
+		if err != nil {
+			return nil, err
+		}
+		cAllocateInfo.pNext = pNext
+	}
+
+	cSets := make([]C.VkDescriptorSet, len(cLayouts))
+	result := Result(C.vkAllocateDescriptorSets(C.VkDevice(device), &cAllocateInfo, &cSets[0]))
+	traceResult("vkAllocateDescriptorSets", result)
+	if result != Success {
+		return nil, NewVulkanError(result, "AllocateDescriptorSets", "failed to allocate descriptor sets")
+	}
+
+	sets := make([]DescriptorSet, len(cSets))
+	for i, set := range cSets {
+		sets[i] = DescriptorSet(set)
+		if count, ok := dynamicDescriptors.layoutCount(device, allocateInfo.SetLayouts[i]); ok {
+			dynamicDescriptors.addSet(device, allocateInfo.DescriptorPool, sets[i], count)
+		}
+	}
+	return sets, nil
+}
+
+// UpdateDescriptorSets writes descriptors into allocated descriptor sets
+func UpdateDescriptorSets(device Device, writes []WriteDescriptorSet) error {
+	if device == nil {
+		return NewValidationError("device", "cannot be nil")
+	}
+	if len(writes) == 0 {
+		return nil
+	}
+
+	var allocations []unsafe.Pointer
+	defer func() { freeAllocations(allocations) }()
+
+	cWrites, err := writeDescriptorSetsToC(writes, &allocations)
+	if err != nil {
+		return err
+	}
+
+	C.vkUpdateDescriptorSets(C.VkDevice(device), C.uint32_t(len(writes)), cWrites, 0, nil)
+	return nil
+}
+
+// writeDescriptorSetsToC converts descriptor writes to a C array. All arrays are allocated
+// in C memory and appended to allocations, which the caller must free.
+func writeDescriptorSetsToC(writes []WriteDescriptorSet, allocations *[]unsafe.Pointer) (*C.VkWriteDescriptorSet, error) {
+	cWritesPtr := (*C.VkWriteDescriptorSet)(C.calloc(C.size_t(len(writes)), C.sizeof_VkWriteDescriptorSet))
+	if cWritesPtr == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "WriteDescriptorSet", "failed to allocate memory for descriptor writes")
+	}
+	*allocations = append(*allocations, unsafe.Pointer(cWritesPtr))
+
+	cWrites := unsafe.Slice(cWritesPtr, len(writes))
+	for i, write := range writes {
+		cWrites[i].sType = C.VK_STRUCTURE_TYPE_WRITE_DESCRIPTOR_SET
+		cWrites[i].pNext = nil
+		cWrites[i].dstSet = C.VkDescriptorSet(write.DstSet)
+		cWrites[i].dstBinding = C.uint32_t(write.DstBinding)
+		cWrites[i].dstArrayElement = C.uint32_t(write.DstArrayElement)
+		cWrites[i].descriptorType = C.VkDescriptorType(write.DescriptorType)
+
+		switch {
+		case len(write.ImageInfo) > 0:
+			cImageInfos := (*C.VkDescriptorImageInfo)(C.calloc(C.size_t(len(write.ImageInfo)), C.sizeof_VkDescriptorImageInfo))
+			if cImageInfos == nil {
+				return nil, NewVulkanError(ErrorOutOfHostMemory, "WriteDescriptorSet", "failed to allocate memory for image infos")
+			}
+			*allocations = append(*allocations, unsafe.Pointer(cImageInfos))
+			infos := unsafe.Slice(cImageInfos, len(write.ImageInfo))
+			for j, info := range write.ImageInfo {
+				infos[j].sampler = C.VkSampler(info.Sampler)
+				infos[j].imageView = C.VkImageView(info.ImageView)
+				infos[j].imageLayout = C.VkImageLayout(info.ImageLayout)
+			}
+			cWrites[i].descriptorCount = C.uint32_t(len(write.ImageInfo))
+			cWrites[i].pImageInfo = cImageInfos
+		case len(write.BufferInfo) > 0:
+			cBufferInfos := (*C.VkDescriptorBufferInfo)(C.calloc(C.size_t(len(write.BufferInfo)), C.sizeof_VkDescriptorBufferInfo))
+			if cBufferInfos == nil {
+				return nil, NewVulkanError(ErrorOutOfHostMemory, "WriteDescriptorSet", "failed to allocate memory for buffer infos")
+			}
+			*allocations = append(*allocations, unsafe.Pointer(cBufferInfos))
+			infos := unsafe.Slice(cBufferInfos, len(write.BufferInfo))
+			for j, info := range write.BufferInfo {
+				infos[j].buffer = C.VkBuffer(info.Buffer)
+				infos[j].offset = C.VkDeviceSize(info.Offset)
+				infos[j]._range = C.VkDeviceSize(info.Range)
+			}
+			cWrites[i].descriptorCount = C.uint32_t(len(write.BufferInfo))
+			cWrites[i].pBufferInfo = cBufferInfos
+		case len(write.TexelBufferViews) > 0:
+			cViews := (*C.VkBufferView)(C.calloc(C.size_t(len(write.TexelBufferViews)), C.size_t(unsafe.Sizeof(C.VkBufferView(nil)))))
+			if cViews == nil {
+				return nil, NewVulkanError(ErrorOutOfHostMemory, "WriteDescriptorSet", "failed to allocate memory for texel buffer views")
+			}
+			*allocations = append(*allocations, unsafe.Pointer(cViews))
+			views := unsafe.Slice(cViews, len(write.TexelBufferViews))
+			for j, view := range write.TexelBufferViews {
+				views[j] = C.VkBufferView(view)
+			}
+			cWrites[i].descriptorCount = C.uint32_t(len(write.TexelBufferViews))
+			cWrites[i].pTexelBufferView = cViews
+		default:
+			return nil, NewValidationError("writes", "descriptor write must set ImageInfo, BufferInfo or TexelBufferViews")
+		}
+	}
+
+	return cWritesPtr, nil
 }
@@ -0,0 +1,280 @@
+//go:build cgo
+
+package vulkan
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestCreateBufferViewValidation tests input validation for CreateBufferView
+func TestCreateBufferViewValidation(t *testing.T) {
+	fakeDevice := Device(uintptr(0x1234))
+	fakeBuffer := Buffer(uintptr(0x5678))
+
+	tests := []struct {
+		name       string
+		createInfo *BufferViewCreateInfo
+		errorParam string
+	}{
+		{"nil create info", nil, "createInfo"},
+		{"nil buffer", &BufferViewCreateInfo{Format: FormatR8G8B8A8Unorm, Range: 256}, "createInfo.Buffer"},
+		{"undefined format", &BufferViewCreateInfo{Buffer: fakeBuffer, Range: 256}, "createInfo.Format"},
+		{"zero range", &BufferViewCreateInfo{Buffer: fakeBuffer, Format: FormatR8G8B8A8Unorm}, "createInfo.Range"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CreateBufferView(fakeDevice, tt.createInfo)
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+}
+
+// TestDescriptorIndexingValidation tests binding flag and variable descriptor count validation
+func TestDescriptorIndexingValidation(t *testing.T) {
+	fakeDevice := Device(uintptr(0x1234))
+	bindings := []DescriptorSetLayoutBinding{
+		{Binding: 0, DescriptorType: DescriptorTypeUniformBuffer, DescriptorCount: 1, StageFlags: ShaderStageFragmentBit},
+		{Binding: 1, DescriptorType: DescriptorTypeCombinedImageSampler, DescriptorCount: 4096, StageFlags: ShaderStageFragmentBit},
+	}
+
+	layoutTests := []struct {
+		name       string
+		createInfo *DescriptorSetLayoutCreateInfo
+		errorParam string
+	}{
+		{
+			name: "flag count mismatch",
+			createInfo: &DescriptorSetLayoutCreateInfo{
+				Bindings:     bindings,
+				BindingFlags: []DescriptorBindingFlags{DescriptorBindingPartiallyBoundBit},
+			},
+			errorParam: "createInfo.BindingFlags",
+		},
+		{
+			name: "update after bind without pool flag",
+			createInfo: &DescriptorSetLayoutCreateInfo{
+				Bindings:     bindings,
+				BindingFlags: []DescriptorBindingFlags{0, DescriptorBindingUpdateAfterBindBit},
+			},
+			errorParam: "createInfo.BindingFlags[1]",
+		},
+		{
+			name: "variable count on lower binding",
+			createInfo: &DescriptorSetLayoutCreateInfo{
+				Bindings:     bindings,
+				BindingFlags: []DescriptorBindingFlags{DescriptorBindingVariableDescriptorCountBit, 0},
+			},
+			errorParam: "createInfo.BindingFlags[0]",
+		},
+	}
+
+	for _, tt := range layoutTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CreateDescriptorSetLayout(fakeDevice, tt.createInfo)
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+
+	fakePool := DescriptorPool(uintptr(0x5678))
+	fakeLayout := DescriptorSetLayout(uintptr(0x9abc))
+	allocateTests := []struct {
+		name         string
+		allocateInfo *DescriptorSetAllocateInfo
+		errorParam   string
+	}{
+		{"nil allocate info", nil, "allocateInfo"},
+		{"nil pool", &DescriptorSetAllocateInfo{SetLayouts: []DescriptorSetLayout{fakeLayout}}, "allocateInfo.DescriptorPool"},
+		{"no layouts", &DescriptorSetAllocateInfo{DescriptorPool: fakePool}, "allocateInfo.SetLayouts"},
+		{
+			"variable count mismatch",
+			&DescriptorSetAllocateInfo{
+				DescriptorPool:           fakePool,
+				SetLayouts:               []DescriptorSetLayout{fakeLayout, fakeLayout},
+				VariableDescriptorCounts: []uint32{1024},
+			},
+			"allocateInfo.VariableDescriptorCounts",
+		},
+		{"nil layout", &DescriptorSetAllocateInfo{DescriptorPool: fakePool, SetLayouts: []DescriptorSetLayout{fakeLayout, nil}}, "allocateInfo.SetLayouts[1]"},
+	}
+
+	for _, tt := range allocateTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := AllocateDescriptorSets(fakeDevice, tt.allocateInfo)
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+}
+
+// TestDynamicOffsetValidation tests that bound sets take one offset per dynamic descriptor
+func TestDynamicOffsetValidation(t *testing.T) {
+	fakeCommandBuffer := CommandBuffer(uintptr(0x1234))
+	fakePool := DescriptorPool(uintptr(0x5678))
+	setWithTwo := DescriptorSet(uintptr(0x9abc))
+	setWithOne := DescriptorSet(uintptr(0xdef0))
+	fakeDevice := Device(uintptr(0x4321))
+	otherDevice := Device(uintptr(0x8421))
+	otherCommandBuffer := CommandBuffer(uintptr(0x2345))
+	dynamicDescriptors.addSet(fakeDevice, fakePool, setWithTwo, 2)
+	dynamicDescriptors.addSet(fakeDevice, fakePool, setWithOne, 1)
+	defer dynamicDescriptors.removeDevice(fakeDevice)
+	allocatedCommandBuffers.add(fakeDevice, CommandPool(uintptr(0x8765)), []CommandBuffer{fakeCommandBuffer}, false)
+	defer allocatedCommandBuffers.removeDevice(fakeDevice)
+	// The same set handle on another device has no dynamic descriptors
+	dynamicDescriptors.addSet(otherDevice, fakePool, setWithTwo, 0)
+	defer dynamicDescriptors.removeDevice(otherDevice)
+	allocatedCommandBuffers.add(otherDevice, CommandPool(uintptr(0x8765)), []CommandBuffer{otherCommandBuffer}, false)
+	defer allocatedCommandBuffers.removeDevice(otherDevice)
+
+	tests := []struct {
+		name           string
+		sets           []DescriptorSet
+		dynamicOffsets []uint32
+		errorParam     string
+	}{
+		{"nil set", []DescriptorSet{setWithTwo, nil}, []uint32{0, 256}, "descriptorSets[1]"},
+		{"missing offsets", []DescriptorSet{setWithTwo, setWithOne}, nil, "dynamicOffsets"},
+		{"too few offsets", []DescriptorSet{setWithTwo, setWithOne}, []uint32{0, 256}, "dynamicOffsets"},
+		{"too many offsets", []DescriptorSet{setWithOne}, []uint32{0, 256}, "dynamicOffsets"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CmdBindDescriptorSets(fakeCommandBuffer, PipelineBindPointCompute, nil, 0, tt.sets, tt.dynamicOffsets)
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+
+	// Counts are looked up on the command buffer's device, and untracked command buffers
+	// skip the check
+	if err := validateDynamicOffsets(otherCommandBuffer, []DescriptorSet{setWithTwo}, nil); err != nil {
+		t.Errorf("Expected the other device's count of 0 to be used, got %v", err)
+	}
+	if err := validateDynamicOffsets(CommandBuffer(uintptr(0x3456)), []DescriptorSet{setWithTwo}, nil); err != nil {
+		t.Errorf("Expected untracked command buffer to skip the count check, got %v", err)
+	}
+}
+
+// TestDynamicDescriptorRegistryLifecycle tests that dynamic descriptor counts are forgotten
+// when sets are freed, their pool is reset or destroyed, or their layout or device is
+// destroyed, so recycled handles start clean
+func TestDynamicDescriptorRegistryLifecycle(t *testing.T) {
+	deviceA := Device(uintptr(0x1000))
+	deviceB := Device(uintptr(0x2000))
+	layout := DescriptorSetLayout(uintptr(0x5000))
+	pool1 := DescriptorPool(uintptr(0x3000))
+	pool2 := DescriptorPool(uintptr(0x4000))
+	freed := DescriptorSet(uintptr(0x1001))
+	kept := DescriptorSet(uintptr(0x1002))
+	inPool2 := DescriptorSet(uintptr(0x1003))
+	onDeviceB := DescriptorSet(uintptr(0x2001))
+	defer dynamicDescriptors.removeDevice(deviceA)
+	defer dynamicDescriptors.removeDevice(deviceB)
+
+	dynamicDescriptors.addLayout(deviceA, layout, 2)
+	dynamicDescriptors.addSet(deviceA, pool1, freed, 2)
+	dynamicDescriptors.addSet(deviceA, pool1, kept, 2)
+	dynamicDescriptors.addSet(deviceA, pool2, inPool2, 2)
+	dynamicDescriptors.addSet(deviceB, pool1, onDeviceB, 1)
+	// Handles are per device, so deviceB may hand out the same handle as deviceA
+	dynamicDescriptors.addSet(deviceB, pool1, freed, 1)
+
+	if _, ok := dynamicDescriptors.layoutCount(deviceB, layout); ok {
+		t.Error("Expected layout count to be scoped to the creating device")
+	}
+	dynamicDescriptors.removeLayout(deviceA, layout)
+	if _, ok := dynamicDescriptors.layoutCount(deviceA, layout); ok {
+		t.Error("Expected destroyed layout to be forgotten")
+	}
+	if count, ok := dynamicDescriptors.setCount(deviceA, kept); !ok || count != 2 {
+		t.Errorf("Expected sets to keep their count after the layout is destroyed, got %d (ok=%v)", count, ok)
+	}
+
+	dynamicDescriptors.removeSets(deviceA, []DescriptorSet{freed})
+	if _, ok := dynamicDescriptors.setCount(deviceA, freed); ok {
+		t.Error("Expected freed set to be forgotten")
+	}
+	if count, ok := dynamicDescriptors.setCount(deviceB, freed); !ok || count != 1 {
+		t.Errorf("Expected freeing a set to leave the same handle on another device untouched, got %d (ok=%v)", count, ok)
+	}
+
+	dynamicDescriptors.removePool(deviceA, pool1)
+	if _, ok := dynamicDescriptors.setCount(deviceA, kept); ok {
+		t.Error("Expected sets of a reset or destroyed pool to be forgotten")
+	}
+	if _, ok := dynamicDescriptors.setCount(deviceA, inPool2); !ok {
+		t.Error("Expected resetting a pool to leave other pools untouched")
+	}
+	if _, ok := dynamicDescriptors.setCount(deviceB, onDeviceB); !ok {
+		t.Error("Expected resetting a pool to leave other devices untouched")
+	}
+
+	dynamicDescriptors.removeDevice(deviceA)
+	if _, ok := dynamicDescriptors.setCount(deviceA, inPool2); ok {
+		t.Error("Expected sets of a destroyed device to be forgotten")
+	}
+	if _, ok := dynamicDescriptors.setCount(deviceB, onDeviceB); !ok {
+		t.Error("Expected destroying a device to leave other devices untouched")
+	}
+}
+
+// TestDescriptorSetReleaseValidation tests input validation for FreeDescriptorSets and
+// ResetDescriptorPool
+func TestDescriptorSetReleaseValidation(t *testing.T) {
+	fakeDevice := Device(uintptr(0x1234))
+	fakePool := DescriptorPool(uintptr(0x5678))
+
+	tests := []struct {
+		name       string
+		call       func() error
+		errorParam string
+	}{
+		{"free with nil device", func() error { return FreeDescriptorSets(nil, fakePool, nil) }, "device"},
+		{"free with nil pool", func() error { return FreeDescriptorSets(fakeDevice, nil, nil) }, "pool"},
+		{"reset with nil device", func() error { return ResetDescriptorPool(nil, fakePool) }, "device"},
+		{"reset with nil pool", func() error { return ResetDescriptorPool(fakeDevice, nil) }, "pool"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+}
@@ -8,6 +8,8 @@ package vulkan
 import "C"
 
 import (
+	"slices"
+	"sync"
 	"unsafe"
 )
 
@@ -23,6 +25,50 @@ type DeviceCreateInfo struct {
 	EnabledLayerNames     []string
 	EnabledExtensionNames []string
 	EnabledFeatures       *PhysicalDeviceFeatures
+	// RayTracingFeatures enables the buffer device address, acceleration structure
+	// and ray tracing pipeline features when set
+	RayTracingFeatures *PhysicalDeviceRayTracingFeatures
+	// MeshShaderFeatures enables the task and mesh shader features when set
+	MeshShaderFeatures *PhysicalDeviceMeshShaderFeatures
+	// HostImageCopyFeatures enables the host image copy feature when set
+	HostImageCopyFeatures *PhysicalDeviceHostImageCopyFeatures
+	// TimelineSemaphoreFeatures enables timeline semaphores when set
+	TimelineSemaphoreFeatures *PhysicalDeviceTimelineSemaphoreFeatures
+	// ConditionalRenderingFeatures enables the conditional rendering features when set
+	ConditionalRenderingFeatures *PhysicalDeviceConditionalRenderingFeatures
+	// TransformFeedbackFeatures enables the transform feedback features when set
+	TransformFeedbackFeatures *PhysicalDeviceTransformFeedbackFeatures
+	// PresentWaitFeatures enables the present ID and present wait features when set
+	PresentWaitFeatures *PhysicalDevicePresentWaitFeatures
+	// DescriptorIndexingFeatures enables the descriptor indexing features when set
+	DescriptorIndexingFeatures *PhysicalDeviceDescriptorIndexingFeatures
+	// ExtendedDynamicState3Features enables the extended dynamic state 3 features when set
+	ExtendedDynamicState3Features *PhysicalDeviceExtendedDynamicState3Features
+	// CoherentMemoryFeatures enables the AMD device coherent memory feature when set
+	CoherentMemoryFeatures *PhysicalDeviceCoherentMemoryFeatures
+	// DeviceFaultFeatures enables the device fault reporting features when set
+	DeviceFaultFeatures *PhysicalDeviceFaultFeatures
+	// GraphicsPipelineLibraryFeatures enables the graphics pipeline library feature when set
+	GraphicsPipelineLibraryFeatures *PhysicalDeviceGraphicsPipelineLibraryFeatures
+	// CooperativeMatrixFeatures enables the cooperative matrix features when set
+	CooperativeMatrixFeatures *PhysicalDeviceCooperativeMatrixFeatures
+	// VertexInputDynamicStateFeatures enables the vertex input dynamic state feature when set
+	VertexInputDynamicStateFeatures *PhysicalDeviceVertexInputDynamicStateFeatures
+	// FragmentShadingRateFeatures enables the fragment shading rate features when set
+	FragmentShadingRateFeatures *PhysicalDeviceFragmentShadingRateFeatures
+	// ImageCompressionControlFeatures enables the image compression control feature when set
+	ImageCompressionControlFeatures *PhysicalDeviceImageCompressionControlFeatures
+	// DynamicRenderingFeatures enables dynamic rendering on Vulkan 1.2 devices that enable
+	// ExtensionNameDynamicRendering. Use Vulkan13Features on Vulkan 1.3 devices.
+	DynamicRenderingFeatures *PhysicalDeviceDynamicRenderingFeatures
+	// Synchronization2Features enables synchronization2 on Vulkan 1.2 devices that enable
+	// ExtensionNameSynchronization2. Use Vulkan13Features on Vulkan 1.3 devices.
+	Synchronization2Features *PhysicalDeviceSynchronization2Features
+	// Vulkan13Features enables the features promoted to core in Vulkan 1.3 when set. It
+	// cannot be combined with DynamicRenderingFeatures or Synchronization2Features.
+	Vulkan13Features *PhysicalDeviceVulkan13Features
+	// DeviceGroup creates the device across several physical devices of one group when set
+	DeviceGroup *DeviceGroupDeviceCreateInfo
 }
 
 // PhysicalDeviceFeatures contains physical device features
@@ -126,6 +172,47 @@ const (
 	MemoryHeapMultiInstanceBit MemoryHeapFlags = C.VK_MEMORY_HEAP_MULTI_INSTANCE_BIT
 )
 
+// deviceCreateInfoChainer prepends a struct to the pNext chain next of VkDeviceCreateInfo. The
+// struct is allocated in C memory and appended to allocations, which the caller must free.
+type deviceCreateInfoChainer func(next unsafe.Pointer, allocations *[]unsafe.Pointer) (unsafe.Pointer, error)
+
+// chainIfSet returns a chainer converting info with toC, or nil if info is not set
+func chainIfSet[T any](info *T, toC func(*T, unsafe.Pointer, *[]unsafe.Pointer) (unsafe.Pointer, error)) deviceCreateInfoChainer {
+	if info == nil {
+		return nil
+	}
+	return func(next unsafe.Pointer, allocations *[]unsafe.Pointer) (unsafe.Pointer, error) {
+		return toC(info, next, allocations)
+	}
+}
+
+// deviceCreateInfoChain lists the structs CreateDevice chains onto VkDeviceCreateInfo, one per
+// optional DeviceCreateInfo field. Entries for unset fields are nil.
+func deviceCreateInfoChain(createInfo *DeviceCreateInfo) []deviceCreateInfoChainer {
+	return []deviceCreateInfoChainer{
+		chainIfSet(createInfo.RayTracingFeatures, rayTracingFeaturesToC),
+		chainIfSet(createInfo.MeshShaderFeatures, meshShaderFeaturesToC),
+		chainIfSet(createInfo.HostImageCopyFeatures, hostImageCopyFeaturesToC),
+		chainIfSet(createInfo.TimelineSemaphoreFeatures, timelineSemaphoreFeaturesToC),
+		chainIfSet(createInfo.ConditionalRenderingFeatures, conditionalRenderingFeaturesToC),
+		chainIfSet(createInfo.TransformFeedbackFeatures, transformFeedbackFeaturesToC),
+		chainIfSet(createInfo.PresentWaitFeatures, presentWaitFeaturesToC),
+		chainIfSet(createInfo.DescriptorIndexingFeatures, descriptorIndexingFeaturesToC),
+		chainIfSet(createInfo.ExtendedDynamicState3Features, extendedDynamicState3FeaturesToC),
+		chainIfSet(createInfo.CoherentMemoryFeatures, coherentMemoryFeaturesToC),
+		chainIfSet(createInfo.DeviceFaultFeatures, deviceFaultFeaturesToC),
+		chainIfSet(createInfo.GraphicsPipelineLibraryFeatures, graphicsPipelineLibraryFeaturesToC),
+		chainIfSet(createInfo.CooperativeMatrixFeatures, cooperativeMatrixFeaturesToC),
+		chainIfSet(createInfo.VertexInputDynamicStateFeatures, vertexInputDynamicStateFeaturesToC),
+		chainIfSet(createInfo.FragmentShadingRateFeatures, fragmentShadingRateFeaturesToC),
+		chainIfSet(createInfo.ImageCompressionControlFeatures, imageCompressionControlFeaturesToC),
+		chainIfSet(createInfo.DynamicRenderingFeatures, dynamicRenderingFeaturesToC),
+		chainIfSet(createInfo.Synchronization2Features, synchronization2FeaturesToC),
+		chainIfSet(createInfo.Vulkan13Features, vulkan13FeaturesToC),
+		chainIfSet(createInfo.DeviceGroup, deviceGroupDeviceCreateInfoToC),
+	}
+}
+
 // CreateDevice creates a logical device
 func CreateDevice(physicalDevice PhysicalDevice, createInfo *DeviceCreateInfo) (Device, error) {
 	// Input validation
@@ -181,6 +268,19 @@ func CreateDevice(physicalDevice PhysicalDevice, createInfo *DeviceCreateInfo) (
 		}
 	}
 
+	if createInfo.Vulkan13Features != nil && createInfo.DynamicRenderingFeatures != nil {
+		return nil, NewValidationError("DynamicRenderingFeatures", "cannot be combined with Vulkan13Features; set Vulkan13Features.DynamicRendering instead")
+	}
+	if createInfo.Vulkan13Features != nil && createInfo.Synchronization2Features != nil {
+		return nil, NewValidationError("Synchronization2Features", "cannot be combined with Vulkan13Features; set Vulkan13Features.Synchronization2 instead")
+	}
+
+	if createInfo.DeviceGroup != nil {
+		if err := validateDeviceGroupDeviceCreateInfo(physicalDevice, createInfo.DeviceGroup); err != nil {
+			return nil, err
+		}
+	}
+
 	// Allocate create info in C memory to avoid Go pointer issues
 	cCreateInfoPtr := (*C.VkDeviceCreateInfo)(C.malloc(C.sizeof_VkDeviceCreateInfo))
 	if cCreateInfoPtr == nil {
@@ -296,18 +396,72 @@ func CreateDevice(physicalDevice PhysicalDevice, createInfo *DeviceCreateInfo) (
 		defer C.free(unsafe.Pointer(cFeaturesPtr))
 	}
 
+	// Extension features - chained through pNext in C memory
+	var featureAllocations []unsafe.Pointer
+	defer func() { freeAllocations(featureAllocations) }()
+	var pNext unsafe.Pointer
+	for _, chain := range deviceCreateInfoChain(createInfo) {
+		if chain == nil {
+			continue
+		}
+		var err error
+		if pNext, err = chain(pNext, &featureAllocations); err != nil {
+			return nil, err
+		}
+	}
+	cCreateInfoPtr.pNext = pNext
+
 	var device C.VkDevice
 	result := Result(C.vkCreateDevice(C.VkPhysicalDevice(physicalDevice), cCreateInfoPtr, nil, &device))
+	traceResult("vkCreateDevice", result)
 	
 	if result != Success {
 		return nil, NewVulkanError(result, "CreateDevice", "Vulkan device creation failed")
 	}
 
+	var enabledFeatures PhysicalDeviceFeatures
+	if createInfo.EnabledFeatures != nil {
+		enabledFeatures = *createInfo.EnabledFeatures
+	}
+	deviceEnabledFeatures.Store(Device(device), enabledFeatures)
+	if slices.Contains(createInfo.EnabledExtensionNames, ExtensionNameDepthRangeUnrestricted) {
+		depthRangeUnrestrictedDevices.Store(Device(device), struct{}{})
+	}
+	if createInfo.HostImageCopyFeatures != nil && createInfo.HostImageCopyFeatures.HostImageCopy {
+		recordHostImageCopyLayouts(Device(device), physicalDevice)
+	}
+
 	return Device(device), nil
 }
 
+// deviceEnabledFeatures records the core features enabled on devices created through
+// CreateDevice so resource creation can be checked against them without the validation layers
+var deviceEnabledFeatures sync.Map
+
+// enabledDeviceFeatures returns the core features enabled on device, or false if the device
+// was not created through CreateDevice
+func enabledDeviceFeatures(device Device) (PhysicalDeviceFeatures, bool) {
+	features, ok := deviceEnabledFeatures.Load(device)
+	if !ok {
+		return PhysicalDeviceFeatures{}, false
+	}
+	return features.(PhysicalDeviceFeatures), true
+}
+
+// depthRangeUnrestrictedDevices records the devices created with
+// ExtensionNameDepthRangeUnrestricted enabled, whose command buffers accept depth values
+// outside [0, 1]
+var depthRangeUnrestrictedDevices sync.Map
+
 // DestroyDevice destroys a logical device
 func DestroyDevice(device Device) {
+	deviceEnabledFeatures.Delete(device)
+	depthRangeUnrestrictedDevices.Delete(device)
+	hostImageCopyLayouts.Delete(device)
+	allocatedCommandBuffers.removeDevice(device)
+	bufferUsages.removeDevice(device)
+	dynamicDescriptors.removeDevice(device)
+	forgetDynamicRendering(device)
 	C.vkDestroyDevice(C.VkDevice(device), nil)
 }
 
@@ -321,6 +475,7 @@ func GetDeviceQueue(device Device, queueFamilyIndex, queueIndex uint32) Queue {
 // QueueWaitIdle waits for a queue to become idle
 func QueueWaitIdle(queue Queue) error {
 	result := Result(C.vkQueueWaitIdle(C.VkQueue(queue)))
+	traceResult("vkQueueWaitIdle", result)
 	if result != Success {
 		return result
 	}
@@ -330,6 +485,7 @@ func QueueWaitIdle(queue Queue) error {
 // DeviceWaitIdle waits for a device to become idle
 func DeviceWaitIdle(device Device) error {
 	result := Result(C.vkDeviceWaitIdle(C.VkDevice(device)))
+	traceResult("vkDeviceWaitIdle", result)
 	if result != Success {
 		return result
 	}
@@ -347,7 +503,11 @@ func GetPhysicalDeviceFeatures(physicalDevice PhysicalDevice) PhysicalDeviceFeat
 func GetPhysicalDeviceMemoryProperties(physicalDevice PhysicalDevice) PhysicalDeviceMemoryProperties {
 	var cProps C.VkPhysicalDeviceMemoryProperties
 	C.vkGetPhysicalDeviceMemoryProperties(C.VkPhysicalDevice(physicalDevice), &cProps)
+	return memoryPropertiesFromC(&cProps)
+}
 
+// memoryPropertiesFromC converts C memory properties to Go
+func memoryPropertiesFromC(cProps *C.VkPhysicalDeviceMemoryProperties) PhysicalDeviceMemoryProperties {
 	props := PhysicalDeviceMemoryProperties{
 		MemoryTypeCount: uint32(cProps.memoryTypeCount),
 		MemoryHeapCount: uint32(cProps.memoryHeapCount),
@@ -370,6 +530,279 @@ func GetPhysicalDeviceMemoryProperties(physicalDevice PhysicalDevice) PhysicalDe
 	return props
 }
 
+// ExtensionNameMemoryBudget is the memory budget extension name
+const ExtensionNameMemoryBudget = "VK_EXT_memory_budget"
+
+// PhysicalDeviceMemoryProperties2 contains memory properties together with the
+// driver-reported per-heap budget and usage from VK_EXT_memory_budget
+type PhysicalDeviceMemoryProperties2 struct {
+	MemoryProperties PhysicalDeviceMemoryProperties
+	// HeapBudget is an estimate of how much memory the process can allocate from each heap
+	HeapBudget [MaxMemoryHeaps]DeviceSize
+	// HeapUsage is an estimate of how much memory the process currently uses in each heap
+	HeapUsage [MaxMemoryHeaps]DeviceSize
+}
+
+// GetPhysicalDeviceMemoryProperties2 gets memory properties and per-heap budgets.
+// The physical device must support VK_EXT_memory_budget; the values are snapshots and
+// change as memory is allocated by this and other processes.
+func GetPhysicalDeviceMemoryProperties2(physicalDevice PhysicalDevice) (*PhysicalDeviceMemoryProperties2, error) {
+	if physicalDevice == nil {
+		return nil, NewValidationError("physicalDevice", "cannot be nil")
+	}
+	if GetPhysicalDeviceProperties(physicalDevice).APIVersion < Version11 {
+		return nil, NewVulkanError(ErrorFeatureNotPresent, "GetPhysicalDeviceMemoryProperties2", "requires a Vulkan 1.1 device")
+	}
+
+	extensions, err := EnumerateDeviceExtensionProperties(physicalDevice, "")
+	if err != nil {
+		return nil, err
+	}
+	budgetSupported := false
+	for _, ext := range extensions {
+		if ext.ExtensionName == ExtensionNameMemoryBudget {
+			budgetSupported = true
+			break
+		}
+	}
+	if !budgetSupported {
+		return nil, NewVulkanError(ErrorExtensionNotPresent, "GetPhysicalDeviceMemoryProperties2", "VK_EXT_memory_budget is not supported by this device")
+	}
+
+	cProps2 := (*C.VkPhysicalDeviceMemoryProperties2)(C.calloc(1, C.sizeof_VkPhysicalDeviceMemoryProperties2))
+	cBudget := (*C.VkPhysicalDeviceMemoryBudgetPropertiesEXT)(C.calloc(1, C.sizeof_VkPhysicalDeviceMemoryBudgetPropertiesEXT))
+	defer C.free(unsafe.Pointer(cProps2))
+	defer C.free(unsafe.Pointer(cBudget))
+	if cProps2 == nil || cBudget == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "GetPhysicalDeviceMemoryProperties2", "failed to allocate memory for properties")
+	}
+
+	cProps2.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_MEMORY_PROPERTIES_2
+	cProps2.pNext = unsafe.Pointer(cBudget)
+	cBudget.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_MEMORY_BUDGET_PROPERTIES_EXT
+
+	C.vkGetPhysicalDeviceMemoryProperties2(C.VkPhysicalDevice(physicalDevice), cProps2)
+
+	props := &PhysicalDeviceMemoryProperties2{
+		MemoryProperties: memoryPropertiesFromC(&cProps2.memoryProperties),
+	}
+	for i := uint32(0); i < props.MemoryProperties.MemoryHeapCount; i++ {
+		props.HeapBudget[i] = DeviceSize(cBudget.heapBudget[i])
+		props.HeapUsage[i] = DeviceSize(cBudget.heapUsage[i])
+	}
+	return props, nil
+}
+
+// FormatFeatureFlags represents format feature flags
+type FormatFeatureFlags uint32
+
+const (
+	FormatFeatureSampledImageBit             FormatFeatureFlags = C.VK_FORMAT_FEATURE_SAMPLED_IMAGE_BIT
+	FormatFeatureStorageImageBit             FormatFeatureFlags = C.VK_FORMAT_FEATURE_STORAGE_IMAGE_BIT
+	FormatFeatureStorageImageAtomicBit       FormatFeatureFlags = C.VK_FORMAT_FEATURE_STORAGE_IMAGE_ATOMIC_BIT
+	FormatFeatureUniformTexelBufferBit       FormatFeatureFlags = C.VK_FORMAT_FEATURE_UNIFORM_TEXEL_BUFFER_BIT
+	FormatFeatureStorageTexelBufferBit       FormatFeatureFlags = C.VK_FORMAT_FEATURE_STORAGE_TEXEL_BUFFER_BIT
+	FormatFeatureStorageTexelBufferAtomicBit FormatFeatureFlags = C.VK_FORMAT_FEATURE_STORAGE_TEXEL_BUFFER_ATOMIC_BIT
+	FormatFeatureVertexBufferBit             FormatFeatureFlags = C.VK_FORMAT_FEATURE_VERTEX_BUFFER_BIT
+	FormatFeatureColorAttachmentBit          FormatFeatureFlags = C.VK_FORMAT_FEATURE_COLOR_ATTACHMENT_BIT
+	FormatFeatureColorAttachmentBlendBit     FormatFeatureFlags = C.VK_FORMAT_FEATURE_COLOR_ATTACHMENT_BLEND_BIT
+	FormatFeatureDepthStencilAttachmentBit   FormatFeatureFlags = C.VK_FORMAT_FEATURE_DEPTH_STENCIL_ATTACHMENT_BIT
+	FormatFeatureBlitSrcBit                  FormatFeatureFlags = C.VK_FORMAT_FEATURE_BLIT_SRC_BIT
+	FormatFeatureBlitDstBit                  FormatFeatureFlags = C.VK_FORMAT_FEATURE_BLIT_DST_BIT
+	FormatFeatureSampledImageFilterLinearBit FormatFeatureFlags = C.VK_FORMAT_FEATURE_SAMPLED_IMAGE_FILTER_LINEAR_BIT
+	FormatFeatureTransferSrcBit              FormatFeatureFlags = C.VK_FORMAT_FEATURE_TRANSFER_SRC_BIT
+	FormatFeatureTransferDstBit              FormatFeatureFlags = C.VK_FORMAT_FEATURE_TRANSFER_DST_BIT
+)
+
+// FormatProperties contains the features supported by a format
+type FormatProperties struct {
+	LinearTilingFeatures  FormatFeatureFlags
+	OptimalTilingFeatures FormatFeatureFlags
+	BufferFeatures        FormatFeatureFlags
+}
+
+// ImageFormatProperties contains the limits of an image format/type/tiling/usage combination
+type ImageFormatProperties struct {
+	MaxExtent       Extent3D
+	MaxMipLevels    uint32
+	MaxArrayLayers  uint32
+	SampleCounts    SampleCountFlags
+	MaxResourceSize DeviceSize
+}
+
+// GetPhysicalDeviceFormatProperties gets the features supported by a format
+func GetPhysicalDeviceFormatProperties(physicalDevice PhysicalDevice, format Format) FormatProperties {
+	var cProps C.VkFormatProperties
+	C.vkGetPhysicalDeviceFormatProperties(C.VkPhysicalDevice(physicalDevice), C.VkFormat(format), &cProps)
+
+	return FormatProperties{
+		LinearTilingFeatures:  FormatFeatureFlags(cProps.linearTilingFeatures),
+		OptimalTilingFeatures: FormatFeatureFlags(cProps.optimalTilingFeatures),
+		BufferFeatures:        FormatFeatureFlags(cProps.bufferFeatures),
+	}
+}
+
+// GetPhysicalDeviceImageFormatProperties gets the image limits for a format/type/tiling/usage combination.
+// Returns an error wrapping ErrorFormatNotSupported if the combination cannot be used.
+func GetPhysicalDeviceImageFormatProperties(physicalDevice PhysicalDevice, format Format, imageType ImageType, tiling ImageTiling, usage ImageUsageFlags, flags ImageCreateFlags) (ImageFormatProperties, error) {
+	if physicalDevice == nil {
+		return ImageFormatProperties{}, NewValidationError("physicalDevice", "cannot be nil")
+	}
+
+	var cProps C.VkImageFormatProperties
+	result := Result(C.vkGetPhysicalDeviceImageFormatProperties(
+		C.VkPhysicalDevice(physicalDevice),
+		C.VkFormat(format),
+		C.VkImageType(imageType),
+		C.VkImageTiling(tiling),
+		C.VkImageUsageFlags(usage),
+		C.VkImageCreateFlags(flags),
+		&cProps,
+	))
+	traceResult("vkGetPhysicalDeviceImageFormatProperties", result)
+	if result != Success {
+		return ImageFormatProperties{}, NewVulkanError(result, "GetPhysicalDeviceImageFormatProperties", "image format combination not supported")
+	}
+
+	return ImageFormatProperties{
+		MaxExtent: Extent3D{
+			Width:  uint32(cProps.maxExtent.width),
+			Height: uint32(cProps.maxExtent.height),
+			Depth:  uint32(cProps.maxExtent.depth),
+		},
+		MaxMipLevels:    uint32(cProps.maxMipLevels),
+		MaxArrayLayers:  uint32(cProps.maxArrayLayers),
+		SampleCounts:    SampleCountFlags(cProps.sampleCounts),
+		MaxResourceSize: DeviceSize(cProps.maxResourceSize),
+	}, nil
+}
+
+// PhysicalDeviceImageFormatInfo2 describes the image GetPhysicalDeviceImageFormatProperties2
+// queries
+type PhysicalDeviceImageFormatInfo2 struct {
+	Format Format
+	Type   ImageType
+	Tiling ImageTiling
+	Usage  ImageUsageFlags
+	Flags  ImageCreateFlags
+	// ImageCompressionControl queries the compression an image created with this control
+	// would get and fills ImageFormatProperties2.ImageCompressionProperties. Requires
+	// ExtensionNameImageCompressionControl.
+	ImageCompressionControl *ImageCompressionControlEXT
+}
+
+// ImageFormatProperties2 holds the image format limits and the results of chained queries
+type ImageFormatProperties2 struct {
+	ImageFormatProperties
+	// ImageCompressionProperties reports the compression the image would get. Only filled
+	// in when PhysicalDeviceImageFormatInfo2.ImageCompressionControl is set.
+	ImageCompressionProperties ImageCompressionProperties
+}
+
+// GetPhysicalDeviceImageFormatProperties2 gets the image limits for the image described by
+// formatInfo, including the chained queries it selects (Vulkan 1.1).
+// Returns an error wrapping ErrorFormatNotSupported if the combination cannot be used.
+func GetPhysicalDeviceImageFormatProperties2(physicalDevice PhysicalDevice, formatInfo *PhysicalDeviceImageFormatInfo2) (ImageFormatProperties2, error) {
+	if physicalDevice == nil {
+		return ImageFormatProperties2{}, NewValidationError("physicalDevice", "cannot be nil")
+	}
+	if formatInfo == nil {
+		return ImageFormatProperties2{}, NewValidationError("formatInfo", "cannot be nil")
+	}
+	if formatInfo.ImageCompressionControl != nil {
+		if err := validateImageCompressionControl("formatInfo.ImageCompressionControl", formatInfo.ImageCompressionControl); err != nil {
+			return ImageFormatProperties2{}, err
+		}
+	}
+
+	var allocations []unsafe.Pointer
+	defer func() { freeAllocations(allocations) }()
+
+	cInfo := (*C.VkPhysicalDeviceImageFormatInfo2)(C.calloc(1, C.sizeof_VkPhysicalDeviceImageFormatInfo2))
+	cProps := (*C.VkImageFormatProperties2)(C.calloc(1, C.sizeof_VkImageFormatProperties2))
+	allocations = append(allocations, unsafe.Pointer(cInfo), unsafe.Pointer(cProps))
+	if cInfo == nil || cProps == nil {
+		return ImageFormatProperties2{}, NewVulkanError(ErrorOutOfHostMemory, "GetPhysicalDeviceImageFormatProperties2", "failed to allocate memory for image format query")
+	}
+
+	cInfo.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_IMAGE_FORMAT_INFO_2
+	cInfo.format = C.VkFormat(formatInfo.Format)
+	cInfo._type = C.VkImageType(formatInfo.Type)
+	cInfo.tiling = C.VkImageTiling(formatInfo.Tiling)
+	cInfo.usage = C.VkImageUsageFlags(formatInfo.Usage)
+	cInfo.flags = C.VkImageCreateFlags(formatInfo.Flags)
+	cProps.sType = C.VK_STRUCTURE_TYPE_IMAGE_FORMAT_PROPERTIES_2
+
+	var cCompression *C.VkImageCompressionPropertiesEXT
+	if formatInfo.ImageCompressionControl != nil {
+		var err error
+		if cInfo.pNext, err = imageCompressionControlToC("GetPhysicalDeviceImageFormatProperties2", formatInfo.ImageCompressionControl, nil, &allocations); err != nil {
+			return ImageFormatProperties2{}, err
+		}
+		cCompression = (*C.VkImageCompressionPropertiesEXT)(C.calloc(1, C.sizeof_VkImageCompressionPropertiesEXT))
+		if cCompression == nil {
+			return ImageFormatProperties2{}, NewVulkanError(ErrorOutOfHostMemory, "GetPhysicalDeviceImageFormatProperties2", "failed to allocate memory for image compression properties")
+		}
+		allocations = append(allocations, unsafe.Pointer(cCompression))
+		cCompression.sType = C.VK_STRUCTURE_TYPE_IMAGE_COMPRESSION_PROPERTIES_EXT
+		cProps.pNext = unsafe.Pointer(cCompression)
+	}
+
+	result := Result(C.vkGetPhysicalDeviceImageFormatProperties2(C.VkPhysicalDevice(physicalDevice), cInfo, cProps))
+	traceResult("vkGetPhysicalDeviceImageFormatProperties2", result)
+	if result != Success {
+		return ImageFormatProperties2{}, NewVulkanError(result, "GetPhysicalDeviceImageFormatProperties2", "image format combination not supported")
+	}
+
+	c := &cProps.imageFormatProperties
+	properties := ImageFormatProperties2{
+		ImageFormatProperties: ImageFormatProperties{
+			MaxExtent: Extent3D{
+				Width:  uint32(c.maxExtent.width),
+				Height: uint32(c.maxExtent.height),
+				Depth:  uint32(c.maxExtent.depth),
+			},
+			MaxMipLevels:    uint32(c.maxMipLevels),
+			MaxArrayLayers:  uint32(c.maxArrayLayers),
+			SampleCounts:    SampleCountFlags(c.sampleCounts),
+			MaxResourceSize: DeviceSize(c.maxResourceSize),
+		},
+	}
+	if cCompression != nil {
+		properties.ImageCompressionProperties = imageCompressionPropertiesFromC(cCompression)
+	}
+	return properties, nil
+}
+
+// FindSupportedFormat returns the first candidate format whose features for the given
+// tiling contain all requested feature bits, e.g. to pick a depth format at runtime
+func FindSupportedFormat(physicalDevice PhysicalDevice, candidates []Format, tiling ImageTiling, features FormatFeatureFlags) (Format, error) {
+	if physicalDevice == nil {
+		return FormatUndefined, NewValidationError("physicalDevice", "cannot be nil")
+	}
+	if len(candidates) == 0 {
+		return FormatUndefined, NewValidationError("candidates", "must contain at least one format")
+	}
+
+	for _, format := range candidates {
+		props := GetPhysicalDeviceFormatProperties(physicalDevice, format)
+
+		var supported FormatFeatureFlags
+		switch tiling {
+		case ImageTilingLinear:
+			supported = props.LinearTilingFeatures
+		case ImageTilingOptimal:
+			supported = props.OptimalTilingFeatures
+		}
+
+		if supported&features == features {
+			return format, nil
+		}
+	}
+
+	return FormatUndefined, NewVulkanError(ErrorFormatNotSupported, "FindSupportedFormat", "no candidate format supports the requested features")
+}
+
 // EnumerateDeviceExtensionProperties enumerates device extension properties
 func EnumerateDeviceExtensionProperties(physicalDevice PhysicalDevice, layerName string) ([]ExtensionProperties, error) {
 	var cLayerName *C.char
@@ -380,8 +813,9 @@ func EnumerateDeviceExtensionProperties(physicalDevice PhysicalDevice, layerName
 
 	var propertyCount C.uint32_t
 	result := Result(C.vkEnumerateDeviceExtensionProperties(C.VkPhysicalDevice(physicalDevice), cLayerName, &propertyCount, nil))
+	traceResult("vkEnumerateDeviceExtensionProperties", result)
 	if result != Success {
-		return nil, result
+		return nil, NewVulkanError(result, "EnumerateDeviceExtensionProperties", "failed to get extension count")
 	}
 
 	if propertyCount == 0 {
@@ -390,8 +824,9 @@ func EnumerateDeviceExtensionProperties(physicalDevice PhysicalDevice, layerName
 
 	cProperties := make([]C.VkExtensionProperties, propertyCount)
 	result = Result(C.vkEnumerateDeviceExtensionProperties(C.VkPhysicalDevice(physicalDevice), cLayerName, &propertyCount, &cProperties[0]))
+	traceResult("vkEnumerateDeviceExtensionProperties", result)
 	if result != Success {
-		return nil, result
+		return nil, NewVulkanError(result, "EnumerateDeviceExtensionProperties", "failed to enumerate extensions")
 	}
 
 	properties := make([]ExtensionProperties, propertyCount)
@@ -0,0 +1,93 @@
+//go:build cgo
+
+package vulkan
+
+import (
+	"errors"
+	"testing"
+	"unsafe"
+)
+
+// TestDeviceCreateInfoChain tests that only the set optional structs are chained, in field
+// order, each linked to the previous one
+func TestDeviceCreateInfoChain(t *testing.T) {
+	for _, chain := range deviceCreateInfoChain(&DeviceCreateInfo{}) {
+		if chain != nil {
+			t.Fatal("Expected no chained structs for an empty create info")
+		}
+	}
+
+	createInfo := &DeviceCreateInfo{
+		MeshShaderFeatures:        &PhysicalDeviceMeshShaderFeatures{MeshShader: true},
+		TimelineSemaphoreFeatures: &PhysicalDeviceTimelineSemaphoreFeatures{TimelineSemaphore: true},
+	}
+	var allocations []unsafe.Pointer
+	defer func() { freeAllocations(allocations) }()
+
+	var pNext unsafe.Pointer
+	chained := 0
+	for _, chain := range deviceCreateInfoChain(createInfo) {
+		if chain == nil {
+			continue
+		}
+		next, err := chain(pNext, &allocations)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		// Every struct starts with sType and pNext, so the new head must link to the old one
+		if link := (*struct {
+			sType int32
+			pNext unsafe.Pointer
+		})(next).pNext; link != pNext {
+			t.Errorf("Expected chained struct %d to link to the previous head", chained)
+		}
+		pNext = next
+		chained++
+	}
+	if chained != 2 {
+		t.Errorf("Expected 2 chained structs, got %d", chained)
+	}
+}
+
+// TestCreateDeviceFeatureConflicts tests that the 1.2 extension feature structs cannot be
+// chained next to the Vulkan 1.3 features, which the specification forbids
+func TestCreateDeviceFeatureConflicts(t *testing.T) {
+	queueCreateInfos := []DeviceQueueCreateInfo{{QueuePriorities: []float32{1.0}}}
+	tests := []struct {
+		name       string
+		createInfo *DeviceCreateInfo
+		wantParam  string
+	}{
+		{
+			name: "dynamic rendering with Vulkan 1.3 features",
+			createInfo: &DeviceCreateInfo{
+				QueueCreateInfos:         queueCreateInfos,
+				DynamicRenderingFeatures: &PhysicalDeviceDynamicRenderingFeatures{DynamicRendering: true},
+				Vulkan13Features:         &PhysicalDeviceVulkan13Features{DynamicRendering: true},
+			},
+			wantParam: "DynamicRenderingFeatures",
+		},
+		{
+			name: "synchronization2 with Vulkan 1.3 features",
+			createInfo: &DeviceCreateInfo{
+				QueueCreateInfos:         queueCreateInfos,
+				Synchronization2Features: &PhysicalDeviceSynchronization2Features{Synchronization2: true},
+				Vulkan13Features:         &PhysicalDeviceVulkan13Features{Synchronization2: true},
+			},
+			wantParam: "Synchronization2Features",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CreateDevice(PhysicalDevice(uintptr(0x1234)), tt.createInfo)
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %v", err)
+			}
+			if validationErr.Parameter != tt.wantParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.wantParam, validationErr.Parameter)
+			}
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package vulkan
+
+/*
+#include <vulkan/vulkan.h>
+#include <stdlib.h>
+
+// Function pointers for VK_EXT_device_fault functions
+// These need to be loaded dynamically at runtime.
+//
+// IMPORTANT: These are global static pointers and NOT thread-safe during loading.
+// LoadDeviceFaultFunctions must be called from a single thread during initialization
+// before any concurrent device fault API usage.
+static PFN_vkGetDeviceFaultInfoEXT pfn_vkGetDeviceFaultInfoEXT = NULL;
+
+static int loadDeviceFaultDeviceFunctions(VkDevice device) {
+    if (device == VK_NULL_HANDLE) {
+        return 0;
+    }
+    pfn_vkGetDeviceFaultInfoEXT = (PFN_vkGetDeviceFaultInfoEXT)
+        vkGetDeviceProcAddr(device, "vkGetDeviceFaultInfoEXT");
+
+    return pfn_vkGetDeviceFaultInfoEXT != NULL;
+}
+
+// Wrappers return VK_ERROR_EXTENSION_NOT_PRESENT if the function pointer is NULL.
+static VkResult call_vkGetDeviceFaultInfoEXT(VkDevice device, VkDeviceFaultCountsEXT* pFaultCounts, VkDeviceFaultInfoEXT* pFaultInfo) {
+    if (pfn_vkGetDeviceFaultInfoEXT == NULL) {
+        return VK_ERROR_EXTENSION_NOT_PRESENT;
+    }
+    return pfn_vkGetDeviceFaultInfoEXT(device, pFaultCounts, pFaultInfo);
+}
+*/
+import "C"
+
+import "unsafe"
+
+// ExtensionNameDeviceFault is the device fault reporting extension name
+const ExtensionNameDeviceFault = "VK_EXT_device_fault"
+
+// DeviceFaultAddressType describes what kind of access a reported fault address relates to
+type DeviceFaultAddressType int32
+
+const (
+	// DeviceFaultAddressTypeNone means the fault is not associated with an address
+	DeviceFaultAddressTypeNone                      DeviceFaultAddressType = C.VK_DEVICE_FAULT_ADDRESS_TYPE_NONE_EXT
+	DeviceFaultAddressTypeReadInvalid               DeviceFaultAddressType = C.VK_DEVICE_FAULT_ADDRESS_TYPE_READ_INVALID_EXT
+	DeviceFaultAddressTypeWriteInvalid              DeviceFaultAddressType = C.VK_DEVICE_FAULT_ADDRESS_TYPE_WRITE_INVALID_EXT
+	DeviceFaultAddressTypeExecuteInvalid            DeviceFaultAddressType = C.VK_DEVICE_FAULT_ADDRESS_TYPE_EXECUTE_INVALID_EXT
+	DeviceFaultAddressTypeInstructionPointerUnknown DeviceFaultAddressType = C.VK_DEVICE_FAULT_ADDRESS_TYPE_INSTRUCTION_POINTER_UNKNOWN_EXT
+	DeviceFaultAddressTypeInstructionPointerInvalid DeviceFaultAddressType = C.VK_DEVICE_FAULT_ADDRESS_TYPE_INSTRUCTION_POINTER_INVALID_EXT
+	DeviceFaultAddressTypeInstructionPointerFault   DeviceFaultAddressType = C.VK_DEVICE_FAULT_ADDRESS_TYPE_INSTRUCTION_POINTER_FAULT_EXT
+)
+
+// PhysicalDeviceFaultFeatures contains the device fault reporting features
+type PhysicalDeviceFaultFeatures struct {
+	// DeviceFault allows querying fault information with GetDeviceFaultInfoEXT
+	DeviceFault bool
+	// DeviceFaultVendorBinary allows retrieving the vendor-specific crash dump
+	DeviceFaultVendorBinary bool
+}
+
+// DeviceFaultAddressInfo describes a GPU virtual address involved in a fault. The faulting
+// address lies within ReportedAddress rounded down and up to a multiple of AddressPrecision.
+type DeviceFaultAddressInfo struct {
+	AddressType      DeviceFaultAddressType
+	ReportedAddress  DeviceAddress
+	AddressPrecision DeviceSize
+}
+
+// DeviceFaultVendorInfo is a vendor-specific fault description
+type DeviceFaultVendorInfo struct {
+	Description     string
+	VendorFaultCode uint64
+	VendorFaultData uint64
+}
+
+// DeviceFaultInfo describes the fault that caused a device loss
+type DeviceFaultInfo struct {
+	Description  string
+	AddressInfos []DeviceFaultAddressInfo
+	VendorInfos  []DeviceFaultVendorInfo
+	// VendorBinaryData is the vendor crash dump, which starts with a
+	// VkDeviceFaultVendorBinaryHeaderVersionOneEXT header. It is only reported when the
+	// DeviceFaultVendorBinary feature is enabled.
+	VendorBinaryData []byte
+}
+
+// LoadDeviceFaultFunctions loads VK_EXT_device_fault functions for a device.
+//
+// This function MUST be called after creating a logical device with the
+// VK_EXT_device_fault extension enabled and before querying fault information.
+//
+// IMPORTANT: This function is NOT thread-safe. Only one device is supported at a time;
+// calling this function again will overwrite previously loaded function pointers.
+//
+// Returns false if any device fault function could not be loaded.
+func LoadDeviceFaultFunctions(device Device) bool {
+	return C.loadDeviceFaultDeviceFunctions(C.VkDevice(device)) != 0
+}
+
+// deviceFaultFeaturesToC prepends a struct enabling the requested device fault features to
+// the pNext chain next. The struct is allocated in C memory and appended to allocations,
+// which the caller must free.
+func deviceFaultFeaturesToC(features *PhysicalDeviceFaultFeatures, next unsafe.Pointer, allocations *[]unsafe.Pointer) (unsafe.Pointer, error) {
+	cFault := (*C.VkPhysicalDeviceFaultFeaturesEXT)(C.calloc(1, C.sizeof_VkPhysicalDeviceFaultFeaturesEXT))
+	if cFault == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "CreateDevice", "failed to allocate memory for device fault features")
+	}
+	*allocations = append(*allocations, unsafe.Pointer(cFault))
+
+	cFault.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_FAULT_FEATURES_EXT
+	cFault.pNext = next
+	cFault.deviceFault = boolToVkBool32(features.DeviceFault)
+	cFault.deviceFaultVendorBinary = boolToVkBool32(features.DeviceFaultVendorBinary)
+
+	return unsafe.Pointer(cFault), nil
+}
+
+// GetPhysicalDeviceFaultFeaturesEXT queries device fault reporting support
+func GetPhysicalDeviceFaultFeaturesEXT(physicalDevice PhysicalDevice) PhysicalDeviceFaultFeatures {
+	cFeatures2 := (*C.VkPhysicalDeviceFeatures2)(C.calloc(1, C.sizeof_VkPhysicalDeviceFeatures2))
+	cFault := (*C.VkPhysicalDeviceFaultFeaturesEXT)(C.calloc(1, C.sizeof_VkPhysicalDeviceFaultFeaturesEXT))
+	defer C.free(unsafe.Pointer(cFeatures2))
+	defer C.free(unsafe.Pointer(cFault))
+	if cFeatures2 == nil || cFault == nil {
+		return PhysicalDeviceFaultFeatures{}
+	}
+
+	cFeatures2.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_FEATURES_2
+	cFeatures2.pNext = unsafe.Pointer(cFault)
+	cFault.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_FAULT_FEATURES_EXT
+
+	C.vkGetPhysicalDeviceFeatures2(C.VkPhysicalDevice(physicalDevice), cFeatures2)
+
+	return PhysicalDeviceFaultFeatures{
+		DeviceFault:             vkBool32ToBool(cFault.deviceFault),
+		DeviceFaultVendorBinary: vkBool32ToBool(cFault.deviceFaultVendorBinary),
+	}
+}
+
+// GetDeviceFaultInfoEXT reports what caused a device loss. Call it after an operation
+// returned ErrorDeviceLost; the DeviceFault feature must have been enabled.
+// Returns an error if LoadDeviceFaultFunctions was not called.
+func GetDeviceFaultInfoEXT(device Device) (*DeviceFaultInfo, error) {
+	if device == nil {
+		return nil, NewValidationError("device", "cannot be nil")
+	}
+
+	var cCounts C.VkDeviceFaultCountsEXT
+	cCounts.sType = C.VK_STRUCTURE_TYPE_DEVICE_FAULT_COUNTS_EXT
+	result := Result(C.call_vkGetDeviceFaultInfoEXT(C.VkDevice(device), &cCounts, nil))
+	traceResult("vkGetDeviceFaultInfoEXT", result)
+	if result != Success {
+		return nil, NewVulkanError(result, "GetDeviceFaultInfoEXT", "failed to query device fault counts")
+	}
+
+	var cInfo C.VkDeviceFaultInfoEXT
+	cInfo.sType = C.VK_STRUCTURE_TYPE_DEVICE_FAULT_INFO_EXT
+
+	if cCounts.addressInfoCount > 0 {
+		cInfo.pAddressInfos = (*C.VkDeviceFaultAddressInfoEXT)(C.calloc(C.size_t(cCounts.addressInfoCount), C.sizeof_VkDeviceFaultAddressInfoEXT))
+		if cInfo.pAddressInfos == nil {
+			return nil, NewVulkanError(ErrorOutOfHostMemory, "GetDeviceFaultInfoEXT", "failed to allocate memory for fault address info")
+		}
+		defer C.free(unsafe.Pointer(cInfo.pAddressInfos))
+	}
+	if cCounts.vendorInfoCount > 0 {
+		cInfo.pVendorInfos = (*C.VkDeviceFaultVendorInfoEXT)(C.calloc(C.size_t(cCounts.vendorInfoCount), C.sizeof_VkDeviceFaultVendorInfoEXT))
+		if cInfo.pVendorInfos == nil {
+			return nil, NewVulkanError(ErrorOutOfHostMemory, "GetDeviceFaultInfoEXT", "failed to allocate memory for fault vendor info")
+		}
+		defer C.free(unsafe.Pointer(cInfo.pVendorInfos))
+	}
+	if cCounts.vendorBinarySize > 0 {
+		cInfo.pVendorBinaryData = C.malloc(C.size_t(cCounts.vendorBinarySize))
+		if cInfo.pVendorBinaryData == nil {
+			return nil, NewVulkanError(ErrorOutOfHostMemory, "GetDeviceFaultInfoEXT", "failed to allocate memory for fault vendor binary")
+		}
+		defer C.free(cInfo.pVendorBinaryData)
+	}
+
+	// Incomplete means the fault data grew between the calls; what was written is still valid
+	result = Result(C.call_vkGetDeviceFaultInfoEXT(C.VkDevice(device), &cCounts, &cInfo))
+	traceResult("vkGetDeviceFaultInfoEXT", result)
+	if result != Success && result != Incomplete {
+		return nil, NewVulkanError(result, "GetDeviceFaultInfoEXT", "failed to get device fault info")
+	}
+
+	info := &DeviceFaultInfo{
+		Description: C.GoString(&cInfo.description[0]),
+	}
+	if cCounts.addressInfoCount > 0 {
+		for _, cAddress := range unsafe.Slice(cInfo.pAddressInfos, cCounts.addressInfoCount) {
+			info.AddressInfos = append(info.AddressInfos, DeviceFaultAddressInfo{
+				AddressType:      DeviceFaultAddressType(cAddress.addressType),
+				ReportedAddress:  DeviceAddress(cAddress.reportedAddress),
+				AddressPrecision: DeviceSize(cAddress.addressPrecision),
+			})
+		}
+	}
+	if cCounts.vendorInfoCount > 0 {
+		for _, cVendor := range unsafe.Slice(cInfo.pVendorInfos, cCounts.vendorInfoCount) {
+			info.VendorInfos = append(info.VendorInfos, DeviceFaultVendorInfo{
+				Description:     C.GoString(&cVendor.description[0]),
+				VendorFaultCode: uint64(cVendor.vendorFaultCode),
+				VendorFaultData: uint64(cVendor.vendorFaultData),
+			})
+		}
+	}
+	if cCounts.vendorBinarySize > 0 {
+		info.VendorBinaryData = C.GoBytes(cInfo.pVendorBinaryData, C.int(cCounts.vendorBinarySize))
+	}
+	return info, nil
+}
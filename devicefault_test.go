@@ -0,0 +1,29 @@
+//go:build cgo
+
+package vulkan
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestGetDeviceFaultInfoValidation tests input validation and the unloaded-extension error of GetDeviceFaultInfoEXT
+func TestGetDeviceFaultInfoValidation(t *testing.T) {
+	_, err := GetDeviceFaultInfoEXT(nil)
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+	}
+	if validationErr.Parameter != "device" {
+		t.Errorf("Expected error for parameter 'device', got '%s'", validationErr.Parameter)
+	}
+
+	info, err := GetDeviceFaultInfoEXT(Device(uintptr(0x1234)))
+	if !errors.Is(err, ErrorExtensionNotPresent) {
+		t.Errorf("Expected ErrorExtensionNotPresent, got %v", err)
+	}
+	if info != nil {
+		t.Errorf("Expected no fault info, got %+v", info)
+	}
+}
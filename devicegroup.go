@@ -0,0 +1,174 @@
+package vulkan
+
+/*
+#include <vulkan/vulkan.h>
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// ExtensionNameDeviceGroupCreation is the device group creation instance extension name.
+// Device groups are core in Vulkan 1.1; the extension is only needed on 1.0 instances.
+const ExtensionNameDeviceGroupCreation = "VK_KHR_device_group_creation"
+
+// ExtensionNameDeviceGroup is the device group device extension name. Device groups are core
+// in Vulkan 1.1; the extension is only needed on 1.0 devices.
+const ExtensionNameDeviceGroup = "VK_KHR_device_group"
+
+// MaxDeviceGroupSize is the maximum number of physical devices in a device group
+const MaxDeviceGroupSize = C.VK_MAX_DEVICE_GROUP_SIZE
+
+// PipelineCreateDispatchBaseBit allows a compute pipeline to be dispatched with
+// CmdDispatchBase and a non-zero base workgroup
+const PipelineCreateDispatchBaseBit PipelineCreateFlags = C.VK_PIPELINE_CREATE_DISPATCH_BASE_BIT
+
+// PhysicalDeviceGroupProperties describes a set of physical devices that can be driven as a
+// single logical device, e.g. GPUs linked with SLI or CrossFire
+type PhysicalDeviceGroupProperties struct {
+	// PhysicalDevices lists the devices in the group. The index of a device in this slice is
+	// its device index, and bit i of a device mask selects PhysicalDevices[i].
+	PhysicalDevices []PhysicalDevice
+	// SubsetAllocation means memory can be allocated on a subset of the devices with
+	// MemoryAllocateInfo.DeviceMask. Otherwise every allocation is made on all of them.
+	SubsetAllocation bool
+}
+
+// DeviceGroupDeviceCreateInfo creates a logical device spanning several physical devices
+type DeviceGroupDeviceCreateInfo struct {
+	// PhysicalDevices are the devices of one PhysicalDeviceGroupProperties, in the order
+	// that defines their device indices. It must include the physical device passed to
+	// CreateDevice.
+	PhysicalDevices []PhysicalDevice
+}
+
+// EnumeratePhysicalDeviceGroups lists the device groups of an instance. Every physical device
+// is in exactly one group, so a system without linked GPUs returns one single-device group
+// per physical device.
+func EnumeratePhysicalDeviceGroups(instance Instance) ([]PhysicalDeviceGroupProperties, error) {
+	if instance == nil {
+		return nil, NewValidationError("instance", "cannot be nil")
+	}
+
+	var groupCount C.uint32_t
+	result := Result(C.vkEnumeratePhysicalDeviceGroups(C.VkInstance(instance), &groupCount, nil))
+	traceResult("vkEnumeratePhysicalDeviceGroups", result)
+	if result != Success {
+		return nil, NewVulkanError(result, "EnumeratePhysicalDeviceGroups", "failed to get physical device group count")
+	}
+	if groupCount == 0 {
+		return nil, nil
+	}
+
+	cGroupsPtr := (*C.VkPhysicalDeviceGroupProperties)(C.calloc(C.size_t(groupCount), C.sizeof_VkPhysicalDeviceGroupProperties))
+	if cGroupsPtr == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "EnumeratePhysicalDeviceGroups", "failed to allocate memory for physical device groups")
+	}
+	defer C.free(unsafe.Pointer(cGroupsPtr))
+
+	cGroups := unsafe.Slice(cGroupsPtr, groupCount)
+	for i := range cGroups {
+		cGroups[i].sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_GROUP_PROPERTIES
+	}
+
+	result = Result(C.vkEnumeratePhysicalDeviceGroups(C.VkInstance(instance), &groupCount, cGroupsPtr))
+	traceResult("vkEnumeratePhysicalDeviceGroups", result)
+	if result != Success && result != Incomplete {
+		return nil, NewVulkanError(result, "EnumeratePhysicalDeviceGroups", "failed to enumerate physical device groups")
+	}
+
+	groups := make([]PhysicalDeviceGroupProperties, groupCount)
+	for i := range groups {
+		cGroup := &cGroups[i]
+		devices := make([]PhysicalDevice, cGroup.physicalDeviceCount)
+		for j := range devices {
+			devices[j] = PhysicalDevice(cGroup.physicalDevices[j])
+		}
+		groups[i] = PhysicalDeviceGroupProperties{
+			PhysicalDevices:  devices,
+			SubsetAllocation: vkBool32ToBool(cGroup.subsetAllocation),
+		}
+	}
+	return groups, nil
+}
+
+// validateDeviceGroupDeviceCreateInfo checks that a device group lists distinct devices
+// including the physical device the logical device is created from
+func validateDeviceGroupDeviceCreateInfo(physicalDevice PhysicalDevice, info *DeviceGroupDeviceCreateInfo) error {
+	if len(info.PhysicalDevices) == 0 {
+		return nil
+	}
+	if len(info.PhysicalDevices) > MaxDeviceGroupSize {
+		return NewValidationError("DeviceGroup.PhysicalDevices", fmt.Sprintf("exceeds maximum of %d physical devices", MaxDeviceGroupSize))
+	}
+
+	found := false
+	for i, device := range info.PhysicalDevices {
+		if device == nil {
+			return NewValidationError("DeviceGroup.PhysicalDevices", fmt.Sprintf("physical device %d cannot be nil", i))
+		}
+		for _, other := range info.PhysicalDevices[:i] {
+			if other == device {
+				return NewValidationError("DeviceGroup.PhysicalDevices", fmt.Sprintf("physical device %d is listed more than once", i))
+			}
+		}
+		if device == physicalDevice {
+			found = true
+		}
+	}
+	if !found {
+		return NewValidationError("DeviceGroup.PhysicalDevices", "must include the physical device the device is created from")
+	}
+	return nil
+}
+
+// deviceGroupDeviceCreateInfoToC prepends a VkDeviceGroupDeviceCreateInfo to the pNext chain
+// next. The struct and device array are allocated in C memory and appended to allocations,
+// which the caller must free.
+func deviceGroupDeviceCreateInfoToC(info *DeviceGroupDeviceCreateInfo, next unsafe.Pointer, allocations *[]unsafe.Pointer) (unsafe.Pointer, error) {
+	cGroup := (*C.VkDeviceGroupDeviceCreateInfo)(C.calloc(1, C.sizeof_VkDeviceGroupDeviceCreateInfo))
+	if cGroup == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "CreateDevice", "failed to allocate memory for device group create info")
+	}
+	*allocations = append(*allocations, unsafe.Pointer(cGroup))
+
+	cGroup.sType = C.VK_STRUCTURE_TYPE_DEVICE_GROUP_DEVICE_CREATE_INFO
+	cGroup.pNext = next
+
+	if len(info.PhysicalDevices) > 0 {
+		cDevicesPtr := (*C.VkPhysicalDevice)(C.calloc(C.size_t(len(info.PhysicalDevices)), C.size_t(unsafe.Sizeof(C.VkPhysicalDevice(nil)))))
+		if cDevicesPtr == nil {
+			return nil, NewVulkanError(ErrorOutOfHostMemory, "CreateDevice", "failed to allocate memory for device group physical devices")
+		}
+		*allocations = append(*allocations, unsafe.Pointer(cDevicesPtr))
+
+		cDevices := unsafe.Slice(cDevicesPtr, len(info.PhysicalDevices))
+		for i, device := range info.PhysicalDevices {
+			cDevices[i] = C.VkPhysicalDevice(device)
+		}
+		cGroup.physicalDeviceCount = C.uint32_t(len(info.PhysicalDevices))
+		cGroup.pPhysicalDevices = cDevicesPtr
+	}
+
+	return unsafe.Pointer(cGroup), nil
+}
+
+// CmdSetDeviceMask sets which devices of a device group execute the following commands.
+// Bit i selects the device at index i of DeviceGroupDeviceCreateInfo.PhysicalDevices. The
+// mask must be non-zero and a subset of the CommandBufferSubmitInfo.DeviceMask the command
+// buffer is submitted with.
+func CmdSetDeviceMask(commandBuffer CommandBuffer, deviceMask uint32) {
+	C.vkCmdSetDeviceMask(C.VkCommandBuffer(commandBuffer), C.uint32_t(deviceMask))
+}
+
+// CmdDispatchBase dispatches compute work with workgroup IDs starting at the base instead of
+// zero, which lets a dispatch be split between the devices of a group with CmdSetDeviceMask.
+// A non-zero base requires a pipeline created with PipelineCreateDispatchBaseBit.
+func CmdDispatchBase(commandBuffer CommandBuffer, baseGroupX, baseGroupY, baseGroupZ, groupCountX, groupCountY, groupCountZ uint32) {
+	C.vkCmdDispatchBase(C.VkCommandBuffer(commandBuffer),
+		C.uint32_t(baseGroupX), C.uint32_t(baseGroupY), C.uint32_t(baseGroupZ),
+		C.uint32_t(groupCountX), C.uint32_t(groupCountY), C.uint32_t(groupCountZ))
+}
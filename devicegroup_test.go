@@ -0,0 +1,98 @@
+//go:build cgo
+
+package vulkan
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestDeviceGroupValidation tests input validation of device group creation and allocation
+func TestDeviceGroupValidation(t *testing.T) {
+	fakePhysicalDevice := PhysicalDevice(uintptr(0x1234))
+	otherPhysicalDevice := PhysicalDevice(uintptr(0x5678))
+	queues := []DeviceQueueCreateInfo{{QueueFamilyIndex: 0, QueuePriorities: []float32{1.0}}}
+
+	tests := []struct {
+		name       string
+		call       func() error
+		errorParam string
+	}{
+		{
+			name: "nil instance",
+			call: func() error {
+				_, err := EnumeratePhysicalDeviceGroups(nil)
+				return err
+			},
+			errorParam: "instance",
+		},
+		{
+			name: "group without creating device",
+			call: func() error {
+				_, err := CreateDevice(fakePhysicalDevice, &DeviceCreateInfo{
+					QueueCreateInfos: queues,
+					DeviceGroup:      &DeviceGroupDeviceCreateInfo{PhysicalDevices: []PhysicalDevice{otherPhysicalDevice}},
+				})
+				return err
+			},
+			errorParam: "DeviceGroup.PhysicalDevices",
+		},
+		{
+			name: "duplicate group device",
+			call: func() error {
+				_, err := CreateDevice(fakePhysicalDevice, &DeviceCreateInfo{
+					QueueCreateInfos: queues,
+					DeviceGroup: &DeviceGroupDeviceCreateInfo{
+						PhysicalDevices: []PhysicalDevice{fakePhysicalDevice, otherPhysicalDevice, fakePhysicalDevice},
+					},
+				})
+				return err
+			},
+			errorParam: "DeviceGroup.PhysicalDevices",
+		},
+		{
+			name: "nil group device",
+			call: func() error {
+				_, err := CreateDevice(fakePhysicalDevice, &DeviceCreateInfo{
+					QueueCreateInfos: queues,
+					DeviceGroup:      &DeviceGroupDeviceCreateInfo{PhysicalDevices: []PhysicalDevice{fakePhysicalDevice, nil}},
+				})
+				return err
+			},
+			errorParam: "DeviceGroup.PhysicalDevices",
+		},
+		{
+			name: "device mask without flag",
+			call: func() error {
+				_, err := AllocateMemory(Device(uintptr(0x9abc)), &MemoryAllocateInfo{AllocationSize: 1024, DeviceMask: 0x1})
+				return err
+			},
+			errorParam: "DeviceMask",
+		},
+		{
+			name: "flag without device mask",
+			call: func() error {
+				_, err := AllocateMemory(Device(uintptr(0x9abc)), &MemoryAllocateInfo{
+					AllocationSize: 1024,
+					Flags:          MemoryAllocateDeviceMaskBit,
+				})
+				return err
+			},
+			errorParam: "DeviceMask",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+}
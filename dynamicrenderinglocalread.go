@@ -0,0 +1,182 @@
+package vulkan
+
+/*
+#include <vulkan/vulkan.h>
+#include <stdlib.h>
+
+// Function pointers for VK_KHR_dynamic_rendering_local_read functions. The core Vulkan 1.4
+// names are tried first and the KHR names are used as a fallback; both share a signature.
+//
+// IMPORTANT: These are global static pointers and NOT thread-safe during loading.
+// LoadDynamicRenderingLocalReadFunctions must be called from a single thread during
+// initialization before any concurrent local read API usage.
+static PFN_vkCmdSetRenderingAttachmentLocationsKHR pfn_vkCmdSetRenderingAttachmentLocationsKHR = NULL;
+static PFN_vkCmdSetRenderingInputAttachmentIndicesKHR pfn_vkCmdSetRenderingInputAttachmentIndicesKHR = NULL;
+
+static int loadDynamicRenderingLocalReadDeviceFunctions(VkDevice device) {
+    if (device == VK_NULL_HANDLE) {
+        return 0;
+    }
+    pfn_vkCmdSetRenderingAttachmentLocationsKHR = (PFN_vkCmdSetRenderingAttachmentLocationsKHR)
+        vkGetDeviceProcAddr(device, "vkCmdSetRenderingAttachmentLocations");
+    if (pfn_vkCmdSetRenderingAttachmentLocationsKHR == NULL) {
+        pfn_vkCmdSetRenderingAttachmentLocationsKHR = (PFN_vkCmdSetRenderingAttachmentLocationsKHR)
+            vkGetDeviceProcAddr(device, "vkCmdSetRenderingAttachmentLocationsKHR");
+    }
+    pfn_vkCmdSetRenderingInputAttachmentIndicesKHR = (PFN_vkCmdSetRenderingInputAttachmentIndicesKHR)
+        vkGetDeviceProcAddr(device, "vkCmdSetRenderingInputAttachmentIndices");
+    if (pfn_vkCmdSetRenderingInputAttachmentIndicesKHR == NULL) {
+        pfn_vkCmdSetRenderingInputAttachmentIndicesKHR = (PFN_vkCmdSetRenderingInputAttachmentIndicesKHR)
+            vkGetDeviceProcAddr(device, "vkCmdSetRenderingInputAttachmentIndicesKHR");
+    }
+
+    return pfn_vkCmdSetRenderingAttachmentLocationsKHR != NULL &&
+           pfn_vkCmdSetRenderingInputAttachmentIndicesKHR != NULL;
+}
+
+// Command buffer wrapper functions return 1 on success, 0 if function pointer is NULL.
+static int call_vkCmdSetRenderingAttachmentLocationsKHR(
+    VkCommandBuffer commandBuffer,
+    uint32_t colorAttachmentCount,
+    const uint32_t* pColorAttachmentLocations) {
+    if (pfn_vkCmdSetRenderingAttachmentLocationsKHR == NULL) {
+        return 0;
+    }
+    VkRenderingAttachmentLocationInfoKHR locationInfo = {
+        .sType = VK_STRUCTURE_TYPE_RENDERING_ATTACHMENT_LOCATION_INFO_KHR,
+        .pNext = NULL,
+        .colorAttachmentCount = colorAttachmentCount,
+        .pColorAttachmentLocations = pColorAttachmentLocations,
+    };
+    pfn_vkCmdSetRenderingAttachmentLocationsKHR(commandBuffer, &locationInfo);
+    return 1;
+}
+
+static int call_vkCmdSetRenderingInputAttachmentIndicesKHR(
+    VkCommandBuffer commandBuffer,
+    uint32_t colorAttachmentCount,
+    const uint32_t* pColorAttachmentInputIndices,
+    const uint32_t* pDepthInputAttachmentIndex,
+    const uint32_t* pStencilInputAttachmentIndex) {
+    if (pfn_vkCmdSetRenderingInputAttachmentIndicesKHR == NULL) {
+        return 0;
+    }
+    VkRenderingInputAttachmentIndexInfoKHR indexInfo = {
+        .sType = VK_STRUCTURE_TYPE_RENDERING_INPUT_ATTACHMENT_INDEX_INFO_KHR,
+        .pNext = NULL,
+        .colorAttachmentCount = colorAttachmentCount,
+        .pColorAttachmentInputIndices = pColorAttachmentInputIndices,
+        .pDepthInputAttachmentIndex = pDepthInputAttachmentIndex,
+        .pStencilInputAttachmentIndex = pStencilInputAttachmentIndex,
+    };
+    pfn_vkCmdSetRenderingInputAttachmentIndicesKHR(commandBuffer, &indexInfo);
+    return 1;
+}
+*/
+import "C"
+
+import "fmt"
+
+// ExtensionNameDynamicRenderingLocalRead is the dynamic rendering local read extension name.
+// The extension is core in Vulkan 1.4.
+const ExtensionNameDynamicRenderingLocalRead = "VK_KHR_dynamic_rendering_local_read"
+
+// RenderingInputAttachmentIndexInfo maps the attachments of the current dynamic render pass
+// to shader input attachment indices
+type RenderingInputAttachmentIndexInfo struct {
+	// ColorAttachmentInputIndices holds the InputAttachmentIndex of each color attachment, or
+	// AttachmentUnused if the attachment is not read as an input
+	ColorAttachmentInputIndices []uint32
+	// DepthInputAttachmentIndex is the depth attachment's InputAttachmentIndex; nil means the
+	// depth attachment is read through an input attachment without an index decoration
+	DepthInputAttachmentIndex *uint32
+	// StencilInputAttachmentIndex is the stencil attachment's InputAttachmentIndex; nil means
+	// the stencil attachment is read through an input attachment without an index decoration
+	StencilInputAttachmentIndex *uint32
+}
+
+// LoadDynamicRenderingLocalReadFunctions loads the dynamic rendering local read functions for
+// a device, using the core Vulkan 1.4 commands when available.
+//
+// This function MUST be called after creating a logical device with the
+// VK_KHR_dynamic_rendering_local_read extension enabled, or a Vulkan 1.4 device with the
+// DynamicRenderingLocalRead feature, and before remapping attachments.
+//
+// IMPORTANT: This function is NOT thread-safe. Only one device is supported at a time;
+// calling this function again will overwrite previously loaded function pointers.
+//
+// Returns false if any local read function could not be loaded.
+func LoadDynamicRenderingLocalReadFunctions(device Device) bool {
+	return C.loadDynamicRenderingLocalReadDeviceFunctions(C.VkDevice(device)) != 0
+}
+
+// validateAttachmentMapping checks that no two attachments share a location or index
+func validateAttachmentMapping(param string, values []uint32) error {
+	seen := make(map[uint32]bool, len(values))
+	for i, value := range values {
+		if value == AttachmentUnused {
+			continue
+		}
+		if seen[value] {
+			return NewValidationError(fmt.Sprintf("%s[%d]", param, i), "is already used by another attachment")
+		}
+		seen[value] = true
+	}
+	return nil
+}
+
+// CmdSetRenderingAttachmentLocationsKHR remaps the color attachments of the current dynamic
+// render pass to fragment shader output locations. locations[i] is the output location of
+// color attachment i, or AttachmentUnused to stop writing it, which lets one render pass
+// write a G-buffer and then read it in a later lighting pass with input attachments.
+// Returns an error if LoadDynamicRenderingLocalReadFunctions was not called.
+func CmdSetRenderingAttachmentLocationsKHR(commandBuffer CommandBuffer, locations []uint32) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+	if len(locations) == 0 {
+		return NewValidationError("locations", "cannot be empty")
+	}
+	if err := validateAttachmentMapping("locations", locations); err != nil {
+		return err
+	}
+
+	if C.call_vkCmdSetRenderingAttachmentLocationsKHR(C.VkCommandBuffer(commandBuffer), C.uint32_t(len(locations)),
+		(*C.uint32_t)(&locations[0])) == 0 {
+		return NewVulkanError(ErrorExtensionNotPresent, "CmdSetRenderingAttachmentLocationsKHR", "dynamic rendering local read extension not loaded - call LoadDynamicRenderingLocalReadFunctions first")
+	}
+	return nil
+}
+
+// CmdSetRenderingInputAttachmentIndicesKHR maps the attachments of the current dynamic render
+// pass to the input attachment indices fragment shaders read them through.
+// Returns an error if LoadDynamicRenderingLocalReadFunctions was not called.
+func CmdSetRenderingInputAttachmentIndicesKHR(commandBuffer CommandBuffer, indexInfo *RenderingInputAttachmentIndexInfo) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+	if indexInfo == nil {
+		return NewValidationError("indexInfo", "cannot be nil")
+	}
+	if len(indexInfo.ColorAttachmentInputIndices) == 0 {
+		return NewValidationError("indexInfo.ColorAttachmentInputIndices", "cannot be empty")
+	}
+	if err := validateAttachmentMapping("indexInfo.ColorAttachmentInputIndices", indexInfo.ColorAttachmentInputIndices); err != nil {
+		return err
+	}
+
+	var cDepthIndex, cStencilIndex *C.uint32_t
+	if indexInfo.DepthInputAttachmentIndex != nil {
+		cDepthIndex = (*C.uint32_t)(indexInfo.DepthInputAttachmentIndex)
+	}
+	if indexInfo.StencilInputAttachmentIndex != nil {
+		cStencilIndex = (*C.uint32_t)(indexInfo.StencilInputAttachmentIndex)
+	}
+
+	if C.call_vkCmdSetRenderingInputAttachmentIndicesKHR(C.VkCommandBuffer(commandBuffer),
+		C.uint32_t(len(indexInfo.ColorAttachmentInputIndices)), (*C.uint32_t)(&indexInfo.ColorAttachmentInputIndices[0]),
+		cDepthIndex, cStencilIndex) == 0 {
+		return NewVulkanError(ErrorExtensionNotPresent, "CmdSetRenderingInputAttachmentIndicesKHR", "dynamic rendering local read extension not loaded - call LoadDynamicRenderingLocalReadFunctions first")
+	}
+	return nil
+}
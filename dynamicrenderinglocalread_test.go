@@ -0,0 +1,72 @@
+//go:build cgo
+
+package vulkan
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestDynamicRenderingLocalReadValidation tests attachment mapping validation and the unloaded-extension error
+func TestDynamicRenderingLocalReadValidation(t *testing.T) {
+	fakeCommandBuffer := CommandBuffer(uintptr(0x1234))
+
+	tests := []struct {
+		name       string
+		call       func() error
+		errorParam string
+	}{
+		{
+			name: "no locations",
+			call: func() error {
+				return CmdSetRenderingAttachmentLocationsKHR(fakeCommandBuffer, nil)
+			},
+			errorParam: "locations",
+		},
+		{
+			name: "duplicate location",
+			call: func() error {
+				return CmdSetRenderingAttachmentLocationsKHR(fakeCommandBuffer, []uint32{0, 1, 1})
+			},
+			errorParam: "locations[2]",
+		},
+		{
+			name: "nil index info",
+			call: func() error {
+				return CmdSetRenderingInputAttachmentIndicesKHR(fakeCommandBuffer, nil)
+			},
+			errorParam: "indexInfo",
+		},
+		{
+			name: "duplicate input index",
+			call: func() error {
+				return CmdSetRenderingInputAttachmentIndicesKHR(fakeCommandBuffer, &RenderingInputAttachmentIndexInfo{
+					ColorAttachmentInputIndices: []uint32{2, AttachmentUnused, 2},
+				})
+			},
+			errorParam: "indexInfo.ColorAttachmentInputIndices[2]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+
+	// Unused attachments may repeat; without LoadDynamicRenderingLocalReadFunctions the
+	// command reports the missing extension
+	err := CmdSetRenderingAttachmentLocationsKHR(fakeCommandBuffer, []uint32{0, AttachmentUnused, AttachmentUnused})
+	var vulkanErr *VulkanError
+	if !errors.As(err, &vulkanErr) || vulkanErr.Result != ErrorExtensionNotPresent {
+		t.Errorf("Expected ErrorExtensionNotPresent, got %v", err)
+	}
+}
@@ -1,5 +1,15 @@
 package vulkan
 
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrTimeout is returned by the timeout-based wait helpers when the timeout elapses
+// before the wait completes. VK_TIMEOUT is a success code rather than an error, so it
+// is reported through this sentinel instead of a VulkanError.
+var ErrTimeout = errors.New("vulkan: wait timed out")
+
 // VulkanError represents a structured Vulkan error with additional context
 type VulkanError struct {
 	Result    Result
@@ -35,6 +45,16 @@ func NewVulkanError(result Result, operation string, details string) *VulkanErro
 	}
 }
 
+// wrapError reports err, returned by a wrapper a helper called, as a VulkanError for
+// operation. The Result is taken from anywhere in err's chain, or is fallback when err
+// carries none, such as a ValidationError. err stays reachable through errors.Is and
+// errors.As.
+func wrapError(err error, fallback Result, operation, details string) error {
+	result := fallback
+	errors.As(err, &result)
+	return fmt.Errorf("%w: %w", NewVulkanError(result, operation, details), err)
+}
+
 // ValidationError represents input validation errors
 type ValidationError struct {
 	Parameter string
@@ -36,6 +36,17 @@ func main() {
 		fmt.Printf("  - %s: %s\n", layer.LayerName, layer.Description)
 	}
 
+	// Request Vulkan 1.3, or the loader's version if it is older
+	apiVersion := vulkan.Version13
+	loaderVersion, err := vulkan.EnumerateInstanceVersion()
+	if err != nil {
+		log.Fatalf("Failed to query instance version: %v", err)
+	}
+	if loaderVersion < apiVersion {
+		fmt.Printf("\nLoader supports Vulkan %s, requesting it instead of %s\n", loaderVersion, apiVersion)
+		apiVersion = loaderVersion
+	}
+
 	// Create Vulkan instance
 	fmt.Println("\n3. Creating Vulkan instance...")
 	instanceCreateInfo := &vulkan.InstanceCreateInfo{
@@ -44,7 +55,7 @@ func main() {
 			ApplicationVersion: vulkan.MakeVersion(1, 0, 0),
 			EngineName:         "Go Vulkan Engine",
 			EngineVersion:      vulkan.MakeVersion(1, 0, 0),
-			APIVersion:         vulkan.Version13, // Use 1.3 since 1.4 may not be available
+			APIVersion:         apiVersion,
 		},
 		// Leave layers and extensions empty for now to avoid CGO issues
 		EnabledLayerNames:     []string{},
@@ -77,9 +88,7 @@ func main() {
 		fmt.Printf("  Device %d: %s\n", i, props.DeviceName)
 		fmt.Printf("    Type: %d, Vendor ID: 0x%x, Device ID: 0x%x\n",
 			props.DeviceType, props.VendorID, props.DeviceID)
-		fmt.Printf("    API Version: %d.%d.%d, Driver Version: %d.%d.%d\n",
-			props.APIVersion.Major(), props.APIVersion.Minor(), props.APIVersion.Patch(),
-			props.DriverVersion.Major(), props.DriverVersion.Minor(), props.DriverVersion.Patch())
+		fmt.Printf("    API Version: %s, Driver Version: %s\n", props.APIVersion, props.DriverVersion)
 
 		if i == 0 {
 			selectedDevice = device // Use the first device
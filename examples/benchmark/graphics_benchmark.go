@@ -1,7 +1,9 @@
 package main
 
 import (
+	"context"
 	"encoding/csv"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
@@ -50,6 +52,7 @@ type BenchmarkApp struct {
 	device         vulkan.Device
 	graphicsQueue  vulkan.Queue
 	commandPool    vulkan.CommandPool
+	queueFamilies  vulkan.QueueFamilyIndices
 
 	// Test configuration
 	testMode    TestMode
@@ -82,6 +85,7 @@ type BenchmarkApp struct {
 
 	// Error detection
 	artifactDetection bool
+	gpuValidation     bool
 	errorCount        uint64
 	lastErrorTime     time.Time
 
@@ -184,6 +188,9 @@ func showDetailedHelp() {
 	fmt.Println("  # Ultra quality stress test with artifact detection")
 	fmt.Println("  go run graphics_benchmark.go -quality=ultra -artifacts")
 	fmt.Println()
+	fmt.Println("  # Investigate artifacts with GPU-assisted validation")
+	fmt.Println("  go run graphics_benchmark.go -artifacts -gpu-validation")
+	fmt.Println()
 	fmt.Println("  # Custom resolution benchmark")
 	fmt.Println("  go run graphics_benchmark.go -resolution=2560x1440 -mode=benchmark -duration=2m")
 	fmt.Println()
@@ -323,6 +330,7 @@ func main() {
 		simMode         = flag.Bool("sim", false, "Force simulation mode (no Vulkan)")
 		listResolutions = flag.Bool("list-res", false, "List available resolutions")
 		verboseMode     = flag.Bool("verbose", false, "Enable verbose logging")
+		gpuValidation   = flag.Bool("gpu-validation", false, "Enable GPU-assisted validation (requires the Khronos validation layer)")
 	)
 	flag.Parse()
 
@@ -360,6 +368,7 @@ func main() {
 		targetFPS:         config.TargetFPS,
 		maxDuration:       config.Duration,
 		artifactDetection: *artifactScan,
+		gpuValidation:     *gpuValidation,
 		monitoringEnabled: true,
 		frameTimesMs:      make([]float64, 0, 1000),
 		statsHistory:      make([]GPUStats, 0, 1000),
@@ -416,6 +425,17 @@ func (app *BenchmarkApp) initVulkan() error {
 			APIVersion:         vulkan.Version13,
 		},
 	}
+	if app.gpuValidation {
+		// GPU-assisted validation catches out-of-bounds shader accesses that show up as artifacts
+		instanceCreateInfo.EnabledLayerNames = []string{"VK_LAYER_KHRONOS_validation"}
+		instanceCreateInfo.EnabledExtensionNames = []string{vulkan.ExtensionNameValidationFeatures}
+		instanceCreateInfo.ValidationFeatures = &vulkan.ValidationFeatures{
+			EnabledValidationFeatures: []vulkan.ValidationFeatureEnable{
+				vulkan.ValidationFeatureEnableGPUAssisted,
+				vulkan.ValidationFeatureEnableGPUAssistedReserveBindingSlot,
+			},
+		}
+	}
 
 	instance, err := vulkan.CreateInstance(instanceCreateInfo)
 	if err != nil {
@@ -423,25 +443,27 @@ func (app *BenchmarkApp) initVulkan() error {
 	}
 	app.instance = instance
 
-	// Enumerate physical devices
-	physicalDevices, err := vulkan.EnumeratePhysicalDevices(instance)
+	// Prefer a discrete GPU with a graphics queue
+	physicalDevice, err := vulkan.SelectPhysicalDevice(instance, vulkan.DeviceSelectionOptions{
+		RequiredQueueFlags: vulkan.QueueGraphicsBit,
+	})
 	if err != nil {
-		return fmt.Errorf("failed to enumerate physical devices: %v", err)
-	}
-
-	if len(physicalDevices) == 0 {
-		return fmt.Errorf("no physical devices found")
+		return fmt.Errorf("failed to select a physical device: %v", err)
 	}
-
-	app.physicalDevice = physicalDevices[0]
+	app.physicalDevice = physicalDevice
 
 	// Get device properties for display
 	props := vulkan.GetPhysicalDeviceProperties(app.physicalDevice)
 	fmt.Printf("Using GPU: %s\n", props.DeviceName)
-	fmt.Printf("Driver Version: %d.%d.%d\n",
-		props.DriverVersion.Major(),
-		props.DriverVersion.Minor(),
-		props.DriverVersion.Patch())
+	fmt.Printf("Driver Version: %s\n", props.DriverVersion)
+	fmt.Printf("Max MSAA: %dx\n", props.Limits.MaxUsableSampleCount())
+
+	// Capture tools and profilers add overhead that skews the results
+	if tools, err := vulkan.GetPhysicalDeviceToolProperties(app.physicalDevice); err == nil {
+		for _, tool := range tools {
+			fmt.Printf("⚠️  Active tool: %s %s (results may be affected)\n", tool.Name, tool.Version)
+		}
+	}
 
 	// Create logical device
 	if err := app.createLogicalDevice(); err != nil {
@@ -457,19 +479,11 @@ func (app *BenchmarkApp) initVulkan() error {
 }
 
 func (app *BenchmarkApp) createLogicalDevice() error {
-	queueFamilies := vulkan.GetPhysicalDeviceQueueFamilyProperties(app.physicalDevice)
-
-	var graphicsQueueFamily uint32 = ^uint32(0)
-	for i, queueFamily := range queueFamilies {
-		if queueFamily.QueueFlags&vulkan.QueueGraphicsBit != 0 {
-			graphicsQueueFamily = uint32(i)
-			break
-		}
-	}
-
-	if graphicsQueueFamily == ^uint32(0) {
+	app.queueFamilies = vulkan.FindQueueFamilies(app.physicalDevice, nil)
+	if !app.queueFamilies.HasGraphics {
 		return fmt.Errorf("no graphics queue family found")
 	}
+	graphicsQueueFamily := app.queueFamilies.Graphics
 
 	queuePriority := float32(1.0)
 	deviceQueueCreateInfo := vulkan.DeviceQueueCreateInfo{
@@ -494,19 +508,9 @@ func (app *BenchmarkApp) createLogicalDevice() error {
 }
 
 func (app *BenchmarkApp) createCommandPool() error {
-	queueFamilies := vulkan.GetPhysicalDeviceQueueFamilyProperties(app.physicalDevice)
-
-	var graphicsQueueFamily uint32 = ^uint32(0)
-	for i, queueFamily := range queueFamilies {
-		if queueFamily.QueueFlags&vulkan.QueueGraphicsBit != 0 {
-			graphicsQueueFamily = uint32(i)
-			break
-		}
-	}
-
 	commandPoolCreateInfo := &vulkan.CommandPoolCreateInfo{
 		Flags:            vulkan.CommandPoolCreateResetCommandBufferBit,
-		QueueFamilyIndex: graphicsQueueFamily,
+		QueueFamilyIndex: app.queueFamilies.Graphics,
 	}
 
 	commandPool, err := vulkan.CreateCommandPool(app.device, commandPoolCreateInfo)
@@ -529,6 +533,17 @@ func (app *BenchmarkApp) cleanupGPUMonitoring() {
 }
 
 func (app *BenchmarkApp) cleanup() {
+	if app.device != nil {
+		// A wedged GPU must not hang the whole process on exit
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		err := vulkan.DeviceWaitIdleContext(ctx, app.device)
+		cancel()
+		if errors.Is(err, context.DeadlineExceeded) {
+			// The device may still be executing work, so leak it rather than destroy it
+			log.Printf("Device did not become idle within 5s, skipping Vulkan cleanup")
+			return
+		}
+	}
 	if app.commandPool != nil {
 		vulkan.DestroyCommandPool(app.device, app.commandPool)
 	}
@@ -650,17 +665,19 @@ func (app *BenchmarkApp) getGenericGPUStats() *GPUStats {
 		}
 	}
 
-	// Try to get GPU memory usage (very rough estimation)
-	if memInfo := app.readMemoryInfo(); memInfo != nil {
-		// This is a very rough approximation
-		estimatedGPUMem := memInfo["MemTotal"] / 8 // Assume discrete GPU has 1/8 of system memory
-		stats.MemoryTotal = estimatedGPUMem * 1024 // Convert to bytes
+	// Prefer driver-reported VRAM figures, falling back to a rough estimation
+	if !app.readVulkanMemoryBudget(stats) {
+		if memInfo := app.readMemoryInfo(); memInfo != nil {
+			// This is a very rough approximation
+			estimatedGPUMem := memInfo["MemTotal"] / 8 // Assume discrete GPU has 1/8 of system memory
+			stats.MemoryTotal = estimatedGPUMem * 1024 // Convert to bytes
 
-		// Estimate usage based on system memory pressure
-		if memAvailable, ok := memInfo["MemAvailable"]; ok {
-			memUsedSystem := memInfo["MemTotal"] - memAvailable
-			usageRatio := float64(memUsedSystem) / float64(memInfo["MemTotal"])
-			stats.MemoryUsed = uint64(float64(stats.MemoryTotal) * usageRatio * 0.5) // Rough estimate
+			// Estimate usage based on system memory pressure
+			if memAvailable, ok := memInfo["MemAvailable"]; ok {
+				memUsedSystem := memInfo["MemTotal"] - memAvailable
+				usageRatio := float64(memUsedSystem) / float64(memInfo["MemTotal"])
+				stats.MemoryUsed = uint64(float64(stats.MemoryTotal) * usageRatio * 0.5) // Rough estimate
+			}
 		}
 	}
 
@@ -718,6 +735,35 @@ func (app *BenchmarkApp) readIntFromFile(filename string) int64 {
 	return value
 }
 
+// readVulkanMemoryBudget fills the VRAM figures from the device-local heaps reported by
+// VK_EXT_memory_budget. It returns false if the driver doesn't expose budgets.
+func (app *BenchmarkApp) readVulkanMemoryBudget(stats *GPUStats) bool {
+	if app.physicalDevice == nil {
+		return false
+	}
+	props, err := vulkan.GetPhysicalDeviceMemoryProperties2(app.physicalDevice)
+	if err != nil {
+		return false
+	}
+
+	var total, used uint64
+	for i := uint32(0); i < props.MemoryProperties.MemoryHeapCount; i++ {
+		heap := props.MemoryProperties.MemoryHeaps[i]
+		if heap.Flags&vulkan.MemoryHeapDeviceLocalBit == 0 {
+			continue
+		}
+		total += uint64(heap.Size)
+		used += uint64(props.HeapUsage[i])
+	}
+	if total == 0 {
+		return false
+	}
+
+	stats.MemoryTotal = total
+	stats.MemoryUsed = used
+	return true
+}
+
 func (app *BenchmarkApp) readMemoryInfo() map[string]uint64 {
 	data, err := os.ReadFile("/proc/meminfo")
 	if err != nil {
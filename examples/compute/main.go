@@ -37,18 +37,13 @@ func main() {
 	}
 	defer vulkan.DestroyInstance(instance)
 
-	// Get physical devices
-	physicalDevices, err := vulkan.EnumeratePhysicalDevices(instance)
+	// Pick the best device with compute queue support
+	physicalDevice, err := vulkan.SelectPhysicalDevice(instance, vulkan.DeviceSelectionOptions{
+		RequiredQueueFlags: vulkan.QueueComputeBit,
+	})
 	if err != nil {
-		log.Fatal("Failed to enumerate physical devices:", err)
-	}
-
-	if len(physicalDevices) == 0 {
-		log.Fatal("No Vulkan-capable devices found")
+		log.Fatal("No suitable Vulkan device found:", err)
 	}
-
-	// Use the first device and check for compute queue support
-	physicalDevice := physicalDevices[0]
 	properties := vulkan.GetPhysicalDeviceProperties(physicalDevice)
 	fmt.Printf("Using device: %s\n", properties.DeviceName)
 
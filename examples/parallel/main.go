@@ -0,0 +1,186 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"unsafe"
+
+	vulkan "github.com/darkace1998/golang-vulkan-api"
+)
+
+// Number of goroutines recording secondary command buffers
+const workerCount = 4
+
+// Bytes each worker copies
+const chunkSize = vulkan.DeviceSize(64 * 1024)
+
+// worker owns the command pool and secondary command buffer of one goroutine.
+// Command pools are not thread-safe, so every goroutine needs its own.
+type worker struct {
+	pool      vulkan.CommandPool
+	secondary vulkan.CommandBuffer
+}
+
+func main() {
+	fmt.Println("=== Parallel Command Recording Example ===")
+
+	instance, err := vulkan.CreateInstance(&vulkan.InstanceCreateInfo{
+		ApplicationInfo: &vulkan.ApplicationInfo{
+			ApplicationName:    "Parallel Recording Example",
+			ApplicationVersion: vulkan.MakeVersion(1, 0, 0),
+			EngineName:         "No Engine",
+			EngineVersion:      vulkan.MakeVersion(1, 0, 0),
+			APIVersion:         vulkan.Version13,
+		},
+	})
+	if err != nil {
+		log.Fatal("Failed to create Vulkan instance:", err)
+	}
+	defer vulkan.DestroyInstance(instance)
+
+	physicalDevice, err := vulkan.SelectPhysicalDevice(instance, vulkan.DeviceSelectionOptions{})
+	if err != nil {
+		log.Fatal("No suitable Vulkan device found:", err)
+	}
+	fmt.Printf("Using device: %s\n", vulkan.GetPhysicalDeviceProperties(physicalDevice).DeviceName)
+
+	// Graphics and compute queues always support transfer operations
+	queueFamily := ^uint32(0)
+	for i, family := range vulkan.GetPhysicalDeviceQueueFamilyProperties(physicalDevice) {
+		if family.QueueFlags&(vulkan.QueueGraphicsBit|vulkan.QueueComputeBit) != 0 {
+			queueFamily = uint32(i)
+			break
+		}
+	}
+	if queueFamily == ^uint32(0) {
+		log.Fatal("No graphics or compute queue family found")
+	}
+
+	device, err := vulkan.CreateDevice(physicalDevice, &vulkan.DeviceCreateInfo{
+		QueueCreateInfos: []vulkan.DeviceQueueCreateInfo{
+			{
+				QueueFamilyIndex: queueFamily,
+				QueuePriorities:  []float32{1.0},
+			},
+		},
+	})
+	if err != nil {
+		log.Fatal("Failed to create device:", err)
+	}
+	defer vulkan.DestroyDevice(device)
+	queue := vulkan.GetDeviceQueue(device, queueFamily, 0)
+
+	allocator, err := vulkan.NewAllocator(device, physicalDevice)
+	if err != nil {
+		log.Fatal("Failed to create allocator:", err)
+	}
+	defer allocator.Destroy()
+
+	bufferSize := chunkSize * workerCount
+	hostVisible := vulkan.MemoryPropertyHostVisibleBit | vulkan.MemoryPropertyHostCoherentBit
+
+	src, err := allocator.AllocateBuffer(bufferSize, vulkan.BufferUsageTransferSrcBit, hostVisible)
+	if err != nil {
+		log.Fatal("Failed to allocate source buffer:", err)
+	}
+	defer allocator.Free(src)
+
+	dst, err := allocator.AllocateBuffer(bufferSize, vulkan.BufferUsageTransferDstBit, hostVisible)
+	if err != nil {
+		log.Fatal("Failed to allocate destination buffer:", err)
+	}
+	defer allocator.Free(dst)
+
+	srcBytes := unsafe.Slice((*byte)(src.Mapped), bufferSize)
+	for i := range srcBytes {
+		srcBytes[i] = byte(i / int(chunkSize))
+	}
+
+	// Each worker gets its own pool and allocates one secondary command buffer from it
+	workers := make([]worker, workerCount)
+	for i := range workers {
+		pool, err := vulkan.CreateCommandPool(device, &vulkan.CommandPoolCreateInfo{
+			Flags:            vulkan.CommandPoolCreateTransientBit,
+			QueueFamilyIndex: queueFamily,
+		})
+		if err != nil {
+			log.Fatal("Failed to create command pool:", err)
+		}
+		defer vulkan.DestroyCommandPool(device, pool)
+
+		commandBuffers, err := vulkan.AllocateCommandBuffers(device, &vulkan.CommandBufferAllocateInfo{
+			CommandPool:        pool,
+			Level:              vulkan.CommandBufferLevelSecondary,
+			CommandBufferCount: 1,
+		})
+		if err != nil {
+			log.Fatal("Failed to allocate secondary command buffer:", err)
+		}
+		workers[i] = worker{pool: pool, secondary: commandBuffers[0]}
+	}
+
+	// Record every secondary on its own goroutine
+	var wg sync.WaitGroup
+	errs := make([]error, workerCount)
+	for i := range workers {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = recordChunkCopy(workers[i].secondary, src, dst, vulkan.DeviceSize(i)*chunkSize)
+		}(i)
+	}
+	wg.Wait()
+
+	secondaries := make([]vulkan.CommandBuffer, workerCount)
+	for i, err := range errs {
+		if err != nil {
+			log.Fatalf("Worker %d failed to record: %v", i, err)
+		}
+		secondaries[i] = workers[i].secondary
+	}
+	fmt.Printf("Recorded %d secondary command buffers in parallel\n", workerCount)
+
+	// Execute all secondaries from a single primary command buffer
+	primaryPool, err := vulkan.CreateCommandPool(device, &vulkan.CommandPoolCreateInfo{
+		Flags:            vulkan.CommandPoolCreateTransientBit,
+		QueueFamilyIndex: queueFamily,
+	})
+	if err != nil {
+		log.Fatal("Failed to create primary command pool:", err)
+	}
+	defer vulkan.DestroyCommandPool(device, primaryPool)
+
+	err = vulkan.RunOneTimeCommands(device, primaryPool, queue, func(cb vulkan.CommandBuffer) error {
+		return vulkan.CmdExecuteCommands(cb, secondaries)
+	})
+	if err != nil {
+		log.Fatal("Failed to execute secondary command buffers:", err)
+	}
+
+	dstBytes := unsafe.Slice((*byte)(dst.Mapped), bufferSize)
+	for i := range dstBytes {
+		if dstBytes[i] != srcBytes[i] {
+			log.Fatalf("Mismatch at byte %d: got %d, want %d", i, dstBytes[i], srcBytes[i])
+		}
+	}
+	fmt.Println("✓ All chunks copied correctly")
+}
+
+// recordChunkCopy records a secondary command buffer that copies one chunk from src to dst.
+// Secondaries used outside a render pass still need (empty) inheritance info.
+func recordChunkCopy(cb vulkan.CommandBuffer, src, dst *vulkan.Allocation, offset vulkan.DeviceSize) error {
+	err := vulkan.BeginCommandBuffer(cb, &vulkan.CommandBufferBeginInfo{
+		Flags:           vulkan.CommandBufferUsageOneTimeSubmitBit,
+		InheritanceInfo: &vulkan.CommandBufferInheritanceInfo{},
+	})
+	if err != nil {
+		return err
+	}
+
+	vulkan.CmdCopyBuffer(cb, src.Buffer, dst.Buffer, []vulkan.BufferCopy{
+		{SrcOffset: offset, DstOffset: offset, Size: chunkSize},
+	})
+
+	return vulkan.EndCommandBuffer(cb)
+}
@@ -16,8 +16,7 @@ func main() {
 	// Test 1: Version support
 	fmt.Println("\n1. Testing Vulkan 1.3 version support...")
 	version13 := vulkan.Version13
-	fmt.Printf("   Vulkan 1.3 version: %d.%d.%d\n",
-		version13.Major(), version13.Minor(), version13.Patch())
+	fmt.Printf("   Vulkan 1.3 version: %s\n", version13)
 
 	// Test 2: Create instance
 	fmt.Println("\n2. Creating Vulkan instance...")
@@ -53,13 +52,16 @@ func main() {
 		log.Fatal("No physical devices found")
 	}
 
-	physicalDevice := physicalDevices[0]
+	physicalDevice, err := vulkan.SelectPhysicalDevice(instance, vulkan.DeviceSelectionOptions{})
+	if err != nil {
+		log.Fatalf("Failed to select a physical device: %v", err)
+	}
 	properties := vulkan.GetPhysicalDeviceProperties(physicalDevice)
 	fmt.Printf("   ✓ Found %d device(s), using: %s\n", len(physicalDevices), properties.DeviceName)
-	fmt.Printf("   ✓ API Version: %d.%d.%d\n",
-		vulkan.Version(properties.APIVersion).Major(),
-		vulkan.Version(properties.APIVersion).Minor(),
-		vulkan.Version(properties.APIVersion).Patch())
+	fmt.Printf("   ✓ API Version: %s\n", properties.APIVersion)
+
+	// Devices below 1.3 lack the core 1.3 commands; only dynamic rendering has an extension fallback here
+	is13 := properties.APIVersion >= vulkan.Version13
 
 	// Test 4: Check Vulkan 1.3 feature support
 	fmt.Println("\n4. Checking Vulkan 1.3 features...")
@@ -112,6 +114,26 @@ func main() {
 		EnabledExtensionNames: []string{}, // Add device extensions as needed
 	}
 
+	// The 1.3 commands used below only work once their features are enabled
+	if is13 {
+		deviceCreateInfo.Vulkan13Features = &vulkan.PhysicalDeviceVulkan13Features{
+			DynamicRendering: true,
+			Synchronization2: true,
+			PrivateData:      true,
+			Maintenance4:     true,
+		}
+	} else {
+		extensions, err := vulkan.EnumerateDeviceExtensionProperties(physicalDevice, "")
+		if err != nil {
+			log.Fatalf("Failed to enumerate device extensions: %v", err)
+		}
+		if !vulkan.IsExtensionSupported(vulkan.ExtensionNameDynamicRendering, extensions) {
+			log.Fatalf("Device reports Vulkan %s and does not support %s", properties.APIVersion, vulkan.ExtensionNameDynamicRendering)
+		}
+		deviceCreateInfo.EnabledExtensionNames = append(deviceCreateInfo.EnabledExtensionNames, vulkan.ExtensionNameDynamicRendering)
+		deviceCreateInfo.DynamicRenderingFeatures = &vulkan.PhysicalDeviceDynamicRenderingFeatures{DynamicRendering: true}
+	}
+
 	device, err := vulkan.CreateDevice(physicalDevice, deviceCreateInfo)
 	if err != nil {
 		log.Fatalf("Failed to create device: %v", err)
@@ -119,6 +141,10 @@ func main() {
 	defer vulkan.DestroyDevice(device)
 	fmt.Println("   ✓ Logical device created successfully")
 
+	// Report whether dynamic rendering uses the core or the VK_KHR_dynamic_rendering entry points
+	renderingPath := vulkan.LoadDynamicRenderingFunctions(device)
+	fmt.Printf("   ✓ Dynamic rendering path: %s\n", renderingPath)
+
 	// Test 6: Vulkan 1.3 Dynamic Rendering Support
 	fmt.Println("\n6. Testing Dynamic Rendering (Vulkan 1.3)...")
 
@@ -171,36 +197,44 @@ func main() {
 	}
 
 	// These functions are now available in Vulkan 1.3
-	vulkan.CmdBeginRendering(commandBuffer, renderingInfo)
-	vulkan.CmdEndRendering(commandBuffer)
+	if err := vulkan.CmdBeginRendering(commandBuffer, renderingInfo); err != nil {
+		log.Fatalf("Failed to begin rendering: %v", err)
+	}
+	if err := vulkan.CmdEndRendering(commandBuffer); err != nil {
+		log.Fatalf("Failed to end rendering: %v", err)
+	}
 	fmt.Println("   ✓ Dynamic Rendering commands available")
 
 	// Test 7: Vulkan 1.3 Extended Dynamic State
 	fmt.Println("\n7. Testing Extended Dynamic State (Vulkan 1.3)...")
 
-	// Test dynamic cull mode
-	vulkan.CmdSetCullMode(commandBuffer, vulkan.CullModeBack)
-	fmt.Println("   ✓ CmdSetCullMode available")
+	if is13 {
+		// Test dynamic cull mode
+		vulkan.CmdSetCullMode(commandBuffer, vulkan.CullModeBack)
+		fmt.Println("   ✓ CmdSetCullMode available")
 
-	// Test dynamic front face
-	vulkan.CmdSetFrontFace(commandBuffer, vulkan.FrontFaceCounterClockwise)
-	fmt.Println("   ✓ CmdSetFrontFace available")
+		// Test dynamic front face
+		vulkan.CmdSetFrontFace(commandBuffer, vulkan.FrontFaceCounterClockwise)
+		fmt.Println("   ✓ CmdSetFrontFace available")
 
-	// Test dynamic primitive topology
-	vulkan.CmdSetPrimitiveTopology(commandBuffer, vulkan.PrimitiveTopologyTriangleList)
-	fmt.Println("   ✓ CmdSetPrimitiveTopology available")
+		// Test dynamic primitive topology
+		vulkan.CmdSetPrimitiveTopology(commandBuffer, vulkan.PrimitiveTopologyTriangleList)
+		fmt.Println("   ✓ CmdSetPrimitiveTopology available")
 
-	// Test dynamic depth test enable
-	vulkan.CmdSetDepthTestEnable(commandBuffer, true)
-	fmt.Println("   ✓ CmdSetDepthTestEnable available")
+		// Test dynamic depth test enable
+		vulkan.CmdSetDepthTestEnable(commandBuffer, true)
+		fmt.Println("   ✓ CmdSetDepthTestEnable available")
 
-	// Test dynamic depth write enable
-	vulkan.CmdSetDepthWriteEnable(commandBuffer, true)
-	fmt.Println("   ✓ CmdSetDepthWriteEnable available")
+		// Test dynamic depth write enable
+		vulkan.CmdSetDepthWriteEnable(commandBuffer, true)
+		fmt.Println("   ✓ CmdSetDepthWriteEnable available")
 
-	// Test dynamic depth compare op
-	vulkan.CmdSetDepthCompareOp(commandBuffer, vulkan.CompareOpLess)
-	fmt.Println("   ✓ CmdSetDepthCompareOp available")
+		// Test dynamic depth compare op
+		vulkan.CmdSetDepthCompareOp(commandBuffer, vulkan.CompareOpLess)
+		fmt.Println("   ✓ CmdSetDepthCompareOp available")
+	} else {
+		fmt.Println("   - Skipped: requires a Vulkan 1.3 device")
+	}
 
 	// End command buffer
 	err = vulkan.EndCommandBuffer(commandBuffer)
@@ -225,26 +259,35 @@ func main() {
 	// Get queue
 	queue := vulkan.GetDeviceQueue(device, graphicsQueueFamily, 0)
 
-	// Test QueueSubmit2 (Vulkan 1.3 enhanced submission)
-	submitInfo2 := []vulkan.SubmitInfo2{
-		{
-			Flags: 0,
-			CommandBufferInfos: []vulkan.CommandBufferSubmitInfo{
-				{
-					CommandBuffer: commandBuffer,
-					DeviceMask:    0,
+	if is13 {
+		// Test QueueSubmit2 (Vulkan 1.3 enhanced submission)
+		submitInfo2 := []vulkan.SubmitInfo2{
+			{
+				Flags: 0,
+				CommandBufferInfos: []vulkan.CommandBufferSubmitInfo{
+					{
+						CommandBuffer: commandBuffer,
+						DeviceMask:    0,
+					},
 				},
+				WaitSemaphoreInfos:   []vulkan.SemaphoreSubmitInfo{},
+				SignalSemaphoreInfos: []vulkan.SemaphoreSubmitInfo{},
 			},
-			WaitSemaphoreInfos:   []vulkan.SemaphoreSubmitInfo{},
-			SignalSemaphoreInfos: []vulkan.SemaphoreSubmitInfo{},
-		},
-	}
+		}
 
-	err = vulkan.QueueSubmit2(queue, submitInfo2, fence)
-	if err != nil {
-		log.Fatalf("Failed to submit with QueueSubmit2: %v", err)
+		err = vulkan.QueueSubmit2(queue, submitInfo2, fence)
+		if err != nil {
+			log.Fatalf("Failed to submit with QueueSubmit2: %v", err)
+		}
+		fmt.Println("   ✓ QueueSubmit2 available and working")
+	} else {
+		// QueueSubmit2 is core 1.3 only, so fall back to the 1.0 submission path
+		err = vulkan.QueueSubmit(queue, []vulkan.SubmitInfo{{CommandBuffers: []vulkan.CommandBuffer{commandBuffer}}}, fence)
+		if err != nil {
+			log.Fatalf("Failed to submit with QueueSubmit: %v", err)
+		}
+		fmt.Println("   - QueueSubmit2 skipped (requires Vulkan 1.3), submitted with QueueSubmit")
 	}
-	fmt.Println("   ✓ QueueSubmit2 available and working")
 
 	// Wait for completion
 	err = vulkan.WaitForFences(device, []vulkan.Fence{fence}, true, ^uint64(0))
@@ -253,69 +296,79 @@ func main() {
 	}
 	fmt.Println("   ✓ Synchronization2 working correctly")
 
-	// Test 9: Vulkan 1.3 Private Data
-	fmt.Println("\n9. Testing Private Data (Vulkan 1.3)...")
+	if is13 {
+		// Test 9: Vulkan 1.3 Private Data
+		fmt.Println("\n9. Testing Private Data (Vulkan 1.3)...")
 
-	// Create private data slot
-	privateDataSlotCreateInfo := &vulkan.PrivateDataSlotCreateInfo{
-		Flags: 0,
-	}
+		// Create private data slot
+		privateDataSlotCreateInfo := &vulkan.PrivateDataSlotCreateInfo{
+			Flags: 0,
+		}
 
-	privateDataSlot, err := vulkan.CreatePrivateDataSlot(device, privateDataSlotCreateInfo)
-	if err != nil {
-		log.Fatalf("Failed to create private data slot: %v", err)
-	}
-	defer vulkan.DestroyPrivateDataSlot(device, privateDataSlot)
-	fmt.Println("   ✓ Private data slot created")
+		privateDataSlot, err := vulkan.CreatePrivateDataSlot(device, privateDataSlotCreateInfo)
+		if err != nil {
+			log.Fatalf("Failed to create private data slot: %v", err)
+		}
+		defer vulkan.DestroyPrivateDataSlot(device, privateDataSlot)
+		fmt.Println("   ✓ Private data slot created")
+
+		// Set private data
+		testData := uint64(0xDEADBEEF)
+		err = vulkan.SetPrivateData(device, vulkan.ObjectTypeDevice, uint64(uintptr(device)), privateDataSlot, testData)
+		if err != nil {
+			log.Fatalf("Failed to set private data: %v", err)
+		}
 
-	// Set private data
-	testData := uint64(0xDEADBEEF)
-	err = vulkan.SetPrivateData(device, vulkan.ObjectTypeDevice, uint64(uintptr(device)), privateDataSlot, testData)
-	if err != nil {
-		log.Fatalf("Failed to set private data: %v", err)
-	}
+		// Get private data
+		retrievedData := vulkan.GetPrivateData(device, vulkan.ObjectTypeDevice, uint64(uintptr(device)), privateDataSlot)
+		if retrievedData != testData {
+			log.Fatalf("Private data mismatch: expected %x, got %x", testData, retrievedData)
+		}
+		fmt.Printf("   ✓ Private data working correctly (stored: %x, retrieved: %x)\n", testData, retrievedData)
 
-	// Get private data
-	retrievedData := vulkan.GetPrivateData(device, vulkan.ObjectTypeDevice, uint64(uintptr(device)), privateDataSlot)
-	if retrievedData != testData {
-		log.Fatalf("Private data mismatch: expected %x, got %x", testData, retrievedData)
-	}
-	fmt.Printf("   ✓ Private data working correctly (stored: %x, retrieved: %x)\n", testData, retrievedData)
+		// Test 10: Vulkan 1.3 Maintenance4
+		fmt.Println("\n10. Testing Maintenance4 features (Vulkan 1.3)...")
 
-	// Test 10: Vulkan 1.3 Maintenance4
-	fmt.Println("\n10. Testing Maintenance4 features (Vulkan 1.3)...")
+		// Test GetDeviceBufferMemoryRequirements
+		bufferCreateInfo := &vulkan.BufferCreateInfo{
+			Flags:       0, // Default flags
+			Size:        1024,
+			Usage:       vulkan.BufferUsageStorageBufferBit,
+			SharingMode: vulkan.SharingModeExclusive,
+		}
 
-	// Test GetDeviceBufferMemoryRequirements
-	bufferCreateInfo := &vulkan.BufferCreateInfo{
-		Flags:       0, // Default flags
-		Size:        1024,
-		Usage:       vulkan.BufferUsageStorageBufferBit,
-		SharingMode: vulkan.SharingModeExclusive,
-	}
+		memReqs, err := vulkan.GetDeviceBufferMemoryRequirements(device, bufferCreateInfo)
+		if err != nil {
+			log.Fatalf("Failed to get buffer memory requirements: %v", err)
+		}
+		fmt.Printf("   ✓ Buffer memory requirements: size=%d, alignment=%d, typeBits=0x%x\n",
+			memReqs.Size, memReqs.Alignment, memReqs.MemoryTypeBits)
+
+		// Test GetDeviceImageMemoryRequirements
+		imageCreateInfo := &vulkan.ImageCreateInfo{
+			Flags:         0, // Default flags
+			ImageType:     vulkan.ImageType2D,
+			Format:        vulkan.FormatR8G8B8A8Unorm,
+			Extent:        vulkan.Extent3D{Width: 256, Height: 256, Depth: 1},
+			MipLevels:     1,
+			ArrayLayers:   1,
+			Samples:       vulkan.SampleCount1Bit,
+			Tiling:        vulkan.ImageTilingOptimal,
+			Usage:         vulkan.ImageUsageColorAttachmentBit,
+			SharingMode:   vulkan.SharingModeExclusive,
+			InitialLayout: vulkan.ImageLayoutUndefined,
+		}
 
-	memReqs := vulkan.GetDeviceBufferMemoryRequirements(device, bufferCreateInfo)
-	fmt.Printf("   ✓ Buffer memory requirements: size=%d, alignment=%d, typeBits=0x%x\n",
-		memReqs.Size, memReqs.Alignment, memReqs.MemoryTypeBits)
-
-	// Test GetDeviceImageMemoryRequirements
-	imageCreateInfo := &vulkan.ImageCreateInfo{
-		Flags:         0, // Default flags
-		ImageType:     vulkan.ImageType2D,
-		Format:        vulkan.FormatR8G8B8A8Unorm,
-		Extent:        vulkan.Extent3D{Width: 256, Height: 256, Depth: 1},
-		MipLevels:     1,
-		ArrayLayers:   1,
-		Samples:       vulkan.SampleCount1Bit,
-		Tiling:        vulkan.ImageTilingOptimal,
-		Usage:         vulkan.ImageUsageColorAttachmentBit,
-		SharingMode:   vulkan.SharingModeExclusive,
-		InitialLayout: vulkan.ImageLayoutUndefined,
+		imageMemReqs, err := vulkan.GetDeviceImageMemoryRequirements(device, imageCreateInfo)
+		if err != nil {
+			log.Fatalf("Failed to get image memory requirements: %v", err)
+		}
+		fmt.Printf("   ✓ Image memory requirements: size=%d, alignment=%d, typeBits=0x%x\n",
+			imageMemReqs.Size, imageMemReqs.Alignment, imageMemReqs.MemoryTypeBits)
+	} else {
+		fmt.Println("\n9-10. Skipped Private Data and Maintenance4: require a Vulkan 1.3 device")
 	}
 
-	imageMemReqs := vulkan.GetDeviceImageMemoryRequirements(device, imageCreateInfo)
-	fmt.Printf("   ✓ Image memory requirements: size=%d, alignment=%d, typeBits=0x%x\n",
-		imageMemReqs.Size, imageMemReqs.Alignment, imageMemReqs.MemoryTypeBits)
-
 	fmt.Println("\n=== Vulkan 1.3 Feature Test Complete ===")
 	fmt.Println("All major Vulkan 1.3 features are implemented and working!")
 	fmt.Println("\nImplemented Vulkan 1.3 features:")
@@ -0,0 +1,378 @@
+package vulkan
+
+/*
+#include <vulkan/vulkan.h>
+#include <stdlib.h>
+
+// Function pointers for VK_EXT_extended_dynamic_state3 functions
+// These need to be loaded dynamically at runtime.
+//
+// IMPORTANT: These are global static pointers and NOT thread-safe during loading.
+// LoadExtendedDynamicState3Functions must be called from a single thread during
+// initialization before any concurrent extended dynamic state 3 API usage.
+static PFN_vkCmdSetColorBlendEnableEXT pfn_vkCmdSetColorBlendEnableEXT = NULL;
+static PFN_vkCmdSetColorBlendEquationEXT pfn_vkCmdSetColorBlendEquationEXT = NULL;
+static PFN_vkCmdSetColorWriteMaskEXT pfn_vkCmdSetColorWriteMaskEXT = NULL;
+static PFN_vkCmdSetRasterizationSamplesEXT pfn_vkCmdSetRasterizationSamplesEXT = NULL;
+static PFN_vkCmdSetPolygonModeEXT pfn_vkCmdSetPolygonModeEXT = NULL;
+static PFN_vkCmdSetLogicOpEnableEXT pfn_vkCmdSetLogicOpEnableEXT = NULL;
+
+static int loadExtendedDynamicState3DeviceFunctions(VkDevice device) {
+    if (device == VK_NULL_HANDLE) {
+        return 0;
+    }
+    pfn_vkCmdSetColorBlendEnableEXT = (PFN_vkCmdSetColorBlendEnableEXT)
+        vkGetDeviceProcAddr(device, "vkCmdSetColorBlendEnableEXT");
+    pfn_vkCmdSetColorBlendEquationEXT = (PFN_vkCmdSetColorBlendEquationEXT)
+        vkGetDeviceProcAddr(device, "vkCmdSetColorBlendEquationEXT");
+    pfn_vkCmdSetColorWriteMaskEXT = (PFN_vkCmdSetColorWriteMaskEXT)
+        vkGetDeviceProcAddr(device, "vkCmdSetColorWriteMaskEXT");
+    pfn_vkCmdSetRasterizationSamplesEXT = (PFN_vkCmdSetRasterizationSamplesEXT)
+        vkGetDeviceProcAddr(device, "vkCmdSetRasterizationSamplesEXT");
+    pfn_vkCmdSetPolygonModeEXT = (PFN_vkCmdSetPolygonModeEXT)
+        vkGetDeviceProcAddr(device, "vkCmdSetPolygonModeEXT");
+    pfn_vkCmdSetLogicOpEnableEXT = (PFN_vkCmdSetLogicOpEnableEXT)
+        vkGetDeviceProcAddr(device, "vkCmdSetLogicOpEnableEXT");
+
+    // Each command is only exposed when its feature is supported, so report success if any
+    // of them could be loaded; the wrappers check their own pointer
+    return pfn_vkCmdSetColorBlendEnableEXT != NULL ||
+           pfn_vkCmdSetColorBlendEquationEXT != NULL ||
+           pfn_vkCmdSetColorWriteMaskEXT != NULL ||
+           pfn_vkCmdSetRasterizationSamplesEXT != NULL ||
+           pfn_vkCmdSetPolygonModeEXT != NULL ||
+           pfn_vkCmdSetLogicOpEnableEXT != NULL;
+}
+
+// Command buffer wrapper functions return 1 on success, 0 if function pointer is NULL.
+static int call_vkCmdSetColorBlendEnableEXT(
+    VkCommandBuffer commandBuffer,
+    uint32_t firstAttachment,
+    uint32_t attachmentCount,
+    const VkBool32* pColorBlendEnables) {
+    if (pfn_vkCmdSetColorBlendEnableEXT == NULL) {
+        return 0;
+    }
+    pfn_vkCmdSetColorBlendEnableEXT(commandBuffer, firstAttachment, attachmentCount, pColorBlendEnables);
+    return 1;
+}
+
+static int call_vkCmdSetColorBlendEquationEXT(
+    VkCommandBuffer commandBuffer,
+    uint32_t firstAttachment,
+    uint32_t attachmentCount,
+    const VkColorBlendEquationEXT* pColorBlendEquations) {
+    if (pfn_vkCmdSetColorBlendEquationEXT == NULL) {
+        return 0;
+    }
+    pfn_vkCmdSetColorBlendEquationEXT(commandBuffer, firstAttachment, attachmentCount, pColorBlendEquations);
+    return 1;
+}
+
+static int call_vkCmdSetColorWriteMaskEXT(
+    VkCommandBuffer commandBuffer,
+    uint32_t firstAttachment,
+    uint32_t attachmentCount,
+    const VkColorComponentFlags* pColorWriteMasks) {
+    if (pfn_vkCmdSetColorWriteMaskEXT == NULL) {
+        return 0;
+    }
+    pfn_vkCmdSetColorWriteMaskEXT(commandBuffer, firstAttachment, attachmentCount, pColorWriteMasks);
+    return 1;
+}
+
+static int call_vkCmdSetRasterizationSamplesEXT(VkCommandBuffer commandBuffer, VkSampleCountFlagBits rasterizationSamples) {
+    if (pfn_vkCmdSetRasterizationSamplesEXT == NULL) {
+        return 0;
+    }
+    pfn_vkCmdSetRasterizationSamplesEXT(commandBuffer, rasterizationSamples);
+    return 1;
+}
+
+static int call_vkCmdSetPolygonModeEXT(VkCommandBuffer commandBuffer, VkPolygonMode polygonMode) {
+    if (pfn_vkCmdSetPolygonModeEXT == NULL) {
+        return 0;
+    }
+    pfn_vkCmdSetPolygonModeEXT(commandBuffer, polygonMode);
+    return 1;
+}
+
+static int call_vkCmdSetLogicOpEnableEXT(VkCommandBuffer commandBuffer, VkBool32 logicOpEnable) {
+    if (pfn_vkCmdSetLogicOpEnableEXT == NULL) {
+        return 0;
+    }
+    pfn_vkCmdSetLogicOpEnableEXT(commandBuffer, logicOpEnable);
+    return 1;
+}
+*/
+import "C"
+
+import (
+	"math/bits"
+	"unsafe"
+)
+
+// ExtensionNameExtendedDynamicState3 is the extended dynamic state 3 extension name
+const ExtensionNameExtendedDynamicState3 = "VK_EXT_extended_dynamic_state3"
+
+// PolygonMode selects how polygons are rasterized
+type PolygonMode int32
+
+const (
+	PolygonModeFill PolygonMode = C.VK_POLYGON_MODE_FILL
+	// PolygonModeLine draws polygon edges as lines; requires the FillModeNonSolid feature
+	PolygonModeLine PolygonMode = C.VK_POLYGON_MODE_LINE
+	// PolygonModePoint draws polygon vertices as points; requires the FillModeNonSolid feature
+	PolygonModePoint PolygonMode = C.VK_POLYGON_MODE_POINT
+)
+
+// BlendFactor selects the source or destination weight of a blend equation
+type BlendFactor int32
+
+const (
+	BlendFactorZero                  BlendFactor = C.VK_BLEND_FACTOR_ZERO
+	BlendFactorOne                   BlendFactor = C.VK_BLEND_FACTOR_ONE
+	BlendFactorSrcColor              BlendFactor = C.VK_BLEND_FACTOR_SRC_COLOR
+	BlendFactorOneMinusSrcColor      BlendFactor = C.VK_BLEND_FACTOR_ONE_MINUS_SRC_COLOR
+	BlendFactorDstColor              BlendFactor = C.VK_BLEND_FACTOR_DST_COLOR
+	BlendFactorOneMinusDstColor      BlendFactor = C.VK_BLEND_FACTOR_ONE_MINUS_DST_COLOR
+	BlendFactorSrcAlpha              BlendFactor = C.VK_BLEND_FACTOR_SRC_ALPHA
+	BlendFactorOneMinusSrcAlpha      BlendFactor = C.VK_BLEND_FACTOR_ONE_MINUS_SRC_ALPHA
+	BlendFactorDstAlpha              BlendFactor = C.VK_BLEND_FACTOR_DST_ALPHA
+	BlendFactorOneMinusDstAlpha      BlendFactor = C.VK_BLEND_FACTOR_ONE_MINUS_DST_ALPHA
+	BlendFactorConstantColor         BlendFactor = C.VK_BLEND_FACTOR_CONSTANT_COLOR
+	BlendFactorOneMinusConstantColor BlendFactor = C.VK_BLEND_FACTOR_ONE_MINUS_CONSTANT_COLOR
+	BlendFactorConstantAlpha         BlendFactor = C.VK_BLEND_FACTOR_CONSTANT_ALPHA
+	BlendFactorOneMinusConstantAlpha BlendFactor = C.VK_BLEND_FACTOR_ONE_MINUS_CONSTANT_ALPHA
+	BlendFactorSrcAlphaSaturate      BlendFactor = C.VK_BLEND_FACTOR_SRC_ALPHA_SATURATE
+)
+
+// BlendOp selects how weighted source and destination values are combined
+type BlendOp int32
+
+const (
+	BlendOpAdd             BlendOp = C.VK_BLEND_OP_ADD
+	BlendOpSubtract        BlendOp = C.VK_BLEND_OP_SUBTRACT
+	BlendOpReverseSubtract BlendOp = C.VK_BLEND_OP_REVERSE_SUBTRACT
+	BlendOpMin             BlendOp = C.VK_BLEND_OP_MIN
+	BlendOpMax             BlendOp = C.VK_BLEND_OP_MAX
+)
+
+// ColorComponentFlags select the color channels written to an attachment
+type ColorComponentFlags uint32
+
+const (
+	ColorComponentRBit ColorComponentFlags = C.VK_COLOR_COMPONENT_R_BIT
+	ColorComponentGBit ColorComponentFlags = C.VK_COLOR_COMPONENT_G_BIT
+	ColorComponentBBit ColorComponentFlags = C.VK_COLOR_COMPONENT_B_BIT
+	ColorComponentABit ColorComponentFlags = C.VK_COLOR_COMPONENT_A_BIT
+	// ColorComponentAll writes every channel
+	ColorComponentAll = ColorComponentRBit | ColorComponentGBit | ColorComponentBBit | ColorComponentABit
+)
+
+// ColorBlendEquation describes the color and alpha blending of one color attachment
+type ColorBlendEquation struct {
+	SrcColorBlendFactor BlendFactor
+	DstColorBlendFactor BlendFactor
+	ColorBlendOp        BlendOp
+	SrcAlphaBlendFactor BlendFactor
+	DstAlphaBlendFactor BlendFactor
+	AlphaBlendOp        BlendOp
+}
+
+// PhysicalDeviceExtendedDynamicState3Features contains the extended dynamic state 3 features
+// for the commands in this package. Each command can only be used when its feature is enabled.
+type PhysicalDeviceExtendedDynamicState3Features struct {
+	ExtendedDynamicState3PolygonMode          bool
+	ExtendedDynamicState3RasterizationSamples bool
+	ExtendedDynamicState3LogicOpEnable        bool
+	ExtendedDynamicState3ColorBlendEnable     bool
+	ExtendedDynamicState3ColorBlendEquation   bool
+	ExtendedDynamicState3ColorWriteMask       bool
+}
+
+// LoadExtendedDynamicState3Functions loads extended dynamic state 3 functions for a device.
+//
+// This function MUST be called after creating a logical device with the
+// VK_EXT_extended_dynamic_state3 extension enabled and before recording any of its commands.
+//
+// IMPORTANT: This function is NOT thread-safe. Only one device is supported at a time;
+// calling this function again will overwrite previously loaded function pointers.
+//
+// Returns false if none of the extended dynamic state 3 functions could be loaded. Commands
+// whose feature is not supported return ErrorExtensionNotPresent.
+func LoadExtendedDynamicState3Functions(device Device) bool {
+	return C.loadExtendedDynamicState3DeviceFunctions(C.VkDevice(device)) != 0
+}
+
+// extendedDynamicState3FeaturesToC prepends a struct enabling the requested extended dynamic
+// state 3 features to the pNext chain next. The struct is allocated in C memory and appended
+// to allocations, which the caller must free.
+func extendedDynamicState3FeaturesToC(features *PhysicalDeviceExtendedDynamicState3Features, next unsafe.Pointer, allocations *[]unsafe.Pointer) (unsafe.Pointer, error) {
+	cState3 := (*C.VkPhysicalDeviceExtendedDynamicState3FeaturesEXT)(C.calloc(1, C.sizeof_VkPhysicalDeviceExtendedDynamicState3FeaturesEXT))
+	if cState3 == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "CreateDevice", "failed to allocate memory for extended dynamic state 3 features")
+	}
+	*allocations = append(*allocations, unsafe.Pointer(cState3))
+
+	cState3.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_EXTENDED_DYNAMIC_STATE_3_FEATURES_EXT
+	cState3.pNext = next
+	cState3.extendedDynamicState3PolygonMode = boolToVkBool32(features.ExtendedDynamicState3PolygonMode)
+	cState3.extendedDynamicState3RasterizationSamples = boolToVkBool32(features.ExtendedDynamicState3RasterizationSamples)
+	cState3.extendedDynamicState3LogicOpEnable = boolToVkBool32(features.ExtendedDynamicState3LogicOpEnable)
+	cState3.extendedDynamicState3ColorBlendEnable = boolToVkBool32(features.ExtendedDynamicState3ColorBlendEnable)
+	cState3.extendedDynamicState3ColorBlendEquation = boolToVkBool32(features.ExtendedDynamicState3ColorBlendEquation)
+	cState3.extendedDynamicState3ColorWriteMask = boolToVkBool32(features.ExtendedDynamicState3ColorWriteMask)
+
+	return unsafe.Pointer(cState3), nil
+}
+
+// GetPhysicalDeviceExtendedDynamicState3FeaturesEXT queries extended dynamic state 3 feature support
+func GetPhysicalDeviceExtendedDynamicState3FeaturesEXT(physicalDevice PhysicalDevice) PhysicalDeviceExtendedDynamicState3Features {
+	cFeatures2 := (*C.VkPhysicalDeviceFeatures2)(C.calloc(1, C.sizeof_VkPhysicalDeviceFeatures2))
+	cState3 := (*C.VkPhysicalDeviceExtendedDynamicState3FeaturesEXT)(C.calloc(1, C.sizeof_VkPhysicalDeviceExtendedDynamicState3FeaturesEXT))
+	defer C.free(unsafe.Pointer(cFeatures2))
+	defer C.free(unsafe.Pointer(cState3))
+	if cFeatures2 == nil || cState3 == nil {
+		return PhysicalDeviceExtendedDynamicState3Features{}
+	}
+
+	cFeatures2.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_FEATURES_2
+	cFeatures2.pNext = unsafe.Pointer(cState3)
+	cState3.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_EXTENDED_DYNAMIC_STATE_3_FEATURES_EXT
+
+	C.vkGetPhysicalDeviceFeatures2(C.VkPhysicalDevice(physicalDevice), cFeatures2)
+
+	return PhysicalDeviceExtendedDynamicState3Features{
+		ExtendedDynamicState3PolygonMode:          vkBool32ToBool(cState3.extendedDynamicState3PolygonMode),
+		ExtendedDynamicState3RasterizationSamples: vkBool32ToBool(cState3.extendedDynamicState3RasterizationSamples),
+		ExtendedDynamicState3LogicOpEnable:        vkBool32ToBool(cState3.extendedDynamicState3LogicOpEnable),
+		ExtendedDynamicState3ColorBlendEnable:     vkBool32ToBool(cState3.extendedDynamicState3ColorBlendEnable),
+		ExtendedDynamicState3ColorBlendEquation:   vkBool32ToBool(cState3.extendedDynamicState3ColorBlendEquation),
+		ExtendedDynamicState3ColorWriteMask:       vkBool32ToBool(cState3.extendedDynamicState3ColorWriteMask),
+	}
+}
+
+// CmdSetColorBlendEnableEXT enables or disables blending for the color attachments starting
+// at firstAttachment, one entry per attachment.
+// Returns an error if LoadExtendedDynamicState3Functions was not called or the
+// ExtendedDynamicState3ColorBlendEnable feature is not supported.
+func CmdSetColorBlendEnableEXT(commandBuffer CommandBuffer, firstAttachment uint32, colorBlendEnables []bool) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+	if len(colorBlendEnables) == 0 {
+		return NewValidationError("colorBlendEnables", "cannot be empty")
+	}
+
+	cEnables := make([]C.VkBool32, len(colorBlendEnables))
+	for i, enable := range colorBlendEnables {
+		cEnables[i] = boolToVkBool32(enable)
+	}
+
+	if C.call_vkCmdSetColorBlendEnableEXT(C.VkCommandBuffer(commandBuffer), C.uint32_t(firstAttachment), C.uint32_t(len(cEnables)), &cEnables[0]) == 0 {
+		return NewVulkanError(ErrorExtensionNotPresent, "CmdSetColorBlendEnableEXT", "extended dynamic state 3 extension not loaded - call LoadExtendedDynamicState3Functions first")
+	}
+	return nil
+}
+
+// CmdSetColorBlendEquationEXT sets the blend factors and operations for the color
+// attachments starting at firstAttachment, one entry per attachment.
+// Returns an error if LoadExtendedDynamicState3Functions was not called or the
+// ExtendedDynamicState3ColorBlendEquation feature is not supported.
+func CmdSetColorBlendEquationEXT(commandBuffer CommandBuffer, firstAttachment uint32, colorBlendEquations []ColorBlendEquation) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+	if len(colorBlendEquations) == 0 {
+		return NewValidationError("colorBlendEquations", "cannot be empty")
+	}
+
+	cEquations := make([]C.VkColorBlendEquationEXT, len(colorBlendEquations))
+	for i, equation := range colorBlendEquations {
+		cEquations[i] = C.VkColorBlendEquationEXT{
+			srcColorBlendFactor: C.VkBlendFactor(equation.SrcColorBlendFactor),
+			dstColorBlendFactor: C.VkBlendFactor(equation.DstColorBlendFactor),
+			colorBlendOp:        C.VkBlendOp(equation.ColorBlendOp),
+			srcAlphaBlendFactor: C.VkBlendFactor(equation.SrcAlphaBlendFactor),
+			dstAlphaBlendFactor: C.VkBlendFactor(equation.DstAlphaBlendFactor),
+			alphaBlendOp:        C.VkBlendOp(equation.AlphaBlendOp),
+		}
+	}
+
+	if C.call_vkCmdSetColorBlendEquationEXT(C.VkCommandBuffer(commandBuffer), C.uint32_t(firstAttachment), C.uint32_t(len(cEquations)), &cEquations[0]) == 0 {
+		return NewVulkanError(ErrorExtensionNotPresent, "CmdSetColorBlendEquationEXT", "extended dynamic state 3 extension not loaded - call LoadExtendedDynamicState3Functions first")
+	}
+	return nil
+}
+
+// CmdSetColorWriteMaskEXT sets which channels are written for the color attachments starting
+// at firstAttachment, one entry per attachment.
+// Returns an error if LoadExtendedDynamicState3Functions was not called or the
+// ExtendedDynamicState3ColorWriteMask feature is not supported.
+func CmdSetColorWriteMaskEXT(commandBuffer CommandBuffer, firstAttachment uint32, colorWriteMasks []ColorComponentFlags) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+	if len(colorWriteMasks) == 0 {
+		return NewValidationError("colorWriteMasks", "cannot be empty")
+	}
+
+	cMasks := make([]C.VkColorComponentFlags, len(colorWriteMasks))
+	for i, mask := range colorWriteMasks {
+		cMasks[i] = C.VkColorComponentFlags(mask)
+	}
+
+	if C.call_vkCmdSetColorWriteMaskEXT(C.VkCommandBuffer(commandBuffer), C.uint32_t(firstAttachment), C.uint32_t(len(cMasks)), &cMasks[0]) == 0 {
+		return NewVulkanError(ErrorExtensionNotPresent, "CmdSetColorWriteMaskEXT", "extended dynamic state 3 extension not loaded - call LoadExtendedDynamicState3Functions first")
+	}
+	return nil
+}
+
+// CmdSetRasterizationSamplesEXT sets the number of rasterization samples, which must match the
+// sample count of the attachments being rendered to.
+// Returns an error if LoadExtendedDynamicState3Functions was not called or the
+// ExtendedDynamicState3RasterizationSamples feature is not supported.
+func CmdSetRasterizationSamplesEXT(commandBuffer CommandBuffer, rasterizationSamples SampleCountFlags) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+	if bits.OnesCount32(uint32(rasterizationSamples)) != 1 {
+		return NewValidationError("rasterizationSamples", "must be a single sample count")
+	}
+
+	if C.call_vkCmdSetRasterizationSamplesEXT(C.VkCommandBuffer(commandBuffer), C.VkSampleCountFlagBits(rasterizationSamples)) == 0 {
+		return NewVulkanError(ErrorExtensionNotPresent, "CmdSetRasterizationSamplesEXT", "extended dynamic state 3 extension not loaded - call LoadExtendedDynamicState3Functions first")
+	}
+	return nil
+}
+
+// CmdSetPolygonModeEXT sets how polygons are rasterized, e.g. PolygonModeLine for a
+// wireframe view without a second pipeline.
+// Returns an error if LoadExtendedDynamicState3Functions was not called or the
+// ExtendedDynamicState3PolygonMode feature is not supported.
+func CmdSetPolygonModeEXT(commandBuffer CommandBuffer, polygonMode PolygonMode) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+
+	if C.call_vkCmdSetPolygonModeEXT(C.VkCommandBuffer(commandBuffer), C.VkPolygonMode(polygonMode)) == 0 {
+		return NewVulkanError(ErrorExtensionNotPresent, "CmdSetPolygonModeEXT", "extended dynamic state 3 extension not loaded - call LoadExtendedDynamicState3Functions first")
+	}
+	return nil
+}
+
+// CmdSetLogicOpEnableEXT enables or disables logical operations on color attachments.
+// Requires the LogicOp device feature.
+// Returns an error if LoadExtendedDynamicState3Functions was not called or the
+// ExtendedDynamicState3LogicOpEnable feature is not supported.
+func CmdSetLogicOpEnableEXT(commandBuffer CommandBuffer, logicOpEnable bool) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+
+	if C.call_vkCmdSetLogicOpEnableEXT(C.VkCommandBuffer(commandBuffer), boolToVkBool32(logicOpEnable)) == 0 {
+		return NewVulkanError(ErrorExtensionNotPresent, "CmdSetLogicOpEnableEXT", "extended dynamic state 3 extension not loaded - call LoadExtendedDynamicState3Functions first")
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+//go:build cgo
+
+package vulkan
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestExtendedDynamicState3Validation tests input validation of the extended dynamic state 3 commands
+func TestExtendedDynamicState3Validation(t *testing.T) {
+	fakeCommandBuffer := CommandBuffer(uintptr(0x1234))
+
+	tests := []struct {
+		name       string
+		call       func() error
+		errorParam string
+	}{
+		{
+			name:       "nil command buffer",
+			call:       func() error { return CmdSetPolygonModeEXT(nil, PolygonModeLine) },
+			errorParam: "commandBuffer",
+		},
+		{
+			name:       "no blend enables",
+			call:       func() error { return CmdSetColorBlendEnableEXT(fakeCommandBuffer, 0, nil) },
+			errorParam: "colorBlendEnables",
+		},
+		{
+			name:       "no blend equations",
+			call:       func() error { return CmdSetColorBlendEquationEXT(fakeCommandBuffer, 0, []ColorBlendEquation{}) },
+			errorParam: "colorBlendEquations",
+		},
+		{
+			name:       "no write masks",
+			call:       func() error { return CmdSetColorWriteMaskEXT(fakeCommandBuffer, 0, nil) },
+			errorParam: "colorWriteMasks",
+		},
+		{
+			name:       "zero rasterization samples",
+			call:       func() error { return CmdSetRasterizationSamplesEXT(fakeCommandBuffer, 0) },
+			errorParam: "rasterizationSamples",
+		},
+		{
+			name: "multiple rasterization samples",
+			call: func() error {
+				return CmdSetRasterizationSamplesEXT(fakeCommandBuffer, SampleCount1Bit|SampleCount4Bit)
+			},
+			errorParam: "rasterizationSamples",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+}
+
+// TestExtendedDynamicState3NotLoaded tests the unloaded-extension error of the extended dynamic state 3 commands
+func TestExtendedDynamicState3NotLoaded(t *testing.T) {
+	fakeCommandBuffer := CommandBuffer(uintptr(0x1234))
+
+	calls := map[string]func() error{
+		"CmdSetColorBlendEnableEXT": func() error {
+			return CmdSetColorBlendEnableEXT(fakeCommandBuffer, 0, []bool{true, false})
+		},
+		"CmdSetColorBlendEquationEXT": func() error {
+			return CmdSetColorBlendEquationEXT(fakeCommandBuffer, 0, []ColorBlendEquation{{
+				SrcColorBlendFactor: BlendFactorSrcAlpha,
+				DstColorBlendFactor: BlendFactorOneMinusSrcAlpha,
+				ColorBlendOp:        BlendOpAdd,
+				SrcAlphaBlendFactor: BlendFactorOne,
+				DstAlphaBlendFactor: BlendFactorZero,
+				AlphaBlendOp:        BlendOpAdd,
+			}})
+		},
+		"CmdSetColorWriteMaskEXT": func() error {
+			return CmdSetColorWriteMaskEXT(fakeCommandBuffer, 0, []ColorComponentFlags{ColorComponentAll})
+		},
+		"CmdSetRasterizationSamplesEXT": func() error {
+			return CmdSetRasterizationSamplesEXT(fakeCommandBuffer, SampleCount4Bit)
+		},
+		"CmdSetPolygonModeEXT":   func() error { return CmdSetPolygonModeEXT(fakeCommandBuffer, PolygonModeLine) },
+		"CmdSetLogicOpEnableEXT": func() error { return CmdSetLogicOpEnableEXT(fakeCommandBuffer, true) },
+	}
+
+	for name, call := range calls {
+		if err := call(); !errors.Is(err, ErrorExtensionNotPresent) {
+			t.Errorf("%s: expected ErrorExtensionNotPresent, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package vulkan
+
+/*
+#include <vulkan/vulkan.h>
+#include <stdlib.h>
+
+// Function pointers for VK_KHR_external_memory_fd functions
+// These need to be loaded dynamically at runtime.
+//
+// IMPORTANT: These are global static pointers and NOT thread-safe during loading.
+// LoadExternalMemoryFdFunctions must be called from a single thread during initialization
+// before any concurrent external memory API usage.
+static PFN_vkGetMemoryFdKHR pfn_vkGetMemoryFdKHR = NULL;
+
+static int loadExternalMemoryFdDeviceFunctions(VkDevice device) {
+    if (device == VK_NULL_HANDLE) {
+        return 0;
+    }
+    pfn_vkGetMemoryFdKHR = (PFN_vkGetMemoryFdKHR)
+        vkGetDeviceProcAddr(device, "vkGetMemoryFdKHR");
+
+    return pfn_vkGetMemoryFdKHR != NULL;
+}
+
+// Wrappers return VK_ERROR_EXTENSION_NOT_PRESENT if the function pointer is NULL.
+static VkResult call_vkGetMemoryFdKHR(VkDevice device, const VkMemoryGetFdInfoKHR* pGetFdInfo, int* pFd) {
+    if (pfn_vkGetMemoryFdKHR == NULL) {
+        return VK_ERROR_EXTENSION_NOT_PRESENT;
+    }
+    return pfn_vkGetMemoryFdKHR(device, pGetFdInfo, pFd);
+}
+*/
+import "C"
+
+import "math/bits"
+
+// ExtensionNameExternalMemoryFd is the external memory file descriptor device extension name
+const ExtensionNameExternalMemoryFd = "VK_KHR_external_memory_fd"
+
+// ExternalMemoryHandleTypeFlags identify the kinds of OS handles memory can be shared through
+// (external memory, core in Vulkan 1.1)
+type ExternalMemoryHandleTypeFlags uint32
+
+const (
+	// ExternalMemoryHandleTypeOpaqueFdBit is a POSIX file descriptor only meaningful to Vulkan
+	// drivers and APIs sharing the same driver, such as CUDA or OpenGL
+	ExternalMemoryHandleTypeOpaqueFdBit        ExternalMemoryHandleTypeFlags = C.VK_EXTERNAL_MEMORY_HANDLE_TYPE_OPAQUE_FD_BIT
+	ExternalMemoryHandleTypeOpaqueWin32Bit     ExternalMemoryHandleTypeFlags = C.VK_EXTERNAL_MEMORY_HANDLE_TYPE_OPAQUE_WIN32_BIT
+	ExternalMemoryHandleTypeOpaqueWin32KmtBit  ExternalMemoryHandleTypeFlags = C.VK_EXTERNAL_MEMORY_HANDLE_TYPE_OPAQUE_WIN32_KMT_BIT
+	ExternalMemoryHandleTypeD3D11TextureBit    ExternalMemoryHandleTypeFlags = C.VK_EXTERNAL_MEMORY_HANDLE_TYPE_D3D11_TEXTURE_BIT
+	ExternalMemoryHandleTypeD3D11TextureKmtBit ExternalMemoryHandleTypeFlags = C.VK_EXTERNAL_MEMORY_HANDLE_TYPE_D3D11_TEXTURE_KMT_BIT
+	ExternalMemoryHandleTypeD3D12HeapBit       ExternalMemoryHandleTypeFlags = C.VK_EXTERNAL_MEMORY_HANDLE_TYPE_D3D12_HEAP_BIT
+	ExternalMemoryHandleTypeD3D12ResourceBit   ExternalMemoryHandleTypeFlags = C.VK_EXTERNAL_MEMORY_HANDLE_TYPE_D3D12_RESOURCE_BIT
+	// ExternalMemoryHandleTypeDmaBufBit is a Linux dma-buf file descriptor, the usual way to
+	// hand buffers to compositors and media APIs (VK_EXT_external_memory_dma_buf)
+	ExternalMemoryHandleTypeDmaBufBit ExternalMemoryHandleTypeFlags = C.VK_EXTERNAL_MEMORY_HANDLE_TYPE_DMA_BUF_BIT_EXT
+	// ExternalMemoryHandleTypeHostAllocationBit is a host pointer allocated by the application
+	// (VK_EXT_external_memory_host)
+	ExternalMemoryHandleTypeHostAllocationBit ExternalMemoryHandleTypeFlags = C.VK_EXTERNAL_MEMORY_HANDLE_TYPE_HOST_ALLOCATION_BIT_EXT
+)
+
+// ExternalMemoryFeatureFlags describe what can be done with an external memory handle type
+type ExternalMemoryFeatureFlags uint32
+
+const (
+	// ExternalMemoryFeatureDedicatedOnlyBit requires memory of this handle type to be a
+	// dedicated allocation
+	ExternalMemoryFeatureDedicatedOnlyBit ExternalMemoryFeatureFlags = C.VK_EXTERNAL_MEMORY_FEATURE_DEDICATED_ONLY_BIT
+	// ExternalMemoryFeatureExportableBit allows exporting memory as this handle type
+	ExternalMemoryFeatureExportableBit ExternalMemoryFeatureFlags = C.VK_EXTERNAL_MEMORY_FEATURE_EXPORTABLE_BIT
+	// ExternalMemoryFeatureImportableBit allows importing handles of this type
+	ExternalMemoryFeatureImportableBit ExternalMemoryFeatureFlags = C.VK_EXTERNAL_MEMORY_FEATURE_IMPORTABLE_BIT
+)
+
+// PhysicalDeviceExternalBufferInfo describes a buffer whose external memory support is queried
+type PhysicalDeviceExternalBufferInfo struct {
+	Flags BufferCreateFlags
+	Usage BufferUsageFlags
+	// HandleType is the single handle type the buffer memory would be shared through
+	HandleType ExternalMemoryHandleTypeFlags
+}
+
+// ExternalBufferProperties reports how a buffer can share memory through a handle type
+type ExternalBufferProperties struct {
+	ExternalMemoryFeatures ExternalMemoryFeatureFlags
+	// ExportFromImportedHandleTypes lists the handle types memory imported through the queried
+	// handle type can be exported as again
+	ExportFromImportedHandleTypes ExternalMemoryHandleTypeFlags
+	// CompatibleHandleTypes lists the handle types that can be requested together with the
+	// queried one when creating the buffer
+	CompatibleHandleTypes ExternalMemoryHandleTypeFlags
+}
+
+// GetPhysicalDeviceExternalBufferProperties reports whether buffers created with the given
+// flags and usage can export or import memory through externalBufferInfo.HandleType. Query it
+// before allocating exportable memory or importing memory from another API. Core in Vulkan 1.1.
+// An empty ExternalBufferProperties means the handle type is not supported.
+func GetPhysicalDeviceExternalBufferProperties(physicalDevice PhysicalDevice, externalBufferInfo *PhysicalDeviceExternalBufferInfo) ExternalBufferProperties {
+	if physicalDevice == nil || externalBufferInfo == nil {
+		return ExternalBufferProperties{}
+	}
+
+	cInfo := C.VkPhysicalDeviceExternalBufferInfo{
+		sType:      C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_EXTERNAL_BUFFER_INFO,
+		flags:      C.VkBufferCreateFlags(externalBufferInfo.Flags),
+		usage:      C.VkBufferUsageFlags(externalBufferInfo.Usage),
+		handleType: C.VkExternalMemoryHandleTypeFlagBits(externalBufferInfo.HandleType),
+	}
+	var cProps C.VkExternalBufferProperties
+	cProps.sType = C.VK_STRUCTURE_TYPE_EXTERNAL_BUFFER_PROPERTIES
+
+	C.vkGetPhysicalDeviceExternalBufferProperties(C.VkPhysicalDevice(physicalDevice), &cInfo, &cProps)
+
+	return ExternalBufferProperties{
+		ExternalMemoryFeatures:        ExternalMemoryFeatureFlags(cProps.externalMemoryProperties.externalMemoryFeatures),
+		ExportFromImportedHandleTypes: ExternalMemoryHandleTypeFlags(cProps.externalMemoryProperties.exportFromImportedHandleTypes),
+		CompatibleHandleTypes:         ExternalMemoryHandleTypeFlags(cProps.externalMemoryProperties.compatibleHandleTypes),
+	}
+}
+
+// MemoryGetFdInfo selects the memory and handle type exported by GetMemoryFdKHR
+type MemoryGetFdInfo struct {
+	// Memory must have been allocated with HandleType in MemoryAllocateInfo.ExportHandleTypes
+	Memory     DeviceMemory
+	HandleType ExternalMemoryHandleTypeFlags
+}
+
+// ImportMemoryFdInfo imports memory from a file descriptor when passed in
+// MemoryAllocateInfo.ImportMemoryFd. On success Vulkan takes ownership of Fd, which the
+// application must not use or close afterwards.
+type ImportMemoryFdInfo struct {
+	// HandleType must be ExternalMemoryHandleTypeOpaqueFdBit or ExternalMemoryHandleTypeDmaBufBit
+	HandleType ExternalMemoryHandleTypeFlags
+	Fd         int
+}
+
+// ImportMemoryWin32HandleInfo imports memory from a Windows handle when passed in
+// MemoryAllocateInfo.ImportMemoryWin32Handle. Only supported on Windows; unlike file
+// descriptors, the application keeps ownership of Handle.
+type ImportMemoryWin32HandleInfo struct {
+	// HandleType is one of the Win32 or D3D handle types
+	HandleType ExternalMemoryHandleTypeFlags
+	// Handle is the HANDLE value to import
+	Handle uintptr
+}
+
+// validateSingleHandleType checks that handleType names exactly one external handle type
+func validateSingleHandleType(param string, handleType uint32) error {
+	if bits.OnesCount32(handleType) != 1 {
+		return NewValidationError(param, "must be exactly one external handle type")
+	}
+	return nil
+}
+
+// validateImportMemoryFdInfo checks a file descriptor import request
+func validateImportMemoryFdInfo(importInfo *ImportMemoryFdInfo) error {
+	if importInfo.HandleType != ExternalMemoryHandleTypeOpaqueFdBit && importInfo.HandleType != ExternalMemoryHandleTypeDmaBufBit {
+		return NewValidationError("ImportMemoryFd.HandleType", "must be ExternalMemoryHandleTypeOpaqueFdBit or ExternalMemoryHandleTypeDmaBufBit")
+	}
+	if importInfo.Fd < 0 {
+		return NewValidationError("ImportMemoryFd.Fd", "must be a valid file descriptor")
+	}
+	return nil
+}
+
+// LoadExternalMemoryFdFunctions loads VK_KHR_external_memory_fd functions for a device.
+//
+// This function MUST be called after creating a logical device with the
+// VK_KHR_external_memory_fd extension enabled and before exporting memory.
+//
+// IMPORTANT: This function is NOT thread-safe. Only one device is supported at a time;
+// calling this function again will overwrite previously loaded function pointers.
+//
+// Returns false if any external memory fd function could not be loaded.
+func LoadExternalMemoryFdFunctions(device Device) bool {
+	return C.loadExternalMemoryFdDeviceFunctions(C.VkDevice(device)) != 0
+}
+
+// GetMemoryFdKHR exports device memory as a POSIX file descriptor that another API or process
+// can import, e.g. to hand decoded video frames to OpenGL or CUDA. Each call returns a new
+// descriptor owned by the caller, who must close it.
+// Returns an error if LoadExternalMemoryFdFunctions was not called.
+func GetMemoryFdKHR(device Device, getFdInfo *MemoryGetFdInfo) (int, error) {
+	if device == nil {
+		return -1, NewValidationError("device", "cannot be nil")
+	}
+	if getFdInfo == nil {
+		return -1, NewValidationError("getFdInfo", "cannot be nil")
+	}
+	if getFdInfo.Memory == nil {
+		return -1, NewValidationError("getFdInfo.Memory", "cannot be nil")
+	}
+	if err := validateSingleHandleType("getFdInfo.HandleType", uint32(getFdInfo.HandleType)); err != nil {
+		return -1, err
+	}
+
+	cInfo := C.VkMemoryGetFdInfoKHR{
+		sType:      C.VK_STRUCTURE_TYPE_MEMORY_GET_FD_INFO_KHR,
+		memory:     C.VkDeviceMemory(getFdInfo.Memory),
+		handleType: C.VkExternalMemoryHandleTypeFlagBits(getFdInfo.HandleType),
+	}
+	var fd C.int
+	result := Result(C.call_vkGetMemoryFdKHR(C.VkDevice(device), &cInfo, &fd))
+	traceResult("vkGetMemoryFdKHR", result)
+	if result != Success {
+		return -1, NewVulkanError(result, "GetMemoryFdKHR", "failed to export memory file descriptor")
+	}
+	return int(fd), nil
+}
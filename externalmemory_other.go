@@ -0,0 +1,10 @@
+//go:build cgo && !windows
+
+package vulkan
+
+import "unsafe"
+
+// importMemoryWin32HandleInfoToC rejects Win32 handle imports, which only exist on Windows
+func importMemoryWin32HandleInfoToC(importInfo *ImportMemoryWin32HandleInfo, next unsafe.Pointer) (unsafe.Pointer, error) {
+	return nil, NewValidationError("ImportMemoryWin32Handle", "is only supported on Windows")
+}
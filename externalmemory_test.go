@@ -0,0 +1,116 @@
+//go:build cgo
+
+package vulkan
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestGetPhysicalDeviceExternalBufferPropertiesInvalidInput tests that invalid input reports no support
+func TestGetPhysicalDeviceExternalBufferPropertiesInvalidInput(t *testing.T) {
+	info := &PhysicalDeviceExternalBufferInfo{
+		Usage:      BufferUsageTransferSrcBit,
+		HandleType: ExternalMemoryHandleTypeOpaqueFdBit,
+	}
+
+	if props := GetPhysicalDeviceExternalBufferProperties(nil, info); props != (ExternalBufferProperties{}) {
+		t.Errorf("Expected empty properties for nil physical device, got %+v", props)
+	}
+	if props := GetPhysicalDeviceExternalBufferProperties(PhysicalDevice(uintptr(0x1234)), nil); props != (ExternalBufferProperties{}) {
+		t.Errorf("Expected empty properties for nil info, got %+v", props)
+	}
+}
+
+// TestExternalMemoryFdValidation tests input validation and the unloaded-extension error
+func TestExternalMemoryFdValidation(t *testing.T) {
+	fakeDevice := Device(uintptr(0x1234))
+	fakeMemory := DeviceMemory(uintptr(0x5678))
+
+	tests := []struct {
+		name       string
+		call       func() error
+		errorParam string
+	}{
+		{
+			name: "nil get fd info",
+			call: func() error {
+				_, err := GetMemoryFdKHR(fakeDevice, nil)
+				return err
+			},
+			errorParam: "getFdInfo",
+		},
+		{
+			name: "nil memory",
+			call: func() error {
+				_, err := GetMemoryFdKHR(fakeDevice, &MemoryGetFdInfo{HandleType: ExternalMemoryHandleTypeOpaqueFdBit})
+				return err
+			},
+			errorParam: "getFdInfo.Memory",
+		},
+		{
+			name: "several handle types",
+			call: func() error {
+				_, err := GetMemoryFdKHR(fakeDevice, &MemoryGetFdInfo{
+					Memory:     fakeMemory,
+					HandleType: ExternalMemoryHandleTypeOpaqueFdBit | ExternalMemoryHandleTypeDmaBufBit,
+				})
+				return err
+			},
+			errorParam: "getFdInfo.HandleType",
+		},
+		{
+			name: "import with win32 handle type",
+			call: func() error {
+				_, err := AllocateMemory(fakeDevice, &MemoryAllocateInfo{
+					AllocationSize: 1024,
+					ImportMemoryFd: &ImportMemoryFdInfo{HandleType: ExternalMemoryHandleTypeOpaqueWin32Bit, Fd: 3},
+				})
+				return err
+			},
+			errorParam: "ImportMemoryFd.HandleType",
+		},
+		{
+			name: "import with invalid fd",
+			call: func() error {
+				_, err := AllocateMemory(fakeDevice, &MemoryAllocateInfo{
+					AllocationSize: 1024,
+					ImportMemoryFd: &ImportMemoryFdInfo{HandleType: ExternalMemoryHandleTypeDmaBufBit, Fd: -1},
+				})
+				return err
+			},
+			errorParam: "ImportMemoryFd.Fd",
+		},
+		{
+			name: "fd and win32 imports",
+			call: func() error {
+				_, err := AllocateMemory(fakeDevice, &MemoryAllocateInfo{
+					AllocationSize:          1024,
+					ImportMemoryFd:          &ImportMemoryFdInfo{HandleType: ExternalMemoryHandleTypeOpaqueFdBit, Fd: 3},
+					ImportMemoryWin32Handle: &ImportMemoryWin32HandleInfo{HandleType: ExternalMemoryHandleTypeOpaqueWin32Bit, Handle: 0x10},
+				})
+				return err
+			},
+			errorParam: "allocateInfo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+
+	// Without LoadExternalMemoryFdFunctions the export reports the missing extension
+	_, err := GetMemoryFdKHR(fakeDevice, &MemoryGetFdInfo{Memory: fakeMemory, HandleType: ExternalMemoryHandleTypeOpaqueFdBit})
+	if !errors.Is(err, ErrorExtensionNotPresent) {
+		t.Errorf("Expected ErrorExtensionNotPresent, got %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+//go:build windows
+
+package vulkan
+
+/*
+#define VK_USE_PLATFORM_WIN32_KHR
+#include <vulkan/vulkan.h>
+#include <stdlib.h>
+#include <stdint.h>
+
+// Function pointers for VK_KHR_external_memory_win32 functions
+// These need to be loaded dynamically at runtime.
+//
+// IMPORTANT: These are global static pointers and NOT thread-safe during loading.
+// LoadExternalMemoryWin32Functions must be called from a single thread during
+// initialization before any concurrent external memory API usage.
+static PFN_vkGetMemoryWin32HandleKHR pfn_vkGetMemoryWin32HandleKHR = NULL;
+
+static int loadExternalMemoryWin32DeviceFunctions(VkDevice device) {
+    if (device == VK_NULL_HANDLE) {
+        return 0;
+    }
+    pfn_vkGetMemoryWin32HandleKHR = (PFN_vkGetMemoryWin32HandleKHR)
+        vkGetDeviceProcAddr(device, "vkGetMemoryWin32HandleKHR");
+
+    return pfn_vkGetMemoryWin32HandleKHR != NULL;
+}
+
+// Wrappers return VK_ERROR_EXTENSION_NOT_PRESENT if the function pointer is NULL.
+// HANDLE values cross the cgo boundary as uintptr_t so Go never holds them as pointers.
+static VkResult call_vkGetMemoryWin32HandleKHR(VkDevice device, const VkMemoryGetWin32HandleInfoKHR* pGetWin32HandleInfo, uintptr_t* pHandle) {
+    if (pfn_vkGetMemoryWin32HandleKHR == NULL) {
+        return VK_ERROR_EXTENSION_NOT_PRESENT;
+    }
+    HANDLE handle = NULL;
+    VkResult result = pfn_vkGetMemoryWin32HandleKHR(device, pGetWin32HandleInfo, &handle);
+    *pHandle = (uintptr_t)handle;
+    return result;
+}
+
+static void setImportMemoryWin32Handle(VkImportMemoryWin32HandleInfoKHR* info, uintptr_t handle) {
+    info->handle = (HANDLE)handle;
+}
+*/
+import "C"
+
+import "unsafe"
+
+// ExtensionNameExternalMemoryWin32 is the external memory Win32 handle device extension name
+const ExtensionNameExternalMemoryWin32 = "VK_KHR_external_memory_win32"
+
+// MemoryGetWin32HandleInfo selects the memory and handle type exported by
+// GetMemoryWin32HandleKHR
+type MemoryGetWin32HandleInfo struct {
+	// Memory must have been allocated with HandleType in MemoryAllocateInfo.ExportHandleTypes
+	Memory     DeviceMemory
+	HandleType ExternalMemoryHandleTypeFlags
+}
+
+// LoadExternalMemoryWin32Functions loads VK_KHR_external_memory_win32 functions for a device.
+//
+// This function MUST be called after creating a logical device with the
+// VK_KHR_external_memory_win32 extension enabled and before exporting memory.
+//
+// IMPORTANT: This function is NOT thread-safe. Only one device is supported at a time;
+// calling this function again will overwrite previously loaded function pointers.
+//
+// Returns false if any external memory Win32 function could not be loaded.
+func LoadExternalMemoryWin32Functions(device Device) bool {
+	return C.loadExternalMemoryWin32DeviceFunctions(C.VkDevice(device)) != 0
+}
+
+// GetMemoryWin32HandleKHR exports device memory as a Windows handle. NT handles returned for
+// ExternalMemoryHandleTypeOpaqueWin32Bit are owned by the caller and must be closed with
+// CloseHandle; KMT handles are not reference counted.
+// Returns an error if LoadExternalMemoryWin32Functions was not called.
+func GetMemoryWin32HandleKHR(device Device, getWin32HandleInfo *MemoryGetWin32HandleInfo) (uintptr, error) {
+	if device == nil {
+		return 0, NewValidationError("device", "cannot be nil")
+	}
+	if getWin32HandleInfo == nil {
+		return 0, NewValidationError("getWin32HandleInfo", "cannot be nil")
+	}
+	if getWin32HandleInfo.Memory == nil {
+		return 0, NewValidationError("getWin32HandleInfo.Memory", "cannot be nil")
+	}
+	if err := validateSingleHandleType("getWin32HandleInfo.HandleType", uint32(getWin32HandleInfo.HandleType)); err != nil {
+		return 0, err
+	}
+
+	cInfo := C.VkMemoryGetWin32HandleInfoKHR{
+		sType:      C.VK_STRUCTURE_TYPE_MEMORY_GET_WIN32_HANDLE_INFO_KHR,
+		memory:     C.VkDeviceMemory(getWin32HandleInfo.Memory),
+		handleType: C.VkExternalMemoryHandleTypeFlagBits(getWin32HandleInfo.HandleType),
+	}
+	var handle C.uintptr_t
+	result := Result(C.call_vkGetMemoryWin32HandleKHR(C.VkDevice(device), &cInfo, &handle))
+	traceResult("vkGetMemoryWin32HandleKHR", result)
+	if result != Success {
+		return 0, NewVulkanError(result, "GetMemoryWin32HandleKHR", "failed to export memory handle")
+	}
+	return uintptr(handle), nil
+}
+
+// importMemoryWin32HandleInfoToC prepends a VkImportMemoryWin32HandleInfoKHR struct to the
+// pNext chain next. The struct is allocated in C memory, which the caller must free.
+func importMemoryWin32HandleInfoToC(importInfo *ImportMemoryWin32HandleInfo, next unsafe.Pointer) (unsafe.Pointer, error) {
+	if err := validateSingleHandleType("ImportMemoryWin32Handle.HandleType", uint32(importInfo.HandleType)); err != nil {
+		return nil, err
+	}
+	if importInfo.HandleType&(ExternalMemoryHandleTypeOpaqueFdBit|ExternalMemoryHandleTypeDmaBufBit|ExternalMemoryHandleTypeHostAllocationBit) != 0 {
+		return nil, NewValidationError("ImportMemoryWin32Handle.HandleType", "must be a Win32 or D3D handle type")
+	}
+	if importInfo.Handle == 0 {
+		return nil, NewValidationError("ImportMemoryWin32Handle.Handle", "cannot be zero")
+	}
+
+	cImportInfo := (*C.VkImportMemoryWin32HandleInfoKHR)(C.calloc(1, C.sizeof_VkImportMemoryWin32HandleInfoKHR))
+	if cImportInfo == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "AllocateMemory", "failed to allocate memory for import memory info")
+	}
+	cImportInfo.sType = C.VK_STRUCTURE_TYPE_IMPORT_MEMORY_WIN32_HANDLE_INFO_KHR
+	cImportInfo.pNext = next
+	cImportInfo.handleType = C.VkExternalMemoryHandleTypeFlagBits(importInfo.HandleType)
+	C.setImportMemoryWin32Handle(cImportInfo, C.uintptr_t(importInfo.Handle))
+	return unsafe.Pointer(cImportInfo), nil
+}
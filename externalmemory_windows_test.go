@@ -0,0 +1,31 @@
+//go:build windows && cgo
+
+package vulkan
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestExternalMemoryWin32Validation tests input validation and the unloaded-extension error
+func TestExternalMemoryWin32Validation(t *testing.T) {
+	fakeDevice := Device(uintptr(0x1234))
+	fakeMemory := DeviceMemory(uintptr(0x5678))
+
+	var validationErr *ValidationError
+	if _, err := GetMemoryWin32HandleKHR(fakeDevice, &MemoryGetWin32HandleInfo{HandleType: ExternalMemoryHandleTypeOpaqueWin32Bit}); !errors.As(err, &validationErr) || validationErr.Parameter != "getWin32HandleInfo.Memory" {
+		t.Errorf("Expected ValidationError for getWin32HandleInfo.Memory, got %v", err)
+	}
+	if _, err := AllocateMemory(fakeDevice, &MemoryAllocateInfo{
+		AllocationSize:          1024,
+		ImportMemoryWin32Handle: &ImportMemoryWin32HandleInfo{HandleType: ExternalMemoryHandleTypeOpaqueFdBit, Handle: 0x10},
+	}); !errors.As(err, &validationErr) || validationErr.Parameter != "ImportMemoryWin32Handle.HandleType" {
+		t.Errorf("Expected ValidationError for ImportMemoryWin32Handle.HandleType, got %v", err)
+	}
+
+	// Without LoadExternalMemoryWin32Functions the export reports the missing extension
+	_, err := GetMemoryWin32HandleKHR(fakeDevice, &MemoryGetWin32HandleInfo{Memory: fakeMemory, HandleType: ExternalMemoryHandleTypeOpaqueWin32Bit})
+	if !errors.Is(err, ErrorExtensionNotPresent) {
+		t.Errorf("Expected ErrorExtensionNotPresent, got %v", err)
+	}
+}
@@ -0,0 +1,415 @@
+package vulkan
+
+/*
+#include <vulkan/vulkan.h>
+#include <stdlib.h>
+
+// Function pointers for VK_KHR_external_semaphore_fd and VK_KHR_external_fence_fd functions
+// These need to be loaded dynamically at runtime.
+//
+// IMPORTANT: These are global static pointers and NOT thread-safe during loading.
+// LoadExternalSemaphoreFdFunctions and LoadExternalFenceFdFunctions must be called from a
+// single thread during initialization before any concurrent external sync API usage.
+static PFN_vkGetSemaphoreFdKHR pfn_vkGetSemaphoreFdKHR = NULL;
+static PFN_vkImportSemaphoreFdKHR pfn_vkImportSemaphoreFdKHR = NULL;
+static PFN_vkGetFenceFdKHR pfn_vkGetFenceFdKHR = NULL;
+static PFN_vkImportFenceFdKHR pfn_vkImportFenceFdKHR = NULL;
+
+static int loadExternalSemaphoreFdDeviceFunctions(VkDevice device) {
+    if (device == VK_NULL_HANDLE) {
+        return 0;
+    }
+    pfn_vkGetSemaphoreFdKHR = (PFN_vkGetSemaphoreFdKHR)
+        vkGetDeviceProcAddr(device, "vkGetSemaphoreFdKHR");
+    pfn_vkImportSemaphoreFdKHR = (PFN_vkImportSemaphoreFdKHR)
+        vkGetDeviceProcAddr(device, "vkImportSemaphoreFdKHR");
+
+    return pfn_vkGetSemaphoreFdKHR != NULL &&
+           pfn_vkImportSemaphoreFdKHR != NULL;
+}
+
+static int loadExternalFenceFdDeviceFunctions(VkDevice device) {
+    if (device == VK_NULL_HANDLE) {
+        return 0;
+    }
+    pfn_vkGetFenceFdKHR = (PFN_vkGetFenceFdKHR)
+        vkGetDeviceProcAddr(device, "vkGetFenceFdKHR");
+    pfn_vkImportFenceFdKHR = (PFN_vkImportFenceFdKHR)
+        vkGetDeviceProcAddr(device, "vkImportFenceFdKHR");
+
+    return pfn_vkGetFenceFdKHR != NULL &&
+           pfn_vkImportFenceFdKHR != NULL;
+}
+
+// Wrappers return VK_ERROR_EXTENSION_NOT_PRESENT if the function pointer is NULL.
+static VkResult call_vkGetSemaphoreFdKHR(VkDevice device, const VkSemaphoreGetFdInfoKHR* pGetFdInfo, int* pFd) {
+    if (pfn_vkGetSemaphoreFdKHR == NULL) {
+        return VK_ERROR_EXTENSION_NOT_PRESENT;
+    }
+    return pfn_vkGetSemaphoreFdKHR(device, pGetFdInfo, pFd);
+}
+
+static VkResult call_vkImportSemaphoreFdKHR(VkDevice device, const VkImportSemaphoreFdInfoKHR* pImportSemaphoreFdInfo) {
+    if (pfn_vkImportSemaphoreFdKHR == NULL) {
+        return VK_ERROR_EXTENSION_NOT_PRESENT;
+    }
+    return pfn_vkImportSemaphoreFdKHR(device, pImportSemaphoreFdInfo);
+}
+
+static VkResult call_vkGetFenceFdKHR(VkDevice device, const VkFenceGetFdInfoKHR* pGetFdInfo, int* pFd) {
+    if (pfn_vkGetFenceFdKHR == NULL) {
+        return VK_ERROR_EXTENSION_NOT_PRESENT;
+    }
+    return pfn_vkGetFenceFdKHR(device, pGetFdInfo, pFd);
+}
+
+static VkResult call_vkImportFenceFdKHR(VkDevice device, const VkImportFenceFdInfoKHR* pImportFenceFdInfo) {
+    if (pfn_vkImportFenceFdKHR == NULL) {
+        return VK_ERROR_EXTENSION_NOT_PRESENT;
+    }
+    return pfn_vkImportFenceFdKHR(device, pImportFenceFdInfo);
+}
+*/
+import "C"
+
+// Extension names for sharing semaphores and fences through file descriptors
+const (
+	ExtensionNameExternalSemaphoreFd = "VK_KHR_external_semaphore_fd"
+	ExtensionNameExternalFenceFd     = "VK_KHR_external_fence_fd"
+)
+
+// ExternalSemaphoreHandleTypeFlags identify the kinds of OS handles a semaphore can be shared
+// through (external semaphores, core in Vulkan 1.1)
+type ExternalSemaphoreHandleTypeFlags uint32
+
+const (
+	// ExternalSemaphoreHandleTypeOpaqueFdBit is a POSIX file descriptor only meaningful to
+	// Vulkan drivers and APIs sharing the same driver
+	ExternalSemaphoreHandleTypeOpaqueFdBit       ExternalSemaphoreHandleTypeFlags = C.VK_EXTERNAL_SEMAPHORE_HANDLE_TYPE_OPAQUE_FD_BIT
+	ExternalSemaphoreHandleTypeOpaqueWin32Bit    ExternalSemaphoreHandleTypeFlags = C.VK_EXTERNAL_SEMAPHORE_HANDLE_TYPE_OPAQUE_WIN32_BIT
+	ExternalSemaphoreHandleTypeOpaqueWin32KmtBit ExternalSemaphoreHandleTypeFlags = C.VK_EXTERNAL_SEMAPHORE_HANDLE_TYPE_OPAQUE_WIN32_KMT_BIT
+	ExternalSemaphoreHandleTypeD3D12FenceBit     ExternalSemaphoreHandleTypeFlags = C.VK_EXTERNAL_SEMAPHORE_HANDLE_TYPE_D3D12_FENCE_BIT
+	// ExternalSemaphoreHandleTypeSyncFdBit is a Linux sync file, the fence type used by
+	// compositors and EGL_ANDROID_native_fence_sync; it can only be imported temporarily
+	ExternalSemaphoreHandleTypeSyncFdBit ExternalSemaphoreHandleTypeFlags = C.VK_EXTERNAL_SEMAPHORE_HANDLE_TYPE_SYNC_FD_BIT
+)
+
+// ExternalFenceHandleTypeFlags identify the kinds of OS handles a fence can be shared through
+// (external fences, core in Vulkan 1.1)
+type ExternalFenceHandleTypeFlags uint32
+
+const (
+	// ExternalFenceHandleTypeOpaqueFdBit is a POSIX file descriptor only meaningful to Vulkan
+	// drivers and APIs sharing the same driver
+	ExternalFenceHandleTypeOpaqueFdBit       ExternalFenceHandleTypeFlags = C.VK_EXTERNAL_FENCE_HANDLE_TYPE_OPAQUE_FD_BIT
+	ExternalFenceHandleTypeOpaqueWin32Bit    ExternalFenceHandleTypeFlags = C.VK_EXTERNAL_FENCE_HANDLE_TYPE_OPAQUE_WIN32_BIT
+	ExternalFenceHandleTypeOpaqueWin32KmtBit ExternalFenceHandleTypeFlags = C.VK_EXTERNAL_FENCE_HANDLE_TYPE_OPAQUE_WIN32_KMT_BIT
+	// ExternalFenceHandleTypeSyncFdBit is a Linux sync file; it can only be imported temporarily
+	ExternalFenceHandleTypeSyncFdBit ExternalFenceHandleTypeFlags = C.VK_EXTERNAL_FENCE_HANDLE_TYPE_SYNC_FD_BIT
+)
+
+// ExternalSemaphoreFeatureFlags describe what can be done with an external semaphore handle type
+type ExternalSemaphoreFeatureFlags uint32
+
+const (
+	ExternalSemaphoreFeatureExportableBit ExternalSemaphoreFeatureFlags = C.VK_EXTERNAL_SEMAPHORE_FEATURE_EXPORTABLE_BIT
+	ExternalSemaphoreFeatureImportableBit ExternalSemaphoreFeatureFlags = C.VK_EXTERNAL_SEMAPHORE_FEATURE_IMPORTABLE_BIT
+)
+
+// ExternalFenceFeatureFlags describe what can be done with an external fence handle type
+type ExternalFenceFeatureFlags uint32
+
+const (
+	ExternalFenceFeatureExportableBit ExternalFenceFeatureFlags = C.VK_EXTERNAL_FENCE_FEATURE_EXPORTABLE_BIT
+	ExternalFenceFeatureImportableBit ExternalFenceFeatureFlags = C.VK_EXTERNAL_FENCE_FEATURE_IMPORTABLE_BIT
+)
+
+// SemaphoreImportFlags control how an imported payload replaces a semaphore's own
+type SemaphoreImportFlags uint32
+
+const (
+	// SemaphoreImportTemporaryBit replaces the payload only until the next wait, after which
+	// the semaphore returns to its original payload
+	SemaphoreImportTemporaryBit SemaphoreImportFlags = C.VK_SEMAPHORE_IMPORT_TEMPORARY_BIT
+)
+
+// FenceImportFlags control how an imported payload replaces a fence's own
+type FenceImportFlags uint32
+
+const (
+	// FenceImportTemporaryBit replaces the payload only until the fence is reset
+	FenceImportTemporaryBit FenceImportFlags = C.VK_FENCE_IMPORT_TEMPORARY_BIT
+)
+
+// ExternalSemaphoreProperties reports how a semaphore can be shared through a handle type
+type ExternalSemaphoreProperties struct {
+	// ExportFromImportedHandleTypes lists the handle types a semaphore imported through the
+	// queried handle type can be exported as again
+	ExportFromImportedHandleTypes ExternalSemaphoreHandleTypeFlags
+	CompatibleHandleTypes         ExternalSemaphoreHandleTypeFlags
+	ExternalSemaphoreFeatures     ExternalSemaphoreFeatureFlags
+}
+
+// ExternalFenceProperties reports how a fence can be shared through a handle type
+type ExternalFenceProperties struct {
+	// ExportFromImportedHandleTypes lists the handle types a fence imported through the
+	// queried handle type can be exported as again
+	ExportFromImportedHandleTypes ExternalFenceHandleTypeFlags
+	CompatibleHandleTypes         ExternalFenceHandleTypeFlags
+	ExternalFenceFeatures         ExternalFenceFeatureFlags
+}
+
+// SemaphoreGetFdInfo selects the semaphore and handle type exported by GetSemaphoreFdKHR
+type SemaphoreGetFdInfo struct {
+	// Semaphore must have been created with HandleType in SemaphoreCreateInfo.ExportHandleTypes
+	Semaphore  Semaphore
+	HandleType ExternalSemaphoreHandleTypeFlags
+}
+
+// ImportSemaphoreFdInfo describes a file descriptor imported into a semaphore. On success
+// Vulkan takes ownership of Fd, which the application must not use or close afterwards.
+type ImportSemaphoreFdInfo struct {
+	Semaphore  Semaphore
+	Flags      SemaphoreImportFlags
+	HandleType ExternalSemaphoreHandleTypeFlags
+	// Fd may be -1 for ExternalSemaphoreHandleTypeSyncFdBit to import an already signaled payload
+	Fd int
+}
+
+// FenceGetFdInfo selects the fence and handle type exported by GetFenceFdKHR
+type FenceGetFdInfo struct {
+	// Fence must have been created with HandleType in FenceCreateInfo.ExportHandleTypes
+	Fence      Fence
+	HandleType ExternalFenceHandleTypeFlags
+}
+
+// ImportFenceFdInfo describes a file descriptor imported into a fence. On success Vulkan
+// takes ownership of Fd, which the application must not use or close afterwards.
+type ImportFenceFdInfo struct {
+	Fence      Fence
+	Flags      FenceImportFlags
+	HandleType ExternalFenceHandleTypeFlags
+	// Fd may be -1 for ExternalFenceHandleTypeSyncFdBit to import an already signaled payload
+	Fd int
+}
+
+// GetPhysicalDeviceExternalSemaphoreProperties reports whether binary semaphores can be
+// exported or imported through handleType. Core in Vulkan 1.1.
+// An empty ExternalSemaphoreProperties means the handle type is not supported.
+func GetPhysicalDeviceExternalSemaphoreProperties(physicalDevice PhysicalDevice, handleType ExternalSemaphoreHandleTypeFlags) ExternalSemaphoreProperties {
+	if physicalDevice == nil {
+		return ExternalSemaphoreProperties{}
+	}
+
+	cInfo := C.VkPhysicalDeviceExternalSemaphoreInfo{
+		sType:      C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_EXTERNAL_SEMAPHORE_INFO,
+		handleType: C.VkExternalSemaphoreHandleTypeFlagBits(handleType),
+	}
+	var cProps C.VkExternalSemaphoreProperties
+	cProps.sType = C.VK_STRUCTURE_TYPE_EXTERNAL_SEMAPHORE_PROPERTIES
+
+	C.vkGetPhysicalDeviceExternalSemaphoreProperties(C.VkPhysicalDevice(physicalDevice), &cInfo, &cProps)
+
+	return ExternalSemaphoreProperties{
+		ExportFromImportedHandleTypes: ExternalSemaphoreHandleTypeFlags(cProps.exportFromImportedHandleTypes),
+		CompatibleHandleTypes:         ExternalSemaphoreHandleTypeFlags(cProps.compatibleHandleTypes),
+		ExternalSemaphoreFeatures:     ExternalSemaphoreFeatureFlags(cProps.externalSemaphoreFeatures),
+	}
+}
+
+// GetPhysicalDeviceExternalFenceProperties reports whether fences can be exported or
+// imported through handleType. Core in Vulkan 1.1.
+// An empty ExternalFenceProperties means the handle type is not supported.
+func GetPhysicalDeviceExternalFenceProperties(physicalDevice PhysicalDevice, handleType ExternalFenceHandleTypeFlags) ExternalFenceProperties {
+	if physicalDevice == nil {
+		return ExternalFenceProperties{}
+	}
+
+	cInfo := C.VkPhysicalDeviceExternalFenceInfo{
+		sType:      C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_EXTERNAL_FENCE_INFO,
+		handleType: C.VkExternalFenceHandleTypeFlagBits(handleType),
+	}
+	var cProps C.VkExternalFenceProperties
+	cProps.sType = C.VK_STRUCTURE_TYPE_EXTERNAL_FENCE_PROPERTIES
+
+	C.vkGetPhysicalDeviceExternalFenceProperties(C.VkPhysicalDevice(physicalDevice), &cInfo, &cProps)
+
+	return ExternalFenceProperties{
+		ExportFromImportedHandleTypes: ExternalFenceHandleTypeFlags(cProps.exportFromImportedHandleTypes),
+		CompatibleHandleTypes:         ExternalFenceHandleTypeFlags(cProps.compatibleHandleTypes),
+		ExternalFenceFeatures:         ExternalFenceFeatureFlags(cProps.externalFenceFeatures),
+	}
+}
+
+// LoadExternalSemaphoreFdFunctions loads VK_KHR_external_semaphore_fd functions for a device.
+//
+// This function MUST be called after creating a logical device with the
+// VK_KHR_external_semaphore_fd extension enabled and before sharing semaphores.
+//
+// IMPORTANT: This function is NOT thread-safe. Only one device is supported at a time;
+// calling this function again will overwrite previously loaded function pointers.
+//
+// Returns false if any external semaphore fd function could not be loaded.
+func LoadExternalSemaphoreFdFunctions(device Device) bool {
+	return C.loadExternalSemaphoreFdDeviceFunctions(C.VkDevice(device)) != 0
+}
+
+// LoadExternalFenceFdFunctions loads VK_KHR_external_fence_fd functions for a device.
+//
+// This function MUST be called after creating a logical device with the
+// VK_KHR_external_fence_fd extension enabled and before sharing fences.
+//
+// IMPORTANT: This function is NOT thread-safe. Only one device is supported at a time;
+// calling this function again will overwrite previously loaded function pointers.
+//
+// Returns false if any external fence fd function could not be loaded.
+func LoadExternalFenceFdFunctions(device Device) bool {
+	return C.loadExternalFenceFdDeviceFunctions(C.VkDevice(device)) != 0
+}
+
+// GetSemaphoreFdKHR exports a semaphore payload as a file descriptor owned by the caller. For
+// ExternalSemaphoreHandleTypeSyncFdBit the semaphore must have a pending signal operation,
+// and exporting resets it as if it had been waited on.
+// Returns an error if LoadExternalSemaphoreFdFunctions was not called.
+func GetSemaphoreFdKHR(device Device, getFdInfo *SemaphoreGetFdInfo) (int, error) {
+	if device == nil {
+		return -1, NewValidationError("device", "cannot be nil")
+	}
+	if getFdInfo == nil {
+		return -1, NewValidationError("getFdInfo", "cannot be nil")
+	}
+	if getFdInfo.Semaphore == nil {
+		return -1, NewValidationError("getFdInfo.Semaphore", "cannot be nil")
+	}
+	if err := validateSingleHandleType("getFdInfo.HandleType", uint32(getFdInfo.HandleType)); err != nil {
+		return -1, err
+	}
+
+	cInfo := C.VkSemaphoreGetFdInfoKHR{
+		sType:      C.VK_STRUCTURE_TYPE_SEMAPHORE_GET_FD_INFO_KHR,
+		semaphore:  C.VkSemaphore(getFdInfo.Semaphore),
+		handleType: C.VkExternalSemaphoreHandleTypeFlagBits(getFdInfo.HandleType),
+	}
+	var fd C.int
+	result := Result(C.call_vkGetSemaphoreFdKHR(C.VkDevice(device), &cInfo, &fd))
+	traceResult("vkGetSemaphoreFdKHR", result)
+	if result != Success {
+		return -1, NewVulkanError(result, "GetSemaphoreFdKHR", "failed to export semaphore file descriptor")
+	}
+	return int(fd), nil
+}
+
+// ImportSemaphoreFdKHR replaces a semaphore's payload with one exported by another API or
+// process, e.g. a sync file from a GL compositor that the next queue submission waits on.
+// Sync files can only be imported with SemaphoreImportTemporaryBit.
+// Returns an error if LoadExternalSemaphoreFdFunctions was not called.
+func ImportSemaphoreFdKHR(device Device, importInfo *ImportSemaphoreFdInfo) error {
+	if device == nil {
+		return NewValidationError("device", "cannot be nil")
+	}
+	if importInfo == nil {
+		return NewValidationError("importInfo", "cannot be nil")
+	}
+	if importInfo.Semaphore == nil {
+		return NewValidationError("importInfo.Semaphore", "cannot be nil")
+	}
+	switch importInfo.HandleType {
+	case ExternalSemaphoreHandleTypeOpaqueFdBit:
+		if importInfo.Fd < 0 {
+			return NewValidationError("importInfo.Fd", "must be a valid file descriptor")
+		}
+	case ExternalSemaphoreHandleTypeSyncFdBit:
+		if importInfo.Flags&SemaphoreImportTemporaryBit == 0 {
+			return NewValidationError("importInfo.Flags", "sync file imports require SemaphoreImportTemporaryBit")
+		}
+	default:
+		return NewValidationError("importInfo.HandleType", "must be ExternalSemaphoreHandleTypeOpaqueFdBit or ExternalSemaphoreHandleTypeSyncFdBit")
+	}
+
+	cInfo := C.VkImportSemaphoreFdInfoKHR{
+		sType:      C.VK_STRUCTURE_TYPE_IMPORT_SEMAPHORE_FD_INFO_KHR,
+		semaphore:  C.VkSemaphore(importInfo.Semaphore),
+		flags:      C.VkSemaphoreImportFlags(importInfo.Flags),
+		handleType: C.VkExternalSemaphoreHandleTypeFlagBits(importInfo.HandleType),
+		fd:         C.int(importInfo.Fd),
+	}
+	result := Result(C.call_vkImportSemaphoreFdKHR(C.VkDevice(device), &cInfo))
+	traceResult("vkImportSemaphoreFdKHR", result)
+	if result != Success {
+		return NewVulkanError(result, "ImportSemaphoreFdKHR", "failed to import semaphore file descriptor")
+	}
+	return nil
+}
+
+// GetFenceFdKHR exports a fence payload as a file descriptor owned by the caller. For
+// ExternalFenceHandleTypeSyncFdBit the fence must be signaled or have a pending signal
+// operation.
+// Returns an error if LoadExternalFenceFdFunctions was not called.
+func GetFenceFdKHR(device Device, getFdInfo *FenceGetFdInfo) (int, error) {
+	if device == nil {
+		return -1, NewValidationError("device", "cannot be nil")
+	}
+	if getFdInfo == nil {
+		return -1, NewValidationError("getFdInfo", "cannot be nil")
+	}
+	if getFdInfo.Fence == nil {
+		return -1, NewValidationError("getFdInfo.Fence", "cannot be nil")
+	}
+	if err := validateSingleHandleType("getFdInfo.HandleType", uint32(getFdInfo.HandleType)); err != nil {
+		return -1, err
+	}
+
+	cInfo := C.VkFenceGetFdInfoKHR{
+		sType:      C.VK_STRUCTURE_TYPE_FENCE_GET_FD_INFO_KHR,
+		fence:      C.VkFence(getFdInfo.Fence),
+		handleType: C.VkExternalFenceHandleTypeFlagBits(getFdInfo.HandleType),
+	}
+	var fd C.int
+	result := Result(C.call_vkGetFenceFdKHR(C.VkDevice(device), &cInfo, &fd))
+	traceResult("vkGetFenceFdKHR", result)
+	if result != Success {
+		return -1, NewVulkanError(result, "GetFenceFdKHR", "failed to export fence file descriptor")
+	}
+	return int(fd), nil
+}
+
+// ImportFenceFdKHR replaces a fence's payload with one exported by another API or process.
+// Sync files can only be imported with FenceImportTemporaryBit.
+// Returns an error if LoadExternalFenceFdFunctions was not called.
+func ImportFenceFdKHR(device Device, importInfo *ImportFenceFdInfo) error {
+	if device == nil {
+		return NewValidationError("device", "cannot be nil")
+	}
+	if importInfo == nil {
+		return NewValidationError("importInfo", "cannot be nil")
+	}
+	if importInfo.Fence == nil {
+		return NewValidationError("importInfo.Fence", "cannot be nil")
+	}
+	switch importInfo.HandleType {
+	case ExternalFenceHandleTypeOpaqueFdBit:
+		if importInfo.Fd < 0 {
+			return NewValidationError("importInfo.Fd", "must be a valid file descriptor")
+		}
+	case ExternalFenceHandleTypeSyncFdBit:
+		if importInfo.Flags&FenceImportTemporaryBit == 0 {
+			return NewValidationError("importInfo.Flags", "sync file imports require FenceImportTemporaryBit")
+		}
+	default:
+		return NewValidationError("importInfo.HandleType", "must be ExternalFenceHandleTypeOpaqueFdBit or ExternalFenceHandleTypeSyncFdBit")
+	}
+
+	cInfo := C.VkImportFenceFdInfoKHR{
+		sType:      C.VK_STRUCTURE_TYPE_IMPORT_FENCE_FD_INFO_KHR,
+		fence:      C.VkFence(importInfo.Fence),
+		flags:      C.VkFenceImportFlags(importInfo.Flags),
+		handleType: C.VkExternalFenceHandleTypeFlagBits(importInfo.HandleType),
+		fd:         C.int(importInfo.Fd),
+	}
+	result := Result(C.call_vkImportFenceFdKHR(C.VkDevice(device), &cInfo))
+	traceResult("vkImportFenceFdKHR", result)
+	if result != Success {
+		return NewVulkanError(result, "ImportFenceFdKHR", "failed to import fence file descriptor")
+	}
+	return nil
+}
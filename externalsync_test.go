@@ -0,0 +1,128 @@
+//go:build cgo
+
+package vulkan
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestExternalSyncFdValidation tests input validation and the unloaded-extension errors
+func TestExternalSyncFdValidation(t *testing.T) {
+	fakeDevice := Device(uintptr(0x1234))
+	fakeSemaphore := Semaphore(uintptr(0x5678))
+	fakeFence := Fence(uintptr(0x9abc))
+
+	tests := []struct {
+		name       string
+		call       func() error
+		errorParam string
+	}{
+		{
+			name: "nil semaphore export",
+			call: func() error {
+				_, err := GetSemaphoreFdKHR(fakeDevice, &SemaphoreGetFdInfo{HandleType: ExternalSemaphoreHandleTypeOpaqueFdBit})
+				return err
+			},
+			errorParam: "getFdInfo.Semaphore",
+		},
+		{
+			name: "several semaphore handle types",
+			call: func() error {
+				_, err := GetSemaphoreFdKHR(fakeDevice, &SemaphoreGetFdInfo{
+					Semaphore:  fakeSemaphore,
+					HandleType: ExternalSemaphoreHandleTypeOpaqueFdBit | ExternalSemaphoreHandleTypeSyncFdBit,
+				})
+				return err
+			},
+			errorParam: "getFdInfo.HandleType",
+		},
+		{
+			name: "permanent sync fd semaphore import",
+			call: func() error {
+				return ImportSemaphoreFdKHR(fakeDevice, &ImportSemaphoreFdInfo{
+					Semaphore:  fakeSemaphore,
+					HandleType: ExternalSemaphoreHandleTypeSyncFdBit,
+					Fd:         -1,
+				})
+			},
+			errorParam: "importInfo.Flags",
+		},
+		{
+			name: "invalid opaque fd semaphore import",
+			call: func() error {
+				return ImportSemaphoreFdKHR(fakeDevice, &ImportSemaphoreFdInfo{
+					Semaphore:  fakeSemaphore,
+					HandleType: ExternalSemaphoreHandleTypeOpaqueFdBit,
+					Fd:         -1,
+				})
+			},
+			errorParam: "importInfo.Fd",
+		},
+		{
+			name: "win32 semaphore handle type",
+			call: func() error {
+				return ImportSemaphoreFdKHR(fakeDevice, &ImportSemaphoreFdInfo{
+					Semaphore:  fakeSemaphore,
+					HandleType: ExternalSemaphoreHandleTypeOpaqueWin32Bit,
+					Fd:         3,
+				})
+			},
+			errorParam: "importInfo.HandleType",
+		},
+		{
+			name: "nil fence export",
+			call: func() error {
+				_, err := GetFenceFdKHR(fakeDevice, &FenceGetFdInfo{HandleType: ExternalFenceHandleTypeSyncFdBit})
+				return err
+			},
+			errorParam: "getFdInfo.Fence",
+		},
+		{
+			name: "nil fence import info",
+			call: func() error {
+				return ImportFenceFdKHR(fakeDevice, nil)
+			},
+			errorParam: "importInfo",
+		},
+		{
+			name: "permanent sync fd fence import",
+			call: func() error {
+				return ImportFenceFdKHR(fakeDevice, &ImportFenceFdInfo{
+					Fence:      fakeFence,
+					HandleType: ExternalFenceHandleTypeSyncFdBit,
+					Fd:         -1,
+				})
+			},
+			errorParam: "importInfo.Flags",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+
+	// Without the Load functions the calls report the missing extensions
+	err := ImportSemaphoreFdKHR(fakeDevice, &ImportSemaphoreFdInfo{
+		Semaphore:  fakeSemaphore,
+		Flags:      SemaphoreImportTemporaryBit,
+		HandleType: ExternalSemaphoreHandleTypeSyncFdBit,
+		Fd:         -1,
+	})
+	if !errors.Is(err, ErrorExtensionNotPresent) {
+		t.Errorf("Expected ErrorExtensionNotPresent, got %v", err)
+	}
+	_, err = GetFenceFdKHR(fakeDevice, &FenceGetFdInfo{Fence: fakeFence, HandleType: ExternalFenceHandleTypeSyncFdBit})
+	if !errors.Is(err, ErrorExtensionNotPresent) {
+		t.Errorf("Expected ErrorExtensionNotPresent, got %v", err)
+	}
+}
@@ -0,0 +1,283 @@
+//go:build windows
+
+package vulkan
+
+/*
+#define VK_USE_PLATFORM_WIN32_KHR
+#include <vulkan/vulkan.h>
+#include <stdlib.h>
+#include <stdint.h>
+
+// Function pointers for VK_KHR_external_semaphore_win32 and VK_KHR_external_fence_win32
+// functions. These need to be loaded dynamically at runtime.
+//
+// IMPORTANT: These are global static pointers and NOT thread-safe during loading.
+// LoadExternalSemaphoreWin32Functions and LoadExternalFenceWin32Functions must be called
+// from a single thread during initialization before any concurrent external sync API usage.
+static PFN_vkGetSemaphoreWin32HandleKHR pfn_vkGetSemaphoreWin32HandleKHR = NULL;
+static PFN_vkImportSemaphoreWin32HandleKHR pfn_vkImportSemaphoreWin32HandleKHR = NULL;
+static PFN_vkGetFenceWin32HandleKHR pfn_vkGetFenceWin32HandleKHR = NULL;
+static PFN_vkImportFenceWin32HandleKHR pfn_vkImportFenceWin32HandleKHR = NULL;
+
+static int loadExternalSemaphoreWin32DeviceFunctions(VkDevice device) {
+    if (device == VK_NULL_HANDLE) {
+        return 0;
+    }
+    pfn_vkGetSemaphoreWin32HandleKHR = (PFN_vkGetSemaphoreWin32HandleKHR)
+        vkGetDeviceProcAddr(device, "vkGetSemaphoreWin32HandleKHR");
+    pfn_vkImportSemaphoreWin32HandleKHR = (PFN_vkImportSemaphoreWin32HandleKHR)
+        vkGetDeviceProcAddr(device, "vkImportSemaphoreWin32HandleKHR");
+
+    return pfn_vkGetSemaphoreWin32HandleKHR != NULL &&
+           pfn_vkImportSemaphoreWin32HandleKHR != NULL;
+}
+
+static int loadExternalFenceWin32DeviceFunctions(VkDevice device) {
+    if (device == VK_NULL_HANDLE) {
+        return 0;
+    }
+    pfn_vkGetFenceWin32HandleKHR = (PFN_vkGetFenceWin32HandleKHR)
+        vkGetDeviceProcAddr(device, "vkGetFenceWin32HandleKHR");
+    pfn_vkImportFenceWin32HandleKHR = (PFN_vkImportFenceWin32HandleKHR)
+        vkGetDeviceProcAddr(device, "vkImportFenceWin32HandleKHR");
+
+    return pfn_vkGetFenceWin32HandleKHR != NULL &&
+           pfn_vkImportFenceWin32HandleKHR != NULL;
+}
+
+// Wrappers return VK_ERROR_EXTENSION_NOT_PRESENT if the function pointer is NULL.
+// HANDLE values cross the cgo boundary as uintptr_t so Go never holds them as pointers.
+static VkResult call_vkGetSemaphoreWin32HandleKHR(VkDevice device, VkSemaphore semaphore,
+    VkExternalSemaphoreHandleTypeFlagBits handleType, uintptr_t* pHandle) {
+    if (pfn_vkGetSemaphoreWin32HandleKHR == NULL) {
+        return VK_ERROR_EXTENSION_NOT_PRESENT;
+    }
+    VkSemaphoreGetWin32HandleInfoKHR info = {
+        .sType = VK_STRUCTURE_TYPE_SEMAPHORE_GET_WIN32_HANDLE_INFO_KHR,
+        .pNext = NULL,
+        .semaphore = semaphore,
+        .handleType = handleType,
+    };
+    HANDLE handle = NULL;
+    VkResult result = pfn_vkGetSemaphoreWin32HandleKHR(device, &info, &handle);
+    *pHandle = (uintptr_t)handle;
+    return result;
+}
+
+static VkResult call_vkImportSemaphoreWin32HandleKHR(VkDevice device, VkSemaphore semaphore,
+    VkSemaphoreImportFlags flags, VkExternalSemaphoreHandleTypeFlagBits handleType, uintptr_t handle) {
+    if (pfn_vkImportSemaphoreWin32HandleKHR == NULL) {
+        return VK_ERROR_EXTENSION_NOT_PRESENT;
+    }
+    VkImportSemaphoreWin32HandleInfoKHR info = {
+        .sType = VK_STRUCTURE_TYPE_IMPORT_SEMAPHORE_WIN32_HANDLE_INFO_KHR,
+        .pNext = NULL,
+        .semaphore = semaphore,
+        .flags = flags,
+        .handleType = handleType,
+        .handle = (HANDLE)handle,
+        .name = NULL,
+    };
+    return pfn_vkImportSemaphoreWin32HandleKHR(device, &info);
+}
+
+static VkResult call_vkGetFenceWin32HandleKHR(VkDevice device, VkFence fence,
+    VkExternalFenceHandleTypeFlagBits handleType, uintptr_t* pHandle) {
+    if (pfn_vkGetFenceWin32HandleKHR == NULL) {
+        return VK_ERROR_EXTENSION_NOT_PRESENT;
+    }
+    VkFenceGetWin32HandleInfoKHR info = {
+        .sType = VK_STRUCTURE_TYPE_FENCE_GET_WIN32_HANDLE_INFO_KHR,
+        .pNext = NULL,
+        .fence = fence,
+        .handleType = handleType,
+    };
+    HANDLE handle = NULL;
+    VkResult result = pfn_vkGetFenceWin32HandleKHR(device, &info, &handle);
+    *pHandle = (uintptr_t)handle;
+    return result;
+}
+
+static VkResult call_vkImportFenceWin32HandleKHR(VkDevice device, VkFence fence,
+    VkFenceImportFlags flags, VkExternalFenceHandleTypeFlagBits handleType, uintptr_t handle) {
+    if (pfn_vkImportFenceWin32HandleKHR == NULL) {
+        return VK_ERROR_EXTENSION_NOT_PRESENT;
+    }
+    VkImportFenceWin32HandleInfoKHR info = {
+        .sType = VK_STRUCTURE_TYPE_IMPORT_FENCE_WIN32_HANDLE_INFO_KHR,
+        .pNext = NULL,
+        .fence = fence,
+        .flags = flags,
+        .handleType = handleType,
+        .handle = (HANDLE)handle,
+        .name = NULL,
+    };
+    return pfn_vkImportFenceWin32HandleKHR(device, &info);
+}
+*/
+import "C"
+
+// Extension names for sharing semaphores and fences through Windows handles
+const (
+	ExtensionNameExternalSemaphoreWin32 = "VK_KHR_external_semaphore_win32"
+	ExtensionNameExternalFenceWin32     = "VK_KHR_external_fence_win32"
+)
+
+// ImportSemaphoreWin32HandleInfo describes a Windows handle imported into a semaphore. The
+// application keeps ownership of Handle.
+type ImportSemaphoreWin32HandleInfo struct {
+	Semaphore  Semaphore
+	Flags      SemaphoreImportFlags
+	HandleType ExternalSemaphoreHandleTypeFlags
+	Handle     uintptr
+}
+
+// ImportFenceWin32HandleInfo describes a Windows handle imported into a fence. The
+// application keeps ownership of Handle.
+type ImportFenceWin32HandleInfo struct {
+	Fence      Fence
+	Flags      FenceImportFlags
+	HandleType ExternalFenceHandleTypeFlags
+	Handle     uintptr
+}
+
+// LoadExternalSemaphoreWin32Functions loads VK_KHR_external_semaphore_win32 functions for a
+// device.
+//
+// This function MUST be called after creating a logical device with the
+// VK_KHR_external_semaphore_win32 extension enabled and before sharing semaphores.
+//
+// IMPORTANT: This function is NOT thread-safe. Only one device is supported at a time;
+// calling this function again will overwrite previously loaded function pointers.
+//
+// Returns false if any external semaphore Win32 function could not be loaded.
+func LoadExternalSemaphoreWin32Functions(device Device) bool {
+	return C.loadExternalSemaphoreWin32DeviceFunctions(C.VkDevice(device)) != 0
+}
+
+// LoadExternalFenceWin32Functions loads VK_KHR_external_fence_win32 functions for a device.
+//
+// This function MUST be called after creating a logical device with the
+// VK_KHR_external_fence_win32 extension enabled and before sharing fences.
+//
+// IMPORTANT: This function is NOT thread-safe. Only one device is supported at a time;
+// calling this function again will overwrite previously loaded function pointers.
+//
+// Returns false if any external fence Win32 function could not be loaded.
+func LoadExternalFenceWin32Functions(device Device) bool {
+	return C.loadExternalFenceWin32DeviceFunctions(C.VkDevice(device)) != 0
+}
+
+// GetSemaphoreWin32HandleKHR exports a semaphore payload as a Windows handle. NT handles are
+// owned by the caller and must be closed with CloseHandle.
+// Returns an error if LoadExternalSemaphoreWin32Functions was not called.
+func GetSemaphoreWin32HandleKHR(device Device, semaphore Semaphore, handleType ExternalSemaphoreHandleTypeFlags) (uintptr, error) {
+	if device == nil {
+		return 0, NewValidationError("device", "cannot be nil")
+	}
+	if semaphore == nil {
+		return 0, NewValidationError("semaphore", "cannot be nil")
+	}
+	if err := validateSingleHandleType("handleType", uint32(handleType)); err != nil {
+		return 0, err
+	}
+
+	var handle C.uintptr_t
+	result := Result(C.call_vkGetSemaphoreWin32HandleKHR(C.VkDevice(device), C.VkSemaphore(semaphore),
+		C.VkExternalSemaphoreHandleTypeFlagBits(handleType), &handle))
+	traceResult("vkGetSemaphoreWin32HandleKHR", result)
+	if result != Success {
+		return 0, NewVulkanError(result, "GetSemaphoreWin32HandleKHR", "failed to export semaphore handle")
+	}
+	return uintptr(handle), nil
+}
+
+// ImportSemaphoreWin32HandleKHR replaces a semaphore's payload with one exported by another
+// API or process, e.g. a D3D12 fence shared with a compositor.
+// Returns an error if LoadExternalSemaphoreWin32Functions was not called.
+func ImportSemaphoreWin32HandleKHR(device Device, importInfo *ImportSemaphoreWin32HandleInfo) error {
+	if device == nil {
+		return NewValidationError("device", "cannot be nil")
+	}
+	if importInfo == nil {
+		return NewValidationError("importInfo", "cannot be nil")
+	}
+	if importInfo.Semaphore == nil {
+		return NewValidationError("importInfo.Semaphore", "cannot be nil")
+	}
+	if err := validateSingleHandleType("importInfo.HandleType", uint32(importInfo.HandleType)); err != nil {
+		return err
+	}
+	if importInfo.HandleType&(ExternalSemaphoreHandleTypeOpaqueFdBit|ExternalSemaphoreHandleTypeSyncFdBit) != 0 {
+		return NewValidationError("importInfo.HandleType", "must be a Win32 or D3D12 handle type")
+	}
+	if importInfo.Handle == 0 {
+		return NewValidationError("importInfo.Handle", "cannot be zero")
+	}
+
+	result := Result(C.call_vkImportSemaphoreWin32HandleKHR(C.VkDevice(device), C.VkSemaphore(importInfo.Semaphore),
+		C.VkSemaphoreImportFlags(importInfo.Flags), C.VkExternalSemaphoreHandleTypeFlagBits(importInfo.HandleType),
+		C.uintptr_t(importInfo.Handle)))
+	traceResult("vkImportSemaphoreWin32HandleKHR", result)
+	if result != Success {
+		return NewVulkanError(result, "ImportSemaphoreWin32HandleKHR", "failed to import semaphore handle")
+	}
+	return nil
+}
+
+// GetFenceWin32HandleKHR exports a fence payload as a Windows handle. NT handles are owned by
+// the caller and must be closed with CloseHandle.
+// Returns an error if LoadExternalFenceWin32Functions was not called.
+func GetFenceWin32HandleKHR(device Device, fence Fence, handleType ExternalFenceHandleTypeFlags) (uintptr, error) {
+	if device == nil {
+		return 0, NewValidationError("device", "cannot be nil")
+	}
+	if fence == nil {
+		return 0, NewValidationError("fence", "cannot be nil")
+	}
+	if err := validateSingleHandleType("handleType", uint32(handleType)); err != nil {
+		return 0, err
+	}
+
+	var handle C.uintptr_t
+	result := Result(C.call_vkGetFenceWin32HandleKHR(C.VkDevice(device), C.VkFence(fence),
+		C.VkExternalFenceHandleTypeFlagBits(handleType), &handle))
+	traceResult("vkGetFenceWin32HandleKHR", result)
+	if result != Success {
+		return 0, NewVulkanError(result, "GetFenceWin32HandleKHR", "failed to export fence handle")
+	}
+	return uintptr(handle), nil
+}
+
+// ImportFenceWin32HandleKHR replaces a fence's payload with one exported by another API or
+// process.
+// Returns an error if LoadExternalFenceWin32Functions was not called.
+func ImportFenceWin32HandleKHR(device Device, importInfo *ImportFenceWin32HandleInfo) error {
+	if device == nil {
+		return NewValidationError("device", "cannot be nil")
+	}
+	if importInfo == nil {
+		return NewValidationError("importInfo", "cannot be nil")
+	}
+	if importInfo.Fence == nil {
+		return NewValidationError("importInfo.Fence", "cannot be nil")
+	}
+	if err := validateSingleHandleType("importInfo.HandleType", uint32(importInfo.HandleType)); err != nil {
+		return err
+	}
+	if importInfo.HandleType&(ExternalFenceHandleTypeOpaqueFdBit|ExternalFenceHandleTypeSyncFdBit) != 0 {
+		return NewValidationError("importInfo.HandleType", "must be a Win32 handle type")
+	}
+	if importInfo.Handle == 0 {
+		return NewValidationError("importInfo.Handle", "cannot be zero")
+	}
+
+	result := Result(C.call_vkImportFenceWin32HandleKHR(C.VkDevice(device), C.VkFence(importInfo.Fence),
+		C.VkFenceImportFlags(importInfo.Flags), C.VkExternalFenceHandleTypeFlagBits(importInfo.HandleType),
+		C.uintptr_t(importInfo.Handle)))
+	traceResult("vkImportFenceWin32HandleKHR", result)
+	if result != Success {
+		return NewVulkanError(result, "ImportFenceWin32HandleKHR", "failed to import fence handle")
+	}
+	return nil
+}
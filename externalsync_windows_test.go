@@ -0,0 +1,36 @@
+//go:build windows && cgo
+
+package vulkan
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestExternalSyncWin32Validation tests input validation and the unloaded-extension error
+func TestExternalSyncWin32Validation(t *testing.T) {
+	fakeDevice := Device(uintptr(0x1234))
+	fakeSemaphore := Semaphore(uintptr(0x5678))
+	fakeFence := Fence(uintptr(0x9abc))
+
+	var validationErr *ValidationError
+	if err := ImportSemaphoreWin32HandleKHR(fakeDevice, &ImportSemaphoreWin32HandleInfo{
+		Semaphore:  fakeSemaphore,
+		HandleType: ExternalSemaphoreHandleTypeSyncFdBit,
+		Handle:     0x10,
+	}); !errors.As(err, &validationErr) || validationErr.Parameter != "importInfo.HandleType" {
+		t.Errorf("Expected ValidationError for importInfo.HandleType, got %v", err)
+	}
+	if err := ImportFenceWin32HandleKHR(fakeDevice, &ImportFenceWin32HandleInfo{
+		Fence:      fakeFence,
+		HandleType: ExternalFenceHandleTypeOpaqueWin32Bit,
+	}); !errors.As(err, &validationErr) || validationErr.Parameter != "importInfo.Handle" {
+		t.Errorf("Expected ValidationError for importInfo.Handle, got %v", err)
+	}
+
+	// Without LoadExternalFenceWin32Functions the export reports the missing extension
+	_, err := GetFenceWin32HandleKHR(fakeDevice, fakeFence, ExternalFenceHandleTypeOpaqueWin32Bit)
+	if !errors.Is(err, ErrorExtensionNotPresent) {
+		t.Errorf("Expected ErrorExtensionNotPresent, got %v", err)
+	}
+}
@@ -0,0 +1,85 @@
+package vulkan
+
+import "sync"
+
+// FencePool recycles fences for workloads that submit many short command buffers.
+// Creating and destroying a fence per submission costs a driver round trip each time;
+// a pool keeps a free list of unsignaled fences and hands them out again.
+//
+// A FencePool is safe for concurrent use.
+type FencePool struct {
+	mu     sync.Mutex
+	device Device
+	free   []Fence
+	// acquired holds fences handed out by Acquire that have not been released yet
+	acquired map[Fence]struct{}
+}
+
+// NewFencePool creates an empty fence pool for a device
+func NewFencePool(device Device) (*FencePool, error) {
+	if device == nil {
+		return nil, NewValidationError("device", "cannot be nil")
+	}
+
+	return &FencePool{
+		device:   device,
+		acquired: make(map[Fence]struct{}),
+	}, nil
+}
+
+// Acquire returns an unsignaled fence, creating one if the free list is empty
+func (p *FencePool) Acquire() (Fence, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if n := len(p.free); n > 0 {
+		fence := p.free[n-1]
+		p.free = p.free[:n-1]
+		p.acquired[fence] = struct{}{}
+		return fence, nil
+	}
+
+	fence, err := CreateFence(p.device, &FenceCreateInfo{})
+	if err != nil {
+		return nil, err
+	}
+	p.acquired[fence] = struct{}{}
+	return fence, nil
+}
+
+// Release resets a fence obtained from Acquire and returns it to the free list. The fence
+// must no longer be in use by a pending submission, typically because it has been waited on.
+// Fences that were not acquired from this pool are ignored.
+func (p *FencePool) Release(fence Fence) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.acquired[fence]; !ok {
+		return
+	}
+	delete(p.acquired, fence)
+
+	// A fence that cannot be reset is not safe to hand out again
+	if err := ResetFences(p.device, []Fence{fence}); err != nil {
+		DestroyFence(p.device, fence)
+		return
+	}
+	p.free = append(p.free, fence)
+}
+
+// Destroy destroys every fence created by the pool, including fences that were acquired
+// but not released. All submissions using them must have completed.
+func (p *FencePool) Destroy() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, fence := range p.free {
+		DestroyFence(p.device, fence)
+	}
+	p.free = nil
+
+	for fence := range p.acquired {
+		DestroyFence(p.device, fence)
+		delete(p.acquired, fence)
+	}
+}
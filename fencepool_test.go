@@ -0,0 +1,41 @@
+//go:build cgo
+
+package vulkan
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestFencePoolValidation tests that fence pools and fence resets require a device
+func TestFencePoolValidation(t *testing.T) {
+	_, err := NewFencePool(nil)
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+	}
+	if validationErr.Parameter != "device" {
+		t.Errorf("Expected error for parameter 'device', got '%s'", validationErr.Parameter)
+	}
+
+	err = WaitAndReset(Device(uintptr(0x1234)), nil)
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+	}
+	if validationErr.Parameter != "fence" {
+		t.Errorf("Expected error for parameter 'fence', got '%s'", validationErr.Parameter)
+	}
+}
+
+// TestFencePoolReleaseUnknownFence tests that fences not acquired from the pool are ignored
+func TestFencePoolReleaseUnknownFence(t *testing.T) {
+	pool, err := NewFencePool(Device(uintptr(0x1234)))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	pool.Release(Fence(uintptr(0x5678)))
+	if len(pool.free) != 0 {
+		t.Errorf("Expected empty free list after releasing a foreign fence, got %d fences", len(pool.free))
+	}
+}
@@ -0,0 +1,138 @@
+package vulkan
+
+/*
+#include <vulkan/vulkan.h>
+*/
+import "C"
+
+// Formats not covered by the constants in memory.go, mainly wider integer and float formats
+// and the block-compressed BC, ETC2/EAC and ASTC families
+const (
+	FormatA8B8G8R8UnormPack32      Format = C.VK_FORMAT_A8B8G8R8_UNORM_PACK32
+	FormatA8B8G8R8SnormPack32      Format = C.VK_FORMAT_A8B8G8R8_SNORM_PACK32
+	FormatA8B8G8R8UscaledPack32    Format = C.VK_FORMAT_A8B8G8R8_USCALED_PACK32
+	FormatA8B8G8R8SscaledPack32    Format = C.VK_FORMAT_A8B8G8R8_SSCALED_PACK32
+	FormatA8B8G8R8UintPack32       Format = C.VK_FORMAT_A8B8G8R8_UINT_PACK32
+	FormatA8B8G8R8SintPack32       Format = C.VK_FORMAT_A8B8G8R8_SINT_PACK32
+	FormatA8B8G8R8SrgbPack32       Format = C.VK_FORMAT_A8B8G8R8_SRGB_PACK32
+	FormatA2R10G10B10UnormPack32   Format = C.VK_FORMAT_A2R10G10B10_UNORM_PACK32
+	FormatA2R10G10B10SnormPack32   Format = C.VK_FORMAT_A2R10G10B10_SNORM_PACK32
+	FormatA2R10G10B10UscaledPack32 Format = C.VK_FORMAT_A2R10G10B10_USCALED_PACK32
+	FormatA2R10G10B10SscaledPack32 Format = C.VK_FORMAT_A2R10G10B10_SSCALED_PACK32
+	FormatA2R10G10B10UintPack32    Format = C.VK_FORMAT_A2R10G10B10_UINT_PACK32
+	FormatA2R10G10B10SintPack32    Format = C.VK_FORMAT_A2R10G10B10_SINT_PACK32
+	FormatA2B10G10R10UnormPack32   Format = C.VK_FORMAT_A2B10G10R10_UNORM_PACK32
+	FormatA2B10G10R10SnormPack32   Format = C.VK_FORMAT_A2B10G10R10_SNORM_PACK32
+	FormatA2B10G10R10UscaledPack32 Format = C.VK_FORMAT_A2B10G10R10_USCALED_PACK32
+	FormatA2B10G10R10SscaledPack32 Format = C.VK_FORMAT_A2B10G10R10_SSCALED_PACK32
+	FormatA2B10G10R10UintPack32    Format = C.VK_FORMAT_A2B10G10R10_UINT_PACK32
+	FormatA2B10G10R10SintPack32    Format = C.VK_FORMAT_A2B10G10R10_SINT_PACK32
+	FormatR16Unorm                 Format = C.VK_FORMAT_R16_UNORM
+	FormatR16Snorm                 Format = C.VK_FORMAT_R16_SNORM
+	FormatR16Uscaled               Format = C.VK_FORMAT_R16_USCALED
+	FormatR16Sscaled               Format = C.VK_FORMAT_R16_SSCALED
+	FormatR16Uint                  Format = C.VK_FORMAT_R16_UINT
+	FormatR16Sint                  Format = C.VK_FORMAT_R16_SINT
+	FormatR16Sfloat                Format = C.VK_FORMAT_R16_SFLOAT
+	FormatR16G16Unorm              Format = C.VK_FORMAT_R16G16_UNORM
+	FormatR16G16Snorm              Format = C.VK_FORMAT_R16G16_SNORM
+	FormatR16G16Uscaled            Format = C.VK_FORMAT_R16G16_USCALED
+	FormatR16G16Sscaled            Format = C.VK_FORMAT_R16G16_SSCALED
+	FormatR16G16Uint               Format = C.VK_FORMAT_R16G16_UINT
+	FormatR16G16Sint               Format = C.VK_FORMAT_R16G16_SINT
+	FormatR16G16Sfloat             Format = C.VK_FORMAT_R16G16_SFLOAT
+	FormatR16G16B16Unorm           Format = C.VK_FORMAT_R16G16B16_UNORM
+	FormatR16G16B16Snorm           Format = C.VK_FORMAT_R16G16B16_SNORM
+	FormatR16G16B16Uscaled         Format = C.VK_FORMAT_R16G16B16_USCALED
+	FormatR16G16B16Sscaled         Format = C.VK_FORMAT_R16G16B16_SSCALED
+	FormatR16G16B16Uint            Format = C.VK_FORMAT_R16G16B16_UINT
+	FormatR16G16B16Sint            Format = C.VK_FORMAT_R16G16B16_SINT
+	FormatR16G16B16Sfloat          Format = C.VK_FORMAT_R16G16B16_SFLOAT
+	FormatR16G16B16A16Unorm        Format = C.VK_FORMAT_R16G16B16A16_UNORM
+	FormatR16G16B16A16Snorm        Format = C.VK_FORMAT_R16G16B16A16_SNORM
+	FormatR16G16B16A16Uscaled      Format = C.VK_FORMAT_R16G16B16A16_USCALED
+	FormatR16G16B16A16Sscaled      Format = C.VK_FORMAT_R16G16B16A16_SSCALED
+	FormatR16G16B16A16Uint         Format = C.VK_FORMAT_R16G16B16A16_UINT
+	FormatR16G16B16A16Sint         Format = C.VK_FORMAT_R16G16B16A16_SINT
+	FormatR16G16B16A16Sfloat       Format = C.VK_FORMAT_R16G16B16A16_SFLOAT
+	FormatR32Uint                  Format = C.VK_FORMAT_R32_UINT
+	FormatR32Sint                  Format = C.VK_FORMAT_R32_SINT
+	FormatR32Sfloat                Format = C.VK_FORMAT_R32_SFLOAT
+	FormatR32G32Uint               Format = C.VK_FORMAT_R32G32_UINT
+	FormatR32G32Sint               Format = C.VK_FORMAT_R32G32_SINT
+	FormatR32G32Sfloat             Format = C.VK_FORMAT_R32G32_SFLOAT
+	FormatR32G32B32Uint            Format = C.VK_FORMAT_R32G32B32_UINT
+	FormatR32G32B32Sint            Format = C.VK_FORMAT_R32G32B32_SINT
+	FormatR32G32B32Sfloat          Format = C.VK_FORMAT_R32G32B32_SFLOAT
+	FormatR32G32B32A32Uint         Format = C.VK_FORMAT_R32G32B32A32_UINT
+	FormatR32G32B32A32Sint         Format = C.VK_FORMAT_R32G32B32A32_SINT
+	FormatR32G32B32A32Sfloat       Format = C.VK_FORMAT_R32G32B32A32_SFLOAT
+	FormatR64Uint                  Format = C.VK_FORMAT_R64_UINT
+	FormatR64Sint                  Format = C.VK_FORMAT_R64_SINT
+	FormatR64Sfloat                Format = C.VK_FORMAT_R64_SFLOAT
+	FormatR64G64Uint               Format = C.VK_FORMAT_R64G64_UINT
+	FormatR64G64Sint               Format = C.VK_FORMAT_R64G64_SINT
+	FormatR64G64Sfloat             Format = C.VK_FORMAT_R64G64_SFLOAT
+	FormatR64G64B64Uint            Format = C.VK_FORMAT_R64G64B64_UINT
+	FormatR64G64B64Sint            Format = C.VK_FORMAT_R64G64B64_SINT
+	FormatR64G64B64Sfloat          Format = C.VK_FORMAT_R64G64B64_SFLOAT
+	FormatR64G64B64A64Uint         Format = C.VK_FORMAT_R64G64B64A64_UINT
+	FormatR64G64B64A64Sint         Format = C.VK_FORMAT_R64G64B64A64_SINT
+	FormatR64G64B64A64Sfloat       Format = C.VK_FORMAT_R64G64B64A64_SFLOAT
+	FormatB10G11R11UfloatPack32    Format = C.VK_FORMAT_B10G11R11_UFLOAT_PACK32
+	FormatE5B9G9R9UfloatPack32     Format = C.VK_FORMAT_E5B9G9R9_UFLOAT_PACK32
+	FormatBC1RGBUnormBlock         Format = C.VK_FORMAT_BC1_RGB_UNORM_BLOCK
+	FormatBC1RGBSrgbBlock          Format = C.VK_FORMAT_BC1_RGB_SRGB_BLOCK
+	FormatBC1RGBAUnormBlock        Format = C.VK_FORMAT_BC1_RGBA_UNORM_BLOCK
+	FormatBC1RGBASrgbBlock         Format = C.VK_FORMAT_BC1_RGBA_SRGB_BLOCK
+	FormatBC2UnormBlock            Format = C.VK_FORMAT_BC2_UNORM_BLOCK
+	FormatBC2SrgbBlock             Format = C.VK_FORMAT_BC2_SRGB_BLOCK
+	FormatBC3UnormBlock            Format = C.VK_FORMAT_BC3_UNORM_BLOCK
+	FormatBC3SrgbBlock             Format = C.VK_FORMAT_BC3_SRGB_BLOCK
+	FormatBC4UnormBlock            Format = C.VK_FORMAT_BC4_UNORM_BLOCK
+	FormatBC4SnormBlock            Format = C.VK_FORMAT_BC4_SNORM_BLOCK
+	FormatBC5UnormBlock            Format = C.VK_FORMAT_BC5_UNORM_BLOCK
+	FormatBC5SnormBlock            Format = C.VK_FORMAT_BC5_SNORM_BLOCK
+	FormatBC6HUfloatBlock          Format = C.VK_FORMAT_BC6H_UFLOAT_BLOCK
+	FormatBC6HSfloatBlock          Format = C.VK_FORMAT_BC6H_SFLOAT_BLOCK
+	FormatBC7UnormBlock            Format = C.VK_FORMAT_BC7_UNORM_BLOCK
+	FormatBC7SrgbBlock             Format = C.VK_FORMAT_BC7_SRGB_BLOCK
+	FormatETC2R8G8B8UnormBlock     Format = C.VK_FORMAT_ETC2_R8G8B8_UNORM_BLOCK
+	FormatETC2R8G8B8SrgbBlock      Format = C.VK_FORMAT_ETC2_R8G8B8_SRGB_BLOCK
+	FormatETC2R8G8B8A1UnormBlock   Format = C.VK_FORMAT_ETC2_R8G8B8A1_UNORM_BLOCK
+	FormatETC2R8G8B8A1SrgbBlock    Format = C.VK_FORMAT_ETC2_R8G8B8A1_SRGB_BLOCK
+	FormatETC2R8G8B8A8UnormBlock   Format = C.VK_FORMAT_ETC2_R8G8B8A8_UNORM_BLOCK
+	FormatETC2R8G8B8A8SrgbBlock    Format = C.VK_FORMAT_ETC2_R8G8B8A8_SRGB_BLOCK
+	FormatEACR11UnormBlock         Format = C.VK_FORMAT_EAC_R11_UNORM_BLOCK
+	FormatEACR11SnormBlock         Format = C.VK_FORMAT_EAC_R11_SNORM_BLOCK
+	FormatEACR11G11UnormBlock      Format = C.VK_FORMAT_EAC_R11G11_UNORM_BLOCK
+	FormatEACR11G11SnormBlock      Format = C.VK_FORMAT_EAC_R11G11_SNORM_BLOCK
+	FormatASTC4x4UnormBlock        Format = C.VK_FORMAT_ASTC_4x4_UNORM_BLOCK
+	FormatASTC4x4SrgbBlock         Format = C.VK_FORMAT_ASTC_4x4_SRGB_BLOCK
+	FormatASTC5x4UnormBlock        Format = C.VK_FORMAT_ASTC_5x4_UNORM_BLOCK
+	FormatASTC5x4SrgbBlock         Format = C.VK_FORMAT_ASTC_5x4_SRGB_BLOCK
+	FormatASTC5x5UnormBlock        Format = C.VK_FORMAT_ASTC_5x5_UNORM_BLOCK
+	FormatASTC5x5SrgbBlock         Format = C.VK_FORMAT_ASTC_5x5_SRGB_BLOCK
+	FormatASTC6x5UnormBlock        Format = C.VK_FORMAT_ASTC_6x5_UNORM_BLOCK
+	FormatASTC6x5SrgbBlock         Format = C.VK_FORMAT_ASTC_6x5_SRGB_BLOCK
+	FormatASTC6x6UnormBlock        Format = C.VK_FORMAT_ASTC_6x6_UNORM_BLOCK
+	FormatASTC6x6SrgbBlock         Format = C.VK_FORMAT_ASTC_6x6_SRGB_BLOCK
+	FormatASTC8x5UnormBlock        Format = C.VK_FORMAT_ASTC_8x5_UNORM_BLOCK
+	FormatASTC8x5SrgbBlock         Format = C.VK_FORMAT_ASTC_8x5_SRGB_BLOCK
+	FormatASTC8x6UnormBlock        Format = C.VK_FORMAT_ASTC_8x6_UNORM_BLOCK
+	FormatASTC8x6SrgbBlock         Format = C.VK_FORMAT_ASTC_8x6_SRGB_BLOCK
+	FormatASTC8x8UnormBlock        Format = C.VK_FORMAT_ASTC_8x8_UNORM_BLOCK
+	FormatASTC8x8SrgbBlock         Format = C.VK_FORMAT_ASTC_8x8_SRGB_BLOCK
+	FormatASTC10x5UnormBlock       Format = C.VK_FORMAT_ASTC_10x5_UNORM_BLOCK
+	FormatASTC10x5SrgbBlock        Format = C.VK_FORMAT_ASTC_10x5_SRGB_BLOCK
+	FormatASTC10x6UnormBlock       Format = C.VK_FORMAT_ASTC_10x6_UNORM_BLOCK
+	FormatASTC10x6SrgbBlock        Format = C.VK_FORMAT_ASTC_10x6_SRGB_BLOCK
+	FormatASTC10x8UnormBlock       Format = C.VK_FORMAT_ASTC_10x8_UNORM_BLOCK
+	FormatASTC10x8SrgbBlock        Format = C.VK_FORMAT_ASTC_10x8_SRGB_BLOCK
+	FormatASTC10x10UnormBlock      Format = C.VK_FORMAT_ASTC_10x10_UNORM_BLOCK
+	FormatASTC10x10SrgbBlock       Format = C.VK_FORMAT_ASTC_10x10_SRGB_BLOCK
+	FormatASTC12x10UnormBlock      Format = C.VK_FORMAT_ASTC_12x10_UNORM_BLOCK
+	FormatASTC12x10SrgbBlock       Format = C.VK_FORMAT_ASTC_12x10_SRGB_BLOCK
+	FormatASTC12x12UnormBlock      Format = C.VK_FORMAT_ASTC_12x12_UNORM_BLOCK
+	FormatASTC12x12SrgbBlock       Format = C.VK_FORMAT_ASTC_12x12_SRGB_BLOCK
+)
@@ -0,0 +1,47 @@
+package vulkan
+
+import "testing"
+
+// TestFormatIntrospection tests the texel block table for uncompressed, depth-stencil and
+// compressed formats
+func TestFormatIntrospection(t *testing.T) {
+	tests := []struct {
+		name       string
+		format     Format
+		blockSize  uint32
+		extent     Extent3D
+		compressed bool
+		components uint32
+	}{
+		{"R8G8B8A8Unorm", FormatR8G8B8A8Unorm, 4, Extent3D{1, 1, 1}, false, 4},
+		{"R16G16Sfloat", FormatR16G16Sfloat, 4, Extent3D{1, 1, 1}, false, 2},
+		{"R32G32B32A32Sfloat", FormatR32G32B32A32Sfloat, 16, Extent3D{1, 1, 1}, false, 4},
+		{"R64Sfloat", FormatR64Sfloat, 8, Extent3D{1, 1, 1}, false, 1},
+		{"B10G11R11UfloatPack32", FormatB10G11R11UfloatPack32, 4, Extent3D{1, 1, 1}, false, 3},
+		{"D24UnormS8Uint", FormatD24UnormS8Uint, 4, Extent3D{1, 1, 1}, false, 2},
+		{"D32SfloatS8Uint", FormatD32SfloatS8Uint, 5, Extent3D{1, 1, 1}, false, 2},
+		{"BC1RGBUnormBlock", FormatBC1RGBUnormBlock, 8, Extent3D{4, 4, 1}, true, 3},
+		{"BC7SrgbBlock", FormatBC7SrgbBlock, 16, Extent3D{4, 4, 1}, true, 4},
+		{"EACR11G11UnormBlock", FormatEACR11G11UnormBlock, 16, Extent3D{4, 4, 1}, true, 2},
+		{"ASTC10x6UnormBlock", FormatASTC10x6UnormBlock, 16, Extent3D{10, 6, 1}, true, 4},
+		{"Undefined", FormatUndefined, 0, Extent3D{1, 1, 1}, false, 0},
+		{"multi-planar", FormatG8B8R82Plane420Unorm, 0, Extent3D{1, 1, 1}, false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatTexelBlockSize(tt.format); got != tt.blockSize {
+				t.Errorf("FormatTexelBlockSize = %d, want %d", got, tt.blockSize)
+			}
+			if got := FormatBlockExtent(tt.format); got != tt.extent {
+				t.Errorf("FormatBlockExtent = %+v, want %+v", got, tt.extent)
+			}
+			if got := FormatIsCompressed(tt.format); got != tt.compressed {
+				t.Errorf("FormatIsCompressed = %v, want %v", got, tt.compressed)
+			}
+			if got := FormatComponentCount(tt.format); got != tt.components {
+				t.Errorf("FormatComponentCount = %d, want %d", got, tt.components)
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package vulkan
+
+// formatInfo describes the texel block of a format
+type formatInfo struct {
+	// blockSize is the size of a texel block in bytes
+	blockSize uint32
+	// blockWidth and blockHeight are the texel block dimensions; 1x1 for uncompressed formats
+	blockWidth, blockHeight uint32
+	// components is the number of color, depth or stencil components
+	components uint32
+}
+
+// formatInfos holds the texel block of every single-plane core format
+var formatInfos = map[Format]formatInfo{
+	FormatR4G4UnormPack8:           {1, 1, 1, 2},
+	FormatR4G4B4A4UnormPack16:      {2, 1, 1, 4},
+	FormatB4G4R4A4UnormPack16:      {2, 1, 1, 4},
+	FormatR5G6B5UnormPack16:        {2, 1, 1, 3},
+	FormatB5G6R5UnormPack16:        {2, 1, 1, 3},
+	FormatR5G5B5A1UnormPack16:      {2, 1, 1, 4},
+	FormatB5G5R5A1UnormPack16:      {2, 1, 1, 4},
+	FormatA1R5G5B5UnormPack16:      {2, 1, 1, 4},
+	FormatR8Unorm:                  {1, 1, 1, 1},
+	FormatR8Snorm:                  {1, 1, 1, 1},
+	FormatR8Uscaled:                {1, 1, 1, 1},
+	FormatR8Sscaled:                {1, 1, 1, 1},
+	FormatR8Uint:                   {1, 1, 1, 1},
+	FormatR8Sint:                   {1, 1, 1, 1},
+	FormatR8Srgb:                   {1, 1, 1, 1},
+	FormatR8G8Unorm:                {2, 1, 1, 2},
+	FormatR8G8Snorm:                {2, 1, 1, 2},
+	FormatR8G8Uscaled:              {2, 1, 1, 2},
+	FormatR8G8Sscaled:              {2, 1, 1, 2},
+	FormatR8G8Uint:                 {2, 1, 1, 2},
+	FormatR8G8Sint:                 {2, 1, 1, 2},
+	FormatR8G8Srgb:                 {2, 1, 1, 2},
+	FormatR8G8B8Unorm:              {3, 1, 1, 3},
+	FormatR8G8B8Snorm:              {3, 1, 1, 3},
+	FormatR8G8B8Uscaled:            {3, 1, 1, 3},
+	FormatR8G8B8Sscaled:            {3, 1, 1, 3},
+	FormatR8G8B8Uint:               {3, 1, 1, 3},
+	FormatR8G8B8Sint:               {3, 1, 1, 3},
+	FormatR8G8B8Srgb:               {3, 1, 1, 3},
+	FormatB8G8R8Unorm:              {3, 1, 1, 3},
+	FormatB8G8R8Snorm:              {3, 1, 1, 3},
+	FormatB8G8R8Uscaled:            {3, 1, 1, 3},
+	FormatB8G8R8Sscaled:            {3, 1, 1, 3},
+	FormatB8G8R8Uint:               {3, 1, 1, 3},
+	FormatB8G8R8Sint:               {3, 1, 1, 3},
+	FormatB8G8R8Srgb:               {3, 1, 1, 3},
+	FormatR8G8B8A8Unorm:            {4, 1, 1, 4},
+	FormatR8G8B8A8Snorm:            {4, 1, 1, 4},
+	FormatR8G8B8A8Uscaled:          {4, 1, 1, 4},
+	FormatR8G8B8A8Sscaled:          {4, 1, 1, 4},
+	FormatR8G8B8A8Uint:             {4, 1, 1, 4},
+	FormatR8G8B8A8Sint:             {4, 1, 1, 4},
+	FormatR8G8B8A8Srgb:             {4, 1, 1, 4},
+	FormatB8G8R8A8Unorm:            {4, 1, 1, 4},
+	FormatB8G8R8A8Snorm:            {4, 1, 1, 4},
+	FormatB8G8R8A8Uscaled:          {4, 1, 1, 4},
+	FormatB8G8R8A8Sscaled:          {4, 1, 1, 4},
+	FormatB8G8R8A8Uint:             {4, 1, 1, 4},
+	FormatB8G8R8A8Sint:             {4, 1, 1, 4},
+	FormatB8G8R8A8Srgb:             {4, 1, 1, 4},
+	FormatA8B8G8R8UnormPack32:      {4, 1, 1, 4},
+	FormatA8B8G8R8SnormPack32:      {4, 1, 1, 4},
+	FormatA8B8G8R8UscaledPack32:    {4, 1, 1, 4},
+	FormatA8B8G8R8SscaledPack32:    {4, 1, 1, 4},
+	FormatA8B8G8R8UintPack32:       {4, 1, 1, 4},
+	FormatA8B8G8R8SintPack32:       {4, 1, 1, 4},
+	FormatA8B8G8R8SrgbPack32:       {4, 1, 1, 4},
+	FormatA2R10G10B10UnormPack32:   {4, 1, 1, 4},
+	FormatA2R10G10B10SnormPack32:   {4, 1, 1, 4},
+	FormatA2R10G10B10UscaledPack32: {4, 1, 1, 4},
+	FormatA2R10G10B10SscaledPack32: {4, 1, 1, 4},
+	FormatA2R10G10B10UintPack32:    {4, 1, 1, 4},
+	FormatA2R10G10B10SintPack32:    {4, 1, 1, 4},
+	FormatA2B10G10R10UnormPack32:   {4, 1, 1, 4},
+	FormatA2B10G10R10SnormPack32:   {4, 1, 1, 4},
+	FormatA2B10G10R10UscaledPack32: {4, 1, 1, 4},
+	FormatA2B10G10R10SscaledPack32: {4, 1, 1, 4},
+	FormatA2B10G10R10UintPack32:    {4, 1, 1, 4},
+	FormatA2B10G10R10SintPack32:    {4, 1, 1, 4},
+	FormatR16Unorm:                 {2, 1, 1, 1},
+	FormatR16Snorm:                 {2, 1, 1, 1},
+	FormatR16Uscaled:               {2, 1, 1, 1},
+	FormatR16Sscaled:               {2, 1, 1, 1},
+	FormatR16Uint:                  {2, 1, 1, 1},
+	FormatR16Sint:                  {2, 1, 1, 1},
+	FormatR16Sfloat:                {2, 1, 1, 1},
+	FormatR16G16Unorm:              {4, 1, 1, 2},
+	FormatR16G16Snorm:              {4, 1, 1, 2},
+	FormatR16G16Uscaled:            {4, 1, 1, 2},
+	FormatR16G16Sscaled:            {4, 1, 1, 2},
+	FormatR16G16Uint:               {4, 1, 1, 2},
+	FormatR16G16Sint:               {4, 1, 1, 2},
+	FormatR16G16Sfloat:             {4, 1, 1, 2},
+	FormatR16G16B16Unorm:           {6, 1, 1, 3},
+	FormatR16G16B16Snorm:           {6, 1, 1, 3},
+	FormatR16G16B16Uscaled:         {6, 1, 1, 3},
+	FormatR16G16B16Sscaled:         {6, 1, 1, 3},
+	FormatR16G16B16Uint:            {6, 1, 1, 3},
+	FormatR16G16B16Sint:            {6, 1, 1, 3},
+	FormatR16G16B16Sfloat:          {6, 1, 1, 3},
+	FormatR16G16B16A16Unorm:        {8, 1, 1, 4},
+	FormatR16G16B16A16Snorm:        {8, 1, 1, 4},
+	FormatR16G16B16A16Uscaled:      {8, 1, 1, 4},
+	FormatR16G16B16A16Sscaled:      {8, 1, 1, 4},
+	FormatR16G16B16A16Uint:         {8, 1, 1, 4},
+	FormatR16G16B16A16Sint:         {8, 1, 1, 4},
+	FormatR16G16B16A16Sfloat:       {8, 1, 1, 4},
+	FormatR32Uint:                  {4, 1, 1, 1},
+	FormatR32Sint:                  {4, 1, 1, 1},
+	FormatR32Sfloat:                {4, 1, 1, 1},
+	FormatR32G32Uint:               {8, 1, 1, 2},
+	FormatR32G32Sint:               {8, 1, 1, 2},
+	FormatR32G32Sfloat:             {8, 1, 1, 2},
+	FormatR32G32B32Uint:            {12, 1, 1, 3},
+	FormatR32G32B32Sint:            {12, 1, 1, 3},
+	FormatR32G32B32Sfloat:          {12, 1, 1, 3},
+	FormatR32G32B32A32Uint:         {16, 1, 1, 4},
+	FormatR32G32B32A32Sint:         {16, 1, 1, 4},
+	FormatR32G32B32A32Sfloat:       {16, 1, 1, 4},
+	FormatR64Uint:                  {8, 1, 1, 1},
+	FormatR64Sint:                  {8, 1, 1, 1},
+	FormatR64Sfloat:                {8, 1, 1, 1},
+	FormatR64G64Uint:               {16, 1, 1, 2},
+	FormatR64G64Sint:               {16, 1, 1, 2},
+	FormatR64G64Sfloat:             {16, 1, 1, 2},
+	FormatR64G64B64Uint:            {24, 1, 1, 3},
+	FormatR64G64B64Sint:            {24, 1, 1, 3},
+	FormatR64G64B64Sfloat:          {24, 1, 1, 3},
+	FormatR64G64B64A64Uint:         {32, 1, 1, 4},
+	FormatR64G64B64A64Sint:         {32, 1, 1, 4},
+	FormatR64G64B64A64Sfloat:       {32, 1, 1, 4},
+	FormatB10G11R11UfloatPack32:    {4, 1, 1, 3},
+	FormatE5B9G9R9UfloatPack32:     {4, 1, 1, 3},
+	FormatD16Unorm:                 {2, 1, 1, 1},
+	FormatX8D24UnormPack32:         {4, 1, 1, 1},
+	FormatD32Sfloat:                {4, 1, 1, 1},
+	FormatS8Uint:                   {1, 1, 1, 1},
+	FormatD16UnormS8Uint:           {3, 1, 1, 2},
+	FormatD24UnormS8Uint:           {4, 1, 1, 2},
+	FormatD32SfloatS8Uint:          {5, 1, 1, 2},
+	FormatBC1RGBUnormBlock:         {8, 4, 4, 3},
+	FormatBC1RGBSrgbBlock:          {8, 4, 4, 3},
+	FormatBC1RGBAUnormBlock:        {8, 4, 4, 4},
+	FormatBC1RGBASrgbBlock:         {8, 4, 4, 4},
+	FormatBC2UnormBlock:            {16, 4, 4, 4},
+	FormatBC2SrgbBlock:             {16, 4, 4, 4},
+	FormatBC3UnormBlock:            {16, 4, 4, 4},
+	FormatBC3SrgbBlock:             {16, 4, 4, 4},
+	FormatBC4UnormBlock:            {8, 4, 4, 1},
+	FormatBC4SnormBlock:            {8, 4, 4, 1},
+	FormatBC5UnormBlock:            {16, 4, 4, 2},
+	FormatBC5SnormBlock:            {16, 4, 4, 2},
+	FormatBC6HUfloatBlock:          {16, 4, 4, 3},
+	FormatBC6HSfloatBlock:          {16, 4, 4, 3},
+	FormatBC7UnormBlock:            {16, 4, 4, 4},
+	FormatBC7SrgbBlock:             {16, 4, 4, 4},
+	FormatETC2R8G8B8UnormBlock:     {8, 4, 4, 3},
+	FormatETC2R8G8B8SrgbBlock:      {8, 4, 4, 3},
+	FormatETC2R8G8B8A1UnormBlock:   {8, 4, 4, 4},
+	FormatETC2R8G8B8A1SrgbBlock:    {8, 4, 4, 4},
+	FormatETC2R8G8B8A8UnormBlock:   {16, 4, 4, 4},
+	FormatETC2R8G8B8A8SrgbBlock:    {16, 4, 4, 4},
+	FormatEACR11UnormBlock:         {8, 4, 4, 1},
+	FormatEACR11SnormBlock:         {8, 4, 4, 1},
+	FormatEACR11G11UnormBlock:      {16, 4, 4, 2},
+	FormatEACR11G11SnormBlock:      {16, 4, 4, 2},
+	FormatASTC4x4UnormBlock:        {16, 4, 4, 4},
+	FormatASTC4x4SrgbBlock:         {16, 4, 4, 4},
+	FormatASTC5x4UnormBlock:        {16, 5, 4, 4},
+	FormatASTC5x4SrgbBlock:         {16, 5, 4, 4},
+	FormatASTC5x5UnormBlock:        {16, 5, 5, 4},
+	FormatASTC5x5SrgbBlock:         {16, 5, 5, 4},
+	FormatASTC6x5UnormBlock:        {16, 6, 5, 4},
+	FormatASTC6x5SrgbBlock:         {16, 6, 5, 4},
+	FormatASTC6x6UnormBlock:        {16, 6, 6, 4},
+	FormatASTC6x6SrgbBlock:         {16, 6, 6, 4},
+	FormatASTC8x5UnormBlock:        {16, 8, 5, 4},
+	FormatASTC8x5SrgbBlock:         {16, 8, 5, 4},
+	FormatASTC8x6UnormBlock:        {16, 8, 6, 4},
+	FormatASTC8x6SrgbBlock:         {16, 8, 6, 4},
+	FormatASTC8x8UnormBlock:        {16, 8, 8, 4},
+	FormatASTC8x8SrgbBlock:         {16, 8, 8, 4},
+	FormatASTC10x5UnormBlock:       {16, 10, 5, 4},
+	FormatASTC10x5SrgbBlock:        {16, 10, 5, 4},
+	FormatASTC10x6UnormBlock:       {16, 10, 6, 4},
+	FormatASTC10x6SrgbBlock:        {16, 10, 6, 4},
+	FormatASTC10x8UnormBlock:       {16, 10, 8, 4},
+	FormatASTC10x8SrgbBlock:        {16, 10, 8, 4},
+	FormatASTC10x10UnormBlock:      {16, 10, 10, 4},
+	FormatASTC10x10SrgbBlock:       {16, 10, 10, 4},
+	FormatASTC12x10UnormBlock:      {16, 12, 10, 4},
+	FormatASTC12x10SrgbBlock:       {16, 12, 10, 4},
+	FormatASTC12x12UnormBlock:      {16, 12, 12, 4},
+	FormatASTC12x12SrgbBlock:       {16, 12, 12, 4},
+}
+
+// FormatTexelBlockSize returns the size in bytes of one texel block of format: one texel for
+// uncompressed formats and one FormatBlockExtent block for compressed formats. Combined
+// depth-stencil formats report the size of the packed texel, but buffer copies transfer one
+// aspect at a time, with D24 depth copied as 4 bytes and stencil as 1 byte.
+// It returns 0 for FormatUndefined, multi-planar and unknown formats.
+func FormatTexelBlockSize(format Format) uint32 {
+	return formatInfos[format].blockSize
+}
+
+// FormatBlockExtent returns the texel dimensions of one block of format, e.g. 4x4x1 for BC7
+// or 8x6x1 for FormatASTC8x6UnormBlock. It returns 1x1x1 for uncompressed and unknown formats.
+func FormatBlockExtent(format Format) Extent3D {
+	info, ok := formatInfos[format]
+	if !ok {
+		return Extent3D{Width: 1, Height: 1, Depth: 1}
+	}
+	return Extent3D{Width: info.blockWidth, Height: info.blockHeight, Depth: 1}
+}
+
+// FormatIsCompressed reports whether format is a block-compressed format
+func FormatIsCompressed(format Format) bool {
+	info := formatInfos[format]
+	return info.blockWidth > 1 || info.blockHeight > 1
+}
+
+// FormatComponentCount returns the number of components of format, e.g. 4 for RGBA formats
+// and 2 for combined depth-stencil formats. It returns 0 for unknown formats.
+func FormatComponentCount(format Format) uint32 {
+	return formatInfos[format].components
+}
@@ -0,0 +1,293 @@
+package vulkan
+
+/*
+#include <vulkan/vulkan.h>
+#include <stdlib.h>
+
+// Function pointers for VK_KHR_fragment_shading_rate functions
+// These need to be loaded dynamically at runtime.
+//
+// IMPORTANT: These are global static pointers shared by every instance and device.
+// LoadFragmentShadingRateInstanceFunctions/LoadFragmentShadingRateDeviceFunctions serialize
+// loading on the Go side, but must not replace the pointers while other goroutines use the
+// fragment shading rate API.
+static PFN_vkGetPhysicalDeviceFragmentShadingRatesKHR pfn_vkGetPhysicalDeviceFragmentShadingRatesKHR = NULL;
+static PFN_vkCmdSetFragmentShadingRateKHR pfn_vkCmdSetFragmentShadingRateKHR = NULL;
+
+static int loadFragmentShadingRateInstanceFunctions(VkInstance instance) {
+    if (instance == VK_NULL_HANDLE) {
+        return 0;
+    }
+    pfn_vkGetPhysicalDeviceFragmentShadingRatesKHR = (PFN_vkGetPhysicalDeviceFragmentShadingRatesKHR)
+        vkGetInstanceProcAddr(instance, "vkGetPhysicalDeviceFragmentShadingRatesKHR");
+    return pfn_vkGetPhysicalDeviceFragmentShadingRatesKHR != NULL;
+}
+
+static int loadFragmentShadingRateDeviceFunctions(VkDevice device) {
+    if (device == VK_NULL_HANDLE) {
+        return 0;
+    }
+    pfn_vkCmdSetFragmentShadingRateKHR = (PFN_vkCmdSetFragmentShadingRateKHR)
+        vkGetDeviceProcAddr(device, "vkCmdSetFragmentShadingRateKHR");
+    return pfn_vkCmdSetFragmentShadingRateKHR != NULL;
+}
+
+static VkResult call_vkGetPhysicalDeviceFragmentShadingRatesKHR(
+    VkPhysicalDevice physicalDevice,
+    uint32_t* pFragmentShadingRateCount,
+    VkPhysicalDeviceFragmentShadingRateKHR* pFragmentShadingRates) {
+    if (pfn_vkGetPhysicalDeviceFragmentShadingRatesKHR == NULL) {
+        return VK_ERROR_EXTENSION_NOT_PRESENT;
+    }
+    return pfn_vkGetPhysicalDeviceFragmentShadingRatesKHR(physicalDevice, pFragmentShadingRateCount, pFragmentShadingRates);
+}
+
+// Command buffer wrapper functions return 1 on success, 0 if function pointer is NULL.
+static int call_vkCmdSetFragmentShadingRateKHR(
+    VkCommandBuffer commandBuffer,
+    const VkExtent2D* pFragmentSize,
+    const VkFragmentShadingRateCombinerOpKHR combinerOps[2]) {
+    if (pfn_vkCmdSetFragmentShadingRateKHR == NULL) {
+        return 0;
+    }
+    pfn_vkCmdSetFragmentShadingRateKHR(commandBuffer, pFragmentSize, combinerOps);
+    return 1;
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"sync"
+	"unsafe"
+)
+
+// ExtensionNameFragmentShadingRate is the fragment shading rate extension name
+const ExtensionNameFragmentShadingRate = "VK_KHR_fragment_shading_rate"
+
+// FragmentShadingRateCombinerOp selects how two shading rates are combined. The first
+// combiner merges the pipeline rate with the primitive rate, the second merges that result
+// with the attachment rate.
+type FragmentShadingRateCombinerOp int32
+
+const (
+	FragmentShadingRateCombinerOpKeep    FragmentShadingRateCombinerOp = C.VK_FRAGMENT_SHADING_RATE_COMBINER_OP_KEEP_KHR
+	FragmentShadingRateCombinerOpReplace FragmentShadingRateCombinerOp = C.VK_FRAGMENT_SHADING_RATE_COMBINER_OP_REPLACE_KHR
+	FragmentShadingRateCombinerOpMin     FragmentShadingRateCombinerOp = C.VK_FRAGMENT_SHADING_RATE_COMBINER_OP_MIN_KHR
+	FragmentShadingRateCombinerOpMax     FragmentShadingRateCombinerOp = C.VK_FRAGMENT_SHADING_RATE_COMBINER_OP_MAX_KHR
+	FragmentShadingRateCombinerOpMul     FragmentShadingRateCombinerOp = C.VK_FRAGMENT_SHADING_RATE_COMBINER_OP_MUL_KHR
+)
+
+// PhysicalDeviceFragmentShadingRate is a fragment size supported for the given sample counts
+type PhysicalDeviceFragmentShadingRate struct {
+	SampleCounts SampleCountFlags
+	FragmentSize Extent2D
+}
+
+// PhysicalDeviceFragmentShadingRateFeatures contains the fragment shading rate features
+type PhysicalDeviceFragmentShadingRateFeatures struct {
+	// PipelineFragmentShadingRate allows CmdSetFragmentShadingRateKHR to set a per-draw rate
+	PipelineFragmentShadingRate   bool
+	PrimitiveFragmentShadingRate  bool
+	AttachmentFragmentShadingRate bool
+}
+
+// fragmentShadingRateLoadState serializes loading of the global fragment shading rate
+// function pointers and caches the result per handle
+var fragmentShadingRateLoadState struct {
+	mu             sync.Mutex
+	instance       Instance
+	instanceLoaded bool
+	device         Device
+	deviceLoaded   bool
+}
+
+// loadFragmentShadingRateInstance and loadFragmentShadingRateDevice call into the C loaders.
+// They are variables so tests can count the loads.
+var (
+	loadFragmentShadingRateInstance = func(instance Instance) bool {
+		return C.loadFragmentShadingRateInstanceFunctions(C.VkInstance(instance)) != 0
+	}
+	loadFragmentShadingRateDevice = func(device Device) bool {
+		return C.loadFragmentShadingRateDeviceFunctions(C.VkDevice(device)) != 0
+	}
+)
+
+// LoadFragmentShadingRateInstanceFunctions loads fragment shading rate functions that require
+// a Vulkan instance.
+//
+// This function MUST be called after creating a Vulkan instance and before calling
+// GetPhysicalDeviceFragmentShadingRatesKHR.
+//
+// Loading is safe to call from multiple goroutines: calls are serialized and a repeated call
+// for the already loaded instance returns the cached result. Only one instance is supported at
+// a time; loading a different instance replaces the function pointers.
+//
+// Returns false if the fragment shading rate functions could not be loaded.
+func LoadFragmentShadingRateInstanceFunctions(instance Instance) bool {
+	fragmentShadingRateLoadState.mu.Lock()
+	defer fragmentShadingRateLoadState.mu.Unlock()
+
+	if instance != nil && instance == fragmentShadingRateLoadState.instance {
+		return fragmentShadingRateLoadState.instanceLoaded
+	}
+	fragmentShadingRateLoadState.instance = instance
+	fragmentShadingRateLoadState.instanceLoaded = loadFragmentShadingRateInstance(instance)
+	return fragmentShadingRateLoadState.instanceLoaded
+}
+
+// LoadFragmentShadingRateDeviceFunctions loads fragment shading rate functions that require a
+// Vulkan device.
+//
+// This function MUST be called after creating a logical device with the
+// VK_KHR_fragment_shading_rate extension enabled and before recording
+// CmdSetFragmentShadingRateKHR.
+//
+// Loading is safe to call from multiple goroutines: calls are serialized and a repeated call
+// for the already loaded device returns the cached result. Only one device is supported at a
+// time; loading a different device replaces the function pointers.
+//
+// Returns false if the fragment shading rate functions could not be loaded.
+func LoadFragmentShadingRateDeviceFunctions(device Device) bool {
+	fragmentShadingRateLoadState.mu.Lock()
+	defer fragmentShadingRateLoadState.mu.Unlock()
+
+	if device != nil && device == fragmentShadingRateLoadState.device {
+		return fragmentShadingRateLoadState.deviceLoaded
+	}
+	fragmentShadingRateLoadState.device = device
+	fragmentShadingRateLoadState.deviceLoaded = loadFragmentShadingRateDevice(device)
+	return fragmentShadingRateLoadState.deviceLoaded
+}
+
+// fragmentShadingRateFeaturesToC prepends a struct enabling the fragment shading rate features
+// to the pNext chain next. The struct is allocated in C memory and appended to allocations,
+// which the caller must free.
+func fragmentShadingRateFeaturesToC(features *PhysicalDeviceFragmentShadingRateFeatures, next unsafe.Pointer, allocations *[]unsafe.Pointer) (unsafe.Pointer, error) {
+	cShadingRate := (*C.VkPhysicalDeviceFragmentShadingRateFeaturesKHR)(C.calloc(1, C.sizeof_VkPhysicalDeviceFragmentShadingRateFeaturesKHR))
+	if cShadingRate == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "CreateDevice", "failed to allocate memory for fragment shading rate features")
+	}
+	*allocations = append(*allocations, unsafe.Pointer(cShadingRate))
+
+	cShadingRate.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_FRAGMENT_SHADING_RATE_FEATURES_KHR
+	cShadingRate.pNext = next
+	cShadingRate.pipelineFragmentShadingRate = boolToVkBool32(features.PipelineFragmentShadingRate)
+	cShadingRate.primitiveFragmentShadingRate = boolToVkBool32(features.PrimitiveFragmentShadingRate)
+	cShadingRate.attachmentFragmentShadingRate = boolToVkBool32(features.AttachmentFragmentShadingRate)
+
+	return unsafe.Pointer(cShadingRate), nil
+}
+
+// GetPhysicalDeviceFragmentShadingRateFeaturesKHR queries fragment shading rate support
+func GetPhysicalDeviceFragmentShadingRateFeaturesKHR(physicalDevice PhysicalDevice) PhysicalDeviceFragmentShadingRateFeatures {
+	cFeatures2 := (*C.VkPhysicalDeviceFeatures2)(C.calloc(1, C.sizeof_VkPhysicalDeviceFeatures2))
+	cShadingRate := (*C.VkPhysicalDeviceFragmentShadingRateFeaturesKHR)(C.calloc(1, C.sizeof_VkPhysicalDeviceFragmentShadingRateFeaturesKHR))
+	defer C.free(unsafe.Pointer(cFeatures2))
+	defer C.free(unsafe.Pointer(cShadingRate))
+	if cFeatures2 == nil || cShadingRate == nil {
+		return PhysicalDeviceFragmentShadingRateFeatures{}
+	}
+
+	cFeatures2.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_FEATURES_2
+	cFeatures2.pNext = unsafe.Pointer(cShadingRate)
+	cShadingRate.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_FRAGMENT_SHADING_RATE_FEATURES_KHR
+
+	C.vkGetPhysicalDeviceFeatures2(C.VkPhysicalDevice(physicalDevice), cFeatures2)
+
+	return PhysicalDeviceFragmentShadingRateFeatures{
+		PipelineFragmentShadingRate:   vkBool32ToBool(cShadingRate.pipelineFragmentShadingRate),
+		PrimitiveFragmentShadingRate:  vkBool32ToBool(cShadingRate.primitiveFragmentShadingRate),
+		AttachmentFragmentShadingRate: vkBool32ToBool(cShadingRate.attachmentFragmentShadingRate),
+	}
+}
+
+// GetPhysicalDeviceFragmentShadingRatesKHR returns the fragment sizes the physical device
+// supports, ordered from largest to smallest fragment area. The 1x1 rate is always present.
+// Returns an error if LoadFragmentShadingRateInstanceFunctions was not called.
+func GetPhysicalDeviceFragmentShadingRatesKHR(physicalDevice PhysicalDevice) ([]PhysicalDeviceFragmentShadingRate, error) {
+	if physicalDevice == nil {
+		return nil, NewValidationError("physicalDevice", "cannot be nil")
+	}
+
+	var count C.uint32_t
+	result := Result(C.call_vkGetPhysicalDeviceFragmentShadingRatesKHR(C.VkPhysicalDevice(physicalDevice), &count, nil))
+	traceResult("vkGetPhysicalDeviceFragmentShadingRatesKHR", result)
+	if result != Success {
+		return nil, NewVulkanError(result, "GetPhysicalDeviceFragmentShadingRatesKHR", "failed to get fragment shading rate count")
+	}
+	if count == 0 {
+		return nil, nil
+	}
+
+	cRates := (*C.VkPhysicalDeviceFragmentShadingRateKHR)(C.calloc(C.size_t(count), C.sizeof_VkPhysicalDeviceFragmentShadingRateKHR))
+	if cRates == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "GetPhysicalDeviceFragmentShadingRatesKHR", "failed to allocate memory for fragment shading rates")
+	}
+	defer C.free(unsafe.Pointer(cRates))
+	cRatesSlice := unsafe.Slice(cRates, count)
+	for i := range cRatesSlice {
+		cRatesSlice[i].sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_FRAGMENT_SHADING_RATE_KHR
+	}
+
+	result = Result(C.call_vkGetPhysicalDeviceFragmentShadingRatesKHR(C.VkPhysicalDevice(physicalDevice), &count, cRates))
+	traceResult("vkGetPhysicalDeviceFragmentShadingRatesKHR", result)
+	if result != Success && result != Incomplete {
+		return nil, NewVulkanError(result, "GetPhysicalDeviceFragmentShadingRatesKHR", "failed to get fragment shading rates")
+	}
+
+	rates := make([]PhysicalDeviceFragmentShadingRate, count)
+	for i := range rates {
+		rates[i] = PhysicalDeviceFragmentShadingRate{
+			SampleCounts: SampleCountFlags(cRatesSlice[i].sampleCounts),
+			FragmentSize: Extent2D{
+				Width:  uint32(cRatesSlice[i].fragmentSize.width),
+				Height: uint32(cRatesSlice[i].fragmentSize.height),
+			},
+		}
+	}
+	return rates, nil
+}
+
+// validateFragmentShadingRate checks that the fragment size is one Vulkan can express and
+// that both combiner operations are known
+func validateFragmentShadingRate(fragmentSize Extent2D, combinerOps [2]FragmentShadingRateCombinerOp) error {
+	validDimension := func(d uint32) bool { return d == 1 || d == 2 || d == 4 }
+	if !validDimension(fragmentSize.Width) || !validDimension(fragmentSize.Height) {
+		return NewValidationError("fragmentSize", fmt.Sprintf("width and height must each be 1, 2 or 4, got %dx%d", fragmentSize.Width, fragmentSize.Height))
+	}
+	for i, op := range combinerOps {
+		if op < FragmentShadingRateCombinerOpKeep || op > FragmentShadingRateCombinerOpMul {
+			return NewValidationError(fmt.Sprintf("combinerOps[%d]", i), fmt.Sprintf("unknown combiner operation %d", op))
+		}
+	}
+	return nil
+}
+
+// CmdSetFragmentShadingRateKHR sets the pipeline fragment shading rate for subsequent draws,
+// so a renderer can shade fewer fragments under load. Use FragmentShadingRateCombinerOpKeep
+// for both combiners to apply fragmentSize as-is. Rates outside the list returned by
+// GetPhysicalDeviceFragmentShadingRatesKHR are clamped by the implementation.
+// Returns an error if LoadFragmentShadingRateDeviceFunctions was not called.
+func CmdSetFragmentShadingRateKHR(commandBuffer CommandBuffer, fragmentSize Extent2D, combinerOps [2]FragmentShadingRateCombinerOp) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+	if err := validateFragmentShadingRate(fragmentSize, combinerOps); err != nil {
+		return err
+	}
+
+	cFragmentSize := C.VkExtent2D{
+		width:  C.uint32_t(fragmentSize.Width),
+		height: C.uint32_t(fragmentSize.Height),
+	}
+	cCombinerOps := [2]C.VkFragmentShadingRateCombinerOpKHR{
+		C.VkFragmentShadingRateCombinerOpKHR(combinerOps[0]),
+		C.VkFragmentShadingRateCombinerOpKHR(combinerOps[1]),
+	}
+
+	if C.call_vkCmdSetFragmentShadingRateKHR(C.VkCommandBuffer(commandBuffer), &cFragmentSize, &cCombinerOps[0]) == 0 {
+		return NewVulkanError(ErrorExtensionNotPresent, "CmdSetFragmentShadingRateKHR", "fragment shading rate extension not loaded - call LoadFragmentShadingRateDeviceFunctions first")
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+//go:build cgo
+
+package vulkan
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// TestFragmentShadingRateValidation tests fragment size and combiner validation and the
+// unloaded-extension errors
+func TestFragmentShadingRateValidation(t *testing.T) {
+	fakeCommandBuffer := CommandBuffer(uintptr(0x1234))
+	keep := [2]FragmentShadingRateCombinerOp{FragmentShadingRateCombinerOpKeep, FragmentShadingRateCombinerOpKeep}
+
+	tests := []struct {
+		name         string
+		fragmentSize Extent2D
+		combinerOps  [2]FragmentShadingRateCombinerOp
+		errorParam   string
+	}{
+		{
+			name:         "zero fragment size",
+			fragmentSize: Extent2D{},
+			combinerOps:  keep,
+			errorParam:   "fragmentSize",
+		},
+		{
+			name:         "non power of two width",
+			fragmentSize: Extent2D{Width: 3, Height: 1},
+			combinerOps:  keep,
+			errorParam:   "fragmentSize",
+		},
+		{
+			name:         "oversized height",
+			fragmentSize: Extent2D{Width: 2, Height: 8},
+			combinerOps:  keep,
+			errorParam:   "fragmentSize",
+		},
+		{
+			name:         "unknown combiner",
+			fragmentSize: Extent2D{Width: 2, Height: 2},
+			combinerOps:  [2]FragmentShadingRateCombinerOp{FragmentShadingRateCombinerOpKeep, FragmentShadingRateCombinerOp(99)},
+			errorParam:   "combinerOps[1]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CmdSetFragmentShadingRateKHR(fakeCommandBuffer, tt.fragmentSize, tt.combinerOps)
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+
+	var validationErr *ValidationError
+	if _, err := GetPhysicalDeviceFragmentShadingRatesKHR(nil); !errors.As(err, &validationErr) || validationErr.Parameter != "physicalDevice" {
+		t.Errorf("Expected ValidationError for physicalDevice, got %v", err)
+	}
+
+	// Without loading, both functions report the missing extension
+	var vulkanErr *VulkanError
+	err := CmdSetFragmentShadingRateKHR(fakeCommandBuffer, Extent2D{Width: 2, Height: 2}, keep)
+	if !errors.As(err, &vulkanErr) || vulkanErr.Result != ErrorExtensionNotPresent {
+		t.Errorf("Expected ErrorExtensionNotPresent from CmdSetFragmentShadingRateKHR, got %v", err)
+	}
+	_, err = GetPhysicalDeviceFragmentShadingRatesKHR(PhysicalDevice(uintptr(0x5678)))
+	if !errors.As(err, &vulkanErr) || vulkanErr.Result != ErrorExtensionNotPresent {
+		t.Errorf("Expected ErrorExtensionNotPresent from GetPhysicalDeviceFragmentShadingRatesKHR, got %v", err)
+	}
+}
+
+// TestLoadFragmentShadingRateFunctionsConcurrentDevice tests that concurrent loads for the same
+// instance and device resolve the function pointers exactly once
+func TestLoadFragmentShadingRateFunctionsConcurrentDevice(t *testing.T) {
+	instance, device := createTestDevice(t)
+	t.Cleanup(func() {
+		LoadFragmentShadingRateDeviceFunctions(nil)
+		LoadFragmentShadingRateInstanceFunctions(nil)
+	})
+
+	var instanceLoads, deviceLoads atomic.Int32
+	origInstance, origDevice := loadFragmentShadingRateInstance, loadFragmentShadingRateDevice
+	loadFragmentShadingRateInstance = func(instance Instance) bool {
+		instanceLoads.Add(1)
+		return origInstance(instance)
+	}
+	loadFragmentShadingRateDevice = func(device Device) bool {
+		deviceLoads.Add(1)
+		return origDevice(device)
+	}
+	t.Cleanup(func() { loadFragmentShadingRateInstance, loadFragmentShadingRateDevice = origInstance, origDevice })
+
+	const goroutines = 16
+	deviceResults := make([]bool, goroutines)
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			LoadFragmentShadingRateInstanceFunctions(instance)
+			deviceResults[i] = LoadFragmentShadingRateDeviceFunctions(device)
+		}(i)
+	}
+	wg.Wait()
+
+	if loads := instanceLoads.Load(); loads != 1 {
+		t.Errorf("Expected instance functions to be resolved once, got %d", loads)
+	}
+	if loads := deviceLoads.Load(); loads != 1 {
+		t.Errorf("Expected device functions to be resolved once, got %d", loads)
+	}
+	for i := 1; i < goroutines; i++ {
+		if deviceResults[i] != deviceResults[0] {
+			t.Fatalf("Expected every goroutine to get the cached result, goroutine %d got %v, goroutine 0 got %v", i, deviceResults[i], deviceResults[0])
+		}
+	}
+}
@@ -0,0 +1,16 @@
+//go:build cgo && !windows
+
+package vulkan
+
+import "unsafe"
+
+// fullScreenExclusiveSurfaceInfoToC rejects full-screen exclusive queries, which only exist
+// on Windows
+func fullScreenExclusiveSurfaceInfoToC(operation string, info *SurfaceFullScreenExclusiveInfo, next unsafe.Pointer, allocations *[]unsafe.Pointer) (unsafe.Pointer, unsafe.Pointer, error) {
+	return nil, nil, NewValidationError("FullScreenExclusive", "is only supported on Windows")
+}
+
+// fullScreenExclusiveSupportedFromC is never reached since no capabilities struct is chained
+func fullScreenExclusiveSupportedFromC(caps unsafe.Pointer) bool {
+	return false
+}
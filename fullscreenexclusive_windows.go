@@ -0,0 +1,173 @@
+//go:build windows
+
+package vulkan
+
+/*
+#define VK_USE_PLATFORM_WIN32_KHR
+#include <vulkan/vulkan.h>
+#include <stdlib.h>
+#include <stdint.h>
+
+// Function pointers for VK_EXT_full_screen_exclusive functions
+// These need to be loaded dynamically at runtime.
+//
+// IMPORTANT: These are global static pointers and NOT thread-safe during loading.
+// LoadFullScreenExclusiveFunctions must be called from a single thread during
+// initialization before any concurrent full-screen exclusive API usage.
+static PFN_vkAcquireFullScreenExclusiveModeEXT pfn_vkAcquireFullScreenExclusiveModeEXT = NULL;
+static PFN_vkReleaseFullScreenExclusiveModeEXT pfn_vkReleaseFullScreenExclusiveModeEXT = NULL;
+
+static int loadFullScreenExclusiveDeviceFunctions(VkDevice device) {
+    if (device == VK_NULL_HANDLE) {
+        return 0;
+    }
+    pfn_vkAcquireFullScreenExclusiveModeEXT = (PFN_vkAcquireFullScreenExclusiveModeEXT)
+        vkGetDeviceProcAddr(device, "vkAcquireFullScreenExclusiveModeEXT");
+    pfn_vkReleaseFullScreenExclusiveModeEXT = (PFN_vkReleaseFullScreenExclusiveModeEXT)
+        vkGetDeviceProcAddr(device, "vkReleaseFullScreenExclusiveModeEXT");
+
+    return pfn_vkAcquireFullScreenExclusiveModeEXT != NULL &&
+           pfn_vkReleaseFullScreenExclusiveModeEXT != NULL;
+}
+
+// Wrappers return VK_ERROR_EXTENSION_NOT_PRESENT if the function pointer is NULL.
+static VkResult call_vkAcquireFullScreenExclusiveModeEXT(VkDevice device, VkSwapchainKHR swapchain) {
+    if (pfn_vkAcquireFullScreenExclusiveModeEXT == NULL) {
+        return VK_ERROR_EXTENSION_NOT_PRESENT;
+    }
+    return pfn_vkAcquireFullScreenExclusiveModeEXT(device, swapchain);
+}
+
+static VkResult call_vkReleaseFullScreenExclusiveModeEXT(VkDevice device, VkSwapchainKHR swapchain) {
+    if (pfn_vkReleaseFullScreenExclusiveModeEXT == NULL) {
+        return VK_ERROR_EXTENSION_NOT_PRESENT;
+    }
+    return pfn_vkReleaseFullScreenExclusiveModeEXT(device, swapchain);
+}
+
+// HMONITOR values cross the cgo boundary as uintptr_t so Go never holds them as pointers.
+static void setFullScreenExclusiveMonitor(VkSurfaceFullScreenExclusiveWin32InfoEXT* info, uintptr_t monitor) {
+    info->hmonitor = (HMONITOR)monitor;
+}
+*/
+import "C"
+
+import "unsafe"
+
+// ExtensionNameFullScreenExclusive is the full-screen exclusive device extension name
+const ExtensionNameFullScreenExclusive = "VK_EXT_full_screen_exclusive"
+
+const (
+	// FullScreenExclusiveDefault lets the driver decide
+	FullScreenExclusiveDefault FullScreenExclusiveEXT = C.VK_FULL_SCREEN_EXCLUSIVE_DEFAULT_EXT
+	// FullScreenExclusiveAllowed lets the driver use exclusive mode when it sees fit
+	FullScreenExclusiveAllowed FullScreenExclusiveEXT = C.VK_FULL_SCREEN_EXCLUSIVE_ALLOWED_EXT
+	// FullScreenExclusiveDisallowed never uses exclusive mode
+	FullScreenExclusiveDisallowed FullScreenExclusiveEXT = C.VK_FULL_SCREEN_EXCLUSIVE_DISALLOWED_EXT
+	// FullScreenExclusiveApplicationControlled enters and leaves exclusive mode only through
+	// AcquireFullScreenExclusiveModeEXT and ReleaseFullScreenExclusiveModeEXT
+	FullScreenExclusiveApplicationControlled FullScreenExclusiveEXT = C.VK_FULL_SCREEN_EXCLUSIVE_APPLICATION_CONTROLLED_EXT
+)
+
+// LoadFullScreenExclusiveFunctions loads full-screen exclusive extension functions for a device.
+//
+// This function MUST be called after creating a logical device with the
+// VK_EXT_full_screen_exclusive extension enabled and before acquiring exclusive mode.
+//
+// IMPORTANT: This function is NOT thread-safe. Only one device is supported at a time;
+// calling this function again will overwrite previously loaded function pointers.
+//
+// Returns false if any full-screen exclusive function could not be loaded.
+func LoadFullScreenExclusiveFunctions(device Device) bool {
+	return C.loadFullScreenExclusiveDeviceFunctions(C.VkDevice(device)) != 0
+}
+
+// AcquireFullScreenExclusiveModeEXT puts an application-controlled swapchain into exclusive
+// full-screen mode. When exclusive mode cannot be acquired or is lost, the returned
+// VulkanError wraps ErrorFullScreenExclusiveModeLostEXT, so callers can detect it with
+// errors.Is(err, ErrorFullScreenExclusiveModeLostEXT) and fall back to windowed presentation.
+// Returns an error if LoadFullScreenExclusiveFunctions was not called.
+func AcquireFullScreenExclusiveModeEXT(device Device, swapchain Swapchain) error {
+	if device == nil {
+		return NewValidationError("device", "cannot be nil")
+	}
+	if swapchain == nil {
+		return NewValidationError("swapchain", "cannot be nil")
+	}
+
+	result := Result(C.call_vkAcquireFullScreenExclusiveModeEXT(C.VkDevice(device), C.VkSwapchainKHR(swapchain)))
+	traceResult("vkAcquireFullScreenExclusiveModeEXT", result)
+	switch result {
+	case Success:
+		return nil
+	case ErrorFullScreenExclusiveModeLostEXT:
+		return NewVulkanError(result, "AcquireFullScreenExclusiveModeEXT", "exclusive full-screen mode is not available for this swapchain")
+	default:
+		return NewVulkanError(result, "AcquireFullScreenExclusiveModeEXT", "failed to acquire exclusive full-screen mode")
+	}
+}
+
+// ReleaseFullScreenExclusiveModeEXT leaves exclusive full-screen mode acquired with
+// AcquireFullScreenExclusiveModeEXT.
+// Returns an error if LoadFullScreenExclusiveFunctions was not called.
+func ReleaseFullScreenExclusiveModeEXT(device Device, swapchain Swapchain) error {
+	if device == nil {
+		return NewValidationError("device", "cannot be nil")
+	}
+	if swapchain == nil {
+		return NewValidationError("swapchain", "cannot be nil")
+	}
+
+	result := Result(C.call_vkReleaseFullScreenExclusiveModeEXT(C.VkDevice(device), C.VkSwapchainKHR(swapchain)))
+	traceResult("vkReleaseFullScreenExclusiveModeEXT", result)
+	if result != Success {
+		return NewVulkanError(result, "ReleaseFullScreenExclusiveModeEXT", "failed to release exclusive full-screen mode")
+	}
+	return nil
+}
+
+// fullScreenExclusiveSurfaceInfoToC prepends the full-screen exclusive structs of info to the
+// surface info pNext chain next. It also returns a VkSurfaceCapabilitiesFullScreenExclusiveEXT
+// to chain to the capabilities output. Everything is allocated in C memory and appended to
+// allocations, which the caller must free.
+func fullScreenExclusiveSurfaceInfoToC(operation string, info *SurfaceFullScreenExclusiveInfo, next unsafe.Pointer, allocations *[]unsafe.Pointer) (unsafe.Pointer, unsafe.Pointer, error) {
+	if info.FullScreenExclusive == FullScreenExclusiveApplicationControlled && info.Monitor == 0 {
+		return nil, nil, NewValidationError("FullScreenExclusive.Monitor", "is required for FullScreenExclusiveApplicationControlled")
+	}
+
+	if info.Monitor != 0 {
+		cWin32Info := (*C.VkSurfaceFullScreenExclusiveWin32InfoEXT)(C.calloc(1, C.sizeof_VkSurfaceFullScreenExclusiveWin32InfoEXT))
+		if cWin32Info == nil {
+			return nil, nil, NewVulkanError(ErrorOutOfHostMemory, operation, "failed to allocate memory for full-screen exclusive monitor info")
+		}
+		*allocations = append(*allocations, unsafe.Pointer(cWin32Info))
+		cWin32Info.sType = C.VK_STRUCTURE_TYPE_SURFACE_FULL_SCREEN_EXCLUSIVE_WIN32_INFO_EXT
+		cWin32Info.pNext = next
+		C.setFullScreenExclusiveMonitor(cWin32Info, C.uintptr_t(info.Monitor))
+		next = unsafe.Pointer(cWin32Info)
+	}
+
+	cInfo := (*C.VkSurfaceFullScreenExclusiveInfoEXT)(C.calloc(1, C.sizeof_VkSurfaceFullScreenExclusiveInfoEXT))
+	if cInfo == nil {
+		return nil, nil, NewVulkanError(ErrorOutOfHostMemory, operation, "failed to allocate memory for full-screen exclusive info")
+	}
+	*allocations = append(*allocations, unsafe.Pointer(cInfo))
+	cInfo.sType = C.VK_STRUCTURE_TYPE_SURFACE_FULL_SCREEN_EXCLUSIVE_INFO_EXT
+	cInfo.pNext = next
+	cInfo.fullScreenExclusive = C.VkFullScreenExclusiveEXT(info.FullScreenExclusive)
+
+	cCaps := (*C.VkSurfaceCapabilitiesFullScreenExclusiveEXT)(C.calloc(1, C.sizeof_VkSurfaceCapabilitiesFullScreenExclusiveEXT))
+	if cCaps == nil {
+		return nil, nil, NewVulkanError(ErrorOutOfHostMemory, operation, "failed to allocate memory for full-screen exclusive capabilities")
+	}
+	*allocations = append(*allocations, unsafe.Pointer(cCaps))
+	cCaps.sType = C.VK_STRUCTURE_TYPE_SURFACE_CAPABILITIES_FULL_SCREEN_EXCLUSIVE_EXT
+
+	return unsafe.Pointer(cInfo), unsafe.Pointer(cCaps), nil
+}
+
+// fullScreenExclusiveSupportedFromC reads the VkSurfaceCapabilitiesFullScreenExclusiveEXT
+// returned by fullScreenExclusiveSurfaceInfoToC after the capabilities query
+func fullScreenExclusiveSupportedFromC(caps unsafe.Pointer) bool {
+	return vkBool32ToBool((*C.VkSurfaceCapabilitiesFullScreenExclusiveEXT)(caps).fullScreenExclusiveSupported)
+}
@@ -0,0 +1,41 @@
+//go:build windows && cgo
+
+package vulkan
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestFullScreenExclusiveValidation tests input validation and the unloaded-extension error
+func TestFullScreenExclusiveValidation(t *testing.T) {
+	fakeDevice := Device(uintptr(0x1234))
+	fakeSwapchain := Swapchain(uintptr(0x5678))
+
+	var validationErr *ValidationError
+	if err := AcquireFullScreenExclusiveModeEXT(fakeDevice, nil); !errors.As(err, &validationErr) || validationErr.Parameter != "swapchain" {
+		t.Errorf("Expected ValidationError for swapchain, got %v", err)
+	}
+	if err := ReleaseFullScreenExclusiveModeEXT(nil, fakeSwapchain); !errors.As(err, &validationErr) || validationErr.Parameter != "device" {
+		t.Errorf("Expected ValidationError for device, got %v", err)
+	}
+
+	// Without LoadFullScreenExclusiveFunctions the calls report the missing extension
+	err := AcquireFullScreenExclusiveModeEXT(fakeDevice, fakeSwapchain)
+	if !errors.Is(err, ErrorExtensionNotPresent) {
+		t.Errorf("Expected ErrorExtensionNotPresent, got %v", err)
+	}
+}
+
+// TestFullScreenExclusiveSurfaceInfoValidation tests that application controlled queries name
+// a monitor
+func TestFullScreenExclusiveSurfaceInfoValidation(t *testing.T) {
+	_, err := GetPhysicalDeviceSurfaceCapabilities2KHR(PhysicalDevice(uintptr(0x1234)), &PhysicalDeviceSurfaceInfo2{
+		Surface:             Surface(uintptr(0x5678)),
+		FullScreenExclusive: &SurfaceFullScreenExclusiveInfo{FullScreenExclusive: FullScreenExclusiveApplicationControlled},
+	})
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Parameter != "FullScreenExclusive.Monitor" {
+		t.Errorf("Expected ValidationError for FullScreenExclusive.Monitor, got %v", err)
+	}
+}
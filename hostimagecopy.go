@@ -0,0 +1,644 @@
+package vulkan
+
+/*
+#include <vulkan/vulkan.h>
+#include <stdlib.h>
+
+// Function pointers for host image copy EXT extension functions
+// These need to be loaded dynamically at runtime.
+//
+// IMPORTANT: These are global static pointers and NOT thread-safe during loading.
+// LoadHostImageCopyFunctions must be called from a single thread during initialization
+// before any concurrent host image copy API usage.
+static PFN_vkCopyMemoryToImageEXT pfn_vkCopyMemoryToImageEXT = NULL;
+static PFN_vkCopyImageToMemoryEXT pfn_vkCopyImageToMemoryEXT = NULL;
+static PFN_vkTransitionImageLayoutEXT pfn_vkTransitionImageLayoutEXT = NULL;
+static PFN_vkGetImageSubresourceLayout2EXT pfn_vkGetImageSubresourceLayout2EXT = NULL;
+
+static int loadHostImageCopyDeviceFunctions(VkDevice device) {
+    if (device == VK_NULL_HANDLE) {
+        return 0;
+    }
+    pfn_vkCopyMemoryToImageEXT = (PFN_vkCopyMemoryToImageEXT)
+        vkGetDeviceProcAddr(device, "vkCopyMemoryToImageEXT");
+    pfn_vkCopyImageToMemoryEXT = (PFN_vkCopyImageToMemoryEXT)
+        vkGetDeviceProcAddr(device, "vkCopyImageToMemoryEXT");
+    pfn_vkTransitionImageLayoutEXT = (PFN_vkTransitionImageLayoutEXT)
+        vkGetDeviceProcAddr(device, "vkTransitionImageLayoutEXT");
+    pfn_vkGetImageSubresourceLayout2EXT = (PFN_vkGetImageSubresourceLayout2EXT)
+        vkGetDeviceProcAddr(device, "vkGetImageSubresourceLayout2EXT");
+
+    return pfn_vkCopyMemoryToImageEXT != NULL &&
+           pfn_vkCopyImageToMemoryEXT != NULL &&
+           pfn_vkTransitionImageLayoutEXT != NULL &&
+           pfn_vkGetImageSubresourceLayout2EXT != NULL;
+}
+
+static VkResult call_vkCopyMemoryToImageEXT(
+    VkDevice device,
+    const VkCopyMemoryToImageInfoEXT* pCopyMemoryToImageInfo) {
+    if (pfn_vkCopyMemoryToImageEXT == NULL) {
+        return VK_ERROR_EXTENSION_NOT_PRESENT;
+    }
+    return pfn_vkCopyMemoryToImageEXT(device, pCopyMemoryToImageInfo);
+}
+
+static VkResult call_vkCopyImageToMemoryEXT(
+    VkDevice device,
+    const VkCopyImageToMemoryInfoEXT* pCopyImageToMemoryInfo) {
+    if (pfn_vkCopyImageToMemoryEXT == NULL) {
+        return VK_ERROR_EXTENSION_NOT_PRESENT;
+    }
+    return pfn_vkCopyImageToMemoryEXT(device, pCopyImageToMemoryInfo);
+}
+
+static VkResult call_vkTransitionImageLayoutEXT(
+    VkDevice device,
+    uint32_t transitionCount,
+    const VkHostImageLayoutTransitionInfoEXT* pTransitions) {
+    if (pfn_vkTransitionImageLayoutEXT == NULL) {
+        return VK_ERROR_EXTENSION_NOT_PRESENT;
+    }
+    return pfn_vkTransitionImageLayoutEXT(device, transitionCount, pTransitions);
+}
+
+// Queries the size of the data HostImageCopyMemcpyBitEXT copies for one subresource
+static VkResult call_hostMemcpySize(
+    VkDevice device,
+    VkImage image,
+    VkImageAspectFlags aspectMask,
+    uint32_t mipLevel,
+    uint32_t arrayLayer,
+    VkDeviceSize* pSize) {
+    if (pfn_vkGetImageSubresourceLayout2EXT == NULL) {
+        return VK_ERROR_EXTENSION_NOT_PRESENT;
+    }
+    VkImageSubresource2EXT subresource = {0};
+    subresource.sType = VK_STRUCTURE_TYPE_IMAGE_SUBRESOURCE_2_EXT;
+    subresource.imageSubresource.aspectMask = aspectMask;
+    subresource.imageSubresource.mipLevel = mipLevel;
+    subresource.imageSubresource.arrayLayer = arrayLayer;
+
+    VkSubresourceHostMemcpySizeEXT memcpySize = {0};
+    memcpySize.sType = VK_STRUCTURE_TYPE_SUBRESOURCE_HOST_MEMCPY_SIZE_EXT;
+    VkSubresourceLayout2EXT layout = {0};
+    layout.sType = VK_STRUCTURE_TYPE_SUBRESOURCE_LAYOUT_2_EXT;
+    layout.pNext = &memcpySize;
+
+    pfn_vkGetImageSubresourceLayout2EXT(device, image, &subresource, &layout);
+    *pSize = memcpySize.size;
+    return VK_SUCCESS;
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"math/bits"
+	"runtime"
+	"slices"
+	"sync"
+	"unsafe"
+)
+
+// ExtensionNameHostImageCopy is the host image copy extension name
+const ExtensionNameHostImageCopy = "VK_EXT_host_image_copy"
+
+// ImageUsageHostTransferBitEXT allows an image to be used with the host image copy commands
+const ImageUsageHostTransferBitEXT ImageUsageFlags = C.VK_IMAGE_USAGE_HOST_TRANSFER_BIT_EXT
+
+// HostImageCopyFlags represents host image copy flags
+type HostImageCopyFlags uint32
+
+const (
+	// HostImageCopyMemcpyBitEXT copies the image data verbatim in its implementation-defined
+	// tiled layout; the row length and image height of each region must be zero
+	HostImageCopyMemcpyBitEXT HostImageCopyFlags = C.VK_HOST_IMAGE_COPY_MEMCPY_EXT
+)
+
+// PhysicalDeviceHostImageCopyFeatures contains host image copy feature support
+type PhysicalDeviceHostImageCopyFeatures struct {
+	HostImageCopy bool
+}
+
+// PhysicalDeviceHostImageCopyProperties reports the image layouts host image copies support
+type PhysicalDeviceHostImageCopyProperties struct {
+	CopySrcLayouts []ImageLayout
+	CopyDstLayouts []ImageLayout
+	// OptimalTilingLayoutUUID identifies the tiled layout used by HostImageCopyMemcpyBitEXT;
+	// memcpy'd data can only be reused on devices reporting the same UUID
+	OptimalTilingLayoutUUID         [UuidSize]byte
+	IdenticalMemoryTypeRequirements bool
+}
+
+// MemoryToImageCopy describes a copy from host memory into an image region.
+// A MemoryRowLength or MemoryImageHeight of zero means Data is tightly packed. Data must hold
+// the whole region; CopyMemoryToImageEXT checks its length before handing it to the driver.
+type MemoryToImageCopy struct {
+	Data              []byte
+	MemoryRowLength   uint32
+	MemoryImageHeight uint32
+	ImageSubresource  ImageSubresourceLayers
+	ImageOffset       Offset3D
+	ImageExtent       Extent3D
+}
+
+// ImageToMemoryCopy describes a copy from an image region into host memory.
+// Data must be large enough to hold the region; CopyImageToMemoryEXT checks its length
+// before the driver writes into it.
+type ImageToMemoryCopy struct {
+	Data              []byte
+	MemoryRowLength   uint32
+	MemoryImageHeight uint32
+	ImageSubresource  ImageSubresourceLayers
+	ImageOffset       Offset3D
+	ImageExtent       Extent3D
+}
+
+// CopyMemoryToImageInfo contains the parameters for CopyMemoryToImageEXT
+type CopyMemoryToImageInfo struct {
+	Flags          HostImageCopyFlags
+	DstImage       Image
+	DstImageLayout ImageLayout
+	// DstImageFormat is the format DstImage was created with. It sizes the data of each
+	// region.
+	DstImageFormat Format
+	Regions        []MemoryToImageCopy
+}
+
+// CopyImageToMemoryInfo contains the parameters for CopyImageToMemoryEXT
+type CopyImageToMemoryInfo struct {
+	Flags          HostImageCopyFlags
+	SrcImage       Image
+	SrcImageLayout ImageLayout
+	// SrcImageFormat is the format SrcImage was created with. It sizes the data of each
+	// region.
+	SrcImageFormat Format
+	Regions        []ImageToMemoryCopy
+}
+
+// HostImageLayoutTransitionInfo describes an image layout transition performed on the host
+type HostImageLayoutTransitionInfo struct {
+	Image            Image
+	OldLayout        ImageLayout
+	NewLayout        ImageLayout
+	SubresourceRange ImageSubresourceRange
+}
+
+// LoadHostImageCopyFunctions loads host image copy extension functions for a device.
+//
+// This function MUST be called after creating a logical device with the VK_EXT_host_image_copy
+// extension enabled and before calling any host image copy function.
+//
+// IMPORTANT: This function is NOT thread-safe. Only one device is supported at a time;
+// calling this function again will overwrite previously loaded function pointers.
+//
+// Returns false if any host image copy function could not be loaded.
+func LoadHostImageCopyFunctions(device Device) bool {
+	return C.loadHostImageCopyDeviceFunctions(C.VkDevice(device)) != 0
+}
+
+// hostImageCopyFeaturesToC prepends a struct enabling host image copy to the pNext chain next.
+// The struct is allocated in C memory and appended to allocations, which the caller must free.
+func hostImageCopyFeaturesToC(features *PhysicalDeviceHostImageCopyFeatures, next unsafe.Pointer, allocations *[]unsafe.Pointer) (unsafe.Pointer, error) {
+	cHostImageCopy := (*C.VkPhysicalDeviceHostImageCopyFeaturesEXT)(C.calloc(1, C.sizeof_VkPhysicalDeviceHostImageCopyFeaturesEXT))
+	if cHostImageCopy == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "CreateDevice", "failed to allocate memory for host image copy features")
+	}
+	*allocations = append(*allocations, unsafe.Pointer(cHostImageCopy))
+
+	cHostImageCopy.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_HOST_IMAGE_COPY_FEATURES_EXT
+	cHostImageCopy.pNext = next
+	cHostImageCopy.hostImageCopy = boolToVkBool32(features.HostImageCopy)
+
+	return unsafe.Pointer(cHostImageCopy), nil
+}
+
+// hostImageCopyLayouts records, for devices created with HostImageCopyFeatures enabled, the
+// properties GetPhysicalDeviceHostImageCopyProperties reported, so host copies and
+// transitions can be checked against the supported layouts. DestroyDevice removes the entry.
+var hostImageCopyLayouts sync.Map
+
+// recordHostImageCopyLayouts queries and records the host image copy layouts of a device
+// created on physicalDevice
+func recordHostImageCopyLayouts(device Device, physicalDevice PhysicalDevice) {
+	properties, err := GetPhysicalDeviceHostImageCopyProperties(physicalDevice)
+	if err != nil {
+		return
+	}
+	hostImageCopyLayouts.Store(device, properties)
+}
+
+// hostImageCopyProperties returns the host image copy layouts recorded for device, or false
+// if the device was not created through CreateDevice with HostImageCopyFeatures enabled
+func hostImageCopyProperties(device Device) (*PhysicalDeviceHostImageCopyProperties, bool) {
+	properties, ok := hostImageCopyLayouts.Load(device)
+	if !ok {
+		return nil, false
+	}
+	return properties.(*PhysicalDeviceHostImageCopyProperties), true
+}
+
+// GetPhysicalDeviceHostImageCopyFeatures queries host image copy feature support
+func GetPhysicalDeviceHostImageCopyFeatures(physicalDevice PhysicalDevice) PhysicalDeviceHostImageCopyFeatures {
+	cFeatures2 := (*C.VkPhysicalDeviceFeatures2)(C.calloc(1, C.sizeof_VkPhysicalDeviceFeatures2))
+	cHostImageCopy := (*C.VkPhysicalDeviceHostImageCopyFeaturesEXT)(C.calloc(1, C.sizeof_VkPhysicalDeviceHostImageCopyFeaturesEXT))
+	defer C.free(unsafe.Pointer(cFeatures2))
+	defer C.free(unsafe.Pointer(cHostImageCopy))
+	if cFeatures2 == nil || cHostImageCopy == nil {
+		return PhysicalDeviceHostImageCopyFeatures{}
+	}
+
+	cFeatures2.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_FEATURES_2
+	cFeatures2.pNext = unsafe.Pointer(cHostImageCopy)
+	cHostImageCopy.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_HOST_IMAGE_COPY_FEATURES_EXT
+
+	C.vkGetPhysicalDeviceFeatures2(C.VkPhysicalDevice(physicalDevice), cFeatures2)
+
+	return PhysicalDeviceHostImageCopyFeatures{
+		HostImageCopy: vkBool32ToBool(cHostImageCopy.hostImageCopy),
+	}
+}
+
+// GetPhysicalDeviceHostImageCopyProperties queries the image layouts supported as the
+// source and destination of host image copies
+func GetPhysicalDeviceHostImageCopyProperties(physicalDevice PhysicalDevice) (*PhysicalDeviceHostImageCopyProperties, error) {
+	if physicalDevice == nil {
+		return nil, NewValidationError("physicalDevice", "cannot be nil")
+	}
+
+	var allocations []unsafe.Pointer
+	defer func() { freeAllocations(allocations) }()
+
+	cProps2 := (*C.VkPhysicalDeviceProperties2)(C.calloc(1, C.sizeof_VkPhysicalDeviceProperties2))
+	cHostImageCopy := (*C.VkPhysicalDeviceHostImageCopyPropertiesEXT)(C.calloc(1, C.sizeof_VkPhysicalDeviceHostImageCopyPropertiesEXT))
+	allocations = append(allocations, unsafe.Pointer(cProps2), unsafe.Pointer(cHostImageCopy))
+	if cProps2 == nil || cHostImageCopy == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "GetPhysicalDeviceHostImageCopyProperties", "failed to allocate memory for properties")
+	}
+
+	cProps2.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_PROPERTIES_2
+	cProps2.pNext = unsafe.Pointer(cHostImageCopy)
+	cHostImageCopy.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_HOST_IMAGE_COPY_PROPERTIES_EXT
+
+	// The first call reports the layout counts, the second fills the arrays
+	C.vkGetPhysicalDeviceProperties2(C.VkPhysicalDevice(physicalDevice), cProps2)
+
+	srcCount := int(cHostImageCopy.copySrcLayoutCount)
+	dstCount := int(cHostImageCopy.copyDstLayoutCount)
+	layoutSize := C.size_t(unsafe.Sizeof(C.VkImageLayout(0)))
+	if srcCount > 0 {
+		cHostImageCopy.pCopySrcLayouts = (*C.VkImageLayout)(C.calloc(C.size_t(srcCount), layoutSize))
+		if cHostImageCopy.pCopySrcLayouts == nil {
+			return nil, NewVulkanError(ErrorOutOfHostMemory, "GetPhysicalDeviceHostImageCopyProperties", "failed to allocate memory for source layouts")
+		}
+		allocations = append(allocations, unsafe.Pointer(cHostImageCopy.pCopySrcLayouts))
+	}
+	if dstCount > 0 {
+		cHostImageCopy.pCopyDstLayouts = (*C.VkImageLayout)(C.calloc(C.size_t(dstCount), layoutSize))
+		if cHostImageCopy.pCopyDstLayouts == nil {
+			return nil, NewVulkanError(ErrorOutOfHostMemory, "GetPhysicalDeviceHostImageCopyProperties", "failed to allocate memory for destination layouts")
+		}
+		allocations = append(allocations, unsafe.Pointer(cHostImageCopy.pCopyDstLayouts))
+	}
+
+	C.vkGetPhysicalDeviceProperties2(C.VkPhysicalDevice(physicalDevice), cProps2)
+
+	properties := &PhysicalDeviceHostImageCopyProperties{
+		CopySrcLayouts:                  make([]ImageLayout, 0, srcCount),
+		CopyDstLayouts:                  make([]ImageLayout, 0, dstCount),
+		IdenticalMemoryTypeRequirements: vkBool32ToBool(cHostImageCopy.identicalMemoryTypeRequirements),
+	}
+	if srcCount > 0 {
+		for _, layout := range unsafe.Slice(cHostImageCopy.pCopySrcLayouts, int(cHostImageCopy.copySrcLayoutCount)) {
+			properties.CopySrcLayouts = append(properties.CopySrcLayouts, ImageLayout(layout))
+		}
+	}
+	if dstCount > 0 {
+		for _, layout := range unsafe.Slice(cHostImageCopy.pCopyDstLayouts, int(cHostImageCopy.copyDstLayoutCount)) {
+			properties.CopyDstLayouts = append(properties.CopyDstLayouts, ImageLayout(layout))
+		}
+	}
+	for i := 0; i < UuidSize; i++ {
+		properties.OptimalTilingLayoutUUID[i] = byte(cHostImageCopy.optimalTilingLayoutUUID[i])
+	}
+	return properties, nil
+}
+
+// CopyMemoryToImageEXT copies host memory directly into an image without a command buffer.
+// The image must have been created with ImageUsageHostTransferBitEXT and be in a layout
+// reported by GetPhysicalDeviceHostImageCopyProperties; on devices created with
+// HostImageCopyFeatures the layout is checked. Each region's Data must hold the region as
+// laid out for DstImageFormat, or a ValidationError is returned.
+// Returns an error if LoadHostImageCopyFunctions was not called.
+func CopyMemoryToImageEXT(device Device, copyInfo *CopyMemoryToImageInfo) error {
+	if device == nil {
+		return NewValidationError("device", "cannot be nil")
+	}
+	if copyInfo == nil {
+		return NewValidationError("copyInfo", "cannot be nil")
+	}
+	if copyInfo.DstImage == nil {
+		return NewValidationError("copyInfo.DstImage", "cannot be nil")
+	}
+	if len(copyInfo.Regions) == 0 {
+		return NewValidationError("copyInfo.Regions", "must contain at least one region")
+	}
+	if properties, ok := hostImageCopyProperties(device); ok && !slices.Contains(properties.CopyDstLayouts, copyInfo.DstImageLayout) {
+		return NewValidationError("copyInfo.DstImageLayout", "is not in PhysicalDeviceHostImageCopyProperties.CopyDstLayouts")
+	}
+	for i, region := range copyInfo.Regions {
+		if err := validateHostCopyRegion("CopyMemoryToImageEXT", fmt.Sprintf("copyInfo.Regions[%d]", i), device, copyInfo.DstImage, copyInfo.Flags, copyInfo.DstImageFormat,
+			len(region.Data), region.MemoryRowLength, region.MemoryImageHeight, region.ImageSubresource, region.ImageExtent); err != nil {
+			return err
+		}
+	}
+
+	cRegionsPtr := (*C.VkMemoryToImageCopyEXT)(C.calloc(C.size_t(len(copyInfo.Regions)), C.sizeof_VkMemoryToImageCopyEXT))
+	if cRegionsPtr == nil {
+		return NewVulkanError(ErrorOutOfHostMemory, "CopyMemoryToImageEXT", "failed to allocate memory for copy regions")
+	}
+	defer C.free(unsafe.Pointer(cRegionsPtr))
+
+	// The regions live in C memory, so the Go buffers they point at must be pinned
+	var pinner runtime.Pinner
+	defer pinner.Unpin()
+
+	cRegions := unsafe.Slice(cRegionsPtr, len(copyInfo.Regions))
+	for i, region := range copyInfo.Regions {
+		pinner.Pin(&region.Data[0])
+		cRegions[i] = C.VkMemoryToImageCopyEXT{
+			sType:             C.VK_STRUCTURE_TYPE_MEMORY_TO_IMAGE_COPY_EXT,
+			pHostPointer:      unsafe.Pointer(&region.Data[0]),
+			memoryRowLength:   C.uint32_t(region.MemoryRowLength),
+			memoryImageHeight: C.uint32_t(region.MemoryImageHeight),
+			imageSubresource:  region.ImageSubresource.toC(),
+			imageOffset:       region.ImageOffset.toC(),
+			imageExtent:       region.ImageExtent.toC(),
+		}
+	}
+
+	cInfo := C.VkCopyMemoryToImageInfoEXT{
+		sType:          C.VK_STRUCTURE_TYPE_COPY_MEMORY_TO_IMAGE_INFO_EXT,
+		flags:          C.VkHostImageCopyFlagsEXT(copyInfo.Flags),
+		dstImage:       C.VkImage(copyInfo.DstImage),
+		dstImageLayout: C.VkImageLayout(copyInfo.DstImageLayout),
+		regionCount:    C.uint32_t(len(copyInfo.Regions)),
+		pRegions:       cRegionsPtr,
+	}
+
+	result := Result(C.call_vkCopyMemoryToImageEXT(C.VkDevice(device), &cInfo))
+	traceResult("vkCopyMemoryToImageEXT", result)
+	if result == ErrorExtensionNotPresent {
+		return NewVulkanError(result, "CopyMemoryToImageEXT", "host image copy extension not loaded - call LoadHostImageCopyFunctions first")
+	}
+	if result != Success {
+		return NewVulkanError(result, "CopyMemoryToImageEXT", "failed to copy memory to image")
+	}
+	return nil
+}
+
+// CopyImageToMemoryEXT copies an image directly into host memory without a command buffer.
+// The image must have been created with ImageUsageHostTransferBitEXT and be in a layout
+// reported by GetPhysicalDeviceHostImageCopyProperties; on devices created with
+// HostImageCopyFeatures the layout is checked. Each region's Data must be large enough for
+// the region as laid out for SrcImageFormat, or a ValidationError is returned.
+// Returns an error if LoadHostImageCopyFunctions was not called.
+func CopyImageToMemoryEXT(device Device, copyInfo *CopyImageToMemoryInfo) error {
+	if device == nil {
+		return NewValidationError("device", "cannot be nil")
+	}
+	if copyInfo == nil {
+		return NewValidationError("copyInfo", "cannot be nil")
+	}
+	if copyInfo.SrcImage == nil {
+		return NewValidationError("copyInfo.SrcImage", "cannot be nil")
+	}
+	if len(copyInfo.Regions) == 0 {
+		return NewValidationError("copyInfo.Regions", "must contain at least one region")
+	}
+	if properties, ok := hostImageCopyProperties(device); ok && !slices.Contains(properties.CopySrcLayouts, copyInfo.SrcImageLayout) {
+		return NewValidationError("copyInfo.SrcImageLayout", "is not in PhysicalDeviceHostImageCopyProperties.CopySrcLayouts")
+	}
+	for i, region := range copyInfo.Regions {
+		if err := validateHostCopyRegion("CopyImageToMemoryEXT", fmt.Sprintf("copyInfo.Regions[%d]", i), device, copyInfo.SrcImage, copyInfo.Flags, copyInfo.SrcImageFormat,
+			len(region.Data), region.MemoryRowLength, region.MemoryImageHeight, region.ImageSubresource, region.ImageExtent); err != nil {
+			return err
+		}
+	}
+
+	cRegionsPtr := (*C.VkImageToMemoryCopyEXT)(C.calloc(C.size_t(len(copyInfo.Regions)), C.sizeof_VkImageToMemoryCopyEXT))
+	if cRegionsPtr == nil {
+		return NewVulkanError(ErrorOutOfHostMemory, "CopyImageToMemoryEXT", "failed to allocate memory for copy regions")
+	}
+	defer C.free(unsafe.Pointer(cRegionsPtr))
+
+	// The regions live in C memory, so the Go buffers they point at must be pinned
+	var pinner runtime.Pinner
+	defer pinner.Unpin()
+
+	cRegions := unsafe.Slice(cRegionsPtr, len(copyInfo.Regions))
+	for i, region := range copyInfo.Regions {
+		pinner.Pin(&region.Data[0])
+		cRegions[i] = C.VkImageToMemoryCopyEXT{
+			sType:             C.VK_STRUCTURE_TYPE_IMAGE_TO_MEMORY_COPY_EXT,
+			pHostPointer:      unsafe.Pointer(&region.Data[0]),
+			memoryRowLength:   C.uint32_t(region.MemoryRowLength),
+			memoryImageHeight: C.uint32_t(region.MemoryImageHeight),
+			imageSubresource:  region.ImageSubresource.toC(),
+			imageOffset:       region.ImageOffset.toC(),
+			imageExtent:       region.ImageExtent.toC(),
+		}
+	}
+
+	cInfo := C.VkCopyImageToMemoryInfoEXT{
+		sType:          C.VK_STRUCTURE_TYPE_COPY_IMAGE_TO_MEMORY_INFO_EXT,
+		flags:          C.VkHostImageCopyFlagsEXT(copyInfo.Flags),
+		srcImage:       C.VkImage(copyInfo.SrcImage),
+		srcImageLayout: C.VkImageLayout(copyInfo.SrcImageLayout),
+		regionCount:    C.uint32_t(len(copyInfo.Regions)),
+		pRegions:       cRegionsPtr,
+	}
+
+	result := Result(C.call_vkCopyImageToMemoryEXT(C.VkDevice(device), &cInfo))
+	traceResult("vkCopyImageToMemoryEXT", result)
+	if result == ErrorExtensionNotPresent {
+		return NewVulkanError(result, "CopyImageToMemoryEXT", "host image copy extension not loaded - call LoadHostImageCopyFunctions first")
+	}
+	if result != Success {
+		return NewVulkanError(result, "CopyImageToMemoryEXT", "failed to copy image to memory")
+	}
+	return nil
+}
+
+// supportsLayout reports whether layout is a source or destination layout of host image copies
+func (p *PhysicalDeviceHostImageCopyProperties) supportsLayout(layout ImageLayout) bool {
+	return slices.Contains(p.CopySrcLayouts, layout) || slices.Contains(p.CopyDstLayouts, layout)
+}
+
+// validateHostCopyRegion checks that dataLen bytes hold a host copy region. Without
+// HostImageCopyMemcpyBitEXT the size follows the buffer addressing rules for format; with it
+// the driver reports the size of the tiled data of each subresource.
+func validateHostCopyRegion(operation, param string, device Device, image Image, flags HostImageCopyFlags, format Format, dataLen int, rowLength, imageHeight uint32, subresource ImageSubresourceLayers, extent Extent3D) error {
+	if dataLen == 0 {
+		return NewValidationError(param, "Data cannot be empty")
+	}
+	if subresource.LayerCount == 0 || subresource.LayerCount == RemainingArrayLayers {
+		return NewValidationError(param, "ImageSubresource.LayerCount must be an explicit non-zero count")
+	}
+	if bits.OnesCount32(uint32(subresource.AspectMask)) != 1 {
+		return NewValidationError(param, "ImageSubresource.AspectMask must name exactly one aspect")
+	}
+
+	var required uint64
+	if flags&HostImageCopyMemcpyBitEXT != 0 {
+		if rowLength != 0 || imageHeight != 0 {
+			return NewValidationError(param, "MemoryRowLength and MemoryImageHeight must be zero with HostImageCopyMemcpyBitEXT")
+		}
+		for layer := uint32(0); layer < subresource.LayerCount; layer++ {
+			var size C.VkDeviceSize
+			result := Result(C.call_hostMemcpySize(C.VkDevice(device), C.VkImage(image), C.VkImageAspectFlags(subresource.AspectMask),
+				C.uint32_t(subresource.MipLevel), C.uint32_t(subresource.BaseArrayLayer+layer), &size))
+			if result != Success {
+				return NewVulkanError(result, operation, "host image copy extension not loaded - call LoadHostImageCopyFunctions first")
+			}
+			required += uint64(size)
+		}
+	} else {
+		if format == FormatUndefined {
+			return NewValidationError(param, "image format must be set to size the region")
+		}
+		size, err := hostCopyDataSize(param, format, subresource.AspectMask, rowLength, imageHeight, extent, subresource.LayerCount)
+		if err != nil {
+			return err
+		}
+		required = size
+	}
+	if uint64(dataLen) < required {
+		return NewValidationError(param, fmt.Sprintf("Data holds %d bytes but the region needs %d", dataLen, required))
+	}
+	return nil
+}
+
+// hostCopyDataSize returns the number of bytes a host copy region of format addresses: the
+// offset of its last texel block plus one block, following the buffer image copy rules where a
+// row length or image height of zero means tightly packed
+func hostCopyDataSize(param string, format Format, aspect ImageAspectFlags, rowLength, imageHeight uint32, extent Extent3D, layerCount uint32) (uint64, error) {
+	blockSize := copyTexelBlockSize(format, aspect)
+	if blockSize == 0 {
+		return 0, NewValidationError(param, fmt.Sprintf("format %d has no known texel size for aspect %#x", format, aspect))
+	}
+	if extent.Width == 0 || extent.Height == 0 || extent.Depth == 0 {
+		return 0, NewValidationError(param, "ImageExtent cannot have a zero dimension")
+	}
+	if rowLength == 0 {
+		rowLength = extent.Width
+	}
+	if imageHeight == 0 {
+		imageHeight = extent.Height
+	}
+	if rowLength < extent.Width {
+		return 0, NewValidationError(param, "MemoryRowLength must be zero or at least ImageExtent.Width")
+	}
+	if imageHeight < extent.Height {
+		return 0, NewValidationError(param, "MemoryImageHeight must be zero or at least ImageExtent.Height")
+	}
+
+	block := FormatBlockExtent(format)
+	rowBlocks := ceilDiv(rowLength, block.Width)
+	sliceHi, sliceBlocks := bits.Mul64(rowBlocks, ceilDiv(imageHeight, block.Height))
+	depthSlices := uint64(extent.Depth) * uint64(layerCount)
+	lastHi, lastSlice := bits.Mul64(depthSlices-1, sliceBlocks)
+	blocks, carry := bits.Add64(lastSlice, (ceilDiv(extent.Height, block.Height)-1)*rowBlocks+ceilDiv(extent.Width, block.Width), 0)
+	sizeHi, size := bits.Mul64(blocks, uint64(blockSize))
+	if sliceHi != 0 || lastHi != 0 || carry != 0 || sizeHi != 0 {
+		return 0, NewValidationError(param, "region is too large to address")
+	}
+	return size, nil
+}
+
+// copyTexelBlockSize returns the size of one texel block of the aspect of format a copy
+// transfers: depth and stencil are copied one aspect at a time, with D24 depth taking 4 bytes
+func copyTexelBlockSize(format Format, aspect ImageAspectFlags) uint32 {
+	switch aspect {
+	case ImageAspectStencilBit:
+		if AspectMaskForFormat(format)&ImageAspectStencilBit != 0 {
+			return 1
+		}
+		return 0
+	case ImageAspectDepthBit:
+		switch format {
+		case FormatD16Unorm, FormatD16UnormS8Uint:
+			return 2
+		case FormatX8D24UnormPack32, FormatD24UnormS8Uint, FormatD32Sfloat, FormatD32SfloatS8Uint:
+			return 4
+		}
+		return 0
+	case ImageAspectColorBit:
+		if AspectMaskForFormat(format) != ImageAspectColorBit {
+			return 0
+		}
+		return FormatTexelBlockSize(format)
+	}
+	return 0
+}
+
+func ceilDiv(n, d uint32) uint64 {
+	return (uint64(n) + uint64(d) - 1) / uint64(d)
+}
+
+// TransitionImageLayoutEXT transitions image layouts on the host, for example to move a
+// freshly created image into a layout usable by CopyMemoryToImageEXT.
+// Returns an error if LoadHostImageCopyFunctions was not called.
+func TransitionImageLayoutEXT(device Device, transitions []HostImageLayoutTransitionInfo) error {
+	if device == nil {
+		return NewValidationError("device", "cannot be nil")
+	}
+	if len(transitions) == 0 {
+		return nil
+	}
+
+	cTransitionsPtr := (*C.VkHostImageLayoutTransitionInfoEXT)(C.calloc(C.size_t(len(transitions)), C.sizeof_VkHostImageLayoutTransitionInfoEXT))
+	if cTransitionsPtr == nil {
+		return NewVulkanError(ErrorOutOfHostMemory, "TransitionImageLayoutEXT", "failed to allocate memory for transitions")
+	}
+	defer C.free(unsafe.Pointer(cTransitionsPtr))
+
+	cTransitions := unsafe.Slice(cTransitionsPtr, len(transitions))
+	for i, transition := range transitions {
+		if transition.Image == nil {
+			return NewValidationError("transitions", "image cannot be nil")
+		}
+		if properties, ok := hostImageCopyProperties(device); ok {
+			if transition.OldLayout != ImageLayoutUndefined && transition.OldLayout != ImageLayoutPreinitialized && !properties.supportsLayout(transition.OldLayout) {
+				return NewValidationError(fmt.Sprintf("transitions[%d].OldLayout", i), "must be ImageLayoutUndefined, ImageLayoutPreinitialized or a host image copy layout")
+			}
+			if !properties.supportsLayout(transition.NewLayout) {
+				return NewValidationError(fmt.Sprintf("transitions[%d].NewLayout", i), "is not in PhysicalDeviceHostImageCopyProperties.CopySrcLayouts or CopyDstLayouts")
+			}
+		}
+		cTransitions[i] = C.VkHostImageLayoutTransitionInfoEXT{
+			sType:     C.VK_STRUCTURE_TYPE_HOST_IMAGE_LAYOUT_TRANSITION_INFO_EXT,
+			image:     C.VkImage(transition.Image),
+			oldLayout: C.VkImageLayout(transition.OldLayout),
+			newLayout: C.VkImageLayout(transition.NewLayout),
+			subresourceRange: C.VkImageSubresourceRange{
+				aspectMask:     C.VkImageAspectFlags(transition.SubresourceRange.AspectMask),
+				baseMipLevel:   C.uint32_t(transition.SubresourceRange.BaseMipLevel),
+				levelCount:     C.uint32_t(transition.SubresourceRange.LevelCount),
+				baseArrayLayer: C.uint32_t(transition.SubresourceRange.BaseArrayLayer),
+				layerCount:     C.uint32_t(transition.SubresourceRange.LayerCount),
+			},
+		}
+	}
+
+	result := Result(C.call_vkTransitionImageLayoutEXT(C.VkDevice(device), C.uint32_t(len(transitions)), cTransitionsPtr))
+	traceResult("vkTransitionImageLayoutEXT", result)
+	if result == ErrorExtensionNotPresent {
+		return NewVulkanError(result, "TransitionImageLayoutEXT", "host image copy extension not loaded - call LoadHostImageCopyFunctions first")
+	}
+	if result != Success {
+		return NewVulkanError(result, "TransitionImageLayoutEXT", "failed to transition image layouts")
+	}
+	return nil
+}
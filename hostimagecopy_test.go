@@ -0,0 +1,249 @@
+//go:build cgo
+
+package vulkan
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestHostCopyDataSize tests the byte count of host copy regions for packed, padded,
+// compressed and depth-stencil layouts
+func TestHostCopyDataSize(t *testing.T) {
+	tests := []struct {
+		name        string
+		format      Format
+		aspect      ImageAspectFlags
+		rowLength   uint32
+		imageHeight uint32
+		extent      Extent3D
+		layerCount  uint32
+		expected    uint64
+	}{
+		{"tightly packed RGBA", FormatR8G8B8A8Unorm, ImageAspectColorBit, 0, 0, Extent3D{Width: 4, Height: 4, Depth: 1}, 1, 64},
+		{"padded rows", FormatR8G8B8A8Unorm, ImageAspectColorBit, 8, 0, Extent3D{Width: 4, Height: 2, Depth: 1}, 1, 48},
+		{"padded layers", FormatR8G8B8A8Unorm, ImageAspectColorBit, 0, 4, Extent3D{Width: 2, Height: 2, Depth: 1}, 2, 48},
+		{"BC1 partial block", FormatBC1RGBUnormBlock, ImageAspectColorBit, 0, 0, Extent3D{Width: 6, Height: 6, Depth: 1}, 1, 32},
+		{"D24 depth aspect", FormatD24UnormS8Uint, ImageAspectDepthBit, 0, 0, Extent3D{Width: 2, Height: 2, Depth: 1}, 1, 16},
+		{"D24 stencil aspect", FormatD24UnormS8Uint, ImageAspectStencilBit, 0, 0, Extent3D{Width: 2, Height: 2, Depth: 1}, 1, 4},
+		{"3D image", FormatR32Sfloat, ImageAspectColorBit, 0, 0, Extent3D{Width: 2, Height: 2, Depth: 3}, 1, 48},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			size, err := hostCopyDataSize("region", tt.format, tt.aspect, tt.rowLength, tt.imageHeight, tt.extent, tt.layerCount)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if size != tt.expected {
+				t.Errorf("Expected %d bytes, got %d", tt.expected, size)
+			}
+		})
+	}
+}
+
+// TestCopyMemoryToImageValidation tests input, region size and layout validation
+func TestCopyMemoryToImageValidation(t *testing.T) {
+	fakeDevice := Device(uintptr(0x1234))
+	fakeImage := Image(uintptr(0x5678))
+	subresource := ImageSubresourceLayers{AspectMask: ImageAspectColorBit, LayerCount: 1}
+	extent := Extent3D{Width: 4, Height: 4, Depth: 1}
+
+	hostImageCopyLayouts.Store(fakeDevice, &PhysicalDeviceHostImageCopyProperties{
+		CopySrcLayouts: []ImageLayout{ImageLayoutGeneral},
+		CopyDstLayouts: []ImageLayout{ImageLayoutGeneral},
+	})
+	defer hostImageCopyLayouts.Delete(fakeDevice)
+
+	copyInfo := func(flags HostImageCopyFlags, layout ImageLayout, format Format, regions ...MemoryToImageCopy) *CopyMemoryToImageInfo {
+		return &CopyMemoryToImageInfo{Flags: flags, DstImage: fakeImage, DstImageLayout: layout, DstImageFormat: format, Regions: regions}
+	}
+
+	tests := []struct {
+		name       string
+		device     Device
+		copyInfo   *CopyMemoryToImageInfo
+		errorParam string
+	}{
+		{"nil device", nil, copyInfo(0, ImageLayoutGeneral, FormatR8G8B8A8Unorm), "device"},
+		{"nil copy info", fakeDevice, nil, "copyInfo"},
+		{"nil image", fakeDevice, &CopyMemoryToImageInfo{Regions: []MemoryToImageCopy{{}}}, "copyInfo.DstImage"},
+		{"no regions", fakeDevice, copyInfo(0, ImageLayoutGeneral, FormatR8G8B8A8Unorm), "copyInfo.Regions"},
+		{
+			name:       "unsupported layout",
+			device:     fakeDevice,
+			copyInfo:   copyInfo(0, ImageLayoutTransferDstOptimal, FormatR8G8B8A8Unorm, MemoryToImageCopy{Data: make([]byte, 64), ImageSubresource: subresource, ImageExtent: extent}),
+			errorParam: "copyInfo.DstImageLayout",
+		},
+		{
+			name:       "empty data",
+			device:     fakeDevice,
+			copyInfo:   copyInfo(0, ImageLayoutGeneral, FormatR8G8B8A8Unorm, MemoryToImageCopy{ImageSubresource: subresource, ImageExtent: extent}),
+			errorParam: "copyInfo.Regions[0]",
+		},
+		{
+			name:       "undefined format",
+			device:     fakeDevice,
+			copyInfo:   copyInfo(0, ImageLayoutGeneral, FormatUndefined, MemoryToImageCopy{Data: make([]byte, 64), ImageSubresource: subresource, ImageExtent: extent}),
+			errorParam: "copyInfo.Regions[0]",
+		},
+		{
+			name:       "short data",
+			device:     fakeDevice,
+			copyInfo:   copyInfo(0, ImageLayoutGeneral, FormatR8G8B8A8Unorm, MemoryToImageCopy{Data: make([]byte, 63), ImageSubresource: subresource, ImageExtent: extent}),
+			errorParam: "copyInfo.Regions[0]",
+		},
+		{
+			name:     "short data with row padding",
+			device:   fakeDevice,
+			copyInfo: copyInfo(0, ImageLayoutGeneral, FormatR8G8B8A8Unorm, MemoryToImageCopy{Data: make([]byte, 64), MemoryRowLength: 8, ImageSubresource: subresource, ImageExtent: extent}),
+			// Rows are 32 bytes apart, so the last row ends at byte 112
+			errorParam: "copyInfo.Regions[0]",
+		},
+		{
+			name:       "row length smaller than extent",
+			device:     fakeDevice,
+			copyInfo:   copyInfo(0, ImageLayoutGeneral, FormatR8G8B8A8Unorm, MemoryToImageCopy{Data: make([]byte, 64), MemoryRowLength: 2, ImageSubresource: subresource, ImageExtent: extent}),
+			errorParam: "copyInfo.Regions[0]",
+		},
+		{
+			name:       "combined depth stencil aspects",
+			device:     fakeDevice,
+			copyInfo:   copyInfo(0, ImageLayoutGeneral, FormatD24UnormS8Uint, MemoryToImageCopy{Data: make([]byte, 80), ImageSubresource: ImageSubresourceLayers{AspectMask: ImageAspectDepthBit | ImageAspectStencilBit, LayerCount: 1}, ImageExtent: extent}),
+			errorParam: "copyInfo.Regions[0]",
+		},
+		{
+			name:       "memcpy with row length",
+			device:     fakeDevice,
+			copyInfo:   copyInfo(HostImageCopyMemcpyBitEXT, ImageLayoutGeneral, FormatR8G8B8A8Unorm, MemoryToImageCopy{Data: make([]byte, 64), MemoryRowLength: 4, ImageSubresource: subresource, ImageExtent: extent}),
+			errorParam: "copyInfo.Regions[0]",
+		},
+		{
+			name:       "second region too small",
+			device:     fakeDevice,
+			copyInfo:   copyInfo(0, ImageLayoutGeneral, FormatR8G8B8A8Unorm, MemoryToImageCopy{Data: make([]byte, 64), ImageSubresource: subresource, ImageExtent: extent}, MemoryToImageCopy{Data: make([]byte, 4), ImageSubresource: subresource, ImageExtent: extent}),
+			errorParam: "copyInfo.Regions[1]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CopyMemoryToImageEXT(tt.device, tt.copyInfo)
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+
+	// A region that passes validation reaches the unloaded extension
+	err := CopyMemoryToImageEXT(fakeDevice, copyInfo(0, ImageLayoutGeneral, FormatR8G8B8A8Unorm, MemoryToImageCopy{Data: make([]byte, 64), ImageSubresource: subresource, ImageExtent: extent}))
+	var vkErr *VulkanError
+	if !errors.As(err, &vkErr) || vkErr.Result != ErrorExtensionNotPresent {
+		t.Errorf("Expected ErrorExtensionNotPresent, got %v", err)
+	}
+}
+
+// TestCopyImageToMemoryValidation tests that short destination slices and unsupported
+// layouts are rejected before the driver writes into them
+func TestCopyImageToMemoryValidation(t *testing.T) {
+	fakeDevice := Device(uintptr(0x1234))
+	fakeImage := Image(uintptr(0x5678))
+	subresource := ImageSubresourceLayers{AspectMask: ImageAspectColorBit, LayerCount: 2}
+	extent := Extent3D{Width: 4, Height: 4, Depth: 1}
+
+	hostImageCopyLayouts.Store(fakeDevice, &PhysicalDeviceHostImageCopyProperties{
+		CopySrcLayouts: []ImageLayout{ImageLayoutGeneral},
+	})
+	defer hostImageCopyLayouts.Delete(fakeDevice)
+
+	tests := []struct {
+		name       string
+		copyInfo   *CopyImageToMemoryInfo
+		errorParam string
+	}{
+		{"nil image", &CopyImageToMemoryInfo{Regions: []ImageToMemoryCopy{{}}}, "copyInfo.SrcImage"},
+		{
+			name:       "unsupported layout",
+			copyInfo:   &CopyImageToMemoryInfo{SrcImage: fakeImage, SrcImageLayout: ImageLayoutTransferSrcOptimal, SrcImageFormat: FormatR8G8B8A8Unorm, Regions: []ImageToMemoryCopy{{Data: make([]byte, 128), ImageSubresource: subresource, ImageExtent: extent}}},
+			errorParam: "copyInfo.SrcImageLayout",
+		},
+		{
+			name:       "data sized for one layer",
+			copyInfo:   &CopyImageToMemoryInfo{SrcImage: fakeImage, SrcImageLayout: ImageLayoutGeneral, SrcImageFormat: FormatR8G8B8A8Unorm, Regions: []ImageToMemoryCopy{{Data: make([]byte, 64), ImageSubresource: subresource, ImageExtent: extent}}},
+			errorParam: "copyInfo.Regions[0]",
+		},
+		{
+			name:       "remaining array layers",
+			copyInfo:   &CopyImageToMemoryInfo{SrcImage: fakeImage, SrcImageLayout: ImageLayoutGeneral, SrcImageFormat: FormatR8G8B8A8Unorm, Regions: []ImageToMemoryCopy{{Data: make([]byte, 128), ImageSubresource: ImageSubresourceLayers{AspectMask: ImageAspectColorBit, LayerCount: RemainingArrayLayers}, ImageExtent: extent}}},
+			errorParam: "copyInfo.Regions[0]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CopyImageToMemoryEXT(fakeDevice, tt.copyInfo)
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+}
+
+// TestTransitionImageLayoutValidation tests that host transitions are limited to the layouts
+// host image copies support
+func TestTransitionImageLayoutValidation(t *testing.T) {
+	fakeDevice := Device(uintptr(0x1234))
+	fakeImage := Image(uintptr(0x5678))
+
+	hostImageCopyLayouts.Store(fakeDevice, &PhysicalDeviceHostImageCopyProperties{
+		CopySrcLayouts: []ImageLayout{ImageLayoutGeneral},
+		CopyDstLayouts: []ImageLayout{ImageLayoutTransferDstOptimal},
+	})
+	defer hostImageCopyLayouts.Delete(fakeDevice)
+
+	tests := []struct {
+		name        string
+		device      Device
+		transitions []HostImageLayoutTransitionInfo
+		errorParam  string
+	}{
+		{"nil device", nil, nil, "device"},
+		{"nil image", fakeDevice, []HostImageLayoutTransitionInfo{{NewLayout: ImageLayoutGeneral}}, "transitions"},
+		{
+			name:        "unsupported new layout",
+			device:      fakeDevice,
+			transitions: []HostImageLayoutTransitionInfo{{Image: fakeImage, OldLayout: ImageLayoutUndefined, NewLayout: ImageLayoutShaderReadOnlyOptimal}},
+			errorParam:  "transitions[0].NewLayout",
+		},
+		{
+			name:        "unsupported old layout",
+			device:      fakeDevice,
+			transitions: []HostImageLayoutTransitionInfo{{Image: fakeImage, OldLayout: ImageLayoutShaderReadOnlyOptimal, NewLayout: ImageLayoutGeneral}},
+			errorParam:  "transitions[0].OldLayout",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := TransitionImageLayoutEXT(tt.device, tt.transitions)
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+}
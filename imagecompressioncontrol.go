@@ -0,0 +1,280 @@
+package vulkan
+
+/*
+#include <vulkan/vulkan.h>
+#include <stdlib.h>
+
+// Function pointers for VK_EXT_image_compression_control functions
+// These need to be loaded dynamically at runtime.
+//
+// IMPORTANT: These are global static pointers and NOT thread-safe during loading.
+// LoadImageCompressionControlFunctions must be called from a single thread during
+// initialization before any concurrent image compression control API usage.
+static PFN_vkGetImageSubresourceLayout2EXT pfn_vkGetImageSubresourceLayout2EXT = NULL;
+
+static int loadImageCompressionControlDeviceFunctions(VkDevice device) {
+    if (device == VK_NULL_HANDLE) {
+        return 0;
+    }
+    pfn_vkGetImageSubresourceLayout2EXT = (PFN_vkGetImageSubresourceLayout2EXT)
+        vkGetDeviceProcAddr(device, "vkGetImageSubresourceLayout2EXT");
+    return pfn_vkGetImageSubresourceLayout2EXT != NULL;
+}
+
+// Wrapper functions return 1 on success, 0 if function pointer is NULL.
+static int call_vkGetImageSubresourceLayout2EXT(
+    VkDevice device,
+    VkImage image,
+    const VkImageSubresource2EXT* pSubresource,
+    VkSubresourceLayout2EXT* pLayout) {
+    if (pfn_vkGetImageSubresourceLayout2EXT == NULL) {
+        return 0;
+    }
+    pfn_vkGetImageSubresourceLayout2EXT(device, image, pSubresource, pLayout);
+    return 1;
+}
+*/
+import "C"
+
+import (
+	"unsafe"
+)
+
+// ExtensionNameImageCompressionControl is the image compression control device extension name
+const ExtensionNameImageCompressionControl = "VK_EXT_image_compression_control"
+
+// maxImageCompressionPlanes is the largest number of planes an image format can have
+const maxImageCompressionPlanes = 3
+
+// ImageCompressionFlags selects the compression an image may use. Exactly one value must be
+// set when requesting compression; ImageCompressionProperties reports one value as well.
+type ImageCompressionFlags uint32
+
+const (
+	// ImageCompressionDefault lets the implementation pick lossless compression
+	ImageCompressionDefault ImageCompressionFlags = C.VK_IMAGE_COMPRESSION_DEFAULT_EXT
+	// ImageCompressionFixedRateDefault allows lossy fixed-rate compression at a rate chosen
+	// by the implementation
+	ImageCompressionFixedRateDefault ImageCompressionFlags = C.VK_IMAGE_COMPRESSION_FIXED_RATE_DEFAULT_EXT
+	// ImageCompressionFixedRateExplicit allows lossy fixed-rate compression at one of the
+	// rates listed per plane in ImageCompressionControlEXT.FixedRateFlags
+	ImageCompressionFixedRateExplicit ImageCompressionFlags = C.VK_IMAGE_COMPRESSION_FIXED_RATE_EXPLICIT_EXT
+	// ImageCompressionDisabled turns off all compression, including lossless compression
+	ImageCompressionDisabled ImageCompressionFlags = C.VK_IMAGE_COMPRESSION_DISABLED_EXT
+)
+
+// ImageCompressionFixedRateFlags lists fixed compression rates in bits per component
+type ImageCompressionFixedRateFlags uint32
+
+const (
+	ImageCompressionFixedRateNone  ImageCompressionFixedRateFlags = C.VK_IMAGE_COMPRESSION_FIXED_RATE_NONE_EXT
+	ImageCompressionFixedRate1BPC  ImageCompressionFixedRateFlags = C.VK_IMAGE_COMPRESSION_FIXED_RATE_1BPC_BIT_EXT
+	ImageCompressionFixedRate2BPC  ImageCompressionFixedRateFlags = C.VK_IMAGE_COMPRESSION_FIXED_RATE_2BPC_BIT_EXT
+	ImageCompressionFixedRate3BPC  ImageCompressionFixedRateFlags = C.VK_IMAGE_COMPRESSION_FIXED_RATE_3BPC_BIT_EXT
+	ImageCompressionFixedRate4BPC  ImageCompressionFixedRateFlags = C.VK_IMAGE_COMPRESSION_FIXED_RATE_4BPC_BIT_EXT
+	ImageCompressionFixedRate5BPC  ImageCompressionFixedRateFlags = C.VK_IMAGE_COMPRESSION_FIXED_RATE_5BPC_BIT_EXT
+	ImageCompressionFixedRate6BPC  ImageCompressionFixedRateFlags = C.VK_IMAGE_COMPRESSION_FIXED_RATE_6BPC_BIT_EXT
+	ImageCompressionFixedRate7BPC  ImageCompressionFixedRateFlags = C.VK_IMAGE_COMPRESSION_FIXED_RATE_7BPC_BIT_EXT
+	ImageCompressionFixedRate8BPC  ImageCompressionFixedRateFlags = C.VK_IMAGE_COMPRESSION_FIXED_RATE_8BPC_BIT_EXT
+	ImageCompressionFixedRate9BPC  ImageCompressionFixedRateFlags = C.VK_IMAGE_COMPRESSION_FIXED_RATE_9BPC_BIT_EXT
+	ImageCompressionFixedRate10BPC ImageCompressionFixedRateFlags = C.VK_IMAGE_COMPRESSION_FIXED_RATE_10BPC_BIT_EXT
+	ImageCompressionFixedRate11BPC ImageCompressionFixedRateFlags = C.VK_IMAGE_COMPRESSION_FIXED_RATE_11BPC_BIT_EXT
+	ImageCompressionFixedRate12BPC ImageCompressionFixedRateFlags = C.VK_IMAGE_COMPRESSION_FIXED_RATE_12BPC_BIT_EXT
+	ImageCompressionFixedRate13BPC ImageCompressionFixedRateFlags = C.VK_IMAGE_COMPRESSION_FIXED_RATE_13BPC_BIT_EXT
+	ImageCompressionFixedRate14BPC ImageCompressionFixedRateFlags = C.VK_IMAGE_COMPRESSION_FIXED_RATE_14BPC_BIT_EXT
+	ImageCompressionFixedRate15BPC ImageCompressionFixedRateFlags = C.VK_IMAGE_COMPRESSION_FIXED_RATE_15BPC_BIT_EXT
+	ImageCompressionFixedRate16BPC ImageCompressionFixedRateFlags = C.VK_IMAGE_COMPRESSION_FIXED_RATE_16BPC_BIT_EXT
+	ImageCompressionFixedRate17BPC ImageCompressionFixedRateFlags = C.VK_IMAGE_COMPRESSION_FIXED_RATE_17BPC_BIT_EXT
+	ImageCompressionFixedRate18BPC ImageCompressionFixedRateFlags = C.VK_IMAGE_COMPRESSION_FIXED_RATE_18BPC_BIT_EXT
+	ImageCompressionFixedRate19BPC ImageCompressionFixedRateFlags = C.VK_IMAGE_COMPRESSION_FIXED_RATE_19BPC_BIT_EXT
+	ImageCompressionFixedRate20BPC ImageCompressionFixedRateFlags = C.VK_IMAGE_COMPRESSION_FIXED_RATE_20BPC_BIT_EXT
+	ImageCompressionFixedRate21BPC ImageCompressionFixedRateFlags = C.VK_IMAGE_COMPRESSION_FIXED_RATE_21BPC_BIT_EXT
+	ImageCompressionFixedRate22BPC ImageCompressionFixedRateFlags = C.VK_IMAGE_COMPRESSION_FIXED_RATE_22BPC_BIT_EXT
+	ImageCompressionFixedRate23BPC ImageCompressionFixedRateFlags = C.VK_IMAGE_COMPRESSION_FIXED_RATE_23BPC_BIT_EXT
+	ImageCompressionFixedRate24BPC ImageCompressionFixedRateFlags = C.VK_IMAGE_COMPRESSION_FIXED_RATE_24BPC_BIT_EXT
+)
+
+// ImageCompressionControlEXT requests the compression of an image, or of the image a format
+// query describes
+type ImageCompressionControlEXT struct {
+	// Flags must be exactly one of the ImageCompression values
+	Flags ImageCompressionFlags
+	// FixedRateFlags lists the allowed fixed rates for each plane of the format when Flags
+	// is ImageCompressionFixedRateExplicit, and must be empty otherwise. The implementation
+	// picks the lowest supported rate in each entry.
+	FixedRateFlags []ImageCompressionFixedRateFlags
+}
+
+// ImageCompressionProperties reports the compression an image got, or would get
+type ImageCompressionProperties struct {
+	Flags ImageCompressionFlags
+	// FixedRateFlags holds the fixed rate in use, or ImageCompressionFixedRateNone when the
+	// image is not fixed-rate compressed
+	FixedRateFlags ImageCompressionFixedRateFlags
+}
+
+// PhysicalDeviceImageCompressionControlFeatures contains the image compression control feature
+type PhysicalDeviceImageCompressionControlFeatures struct {
+	// ImageCompressionControl allows ImageCompressionControlEXT to be chained to image
+	// creation and format queries
+	ImageCompressionControl bool
+}
+
+// LoadImageCompressionControlFunctions loads VK_EXT_image_compression_control functions for
+// a device.
+//
+// This function MUST be called after creating a logical device with the
+// VK_EXT_image_compression_control extension enabled and before calling
+// GetImageSubresourceLayout2EXT.
+//
+// IMPORTANT: This function is NOT thread-safe. Only one device is supported at a time;
+// calling this function again will overwrite previously loaded function pointers.
+//
+// Returns false if any image compression control function could not be loaded.
+func LoadImageCompressionControlFunctions(device Device) bool {
+	return C.loadImageCompressionControlDeviceFunctions(C.VkDevice(device)) != 0
+}
+
+// validateImageCompressionControl checks a compression request before it is chained.
+// parameter names the field the control was passed in.
+func validateImageCompressionControl(parameter string, control *ImageCompressionControlEXT) error {
+	switch control.Flags {
+	case ImageCompressionDefault, ImageCompressionFixedRateDefault, ImageCompressionDisabled:
+		if len(control.FixedRateFlags) != 0 {
+			return NewValidationError(parameter+".FixedRateFlags", "must be empty unless Flags is ImageCompressionFixedRateExplicit")
+		}
+	case ImageCompressionFixedRateExplicit:
+		if len(control.FixedRateFlags) == 0 || len(control.FixedRateFlags) > maxImageCompressionPlanes {
+			return NewValidationError(parameter+".FixedRateFlags", "must hold one entry per plane of the format when Flags is ImageCompressionFixedRateExplicit")
+		}
+	default:
+		return NewValidationError(parameter+".Flags", "must be exactly one ImageCompression value")
+	}
+	return nil
+}
+
+// imageCompressionControlToC prepends a VkImageCompressionControlEXT to the pNext chain
+// next. The struct and its rate array are allocated in C memory and appended to
+// allocations, which the caller must free.
+func imageCompressionControlToC(operation string, control *ImageCompressionControlEXT, next unsafe.Pointer, allocations *[]unsafe.Pointer) (unsafe.Pointer, error) {
+	cControl := (*C.VkImageCompressionControlEXT)(C.calloc(1, C.sizeof_VkImageCompressionControlEXT))
+	if cControl == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, operation, "failed to allocate memory for image compression control")
+	}
+	*allocations = append(*allocations, unsafe.Pointer(cControl))
+
+	cControl.sType = C.VK_STRUCTURE_TYPE_IMAGE_COMPRESSION_CONTROL_EXT
+	cControl.pNext = next
+	cControl.flags = C.VkImageCompressionFlagsEXT(control.Flags)
+
+	if len(control.FixedRateFlags) > 0 {
+		cRatesPtr := (*C.VkImageCompressionFixedRateFlagsEXT)(C.calloc(C.size_t(len(control.FixedRateFlags)), C.size_t(unsafe.Sizeof(C.VkImageCompressionFixedRateFlagsEXT(0)))))
+		if cRatesPtr == nil {
+			return nil, NewVulkanError(ErrorOutOfHostMemory, operation, "failed to allocate memory for fixed rate flags")
+		}
+		*allocations = append(*allocations, unsafe.Pointer(cRatesPtr))
+		cRates := unsafe.Slice(cRatesPtr, len(control.FixedRateFlags))
+		for i, rate := range control.FixedRateFlags {
+			cRates[i] = C.VkImageCompressionFixedRateFlagsEXT(rate)
+		}
+		cControl.compressionControlPlaneCount = C.uint32_t(len(control.FixedRateFlags))
+		cControl.pFixedRateFlags = cRatesPtr
+	}
+
+	return unsafe.Pointer(cControl), nil
+}
+
+// imageCompressionPropertiesFromC converts a VkImageCompressionPropertiesEXT filled in by a
+// query
+func imageCompressionPropertiesFromC(cProps *C.VkImageCompressionPropertiesEXT) ImageCompressionProperties {
+	return ImageCompressionProperties{
+		Flags:          ImageCompressionFlags(cProps.imageCompressionFlags),
+		FixedRateFlags: ImageCompressionFixedRateFlags(cProps.imageCompressionFixedRateFlags),
+	}
+}
+
+// imageCompressionControlFeaturesToC prepends a struct enabling the image compression
+// control feature to the pNext chain next. The struct is allocated in C memory and appended
+// to allocations, which the caller must free.
+func imageCompressionControlFeaturesToC(features *PhysicalDeviceImageCompressionControlFeatures, next unsafe.Pointer, allocations *[]unsafe.Pointer) (unsafe.Pointer, error) {
+	cCompression := (*C.VkPhysicalDeviceImageCompressionControlFeaturesEXT)(C.calloc(1, C.sizeof_VkPhysicalDeviceImageCompressionControlFeaturesEXT))
+	if cCompression == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "CreateDevice", "failed to allocate memory for image compression control features")
+	}
+	*allocations = append(*allocations, unsafe.Pointer(cCompression))
+
+	cCompression.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_IMAGE_COMPRESSION_CONTROL_FEATURES_EXT
+	cCompression.pNext = next
+	cCompression.imageCompressionControl = boolToVkBool32(features.ImageCompressionControl)
+
+	return unsafe.Pointer(cCompression), nil
+}
+
+// GetPhysicalDeviceImageCompressionControlFeaturesEXT queries image compression control support
+func GetPhysicalDeviceImageCompressionControlFeaturesEXT(physicalDevice PhysicalDevice) PhysicalDeviceImageCompressionControlFeatures {
+	cFeatures2 := (*C.VkPhysicalDeviceFeatures2)(C.calloc(1, C.sizeof_VkPhysicalDeviceFeatures2))
+	cCompression := (*C.VkPhysicalDeviceImageCompressionControlFeaturesEXT)(C.calloc(1, C.sizeof_VkPhysicalDeviceImageCompressionControlFeaturesEXT))
+	defer C.free(unsafe.Pointer(cFeatures2))
+	defer C.free(unsafe.Pointer(cCompression))
+	if cFeatures2 == nil || cCompression == nil {
+		return PhysicalDeviceImageCompressionControlFeatures{}
+	}
+
+	cFeatures2.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_FEATURES_2
+	cFeatures2.pNext = unsafe.Pointer(cCompression)
+	cCompression.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_IMAGE_COMPRESSION_CONTROL_FEATURES_EXT
+
+	C.vkGetPhysicalDeviceFeatures2(C.VkPhysicalDevice(physicalDevice), cFeatures2)
+
+	return PhysicalDeviceImageCompressionControlFeatures{
+		ImageCompressionControl: vkBool32ToBool(cCompression.imageCompressionControl),
+	}
+}
+
+// GetImageSubresourceLayout2EXT returns the memory layout of one subresource of an image
+// together with the compression the image actually got, which may differ from the
+// compression requested through ImageCreateInfo.ImageCompressionControl.
+// Returns an error if LoadImageCompressionControlFunctions was not called.
+func GetImageSubresourceLayout2EXT(device Device, image Image, subresource ImageSubresource) (SubresourceLayout, ImageCompressionProperties, error) {
+	if device == nil {
+		return SubresourceLayout{}, ImageCompressionProperties{}, NewValidationError("device", "cannot be nil")
+	}
+	if image == nil {
+		return SubresourceLayout{}, ImageCompressionProperties{}, NewValidationError("image", "cannot be nil")
+	}
+	if subresource.AspectMask == 0 {
+		return SubresourceLayout{}, ImageCompressionProperties{}, NewValidationError("subresource.AspectMask", "must name at least one aspect")
+	}
+
+	// The compression properties are referenced from the layout struct, so they must live
+	// in C memory
+	cCompression := (*C.VkImageCompressionPropertiesEXT)(C.calloc(1, C.sizeof_VkImageCompressionPropertiesEXT))
+	if cCompression == nil {
+		return SubresourceLayout{}, ImageCompressionProperties{}, NewVulkanError(ErrorOutOfHostMemory, "GetImageSubresourceLayout2EXT", "failed to allocate memory for image compression properties")
+	}
+	defer C.free(unsafe.Pointer(cCompression))
+	cCompression.sType = C.VK_STRUCTURE_TYPE_IMAGE_COMPRESSION_PROPERTIES_EXT
+
+	var cSubresource C.VkImageSubresource2EXT
+	cSubresource.sType = C.VK_STRUCTURE_TYPE_IMAGE_SUBRESOURCE_2_EXT
+	cSubresource.imageSubresource.aspectMask = C.VkImageAspectFlags(subresource.AspectMask)
+	cSubresource.imageSubresource.mipLevel = C.uint32_t(subresource.MipLevel)
+	cSubresource.imageSubresource.arrayLayer = C.uint32_t(subresource.ArrayLayer)
+
+	var cLayout C.VkSubresourceLayout2EXT
+	cLayout.sType = C.VK_STRUCTURE_TYPE_SUBRESOURCE_LAYOUT_2_EXT
+	cLayout.pNext = unsafe.Pointer(cCompression)
+
+	if C.call_vkGetImageSubresourceLayout2EXT(C.VkDevice(device), C.VkImage(image), &cSubresource, &cLayout) == 0 {
+		return SubresourceLayout{}, ImageCompressionProperties{}, NewVulkanError(ErrorExtensionNotPresent, "GetImageSubresourceLayout2EXT", "image compression control extension not loaded - call LoadImageCompressionControlFunctions first")
+	}
+
+	layout := SubresourceLayout{
+		Offset:     DeviceSize(cLayout.subresourceLayout.offset),
+		Size:       DeviceSize(cLayout.subresourceLayout.size),
+		RowPitch:   DeviceSize(cLayout.subresourceLayout.rowPitch),
+		ArrayPitch: DeviceSize(cLayout.subresourceLayout.arrayPitch),
+		DepthPitch: DeviceSize(cLayout.subresourceLayout.depthPitch),
+	}
+	return layout, imageCompressionPropertiesFromC(cCompression), nil
+}
@@ -0,0 +1,123 @@
+//go:build cgo
+
+package vulkan
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestImageCompressionControlValidation tests compression request validation on image
+// creation and format queries
+func TestImageCompressionControlValidation(t *testing.T) {
+	tests := []struct {
+		name       string
+		control    ImageCompressionControlEXT
+		errorParam string
+	}{
+		{
+			name:       "combined flags",
+			control:    ImageCompressionControlEXT{Flags: ImageCompressionFixedRateDefault | ImageCompressionDisabled},
+			errorParam: "ImageCompressionControl.Flags",
+		},
+		{
+			name: "rates without explicit flag",
+			control: ImageCompressionControlEXT{
+				Flags:          ImageCompressionFixedRateDefault,
+				FixedRateFlags: []ImageCompressionFixedRateFlags{ImageCompressionFixedRate2BPC},
+			},
+			errorParam: "ImageCompressionControl.FixedRateFlags",
+		},
+		{
+			name:       "explicit flag without rates",
+			control:    ImageCompressionControlEXT{Flags: ImageCompressionFixedRateExplicit},
+			errorParam: "ImageCompressionControl.FixedRateFlags",
+		},
+		{
+			name: "too many planes",
+			control: ImageCompressionControlEXT{
+				Flags: ImageCompressionFixedRateExplicit,
+				FixedRateFlags: []ImageCompressionFixedRateFlags{
+					ImageCompressionFixedRate2BPC, ImageCompressionFixedRate2BPC,
+					ImageCompressionFixedRate2BPC, ImageCompressionFixedRate2BPC,
+				},
+			},
+			errorParam: "ImageCompressionControl.FixedRateFlags",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			control := tt.control
+
+			_, err := CreateImage(Device(uintptr(0x1234)), &ImageCreateInfo{
+				ImageType:               ImageType2D,
+				Format:                  FormatR8G8B8A8Unorm,
+				ImageCompressionControl: &control,
+			})
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError from CreateImage, got %T: %v", err, err)
+			}
+			if expected := "createInfo." + tt.errorParam; validationErr.Parameter != expected {
+				t.Errorf("Expected error for parameter '%s', got '%s'", expected, validationErr.Parameter)
+			}
+
+			_, err = GetPhysicalDeviceImageFormatProperties2(PhysicalDevice(uintptr(0x5678)), &PhysicalDeviceImageFormatInfo2{
+				Format:                  FormatR8G8B8A8Unorm,
+				Type:                    ImageType2D,
+				ImageCompressionControl: &control,
+			})
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError from GetPhysicalDeviceImageFormatProperties2, got %T: %v", err, err)
+			}
+			if expected := "formatInfo." + tt.errorParam; validationErr.Parameter != expected {
+				t.Errorf("Expected error for parameter '%s', got '%s'", expected, validationErr.Parameter)
+			}
+		})
+	}
+
+	var validationErr *ValidationError
+	if _, err := GetPhysicalDeviceImageFormatProperties2(PhysicalDevice(uintptr(0x5678)), nil); !errors.As(err, &validationErr) || validationErr.Parameter != "formatInfo" {
+		t.Errorf("Expected ValidationError for formatInfo, got %v", err)
+	}
+}
+
+// TestGetImageSubresourceLayout2EXTValidation tests argument validation and the
+// unloaded-extension error
+func TestGetImageSubresourceLayout2EXTValidation(t *testing.T) {
+	fakeDevice := Device(uintptr(0x1234))
+	fakeImage := Image(uintptr(0x5678))
+	color := ImageSubresource{AspectMask: ImageAspectColorBit}
+
+	tests := []struct {
+		name        string
+		device      Device
+		image       Image
+		subresource ImageSubresource
+		errorParam  string
+	}{
+		{name: "nil device", device: nil, image: fakeImage, subresource: color, errorParam: "device"},
+		{name: "nil image", device: fakeDevice, image: nil, subresource: color, errorParam: "image"},
+		{name: "no aspect", device: fakeDevice, image: fakeImage, subresource: ImageSubresource{}, errorParam: "subresource.AspectMask"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := GetImageSubresourceLayout2EXT(tt.device, tt.image, tt.subresource)
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+
+	var vulkanErr *VulkanError
+	if _, _, err := GetImageSubresourceLayout2EXT(fakeDevice, fakeImage, color); !errors.As(err, &vulkanErr) || vulkanErr.Result != ErrorExtensionNotPresent {
+		t.Errorf("Expected ErrorExtensionNotPresent, got %v", err)
+	}
+}
@@ -0,0 +1,131 @@
+//go:build cgo
+
+package vulkan
+
+import (
+	"sync"
+)
+
+// access2WriteMask holds the access bits that write memory. Accesses without them can share
+// an image without a barrier between them.
+const access2WriteMask = Access2ShaderWrite | Access2ColorAttachmentWrite | Access2DepthStencilAttachmentWrite |
+	Access2TransferWrite | Access2HostWrite | Access2MemoryWrite | Access2ShaderStorageWrite
+
+// imageStateEntry is the last known use of a tracked image
+type imageStateEntry struct {
+	layout ImageLayout
+	aspect ImageAspectFlags
+	stage  PipelineStageFlags2
+	access AccessFlags2
+}
+
+// ImageState tracks the current layout of images and records the barriers needed to move them
+// between uses. It is opt-in: images are only tracked after Track is called.
+//
+// Layouts are tracked in recording order, so command buffers that use a tracked image must be
+// submitted in the order they were recorded. An ImageState is safe for concurrent use.
+type ImageState struct {
+	mu     sync.Mutex
+	images map[Image]imageStateEntry
+}
+
+// NewImageState creates an empty image layout tracker
+func NewImageState() *ImageState {
+	return &ImageState{images: make(map[Image]imageStateEntry)}
+}
+
+// Track starts tracking image, which is currently in layout. Pass ImageLayoutUndefined for a
+// newly created image, or to discard the contents of an image whose layout is unknown, such
+// as a freshly acquired swapchain image. format selects the aspects transitioned.
+func (s *ImageState) Track(image Image, format Format, layout ImageLayout) error {
+	if image == nil {
+		return NewValidationError("image", "cannot be nil")
+	}
+	stage, access := imageLayoutBarrierScope(layout)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.images[image] = imageStateEntry{
+		layout: layout,
+		aspect: AspectMaskForFormat(format),
+		stage:  PipelineStageFlags2(stage),
+		access: AccessFlags2(access),
+	}
+	return nil
+}
+
+// Forget stops tracking image. Call it before destroying the image.
+func (s *ImageState) Forget(image Image) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.images, image)
+}
+
+// Layout returns the tracked layout of image and whether the image is tracked
+func (s *ImageState) Layout(image Image) (ImageLayout, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.images[image]
+	return entry.layout, ok
+}
+
+// EnsureLayout prepares a tracked image for use in newLayout by dstStage with dstAccess. It
+// records a CmdPipelineBarrier2 image barrier only when one is needed: when the layout
+// changes, or when either the previous or the new use writes the image. Consecutive
+// read-only uses in the same layout record nothing. Like CmdPipelineBarrier2, it requires the
+// synchronization2 feature.
+func (s *ImageState) EnsureLayout(commandBuffer CommandBuffer, image Image, newLayout ImageLayout, dstStage PipelineStageFlags2, dstAccess AccessFlags2) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+	if image == nil {
+		return NewValidationError("image", "cannot be nil")
+	}
+	if newLayout == ImageLayoutUndefined || newLayout == ImageLayoutPreinitialized {
+		return NewValidationError("newLayout", "cannot be ImageLayoutUndefined or ImageLayoutPreinitialized")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.images[image]
+	if !ok {
+		return NewValidationError("image", "is not tracked - call Track first")
+	}
+
+	if entry.layout == newLayout && entry.access&access2WriteMask == 0 && dstAccess&access2WriteMask == 0 {
+		// Read after read: a later writer has to wait for every reader, so widen the scope
+		entry.stage |= dstStage
+		entry.access |= dstAccess
+		s.images[image] = entry
+		return nil
+	}
+
+	err := CmdPipelineBarrier2(commandBuffer, &DependencyInfo{
+		ImageMemoryBarriers: []ImageMemoryBarrier2{{
+			SrcStageMask:  entry.stage,
+			SrcAccessMask: entry.access,
+			DstStageMask:  dstStage,
+			DstAccessMask: dstAccess,
+			OldLayout:     entry.layout,
+			NewLayout:     newLayout,
+			Image:         image,
+			SubresourceRange: ImageSubresourceRange{
+				AspectMask: entry.aspect,
+				LevelCount: RemainingMipLevels,
+				LayerCount: RemainingArrayLayers,
+			},
+		}},
+	})
+	if err != nil {
+		return err
+	}
+
+	s.images[image] = imageStateEntry{
+		layout: newLayout,
+		aspect: entry.aspect,
+		stage:  dstStage,
+		access: dstAccess,
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+//go:build cgo
+
+package vulkan
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestImageStateValidation tests argument validation of the image layout tracker
+func TestImageStateValidation(t *testing.T) {
+	fakeCommandBuffer := CommandBuffer(uintptr(0x1234))
+	fakeImage := Image(uintptr(0x5678))
+	state := NewImageState()
+	if err := state.Track(fakeImage, FormatR8G8B8A8Unorm, ImageLayoutUndefined); err != nil {
+		t.Fatalf("Track failed: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		call       func() error
+		errorParam string
+	}{
+		{
+			name:       "track nil image",
+			call:       func() error { return state.Track(nil, FormatR8G8B8A8Unorm, ImageLayoutUndefined) },
+			errorParam: "image",
+		},
+		{
+			name: "nil command buffer",
+			call: func() error {
+				return state.EnsureLayout(nil, fakeImage, ImageLayoutTransferDstOptimal, PipelineStage2Copy, Access2TransferWrite)
+			},
+			errorParam: "commandBuffer",
+		},
+		{
+			name: "undefined target layout",
+			call: func() error {
+				return state.EnsureLayout(fakeCommandBuffer, fakeImage, ImageLayoutUndefined, PipelineStage2Copy, Access2TransferWrite)
+			},
+			errorParam: "newLayout",
+		},
+		{
+			name: "untracked image",
+			call: func() error {
+				return state.EnsureLayout(fakeCommandBuffer, Image(uintptr(0x9abc)), ImageLayoutTransferDstOptimal, PipelineStage2Copy, Access2TransferWrite)
+			},
+			errorParam: "image",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+}
+
+// TestImageStateReadAfterRead tests that read-only uses in the tracked layout record no
+// barrier and widen the scope a later writer waits on
+func TestImageStateReadAfterRead(t *testing.T) {
+	fakeImage := Image(uintptr(0x5678))
+	state := NewImageState()
+	if err := state.Track(fakeImage, FormatR8G8B8A8Unorm, ImageLayoutShaderReadOnlyOptimal); err != nil {
+		t.Fatalf("Track failed: %v", err)
+	}
+
+	// A barrier would be recorded into this fake command buffer and crash, so success proves
+	// the barrier was skipped
+	err := state.EnsureLayout(CommandBuffer(uintptr(0x1234)), fakeImage, ImageLayoutShaderReadOnlyOptimal, PipelineStage2ComputeShader, Access2ShaderSampledRead)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	entry := state.images[fakeImage]
+	if entry.stage&PipelineStage2ComputeShader == 0 || entry.access&Access2ShaderSampledRead == 0 {
+		t.Errorf("Expected the read scope to include the compute read, got stage %#x access %#x", entry.stage, entry.access)
+	}
+	if layout, ok := state.Layout(fakeImage); !ok || layout != ImageLayoutShaderReadOnlyOptimal {
+		t.Errorf("Expected tracked layout %v, got %v (tracked %v)", ImageLayoutShaderReadOnlyOptimal, layout, ok)
+	}
+
+	state.Forget(fakeImage)
+	if _, ok := state.Layout(fakeImage); ok {
+		t.Error("Expected image to be untracked after Forget")
+	}
+}
@@ -26,10 +26,23 @@ void freeCharArray(char **a, int size) {
     }
     free(a);
 }
+
+// vkEnumerateInstanceVersion was added in Vulkan 1.1, so a 1.0 loader does not export it.
+// Returns 0 if the function is missing, which means the loader only supports Vulkan 1.0.
+static int enumerateInstanceVersion(uint32_t* pApiVersion, VkResult* pResult) {
+    PFN_vkEnumerateInstanceVersion fn = (PFN_vkEnumerateInstanceVersion)
+        vkGetInstanceProcAddr(VK_NULL_HANDLE, "vkEnumerateInstanceVersion");
+    if (fn == NULL) {
+        return 0;
+    }
+    *pResult = fn(pApiVersion);
+    return 1;
+}
 */
 import "C"
 
 import (
+	"math/bits"
 	"unsafe"
 )
 
@@ -42,11 +55,28 @@ type ApplicationInfo struct {
 	APIVersion         Version
 }
 
+// Instance extension name constants
+const (
+	ExtensionNamePortabilityEnumeration = "VK_KHR_portability_enumeration"
+)
+
+// InstanceCreateFlags represents instance creation flags
+type InstanceCreateFlags uint32
+
+const (
+	// InstanceCreateEnumeratePortabilityBitKHR must be set (together with the
+	// VK_KHR_portability_enumeration extension) to create an instance on MoltenVK
+	InstanceCreateEnumeratePortabilityBitKHR InstanceCreateFlags = C.VK_INSTANCE_CREATE_ENUMERATE_PORTABILITY_BIT_KHR
+)
+
 // InstanceCreateInfo contains instance creation information
 type InstanceCreateInfo struct {
+	Flags                 InstanceCreateFlags
 	ApplicationInfo       *ApplicationInfo
 	EnabledLayerNames     []string
 	EnabledExtensionNames []string
+	// ValidationFeatures enables or disables validation layer checks when set
+	ValidationFeatures *ValidationFeatures
 }
 
 // ExtensionProperties contains extension information
@@ -206,6 +236,65 @@ type PhysicalDeviceSparseProperties struct {
 	ResidencyNonResidentStrict               Bool32
 }
 
+// PhysicalDeviceProperties2 contains physical device properties together with the
+// identification, subgroup and driver properties added in Vulkan 1.1 and 1.2
+type PhysicalDeviceProperties2 struct {
+	Properties                  PhysicalDeviceProperties
+	DeviceUUID                  [UuidSize]byte
+	DriverUUID                  [UuidSize]byte
+	DeviceLUID                  [LuidSize]byte
+	DeviceLUIDValid             bool
+	SubgroupSize                uint32
+	SubgroupSupportedStages     ShaderStageFlags
+	SubgroupSupportedOperations SubgroupFeatureFlags
+	// Driver properties are only reported by Vulkan 1.2+ devices
+	DriverID           DriverID
+	DriverName         string
+	DriverInfo         string
+	ConformanceVersion ConformanceVersion
+}
+
+// SubgroupFeatureFlags represents supported subgroup operations
+type SubgroupFeatureFlags uint32
+
+const (
+	SubgroupFeatureBasicBit           SubgroupFeatureFlags = C.VK_SUBGROUP_FEATURE_BASIC_BIT
+	SubgroupFeatureVoteBit            SubgroupFeatureFlags = C.VK_SUBGROUP_FEATURE_VOTE_BIT
+	SubgroupFeatureArithmeticBit      SubgroupFeatureFlags = C.VK_SUBGROUP_FEATURE_ARITHMETIC_BIT
+	SubgroupFeatureBallotBit          SubgroupFeatureFlags = C.VK_SUBGROUP_FEATURE_BALLOT_BIT
+	SubgroupFeatureShuffleBit         SubgroupFeatureFlags = C.VK_SUBGROUP_FEATURE_SHUFFLE_BIT
+	SubgroupFeatureShuffleRelativeBit SubgroupFeatureFlags = C.VK_SUBGROUP_FEATURE_SHUFFLE_RELATIVE_BIT
+	SubgroupFeatureClusteredBit       SubgroupFeatureFlags = C.VK_SUBGROUP_FEATURE_CLUSTERED_BIT
+	SubgroupFeatureQuadBit            SubgroupFeatureFlags = C.VK_SUBGROUP_FEATURE_QUAD_BIT
+)
+
+// DriverID identifies the driver implementation
+type DriverID int32
+
+const (
+	DriverIDAMDProprietary          DriverID = C.VK_DRIVER_ID_AMD_PROPRIETARY
+	DriverIDAMDOpenSource           DriverID = C.VK_DRIVER_ID_AMD_OPEN_SOURCE
+	DriverIDMesaRADV                DriverID = C.VK_DRIVER_ID_MESA_RADV
+	DriverIDNvidiaProprietary       DriverID = C.VK_DRIVER_ID_NVIDIA_PROPRIETARY
+	DriverIDIntelProprietaryWindows DriverID = C.VK_DRIVER_ID_INTEL_PROPRIETARY_WINDOWS
+	DriverIDIntelOpenSourceMesa     DriverID = C.VK_DRIVER_ID_INTEL_OPEN_SOURCE_MESA
+	DriverIDImaginationProprietary  DriverID = C.VK_DRIVER_ID_IMAGINATION_PROPRIETARY
+	DriverIDQualcommProprietary     DriverID = C.VK_DRIVER_ID_QUALCOMM_PROPRIETARY
+	DriverIDARMProprietary          DriverID = C.VK_DRIVER_ID_ARM_PROPRIETARY
+	DriverIDMesaLLVMpipe            DriverID = C.VK_DRIVER_ID_MESA_LLVMPIPE
+	DriverIDMoltenVK                DriverID = C.VK_DRIVER_ID_MOLTENVK
+	DriverIDMesaTurnip              DriverID = C.VK_DRIVER_ID_MESA_TURNIP
+	DriverIDMesaNVK                 DriverID = C.VK_DRIVER_ID_MESA_NVK
+)
+
+// ConformanceVersion is the version of the Vulkan conformance test suite a driver passed
+type ConformanceVersion struct {
+	Major    uint8
+	Minor    uint8
+	Subminor uint8
+	Patch    uint8
+}
+
 // QueueFamilyProperties contains queue family properties
 type QueueFamilyProperties struct {
 	QueueFlags                  QueueFlags
@@ -312,10 +401,27 @@ func CreateInstance(createInfo *InstanceCreateInfo) (Instance, error) {
 		}
 	}
 
+	if createInfo.ValidationFeatures != nil {
+		if err := validateValidationFeatures(createInfo.ValidationFeatures); err != nil {
+			return nil, err
+		}
+	}
+
 	var cCreateInfo C.VkInstanceCreateInfo
 	cCreateInfo.sType = C.VK_STRUCTURE_TYPE_INSTANCE_CREATE_INFO
 	cCreateInfo.pNext = nil
-	cCreateInfo.flags = 0
+	cCreateInfo.flags = C.VkInstanceCreateFlags(createInfo.Flags)
+
+	var allocations []unsafe.Pointer
+	defer func() { freeAllocations(allocations) }()
+
+	if createInfo.ValidationFeatures != nil {
+		pNext, err := validationFeaturesToC(createInfo.ValidationFeatures, nil, &allocations)
+		if err != nil {
+			return nil, err
+		}
+		cCreateInfo.pNext = pNext
+	}
 
 	// Application info - allocate on heap to avoid Go pointer issues
 	var cAppInfo *C.VkApplicationInfo
@@ -404,6 +510,7 @@ func CreateInstance(createInfo *InstanceCreateInfo) (Instance, error) {
 
 	var instance C.VkInstance
 	result := Result(C.vkCreateInstance(&cCreateInfo, nil, &instance))
+	traceResult("vkCreateInstance", result)
 
 	// Clean up memory
 	if appNamePtr != nil {
@@ -434,6 +541,51 @@ func DestroyInstance(instance Instance) {
 	C.vkDestroyInstance(C.VkInstance(instance), nil)
 }
 
+// GetInstanceProcAddr returns the address of an instance-level Vulkan command, or nil if the
+// command is not available. Pass a nil instance to look up global commands such as
+// vkEnumerateInstanceVersion.
+//
+// This is an escape hatch for extensions the package does not wrap. The result is a C
+// function pointer: Go cannot call it directly, so convert it to the matching PFN_vk* type
+// and call it from a C helper in your own cgo preamble. Any structs passed to it must follow
+// the cgo pointer passing rules (nested pointers must refer to C memory or pinned Go memory).
+func GetInstanceProcAddr(instance Instance, name string) unsafe.Pointer {
+	if name == "" {
+		return nil
+	}
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	return unsafe.Pointer(C.vkGetInstanceProcAddr(C.VkInstance(instance), cName))
+}
+
+// GetDeviceProcAddr returns the address of a device-level Vulkan command, or nil if the
+// command is not available on the device. Device-level pointers skip the loader dispatch and
+// are the fastest way to call device commands. The same cgo caveats as GetInstanceProcAddr apply.
+func GetDeviceProcAddr(device Device, name string) unsafe.Pointer {
+	if device == nil || name == "" {
+		return nil
+	}
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	return unsafe.Pointer(C.vkGetDeviceProcAddr(C.VkDevice(device), cName))
+}
+
+// EnumerateInstanceVersion returns the highest Vulkan version the loader supports for instances.
+// Requesting a higher ApplicationInfo.APIVersion makes CreateInstance fail on 1.0 loaders, so
+// clamp the requested version to this first. Version10 is returned for loaders that predate
+// the query.
+func EnumerateInstanceVersion() (Version, error) {
+	var apiVersion C.uint32_t
+	var result C.VkResult
+	if C.enumerateInstanceVersion(&apiVersion, &result) == 0 {
+		return Version10, nil
+	}
+	if Result(result) != Success {
+		return 0, NewVulkanError(Result(result), "EnumerateInstanceVersion", "failed to query instance version")
+	}
+	return Version(apiVersion), nil
+}
+
 // EnumerateInstanceExtensionProperties enumerates available instance extensions
 func EnumerateInstanceExtensionProperties(layerName string) ([]ExtensionProperties, error) {
 	var cLayerName *C.char
@@ -444,8 +596,9 @@ func EnumerateInstanceExtensionProperties(layerName string) ([]ExtensionProperti
 
 	var propertyCount C.uint32_t
 	result := Result(C.vkEnumerateInstanceExtensionProperties(cLayerName, &propertyCount, nil))
+	traceResult("vkEnumerateInstanceExtensionProperties", result)
 	if result != Success {
-		return nil, result
+		return nil, NewVulkanError(result, "EnumerateInstanceExtensionProperties", "failed to get extension count")
 	}
 
 	if propertyCount == 0 {
@@ -454,8 +607,9 @@ func EnumerateInstanceExtensionProperties(layerName string) ([]ExtensionProperti
 
 	cProperties := make([]C.VkExtensionProperties, propertyCount)
 	result = Result(C.vkEnumerateInstanceExtensionProperties(cLayerName, &propertyCount, &cProperties[0]))
+	traceResult("vkEnumerateInstanceExtensionProperties", result)
 	if result != Success {
-		return nil, result
+		return nil, NewVulkanError(result, "EnumerateInstanceExtensionProperties", "failed to enumerate extensions")
 	}
 
 	properties := make([]ExtensionProperties, propertyCount)
@@ -471,8 +625,9 @@ func EnumerateInstanceExtensionProperties(layerName string) ([]ExtensionProperti
 func EnumerateInstanceLayerProperties() ([]LayerProperties, error) {
 	var propertyCount C.uint32_t
 	result := Result(C.vkEnumerateInstanceLayerProperties(&propertyCount, nil))
+	traceResult("vkEnumerateInstanceLayerProperties", result)
 	if result != Success {
-		return nil, result
+		return nil, NewVulkanError(result, "EnumerateInstanceLayerProperties", "failed to get layer count")
 	}
 
 	if propertyCount == 0 {
@@ -481,8 +636,9 @@ func EnumerateInstanceLayerProperties() ([]LayerProperties, error) {
 
 	cProperties := make([]C.VkLayerProperties, propertyCount)
 	result = Result(C.vkEnumerateInstanceLayerProperties(&propertyCount, &cProperties[0]))
+	traceResult("vkEnumerateInstanceLayerProperties", result)
 	if result != Success {
-		return nil, result
+		return nil, NewVulkanError(result, "EnumerateInstanceLayerProperties", "failed to enumerate layers")
 	}
 
 	properties := make([]LayerProperties, propertyCount)
@@ -500,8 +656,9 @@ func EnumerateInstanceLayerProperties() ([]LayerProperties, error) {
 func EnumeratePhysicalDevices(instance Instance) ([]PhysicalDevice, error) {
 	var deviceCount C.uint32_t
 	result := Result(C.vkEnumeratePhysicalDevices(C.VkInstance(instance), &deviceCount, nil))
+	traceResult("vkEnumeratePhysicalDevices", result)
 	if result != Success {
-		return nil, result
+		return nil, NewVulkanError(result, "EnumeratePhysicalDevices", "failed to get physical device count")
 	}
 
 	if deviceCount == 0 {
@@ -510,8 +667,9 @@ func EnumeratePhysicalDevices(instance Instance) ([]PhysicalDevice, error) {
 
 	cDevices := make([]C.VkPhysicalDevice, deviceCount)
 	result = Result(C.vkEnumeratePhysicalDevices(C.VkInstance(instance), &deviceCount, &cDevices[0]))
+	traceResult("vkEnumeratePhysicalDevices", result)
 	if result != Success {
-		return nil, result
+		return nil, NewVulkanError(result, "EnumeratePhysicalDevices", "failed to enumerate physical devices")
 	}
 
 	devices := make([]PhysicalDevice, deviceCount)
@@ -527,6 +685,28 @@ func GetPhysicalDeviceProperties(physicalDevice PhysicalDevice) PhysicalDevicePr
 	var cProperties C.VkPhysicalDeviceProperties
 	C.vkGetPhysicalDeviceProperties(C.VkPhysicalDevice(physicalDevice), &cProperties)
 
+	return physicalDevicePropertiesFromC(&cProperties)
+}
+
+// MaxUsableSampleCount returns the highest sample count supported by both color and depth
+// framebuffer attachments, which is the MSAA level to use for a color+depth render target.
+// It returns SampleCount1Bit when no multisampled count is shared.
+func (l PhysicalDeviceLimits) MaxUsableSampleCount() SampleCountFlags {
+	counts := uint32(l.FramebufferColorSampleCounts & l.FramebufferDepthSampleCounts)
+	if counts == 0 {
+		return SampleCount1Bit
+	}
+	return SampleCountFlags(1 << (bits.Len32(counts) - 1))
+}
+
+// GetMaxUsableSampleCount returns the highest MSAA sample count usable with both color and
+// depth attachments on physicalDevice
+func GetMaxUsableSampleCount(physicalDevice PhysicalDevice) SampleCountFlags {
+	return GetPhysicalDeviceProperties(physicalDevice).Limits.MaxUsableSampleCount()
+}
+
+// physicalDevicePropertiesFromC converts C physical device properties
+func physicalDevicePropertiesFromC(cProperties *C.VkPhysicalDeviceProperties) PhysicalDeviceProperties {
 	properties := PhysicalDeviceProperties{
 		APIVersion:    Version(cProperties.apiVersion),
 		DriverVersion: Version(cProperties.driverVersion),
@@ -669,6 +849,141 @@ func GetPhysicalDeviceProperties(physicalDevice PhysicalDevice) PhysicalDevicePr
 	return properties
 }
 
+// GetPhysicalDeviceProperties2 gets physical device properties including device/driver UUIDs,
+// subgroup properties and, on Vulkan 1.2+ devices, driver identification
+func GetPhysicalDeviceProperties2(physicalDevice PhysicalDevice) (*PhysicalDeviceProperties2, error) {
+	if physicalDevice == nil {
+		return nil, NewValidationError("physicalDevice", "cannot be nil")
+	}
+
+	apiVersion := GetPhysicalDeviceProperties(physicalDevice).APIVersion
+	if apiVersion < Version11 {
+		return nil, NewVulkanError(ErrorFeatureNotPresent, "GetPhysicalDeviceProperties2", "requires a Vulkan 1.1 device")
+	}
+
+	// The chain must live in C memory since it links structs by pointer
+	cProps2 := (*C.VkPhysicalDeviceProperties2)(C.calloc(1, C.sizeof_VkPhysicalDeviceProperties2))
+	cID := (*C.VkPhysicalDeviceIDProperties)(C.calloc(1, C.sizeof_VkPhysicalDeviceIDProperties))
+	cSubgroup := (*C.VkPhysicalDeviceSubgroupProperties)(C.calloc(1, C.sizeof_VkPhysicalDeviceSubgroupProperties))
+	cDriver := (*C.VkPhysicalDeviceDriverProperties)(C.calloc(1, C.sizeof_VkPhysicalDeviceDriverProperties))
+	defer C.free(unsafe.Pointer(cProps2))
+	defer C.free(unsafe.Pointer(cID))
+	defer C.free(unsafe.Pointer(cSubgroup))
+	defer C.free(unsafe.Pointer(cDriver))
+	if cProps2 == nil || cID == nil || cSubgroup == nil || cDriver == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "GetPhysicalDeviceProperties2", "failed to allocate memory for properties")
+	}
+
+	cProps2.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_PROPERTIES_2
+	cProps2.pNext = unsafe.Pointer(cID)
+	cID.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_ID_PROPERTIES
+	cID.pNext = unsafe.Pointer(cSubgroup)
+	cSubgroup.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_SUBGROUP_PROPERTIES
+	if apiVersion >= Version12 {
+		cSubgroup.pNext = unsafe.Pointer(cDriver)
+		cDriver.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_DRIVER_PROPERTIES
+	}
+
+	C.vkGetPhysicalDeviceProperties2(C.VkPhysicalDevice(physicalDevice), cProps2)
+
+	properties := &PhysicalDeviceProperties2{
+		Properties:                  physicalDevicePropertiesFromC(&cProps2.properties),
+		DeviceLUIDValid:             cID.deviceLUIDValid == C.VK_TRUE,
+		SubgroupSize:                uint32(cSubgroup.subgroupSize),
+		SubgroupSupportedStages:     ShaderStageFlags(cSubgroup.supportedStages),
+		SubgroupSupportedOperations: SubgroupFeatureFlags(cSubgroup.supportedOperations),
+	}
+	for i := 0; i < UuidSize; i++ {
+		properties.DeviceUUID[i] = byte(cID.deviceUUID[i])
+		properties.DriverUUID[i] = byte(cID.driverUUID[i])
+	}
+	for i := 0; i < LuidSize; i++ {
+		properties.DeviceLUID[i] = byte(cID.deviceLUID[i])
+	}
+
+	if apiVersion >= Version12 {
+		properties.DriverID = DriverID(cDriver.driverID)
+		properties.DriverName = C.GoString(&cDriver.driverName[0])
+		properties.DriverInfo = C.GoString(&cDriver.driverInfo[0])
+		properties.ConformanceVersion = ConformanceVersion{
+			Major:    uint8(cDriver.conformanceVersion.major),
+			Minor:    uint8(cDriver.conformanceVersion.minor),
+			Subminor: uint8(cDriver.conformanceVersion.subminor),
+			Patch:    uint8(cDriver.conformanceVersion.patch),
+		}
+	}
+
+	return properties, nil
+}
+
+// ToolPurposeFlags describes what an active tool does (Vulkan 1.3)
+type ToolPurposeFlags uint32
+
+const (
+	ToolPurposeValidationBit         ToolPurposeFlags = C.VK_TOOL_PURPOSE_VALIDATION_BIT
+	ToolPurposeProfilingBit          ToolPurposeFlags = C.VK_TOOL_PURPOSE_PROFILING_BIT
+	ToolPurposeTracingBit            ToolPurposeFlags = C.VK_TOOL_PURPOSE_TRACING_BIT
+	ToolPurposeAdditionalFeaturesBit ToolPurposeFlags = C.VK_TOOL_PURPOSE_ADDITIONAL_FEATURES_BIT
+	ToolPurposeModifyingFeaturesBit  ToolPurposeFlags = C.VK_TOOL_PURPOSE_MODIFYING_FEATURES_BIT
+	ToolPurposeDebugReportingBit     ToolPurposeFlags = C.VK_TOOL_PURPOSE_DEBUG_REPORTING_BIT_EXT
+	ToolPurposeDebugMarkersBit       ToolPurposeFlags = C.VK_TOOL_PURPOSE_DEBUG_MARKERS_BIT_EXT
+)
+
+// PhysicalDeviceToolProperties describes a tool, such as a capture tool, profiler or
+// validation layer, that is active on a physical device
+type PhysicalDeviceToolProperties struct {
+	Name        string
+	Version     string
+	Purposes    ToolPurposeFlags
+	Description string
+	// Layer is the name of the layer implementing the tool, or empty if it is not a layer
+	Layer string
+}
+
+// GetPhysicalDeviceToolProperties lists the tools active on a physical device. Use it to
+// detect when a tool such as RenderDoc or the validation layers may be affecting
+// performance. Requires a Vulkan 1.3 device.
+func GetPhysicalDeviceToolProperties(physicalDevice PhysicalDevice) ([]PhysicalDeviceToolProperties, error) {
+	if physicalDevice == nil {
+		return nil, NewValidationError("physicalDevice", "cannot be nil")
+	}
+	if GetPhysicalDeviceProperties(physicalDevice).APIVersion < Version13 {
+		return nil, NewVulkanError(ErrorFeatureNotPresent, "GetPhysicalDeviceToolProperties", "requires a Vulkan 1.3 device")
+	}
+
+	var toolCount C.uint32_t
+	result := Result(C.vkGetPhysicalDeviceToolProperties(C.VkPhysicalDevice(physicalDevice), &toolCount, nil))
+	traceResult("vkGetPhysicalDeviceToolProperties", result)
+	if result != Success {
+		return nil, NewVulkanError(result, "GetPhysicalDeviceToolProperties", "failed to get tool count")
+	}
+
+	if toolCount == 0 {
+		return nil, nil
+	}
+
+	cProperties := make([]C.VkPhysicalDeviceToolProperties, toolCount)
+	for i := range cProperties {
+		cProperties[i].sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_TOOL_PROPERTIES
+	}
+	result = Result(C.vkGetPhysicalDeviceToolProperties(C.VkPhysicalDevice(physicalDevice), &toolCount, &cProperties[0]))
+	traceResult("vkGetPhysicalDeviceToolProperties", result)
+	if result != Success && result != Incomplete {
+		return nil, NewVulkanError(result, "GetPhysicalDeviceToolProperties", "failed to get tool properties")
+	}
+
+	properties := make([]PhysicalDeviceToolProperties, toolCount)
+	for i := range properties {
+		properties[i].Name = C.GoString(&cProperties[i].name[0])
+		properties[i].Version = C.GoString(&cProperties[i].version[0])
+		properties[i].Purposes = ToolPurposeFlags(cProperties[i].purposes)
+		properties[i].Description = C.GoString(&cProperties[i].description[0])
+		properties[i].Layer = C.GoString(&cProperties[i].layer[0])
+	}
+
+	return properties, nil
+}
+
 // GetPhysicalDeviceQueueFamilyProperties gets queue family properties
 func GetPhysicalDeviceQueueFamilyProperties(physicalDevice PhysicalDevice) []QueueFamilyProperties {
 	var queueFamilyCount C.uint32_t
@@ -697,3 +1012,335 @@ func GetPhysicalDeviceQueueFamilyProperties(physicalDevice PhysicalDevice) []Que
 
 	return properties
 }
+
+// GetPhysicalDeviceSurfaceSupportKHR reports whether a queue family can present to a surface
+func GetPhysicalDeviceSurfaceSupportKHR(physicalDevice PhysicalDevice, queueFamilyIndex uint32, surface Surface) (bool, error) {
+	if physicalDevice == nil {
+		return false, NewValidationError("physicalDevice", "cannot be nil")
+	}
+	if surface == nil {
+		return false, NewValidationError("surface", "cannot be nil")
+	}
+
+	var supported C.VkBool32
+	result := Result(C.vkGetPhysicalDeviceSurfaceSupportKHR(C.VkPhysicalDevice(physicalDevice), C.uint32_t(queueFamilyIndex), C.VkSurfaceKHR(surface), &supported))
+	traceResult("vkGetPhysicalDeviceSurfaceSupportKHR", result)
+	if result != Success {
+		return false, NewVulkanError(result, "GetPhysicalDeviceSurfaceSupportKHR", "failed to query surface support")
+	}
+	return supported == C.VK_TRUE, nil
+}
+
+// QueueFamilyIndices holds the queue families an application typically needs. Each index
+// is only meaningful when the matching Has flag is set.
+type QueueFamilyIndices struct {
+	Graphics    uint32
+	HasGraphics bool
+	Compute     uint32
+	HasCompute  bool
+	Transfer    uint32
+	HasTransfer bool
+	Present     uint32
+	HasPresent  bool
+
+	// presentRequired is set when the indices were found for a surface
+	presentRequired bool
+}
+
+// IsComplete reports whether graphics, compute and transfer families were found, plus a
+// present family if a surface was passed to FindQueueFamilies.
+func (q QueueFamilyIndices) IsComplete() bool {
+	return q.HasGraphics && q.HasCompute && q.HasTransfer && (q.HasPresent || !q.presentRequired)
+}
+
+// UniqueIndices returns the distinct family indices that were found, in the order graphics,
+// compute, transfer, present. Use it to build one DeviceQueueCreateInfo per family.
+func (q QueueFamilyIndices) UniqueIndices() []uint32 {
+	var indices []uint32
+	add := func(index uint32, found bool) {
+		if !found {
+			return
+		}
+		for _, existing := range indices {
+			if existing == index {
+				return
+			}
+		}
+		indices = append(indices, index)
+	}
+
+	add(q.Graphics, q.HasGraphics)
+	add(q.Compute, q.HasCompute)
+	add(q.Transfer, q.HasTransfer)
+	add(q.Present, q.HasPresent)
+	return indices
+}
+
+// FindQueueFamilies picks queue families for graphics, compute, transfer and, if surface is
+// not nil, presentation. Compute and transfer prefer dedicated families so that async work
+// does not compete with the graphics queue, and present prefers the graphics family.
+func FindQueueFamilies(physicalDevice PhysicalDevice, surface Surface) QueueFamilyIndices {
+	families := GetPhysicalDeviceQueueFamilyProperties(physicalDevice)
+
+	var canPresent func(index uint32) bool
+	if surface != nil {
+		canPresent = func(index uint32) bool {
+			supported, err := GetPhysicalDeviceSurfaceSupportKHR(physicalDevice, index, surface)
+			return err == nil && supported
+		}
+	}
+	return findQueueFamilies(families, canPresent)
+}
+
+// findQueueFamilies implements FindQueueFamilies. canPresent is nil when no surface was given.
+func findQueueFamilies(families []QueueFamilyProperties, canPresent func(index uint32) bool) QueueFamilyIndices {
+	var indices QueueFamilyIndices
+
+	find := func(required, excluded QueueFlags) (uint32, bool) {
+		for i, family := range families {
+			if family.QueueCount > 0 && family.QueueFlags&required == required && family.QueueFlags&excluded == 0 {
+				return uint32(i), true
+			}
+		}
+		return 0, false
+	}
+
+	indices.Graphics, indices.HasGraphics = find(QueueGraphicsBit, 0)
+
+	indices.Compute, indices.HasCompute = find(QueueComputeBit, QueueGraphicsBit)
+	if !indices.HasCompute {
+		indices.Compute, indices.HasCompute = find(QueueComputeBit, 0)
+	}
+
+	// Graphics and compute families support transfers even when they don't report the bit
+	indices.Transfer, indices.HasTransfer = find(QueueTransferBit, QueueGraphicsBit|QueueComputeBit)
+	if !indices.HasTransfer {
+		indices.Transfer, indices.HasTransfer = find(QueueTransferBit, 0)
+	}
+	if !indices.HasTransfer {
+		if indices.HasCompute {
+			indices.Transfer, indices.HasTransfer = indices.Compute, true
+		} else if indices.HasGraphics {
+			indices.Transfer, indices.HasTransfer = indices.Graphics, true
+		}
+	}
+
+	if canPresent != nil {
+		indices.presentRequired = true
+		if indices.HasGraphics && canPresent(indices.Graphics) {
+			indices.Present, indices.HasPresent = indices.Graphics, true
+		} else {
+			for i, family := range families {
+				if family.QueueCount > 0 && canPresent(uint32(i)) {
+					indices.Present, indices.HasPresent = uint32(i), true
+					break
+				}
+			}
+		}
+	}
+
+	return indices
+}
+
+// DeviceSelectionOptions lists the requirements and preferences SelectPhysicalDevice uses
+// to pick a GPU
+type DeviceSelectionOptions struct {
+	// RequiredExtensions must all be supported by the device
+	RequiredExtensions []string
+	// PreferredExtensions raise the score of devices that support them but are not required
+	PreferredExtensions []string
+	// RequiredQueueFlags must each be supported by at least one queue family
+	RequiredQueueFlags QueueFlags
+	// Surface, if not nil, requires a queue family that can present to it
+	Surface Surface
+	// MinAPIVersion is the lowest Vulkan version the device must support; 0 accepts any
+	MinAPIVersion Version
+}
+
+// physicalDeviceCandidate holds what SelectPhysicalDevice knows about one device
+type physicalDeviceCandidate struct {
+	properties    PhysicalDeviceProperties
+	extensions    []ExtensionProperties
+	queueFamilies []QueueFamilyProperties
+	canPresent    bool
+}
+
+// SelectPhysicalDevice returns the physical device that best matches opts. Devices missing
+// a requirement are skipped; the rest are ranked by type (discrete, integrated, virtual, CPU)
+// and then by the number of preferred extensions they support, so laptops with both an
+// integrated and a discrete GPU pick the discrete one. Ties keep enumeration order.
+// Returns an error wrapping ErrorFeatureNotPresent if no device meets the requirements.
+func SelectPhysicalDevice(instance Instance, opts DeviceSelectionOptions) (PhysicalDevice, error) {
+	if instance == nil {
+		return nil, NewValidationError("instance", "cannot be nil")
+	}
+
+	physicalDevices, err := EnumeratePhysicalDevices(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	var best PhysicalDevice
+	bestScore := -1
+	for _, physicalDevice := range physicalDevices {
+		extensions, err := EnumerateDeviceExtensionProperties(physicalDevice, "")
+		if err != nil {
+			continue
+		}
+		candidate := physicalDeviceCandidate{
+			properties:    GetPhysicalDeviceProperties(physicalDevice),
+			extensions:    extensions,
+			queueFamilies: GetPhysicalDeviceQueueFamilyProperties(physicalDevice),
+		}
+		if opts.Surface != nil {
+			candidate.canPresent = FindQueueFamilies(physicalDevice, opts.Surface).HasPresent
+		}
+
+		if score, ok := scorePhysicalDevice(&candidate, &opts); ok && score > bestScore {
+			best, bestScore = physicalDevice, score
+		}
+	}
+
+	if best == nil {
+		return nil, NewVulkanError(ErrorFeatureNotPresent, "SelectPhysicalDevice", "no physical device meets the selection requirements")
+	}
+	return best, nil
+}
+
+// scorePhysicalDevice ranks a candidate for SelectPhysicalDevice. It returns false if the
+// candidate misses a requirement.
+func scorePhysicalDevice(candidate *physicalDeviceCandidate, opts *DeviceSelectionOptions) (int, bool) {
+	// Ignore the variant bits so that only major, minor and patch are compared
+	const versionMask = Version(1<<29 - 1)
+	if candidate.properties.APIVersion&versionMask < opts.MinAPIVersion&versionMask {
+		return 0, false
+	}
+	for _, extension := range opts.RequiredExtensions {
+		if !IsExtensionSupported(extension, candidate.extensions) {
+			return 0, false
+		}
+	}
+
+	var supportedFlags QueueFlags
+	for _, family := range candidate.queueFamilies {
+		if family.QueueCount > 0 {
+			supportedFlags |= family.QueueFlags
+		}
+	}
+	if supportedFlags&opts.RequiredQueueFlags != opts.RequiredQueueFlags {
+		return 0, false
+	}
+	if opts.Surface != nil && !candidate.canPresent {
+		return 0, false
+	}
+
+	// The device type outweighs any number of preferred extensions
+	score := 0
+	switch candidate.properties.DeviceType {
+	case PhysicalDeviceTypeDiscreteGPU:
+		score = 4000
+	case PhysicalDeviceTypeIntegratedGPU:
+		score = 3000
+	case PhysicalDeviceTypeVirtualGPU:
+		score = 2000
+	case PhysicalDeviceTypeCPU:
+		score = 1000
+	}
+	for _, extension := range opts.PreferredExtensions {
+		if IsExtensionSupported(extension, candidate.extensions) {
+			score++
+		}
+	}
+	return score, true
+}
+
+// QueueFamilyProperties2 contains queue family properties with extension data
+type QueueFamilyProperties2 struct {
+	QueueFamilyProperties QueueFamilyProperties
+	// VideoCodecOperations lists the video codec operations the family supports. It is
+	// only filled in when the device supports VK_KHR_video_queue and is 0 otherwise.
+	VideoCodecOperations VideoCodecOperationFlags
+}
+
+// GetPhysicalDeviceQueueFamilyProperties2 gets queue family properties through
+// vkGetPhysicalDeviceQueueFamilyProperties2, chaining VkQueueFamilyVideoPropertiesKHR
+// when the device supports VK_KHR_video_queue. Use it to pick the queue family for a
+// video decode or encode session.
+func GetPhysicalDeviceQueueFamilyProperties2(physicalDevice PhysicalDevice) ([]QueueFamilyProperties2, error) {
+	if physicalDevice == nil {
+		return nil, NewValidationError("physicalDevice", "cannot be nil")
+	}
+	if GetPhysicalDeviceProperties(physicalDevice).APIVersion < Version11 {
+		return nil, NewVulkanError(ErrorFeatureNotPresent, "GetPhysicalDeviceQueueFamilyProperties2", "requires a Vulkan 1.1 device")
+	}
+
+	extensions, err := EnumerateDeviceExtensionProperties(physicalDevice, "")
+	if err != nil {
+		return nil, err
+	}
+	videoSupported := IsExtensionSupported(ExtensionNameVideoQueue, extensions)
+
+	var queueFamilyCount C.uint32_t
+	C.vkGetPhysicalDeviceQueueFamilyProperties2(C.VkPhysicalDevice(physicalDevice), &queueFamilyCount, nil)
+	if queueFamilyCount == 0 {
+		return nil, nil
+	}
+
+	cProps := (*C.VkQueueFamilyProperties2)(C.calloc(C.size_t(queueFamilyCount), C.sizeof_VkQueueFamilyProperties2))
+	if cProps == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "GetPhysicalDeviceQueueFamilyProperties2", "failed to allocate memory for queue family properties")
+	}
+	defer C.free(unsafe.Pointer(cProps))
+	cPropsSlice := unsafe.Slice(cProps, queueFamilyCount)
+
+	var cVideoSlice []C.VkQueueFamilyVideoPropertiesKHR
+	if videoSupported {
+		cVideo := (*C.VkQueueFamilyVideoPropertiesKHR)(C.calloc(C.size_t(queueFamilyCount), C.sizeof_VkQueueFamilyVideoPropertiesKHR))
+		if cVideo == nil {
+			return nil, NewVulkanError(ErrorOutOfHostMemory, "GetPhysicalDeviceQueueFamilyProperties2", "failed to allocate memory for video properties")
+		}
+		defer C.free(unsafe.Pointer(cVideo))
+		cVideoSlice = unsafe.Slice(cVideo, queueFamilyCount)
+	}
+
+	for i := range cPropsSlice {
+		cPropsSlice[i].sType = C.VK_STRUCTURE_TYPE_QUEUE_FAMILY_PROPERTIES_2
+		if videoSupported {
+			cVideoSlice[i].sType = C.VK_STRUCTURE_TYPE_QUEUE_FAMILY_VIDEO_PROPERTIES_KHR
+			cPropsSlice[i].pNext = unsafe.Pointer(&cVideoSlice[i])
+		}
+	}
+
+	C.vkGetPhysicalDeviceQueueFamilyProperties2(C.VkPhysicalDevice(physicalDevice), &queueFamilyCount, cProps)
+
+	properties := make([]QueueFamilyProperties2, queueFamilyCount)
+	for i := range properties {
+		base := &cPropsSlice[i].queueFamilyProperties
+		properties[i].QueueFamilyProperties = QueueFamilyProperties{
+			QueueFlags:         QueueFlags(base.queueFlags),
+			QueueCount:         uint32(base.queueCount),
+			TimestampValidBits: uint32(base.timestampValidBits),
+			MinImageTransferGranularity: Extent3D{
+				Width:  uint32(base.minImageTransferGranularity.width),
+				Height: uint32(base.minImageTransferGranularity.height),
+				Depth:  uint32(base.minImageTransferGranularity.depth),
+			},
+		}
+		if videoSupported {
+			properties[i].VideoCodecOperations = VideoCodecOperationFlags(cVideoSlice[i].videoCodecOperations)
+		}
+	}
+
+	return properties, nil
+}
+
+// FindVideoQueueFamily returns the index of the first queue family that supports the given
+// video codec operation, for example VideoCodecOperationDecodeH264Bit
+func FindVideoQueueFamily(properties []QueueFamilyProperties2, operation VideoCodecOperationFlags) (uint32, bool) {
+	for i, family := range properties {
+		if operation != 0 && family.QueueFamilyProperties.QueueCount > 0 && family.VideoCodecOperations&operation == operation {
+			return uint32(i), true
+		}
+	}
+	return 0, false
+}
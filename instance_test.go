@@ -1,7 +1,10 @@
+//go:build cgo
+
 package vulkan
 
 import (
 	"errors"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -273,6 +276,69 @@ func TestVersionHelpers(t *testing.T) {
 	if version.Patch() != 3 {
 		t.Errorf("Expected patch version 3, got %d", version.Patch())
 	}
+
+	if version.String() != "1.2.3" {
+		t.Errorf("Expected version string '1.2.3', got '%s'", version.String())
+	}
+
+	if Version13.Variant() != 0 || Version13.String() != "1.3.0" {
+		t.Errorf("Expected variant 0 and '1.3.0' for Version13, got %d and '%s'", Version13.Variant(), Version13.String())
+	}
+
+	// Vulkan SC uses variant 1
+	scVersion := Version(1<<29) | MakeVersion(1, 0, 0)
+	if scVersion.Variant() != 1 || scVersion.String() != "1:1.0.0" {
+		t.Errorf("Expected variant 1 and '1:1.0.0', got %d and '%s'", scVersion.Variant(), scVersion.String())
+	}
+
+	if !True.Bool() || False.Bool() {
+		t.Errorf("Expected Bool32 True and False to convert to true and false")
+	}
+}
+
+// TestMaxUsableSampleCount tests picking the highest sample count shared by color and depth
+func TestMaxUsableSampleCount(t *testing.T) {
+	tests := []struct {
+		name     string
+		color    SampleCountFlags
+		depth    SampleCountFlags
+		expected SampleCountFlags
+	}{
+		{
+			name:     "depth limits color",
+			color:    SampleCount1Bit | SampleCount2Bit | SampleCount4Bit | SampleCount8Bit,
+			depth:    SampleCount1Bit | SampleCount2Bit | SampleCount4Bit,
+			expected: SampleCount4Bit,
+		},
+		{
+			name:     "matching counts",
+			color:    SampleCount1Bit | SampleCount2Bit | SampleCount4Bit | SampleCount8Bit,
+			depth:    SampleCount1Bit | SampleCount2Bit | SampleCount4Bit | SampleCount8Bit,
+			expected: SampleCount8Bit,
+		},
+		{
+			name:     "no shared multisample count",
+			color:    SampleCount1Bit | SampleCount8Bit,
+			depth:    SampleCount1Bit | SampleCount4Bit,
+			expected: SampleCount1Bit,
+		},
+		{
+			name:     "no counts reported",
+			expected: SampleCount1Bit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limits := PhysicalDeviceLimits{
+				FramebufferColorSampleCounts: tt.color,
+				FramebufferDepthSampleCounts: tt.depth,
+			}
+			if got := limits.MaxUsableSampleCount(); got != tt.expected {
+				t.Errorf("Expected %d, got %d", tt.expected, got)
+			}
+		})
+	}
 }
 
 // TestResultHelpers tests Result helper functions
@@ -294,6 +360,49 @@ func TestResultHelpers(t *testing.T) {
 	}
 }
 
+// TestResultStrings tests Result names and error classification
+func TestResultStrings(t *testing.T) {
+	tests := []struct {
+		result  Result
+		name    string
+		isError bool
+	}{
+		{Success, "VK_SUCCESS", false},
+		{NotReady, "VK_NOT_READY", false},
+		{Timeout, "VK_TIMEOUT", false},
+		{EventSet, "VK_EVENT_SET", false},
+		{EventReset, "VK_EVENT_RESET", false},
+		{Incomplete, "VK_INCOMPLETE", false},
+		{SuboptimalKHR, "VK_SUBOPTIMAL_KHR", false},
+		{ErrorOutOfDateKHR, "VK_ERROR_OUT_OF_DATE_KHR", true},
+		{ErrorSurfaceLostKHR, "VK_ERROR_SURFACE_LOST_KHR", true},
+		{ErrorFullScreenExclusiveModeLostEXT, "VK_ERROR_FULL_SCREEN_EXCLUSIVE_MODE_LOST_EXT", true},
+		{ErrorInvalidVideoStdParametersKHR, "VK_ERROR_INVALID_VIDEO_STD_PARAMETERS_KHR", true},
+		{ErrorCompressionExhaustedEXT, "VK_ERROR_COMPRESSION_EXHAUSTED_EXT", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.result.String(); got != tt.name {
+				t.Errorf("Expected String() '%s', got '%s'", tt.name, got)
+			}
+			if got := tt.result.Error(); got != tt.name {
+				t.Errorf("Expected Error() '%s', got '%s'", tt.name, got)
+			}
+			if tt.result.IsError() != tt.isError {
+				t.Errorf("Expected IsError() %v for %s", tt.isError, tt.name)
+			}
+			if tt.result.IsSuccess() == tt.isError {
+				t.Errorf("Expected IsSuccess() %v for %s", !tt.isError, tt.name)
+			}
+		})
+	}
+
+	if got := Result(-123456).String(); got != "Unknown Vulkan error (-123456)" {
+		t.Errorf("Expected numeric fallback for unknown result, got '%s'", got)
+	}
+}
+
 // BenchmarkStringSliceToCharArray benchmarks the string slice conversion
 func BenchmarkStringSliceToCharArray(b *testing.B) {
 	testSlice := []string{"layer1", "layer2", "layer3", "layer4", "layer5"}
@@ -306,3 +415,119 @@ func BenchmarkStringSliceToCharArray(b *testing.B) {
 		}
 	}
 }
+
+// TestGetProcAddrValidation tests that invalid lookups return nil without calling Vulkan
+func TestGetProcAddrValidation(t *testing.T) {
+	if ptr := GetInstanceProcAddr(nil, ""); ptr != nil {
+		t.Errorf("Expected nil for empty instance command name, got %v", ptr)
+	}
+	if ptr := GetDeviceProcAddr(nil, "vkCmdDraw"); ptr != nil {
+		t.Errorf("Expected nil for nil device, got %v", ptr)
+	}
+	if ptr := GetDeviceProcAddr(Device(uintptr(0x1234)), ""); ptr != nil {
+		t.Errorf("Expected nil for empty device command name, got %v", ptr)
+	}
+}
+
+// TestFindQueueFamilies tests queue family selection preferences
+func TestFindQueueFamilies(t *testing.T) {
+	families := []QueueFamilyProperties{
+		{QueueFlags: QueueGraphicsBit | QueueComputeBit | QueueTransferBit, QueueCount: 16},
+		{QueueFlags: QueueComputeBit | QueueTransferBit, QueueCount: 8},
+		{QueueFlags: QueueTransferBit, QueueCount: 2},
+		{QueueFlags: QueueVideoDecodeBitKHR, QueueCount: 1},
+	}
+
+	indices := findQueueFamilies(families, nil)
+	if !indices.IsComplete() {
+		t.Fatalf("Expected complete indices without a surface, got %+v", indices)
+	}
+	if indices.Graphics != 0 || indices.Compute != 1 || indices.Transfer != 2 {
+		t.Errorf("Expected dedicated families 0/1/2, got graphics=%d compute=%d transfer=%d",
+			indices.Graphics, indices.Compute, indices.Transfer)
+	}
+	if indices.HasPresent {
+		t.Errorf("Expected no present family without a surface")
+	}
+
+	// Present prefers the graphics family, and a surface makes it required
+	indices = findQueueFamilies(families, func(index uint32) bool { return index == 0 || index == 3 })
+	if !indices.HasPresent || indices.Present != 0 {
+		t.Errorf("Expected present family 0, got %d (found=%v)", indices.Present, indices.HasPresent)
+	}
+	if expected := []uint32{0, 1, 2}; !reflect.DeepEqual(indices.UniqueIndices(), expected) {
+		t.Errorf("Expected unique indices %v, got %v", expected, indices.UniqueIndices())
+	}
+
+	indices = findQueueFamilies(families, func(index uint32) bool { return false })
+	if indices.IsComplete() {
+		t.Errorf("Expected incomplete indices when no family can present")
+	}
+
+	// A single universal family serves every role
+	indices = findQueueFamilies(families[:1], nil)
+	if expected := []uint32{0}; !reflect.DeepEqual(indices.UniqueIndices(), expected) {
+		t.Errorf("Expected unique indices %v, got %v", expected, indices.UniqueIndices())
+	}
+}
+
+func TestScorePhysicalDevice(t *testing.T) {
+	graphicsFamilies := []QueueFamilyProperties{{QueueFlags: QueueGraphicsBit | QueueComputeBit, QueueCount: 1}}
+	newCandidate := func(deviceType PhysicalDeviceType, apiVersion Version, extensions ...string) *physicalDeviceCandidate {
+		candidate := &physicalDeviceCandidate{
+			properties:    PhysicalDeviceProperties{DeviceType: deviceType, APIVersion: apiVersion},
+			queueFamilies: graphicsFamilies,
+		}
+		for _, extension := range extensions {
+			candidate.extensions = append(candidate.extensions, ExtensionProperties{ExtensionName: extension})
+		}
+		return candidate
+	}
+
+	integrated := newCandidate(PhysicalDeviceTypeIntegratedGPU, Version13, "VK_KHR_swapchain", "VK_EXT_mesh_shader")
+	discrete := newCandidate(PhysicalDeviceTypeDiscreteGPU, Version12, "VK_KHR_swapchain")
+
+	opts := &DeviceSelectionOptions{
+		RequiredExtensions:  []string{"VK_KHR_swapchain"},
+		PreferredExtensions: []string{"VK_EXT_mesh_shader"},
+		RequiredQueueFlags:  QueueGraphicsBit,
+	}
+	integratedScore, ok := scorePhysicalDevice(integrated, opts)
+	if !ok {
+		t.Fatalf("Expected integrated GPU to meet the requirements")
+	}
+	discreteScore, ok := scorePhysicalDevice(discrete, opts)
+	if !ok {
+		t.Fatalf("Expected discrete GPU to meet the requirements")
+	}
+	if discreteScore <= integratedScore {
+		t.Errorf("Expected discrete GPU to outrank integrated GPU with more preferred extensions, got %d <= %d", discreteScore, integratedScore)
+	}
+
+	tests := []struct {
+		name string
+		opts DeviceSelectionOptions
+	}{
+		{name: "missing extension", opts: DeviceSelectionOptions{RequiredExtensions: []string{"VK_KHR_ray_query"}}},
+		{name: "missing queue flags", opts: DeviceSelectionOptions{RequiredQueueFlags: QueueVideoDecodeBitKHR}},
+		{name: "API version too old", opts: DeviceSelectionOptions{MinAPIVersion: Version13}},
+		{name: "no present support", opts: DeviceSelectionOptions{Surface: Surface(uintptr(0x1234))}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, ok := scorePhysicalDevice(discrete, &tt.opts); ok {
+				t.Errorf("Expected discrete GPU to be rejected")
+			}
+		})
+	}
+
+	// The variant bits must not make an older version look newer
+	variantDevice := newCandidate(PhysicalDeviceTypeDiscreteGPU, Version12|(1<<29))
+	if _, ok := scorePhysicalDevice(variantDevice, &DeviceSelectionOptions{MinAPIVersion: Version13}); ok {
+		t.Errorf("Expected version variant bits to be ignored")
+	}
+
+	if _, err := SelectPhysicalDevice(nil, DeviceSelectionOptions{}); err == nil {
+		t.Errorf("Expected error for nil instance")
+	}
+}
@@ -0,0 +1,186 @@
+package vulkan
+
+/*
+#include <vulkan/vulkan.h>
+#include <stdlib.h>
+
+// Function pointers for VK_KHR_maintenance5 functions
+// These need to be loaded dynamically at runtime.
+//
+// IMPORTANT: These are global static pointers and NOT thread-safe during loading.
+// LoadMaintenance5Functions must be called from a single thread during initialization
+// before any concurrent maintenance5 API usage.
+static PFN_vkCmdBindIndexBuffer2KHR pfn_vkCmdBindIndexBuffer2KHR = NULL;
+static PFN_vkGetDeviceImageSubresourceLayoutKHR pfn_vkGetDeviceImageSubresourceLayoutKHR = NULL;
+
+static int loadMaintenance5DeviceFunctions(VkDevice device) {
+    if (device == VK_NULL_HANDLE) {
+        return 0;
+    }
+    pfn_vkCmdBindIndexBuffer2KHR = (PFN_vkCmdBindIndexBuffer2KHR)
+        vkGetDeviceProcAddr(device, "vkCmdBindIndexBuffer2KHR");
+    pfn_vkGetDeviceImageSubresourceLayoutKHR = (PFN_vkGetDeviceImageSubresourceLayoutKHR)
+        vkGetDeviceProcAddr(device, "vkGetDeviceImageSubresourceLayoutKHR");
+
+    return pfn_vkCmdBindIndexBuffer2KHR != NULL &&
+           pfn_vkGetDeviceImageSubresourceLayoutKHR != NULL;
+}
+
+// Wrapper functions return 1 on success, 0 if function pointer is NULL.
+static int call_vkCmdBindIndexBuffer2KHR(
+    VkCommandBuffer commandBuffer,
+    VkBuffer buffer,
+    VkDeviceSize offset,
+    VkDeviceSize size,
+    VkIndexType indexType) {
+    if (pfn_vkCmdBindIndexBuffer2KHR == NULL) {
+        return 0;
+    }
+    pfn_vkCmdBindIndexBuffer2KHR(commandBuffer, buffer, offset, size, indexType);
+    return 1;
+}
+
+static int call_vkGetDeviceImageSubresourceLayoutKHR(
+    VkDevice device,
+    const VkDeviceImageSubresourceInfoKHR* pInfo,
+    VkSubresourceLayout2KHR* pLayout) {
+    if (pfn_vkGetDeviceImageSubresourceLayoutKHR == NULL) {
+        return 0;
+    }
+    pfn_vkGetDeviceImageSubresourceLayoutKHR(device, pInfo, pLayout);
+    return 1;
+}
+*/
+import "C"
+
+import (
+	"unsafe"
+)
+
+// ExtensionNameMaintenance5 is the maintenance5 device extension name. The extension needs
+// Vulkan 1.1 or later and is core in Vulkan 1.4.
+const ExtensionNameMaintenance5 = "VK_KHR_maintenance5"
+
+// LoadMaintenance5Functions loads VK_KHR_maintenance5 functions for a device.
+//
+// This function MUST be called after creating a logical device with the VK_KHR_maintenance5
+// extension enabled, or a Vulkan 1.4 device, and before binding index buffer ranges or
+// querying image subresource layouts.
+//
+// IMPORTANT: This function is NOT thread-safe. Only one device is supported at a time;
+// calling this function again will overwrite previously loaded function pointers.
+//
+// Returns false if any maintenance5 function could not be loaded.
+func LoadMaintenance5Functions(device Device) bool {
+	return C.loadMaintenance5DeviceFunctions(C.VkDevice(device)) != 0
+}
+
+// CmdBindIndexBuffer2KHR binds size bytes of buffer starting at offset as the index buffer.
+// Unlike CmdBindIndexBuffer, the bound range does not have to run to the end of the buffer,
+// so several meshes can share one buffer and out-of-range indices are caught by robustness
+// features. size may be WholeSize to bind the rest of the buffer.
+// Requires VK_KHR_maintenance5 (core in Vulkan 1.4).
+// Returns an error if LoadMaintenance5Functions was not called.
+func CmdBindIndexBuffer2KHR(commandBuffer CommandBuffer, buffer Buffer, offset, size DeviceSize, indexType IndexType) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+	if buffer == nil {
+		return NewValidationError("buffer", "cannot be nil")
+	}
+	if indexType != IndexTypeUint16 && indexType != IndexTypeUint32 {
+		return NewValidationError("indexType", "must be IndexTypeUint16 or IndexTypeUint32")
+	}
+	indexSize := DeviceSize(2)
+	if indexType == IndexTypeUint32 {
+		indexSize = 4
+	}
+	if offset%indexSize != 0 {
+		return NewValidationError("offset", "must be a multiple of the index size")
+	}
+	if size != DeviceSize(WholeSize) && size%indexSize != 0 {
+		return NewValidationError("size", "must be WholeSize or a multiple of the index size")
+	}
+
+	if C.call_vkCmdBindIndexBuffer2KHR(C.VkCommandBuffer(commandBuffer), C.VkBuffer(buffer),
+		C.VkDeviceSize(offset), C.VkDeviceSize(size), C.VkIndexType(indexType)) == 0 {
+		return NewVulkanError(ErrorExtensionNotPresent, "CmdBindIndexBuffer2KHR", "maintenance5 extension not loaded - call LoadMaintenance5Functions first")
+	}
+	return nil
+}
+
+// GetDeviceImageSubresourceLayoutKHR returns the layout a subresource would have in an image
+// created from createInfo, without creating the image. This lets linear staging images be
+// sized and laid out up front. Requires VK_KHR_maintenance5 (core in Vulkan 1.4).
+// Returns an error if LoadMaintenance5Functions was not called.
+func GetDeviceImageSubresourceLayoutKHR(device Device, createInfo *ImageCreateInfo, subresource ImageSubresource) (SubresourceLayout, error) {
+	if device == nil {
+		return SubresourceLayout{}, NewValidationError("device", "cannot be nil")
+	}
+	if createInfo == nil {
+		return SubresourceLayout{}, NewValidationError("createInfo", "cannot be nil")
+	}
+	if err := validateQueueFamilyIndices(createInfo.SharingMode, createInfo.QueueFamilyIndices); err != nil {
+		return SubresourceLayout{}, err
+	}
+
+	cIndices, cIndexCount, err := queueFamilyIndicesToC(createInfo.SharingMode, createInfo.QueueFamilyIndices)
+	if err != nil {
+		return SubresourceLayout{}, err
+	}
+	if cIndices != nil {
+		defer C.free(unsafe.Pointer(cIndices))
+	}
+
+	// The create info and subresource are referenced from the info struct, so they must live
+	// in C memory
+	cCreateInfo := (*C.VkImageCreateInfo)(C.calloc(1, C.sizeof_VkImageCreateInfo))
+	cSubresource := (*C.VkImageSubresource2KHR)(C.calloc(1, C.sizeof_VkImageSubresource2KHR))
+	defer C.free(unsafe.Pointer(cCreateInfo))
+	defer C.free(unsafe.Pointer(cSubresource))
+	if cCreateInfo == nil || cSubresource == nil {
+		return SubresourceLayout{}, NewVulkanError(ErrorOutOfHostMemory, "GetDeviceImageSubresourceLayoutKHR", "failed to allocate memory for image create info")
+	}
+
+	cCreateInfo.sType = C.VK_STRUCTURE_TYPE_IMAGE_CREATE_INFO
+	cCreateInfo.flags = C.VkImageCreateFlags(createInfo.Flags)
+	cCreateInfo.imageType = C.VkImageType(createInfo.ImageType)
+	cCreateInfo.format = C.VkFormat(createInfo.Format)
+	cCreateInfo.extent.width = C.uint32_t(createInfo.Extent.Width)
+	cCreateInfo.extent.height = C.uint32_t(createInfo.Extent.Height)
+	cCreateInfo.extent.depth = C.uint32_t(createInfo.Extent.Depth)
+	cCreateInfo.mipLevels = C.uint32_t(createInfo.MipLevels)
+	cCreateInfo.arrayLayers = C.uint32_t(createInfo.ArrayLayers)
+	cCreateInfo.samples = C.VkSampleCountFlagBits(createInfo.Samples)
+	cCreateInfo.tiling = C.VkImageTiling(createInfo.Tiling)
+	cCreateInfo.usage = C.VkImageUsageFlags(createInfo.Usage)
+	cCreateInfo.sharingMode = C.VkSharingMode(createInfo.SharingMode)
+	cCreateInfo.queueFamilyIndexCount = cIndexCount
+	cCreateInfo.pQueueFamilyIndices = cIndices
+	cCreateInfo.initialLayout = C.VkImageLayout(createInfo.InitialLayout)
+
+	cSubresource.sType = C.VK_STRUCTURE_TYPE_IMAGE_SUBRESOURCE_2_KHR
+	cSubresource.imageSubresource.aspectMask = C.VkImageAspectFlags(subresource.AspectMask)
+	cSubresource.imageSubresource.mipLevel = C.uint32_t(subresource.MipLevel)
+	cSubresource.imageSubresource.arrayLayer = C.uint32_t(subresource.ArrayLayer)
+
+	cInfo := C.VkDeviceImageSubresourceInfoKHR{
+		sType:        C.VK_STRUCTURE_TYPE_DEVICE_IMAGE_SUBRESOURCE_INFO_KHR,
+		pCreateInfo:  cCreateInfo,
+		pSubresource: cSubresource,
+	}
+	var cLayout C.VkSubresourceLayout2KHR
+	cLayout.sType = C.VK_STRUCTURE_TYPE_SUBRESOURCE_LAYOUT_2_KHR
+
+	if C.call_vkGetDeviceImageSubresourceLayoutKHR(C.VkDevice(device), &cInfo, &cLayout) == 0 {
+		return SubresourceLayout{}, NewVulkanError(ErrorExtensionNotPresent, "GetDeviceImageSubresourceLayoutKHR", "maintenance5 extension not loaded - call LoadMaintenance5Functions first")
+	}
+
+	return SubresourceLayout{
+		Offset:     DeviceSize(cLayout.subresourceLayout.offset),
+		Size:       DeviceSize(cLayout.subresourceLayout.size),
+		RowPitch:   DeviceSize(cLayout.subresourceLayout.rowPitch),
+		ArrayPitch: DeviceSize(cLayout.subresourceLayout.arrayPitch),
+		DepthPitch: DeviceSize(cLayout.subresourceLayout.depthPitch),
+	}, nil
+}
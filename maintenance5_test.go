@@ -0,0 +1,78 @@
+//go:build cgo
+
+package vulkan
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestMaintenance5Validation tests input validation and the unloaded-extension error
+func TestMaintenance5Validation(t *testing.T) {
+	fakeCommandBuffer := CommandBuffer(uintptr(0x1234))
+	fakeBuffer := Buffer(uintptr(0x5678))
+
+	tests := []struct {
+		name       string
+		call       func() error
+		errorParam string
+	}{
+		{
+			name: "nil buffer",
+			call: func() error {
+				return CmdBindIndexBuffer2KHR(fakeCommandBuffer, nil, 0, DeviceSize(WholeSize), IndexTypeUint16)
+			},
+			errorParam: "buffer",
+		},
+		{
+			name: "invalid index type",
+			call: func() error {
+				return CmdBindIndexBuffer2KHR(fakeCommandBuffer, fakeBuffer, 0, DeviceSize(WholeSize), IndexType(99))
+			},
+			errorParam: "indexType",
+		},
+		{
+			name: "unaligned offset",
+			call: func() error {
+				return CmdBindIndexBuffer2KHR(fakeCommandBuffer, fakeBuffer, 2, 64, IndexTypeUint32)
+			},
+			errorParam: "offset",
+		},
+		{
+			name: "partial index size",
+			call: func() error {
+				return CmdBindIndexBuffer2KHR(fakeCommandBuffer, fakeBuffer, 0, 63, IndexTypeUint16)
+			},
+			errorParam: "size",
+		},
+		{
+			name: "nil create info",
+			call: func() error {
+				_, err := GetDeviceImageSubresourceLayoutKHR(Device(uintptr(0x9abc)), nil, ImageSubresource{})
+				return err
+			},
+			errorParam: "createInfo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+
+	// Without LoadMaintenance5Functions the command reports the missing extension
+	err := CmdBindIndexBuffer2KHR(fakeCommandBuffer, fakeBuffer, 16, 96, IndexTypeUint16)
+	var vulkanErr *VulkanError
+	if !errors.As(err, &vulkanErr) || vulkanErr.Result != ErrorExtensionNotPresent {
+		t.Errorf("Expected ErrorExtensionNotPresent, got %v", err)
+	}
+}
@@ -0,0 +1,217 @@
+package vulkan
+
+/*
+#include <vulkan/vulkan.h>
+#include <stdlib.h>
+
+// Function pointers for VK_KHR_maintenance6 functions
+// These need to be loaded dynamically at runtime.
+//
+// IMPORTANT: These are global static pointers and NOT thread-safe during loading.
+// LoadMaintenance6Functions must be called from a single thread during initialization
+// before any concurrent maintenance6 API usage.
+static PFN_vkCmdPushConstants2KHR pfn_vkCmdPushConstants2KHR = NULL;
+static PFN_vkCmdBindDescriptorSets2KHR pfn_vkCmdBindDescriptorSets2KHR = NULL;
+
+static int loadMaintenance6DeviceFunctions(VkDevice device) {
+    if (device == VK_NULL_HANDLE) {
+        return 0;
+    }
+    pfn_vkCmdPushConstants2KHR = (PFN_vkCmdPushConstants2KHR)
+        vkGetDeviceProcAddr(device, "vkCmdPushConstants2KHR");
+    pfn_vkCmdBindDescriptorSets2KHR = (PFN_vkCmdBindDescriptorSets2KHR)
+        vkGetDeviceProcAddr(device, "vkCmdBindDescriptorSets2KHR");
+
+    return pfn_vkCmdPushConstants2KHR != NULL &&
+           pfn_vkCmdBindDescriptorSets2KHR != NULL;
+}
+
+// Wrapper functions return 1 on success, 0 if function pointer is NULL.
+static int call_vkCmdPushConstants2KHR(
+    VkCommandBuffer commandBuffer,
+    const VkPushConstantsInfoKHR* pPushConstantsInfo) {
+    if (pfn_vkCmdPushConstants2KHR == NULL) {
+        return 0;
+    }
+    pfn_vkCmdPushConstants2KHR(commandBuffer, pPushConstantsInfo);
+    return 1;
+}
+
+static int call_vkCmdBindDescriptorSets2KHR(
+    VkCommandBuffer commandBuffer,
+    const VkBindDescriptorSetsInfoKHR* pBindDescriptorSetsInfo) {
+    if (pfn_vkCmdBindDescriptorSets2KHR == NULL) {
+        return 0;
+    }
+    pfn_vkCmdBindDescriptorSets2KHR(commandBuffer, pBindDescriptorSetsInfo);
+    return 1;
+}
+*/
+import "C"
+
+import (
+	"unsafe"
+)
+
+// ExtensionNameMaintenance6 is the maintenance6 device extension name. The extension needs
+// Vulkan 1.1 or later and is core in Vulkan 1.4.
+const ExtensionNameMaintenance6 = "VK_KHR_maintenance6"
+
+// PushConstantsInfo describes a push constant update recorded with CmdPushConstants2KHR
+type PushConstantsInfo struct {
+	Layout PipelineLayout
+	// StageFlags selects the shader stages that see the update. Graphics and compute stages
+	// may be combined to update every bind point that uses the layout in one call.
+	StageFlags ShaderStageFlags
+	Offset     uint32
+	// Values holds the bytes to write starting at Offset. Its length must be a non-zero
+	// multiple of 4.
+	Values []byte
+}
+
+// BindDescriptorSetsInfo describes a descriptor set binding recorded with
+// CmdBindDescriptorSets2KHR
+type BindDescriptorSetsInfo struct {
+	// StageFlags selects the bind points the sets are bound to: graphics stages bind to
+	// PipelineBindPointGraphics and ShaderStageComputeBit binds to PipelineBindPointCompute.
+	StageFlags     ShaderStageFlags
+	Layout         PipelineLayout
+	FirstSet       uint32
+	DescriptorSets []DescriptorSet
+	// DynamicOffsets holds one offset per dynamic descriptor of DescriptorSets, ordered as
+	// for CmdBindDescriptorSets
+	DynamicOffsets []uint32
+}
+
+// LoadMaintenance6Functions loads VK_KHR_maintenance6 functions for a device.
+//
+// This function MUST be called after creating a logical device with the VK_KHR_maintenance6
+// extension enabled, or a Vulkan 1.4 device, and before recording CmdPushConstants2KHR or
+// CmdBindDescriptorSets2KHR.
+//
+// IMPORTANT: This function is NOT thread-safe. Only one device is supported at a time;
+// calling this function again will overwrite previously loaded function pointers.
+//
+// Returns false if any maintenance6 function could not be loaded.
+func LoadMaintenance6Functions(device Device) bool {
+	return C.loadMaintenance6DeviceFunctions(C.VkDevice(device)) != 0
+}
+
+// CmdPushConstants2KHR updates push constants for every stage in info.StageFlags. The stages
+// may span graphics and compute, so one call can update constants shared by both bind points.
+// Requires VK_KHR_maintenance6 (core in Vulkan 1.4).
+// Returns an error if LoadMaintenance6Functions was not called.
+func CmdPushConstants2KHR(commandBuffer CommandBuffer, info *PushConstantsInfo) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+	if info == nil {
+		return NewValidationError("info", "cannot be nil")
+	}
+	if info.Layout == nil {
+		return NewValidationError("info.Layout", "cannot be nil")
+	}
+	if info.StageFlags == 0 {
+		return NewValidationError("info.StageFlags", "must name at least one shader stage")
+	}
+	if info.Offset%4 != 0 {
+		return NewValidationError("info.Offset", "must be a multiple of 4")
+	}
+	if len(info.Values) == 0 || len(info.Values)%4 != 0 {
+		return NewValidationError("info.Values", "length must be a non-zero multiple of 4")
+	}
+
+	// The values are referenced from the info struct, so they must live in C memory
+	cValues := C.CBytes(info.Values)
+	defer C.free(cValues)
+
+	cInfo := (*C.VkPushConstantsInfoKHR)(C.calloc(1, C.sizeof_VkPushConstantsInfoKHR))
+	if cInfo == nil {
+		return NewVulkanError(ErrorOutOfHostMemory, "CmdPushConstants2KHR", "failed to allocate memory for push constants info")
+	}
+	defer C.free(unsafe.Pointer(cInfo))
+
+	cInfo.sType = C.VK_STRUCTURE_TYPE_PUSH_CONSTANTS_INFO_KHR
+	cInfo.layout = C.VkPipelineLayout(info.Layout)
+	cInfo.stageFlags = C.VkShaderStageFlags(info.StageFlags)
+	cInfo.offset = C.uint32_t(info.Offset)
+	cInfo.size = C.uint32_t(len(info.Values))
+	cInfo.pValues = cValues
+
+	if C.call_vkCmdPushConstants2KHR(C.VkCommandBuffer(commandBuffer), cInfo) == 0 {
+		return NewVulkanError(ErrorExtensionNotPresent, "CmdPushConstants2KHR", "maintenance6 extension not loaded - call LoadMaintenance6Functions first")
+	}
+	return nil
+}
+
+// CmdBindDescriptorSets2KHR binds descriptor sets to every bind point selected by
+// info.StageFlags, so sets shared by graphics and compute pipelines can be bound in one call.
+// Dynamic offsets are validated as in CmdBindDescriptorSets.
+// Requires VK_KHR_maintenance6 (core in Vulkan 1.4).
+// Returns an error if LoadMaintenance6Functions was not called.
+func CmdBindDescriptorSets2KHR(commandBuffer CommandBuffer, info *BindDescriptorSetsInfo) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+	if info == nil {
+		return NewValidationError("info", "cannot be nil")
+	}
+	if info.Layout == nil {
+		return NewValidationError("info.Layout", "cannot be nil")
+	}
+	if info.StageFlags == 0 {
+		return NewValidationError("info.StageFlags", "must name at least one shader stage")
+	}
+	if len(info.DescriptorSets) == 0 {
+		return nil
+	}
+	if err := validateDynamicOffsets(commandBuffer, info.DescriptorSets, info.DynamicOffsets); err != nil {
+		return err
+	}
+
+	var allocations []unsafe.Pointer
+	defer func() { freeAllocations(allocations) }()
+
+	cSetsPtr := (*C.VkDescriptorSet)(C.calloc(C.size_t(len(info.DescriptorSets)), C.size_t(unsafe.Sizeof(C.VkDescriptorSet(nil)))))
+	if cSetsPtr == nil {
+		return NewVulkanError(ErrorOutOfHostMemory, "CmdBindDescriptorSets2KHR", "failed to allocate memory for descriptor sets")
+	}
+	allocations = append(allocations, unsafe.Pointer(cSetsPtr))
+	cSets := unsafe.Slice(cSetsPtr, len(info.DescriptorSets))
+	for i, set := range info.DescriptorSets {
+		cSets[i] = C.VkDescriptorSet(set)
+	}
+
+	var cOffsetsPtr *C.uint32_t
+	if len(info.DynamicOffsets) > 0 {
+		cOffsetsPtr = (*C.uint32_t)(C.calloc(C.size_t(len(info.DynamicOffsets)), C.size_t(unsafe.Sizeof(C.uint32_t(0)))))
+		if cOffsetsPtr == nil {
+			return NewVulkanError(ErrorOutOfHostMemory, "CmdBindDescriptorSets2KHR", "failed to allocate memory for dynamic offsets")
+		}
+		allocations = append(allocations, unsafe.Pointer(cOffsetsPtr))
+		cOffsets := unsafe.Slice(cOffsetsPtr, len(info.DynamicOffsets))
+		for i, offset := range info.DynamicOffsets {
+			cOffsets[i] = C.uint32_t(offset)
+		}
+	}
+
+	cInfo := (*C.VkBindDescriptorSetsInfoKHR)(C.calloc(1, C.sizeof_VkBindDescriptorSetsInfoKHR))
+	if cInfo == nil {
+		return NewVulkanError(ErrorOutOfHostMemory, "CmdBindDescriptorSets2KHR", "failed to allocate memory for bind descriptor sets info")
+	}
+	allocations = append(allocations, unsafe.Pointer(cInfo))
+
+	cInfo.sType = C.VK_STRUCTURE_TYPE_BIND_DESCRIPTOR_SETS_INFO_KHR
+	cInfo.stageFlags = C.VkShaderStageFlags(info.StageFlags)
+	cInfo.layout = C.VkPipelineLayout(info.Layout)
+	cInfo.firstSet = C.uint32_t(info.FirstSet)
+	cInfo.descriptorSetCount = C.uint32_t(len(info.DescriptorSets))
+	cInfo.pDescriptorSets = cSetsPtr
+	cInfo.dynamicOffsetCount = C.uint32_t(len(info.DynamicOffsets))
+	cInfo.pDynamicOffsets = cOffsetsPtr
+
+	if C.call_vkCmdBindDescriptorSets2KHR(C.VkCommandBuffer(commandBuffer), cInfo) == 0 {
+		return NewVulkanError(ErrorExtensionNotPresent, "CmdBindDescriptorSets2KHR", "maintenance6 extension not loaded - call LoadMaintenance6Functions first")
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+//go:build cgo
+
+package vulkan
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestMaintenance6Validation tests input validation and the unloaded-extension error
+func TestMaintenance6Validation(t *testing.T) {
+	fakeCommandBuffer := CommandBuffer(uintptr(0x1234))
+	fakeLayout := PipelineLayout(uintptr(0x5678))
+	fakeSet := DescriptorSet(uintptr(0x9abc))
+	fakeDevice := Device(uintptr(0x4321))
+	dynamicDescriptors.addSet(fakeDevice, DescriptorPool(uintptr(0x8765)), fakeSet, 1)
+	defer dynamicDescriptors.removeDevice(fakeDevice)
+	allocatedCommandBuffers.add(fakeDevice, CommandPool(uintptr(0x8765)), []CommandBuffer{fakeCommandBuffer}, false)
+	defer allocatedCommandBuffers.removeDevice(fakeDevice)
+
+	bothStages := ShaderStageVertexBit | ShaderStageComputeBit
+
+	tests := []struct {
+		name       string
+		call       func() error
+		errorParam string
+	}{
+		{
+			name: "nil push constants info",
+			call: func() error {
+				return CmdPushConstants2KHR(fakeCommandBuffer, nil)
+			},
+			errorParam: "info",
+		},
+		{
+			name: "no push constant stages",
+			call: func() error {
+				return CmdPushConstants2KHR(fakeCommandBuffer, &PushConstantsInfo{Layout: fakeLayout, Values: make([]byte, 4)})
+			},
+			errorParam: "info.StageFlags",
+		},
+		{
+			name: "unaligned push constant offset",
+			call: func() error {
+				return CmdPushConstants2KHR(fakeCommandBuffer, &PushConstantsInfo{Layout: fakeLayout, StageFlags: bothStages, Offset: 2, Values: make([]byte, 4)})
+			},
+			errorParam: "info.Offset",
+		},
+		{
+			name: "partial push constant word",
+			call: func() error {
+				return CmdPushConstants2KHR(fakeCommandBuffer, &PushConstantsInfo{Layout: fakeLayout, StageFlags: bothStages, Values: make([]byte, 6)})
+			},
+			errorParam: "info.Values",
+		},
+		{
+			name: "nil descriptor set layout",
+			call: func() error {
+				return CmdBindDescriptorSets2KHR(fakeCommandBuffer, &BindDescriptorSetsInfo{StageFlags: bothStages, DescriptorSets: []DescriptorSet{fakeSet}})
+			},
+			errorParam: "info.Layout",
+		},
+		{
+			name: "missing dynamic offset",
+			call: func() error {
+				return CmdBindDescriptorSets2KHR(fakeCommandBuffer, &BindDescriptorSetsInfo{
+					StageFlags:     bothStages,
+					Layout:         fakeLayout,
+					DescriptorSets: []DescriptorSet{fakeSet},
+				})
+			},
+			errorParam: "dynamicOffsets",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+
+	// Without LoadMaintenance6Functions the command reports the missing extension
+	err := CmdPushConstants2KHR(fakeCommandBuffer, &PushConstantsInfo{Layout: fakeLayout, StageFlags: bothStages, Values: make([]byte, 16)})
+	var vulkanErr *VulkanError
+	if !errors.As(err, &vulkanErr) || vulkanErr.Result != ErrorExtensionNotPresent {
+		t.Errorf("Expected ErrorExtensionNotPresent, got %v", err)
+	}
+}
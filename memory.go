@@ -8,6 +8,8 @@ package vulkan
 import "C"
 
 import (
+	"fmt"
+	"sync"
 	"unsafe"
 )
 
@@ -17,9 +19,22 @@ type BufferCreateInfo struct {
 	Size        DeviceSize
 	Usage       BufferUsageFlags
 	SharingMode SharingMode
+	// QueueFamilyIndices lists the queue families that access the buffer when SharingMode
+	// is SharingModeConcurrent. It must hold at least two distinct indices and is ignored
+	// for SharingModeExclusive.
+	QueueFamilyIndices []uint32
+	// OpaqueCaptureAddress requests a previously captured device address when
+	// replaying. Requires BufferCreateDeviceAddressCaptureReplayBit.
+	OpaqueCaptureAddress uint64
+	// ExternalMemoryHandleTypes lists the handle types the buffer's memory may be exported
+	// or imported as. Check support with GetPhysicalDeviceExternalBufferProperties.
+	ExternalMemoryHandleTypes ExternalMemoryHandleTypeFlags
 }
 
-// BufferCreateFlags represents buffer creation flags
+// BufferCreateFlags represents buffer creation flags. The sparse bits require the matching
+// PhysicalDeviceFeatures (SparseBinding, SparseResidencyBuffer, SparseResidencyAliased) to be
+// enabled in DeviceCreateInfo.EnabledFeatures; sparse buffers are bound with QueueBindSparse
+// instead of BindBufferMemory.
 type BufferCreateFlags uint32
 
 const (
@@ -54,12 +69,75 @@ const (
 	SharingModeConcurrent SharingMode = C.VK_SHARING_MODE_CONCURRENT
 )
 
+// validateQueueFamilyIndices checks that concurrent sharing names at least two distinct queue families
+func validateQueueFamilyIndices(sharingMode SharingMode, queueFamilyIndices []uint32) error {
+	if sharingMode != SharingModeConcurrent {
+		return nil
+	}
+	for i, index := range queueFamilyIndices {
+		for _, other := range queueFamilyIndices[:i] {
+			if index == other {
+				return NewValidationError("QueueFamilyIndices", fmt.Sprintf("duplicate queue family index %d", index))
+			}
+		}
+	}
+	if len(queueFamilyIndices) < 2 {
+		return NewValidationError("QueueFamilyIndices", "concurrent sharing mode requires at least two distinct queue family indices")
+	}
+	return nil
+}
+
+// queueFamilyIndicesToC copies the queue family indices used by concurrent sharing into C
+// memory. It returns nil for exclusive sharing; otherwise the caller must free the array.
+func queueFamilyIndicesToC(sharingMode SharingMode, queueFamilyIndices []uint32) (*C.uint32_t, C.uint32_t, error) {
+	if sharingMode != SharingModeConcurrent || len(queueFamilyIndices) == 0 {
+		return nil, 0, nil
+	}
+
+	cIndices := (*C.uint32_t)(C.malloc(C.size_t(len(queueFamilyIndices)) * C.sizeof_uint32_t))
+	if cIndices == nil {
+		return nil, 0, NewVulkanError(ErrorOutOfHostMemory, "queueFamilyIndicesToC", "failed to allocate queue family indices")
+	}
+	indices := unsafe.Slice(cIndices, len(queueFamilyIndices))
+	for i, index := range queueFamilyIndices {
+		indices[i] = C.uint32_t(index)
+	}
+	return cIndices, C.uint32_t(len(queueFamilyIndices)), nil
+}
+
 // MemoryAllocateInfo contains memory allocation information
 type MemoryAllocateInfo struct {
 	AllocationSize  DeviceSize
 	MemoryTypeIndex uint32
+	Flags           MemoryAllocateFlags
+	// DeviceMask selects the devices of a device group the memory is allocated on. Requires
+	// MemoryAllocateDeviceMaskBit; without it, memory is allocated on every device.
+	DeviceMask uint32
+	// OpaqueCaptureAddress requests a previously captured memory address when
+	// replaying. Requires MemoryAllocateDeviceAddressCaptureReplayBit.
+	OpaqueCaptureAddress uint64
+	// DedicatedImage or DedicatedBuffer makes this a dedicated allocation for a single
+	// resource, which must then be bound at offset 0. At most one may be set.
+	DedicatedImage  Image
+	DedicatedBuffer Buffer
+	// ExportHandleTypes makes the memory exportable as these handle types, e.g. with
+	// GetMemoryFdKHR
+	ExportHandleTypes ExternalMemoryHandleTypeFlags
+	// ImportMemoryFd or ImportMemoryWin32Handle imports the memory from a handle exported by
+	// another API or process instead of allocating new memory. At most one may be set.
+	ImportMemoryFd          *ImportMemoryFdInfo
+	ImportMemoryWin32Handle *ImportMemoryWin32HandleInfo
 }
 
+// MemoryAllocateFlags represents memory allocation flags
+type MemoryAllocateFlags uint32
+
+const (
+	MemoryAllocateDeviceMaskBit                 MemoryAllocateFlags = C.VK_MEMORY_ALLOCATE_DEVICE_MASK_BIT
+	MemoryAllocateDeviceAddressBit              MemoryAllocateFlags = C.VK_MEMORY_ALLOCATE_DEVICE_ADDRESS_BIT
+	MemoryAllocateDeviceAddressCaptureReplayBit MemoryAllocateFlags = C.VK_MEMORY_ALLOCATE_DEVICE_ADDRESS_CAPTURE_REPLAY_BIT
+)
+
 // MemoryRequirements contains memory requirements
 type MemoryRequirements struct {
 	Size           DeviceSize
@@ -67,6 +145,28 @@ type MemoryRequirements struct {
 	MemoryTypeBits uint32
 }
 
+// MemoryDedicatedRequirements reports whether a resource should get an allocation of its own
+type MemoryDedicatedRequirements struct {
+	// PrefersDedicated indicates that a dedicated allocation may perform better
+	PrefersDedicated bool
+	// RequiresDedicated indicates that the resource must use a dedicated allocation
+	RequiresDedicated bool
+}
+
+// BindBufferMemoryInfo describes one buffer binding for BindBufferMemory2
+type BindBufferMemoryInfo struct {
+	Buffer       Buffer
+	Memory       DeviceMemory
+	MemoryOffset DeviceSize
+}
+
+// BindImageMemoryInfo describes one image binding for BindImageMemory2
+type BindImageMemoryInfo struct {
+	Image        Image
+	Memory       DeviceMemory
+	MemoryOffset DeviceSize
+}
+
 // ImageCreateInfo contains image creation information
 type ImageCreateInfo struct {
 	Flags         ImageCreateFlags
@@ -80,6 +180,17 @@ type ImageCreateInfo struct {
 	Usage         ImageUsageFlags
 	SharingMode   SharingMode
 	InitialLayout ImageLayout
+	// QueueFamilyIndices lists the queue families that access the image when SharingMode
+	// is SharingModeConcurrent. It must hold at least two distinct indices and is ignored
+	// for SharingModeExclusive.
+	QueueFamilyIndices []uint32
+	// ExternalMemoryHandleTypes lists the handle types the image's memory may be exported
+	// or imported as
+	ExternalMemoryHandleTypes ExternalMemoryHandleTypeFlags
+	// ImageCompressionControl requests fixed-rate, default or disabled compression when set.
+	// Requires ExtensionNameImageCompressionControl; GetImageSubresourceLayout2EXT reports
+	// the compression the image got.
+	ImageCompressionControl *ImageCompressionControlEXT
 }
 
 // ImageType represents image types
@@ -176,6 +287,22 @@ const (
 	FormatD32SfloatS8Uint     Format = C.VK_FORMAT_D32_SFLOAT_S8_UINT
 )
 
+// AspectMaskForFormat returns the aspects an image of format has: depth and/or stencil for
+// depth-stencil formats and color for everything else. Barriers and views covering a whole
+// combined depth-stencil image must name both aspects.
+func AspectMaskForFormat(format Format) ImageAspectFlags {
+	switch format {
+	case FormatD16Unorm, FormatX8D24UnormPack32, FormatD32Sfloat:
+		return ImageAspectDepthBit
+	case FormatS8Uint:
+		return ImageAspectStencilBit
+	case FormatD16UnormS8Uint, FormatD24UnormS8Uint, FormatD32SfloatS8Uint:
+		return ImageAspectDepthBit | ImageAspectStencilBit
+	default:
+		return ImageAspectColorBit
+	}
+}
+
 // ImageTiling represents image tiling modes
 type ImageTiling int32
 
@@ -239,31 +366,196 @@ func CreateBuffer(device Device, createInfo *BufferCreateInfo) (Buffer, error) {
 	if createInfo.Usage == 0 {
 		return nil, NewValidationError("Usage", "buffer usage flags cannot be zero")
 	}
+	if createInfo.OpaqueCaptureAddress != 0 && createInfo.Flags&BufferCreateDeviceAddressCaptureReplayBit == 0 {
+		return nil, NewValidationError("OpaqueCaptureAddress", "requires BufferCreateDeviceAddressCaptureReplayBit")
+	}
+	if err := validateSparseBufferFlags(device, createInfo.Flags); err != nil {
+		return nil, err
+	}
+	if err := validateQueueFamilyIndices(createInfo.SharingMode, createInfo.QueueFamilyIndices); err != nil {
+		return nil, err
+	}
 
 	var cCreateInfo C.VkBufferCreateInfo
 	cCreateInfo.sType = C.VK_STRUCTURE_TYPE_BUFFER_CREATE_INFO
 	cCreateInfo.pNext = nil
+	if createInfo.OpaqueCaptureAddress != 0 {
+		cCaptureInfo := (*C.VkBufferOpaqueCaptureAddressCreateInfo)(C.malloc(C.sizeof_VkBufferOpaqueCaptureAddressCreateInfo))
+		if cCaptureInfo == nil {
+			return nil, NewVulkanError(ErrorOutOfHostMemory, "CreateBuffer", "failed to allocate memory for opaque capture address info")
+		}
+		defer C.free(unsafe.Pointer(cCaptureInfo))
+		cCaptureInfo.sType = C.VK_STRUCTURE_TYPE_BUFFER_OPAQUE_CAPTURE_ADDRESS_CREATE_INFO
+		cCaptureInfo.pNext = nil
+		cCaptureInfo.opaqueCaptureAddress = C.uint64_t(createInfo.OpaqueCaptureAddress)
+		cCreateInfo.pNext = unsafe.Pointer(cCaptureInfo)
+	}
+	if createInfo.ExternalMemoryHandleTypes != 0 {
+		cExternalInfo := (*C.VkExternalMemoryBufferCreateInfo)(C.malloc(C.sizeof_VkExternalMemoryBufferCreateInfo))
+		if cExternalInfo == nil {
+			return nil, NewVulkanError(ErrorOutOfHostMemory, "CreateBuffer", "failed to allocate memory for external memory info")
+		}
+		defer C.free(unsafe.Pointer(cExternalInfo))
+		cExternalInfo.sType = C.VK_STRUCTURE_TYPE_EXTERNAL_MEMORY_BUFFER_CREATE_INFO
+		cExternalInfo.pNext = cCreateInfo.pNext
+		cExternalInfo.handleTypes = C.VkExternalMemoryHandleTypeFlags(createInfo.ExternalMemoryHandleTypes)
+		cCreateInfo.pNext = unsafe.Pointer(cExternalInfo)
+	}
 	cCreateInfo.flags = C.VkBufferCreateFlags(createInfo.Flags)
 	cCreateInfo.size = C.VkDeviceSize(createInfo.Size)
 	cCreateInfo.usage = C.VkBufferUsageFlags(createInfo.Usage)
 	cCreateInfo.sharingMode = C.VkSharingMode(createInfo.SharingMode)
-	cCreateInfo.queueFamilyIndexCount = 0
-	cCreateInfo.pQueueFamilyIndices = nil
+	cIndices, cIndexCount, err := queueFamilyIndicesToC(createInfo.SharingMode, createInfo.QueueFamilyIndices)
+	if err != nil {
+		return nil, err
+	}
+	if cIndices != nil {
+		defer C.free(unsafe.Pointer(cIndices))
+	}
+	cCreateInfo.queueFamilyIndexCount = cIndexCount
+	cCreateInfo.pQueueFamilyIndices = cIndices
 
 	var buffer C.VkBuffer
 	result := Result(C.vkCreateBuffer(C.VkDevice(device), &cCreateInfo, nil, &buffer))
+	traceResult("vkCreateBuffer", result)
 	if result != Success {
 		return nil, NewVulkanError(result, "CreateBuffer", "Vulkan buffer creation failed")
 	}
 
+	bufferUsages.add(device, Buffer(buffer), createInfo.Usage)
 	return Buffer(buffer), nil
 }
 
+// validateSparseBufferFlags checks that sparse residency and aliasing are only requested
+// together with sparse binding, and that the device enabled the matching features. The
+// feature check is skipped for devices not created through CreateDevice.
+func validateSparseBufferFlags(device Device, flags BufferCreateFlags) error {
+	const sparseFlags = BufferCreateSparseBindingBit | BufferCreateSparseResidencyBit | BufferCreateSparseAliasedBit
+	if flags&sparseFlags == 0 {
+		return nil
+	}
+	if flags&BufferCreateSparseBindingBit == 0 {
+		return NewValidationError("Flags", "BufferCreateSparseResidencyBit and BufferCreateSparseAliasedBit require BufferCreateSparseBindingBit")
+	}
+
+	features, ok := enabledDeviceFeatures(device)
+	if !ok {
+		return nil
+	}
+	if !features.SparseBinding {
+		return NewValidationError("Flags", "BufferCreateSparseBindingBit requires the SparseBinding feature")
+	}
+	if flags&BufferCreateSparseResidencyBit != 0 && !features.SparseResidencyBuffer {
+		return NewValidationError("Flags", "BufferCreateSparseResidencyBit requires the SparseResidencyBuffer feature")
+	}
+	if flags&BufferCreateSparseAliasedBit != 0 && !features.SparseResidencyAliased {
+		return NewValidationError("Flags", "BufferCreateSparseAliasedBit requires the SparseResidencyAliased feature")
+	}
+	return nil
+}
+
 // DestroyBuffer destroys a buffer
 func DestroyBuffer(device Device, buffer Buffer) {
+	bufferUsages.remove(device, buffer)
 	C.vkDestroyBuffer(C.VkDevice(device), C.VkBuffer(buffer), nil)
 }
 
+// bufferUsageRegistry records the usage flags of buffers created through CreateBuffer so
+// that address queries can be validated without a round trip through the driver. Buffers
+// are tracked per device because drivers recycle handles, and DestroyDevice drops every
+// buffer of the device.
+type bufferUsageRegistry struct {
+	mu      sync.RWMutex
+	devices map[Device]map[Buffer]BufferUsageFlags
+}
+
+var bufferUsages = bufferUsageRegistry{
+	devices: make(map[Device]map[Buffer]BufferUsageFlags),
+}
+
+// add records the usage flags of a buffer created on device
+func (r *bufferUsageRegistry) add(device Device, buffer Buffer, usage BufferUsageFlags) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	buffers, ok := r.devices[device]
+	if !ok {
+		buffers = make(map[Buffer]BufferUsageFlags)
+		r.devices[device] = buffers
+	}
+	buffers[buffer] = usage
+}
+
+// remove forgets a destroyed buffer
+func (r *bufferUsageRegistry) remove(device Device, buffer Buffer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.devices[device], buffer)
+}
+
+// removeDevice forgets every buffer created on device
+func (r *bufferUsageRegistry) removeDevice(device Device) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.devices, device)
+}
+
+// usage returns the usage flags buffer was created with on device, or false if it was not
+// created through CreateBuffer
+func (r *bufferUsageRegistry) usage(device Device, buffer Buffer) (BufferUsageFlags, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	usage, ok := r.devices[device][buffer]
+	return usage, ok
+}
+
+// GetBufferDeviceAddress returns the device address of a buffer. The buffer must have
+// been created with BufferUsageShaderDeviceAddressBit and bound to memory allocated
+// with MemoryAllocateDeviceAddressBit.
+func GetBufferDeviceAddress(device Device, buffer Buffer) (DeviceAddress, error) {
+	if device == nil {
+		return 0, NewValidationError("device", "cannot be nil")
+	}
+	if buffer == nil {
+		return 0, NewValidationError("buffer", "cannot be nil")
+	}
+	if usage, ok := bufferUsages.usage(device, buffer); ok && usage&BufferUsageShaderDeviceAddressBit == 0 {
+		return 0, NewValidationError("buffer", "must be created with BufferUsageShaderDeviceAddressBit")
+	}
+
+	var cInfo C.VkBufferDeviceAddressInfo
+	cInfo.sType = C.VK_STRUCTURE_TYPE_BUFFER_DEVICE_ADDRESS_INFO
+	cInfo.pNext = nil
+	cInfo.buffer = C.VkBuffer(buffer)
+
+	return DeviceAddress(C.vkGetBufferDeviceAddress(C.VkDevice(device), &cInfo)), nil
+}
+
+// GetBufferOpaqueCaptureAddress returns the opaque capture address of a buffer for
+// use with BufferCreateInfo.OpaqueCaptureAddress during capture replay
+func GetBufferOpaqueCaptureAddress(device Device, buffer Buffer) uint64 {
+	var cInfo C.VkBufferDeviceAddressInfo
+	cInfo.sType = C.VK_STRUCTURE_TYPE_BUFFER_DEVICE_ADDRESS_INFO
+	cInfo.pNext = nil
+	cInfo.buffer = C.VkBuffer(buffer)
+
+	return uint64(C.vkGetBufferOpaqueCaptureAddress(C.VkDevice(device), &cInfo))
+}
+
+// GetDeviceMemoryOpaqueCaptureAddress returns the opaque capture address of a memory
+// allocation for use with MemoryAllocateInfo.OpaqueCaptureAddress during capture replay
+func GetDeviceMemoryOpaqueCaptureAddress(device Device, memory DeviceMemory) uint64 {
+	var cInfo C.VkDeviceMemoryOpaqueCaptureAddressInfo
+	cInfo.sType = C.VK_STRUCTURE_TYPE_DEVICE_MEMORY_OPAQUE_CAPTURE_ADDRESS_INFO
+	cInfo.pNext = nil
+	cInfo.memory = C.VkDeviceMemory(memory)
+
+	return uint64(C.vkGetDeviceMemoryOpaqueCaptureAddress(C.VkDevice(device), &cInfo))
+}
+
 // GetBufferMemoryRequirements gets buffer memory requirements
 func GetBufferMemoryRequirements(device Device, buffer Buffer) MemoryRequirements {
 	var cReqs C.VkMemoryRequirements
@@ -278,14 +570,100 @@ func GetBufferMemoryRequirements(device Device, buffer Buffer) MemoryRequirement
 
 // AllocateMemory allocates device memory
 func AllocateMemory(device Device, allocateInfo *MemoryAllocateInfo) (DeviceMemory, error) {
+	if allocateInfo.DedicatedImage != nil && allocateInfo.DedicatedBuffer != nil {
+		return nil, NewValidationError("allocateInfo", "DedicatedImage and DedicatedBuffer cannot both be set")
+	}
+	if allocateInfo.ImportMemoryFd != nil && allocateInfo.ImportMemoryWin32Handle != nil {
+		return nil, NewValidationError("allocateInfo", "ImportMemoryFd and ImportMemoryWin32Handle cannot both be set")
+	}
+	if allocateInfo.Flags&MemoryAllocateDeviceMaskBit != 0 && allocateInfo.DeviceMask == 0 {
+		return nil, NewValidationError("DeviceMask", "cannot be 0 when MemoryAllocateDeviceMaskBit is set")
+	}
+	if allocateInfo.Flags&MemoryAllocateDeviceMaskBit == 0 && allocateInfo.DeviceMask != 0 {
+		return nil, NewValidationError("DeviceMask", "requires MemoryAllocateDeviceMaskBit")
+	}
+	if allocateInfo.ImportMemoryFd != nil {
+		if err := validateImportMemoryFdInfo(allocateInfo.ImportMemoryFd); err != nil {
+			return nil, err
+		}
+	}
+
 	var cAllocateInfo C.VkMemoryAllocateInfo
 	cAllocateInfo.sType = C.VK_STRUCTURE_TYPE_MEMORY_ALLOCATE_INFO
 	cAllocateInfo.pNext = nil
 	cAllocateInfo.allocationSize = C.VkDeviceSize(allocateInfo.AllocationSize)
 	cAllocateInfo.memoryTypeIndex = C.uint32_t(allocateInfo.MemoryTypeIndex)
 
+	if allocateInfo.Flags != 0 {
+		cFlagsInfo := (*C.VkMemoryAllocateFlagsInfo)(C.malloc(C.sizeof_VkMemoryAllocateFlagsInfo))
+		if cFlagsInfo == nil {
+			return nil, NewVulkanError(ErrorOutOfHostMemory, "AllocateMemory", "failed to allocate memory for allocate flags info")
+		}
+		defer C.free(unsafe.Pointer(cFlagsInfo))
+		cFlagsInfo.sType = C.VK_STRUCTURE_TYPE_MEMORY_ALLOCATE_FLAGS_INFO
+		cFlagsInfo.pNext = cAllocateInfo.pNext
+		cFlagsInfo.flags = C.VkMemoryAllocateFlags(allocateInfo.Flags)
+		cFlagsInfo.deviceMask = C.uint32_t(allocateInfo.DeviceMask)
+		cAllocateInfo.pNext = unsafe.Pointer(cFlagsInfo)
+	}
+	if allocateInfo.OpaqueCaptureAddress != 0 {
+		cCaptureInfo := (*C.VkMemoryOpaqueCaptureAddressAllocateInfo)(C.malloc(C.sizeof_VkMemoryOpaqueCaptureAddressAllocateInfo))
+		if cCaptureInfo == nil {
+			return nil, NewVulkanError(ErrorOutOfHostMemory, "AllocateMemory", "failed to allocate memory for opaque capture address info")
+		}
+		defer C.free(unsafe.Pointer(cCaptureInfo))
+		cCaptureInfo.sType = C.VK_STRUCTURE_TYPE_MEMORY_OPAQUE_CAPTURE_ADDRESS_ALLOCATE_INFO
+		cCaptureInfo.pNext = cAllocateInfo.pNext
+		cCaptureInfo.opaqueCaptureAddress = C.uint64_t(allocateInfo.OpaqueCaptureAddress)
+		cAllocateInfo.pNext = unsafe.Pointer(cCaptureInfo)
+	}
+	if allocateInfo.DedicatedImage != nil || allocateInfo.DedicatedBuffer != nil {
+		cDedicatedInfo := (*C.VkMemoryDedicatedAllocateInfo)(C.malloc(C.sizeof_VkMemoryDedicatedAllocateInfo))
+		if cDedicatedInfo == nil {
+			return nil, NewVulkanError(ErrorOutOfHostMemory, "AllocateMemory", "failed to allocate memory for dedicated allocate info")
+		}
+		defer C.free(unsafe.Pointer(cDedicatedInfo))
+		cDedicatedInfo.sType = C.VK_STRUCTURE_TYPE_MEMORY_DEDICATED_ALLOCATE_INFO
+		cDedicatedInfo.pNext = cAllocateInfo.pNext
+		cDedicatedInfo.image = C.VkImage(allocateInfo.DedicatedImage)
+		cDedicatedInfo.buffer = C.VkBuffer(allocateInfo.DedicatedBuffer)
+		cAllocateInfo.pNext = unsafe.Pointer(cDedicatedInfo)
+	}
+	if allocateInfo.ExportHandleTypes != 0 {
+		cExportInfo := (*C.VkExportMemoryAllocateInfo)(C.malloc(C.sizeof_VkExportMemoryAllocateInfo))
+		if cExportInfo == nil {
+			return nil, NewVulkanError(ErrorOutOfHostMemory, "AllocateMemory", "failed to allocate memory for export memory info")
+		}
+		defer C.free(unsafe.Pointer(cExportInfo))
+		cExportInfo.sType = C.VK_STRUCTURE_TYPE_EXPORT_MEMORY_ALLOCATE_INFO
+		cExportInfo.pNext = cAllocateInfo.pNext
+		cExportInfo.handleTypes = C.VkExternalMemoryHandleTypeFlags(allocateInfo.ExportHandleTypes)
+		cAllocateInfo.pNext = unsafe.Pointer(cExportInfo)
+	}
+	if allocateInfo.ImportMemoryFd != nil {
+		cImportInfo := (*C.VkImportMemoryFdInfoKHR)(C.malloc(C.sizeof_VkImportMemoryFdInfoKHR))
+		if cImportInfo == nil {
+			return nil, NewVulkanError(ErrorOutOfHostMemory, "AllocateMemory", "failed to allocate memory for import memory info")
+		}
+		defer C.free(unsafe.Pointer(cImportInfo))
+		cImportInfo.sType = C.VK_STRUCTURE_TYPE_IMPORT_MEMORY_FD_INFO_KHR
+		cImportInfo.pNext = cAllocateInfo.pNext
+		cImportInfo.handleType = C.VkExternalMemoryHandleTypeFlagBits(allocateInfo.ImportMemoryFd.HandleType)
+		cImportInfo.fd = C.int(allocateInfo.ImportMemoryFd.Fd)
+		cAllocateInfo.pNext = unsafe.Pointer(cImportInfo)
+	}
+	if allocateInfo.ImportMemoryWin32Handle != nil {
+		cImportInfo, err := importMemoryWin32HandleInfoToC(allocateInfo.ImportMemoryWin32Handle, cAllocateInfo.pNext)
+		if err != nil {
+			return nil, err
+		}
+		defer C.free(cImportInfo)
+		cAllocateInfo.pNext = cImportInfo
+	}
+
 	var memory C.VkDeviceMemory
 	result := Result(C.vkAllocateMemory(C.VkDevice(device), &cAllocateInfo, nil, &memory))
+	traceResult("vkAllocateMemory", result)
 	if result != Success {
 		return nil, result
 	}
@@ -301,6 +679,7 @@ func FreeMemory(device Device, memory DeviceMemory) {
 // BindBufferMemory binds buffer memory
 func BindBufferMemory(device Device, buffer Buffer, memory DeviceMemory, memoryOffset DeviceSize) error {
 	result := Result(C.vkBindBufferMemory(C.VkDevice(device), C.VkBuffer(buffer), C.VkDeviceMemory(memory), C.VkDeviceSize(memoryOffset)))
+	traceResult("vkBindBufferMemory", result)
 	if result != Success {
 		return result
 	}
@@ -311,6 +690,7 @@ func BindBufferMemory(device Device, buffer Buffer, memory DeviceMemory, memoryO
 func MapMemory(device Device, memory DeviceMemory, offset, size DeviceSize, flags uint32) (unsafe.Pointer, error) {
 	var data unsafe.Pointer
 	result := Result(C.vkMapMemory(C.VkDevice(device), C.VkDeviceMemory(memory), C.VkDeviceSize(offset), C.VkDeviceSize(size), C.VkMemoryMapFlags(flags), &data))
+	traceResult("vkMapMemory", result)
 	if result != Success {
 		return nil, result
 	}
@@ -324,9 +704,44 @@ func UnmapMemory(device Device, memory DeviceMemory) {
 
 // CreateImage creates an image
 func CreateImage(device Device, createInfo *ImageCreateInfo) (Image, error) {
+	if err := validateQueueFamilyIndices(createInfo.SharingMode, createInfo.QueueFamilyIndices); err != nil {
+		return nil, err
+	}
+	if createInfo.ImageCompressionControl != nil {
+		if err := validateImageCompressionControl("createInfo.ImageCompressionControl", createInfo.ImageCompressionControl); err != nil {
+			return nil, err
+		}
+	}
+	cIndices, cIndexCount, err := queueFamilyIndicesToC(createInfo.SharingMode, createInfo.QueueFamilyIndices)
+	if err != nil {
+		return nil, err
+	}
+	if cIndices != nil {
+		defer C.free(unsafe.Pointer(cIndices))
+	}
+
+	var allocations []unsafe.Pointer
+	defer func() { freeAllocations(allocations) }()
+
 	var cCreateInfo C.VkImageCreateInfo
 	cCreateInfo.sType = C.VK_STRUCTURE_TYPE_IMAGE_CREATE_INFO
 	cCreateInfo.pNext = nil
+	if createInfo.ExternalMemoryHandleTypes != 0 {
+		cExternalInfo := (*C.VkExternalMemoryImageCreateInfo)(C.malloc(C.sizeof_VkExternalMemoryImageCreateInfo))
+		if cExternalInfo == nil {
+			return nil, NewVulkanError(ErrorOutOfHostMemory, "CreateImage", "failed to allocate memory for external memory info")
+		}
+		defer C.free(unsafe.Pointer(cExternalInfo))
+		cExternalInfo.sType = C.VK_STRUCTURE_TYPE_EXTERNAL_MEMORY_IMAGE_CREATE_INFO
+		cExternalInfo.pNext = nil
+		cExternalInfo.handleTypes = C.VkExternalMemoryHandleTypeFlags(createInfo.ExternalMemoryHandleTypes)
+		cCreateInfo.pNext = unsafe.Pointer(cExternalInfo)
+	}
+	if createInfo.ImageCompressionControl != nil {
+		if cCreateInfo.pNext, err = imageCompressionControlToC("CreateImage", createInfo.ImageCompressionControl, cCreateInfo.pNext, &allocations); err != nil {
+			return nil, err
+		}
+	}
 	cCreateInfo.flags = C.VkImageCreateFlags(createInfo.Flags)
 	cCreateInfo.imageType = C.VkImageType(createInfo.ImageType)
 	cCreateInfo.format = C.VkFormat(createInfo.Format)
@@ -339,12 +754,13 @@ func CreateImage(device Device, createInfo *ImageCreateInfo) (Image, error) {
 	cCreateInfo.tiling = C.VkImageTiling(createInfo.Tiling)
 	cCreateInfo.usage = C.VkImageUsageFlags(createInfo.Usage)
 	cCreateInfo.sharingMode = C.VkSharingMode(createInfo.SharingMode)
-	cCreateInfo.queueFamilyIndexCount = 0
-	cCreateInfo.pQueueFamilyIndices = nil
+	cCreateInfo.queueFamilyIndexCount = cIndexCount
+	cCreateInfo.pQueueFamilyIndices = cIndices
 	cCreateInfo.initialLayout = C.VkImageLayout(createInfo.InitialLayout)
 
 	var image C.VkImage
 	result := Result(C.vkCreateImage(C.VkDevice(device), &cCreateInfo, nil, &image))
+	traceResult("vkCreateImage", result)
 	if result != Success {
 		return nil, result
 	}
@@ -369,17 +785,203 @@ func GetImageMemoryRequirements(device Device, image Image) MemoryRequirements {
 	}
 }
 
+// GetBufferMemoryRequirements2 gets buffer memory requirements along with whether the
+// buffer prefers or requires a dedicated allocation (Vulkan 1.1)
+func GetBufferMemoryRequirements2(device Device, buffer Buffer) (MemoryRequirements, MemoryDedicatedRequirements) {
+	cInfo := C.VkBufferMemoryRequirementsInfo2{
+		sType:  C.VK_STRUCTURE_TYPE_BUFFER_MEMORY_REQUIREMENTS_INFO_2,
+		buffer: C.VkBuffer(buffer),
+	}
+
+	cReqs, cDedicated := newMemoryRequirements2()
+	if cReqs == nil {
+		return MemoryRequirements{}, MemoryDedicatedRequirements{}
+	}
+	defer C.free(unsafe.Pointer(cReqs))
+	defer C.free(unsafe.Pointer(cDedicated))
+
+	C.vkGetBufferMemoryRequirements2(C.VkDevice(device), &cInfo, cReqs)
+	return memoryRequirements2FromC(cReqs, cDedicated)
+}
+
+// GetImageMemoryRequirements2 gets image memory requirements along with whether the
+// image prefers or requires a dedicated allocation (Vulkan 1.1)
+func GetImageMemoryRequirements2(device Device, image Image) (MemoryRequirements, MemoryDedicatedRequirements) {
+	cInfo := C.VkImageMemoryRequirementsInfo2{
+		sType: C.VK_STRUCTURE_TYPE_IMAGE_MEMORY_REQUIREMENTS_INFO_2,
+		image: C.VkImage(image),
+	}
+
+	cReqs, cDedicated := newMemoryRequirements2()
+	if cReqs == nil {
+		return MemoryRequirements{}, MemoryDedicatedRequirements{}
+	}
+	defer C.free(unsafe.Pointer(cReqs))
+	defer C.free(unsafe.Pointer(cDedicated))
+
+	C.vkGetImageMemoryRequirements2(C.VkDevice(device), &cInfo, cReqs)
+	return memoryRequirements2FromC(cReqs, cDedicated)
+}
+
+// newMemoryRequirements2 allocates a VkMemoryRequirements2 chained to a
+// VkMemoryDedicatedRequirements in C memory. It returns nils if allocation fails;
+// otherwise the caller must free both.
+func newMemoryRequirements2() (*C.VkMemoryRequirements2, *C.VkMemoryDedicatedRequirements) {
+	cReqs := (*C.VkMemoryRequirements2)(C.calloc(1, C.sizeof_VkMemoryRequirements2))
+	cDedicated := (*C.VkMemoryDedicatedRequirements)(C.calloc(1, C.sizeof_VkMemoryDedicatedRequirements))
+	if cReqs == nil || cDedicated == nil {
+		C.free(unsafe.Pointer(cReqs))
+		C.free(unsafe.Pointer(cDedicated))
+		return nil, nil
+	}
+
+	cDedicated.sType = C.VK_STRUCTURE_TYPE_MEMORY_DEDICATED_REQUIREMENTS
+	cReqs.sType = C.VK_STRUCTURE_TYPE_MEMORY_REQUIREMENTS_2
+	cReqs.pNext = unsafe.Pointer(cDedicated)
+	return cReqs, cDedicated
+}
+
+// memoryRequirements2FromC converts the results filled in by a *MemoryRequirements2 query
+func memoryRequirements2FromC(cReqs *C.VkMemoryRequirements2, cDedicated *C.VkMemoryDedicatedRequirements) (MemoryRequirements, MemoryDedicatedRequirements) {
+	reqs := MemoryRequirements{
+		Size:           DeviceSize(cReqs.memoryRequirements.size),
+		Alignment:      DeviceSize(cReqs.memoryRequirements.alignment),
+		MemoryTypeBits: uint32(cReqs.memoryRequirements.memoryTypeBits),
+	}
+	dedicated := MemoryDedicatedRequirements{
+		PrefersDedicated:  vkBool32ToBool(cDedicated.prefersDedicatedAllocation),
+		RequiresDedicated: vkBool32ToBool(cDedicated.requiresDedicatedAllocation),
+	}
+	return reqs, dedicated
+}
+
+// SubresourceLayout describes where a subresource of a linear-tiled image lives in its
+// bound memory. All values are in bytes relative to the start of the image's memory binding.
+type SubresourceLayout struct {
+	Offset     DeviceSize
+	Size       DeviceSize
+	RowPitch   DeviceSize
+	ArrayPitch DeviceSize
+	DepthPitch DeviceSize
+}
+
+// GetImageSubresourceLayout returns the memory layout of one subresource of an image created
+// with ImageTilingLinear. Rows of mapped memory are RowPitch bytes apart, which may be larger
+// than width times the texel size, so mapped linear images must be read row by row.
+func GetImageSubresourceLayout(device Device, image Image, subresource ImageSubresource) SubresourceLayout {
+	cSubresource := C.VkImageSubresource{
+		aspectMask: C.VkImageAspectFlags(subresource.AspectMask),
+		mipLevel:   C.uint32_t(subresource.MipLevel),
+		arrayLayer: C.uint32_t(subresource.ArrayLayer),
+	}
+
+	var cLayout C.VkSubresourceLayout
+	C.vkGetImageSubresourceLayout(C.VkDevice(device), C.VkImage(image), &cSubresource, &cLayout)
+
+	return SubresourceLayout{
+		Offset:     DeviceSize(cLayout.offset),
+		Size:       DeviceSize(cLayout.size),
+		RowPitch:   DeviceSize(cLayout.rowPitch),
+		ArrayPitch: DeviceSize(cLayout.arrayPitch),
+		DepthPitch: DeviceSize(cLayout.depthPitch),
+	}
+}
+
 // BindImageMemory binds image memory
 func BindImageMemory(device Device, image Image, memory DeviceMemory, memoryOffset DeviceSize) error {
 	result := Result(C.vkBindImageMemory(C.VkDevice(device), C.VkImage(image), C.VkDeviceMemory(memory), C.VkDeviceSize(memoryOffset)))
+	traceResult("vkBindImageMemory", result)
 	if result != Success {
 		return result
 	}
 	return nil
 }
 
+// BindBufferMemory2 binds memory to many buffers in a single call (Vulkan 1.1). Binding a
+// batch is cheaper than one BindBufferMemory call per buffer when creating thousands of
+// resources. An empty batch is a no-op.
+func BindBufferMemory2(device Device, bindInfos []BindBufferMemoryInfo) error {
+	if device == nil {
+		return NewValidationError("device", "cannot be nil")
+	}
+	if len(bindInfos) == 0 {
+		return nil
+	}
+
+	cBindInfos := make([]C.VkBindBufferMemoryInfo, len(bindInfos))
+	for i, info := range bindInfos {
+		if info.Buffer == nil {
+			return NewValidationError(fmt.Sprintf("bindInfos[%d].Buffer", i), "cannot be nil")
+		}
+		if info.Memory == nil {
+			return NewValidationError(fmt.Sprintf("bindInfos[%d].Memory", i), "cannot be nil")
+		}
+		cBindInfos[i].sType = C.VK_STRUCTURE_TYPE_BIND_BUFFER_MEMORY_INFO
+		cBindInfos[i].buffer = C.VkBuffer(info.Buffer)
+		cBindInfos[i].memory = C.VkDeviceMemory(info.Memory)
+		cBindInfos[i].memoryOffset = C.VkDeviceSize(info.MemoryOffset)
+	}
+
+	result := Result(C.vkBindBufferMemory2(C.VkDevice(device), C.uint32_t(len(cBindInfos)), &cBindInfos[0]))
+	traceResult("vkBindBufferMemory2", result)
+	if result != Success {
+		return NewVulkanError(result, "BindBufferMemory2", fmt.Sprintf("failed to bind memory to %d buffers", len(bindInfos)))
+	}
+	return nil
+}
+
+// BindImageMemory2 binds memory to many images in a single call (Vulkan 1.1). An empty
+// batch is a no-op.
+func BindImageMemory2(device Device, bindInfos []BindImageMemoryInfo) error {
+	if device == nil {
+		return NewValidationError("device", "cannot be nil")
+	}
+	if len(bindInfos) == 0 {
+		return nil
+	}
+
+	cBindInfos := make([]C.VkBindImageMemoryInfo, len(bindInfos))
+	for i, info := range bindInfos {
+		if info.Image == nil {
+			return NewValidationError(fmt.Sprintf("bindInfos[%d].Image", i), "cannot be nil")
+		}
+		if info.Memory == nil {
+			return NewValidationError(fmt.Sprintf("bindInfos[%d].Memory", i), "cannot be nil")
+		}
+		cBindInfos[i].sType = C.VK_STRUCTURE_TYPE_BIND_IMAGE_MEMORY_INFO
+		cBindInfos[i].image = C.VkImage(info.Image)
+		cBindInfos[i].memory = C.VkDeviceMemory(info.Memory)
+		cBindInfos[i].memoryOffset = C.VkDeviceSize(info.MemoryOffset)
+	}
+
+	result := Result(C.vkBindImageMemory2(C.VkDevice(device), C.uint32_t(len(cBindInfos)), &cBindInfos[0]))
+	traceResult("vkBindImageMemory2", result)
+	if result != Success {
+		return NewVulkanError(result, "BindImageMemory2", fmt.Sprintf("failed to bind memory to %d images", len(bindInfos)))
+	}
+	return nil
+}
+
 // FindMemoryType finds a suitable memory type
 func FindMemoryType(memProperties PhysicalDeviceMemoryProperties, typeFilter uint32, properties MemoryPropertyFlags) (uint32, bool) {
+	return FindMemoryTypeWithFallback(memProperties, typeFilter, properties, 0)
+}
+
+// FindMemoryTypeWithFallback finds a memory type that has all of the required flags, preferring
+// one that also has the preferred flags. For example required HostVisible with preferred
+// DeviceLocal picks device-local host-visible memory on integrated GPUs and plain host-visible
+// memory elsewhere.
+func FindMemoryTypeWithFallback(memProperties PhysicalDeviceMemoryProperties, typeFilter uint32, required, preferred MemoryPropertyFlags) (uint32, bool) {
+	if preferred != 0 {
+		if index, ok := findMemoryTypeIndex(memProperties, typeFilter, required|preferred); ok {
+			return index, true
+		}
+	}
+	return findMemoryTypeIndex(memProperties, typeFilter, required)
+}
+
+// findMemoryTypeIndex returns the first allowed memory type that has all of the given flags
+func findMemoryTypeIndex(memProperties PhysicalDeviceMemoryProperties, typeFilter uint32, properties MemoryPropertyFlags) (uint32, bool) {
 	for i := uint32(0); i < memProperties.MemoryTypeCount; i++ {
 		if (typeFilter&(1<<i)) != 0 && (memProperties.MemoryTypes[i].PropertyFlags&properties) == properties {
 			return i, true
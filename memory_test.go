@@ -0,0 +1,256 @@
+//go:build cgo
+
+package vulkan
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestFindMemoryTypeWithFallback tests preferred flag selection and fallback to required flags
+func TestFindMemoryTypeWithFallback(t *testing.T) {
+	memProperties := PhysicalDeviceMemoryProperties{MemoryTypeCount: 3}
+	memProperties.MemoryTypes[0].PropertyFlags = MemoryPropertyDeviceLocalBit
+	memProperties.MemoryTypes[1].PropertyFlags = MemoryPropertyHostVisibleBit | MemoryPropertyHostCoherentBit
+	memProperties.MemoryTypes[2].PropertyFlags = MemoryPropertyDeviceLocalBit | MemoryPropertyHostVisibleBit
+
+	tests := []struct {
+		name       string
+		typeFilter uint32
+		required   MemoryPropertyFlags
+		preferred  MemoryPropertyFlags
+		wantIndex  uint32
+		wantFound  bool
+	}{
+		{
+			name:       "preferred available",
+			typeFilter: 0b111,
+			required:   MemoryPropertyHostVisibleBit,
+			preferred:  MemoryPropertyDeviceLocalBit,
+			wantIndex:  2,
+			wantFound:  true,
+		},
+		{
+			name:       "preferred filtered out",
+			typeFilter: 0b011,
+			required:   MemoryPropertyHostVisibleBit,
+			preferred:  MemoryPropertyDeviceLocalBit,
+			wantIndex:  1,
+			wantFound:  true,
+		},
+		{
+			name:       "no preference",
+			typeFilter: 0b111,
+			required:   MemoryPropertyDeviceLocalBit,
+			wantIndex:  0,
+			wantFound:  true,
+		},
+		{
+			name:       "required missing",
+			typeFilter: 0b001,
+			required:   MemoryPropertyHostVisibleBit,
+			preferred:  MemoryPropertyDeviceLocalBit,
+			wantFound:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			index, found := FindMemoryTypeWithFallback(memProperties, tt.typeFilter, tt.required, tt.preferred)
+			if found != tt.wantFound {
+				t.Fatalf("Expected found=%v, got %v", tt.wantFound, found)
+			}
+			if found && index != tt.wantIndex {
+				t.Errorf("Expected memory type %d, got %d", tt.wantIndex, index)
+			}
+		})
+	}
+}
+
+// TestValidateQueueFamilyIndices tests queue family index validation for concurrent sharing
+func TestValidateQueueFamilyIndices(t *testing.T) {
+	tests := []struct {
+		name        string
+		sharingMode SharingMode
+		indices     []uint32
+		expectError bool
+	}{
+		{"exclusive ignores indices", SharingModeExclusive, []uint32{0}, false},
+		{"concurrent with two families", SharingModeConcurrent, []uint32{0, 2}, false},
+		{"concurrent without indices", SharingModeConcurrent, nil, true},
+		{"concurrent with one family", SharingModeConcurrent, []uint32{1}, true},
+		{"concurrent with duplicate family", SharingModeConcurrent, []uint32{1, 1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateQueueFamilyIndices(tt.sharingMode, tt.indices)
+			if !tt.expectError {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != "QueueFamilyIndices" {
+				t.Errorf("Expected error for parameter 'QueueFamilyIndices', got '%s'", validationErr.Parameter)
+			}
+		})
+	}
+
+	// CreateBuffer rejects invalid concurrent sharing before calling into Vulkan
+	_, err := CreateBuffer(Device(uintptr(0x1234)), &BufferCreateInfo{
+		Size:               256,
+		Usage:              BufferUsageStorageBufferBit,
+		SharingMode:        SharingModeConcurrent,
+		QueueFamilyIndices: []uint32{0},
+	})
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Parameter != "QueueFamilyIndices" {
+		t.Errorf("Expected QueueFamilyIndices ValidationError from CreateBuffer, got %v", err)
+	}
+}
+
+// TestAllocateMemoryDedicatedValidation tests that a dedicated allocation names a single resource
+func TestAllocateMemoryDedicatedValidation(t *testing.T) {
+	_, err := AllocateMemory(Device(uintptr(0x1234)), &MemoryAllocateInfo{
+		AllocationSize:  1024,
+		DedicatedImage:  Image(uintptr(0x5678)),
+		DedicatedBuffer: Buffer(uintptr(0x9abc)),
+	})
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+	}
+	if validationErr.Parameter != "allocateInfo" {
+		t.Errorf("Expected error for parameter 'allocateInfo', got '%s'", validationErr.Parameter)
+	}
+}
+
+// TestBufferUsagesPerDevice tests that recorded buffer usage is scoped to the creating
+// device and dropped with it
+func TestBufferUsagesPerDevice(t *testing.T) {
+	fakeDevice := Device(uintptr(0x4321))
+	otherDevice := Device(uintptr(0x8765))
+	fakeBuffer := Buffer(uintptr(0x5000))
+
+	bufferUsages.add(fakeDevice, fakeBuffer, BufferUsageVertexBufferBit)
+	defer bufferUsages.removeDevice(fakeDevice)
+
+	_, err := GetBufferDeviceAddress(fakeDevice, fakeBuffer)
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+	}
+	if validationErr.Parameter != "buffer" {
+		t.Errorf("Expected error for parameter 'buffer', got '%s'", validationErr.Parameter)
+	}
+
+	if _, ok := bufferUsages.usage(otherDevice, fakeBuffer); ok {
+		t.Error("Expected buffer usage to be scoped to the creating device")
+	}
+	bufferUsages.removeDevice(fakeDevice)
+	if _, ok := bufferUsages.usage(fakeDevice, fakeBuffer); ok {
+		t.Error("Expected buffer usage to be dropped with the device")
+	}
+}
+
+// TestBindMemory2Validation tests device and per-entry validation of batched binds
+func TestBindMemory2Validation(t *testing.T) {
+	fakeDevice := Device(uintptr(0x1234))
+	fakeMemory := DeviceMemory(uintptr(0x5678))
+
+	tests := []struct {
+		name       string
+		call       func() error
+		errorParam string
+	}{
+		{
+			name: "nil device",
+			call: func() error {
+				return BindBufferMemory2(nil, []BindBufferMemoryInfo{{Buffer: Buffer(uintptr(0x9abc)), Memory: fakeMemory}})
+			},
+			errorParam: "device",
+		},
+		{
+			name: "nil buffer",
+			call: func() error {
+				return BindBufferMemory2(fakeDevice, []BindBufferMemoryInfo{
+					{Buffer: Buffer(uintptr(0x9abc)), Memory: fakeMemory},
+					{Memory: fakeMemory, MemoryOffset: 256},
+				})
+			},
+			errorParam: "bindInfos[1].Buffer",
+		},
+		{
+			name: "nil buffer memory",
+			call: func() error {
+				return BindBufferMemory2(fakeDevice, []BindBufferMemoryInfo{{Buffer: Buffer(uintptr(0x9abc))}})
+			},
+			errorParam: "bindInfos[0].Memory",
+		},
+		{
+			name: "nil image",
+			call: func() error {
+				return BindImageMemory2(fakeDevice, []BindImageMemoryInfo{{Memory: fakeMemory}})
+			},
+			errorParam: "bindInfos[0].Image",
+		},
+		{
+			name: "nil image memory",
+			call: func() error {
+				return BindImageMemory2(fakeDevice, []BindImageMemoryInfo{{Image: Image(uintptr(0x9abc))}})
+			},
+			errorParam: "bindInfos[0].Memory",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+
+	if err := BindBufferMemory2(fakeDevice, nil); err != nil {
+		t.Errorf("Expected no error for empty buffer batch, got %v", err)
+	}
+	if err := BindImageMemory2(fakeDevice, nil); err != nil {
+		t.Errorf("Expected no error for empty image batch, got %v", err)
+	}
+}
+
+// TestAspectMaskForFormat tests the aspect mask derived from color, depth and stencil formats
+func TestAspectMaskForFormat(t *testing.T) {
+	tests := []struct {
+		format   Format
+		expected ImageAspectFlags
+	}{
+		{FormatR8G8B8A8Unorm, ImageAspectColorBit},
+		{FormatB8G8R8A8Srgb, ImageAspectColorBit},
+		{FormatD16Unorm, ImageAspectDepthBit},
+		{FormatX8D24UnormPack32, ImageAspectDepthBit},
+		{FormatD32Sfloat, ImageAspectDepthBit},
+		{FormatS8Uint, ImageAspectStencilBit},
+		{FormatD16UnormS8Uint, ImageAspectDepthBit | ImageAspectStencilBit},
+		{FormatD24UnormS8Uint, ImageAspectDepthBit | ImageAspectStencilBit},
+		{FormatD32SfloatS8Uint, ImageAspectDepthBit | ImageAspectStencilBit},
+	}
+
+	for _, tt := range tests {
+		if got := AspectMaskForFormat(tt.format); got != tt.expected {
+			t.Errorf("AspectMaskForFormat(%d) = %d, expected %d", tt.format, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package vulkan
+
+/*
+#include <vulkan/vulkan.h>
+#include <stdlib.h>
+
+// Function pointers for mesh shader EXT extension functions
+// These need to be loaded dynamically at runtime.
+//
+// IMPORTANT: These are global static pointers and NOT thread-safe during loading.
+// LoadMeshShaderFunctions must be called from a single thread during initialization
+// before any concurrent mesh shader API usage.
+static PFN_vkCmdDrawMeshTasksEXT pfn_vkCmdDrawMeshTasksEXT = NULL;
+static PFN_vkCmdDrawMeshTasksIndirectEXT pfn_vkCmdDrawMeshTasksIndirectEXT = NULL;
+
+static int loadMeshShaderDeviceFunctions(VkDevice device) {
+    if (device == VK_NULL_HANDLE) {
+        return 0;
+    }
+    pfn_vkCmdDrawMeshTasksEXT = (PFN_vkCmdDrawMeshTasksEXT)
+        vkGetDeviceProcAddr(device, "vkCmdDrawMeshTasksEXT");
+    pfn_vkCmdDrawMeshTasksIndirectEXT = (PFN_vkCmdDrawMeshTasksIndirectEXT)
+        vkGetDeviceProcAddr(device, "vkCmdDrawMeshTasksIndirectEXT");
+
+    return pfn_vkCmdDrawMeshTasksEXT != NULL &&
+           pfn_vkCmdDrawMeshTasksIndirectEXT != NULL;
+}
+
+// Command buffer wrapper functions return 1 on success, 0 if function pointer is NULL.
+static int call_vkCmdDrawMeshTasksEXT(
+    VkCommandBuffer commandBuffer,
+    uint32_t groupCountX,
+    uint32_t groupCountY,
+    uint32_t groupCountZ) {
+    if (pfn_vkCmdDrawMeshTasksEXT == NULL) {
+        return 0;
+    }
+    pfn_vkCmdDrawMeshTasksEXT(commandBuffer, groupCountX, groupCountY, groupCountZ);
+    return 1;
+}
+
+static int call_vkCmdDrawMeshTasksIndirectEXT(
+    VkCommandBuffer commandBuffer,
+    VkBuffer buffer,
+    VkDeviceSize offset,
+    uint32_t drawCount,
+    uint32_t stride) {
+    if (pfn_vkCmdDrawMeshTasksIndirectEXT == NULL) {
+        return 0;
+    }
+    pfn_vkCmdDrawMeshTasksIndirectEXT(commandBuffer, buffer, offset, drawCount, stride);
+    return 1;
+}
+*/
+import "C"
+
+import (
+	"unsafe"
+)
+
+// ExtensionNameMeshShader is the mesh shader extension name
+const ExtensionNameMeshShader = "VK_EXT_mesh_shader"
+
+// Mesh shader stages
+const (
+	ShaderStageTaskBitEXT ShaderStageFlags = C.VK_SHADER_STAGE_TASK_BIT_EXT
+	ShaderStageMeshBitEXT ShaderStageFlags = C.VK_SHADER_STAGE_MESH_BIT_EXT
+)
+
+// Mesh shader pipeline stages
+const (
+	PipelineStageTaskShaderBitEXT PipelineStageFlags = C.VK_PIPELINE_STAGE_TASK_SHADER_BIT_EXT
+	PipelineStageMeshShaderBitEXT PipelineStageFlags = C.VK_PIPELINE_STAGE_MESH_SHADER_BIT_EXT
+)
+
+// DrawMeshTasksIndirectCommandSize is the size in bytes of one indirect mesh draw command
+const DrawMeshTasksIndirectCommandSize = 12
+
+// DrawMeshTasksIndirectCommand is the layout of one indirect mesh draw command
+type DrawMeshTasksIndirectCommand struct {
+	GroupCountX uint32
+	GroupCountY uint32
+	GroupCountZ uint32
+}
+
+// PhysicalDeviceMeshShaderFeatures contains mesh shader features
+type PhysicalDeviceMeshShaderFeatures struct {
+	TaskShader bool
+	MeshShader bool
+}
+
+// PhysicalDeviceMeshShaderProperties contains mesh shader limits
+type PhysicalDeviceMeshShaderProperties struct {
+	MaxTaskWorkGroupTotalCount  uint32
+	MaxTaskWorkGroupCount       [3]uint32
+	MaxTaskWorkGroupInvocations uint32
+	MaxTaskWorkGroupSize        [3]uint32
+	MaxTaskPayloadSize          uint32
+	MaxMeshWorkGroupTotalCount  uint32
+	MaxMeshWorkGroupCount       [3]uint32
+	MaxMeshWorkGroupInvocations uint32
+	MaxMeshWorkGroupSize        [3]uint32
+	MaxMeshOutputVertices       uint32
+	MaxMeshOutputPrimitives     uint32
+}
+
+// LoadMeshShaderFunctions loads mesh shader extension functions for a device.
+//
+// This function MUST be called after creating a logical device with the VK_EXT_mesh_shader
+// extension enabled and before recording any mesh draws.
+//
+// IMPORTANT: This function is NOT thread-safe. Only one device is supported at a time;
+// calling this function again will overwrite previously loaded function pointers.
+//
+// Returns false if any mesh shader function could not be loaded.
+func LoadMeshShaderFunctions(device Device) bool {
+	return C.loadMeshShaderDeviceFunctions(C.VkDevice(device)) != 0
+}
+
+// meshShaderFeaturesToC prepends a struct enabling the requested mesh shader features to the
+// pNext chain next. The struct is allocated in C memory and appended to allocations, which
+// the caller must free.
+func meshShaderFeaturesToC(features *PhysicalDeviceMeshShaderFeatures, next unsafe.Pointer, allocations *[]unsafe.Pointer) (unsafe.Pointer, error) {
+	cMesh := (*C.VkPhysicalDeviceMeshShaderFeaturesEXT)(C.calloc(1, C.sizeof_VkPhysicalDeviceMeshShaderFeaturesEXT))
+	if cMesh == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "CreateDevice", "failed to allocate memory for mesh shader features")
+	}
+	*allocations = append(*allocations, unsafe.Pointer(cMesh))
+
+	cMesh.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_MESH_SHADER_FEATURES_EXT
+	cMesh.pNext = next
+	cMesh.taskShader = boolToVkBool32(features.TaskShader)
+	cMesh.meshShader = boolToVkBool32(features.MeshShader)
+
+	return unsafe.Pointer(cMesh), nil
+}
+
+// GetPhysicalDeviceMeshShaderFeaturesEXT queries mesh shader feature support
+func GetPhysicalDeviceMeshShaderFeaturesEXT(physicalDevice PhysicalDevice) PhysicalDeviceMeshShaderFeatures {
+	cFeatures2 := (*C.VkPhysicalDeviceFeatures2)(C.calloc(1, C.sizeof_VkPhysicalDeviceFeatures2))
+	cMesh := (*C.VkPhysicalDeviceMeshShaderFeaturesEXT)(C.calloc(1, C.sizeof_VkPhysicalDeviceMeshShaderFeaturesEXT))
+	defer C.free(unsafe.Pointer(cFeatures2))
+	defer C.free(unsafe.Pointer(cMesh))
+	if cFeatures2 == nil || cMesh == nil {
+		return PhysicalDeviceMeshShaderFeatures{}
+	}
+
+	cFeatures2.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_FEATURES_2
+	cFeatures2.pNext = unsafe.Pointer(cMesh)
+	cMesh.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_MESH_SHADER_FEATURES_EXT
+
+	C.vkGetPhysicalDeviceFeatures2(C.VkPhysicalDevice(physicalDevice), cFeatures2)
+
+	return PhysicalDeviceMeshShaderFeatures{
+		TaskShader: vkBool32ToBool(cMesh.taskShader),
+		MeshShader: vkBool32ToBool(cMesh.meshShader),
+	}
+}
+
+// GetPhysicalDeviceMeshShaderPropertiesEXT queries mesh shader work group limits
+func GetPhysicalDeviceMeshShaderPropertiesEXT(physicalDevice PhysicalDevice) PhysicalDeviceMeshShaderProperties {
+	cProps2 := (*C.VkPhysicalDeviceProperties2)(C.calloc(1, C.sizeof_VkPhysicalDeviceProperties2))
+	cMesh := (*C.VkPhysicalDeviceMeshShaderPropertiesEXT)(C.calloc(1, C.sizeof_VkPhysicalDeviceMeshShaderPropertiesEXT))
+	defer C.free(unsafe.Pointer(cProps2))
+	defer C.free(unsafe.Pointer(cMesh))
+	if cProps2 == nil || cMesh == nil {
+		return PhysicalDeviceMeshShaderProperties{}
+	}
+
+	cProps2.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_PROPERTIES_2
+	cProps2.pNext = unsafe.Pointer(cMesh)
+	cMesh.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_MESH_SHADER_PROPERTIES_EXT
+
+	C.vkGetPhysicalDeviceProperties2(C.VkPhysicalDevice(physicalDevice), cProps2)
+
+	props := PhysicalDeviceMeshShaderProperties{
+		MaxTaskWorkGroupTotalCount:  uint32(cMesh.maxTaskWorkGroupTotalCount),
+		MaxTaskWorkGroupInvocations: uint32(cMesh.maxTaskWorkGroupInvocations),
+		MaxTaskPayloadSize:          uint32(cMesh.maxTaskPayloadSize),
+		MaxMeshWorkGroupTotalCount:  uint32(cMesh.maxMeshWorkGroupTotalCount),
+		MaxMeshWorkGroupInvocations: uint32(cMesh.maxMeshWorkGroupInvocations),
+		MaxMeshOutputVertices:       uint32(cMesh.maxMeshOutputVertices),
+		MaxMeshOutputPrimitives:     uint32(cMesh.maxMeshOutputPrimitives),
+	}
+	for i := 0; i < 3; i++ {
+		props.MaxTaskWorkGroupCount[i] = uint32(cMesh.maxTaskWorkGroupCount[i])
+		props.MaxTaskWorkGroupSize[i] = uint32(cMesh.maxTaskWorkGroupSize[i])
+		props.MaxMeshWorkGroupCount[i] = uint32(cMesh.maxMeshWorkGroupCount[i])
+		props.MaxMeshWorkGroupSize[i] = uint32(cMesh.maxMeshWorkGroupSize[i])
+	}
+
+	return props
+}
+
+// CmdDrawMeshTasksEXT records a mesh shader draw of the given task or mesh work groups.
+// Returns an error if LoadMeshShaderFunctions was not called.
+func CmdDrawMeshTasksEXT(commandBuffer CommandBuffer, groupCountX, groupCountY, groupCountZ uint32) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+
+	if C.call_vkCmdDrawMeshTasksEXT(C.VkCommandBuffer(commandBuffer), C.uint32_t(groupCountX), C.uint32_t(groupCountY), C.uint32_t(groupCountZ)) == 0 {
+		return NewVulkanError(ErrorExtensionNotPresent, "CmdDrawMeshTasksEXT", "mesh shader extension not loaded - call LoadMeshShaderFunctions first")
+	}
+	return nil
+}
+
+// CmdDrawMeshTasksIndirectEXT records mesh shader draws with parameters read from a buffer
+// of DrawMeshTasksIndirectCommand entries.
+// Returns an error if LoadMeshShaderFunctions was not called.
+func CmdDrawMeshTasksIndirectEXT(commandBuffer CommandBuffer, buffer Buffer, offset DeviceSize, drawCount, stride uint32) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+	if buffer == nil {
+		return NewValidationError("buffer", "cannot be nil")
+	}
+	if offset%4 != 0 {
+		return NewValidationError("offset", "must be a multiple of 4")
+	}
+	if drawCount > 1 && (stride%4 != 0 || stride < DrawMeshTasksIndirectCommandSize) {
+		return NewValidationError("stride", "must be a multiple of 4 and at least 12 bytes")
+	}
+
+	if C.call_vkCmdDrawMeshTasksIndirectEXT(C.VkCommandBuffer(commandBuffer), C.VkBuffer(buffer), C.VkDeviceSize(offset), C.uint32_t(drawCount), C.uint32_t(stride)) == 0 {
+		return NewVulkanError(ErrorExtensionNotPresent, "CmdDrawMeshTasksIndirectEXT", "mesh shader extension not loaded - call LoadMeshShaderFunctions first")
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package vulkan
+
+/*
+#include <vulkan/vulkan.h>
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"math/bits"
+	"unsafe"
+)
+
+// CmdBlitImage copies regions between images with scaling and format conversion. Both images
+// need optimal-tiling blit support for their formats, and FilterLinear additionally requires
+// FormatFeatureSampledImageFilterLinearBit on the source format.
+func CmdBlitImage(commandBuffer CommandBuffer, srcImage Image, srcImageLayout ImageLayout, dstImage Image, dstImageLayout ImageLayout, regions []ImageBlit, filter Filter) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+	if srcImage == nil || dstImage == nil {
+		return NewValidationError("images", "source and destination images cannot be nil")
+	}
+	if len(regions) == 0 {
+		return NewValidationError("regions", "must contain at least one region")
+	}
+	for _, region := range regions {
+		if region.Transform != nil {
+			return NewValidationError("regions", "a copy command transform requires CmdBlitImage2")
+		}
+	}
+
+	cRegionsPtr := (*C.VkImageBlit)(C.calloc(C.size_t(len(regions)), C.sizeof_VkImageBlit))
+	if cRegionsPtr == nil {
+		return NewVulkanError(ErrorOutOfHostMemory, "CmdBlitImage", "failed to allocate memory for blit regions")
+	}
+	defer C.free(unsafe.Pointer(cRegionsPtr))
+
+	cRegions := unsafe.Slice(cRegionsPtr, len(regions))
+	for i, region := range regions {
+		cRegions[i] = C.VkImageBlit{
+			srcSubresource: region.SrcSubresource.toC(),
+			srcOffsets:     [2]C.VkOffset3D{region.SrcOffsets[0].toC(), region.SrcOffsets[1].toC()},
+			dstSubresource: region.DstSubresource.toC(),
+			dstOffsets:     [2]C.VkOffset3D{region.DstOffsets[0].toC(), region.DstOffsets[1].toC()},
+		}
+	}
+
+	C.vkCmdBlitImage(C.VkCommandBuffer(commandBuffer), C.VkImage(srcImage), C.VkImageLayout(srcImageLayout),
+		C.VkImage(dstImage), C.VkImageLayout(dstImageLayout), C.uint32_t(len(regions)), cRegionsPtr, C.VkFilter(filter))
+	return nil
+}
+
+// MipLevelCount returns the number of levels in a full mip chain for a width x height image
+func MipLevelCount(width, height uint32) uint32 {
+	return uint32(bits.Len32(max(width, height, 1)))
+}
+
+// mipBlitRegions returns the blits that build each level of a mip chain from the level before
+// it. Levels never shrink below one texel.
+func mipBlitRegions(aspectMask ImageAspectFlags, width, height int32, mipLevels uint32) []ImageBlit {
+	regions := make([]ImageBlit, 0, mipLevels-1)
+	for level := uint32(1); level < mipLevels; level++ {
+		nextWidth, nextHeight := max(width/2, 1), max(height/2, 1)
+		regions = append(regions, ImageBlit{
+			SrcSubresource: ImageSubresourceLayers{AspectMask: aspectMask, MipLevel: level - 1, LayerCount: 1},
+			SrcOffsets:     [2]Offset3D{{}, {X: width, Y: height, Z: 1}},
+			DstSubresource: ImageSubresourceLayers{AspectMask: aspectMask, MipLevel: level, LayerCount: 1},
+			DstOffsets:     [2]Offset3D{{}, {X: nextWidth, Y: nextHeight, Z: 1}},
+		})
+		width, height = nextWidth, nextHeight
+	}
+	return regions
+}
+
+// GenerateMipmaps records the blits and barriers that fill mip levels 1 to mipLevels-1 of the
+// first array layer of image by repeatedly downsampling level 0 with a linear filter.
+//
+// Every level of the image must be in ImageLayoutTransferDstOptimal, as it is after uploading
+// level 0, and the image needs ImageUsageTransferSrcBit and ImageUsageTransferDstBit. When the
+// recorded commands complete, all levels are in ImageLayoutShaderReadOnlyOptimal.
+//
+// physicalDevice is used to check that format supports linear blits with optimal tiling;
+// ErrorFormatNotSupported is returned otherwise.
+func GenerateMipmaps(physicalDevice PhysicalDevice, commandBuffer CommandBuffer, image Image, format Format, width, height int32, mipLevels uint32) error {
+	if physicalDevice == nil {
+		return NewValidationError("physicalDevice", "cannot be nil")
+	}
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+	if image == nil {
+		return NewValidationError("image", "cannot be nil")
+	}
+	if width <= 0 || height <= 0 {
+		return NewValidationError("extent", "width and height must be greater than 0")
+	}
+	if mipLevels == 0 || mipLevels > MipLevelCount(uint32(width), uint32(height)) {
+		return NewValidationError("mipLevels", "must be between 1 and the full mip chain length")
+	}
+
+	required := FormatFeatureBlitSrcBit | FormatFeatureBlitDstBit | FormatFeatureSampledImageFilterLinearBit
+	if GetPhysicalDeviceFormatProperties(physicalDevice, format).OptimalTilingFeatures&required != required {
+		return NewVulkanError(ErrorFormatNotSupported, "GenerateMipmaps", "format does not support linear blits with optimal tiling")
+	}
+
+	aspectMask := AspectMaskForFormat(format)
+	barrier := C.VkImageMemoryBarrier{
+		sType:               C.VK_STRUCTURE_TYPE_IMAGE_MEMORY_BARRIER,
+		srcQueueFamilyIndex: C.VK_QUEUE_FAMILY_IGNORED,
+		dstQueueFamilyIndex: C.VK_QUEUE_FAMILY_IGNORED,
+		image:               C.VkImage(image),
+		subresourceRange: C.VkImageSubresourceRange{
+			aspectMask:     C.VkImageAspectFlags(aspectMask),
+			levelCount:     1,
+			baseArrayLayer: 0,
+			layerCount:     1,
+		},
+	}
+
+	for level, region := range mipBlitRegions(aspectMask, width, height, mipLevels) {
+		// Wait for the previous level to be written, then read it as the blit source
+		barrier.subresourceRange.baseMipLevel = C.uint32_t(level)
+		barrier.oldLayout = C.VK_IMAGE_LAYOUT_TRANSFER_DST_OPTIMAL
+		barrier.newLayout = C.VK_IMAGE_LAYOUT_TRANSFER_SRC_OPTIMAL
+		barrier.srcAccessMask = C.VK_ACCESS_TRANSFER_WRITE_BIT
+		barrier.dstAccessMask = C.VK_ACCESS_TRANSFER_READ_BIT
+		C.vkCmdPipelineBarrier(C.VkCommandBuffer(commandBuffer),
+			C.VK_PIPELINE_STAGE_TRANSFER_BIT, C.VK_PIPELINE_STAGE_TRANSFER_BIT, 0,
+			0, nil, 0, nil, 1, &barrier)
+
+		if err := CmdBlitImage(commandBuffer, image, ImageLayoutTransferSrcOptimal, image, ImageLayoutTransferDstOptimal,
+			[]ImageBlit{region}, FilterLinear); err != nil {
+			return err
+		}
+
+		// The source level is finished; hand it to shaders
+		barrier.oldLayout = C.VK_IMAGE_LAYOUT_TRANSFER_SRC_OPTIMAL
+		barrier.newLayout = C.VK_IMAGE_LAYOUT_SHADER_READ_ONLY_OPTIMAL
+		barrier.srcAccessMask = C.VK_ACCESS_TRANSFER_READ_BIT
+		barrier.dstAccessMask = C.VK_ACCESS_SHADER_READ_BIT
+		C.vkCmdPipelineBarrier(C.VkCommandBuffer(commandBuffer),
+			C.VK_PIPELINE_STAGE_TRANSFER_BIT, C.VK_PIPELINE_STAGE_FRAGMENT_SHADER_BIT|C.VK_PIPELINE_STAGE_COMPUTE_SHADER_BIT, 0,
+			0, nil, 0, nil, 1, &barrier)
+	}
+
+	// The last level was only ever written
+	barrier.subresourceRange.baseMipLevel = C.uint32_t(mipLevels - 1)
+	barrier.oldLayout = C.VK_IMAGE_LAYOUT_TRANSFER_DST_OPTIMAL
+	barrier.newLayout = C.VK_IMAGE_LAYOUT_SHADER_READ_ONLY_OPTIMAL
+	barrier.srcAccessMask = C.VK_ACCESS_TRANSFER_WRITE_BIT
+	barrier.dstAccessMask = C.VK_ACCESS_SHADER_READ_BIT
+	C.vkCmdPipelineBarrier(C.VkCommandBuffer(commandBuffer),
+		C.VK_PIPELINE_STAGE_TRANSFER_BIT, C.VK_PIPELINE_STAGE_FRAGMENT_SHADER_BIT|C.VK_PIPELINE_STAGE_COMPUTE_SHADER_BIT, 0,
+		0, nil, 0, nil, 1, &barrier)
+	return nil
+}
@@ -0,0 +1,122 @@
+//go:build cgo
+
+package vulkan
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestMipLevelCount tests the length of full mip chains
+func TestMipLevelCount(t *testing.T) {
+	tests := []struct {
+		width, height uint32
+		expected      uint32
+	}{
+		{1, 1, 1},
+		{2, 1, 2},
+		{256, 256, 9},
+		{1024, 768, 11},
+		{300, 17, 9},
+		{0, 0, 1},
+	}
+
+	for _, tt := range tests {
+		if got := MipLevelCount(tt.width, tt.height); got != tt.expected {
+			t.Errorf("MipLevelCount(%d, %d) = %d, expected %d", tt.width, tt.height, got, tt.expected)
+		}
+	}
+}
+
+// TestMipBlitRegions tests that each blit halves the previous level without going below one texel
+func TestMipBlitRegions(t *testing.T) {
+	regions := mipBlitRegions(ImageAspectColorBit, 8, 2, 4)
+	if len(regions) != 3 {
+		t.Fatalf("Expected 3 blits, got %d", len(regions))
+	}
+
+	expected := []struct {
+		srcLevel, dstLevel uint32
+		src, dst           Offset3D
+	}{
+		{0, 1, Offset3D{X: 8, Y: 2, Z: 1}, Offset3D{X: 4, Y: 1, Z: 1}},
+		{1, 2, Offset3D{X: 4, Y: 1, Z: 1}, Offset3D{X: 2, Y: 1, Z: 1}},
+		{2, 3, Offset3D{X: 2, Y: 1, Z: 1}, Offset3D{X: 1, Y: 1, Z: 1}},
+	}
+	for i, want := range expected {
+		region := regions[i]
+		if region.SrcSubresource.MipLevel != want.srcLevel || region.DstSubresource.MipLevel != want.dstLevel {
+			t.Errorf("Blit %d: expected levels %d->%d, got %d->%d", i, want.srcLevel, want.dstLevel,
+				region.SrcSubresource.MipLevel, region.DstSubresource.MipLevel)
+		}
+		if region.SrcOffsets[1] != want.src || region.DstOffsets[1] != want.dst {
+			t.Errorf("Blit %d: expected %+v->%+v, got %+v->%+v", i, want.src, want.dst, region.SrcOffsets[1], region.DstOffsets[1])
+		}
+		if region.SrcSubresource.AspectMask != ImageAspectColorBit || region.DstSubresource.LayerCount != 1 {
+			t.Errorf("Blit %d: unexpected subresource %+v", i, region.DstSubresource)
+		}
+	}
+}
+
+// TestGenerateMipmapsValidation tests input validation of GenerateMipmaps and CmdBlitImage
+func TestGenerateMipmapsValidation(t *testing.T) {
+	fakePhysicalDevice := PhysicalDevice(uintptr(0x1234))
+	fakeCommandBuffer := CommandBuffer(uintptr(0x5678))
+	fakeImage := Image(uintptr(0x9abc))
+
+	tests := []struct {
+		name       string
+		call       func() error
+		errorParam string
+	}{
+		{
+			name: "nil physical device",
+			call: func() error {
+				return GenerateMipmaps(nil, fakeCommandBuffer, fakeImage, FormatR8G8B8A8Unorm, 256, 256, 9)
+			},
+			errorParam: "physicalDevice",
+		},
+		{
+			name: "nil image",
+			call: func() error {
+				return GenerateMipmaps(fakePhysicalDevice, fakeCommandBuffer, nil, FormatR8G8B8A8Unorm, 256, 256, 9)
+			},
+			errorParam: "image",
+		},
+		{
+			name: "zero width",
+			call: func() error {
+				return GenerateMipmaps(fakePhysicalDevice, fakeCommandBuffer, fakeImage, FormatR8G8B8A8Unorm, 0, 256, 1)
+			},
+			errorParam: "extent",
+		},
+		{
+			name: "too many levels",
+			call: func() error {
+				return GenerateMipmaps(fakePhysicalDevice, fakeCommandBuffer, fakeImage, FormatR8G8B8A8Unorm, 256, 256, 10)
+			},
+			errorParam: "mipLevels",
+		},
+		{
+			name: "blit without regions",
+			call: func() error {
+				return CmdBlitImage(fakeCommandBuffer, fakeImage, ImageLayoutTransferSrcOptimal, fakeImage, ImageLayoutTransferDstOptimal, nil, FilterLinear)
+			},
+			errorParam: "regions",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+}
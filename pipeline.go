@@ -7,6 +7,7 @@ package vulkan
 import "C"
 
 import (
+	"fmt"
 	"unsafe"
 )
 
@@ -21,6 +22,25 @@ type PipelineShaderStageCreateInfo struct {
 	Stage  ShaderStageFlags
 	Module ShaderModule
 	Name   string
+	// SpecializationInfo is optional. When set, it overrides the values of the shader's
+	// specialization constants, e.g. a compute shader's workgroup size.
+	SpecializationInfo *SpecializationInfo
+}
+
+// SpecializationInfo supplies values for specialization constants
+type SpecializationInfo struct {
+	MapEntries []SpecializationMapEntry
+	// Data holds the constant values; each map entry selects a range of it
+	Data []byte
+}
+
+// SpecializationMapEntry maps a specialization constant ID to a range of
+// SpecializationInfo.Data. Size must match the constant's type, e.g. 4 for a uint32 or
+// float and 4 for a bool, which is stored as a VkBool32.
+type SpecializationMapEntry struct {
+	ConstantID uint32
+	Offset     uint32
+	Size       uint32
 }
 
 // ShaderStageFlags represents shader stage flags
@@ -143,6 +163,68 @@ const (
 	AccessMemoryWriteBit                 AccessFlags = C.VK_ACCESS_MEMORY_WRITE_BIT
 )
 
+// validateSpecializationInfo checks that every map entry lies within the data and that no
+// constant ID is mapped twice
+func validateSpecializationInfo(param string, info *SpecializationInfo) error {
+	seen := make(map[uint32]struct{}, len(info.MapEntries))
+	for i, entry := range info.MapEntries {
+		if entry.Size == 0 {
+			return NewValidationError(fmt.Sprintf("%s.MapEntries[%d].Size", param, i), "must be greater than 0")
+		}
+		if uint64(entry.Offset)+uint64(entry.Size) > uint64(len(info.Data)) {
+			return NewValidationError(fmt.Sprintf("%s.MapEntries[%d]", param, i),
+				fmt.Sprintf("range [%d, %d) exceeds data size %d", entry.Offset, uint64(entry.Offset)+uint64(entry.Size), len(info.Data)))
+		}
+		if _, ok := seen[entry.ConstantID]; ok {
+			return NewValidationError(fmt.Sprintf("%s.MapEntries[%d].ConstantID", param, i),
+				fmt.Sprintf("constant %d is already mapped", entry.ConstantID))
+		}
+		seen[entry.ConstantID] = struct{}{}
+	}
+	return nil
+}
+
+// specializationInfoToC converts specialization info to C memory, returning nil if info is
+// nil. The allocations are appended to allocations, which the caller must free. operation
+// names the calling function in errors.
+func specializationInfoToC(operation string, info *SpecializationInfo, allocations *[]unsafe.Pointer) (*C.VkSpecializationInfo, error) {
+	if info == nil {
+		return nil, nil
+	}
+
+	cInfo := (*C.VkSpecializationInfo)(C.calloc(1, C.sizeof_VkSpecializationInfo))
+	if cInfo == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, operation, "failed to allocate memory for specialization info")
+	}
+	*allocations = append(*allocations, unsafe.Pointer(cInfo))
+
+	if len(info.MapEntries) > 0 {
+		cEntriesPtr := (*C.VkSpecializationMapEntry)(C.calloc(C.size_t(len(info.MapEntries)), C.sizeof_VkSpecializationMapEntry))
+		if cEntriesPtr == nil {
+			return nil, NewVulkanError(ErrorOutOfHostMemory, operation, "failed to allocate memory for specialization map entries")
+		}
+		*allocations = append(*allocations, unsafe.Pointer(cEntriesPtr))
+
+		cEntries := unsafe.Slice(cEntriesPtr, len(info.MapEntries))
+		for i, entry := range info.MapEntries {
+			cEntries[i].constantID = C.uint32_t(entry.ConstantID)
+			cEntries[i].offset = C.uint32_t(entry.Offset)
+			cEntries[i].size = C.size_t(entry.Size)
+		}
+		cInfo.mapEntryCount = C.uint32_t(len(info.MapEntries))
+		cInfo.pMapEntries = cEntriesPtr
+	}
+
+	if len(info.Data) > 0 {
+		cData := C.CBytes(info.Data)
+		*allocations = append(*allocations, cData)
+		cInfo.dataSize = C.size_t(len(info.Data))
+		cInfo.pData = cData
+	}
+
+	return cInfo, nil
+}
+
 // CreateShaderModule creates a shader module
 func CreateShaderModule(device Device, createInfo *ShaderModuleCreateInfo) (ShaderModule, error) {
 	var cCreateInfo C.VkShaderModuleCreateInfo
@@ -156,6 +238,7 @@ func CreateShaderModule(device Device, createInfo *ShaderModuleCreateInfo) (Shad
 
 	var shaderModule C.VkShaderModule
 	result := Result(C.vkCreateShaderModule(C.VkDevice(device), &cCreateInfo, nil, &shaderModule))
+	traceResult("vkCreateShaderModule", result)
 	if result != Success {
 		return nil, result
 	}
@@ -201,6 +284,7 @@ func CreatePipelineLayout(device Device, createInfo *PipelineLayoutCreateInfo) (
 
 	var pipelineLayout C.VkPipelineLayout
 	result := Result(C.vkCreatePipelineLayout(C.VkDevice(device), &cCreateInfo, nil, &pipelineLayout))
+	traceResult("vkCreatePipelineLayout", result)
 	if result != Success {
 		return nil, result
 	}
@@ -244,6 +328,7 @@ func CreateRenderPass(device Device, createInfo *RenderPassCreateInfo) (RenderPa
 
 	var renderPass C.VkRenderPass
 	result := Result(C.vkCreateRenderPass(C.VkDevice(device), &cCreateInfo, nil, &renderPass))
+	traceResult("vkCreateRenderPass", result)
 	if result != Success {
 		return nil, result
 	}
@@ -258,8 +343,13 @@ func DestroyRenderPass(device Device, renderPass RenderPass) {
 
 // ComputePipelineCreateInfo contains compute pipeline creation information
 type ComputePipelineCreateInfo struct {
+	// Flags is optional, e.g. PipelineCreateDispatchBaseBit for use with CmdDispatchBase
+	Flags  PipelineCreateFlags
 	Stage  PipelineShaderStageCreateInfo
 	Layout PipelineLayout
+	// Feedback is optional. When set, it is filled with creation durations and
+	// whether the pipeline cache was hit after the pipeline has been created.
+	Feedback *PipelineCreationFeedbackCreateInfo
 }
 
 // CreateComputePipelines creates compute pipelines
@@ -268,16 +358,40 @@ func CreateComputePipelines(device Device, pipelineCache PipelineCache, createIn
 		return nil, nil
 	}
 
+	for i, info := range createInfos {
+		if info.Stage.SpecializationInfo != nil {
+			if err := validateSpecializationInfo(fmt.Sprintf("createInfos[%d].Stage.SpecializationInfo", i), info.Stage.SpecializationInfo); err != nil {
+				return nil, err
+			}
+		}
+		if info.Feedback != nil {
+			// Compute pipelines have exactly one shader stage
+			if err := validatePipelineCreationFeedback(fmt.Sprintf("createInfos[%d].Feedback", i), info.Feedback, 1); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var allocations []unsafe.Pointer
+	defer func() { freeAllocations(allocations) }()
+
 	cCreateInfos := make([]C.VkComputePipelineCreateInfo, len(createInfos))
 	cPipelines := make([]C.VkPipeline, len(createInfos))
-
-	// Collect C strings for proper memory management
-	cNames := make([]*C.char, len(createInfos))
+	cFeedbacks := make([]*C.VkPipelineCreationFeedbackCreateInfo, len(createInfos))
 
 	for i, info := range createInfos {
 		cCreateInfos[i].sType = C.VK_STRUCTURE_TYPE_COMPUTE_PIPELINE_CREATE_INFO
 		cCreateInfos[i].pNext = nil
-		cCreateInfos[i].flags = 0
+		cCreateInfos[i].flags = C.VkPipelineCreateFlags(info.Flags)
+
+		if info.Feedback != nil {
+			cFeedback, err := pipelineCreationFeedbackToC(info.Feedback, &allocations)
+			if err != nil {
+				return nil, err
+			}
+			cFeedbacks[i] = cFeedback
+			cCreateInfos[i].pNext = unsafe.Pointer(cFeedback)
+		}
 
 		// Set up shader stage
 		cCreateInfos[i].stage.sType = C.VK_STRUCTURE_TYPE_PIPELINE_SHADER_STAGE_CREATE_INFO
@@ -286,25 +400,22 @@ func CreateComputePipelines(device Device, pipelineCache PipelineCache, createIn
 		cCreateInfos[i].stage.stage = C.VkShaderStageFlagBits(info.Stage.Stage)
 		cCreateInfos[i].stage.module = C.VkShaderModule(info.Stage.Module)
 
-		// Convert name to C string and store for later cleanup
-		cNames[i] = C.CString(info.Stage.Name)
-		cCreateInfos[i].stage.pName = cNames[i]
-		cCreateInfos[i].stage.pSpecializationInfo = nil
+		// Track the name with the other C allocations so every early return frees it
+		cName := C.CString(info.Stage.Name)
+		allocations = append(allocations, unsafe.Pointer(cName))
+		cCreateInfos[i].stage.pName = cName
+
+		cSpecialization, err := specializationInfoToC("CreateComputePipelines", info.Stage.SpecializationInfo, &allocations)
+		if err != nil {
+			return nil, err
+		}
+		cCreateInfos[i].stage.pSpecializationInfo = cSpecialization
 
 		cCreateInfos[i].layout = C.VkPipelineLayout(info.Layout)
 		cCreateInfos[i].basePipelineHandle = C.VkPipeline(nil)
 		cCreateInfos[i].basePipelineIndex = -1
 	}
 
-	// Free all C strings after API call regardless of success/failure
-	defer func() {
-		for _, cName := range cNames {
-			if cName != nil {
-				C.free(unsafe.Pointer(cName))
-			}
-		}
-	}()
-
 	result := Result(C.vkCreateComputePipelines(
 		C.VkDevice(device),
 		C.VkPipelineCache(pipelineCache),
@@ -313,11 +424,18 @@ func CreateComputePipelines(device Device, pipelineCache PipelineCache, createIn
 		nil,
 		&cPipelines[0],
 	))
+	traceResult("vkCreateComputePipelines", result)
 
 	if result != Success {
 		return nil, result
 	}
 
+	for i, cFeedback := range cFeedbacks {
+		if cFeedback != nil {
+			copyPipelineCreationFeedback(cFeedback, createInfos[i].Feedback)
+		}
+	}
+
 	pipelines := make([]Pipeline, len(cPipelines))
 	for i, pipeline := range cPipelines {
 		pipelines[i] = Pipeline(pipeline)
@@ -331,6 +449,117 @@ func DestroyPipeline(device Device, pipeline Pipeline) {
 	C.vkDestroyPipeline(C.VkDevice(device), C.VkPipeline(pipeline), nil)
 }
 
+// CreatePipelineCache creates a pipeline cache, optionally seeded with data previously
+// returned by GetPipelineCacheData. Drivers ignore initial data written by a different
+// driver or device, so stale cache files are safe to pass.
+func CreatePipelineCache(device Device, initialData []byte) (PipelineCache, error) {
+	if device == nil {
+		return nil, NewValidationError("device", "cannot be nil")
+	}
+
+	var cCreateInfo C.VkPipelineCacheCreateInfo
+	cCreateInfo.sType = C.VK_STRUCTURE_TYPE_PIPELINE_CACHE_CREATE_INFO
+	cCreateInfo.pNext = nil
+	cCreateInfo.flags = 0
+	if len(initialData) > 0 {
+		cData := C.CBytes(initialData)
+		defer C.free(cData)
+		cCreateInfo.initialDataSize = C.size_t(len(initialData))
+		cCreateInfo.pInitialData = cData
+	}
+
+	var pipelineCache C.VkPipelineCache
+	result := Result(C.vkCreatePipelineCache(C.VkDevice(device), &cCreateInfo, nil, &pipelineCache))
+	traceResult("vkCreatePipelineCache", result)
+	if result != Success {
+		return nil, NewVulkanError(result, "CreatePipelineCache", "failed to create pipeline cache")
+	}
+
+	return PipelineCache(pipelineCache), nil
+}
+
+// DestroyPipelineCache destroys a pipeline cache
+func DestroyPipelineCache(device Device, pipelineCache PipelineCache) {
+	C.vkDestroyPipelineCache(C.VkDevice(device), C.VkPipelineCache(pipelineCache), nil)
+}
+
+// GetPipelineCacheData serializes a pipeline cache. The bytes can be written to disk and
+// passed to CreatePipelineCache on the next run.
+func GetPipelineCacheData(device Device, pipelineCache PipelineCache) ([]byte, error) {
+	if device == nil {
+		return nil, NewValidationError("device", "cannot be nil")
+	}
+	if pipelineCache == nil {
+		return nil, NewValidationError("pipelineCache", "cannot be nil")
+	}
+
+	// The cache can grow between the size query and the copy, so retry on Incomplete
+	for {
+		var dataSize C.size_t
+		result := Result(C.vkGetPipelineCacheData(C.VkDevice(device), C.VkPipelineCache(pipelineCache), &dataSize, nil))
+		traceResult("vkGetPipelineCacheData", result)
+		if result != Success {
+			return nil, NewVulkanError(result, "GetPipelineCacheData", "failed to query pipeline cache size")
+		}
+		if dataSize == 0 {
+			return nil, nil
+		}
+
+		cData := C.malloc(dataSize)
+		if cData == nil {
+			return nil, NewVulkanError(ErrorOutOfHostMemory, "GetPipelineCacheData", "failed to allocate memory for pipeline cache data")
+		}
+		result = Result(C.vkGetPipelineCacheData(C.VkDevice(device), C.VkPipelineCache(pipelineCache), &dataSize, cData))
+		traceResult("vkGetPipelineCacheData", result)
+		if result == Incomplete {
+			C.free(cData)
+			continue
+		}
+		if result != Success {
+			C.free(cData)
+			return nil, NewVulkanError(result, "GetPipelineCacheData", "failed to get pipeline cache data")
+		}
+
+		data := C.GoBytes(cData, C.int(dataSize))
+		C.free(cData)
+		return data, nil
+	}
+}
+
+// MergePipelineCaches merges the contents of srcCaches into dstCache, for example to
+// combine caches filled by pipelines compiled on several goroutines
+func MergePipelineCaches(device Device, dstCache PipelineCache, srcCaches []PipelineCache) error {
+	if device == nil {
+		return NewValidationError("device", "cannot be nil")
+	}
+	if dstCache == nil {
+		return NewValidationError("dstCache", "cannot be nil")
+	}
+	if len(srcCaches) == 0 {
+		return nil
+	}
+	for i, src := range srcCaches {
+		if src == nil {
+			return NewValidationError(fmt.Sprintf("srcCaches[%d]", i), "cannot be nil")
+		}
+		if src == dstCache {
+			return NewValidationError(fmt.Sprintf("srcCaches[%d]", i), "cannot be the destination cache")
+		}
+	}
+
+	cSrcCaches := make([]C.VkPipelineCache, len(srcCaches))
+	for i, src := range srcCaches {
+		cSrcCaches[i] = C.VkPipelineCache(src)
+	}
+
+	result := Result(C.vkMergePipelineCaches(C.VkDevice(device), C.VkPipelineCache(dstCache), C.uint32_t(len(cSrcCaches)), &cSrcCaches[0]))
+	traceResult("vkMergePipelineCaches", result)
+	if result != Success {
+		return NewVulkanError(result, "MergePipelineCaches", "failed to merge pipeline caches")
+	}
+	return nil
+}
+
 // Additional utility functions for common operations
 
 // GetAPIVersion returns the supported Vulkan API version
@@ -357,3 +586,18 @@ func IsLayerSupported(layerName string, availableLayers []LayerProperties) bool
 	}
 	return false
 }
+
+// FilterSupportedExtensions splits requested extension names into those found in
+// availableExtensions and those that are missing, preserving the requested order. Use it to
+// report every unavailable extension before CreateInstance or CreateDevice fails with
+// ErrorExtensionNotPresent.
+func FilterSupportedExtensions(requested []string, availableExtensions []ExtensionProperties) (supported, missing []string) {
+	for _, name := range requested {
+		if IsExtensionSupported(name, availableExtensions) {
+			supported = append(supported, name)
+		} else {
+			missing = append(missing, name)
+		}
+	}
+	return supported, missing
+}
@@ -0,0 +1,237 @@
+//go:build cgo
+
+package vulkan
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// TestPipelineCacheValidation tests input validation for pipeline cache functions
+func TestPipelineCacheValidation(t *testing.T) {
+	fakeDevice := Device(uintptr(0x1234))
+	fakeCache := PipelineCache(uintptr(0x5678))
+
+	tests := []struct {
+		name       string
+		call       func() error
+		errorParam string
+	}{
+		{
+			name: "create with nil device",
+			call: func() error {
+				_, err := CreatePipelineCache(nil, nil)
+				return err
+			},
+			errorParam: "device",
+		},
+		{
+			name: "get data with nil cache",
+			call: func() error {
+				_, err := GetPipelineCacheData(fakeDevice, nil)
+				return err
+			},
+			errorParam: "pipelineCache",
+		},
+		{
+			name: "merge into nil cache",
+			call: func() error {
+				return MergePipelineCaches(fakeDevice, nil, []PipelineCache{fakeCache})
+			},
+			errorParam: "dstCache",
+		},
+		{
+			name: "merge nil source",
+			call: func() error {
+				return MergePipelineCaches(fakeDevice, fakeCache, []PipelineCache{PipelineCache(uintptr(0x9abc)), nil})
+			},
+			errorParam: "srcCaches[1]",
+		},
+		{
+			name: "merge cache into itself",
+			call: func() error {
+				return MergePipelineCaches(fakeDevice, fakeCache, []PipelineCache{fakeCache})
+			},
+			errorParam: "srcCaches[0]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+}
+
+// TestComputePipelineFeedbackValidation tests validation of creation feedback requests
+func TestComputePipelineFeedbackValidation(t *testing.T) {
+	fakeDevice := Device(uintptr(0x1234))
+
+	tests := []struct {
+		name       string
+		feedback   *PipelineCreationFeedbackCreateInfo
+		errorParam string
+	}{
+		{
+			name:       "missing pipeline feedback",
+			feedback:   &PipelineCreationFeedbackCreateInfo{},
+			errorParam: "createInfos[1].Feedback.PipelineCreationFeedback",
+		},
+		{
+			name: "too many stage feedbacks",
+			feedback: &PipelineCreationFeedbackCreateInfo{
+				PipelineCreationFeedback:       &PipelineCreationFeedback{},
+				PipelineStageCreationFeedbacks: make([]PipelineCreationFeedback, 2),
+			},
+			errorParam: "createInfos[1].Feedback.PipelineStageCreationFeedbacks",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CreateComputePipelines(fakeDevice, nil, []ComputePipelineCreateInfo{
+				{},
+				{Feedback: tt.feedback},
+			})
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+}
+
+// TestComputePipelineSpecializationValidation tests validation of specialization constants
+func TestComputePipelineSpecializationValidation(t *testing.T) {
+	fakeDevice := Device(uintptr(0x1234))
+
+	tests := []struct {
+		name           string
+		specialization *SpecializationInfo
+		errorParam     string
+	}{
+		{
+			name: "entry past end of data",
+			specialization: &SpecializationInfo{
+				MapEntries: []SpecializationMapEntry{{ConstantID: 0, Offset: 4, Size: 4}},
+				Data:       make([]byte, 4),
+			},
+			errorParam: "createInfos[1].Stage.SpecializationInfo.MapEntries[0]",
+		},
+		{
+			name: "zero size entry",
+			specialization: &SpecializationInfo{
+				MapEntries: []SpecializationMapEntry{{ConstantID: 0}},
+				Data:       make([]byte, 4),
+			},
+			errorParam: "createInfos[1].Stage.SpecializationInfo.MapEntries[0].Size",
+		},
+		{
+			name: "duplicate constant ID",
+			specialization: &SpecializationInfo{
+				MapEntries: []SpecializationMapEntry{
+					{ConstantID: 3, Offset: 0, Size: 4},
+					{ConstantID: 3, Offset: 4, Size: 4},
+				},
+				Data: make([]byte, 8),
+			},
+			errorParam: "createInfos[1].Stage.SpecializationInfo.MapEntries[1].ConstantID",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CreateComputePipelines(fakeDevice, nil, []ComputePipelineCreateInfo{
+				{},
+				{Stage: PipelineShaderStageCreateInfo{SpecializationInfo: tt.specialization}},
+			})
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+}
+
+// TestPipelineCreationFeedbackCacheHit tests that cache hits require the valid bit
+func TestPipelineCreationFeedbackCacheHit(t *testing.T) {
+	tests := []struct {
+		flags    PipelineCreationFeedbackFlags
+		expected bool
+	}{
+		{0, false},
+		{PipelineCreationFeedbackValid, false},
+		{PipelineCreationFeedbackApplicationPipelineCacheHit, false},
+		{PipelineCreationFeedbackValid | PipelineCreationFeedbackApplicationPipelineCacheHit, true},
+	}
+
+	for _, tt := range tests {
+		if got := (PipelineCreationFeedback{Flags: tt.flags}).CacheHit(); got != tt.expected {
+			t.Errorf("CacheHit() with flags %#x = %v, expected %v", uint32(tt.flags), got, tt.expected)
+		}
+	}
+}
+
+// TestExtensionAndLayerSupport tests the extension and layer support predicates
+func TestExtensionAndLayerSupport(t *testing.T) {
+	extensions := []ExtensionProperties{
+		{ExtensionName: "VK_KHR_swapchain", SpecVersion: 70},
+		{ExtensionName: ExtensionNameVideoQueue, SpecVersion: 8},
+	}
+	layers := []LayerProperties{{LayerName: "VK_LAYER_KHRONOS_validation"}}
+
+	if !IsExtensionSupported("VK_KHR_swapchain", extensions) {
+		t.Error("Expected VK_KHR_swapchain to be supported")
+	}
+	if IsExtensionSupported("VK_KHR_nonexistent", extensions) {
+		t.Error("Expected VK_KHR_nonexistent to be unsupported")
+	}
+	if IsExtensionSupported("VK_KHR_swapchain", nil) {
+		t.Error("Expected no extension to be supported from an empty list")
+	}
+	if !IsLayerSupported("VK_LAYER_KHRONOS_validation", layers) {
+		t.Error("Expected VK_LAYER_KHRONOS_validation to be supported")
+	}
+	if IsLayerSupported("VK_LAYER_nonexistent", layers) {
+		t.Error("Expected VK_LAYER_nonexistent to be unsupported")
+	}
+}
+
+// TestFilterSupportedExtensions tests splitting requested extensions by availability
+func TestFilterSupportedExtensions(t *testing.T) {
+	available := []ExtensionProperties{
+		{ExtensionName: "VK_KHR_swapchain"},
+		{ExtensionName: ExtensionNameVideoQueue},
+	}
+	requested := []string{"VK_KHR_missing_a", ExtensionNameVideoQueue, "VK_KHR_missing_b", "VK_KHR_swapchain"}
+
+	supported, missing := FilterSupportedExtensions(requested, available)
+	if expected := []string{ExtensionNameVideoQueue, "VK_KHR_swapchain"}; !reflect.DeepEqual(supported, expected) {
+		t.Errorf("Expected supported extensions in requested order, got %v", supported)
+	}
+	if expected := []string{"VK_KHR_missing_a", "VK_KHR_missing_b"}; !reflect.DeepEqual(missing, expected) {
+		t.Errorf("Expected missing extensions in requested order, got %v", missing)
+	}
+
+	supported, missing = FilterSupportedExtensions(nil, available)
+	if supported != nil || missing != nil {
+		t.Errorf("Expected nil results for no requested extensions, got %v and %v", supported, missing)
+	}
+}
@@ -0,0 +1,152 @@
+package vulkan
+
+/*
+#include <vulkan/vulkan.h>
+#include <stdlib.h>
+*/
+import "C"
+
+import "unsafe"
+
+const (
+	// ExtensionNamePipelineLibrary is the pipeline library device extension name
+	ExtensionNamePipelineLibrary = "VK_KHR_pipeline_library"
+	// ExtensionNameGraphicsPipelineLibrary is the graphics pipeline library device extension
+	// name. It requires ExtensionNamePipelineLibrary.
+	ExtensionNameGraphicsPipelineLibrary = "VK_EXT_graphics_pipeline_library"
+)
+
+// PipelineCreateFlags represents pipeline creation flags
+type PipelineCreateFlags uint32
+
+const (
+	// PipelineCreateLibraryBit creates a pipeline library that can only be linked into other
+	// pipelines, not bound
+	PipelineCreateLibraryBit PipelineCreateFlags = C.VK_PIPELINE_CREATE_LIBRARY_BIT_KHR
+	// PipelineCreateRetainLinkTimeOptimizationInfoBit keeps the information a library needs to
+	// be linked later with PipelineCreateLinkTimeOptimizationBit
+	PipelineCreateRetainLinkTimeOptimizationInfoBit PipelineCreateFlags = C.VK_PIPELINE_CREATE_RETAIN_LINK_TIME_OPTIMIZATION_INFO_BIT_EXT
+	// PipelineCreateLinkTimeOptimizationBit optimizes the linked pipeline as a whole instead
+	// of fast-linking the libraries
+	PipelineCreateLinkTimeOptimizationBit PipelineCreateFlags = C.VK_PIPELINE_CREATE_LINK_TIME_OPTIMIZATION_BIT_EXT
+)
+
+// GraphicsPipelineLibraryFlags select the parts of the graphics pipeline state a graphics
+// pipeline library provides
+type GraphicsPipelineLibraryFlags uint32
+
+const (
+	GraphicsPipelineLibraryVertexInputInterfaceBit    GraphicsPipelineLibraryFlags = C.VK_GRAPHICS_PIPELINE_LIBRARY_VERTEX_INPUT_INTERFACE_BIT_EXT
+	GraphicsPipelineLibraryPreRasterizationShadersBit GraphicsPipelineLibraryFlags = C.VK_GRAPHICS_PIPELINE_LIBRARY_PRE_RASTERIZATION_SHADERS_BIT_EXT
+	GraphicsPipelineLibraryFragmentShaderBit          GraphicsPipelineLibraryFlags = C.VK_GRAPHICS_PIPELINE_LIBRARY_FRAGMENT_SHADER_BIT_EXT
+	GraphicsPipelineLibraryFragmentOutputInterfaceBit GraphicsPipelineLibraryFlags = C.VK_GRAPHICS_PIPELINE_LIBRARY_FRAGMENT_OUTPUT_INTERFACE_BIT_EXT
+)
+
+// PhysicalDeviceGraphicsPipelineLibraryFeatures contains the graphics pipeline library feature
+type PhysicalDeviceGraphicsPipelineLibraryFeatures struct {
+	GraphicsPipelineLibrary bool
+}
+
+// PhysicalDeviceGraphicsPipelineLibraryProperties reports how graphics pipeline libraries are
+// linked
+type PhysicalDeviceGraphicsPipelineLibraryProperties struct {
+	// GraphicsPipelineLibraryFastLinking means linking without
+	// PipelineCreateLinkTimeOptimizationBit is cheap enough to do at draw time
+	GraphicsPipelineLibraryFastLinking bool
+	// GraphicsPipelineLibraryIndependentInterpolationDecoration means interpolation
+	// decorations do not have to match between the pre-rasterization and fragment shader
+	// libraries
+	GraphicsPipelineLibraryIndependentInterpolationDecoration bool
+}
+
+// pipelineLibraryInfoToC returns a VkPipelineLibraryCreateInfoKHR linking libraries, or nil if
+// there are none. The struct is allocated in C memory and appended to allocations, which the
+// caller must free. operation names the calling function in errors.
+func pipelineLibraryInfoToC(operation string, libraries []Pipeline, allocations *[]unsafe.Pointer) (*C.VkPipelineLibraryCreateInfoKHR, error) {
+	if len(libraries) == 0 {
+		return nil, nil
+	}
+
+	cLibrariesPtr := (*C.VkPipeline)(C.calloc(C.size_t(len(libraries)), C.size_t(unsafe.Sizeof(C.VkPipeline(nil)))))
+	if cLibrariesPtr == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, operation, "failed to allocate memory for pipeline libraries")
+	}
+	*allocations = append(*allocations, unsafe.Pointer(cLibrariesPtr))
+
+	cLibraries := unsafe.Slice(cLibrariesPtr, len(libraries))
+	for i, library := range libraries {
+		cLibraries[i] = C.VkPipeline(library)
+	}
+
+	cInfo := (*C.VkPipelineLibraryCreateInfoKHR)(C.calloc(1, C.sizeof_VkPipelineLibraryCreateInfoKHR))
+	if cInfo == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, operation, "failed to allocate memory for pipeline library info")
+	}
+	*allocations = append(*allocations, unsafe.Pointer(cInfo))
+
+	cInfo.sType = C.VK_STRUCTURE_TYPE_PIPELINE_LIBRARY_CREATE_INFO_KHR
+	cInfo.libraryCount = C.uint32_t(len(libraries))
+	cInfo.pLibraries = cLibrariesPtr
+	return cInfo, nil
+}
+
+// graphicsPipelineLibraryFeaturesToC prepends a struct enabling the graphics pipeline library
+// feature to the pNext chain next. The struct is allocated in C memory and appended to
+// allocations, which the caller must free.
+func graphicsPipelineLibraryFeaturesToC(features *PhysicalDeviceGraphicsPipelineLibraryFeatures, next unsafe.Pointer, allocations *[]unsafe.Pointer) (unsafe.Pointer, error) {
+	cLibrary := (*C.VkPhysicalDeviceGraphicsPipelineLibraryFeaturesEXT)(C.calloc(1, C.sizeof_VkPhysicalDeviceGraphicsPipelineLibraryFeaturesEXT))
+	if cLibrary == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "CreateDevice", "failed to allocate memory for graphics pipeline library features")
+	}
+	*allocations = append(*allocations, unsafe.Pointer(cLibrary))
+
+	cLibrary.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_GRAPHICS_PIPELINE_LIBRARY_FEATURES_EXT
+	cLibrary.pNext = next
+	cLibrary.graphicsPipelineLibrary = boolToVkBool32(features.GraphicsPipelineLibrary)
+
+	return unsafe.Pointer(cLibrary), nil
+}
+
+// GetPhysicalDeviceGraphicsPipelineLibraryFeaturesEXT queries graphics pipeline library support
+func GetPhysicalDeviceGraphicsPipelineLibraryFeaturesEXT(physicalDevice PhysicalDevice) PhysicalDeviceGraphicsPipelineLibraryFeatures {
+	cFeatures2 := (*C.VkPhysicalDeviceFeatures2)(C.calloc(1, C.sizeof_VkPhysicalDeviceFeatures2))
+	cLibrary := (*C.VkPhysicalDeviceGraphicsPipelineLibraryFeaturesEXT)(C.calloc(1, C.sizeof_VkPhysicalDeviceGraphicsPipelineLibraryFeaturesEXT))
+	defer C.free(unsafe.Pointer(cFeatures2))
+	defer C.free(unsafe.Pointer(cLibrary))
+	if cFeatures2 == nil || cLibrary == nil {
+		return PhysicalDeviceGraphicsPipelineLibraryFeatures{}
+	}
+
+	cFeatures2.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_FEATURES_2
+	cFeatures2.pNext = unsafe.Pointer(cLibrary)
+	cLibrary.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_GRAPHICS_PIPELINE_LIBRARY_FEATURES_EXT
+
+	C.vkGetPhysicalDeviceFeatures2(C.VkPhysicalDevice(physicalDevice), cFeatures2)
+
+	return PhysicalDeviceGraphicsPipelineLibraryFeatures{
+		GraphicsPipelineLibrary: vkBool32ToBool(cLibrary.graphicsPipelineLibrary),
+	}
+}
+
+// GetPhysicalDeviceGraphicsPipelineLibraryPropertiesEXT queries how graphics pipeline libraries
+// are linked
+func GetPhysicalDeviceGraphicsPipelineLibraryPropertiesEXT(physicalDevice PhysicalDevice) PhysicalDeviceGraphicsPipelineLibraryProperties {
+	cProps2 := (*C.VkPhysicalDeviceProperties2)(C.calloc(1, C.sizeof_VkPhysicalDeviceProperties2))
+	cLibrary := (*C.VkPhysicalDeviceGraphicsPipelineLibraryPropertiesEXT)(C.calloc(1, C.sizeof_VkPhysicalDeviceGraphicsPipelineLibraryPropertiesEXT))
+	defer C.free(unsafe.Pointer(cProps2))
+	defer C.free(unsafe.Pointer(cLibrary))
+	if cProps2 == nil || cLibrary == nil {
+		return PhysicalDeviceGraphicsPipelineLibraryProperties{}
+	}
+
+	cProps2.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_PROPERTIES_2
+	cProps2.pNext = unsafe.Pointer(cLibrary)
+	cLibrary.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_GRAPHICS_PIPELINE_LIBRARY_PROPERTIES_EXT
+
+	C.vkGetPhysicalDeviceProperties2(C.VkPhysicalDevice(physicalDevice), cProps2)
+
+	return PhysicalDeviceGraphicsPipelineLibraryProperties{
+		GraphicsPipelineLibraryFastLinking:                        vkBool32ToBool(cLibrary.graphicsPipelineLibraryFastLinking),
+		GraphicsPipelineLibraryIndependentInterpolationDecoration: vkBool32ToBool(cLibrary.graphicsPipelineLibraryIndependentInterpolationDecoration),
+	}
+}
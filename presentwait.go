@@ -0,0 +1,144 @@
+package vulkan
+
+/*
+#include <vulkan/vulkan.h>
+#include <stdlib.h>
+
+// Function pointer for the VK_KHR_present_wait extension function
+// This needs to be loaded dynamically at runtime.
+//
+// IMPORTANT: This is a global static pointer and NOT thread-safe during loading.
+// LoadPresentWaitFunctions must be called from a single thread during initialization
+// before any concurrent present wait API usage.
+static PFN_vkWaitForPresentKHR pfn_vkWaitForPresentKHR = NULL;
+
+static int loadPresentWaitDeviceFunctions(VkDevice device) {
+    if (device == VK_NULL_HANDLE) {
+        return 0;
+    }
+    pfn_vkWaitForPresentKHR = (PFN_vkWaitForPresentKHR)
+        vkGetDeviceProcAddr(device, "vkWaitForPresentKHR");
+
+    return pfn_vkWaitForPresentKHR != NULL;
+}
+
+// Wrapper returns VK_ERROR_EXTENSION_NOT_PRESENT if the function pointer is NULL.
+static VkResult call_vkWaitForPresentKHR(VkDevice device, VkSwapchainKHR swapchain, uint64_t presentId, uint64_t timeout) {
+    if (pfn_vkWaitForPresentKHR == NULL) {
+        return VK_ERROR_EXTENSION_NOT_PRESENT;
+    }
+    return pfn_vkWaitForPresentKHR(device, swapchain, presentId, timeout);
+}
+*/
+import "C"
+
+import (
+	"unsafe"
+)
+
+// Present ID and present wait extension names; present wait requires present ID
+const (
+	ExtensionNamePresentID   = "VK_KHR_present_id"
+	ExtensionNamePresentWait = "VK_KHR_present_wait"
+)
+
+// PhysicalDevicePresentWaitFeatures contains the present ID and present wait features
+type PhysicalDevicePresentWaitFeatures struct {
+	// PresentID allows tagging presents with an application-chosen, increasing ID
+	PresentID bool
+	// PresentWait allows waiting for a tagged present with WaitForPresentKHR
+	PresentWait bool
+}
+
+// LoadPresentWaitFunctions loads present wait extension functions for a device.
+//
+// This function MUST be called after creating a logical device with the VK_KHR_present_id
+// and VK_KHR_present_wait extensions enabled and before waiting for presents.
+//
+// IMPORTANT: This function is NOT thread-safe. Only one device is supported at a time;
+// calling this function again will overwrite previously loaded function pointers.
+//
+// Returns false if the present wait function could not be loaded.
+func LoadPresentWaitFunctions(device Device) bool {
+	return C.loadPresentWaitDeviceFunctions(C.VkDevice(device)) != 0
+}
+
+// presentWaitFeaturesToC prepends structs enabling the requested present ID and present
+// wait features to the pNext chain next. The structs are allocated in C memory and
+// appended to allocations, which the caller must free.
+func presentWaitFeaturesToC(features *PhysicalDevicePresentWaitFeatures, next unsafe.Pointer, allocations *[]unsafe.Pointer) (unsafe.Pointer, error) {
+	cPresentID := (*C.VkPhysicalDevicePresentIdFeaturesKHR)(C.calloc(1, C.sizeof_VkPhysicalDevicePresentIdFeaturesKHR))
+	if cPresentID == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "CreateDevice", "failed to allocate memory for present ID features")
+	}
+	*allocations = append(*allocations, unsafe.Pointer(cPresentID))
+
+	cPresentWait := (*C.VkPhysicalDevicePresentWaitFeaturesKHR)(C.calloc(1, C.sizeof_VkPhysicalDevicePresentWaitFeaturesKHR))
+	if cPresentWait == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "CreateDevice", "failed to allocate memory for present wait features")
+	}
+	*allocations = append(*allocations, unsafe.Pointer(cPresentWait))
+
+	cPresentID.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_PRESENT_ID_FEATURES_KHR
+	cPresentID.pNext = next
+	cPresentID.presentId = boolToVkBool32(features.PresentID)
+
+	cPresentWait.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_PRESENT_WAIT_FEATURES_KHR
+	cPresentWait.pNext = unsafe.Pointer(cPresentID)
+	cPresentWait.presentWait = boolToVkBool32(features.PresentWait)
+
+	return unsafe.Pointer(cPresentWait), nil
+}
+
+// GetPhysicalDevicePresentWaitFeaturesKHR queries present ID and present wait feature support
+func GetPhysicalDevicePresentWaitFeaturesKHR(physicalDevice PhysicalDevice) PhysicalDevicePresentWaitFeatures {
+	cFeatures2 := (*C.VkPhysicalDeviceFeatures2)(C.calloc(1, C.sizeof_VkPhysicalDeviceFeatures2))
+	cPresentID := (*C.VkPhysicalDevicePresentIdFeaturesKHR)(C.calloc(1, C.sizeof_VkPhysicalDevicePresentIdFeaturesKHR))
+	cPresentWait := (*C.VkPhysicalDevicePresentWaitFeaturesKHR)(C.calloc(1, C.sizeof_VkPhysicalDevicePresentWaitFeaturesKHR))
+	defer C.free(unsafe.Pointer(cFeatures2))
+	defer C.free(unsafe.Pointer(cPresentID))
+	defer C.free(unsafe.Pointer(cPresentWait))
+	if cFeatures2 == nil || cPresentID == nil || cPresentWait == nil {
+		return PhysicalDevicePresentWaitFeatures{}
+	}
+
+	cFeatures2.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_FEATURES_2
+	cFeatures2.pNext = unsafe.Pointer(cPresentID)
+	cPresentID.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_PRESENT_ID_FEATURES_KHR
+	cPresentID.pNext = unsafe.Pointer(cPresentWait)
+	cPresentWait.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_PRESENT_WAIT_FEATURES_KHR
+
+	C.vkGetPhysicalDeviceFeatures2(C.VkPhysicalDevice(physicalDevice), cFeatures2)
+
+	return PhysicalDevicePresentWaitFeatures{
+		PresentID:   vkBool32ToBool(cPresentID.presentId),
+		PresentWait: vkBool32ToBool(cPresentWait.presentWait),
+	}
+}
+
+// WaitForPresentKHR waits until the present tagged with presentID, or a later one, has been
+// displayed on swapchain. timeout is in nanoseconds; ErrTimeout is returned if it elapses
+// first. Comparing the wake-up times of consecutive waits gives the real interval between
+// frames reaching the screen. Returns an error if LoadPresentWaitFunctions was not called.
+func WaitForPresentKHR(device Device, swapchain Swapchain, presentID uint64, timeout uint64) error {
+	if device == nil {
+		return NewValidationError("device", "cannot be nil")
+	}
+	if swapchain == nil {
+		return NewValidationError("swapchain", "cannot be nil")
+	}
+	if presentID == 0 {
+		return NewValidationError("presentID", "must be greater than 0")
+	}
+
+	result := Result(C.call_vkWaitForPresentKHR(C.VkDevice(device), C.VkSwapchainKHR(swapchain), C.uint64_t(presentID), C.uint64_t(timeout)))
+	traceResult("vkWaitForPresentKHR", result)
+	switch result {
+	case Success, SuboptimalKHR:
+		return nil
+	case Timeout:
+		return ErrTimeout
+	default:
+		return NewVulkanError(result, "WaitForPresentKHR", "failed to wait for present")
+	}
+}
@@ -0,0 +1,65 @@
+//go:build cgo
+
+package vulkan
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestWaitForPresentValidation tests input validation and the unloaded-extension error
+func TestWaitForPresentValidation(t *testing.T) {
+	fakeDevice := Device(uintptr(0x1234))
+	fakeSwapchain := Swapchain(uintptr(0x5678))
+
+	tests := []struct {
+		name       string
+		device     Device
+		swapchain  Swapchain
+		presentID  uint64
+		errorParam string
+	}{
+		{
+			name:       "nil device",
+			device:     nil,
+			swapchain:  fakeSwapchain,
+			presentID:  1,
+			errorParam: "device",
+		},
+		{
+			name:       "nil swapchain",
+			device:     fakeDevice,
+			swapchain:  nil,
+			presentID:  1,
+			errorParam: "swapchain",
+		},
+		{
+			name:       "zero present ID",
+			device:     fakeDevice,
+			swapchain:  fakeSwapchain,
+			presentID:  0,
+			errorParam: "presentID",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := WaitForPresentKHR(tt.device, tt.swapchain, tt.presentID, 0)
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+
+	// Without LoadPresentWaitFunctions the wait reports the missing extension
+	err := WaitForPresentKHR(fakeDevice, fakeSwapchain, 1, 0)
+	var vulkanErr *VulkanError
+	if !errors.As(err, &vulkanErr) || vulkanErr.Result != ErrorExtensionNotPresent {
+		t.Errorf("Expected ErrorExtensionNotPresent, got %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+package vulkan
+
+/*
+#include <vulkan/vulkan.h>
+#include <stdlib.h>
+
+// Function pointer for the push descriptor KHR extension function
+// This needs to be loaded dynamically at runtime.
+//
+// IMPORTANT: This is a global static pointer and NOT thread-safe during loading.
+// LoadPushDescriptorFunctions must be called from a single thread during initialization.
+static PFN_vkCmdPushDescriptorSetKHR pfn_vkCmdPushDescriptorSetKHR = NULL;
+
+static int loadPushDescriptorDeviceFunctions(VkDevice device) {
+    if (device == VK_NULL_HANDLE) {
+        return 0;
+    }
+    pfn_vkCmdPushDescriptorSetKHR = (PFN_vkCmdPushDescriptorSetKHR)
+        vkGetDeviceProcAddr(device, "vkCmdPushDescriptorSetKHR");
+    return pfn_vkCmdPushDescriptorSetKHR != NULL;
+}
+
+// Returns 1 on success, 0 if function pointer is NULL.
+static int call_vkCmdPushDescriptorSetKHR(
+    VkCommandBuffer commandBuffer,
+    VkPipelineBindPoint pipelineBindPoint,
+    VkPipelineLayout layout,
+    uint32_t set,
+    uint32_t descriptorWriteCount,
+    const VkWriteDescriptorSet* pDescriptorWrites) {
+    if (pfn_vkCmdPushDescriptorSetKHR == NULL) {
+        return 0;
+    }
+    pfn_vkCmdPushDescriptorSetKHR(commandBuffer, pipelineBindPoint, layout, set, descriptorWriteCount, pDescriptorWrites);
+    return 1;
+}
+*/
+import "C"
+
+import (
+	"unsafe"
+)
+
+// ExtensionNamePushDescriptor is the push descriptor extension name
+const ExtensionNamePushDescriptor = "VK_KHR_push_descriptor"
+
+// LoadPushDescriptorFunctions loads the push descriptor extension function for a device.
+//
+// This function MUST be called after creating a logical device with the VK_KHR_push_descriptor
+// extension enabled and before calling CmdPushDescriptorSetKHR.
+//
+// IMPORTANT: This function is NOT thread-safe. Only one device is supported at a time;
+// calling this function again will overwrite the previously loaded function pointer.
+func LoadPushDescriptorFunctions(device Device) bool {
+	return C.loadPushDescriptorDeviceFunctions(C.VkDevice(device)) != 0
+}
+
+// CmdPushDescriptorSetKHR pushes descriptor updates directly into a command buffer. The set
+// must have been created with DescriptorSetLayoutCreatePushDescriptorBitKHR; DstSet in the
+// writes is ignored.
+// Returns an error if LoadPushDescriptorFunctions was not called.
+func CmdPushDescriptorSetKHR(commandBuffer CommandBuffer, pipelineBindPoint PipelineBindPoint, layout PipelineLayout, set uint32, writes []WriteDescriptorSet) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+	if layout == nil {
+		return NewValidationError("layout", "cannot be nil")
+	}
+	if len(writes) == 0 {
+		return nil
+	}
+
+	var allocations []unsafe.Pointer
+	defer func() { freeAllocations(allocations) }()
+
+	cWrites, err := writeDescriptorSetsToC(writes, &allocations)
+	if err != nil {
+		return err
+	}
+
+	if C.call_vkCmdPushDescriptorSetKHR(
+		C.VkCommandBuffer(commandBuffer),
+		C.VkPipelineBindPoint(pipelineBindPoint),
+		C.VkPipelineLayout(layout),
+		C.uint32_t(set),
+		C.uint32_t(len(writes)),
+		cWrites,
+	) == 0 {
+		return NewVulkanError(ErrorExtensionNotPresent, "CmdPushDescriptorSetKHR", "push descriptor extension not loaded - call LoadPushDescriptorFunctions first")
+	}
+	return nil
+}
@@ -0,0 +1,281 @@
+package vulkan
+
+/*
+#include <vulkan/vulkan.h>
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"math/bits"
+	"time"
+	"unsafe"
+)
+
+// QueryType represents the kind of queries in a query pool
+type QueryType int32
+
+const (
+	QueryTypeOcclusion          QueryType = C.VK_QUERY_TYPE_OCCLUSION
+	QueryTypePipelineStatistics QueryType = C.VK_QUERY_TYPE_PIPELINE_STATISTICS
+	QueryTypeTimestamp          QueryType = C.VK_QUERY_TYPE_TIMESTAMP
+)
+
+// QueryResultFlags controls how query results are returned. GetQueryPoolResults always
+// returns 64-bit values, so QueryResult64Bit is implied.
+type QueryResultFlags uint32
+
+const (
+	QueryResult64Bit               QueryResultFlags = C.VK_QUERY_RESULT_64_BIT
+	QueryResultWaitBit             QueryResultFlags = C.VK_QUERY_RESULT_WAIT_BIT
+	QueryResultWithAvailabilityBit QueryResultFlags = C.VK_QUERY_RESULT_WITH_AVAILABILITY_BIT
+	QueryResultPartialBit          QueryResultFlags = C.VK_QUERY_RESULT_PARTIAL_BIT
+)
+
+// Count returns the number of counters selected, which is the number of values each
+// pipeline statistics query returns
+func (f QueryPipelineStatisticFlags) Count() uint32 {
+	return uint32(bits.OnesCount32(uint32(f)))
+}
+
+// QueryPoolCreateInfo contains query pool creation information
+type QueryPoolCreateInfo struct {
+	QueryType  QueryType
+	QueryCount uint32
+	// PipelineStatistics selects the counters of a QueryTypePipelineStatistics pool and
+	// requires the PipelineStatisticsQuery device feature. Ignored for other query types.
+	PipelineStatistics QueryPipelineStatisticFlags
+}
+
+// PipelineStatistics holds the decoded counters of one pipeline statistics query.
+// Counters that were not enabled in the pool are zero.
+type PipelineStatistics struct {
+	InputAssemblyVertices                   uint64
+	InputAssemblyPrimitives                 uint64
+	VertexShaderInvocations                 uint64
+	GeometryShaderInvocations               uint64
+	GeometryShaderPrimitives                uint64
+	ClippingInvocations                     uint64
+	ClippingPrimitives                      uint64
+	FragmentShaderInvocations               uint64
+	TessellationControlShaderPatches        uint64
+	TessellationEvaluationShaderInvocations uint64
+	ComputeShaderInvocations                uint64
+}
+
+// CreateQueryPool creates a query pool
+func CreateQueryPool(device Device, createInfo *QueryPoolCreateInfo) (QueryPool, error) {
+	if device == nil {
+		return nil, NewValidationError("device", "cannot be nil")
+	}
+	if createInfo == nil {
+		return nil, NewValidationError("createInfo", "cannot be nil")
+	}
+	if createInfo.QueryCount == 0 {
+		return nil, NewValidationError("createInfo.QueryCount", "must be greater than 0")
+	}
+	if createInfo.QueryType == QueryTypePipelineStatistics && createInfo.PipelineStatistics == 0 {
+		return nil, NewValidationError("createInfo.PipelineStatistics", "must select at least one counter for pipeline statistics queries")
+	}
+
+	var cCreateInfo C.VkQueryPoolCreateInfo
+	cCreateInfo.sType = C.VK_STRUCTURE_TYPE_QUERY_POOL_CREATE_INFO
+	cCreateInfo.pNext = nil
+	cCreateInfo.queryType = C.VkQueryType(createInfo.QueryType)
+	cCreateInfo.queryCount = C.uint32_t(createInfo.QueryCount)
+	if createInfo.QueryType == QueryTypePipelineStatistics {
+		cCreateInfo.pipelineStatistics = C.VkQueryPipelineStatisticFlags(createInfo.PipelineStatistics)
+	}
+
+	var queryPool C.VkQueryPool
+	result := Result(C.vkCreateQueryPool(C.VkDevice(device), &cCreateInfo, nil, &queryPool))
+	traceResult("vkCreateQueryPool", result)
+	if result != Success {
+		return nil, NewVulkanError(result, "CreateQueryPool", "failed to create query pool")
+	}
+
+	return QueryPool(queryPool), nil
+}
+
+// DestroyQueryPool destroys a query pool
+func DestroyQueryPool(device Device, queryPool QueryPool) {
+	C.vkDestroyQueryPool(C.VkDevice(device), C.VkQueryPool(queryPool), nil)
+}
+
+// GetQueryPoolResults copies the results of queryCount queries starting at firstQuery.
+// valuesPerQuery is the number of values each query produces: 1 for occlusion and
+// timestamp queries, QueryPipelineStatisticFlags.Count() for pipeline statistics queries,
+// plus 1 when QueryResultWithAvailabilityBit is set. The results of query i start at
+// index i*valuesPerQuery.
+//
+// Without QueryResultWaitBit, NotReady is returned together with the results if some
+// queries were unavailable; their values are left zero unless QueryResultPartialBit is set.
+func GetQueryPoolResults(device Device, queryPool QueryPool, firstQuery, queryCount, valuesPerQuery uint32, flags QueryResultFlags) ([]uint64, error) {
+	if device == nil {
+		return nil, NewValidationError("device", "cannot be nil")
+	}
+	if queryPool == nil {
+		return nil, NewValidationError("queryPool", "cannot be nil")
+	}
+	if queryCount == 0 {
+		return nil, NewValidationError("queryCount", "must be greater than 0")
+	}
+	if valuesPerQuery == 0 {
+		return nil, NewValidationError("valuesPerQuery", "must be greater than 0")
+	}
+
+	results := make([]uint64, int(queryCount)*int(valuesPerQuery))
+	stride := C.VkDeviceSize(valuesPerQuery) * 8
+	result := Result(C.vkGetQueryPoolResults(C.VkDevice(device), C.VkQueryPool(queryPool), C.uint32_t(firstQuery), C.uint32_t(queryCount),
+		C.size_t(len(results)*8), unsafe.Pointer(&results[0]), stride, C.VkQueryResultFlags(flags|QueryResult64Bit)))
+	traceResult("vkGetQueryPoolResults", result)
+	switch result {
+	case Success:
+		return results, nil
+	case NotReady:
+		return results, NotReady
+	default:
+		return nil, NewVulkanError(result, "GetQueryPoolResults", "failed to get query pool results")
+	}
+}
+
+// DecodePipelineStatistics maps the values of one pipeline statistics query to named
+// counters. flags must be the PipelineStatistics the pool was created with; results holds
+// the query's values in counter bit order, as returned by GetQueryPoolResults. Extra
+// values, such as an availability value, are ignored.
+func DecodePipelineStatistics(flags QueryPipelineStatisticFlags, results []uint64) PipelineStatistics {
+	var stats PipelineStatistics
+	counters := []struct {
+		bit   QueryPipelineStatisticFlags
+		field *uint64
+	}{
+		{QueryPipelineStatisticInputAssemblyVerticesBit, &stats.InputAssemblyVertices},
+		{QueryPipelineStatisticInputAssemblyPrimitivesBit, &stats.InputAssemblyPrimitives},
+		{QueryPipelineStatisticVertexShaderInvocationsBit, &stats.VertexShaderInvocations},
+		{QueryPipelineStatisticGeometryShaderInvocationsBit, &stats.GeometryShaderInvocations},
+		{QueryPipelineStatisticGeometryShaderPrimitivesBit, &stats.GeometryShaderPrimitives},
+		{QueryPipelineStatisticClippingInvocationsBit, &stats.ClippingInvocations},
+		{QueryPipelineStatisticClippingPrimitivesBit, &stats.ClippingPrimitives},
+		{QueryPipelineStatisticFragmentShaderInvocationsBit, &stats.FragmentShaderInvocations},
+		{QueryPipelineStatisticTessellationControlShaderPatchesBit, &stats.TessellationControlShaderPatches},
+		{QueryPipelineStatisticTessellationEvaluationShaderInvocationsBit, &stats.TessellationEvaluationShaderInvocations},
+		{QueryPipelineStatisticComputeShaderInvocationsBit, &stats.ComputeShaderInvocations},
+	}
+
+	i := 0
+	for _, counter := range counters {
+		if flags&counter.bit == 0 {
+			continue
+		}
+		if i >= len(results) {
+			break
+		}
+		*counter.field = results[i]
+		i++
+	}
+	return stats
+}
+
+// CmdResetQueryPool resets a range of queries; queries must be reset before they are begun
+func CmdResetQueryPool(commandBuffer CommandBuffer, queryPool QueryPool, firstQuery, queryCount uint32) {
+	C.vkCmdResetQueryPool(C.VkCommandBuffer(commandBuffer), C.VkQueryPool(queryPool), C.uint32_t(firstQuery), C.uint32_t(queryCount))
+}
+
+// CmdBeginQuery begins an occlusion or pipeline statistics query
+func CmdBeginQuery(commandBuffer CommandBuffer, queryPool QueryPool, query uint32, flags QueryControlFlags) {
+	C.vkCmdBeginQuery(C.VkCommandBuffer(commandBuffer), C.VkQueryPool(queryPool), C.uint32_t(query), C.VkQueryControlFlags(flags))
+}
+
+// CmdEndQuery ends a query begun with CmdBeginQuery
+func CmdEndQuery(commandBuffer CommandBuffer, queryPool QueryPool, query uint32) {
+	C.vkCmdEndQuery(C.VkCommandBuffer(commandBuffer), C.VkQueryPool(queryPool), C.uint32_t(query))
+}
+
+// CmdWriteTimestamp2 writes the device timestamp into a query of a QueryTypeTimestamp pool
+// once all previous commands have completed stage. stage must be a single pipeline stage.
+// The query must have been reset first. Core in Vulkan 1.3 (VK_KHR_synchronization2); the
+// synchronization2 feature must be enabled, through Vulkan13Features or
+// Synchronization2Features in DeviceCreateInfo.
+func CmdWriteTimestamp2(commandBuffer CommandBuffer, stage PipelineStageFlags2, queryPool QueryPool, query uint32) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+	if queryPool == nil {
+		return NewValidationError("queryPool", "cannot be nil")
+	}
+	if bits.OnesCount64(uint64(stage)) != 1 {
+		return NewValidationError("stage", "must be a single pipeline stage")
+	}
+
+	C.vkCmdWriteTimestamp2(C.VkCommandBuffer(commandBuffer), C.VkPipelineStageFlags2(stage), C.VkQueryPool(queryPool), C.uint32_t(query))
+	return nil
+}
+
+// GPUTimerScope measures the GPU time of the commands recorded between Begin and End using
+// two consecutive queries of a QueryTypeTimestamp pool:
+//
+//	timer, err := vulkan.NewGPUTimerScope(queryPool, 0, limits.TimestampPeriod)
+//	timer.Begin(commandBuffer)
+//	// record the pass
+//	timer.End(commandBuffer)
+//	// submit and wait, then
+//	elapsed, err := timer.Elapsed(device)
+//
+// Use a distinct pair of queries for each pass timed in the same submission.
+type GPUTimerScope struct {
+	queryPool  QueryPool
+	firstQuery uint32
+	// timestampPeriod is the number of nanoseconds per timestamp tick
+	timestampPeriod float32
+}
+
+// NewGPUTimerScope creates a timer that writes to queries firstQuery and firstQuery+1 of
+// queryPool. timestampPeriod is PhysicalDeviceLimits.TimestampPeriod of the device.
+func NewGPUTimerScope(queryPool QueryPool, firstQuery uint32, timestampPeriod float32) (*GPUTimerScope, error) {
+	if queryPool == nil {
+		return nil, NewValidationError("queryPool", "cannot be nil")
+	}
+	if timestampPeriod <= 0 {
+		return nil, NewValidationError("timestampPeriod", "must be greater than 0")
+	}
+
+	return &GPUTimerScope{
+		queryPool:       queryPool,
+		firstQuery:      firstQuery,
+		timestampPeriod: timestampPeriod,
+	}, nil
+}
+
+// Begin resets the timer's queries and writes the start timestamp. Because it resets queries,
+// Begin must be recorded outside a render pass instance.
+func (t *GPUTimerScope) Begin(commandBuffer CommandBuffer) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+
+	CmdResetQueryPool(commandBuffer, t.queryPool, t.firstQuery, 2)
+	return CmdWriteTimestamp2(commandBuffer, PipelineStage2TopOfPipe, t.queryPool, t.firstQuery)
+}
+
+// End writes the end timestamp once all commands recorded before it have completed
+func (t *GPUTimerScope) End(commandBuffer CommandBuffer) error {
+	return CmdWriteTimestamp2(commandBuffer, PipelineStage2BottomOfPipe, t.queryPool, t.firstQuery+1)
+}
+
+// Elapsed waits for both timestamps and returns the GPU time between Begin and End.
+// The command buffer must have been submitted.
+func (t *GPUTimerScope) Elapsed(device Device) (time.Duration, error) {
+	results, err := GetQueryPoolResults(device, t.queryPool, t.firstQuery, 2, 1, QueryResultWaitBit)
+	if err != nil {
+		return 0, err
+	}
+	return timestampDelta(results[0], results[1], t.timestampPeriod)
+}
+
+// timestampDelta converts the ticks between two timestamp query results to a duration
+func timestampDelta(start, end uint64, timestampPeriod float32) (time.Duration, error) {
+	if end < start {
+		return 0, NewValidationError("timestamps", "end timestamp is before start timestamp")
+	}
+	return time.Duration(float64(end-start) * float64(timestampPeriod)), nil
+}
@@ -0,0 +1,127 @@
+//go:build cgo
+
+package vulkan
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestDecodePipelineStatistics tests that query values are assigned to counters in bit order
+func TestDecodePipelineStatistics(t *testing.T) {
+	flags := QueryPipelineStatisticInputAssemblyVerticesBit |
+		QueryPipelineStatisticClippingInvocationsBit |
+		QueryPipelineStatisticFragmentShaderInvocationsBit |
+		QueryPipelineStatisticComputeShaderInvocationsBit
+
+	if flags.Count() != 4 {
+		t.Fatalf("Expected 4 counters, got %d", flags.Count())
+	}
+
+	// A trailing availability value must be ignored
+	stats := DecodePipelineStatistics(flags, []uint64{300, 100, 5000, 64, 1})
+	expected := PipelineStatistics{
+		InputAssemblyVertices:     300,
+		ClippingInvocations:       100,
+		FragmentShaderInvocations: 5000,
+		ComputeShaderInvocations:  64,
+	}
+	if stats != expected {
+		t.Errorf("Expected %+v, got %+v", expected, stats)
+	}
+
+	// Short results leave the remaining counters zero
+	stats = DecodePipelineStatistics(flags, []uint64{300})
+	if stats != (PipelineStatistics{InputAssemblyVertices: 300}) {
+		t.Errorf("Expected only InputAssemblyVertices to be set, got %+v", stats)
+	}
+}
+
+// TestQueryPoolValidation tests input validation of query pool creation and result retrieval
+func TestQueryPoolValidation(t *testing.T) {
+	fakeDevice := Device(uintptr(0x1234))
+	fakeQueryPool := QueryPool(uintptr(0x5678))
+
+	tests := []struct {
+		name       string
+		call       func() error
+		errorParam string
+	}{
+		{
+			name: "zero query count",
+			call: func() error {
+				_, err := CreateQueryPool(fakeDevice, &QueryPoolCreateInfo{QueryType: QueryTypeOcclusion})
+				return err
+			},
+			errorParam: "createInfo.QueryCount",
+		},
+		{
+			name: "statistics without counters",
+			call: func() error {
+				_, err := CreateQueryPool(fakeDevice, &QueryPoolCreateInfo{QueryType: QueryTypePipelineStatistics, QueryCount: 1})
+				return err
+			},
+			errorParam: "createInfo.PipelineStatistics",
+		},
+		{
+			name: "zero values per query",
+			call: func() error {
+				_, err := GetQueryPoolResults(fakeDevice, fakeQueryPool, 0, 1, 0, QueryResultWaitBit)
+				return err
+			},
+			errorParam: "valuesPerQuery",
+		},
+		{
+			name: "timestamp with multiple stages",
+			call: func() error {
+				return CmdWriteTimestamp2(CommandBuffer(uintptr(0x9abc)), PipelineStage2TopOfPipe|PipelineStage2BottomOfPipe, fakeQueryPool, 0)
+			},
+			errorParam: "stage",
+		},
+		{
+			name: "timestamp without query pool",
+			call: func() error {
+				return CmdWriteTimestamp2(CommandBuffer(uintptr(0x9abc)), PipelineStage2BottomOfPipe, nil, 0)
+			},
+			errorParam: "queryPool",
+		},
+		{
+			name: "timer without timestamp period",
+			call: func() error {
+				_, err := NewGPUTimerScope(fakeQueryPool, 0, 0)
+				return err
+			},
+			errorParam: "timestampPeriod",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+}
+
+// TestTimestampDelta tests the conversion of timestamp ticks to a duration
+func TestTimestampDelta(t *testing.T) {
+	elapsed, err := timestampDelta(1000, 3500, 40)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if elapsed != 100*time.Microsecond {
+		t.Errorf("Expected %v, got %v", 100*time.Microsecond, elapsed)
+	}
+
+	if _, err := timestampDelta(3500, 1000, 40); err == nil {
+		t.Error("Expected error for end timestamp before start timestamp")
+	}
+}
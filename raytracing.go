@@ -0,0 +1,995 @@
+package vulkan
+
+/*
+#include <vulkan/vulkan.h>
+#include <stdlib.h>
+#include <string.h>
+
+// Function pointers for ray tracing KHR extension functions
+// These need to be loaded dynamically at runtime.
+//
+// IMPORTANT: These are global static pointers and NOT thread-safe during loading.
+// LoadRayTracingFunctions must be called from a single thread during initialization
+// before any concurrent ray tracing API usage.
+//
+// NOTE: Only one Vulkan device with ray tracing support is supported at a time.
+// Calling the load function multiple times will overwrite previous function pointers.
+static PFN_vkCreateAccelerationStructureKHR pfn_vkCreateAccelerationStructureKHR = NULL;
+static PFN_vkDestroyAccelerationStructureKHR pfn_vkDestroyAccelerationStructureKHR = NULL;
+static PFN_vkCmdBuildAccelerationStructuresKHR pfn_vkCmdBuildAccelerationStructuresKHR = NULL;
+static PFN_vkGetAccelerationStructureBuildSizesKHR pfn_vkGetAccelerationStructureBuildSizesKHR = NULL;
+static PFN_vkGetAccelerationStructureDeviceAddressKHR pfn_vkGetAccelerationStructureDeviceAddressKHR = NULL;
+static PFN_vkCreateRayTracingPipelinesKHR pfn_vkCreateRayTracingPipelinesKHR = NULL;
+static PFN_vkGetRayTracingShaderGroupHandlesKHR pfn_vkGetRayTracingShaderGroupHandlesKHR = NULL;
+static PFN_vkCmdTraceRaysKHR pfn_vkCmdTraceRaysKHR = NULL;
+
+static int loadRayTracingDeviceFunctions(VkDevice device) {
+    if (device == VK_NULL_HANDLE) {
+        return 0;
+    }
+    pfn_vkCreateAccelerationStructureKHR = (PFN_vkCreateAccelerationStructureKHR)
+        vkGetDeviceProcAddr(device, "vkCreateAccelerationStructureKHR");
+    pfn_vkDestroyAccelerationStructureKHR = (PFN_vkDestroyAccelerationStructureKHR)
+        vkGetDeviceProcAddr(device, "vkDestroyAccelerationStructureKHR");
+    pfn_vkCmdBuildAccelerationStructuresKHR = (PFN_vkCmdBuildAccelerationStructuresKHR)
+        vkGetDeviceProcAddr(device, "vkCmdBuildAccelerationStructuresKHR");
+    pfn_vkGetAccelerationStructureBuildSizesKHR = (PFN_vkGetAccelerationStructureBuildSizesKHR)
+        vkGetDeviceProcAddr(device, "vkGetAccelerationStructureBuildSizesKHR");
+    pfn_vkGetAccelerationStructureDeviceAddressKHR = (PFN_vkGetAccelerationStructureDeviceAddressKHR)
+        vkGetDeviceProcAddr(device, "vkGetAccelerationStructureDeviceAddressKHR");
+    pfn_vkCreateRayTracingPipelinesKHR = (PFN_vkCreateRayTracingPipelinesKHR)
+        vkGetDeviceProcAddr(device, "vkCreateRayTracingPipelinesKHR");
+    pfn_vkGetRayTracingShaderGroupHandlesKHR = (PFN_vkGetRayTracingShaderGroupHandlesKHR)
+        vkGetDeviceProcAddr(device, "vkGetRayTracingShaderGroupHandlesKHR");
+    pfn_vkCmdTraceRaysKHR = (PFN_vkCmdTraceRaysKHR)
+        vkGetDeviceProcAddr(device, "vkCmdTraceRaysKHR");
+
+    return pfn_vkCreateAccelerationStructureKHR != NULL &&
+           pfn_vkDestroyAccelerationStructureKHR != NULL &&
+           pfn_vkCmdBuildAccelerationStructuresKHR != NULL &&
+           pfn_vkGetAccelerationStructureBuildSizesKHR != NULL &&
+           pfn_vkGetAccelerationStructureDeviceAddressKHR != NULL &&
+           pfn_vkCreateRayTracingPipelinesKHR != NULL &&
+           pfn_vkGetRayTracingShaderGroupHandlesKHR != NULL &&
+           pfn_vkCmdTraceRaysKHR != NULL;
+}
+
+// Wrapper functions that use the dynamically loaded function pointers
+static VkResult call_vkCreateAccelerationStructureKHR(
+    VkDevice device,
+    const VkAccelerationStructureCreateInfoKHR* pCreateInfo,
+    VkAccelerationStructureKHR* pAccelerationStructure) {
+    if (pfn_vkCreateAccelerationStructureKHR == NULL) {
+        return VK_ERROR_EXTENSION_NOT_PRESENT;
+    }
+    return pfn_vkCreateAccelerationStructureKHR(device, pCreateInfo, NULL, pAccelerationStructure);
+}
+
+static void call_vkDestroyAccelerationStructureKHR(
+    VkDevice device,
+    VkAccelerationStructureKHR accelerationStructure) {
+    if (pfn_vkDestroyAccelerationStructureKHR != NULL) {
+        pfn_vkDestroyAccelerationStructureKHR(device, accelerationStructure, NULL);
+    }
+}
+
+// Command buffer and void wrapper functions return 1 on success, 0 if function pointer is NULL.
+static int call_vkCmdBuildAccelerationStructuresKHR(
+    VkCommandBuffer commandBuffer,
+    uint32_t infoCount,
+    const VkAccelerationStructureBuildGeometryInfoKHR* pInfos,
+    const VkAccelerationStructureBuildRangeInfoKHR* const* ppBuildRangeInfos) {
+    if (pfn_vkCmdBuildAccelerationStructuresKHR == NULL) {
+        return 0;
+    }
+    pfn_vkCmdBuildAccelerationStructuresKHR(commandBuffer, infoCount, pInfos, ppBuildRangeInfos);
+    return 1;
+}
+
+static int call_vkGetAccelerationStructureBuildSizesKHR(
+    VkDevice device,
+    VkAccelerationStructureBuildTypeKHR buildType,
+    const VkAccelerationStructureBuildGeometryInfoKHR* pBuildInfo,
+    const uint32_t* pMaxPrimitiveCounts,
+    VkAccelerationStructureBuildSizesInfoKHR* pSizeInfo) {
+    if (pfn_vkGetAccelerationStructureBuildSizesKHR == NULL) {
+        return 0;
+    }
+    pfn_vkGetAccelerationStructureBuildSizesKHR(device, buildType, pBuildInfo, pMaxPrimitiveCounts, pSizeInfo);
+    return 1;
+}
+
+static VkDeviceAddress call_vkGetAccelerationStructureDeviceAddressKHR(
+    VkDevice device,
+    VkAccelerationStructureKHR accelerationStructure) {
+    if (pfn_vkGetAccelerationStructureDeviceAddressKHR == NULL) {
+        return 0;
+    }
+    VkAccelerationStructureDeviceAddressInfoKHR info;
+    memset(&info, 0, sizeof(info));
+    info.sType = VK_STRUCTURE_TYPE_ACCELERATION_STRUCTURE_DEVICE_ADDRESS_INFO_KHR;
+    info.accelerationStructure = accelerationStructure;
+    return pfn_vkGetAccelerationStructureDeviceAddressKHR(device, &info);
+}
+
+static VkResult call_vkCreateRayTracingPipelinesKHR(
+    VkDevice device,
+    VkPipelineCache pipelineCache,
+    uint32_t createInfoCount,
+    const VkRayTracingPipelineCreateInfoKHR* pCreateInfos,
+    VkPipeline* pPipelines) {
+    if (pfn_vkCreateRayTracingPipelinesKHR == NULL) {
+        return VK_ERROR_EXTENSION_NOT_PRESENT;
+    }
+    return pfn_vkCreateRayTracingPipelinesKHR(device, VK_NULL_HANDLE, pipelineCache, createInfoCount, pCreateInfos, NULL, pPipelines);
+}
+
+static VkResult call_vkGetRayTracingShaderGroupHandlesKHR(
+    VkDevice device,
+    VkPipeline pipeline,
+    uint32_t firstGroup,
+    uint32_t groupCount,
+    size_t dataSize,
+    void* pData) {
+    if (pfn_vkGetRayTracingShaderGroupHandlesKHR == NULL) {
+        return VK_ERROR_EXTENSION_NOT_PRESENT;
+    }
+    return pfn_vkGetRayTracingShaderGroupHandlesKHR(device, pipeline, firstGroup, groupCount, dataSize, pData);
+}
+
+static int call_vkCmdTraceRaysKHR(
+    VkCommandBuffer commandBuffer,
+    const VkStridedDeviceAddressRegionKHR* pRaygen,
+    const VkStridedDeviceAddressRegionKHR* pMiss,
+    const VkStridedDeviceAddressRegionKHR* pHit,
+    const VkStridedDeviceAddressRegionKHR* pCallable,
+    uint32_t width,
+    uint32_t height,
+    uint32_t depth) {
+    if (pfn_vkCmdTraceRaysKHR == NULL) {
+        return 0;
+    }
+    pfn_vkCmdTraceRaysKHR(commandBuffer, pRaygen, pMiss, pHit, pCallable, width, height, depth);
+    return 1;
+}
+
+// cgo cannot address union members, so geometry data and scratch addresses are set in C
+static void setGeometryTriangles(
+    VkAccelerationStructureGeometryKHR* geometry,
+    VkFormat vertexFormat,
+    VkDeviceAddress vertexData,
+    VkDeviceSize vertexStride,
+    uint32_t maxVertex,
+    VkIndexType indexType,
+    VkDeviceAddress indexData,
+    VkDeviceAddress transformData) {
+    VkAccelerationStructureGeometryTrianglesDataKHR* t = &geometry->geometry.triangles;
+    t->sType = VK_STRUCTURE_TYPE_ACCELERATION_STRUCTURE_GEOMETRY_TRIANGLES_DATA_KHR;
+    t->pNext = NULL;
+    t->vertexFormat = vertexFormat;
+    t->vertexData.deviceAddress = vertexData;
+    t->vertexStride = vertexStride;
+    t->maxVertex = maxVertex;
+    t->indexType = indexType;
+    t->indexData.deviceAddress = indexData;
+    t->transformData.deviceAddress = transformData;
+}
+
+static void setGeometryAabbs(VkAccelerationStructureGeometryKHR* geometry, VkDeviceAddress data, VkDeviceSize stride) {
+    VkAccelerationStructureGeometryAabbsDataKHR* a = &geometry->geometry.aabbs;
+    a->sType = VK_STRUCTURE_TYPE_ACCELERATION_STRUCTURE_GEOMETRY_AABBS_DATA_KHR;
+    a->pNext = NULL;
+    a->data.deviceAddress = data;
+    a->stride = stride;
+}
+
+static void setGeometryInstances(VkAccelerationStructureGeometryKHR* geometry, VkBool32 arrayOfPointers, VkDeviceAddress data) {
+    VkAccelerationStructureGeometryInstancesDataKHR* in = &geometry->geometry.instances;
+    in->sType = VK_STRUCTURE_TYPE_ACCELERATION_STRUCTURE_GEOMETRY_INSTANCES_DATA_KHR;
+    in->pNext = NULL;
+    in->arrayOfPointers = arrayOfPointers;
+    in->data.deviceAddress = data;
+}
+
+static void setBuildScratchData(VkAccelerationStructureBuildGeometryInfoKHR* info, VkDeviceAddress scratch) {
+    info->scratchData.deviceAddress = scratch;
+}
+*/
+import "C"
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"unsafe"
+)
+
+// Ray tracing extension name constants
+const (
+	ExtensionNameAccelerationStructure  = "VK_KHR_acceleration_structure"
+	ExtensionNameRayTracingPipeline     = "VK_KHR_ray_tracing_pipeline"
+	ExtensionNameDeferredHostOperations = "VK_KHR_deferred_host_operations"
+)
+
+// ShaderUnused marks an unused shader slot in a ray tracing shader group
+const ShaderUnused = uint32(C.VK_SHADER_UNUSED_KHR)
+
+// Ray tracing shader stages
+const (
+	ShaderStageRaygenBitKHR       ShaderStageFlags = C.VK_SHADER_STAGE_RAYGEN_BIT_KHR
+	ShaderStageAnyHitBitKHR       ShaderStageFlags = C.VK_SHADER_STAGE_ANY_HIT_BIT_KHR
+	ShaderStageClosestHitBitKHR   ShaderStageFlags = C.VK_SHADER_STAGE_CLOSEST_HIT_BIT_KHR
+	ShaderStageMissBitKHR         ShaderStageFlags = C.VK_SHADER_STAGE_MISS_BIT_KHR
+	ShaderStageIntersectionBitKHR ShaderStageFlags = C.VK_SHADER_STAGE_INTERSECTION_BIT_KHR
+	ShaderStageCallableBitKHR     ShaderStageFlags = C.VK_SHADER_STAGE_CALLABLE_BIT_KHR
+)
+
+// PipelineBindPointRayTracingKHR binds a ray tracing pipeline
+const PipelineBindPointRayTracingKHR PipelineBindPoint = C.VK_PIPELINE_BIND_POINT_RAY_TRACING_KHR
+
+// DescriptorTypeAccelerationStructureKHR binds a top-level acceleration structure for ray queries
+// and ray tracing pipelines
+const DescriptorTypeAccelerationStructureKHR DescriptorType = C.VK_DESCRIPTOR_TYPE_ACCELERATION_STRUCTURE_KHR
+
+// Ray tracing buffer usages
+const (
+	BufferUsageAccelerationStructureBuildInputReadOnlyBitKHR BufferUsageFlags = C.VK_BUFFER_USAGE_ACCELERATION_STRUCTURE_BUILD_INPUT_READ_ONLY_BIT_KHR
+	BufferUsageAccelerationStructureStorageBitKHR            BufferUsageFlags = C.VK_BUFFER_USAGE_ACCELERATION_STRUCTURE_STORAGE_BIT_KHR
+	BufferUsageShaderBindingTableBitKHR                      BufferUsageFlags = C.VK_BUFFER_USAGE_SHADER_BINDING_TABLE_BIT_KHR
+)
+
+// Ray tracing pipeline stages
+const (
+	PipelineStageAccelerationStructureBuildBitKHR PipelineStageFlags = C.VK_PIPELINE_STAGE_ACCELERATION_STRUCTURE_BUILD_BIT_KHR
+	PipelineStageRayTracingShaderBitKHR           PipelineStageFlags = C.VK_PIPELINE_STAGE_RAY_TRACING_SHADER_BIT_KHR
+)
+
+// AccelerationStructureType represents acceleration structure types
+type AccelerationStructureType int32
+
+const (
+	AccelerationStructureTypeTopLevel    AccelerationStructureType = C.VK_ACCELERATION_STRUCTURE_TYPE_TOP_LEVEL_KHR
+	AccelerationStructureTypeBottomLevel AccelerationStructureType = C.VK_ACCELERATION_STRUCTURE_TYPE_BOTTOM_LEVEL_KHR
+	AccelerationStructureTypeGeneric     AccelerationStructureType = C.VK_ACCELERATION_STRUCTURE_TYPE_GENERIC_KHR
+)
+
+// AccelerationStructureBuildType represents where an acceleration structure is built
+type AccelerationStructureBuildType int32
+
+const (
+	AccelerationStructureBuildTypeHost         AccelerationStructureBuildType = C.VK_ACCELERATION_STRUCTURE_BUILD_TYPE_HOST_KHR
+	AccelerationStructureBuildTypeDevice       AccelerationStructureBuildType = C.VK_ACCELERATION_STRUCTURE_BUILD_TYPE_DEVICE_KHR
+	AccelerationStructureBuildTypeHostOrDevice AccelerationStructureBuildType = C.VK_ACCELERATION_STRUCTURE_BUILD_TYPE_HOST_OR_DEVICE_KHR
+)
+
+// BuildAccelerationStructureFlags represents acceleration structure build flags
+type BuildAccelerationStructureFlags uint32
+
+const (
+	BuildAccelerationStructureAllowUpdateBit     BuildAccelerationStructureFlags = C.VK_BUILD_ACCELERATION_STRUCTURE_ALLOW_UPDATE_BIT_KHR
+	BuildAccelerationStructureAllowCompactionBit BuildAccelerationStructureFlags = C.VK_BUILD_ACCELERATION_STRUCTURE_ALLOW_COMPACTION_BIT_KHR
+	BuildAccelerationStructurePreferFastTraceBit BuildAccelerationStructureFlags = C.VK_BUILD_ACCELERATION_STRUCTURE_PREFER_FAST_TRACE_BIT_KHR
+	BuildAccelerationStructurePreferFastBuildBit BuildAccelerationStructureFlags = C.VK_BUILD_ACCELERATION_STRUCTURE_PREFER_FAST_BUILD_BIT_KHR
+	BuildAccelerationStructureLowMemoryBit       BuildAccelerationStructureFlags = C.VK_BUILD_ACCELERATION_STRUCTURE_LOW_MEMORY_BIT_KHR
+)
+
+// BuildAccelerationStructureMode represents whether a build creates or updates a structure
+type BuildAccelerationStructureMode int32
+
+const (
+	BuildAccelerationStructureModeBuild  BuildAccelerationStructureMode = C.VK_BUILD_ACCELERATION_STRUCTURE_MODE_BUILD_KHR
+	BuildAccelerationStructureModeUpdate BuildAccelerationStructureMode = C.VK_BUILD_ACCELERATION_STRUCTURE_MODE_UPDATE_KHR
+)
+
+// GeometryType represents acceleration structure geometry types
+type GeometryType int32
+
+const (
+	GeometryTypeTriangles GeometryType = C.VK_GEOMETRY_TYPE_TRIANGLES_KHR
+	GeometryTypeAabbs     GeometryType = C.VK_GEOMETRY_TYPE_AABBS_KHR
+	GeometryTypeInstances GeometryType = C.VK_GEOMETRY_TYPE_INSTANCES_KHR
+)
+
+// GeometryFlags represents acceleration structure geometry flags
+type GeometryFlags uint32
+
+const (
+	GeometryOpaqueBit                      GeometryFlags = C.VK_GEOMETRY_OPAQUE_BIT_KHR
+	GeometryNoDuplicateAnyHitInvocationBit GeometryFlags = C.VK_GEOMETRY_NO_DUPLICATE_ANY_HIT_INVOCATION_BIT_KHR
+)
+
+// GeometryInstanceFlags represents top-level instance flags
+type GeometryInstanceFlags uint32
+
+const (
+	GeometryInstanceTriangleFacingCullDisableBit GeometryInstanceFlags = C.VK_GEOMETRY_INSTANCE_TRIANGLE_FACING_CULL_DISABLE_BIT_KHR
+	GeometryInstanceTriangleFlipFacingBit        GeometryInstanceFlags = C.VK_GEOMETRY_INSTANCE_TRIANGLE_FLIP_FACING_BIT_KHR
+	GeometryInstanceForceOpaqueBit               GeometryInstanceFlags = C.VK_GEOMETRY_INSTANCE_FORCE_OPAQUE_BIT_KHR
+	GeometryInstanceForceNoOpaqueBit             GeometryInstanceFlags = C.VK_GEOMETRY_INSTANCE_FORCE_NO_OPAQUE_BIT_KHR
+)
+
+// RayTracingShaderGroupType represents ray tracing shader group types
+type RayTracingShaderGroupType int32
+
+const (
+	RayTracingShaderGroupTypeGeneral            RayTracingShaderGroupType = C.VK_RAY_TRACING_SHADER_GROUP_TYPE_GENERAL_KHR
+	RayTracingShaderGroupTypeTrianglesHitGroup  RayTracingShaderGroupType = C.VK_RAY_TRACING_SHADER_GROUP_TYPE_TRIANGLES_HIT_GROUP_KHR
+	RayTracingShaderGroupTypeProceduralHitGroup RayTracingShaderGroupType = C.VK_RAY_TRACING_SHADER_GROUP_TYPE_PROCEDURAL_HIT_GROUP_KHR
+)
+
+// PhysicalDeviceRayTracingFeatures contains the features required for ray tracing
+type PhysicalDeviceRayTracingFeatures struct {
+	BufferDeviceAddress   bool
+	AccelerationStructure bool
+	RayTracingPipeline    bool
+}
+
+// PhysicalDeviceRayTracingPipelineProperties contains ray tracing pipeline limits
+type PhysicalDeviceRayTracingPipelineProperties struct {
+	ShaderGroupHandleSize              uint32
+	MaxRayRecursionDepth               uint32
+	MaxShaderGroupStride               uint32
+	ShaderGroupBaseAlignment           uint32
+	ShaderGroupHandleCaptureReplaySize uint32
+	MaxRayDispatchInvocationCount      uint32
+	ShaderGroupHandleAlignment         uint32
+	MaxRayHitAttributeSize             uint32
+}
+
+// AccelerationStructureCreateInfo contains acceleration structure creation information
+type AccelerationStructureCreateInfo struct {
+	Buffer Buffer
+	Offset DeviceSize
+	Size   DeviceSize
+	Type   AccelerationStructureType
+}
+
+// AccelerationStructureGeometryTrianglesData describes triangle geometry for a bottom-level structure
+type AccelerationStructureGeometryTrianglesData struct {
+	VertexFormat  Format
+	VertexData    DeviceAddress
+	VertexStride  DeviceSize
+	MaxVertex     uint32
+	IndexType     IndexType
+	IndexData     DeviceAddress
+	TransformData DeviceAddress
+}
+
+// AccelerationStructureGeometryAabbsData describes procedural AABB geometry
+type AccelerationStructureGeometryAabbsData struct {
+	Data   DeviceAddress
+	Stride DeviceSize
+}
+
+// AccelerationStructureGeometryInstancesData describes instances for a top-level structure
+type AccelerationStructureGeometryInstancesData struct {
+	ArrayOfPointers bool
+	Data            DeviceAddress
+}
+
+// AccelerationStructureGeometry describes one geometry of an acceleration structure.
+// Only the data field matching GeometryType is used.
+type AccelerationStructureGeometry struct {
+	GeometryType GeometryType
+	Triangles    AccelerationStructureGeometryTrianglesData
+	Aabbs        AccelerationStructureGeometryAabbsData
+	Instances    AccelerationStructureGeometryInstancesData
+	Flags        GeometryFlags
+}
+
+// AccelerationStructureBuildGeometryInfo contains acceleration structure build information
+type AccelerationStructureBuildGeometryInfo struct {
+	Type                     AccelerationStructureType
+	Flags                    BuildAccelerationStructureFlags
+	Mode                     BuildAccelerationStructureMode
+	SrcAccelerationStructure AccelerationStructure
+	DstAccelerationStructure AccelerationStructure
+	Geometries               []AccelerationStructureGeometry
+	ScratchData              DeviceAddress
+}
+
+// AccelerationStructureBuildRangeInfo describes the primitives used from one geometry
+type AccelerationStructureBuildRangeInfo struct {
+	PrimitiveCount  uint32
+	PrimitiveOffset uint32
+	FirstVertex     uint32
+	TransformOffset uint32
+}
+
+// AccelerationStructureBuildSizesInfo contains the buffer sizes required for a build
+type AccelerationStructureBuildSizesInfo struct {
+	AccelerationStructureSize DeviceSize
+	UpdateScratchSize         DeviceSize
+	BuildScratchSize          DeviceSize
+}
+
+// TransformMatrix is a row-major 3x4 affine transform
+type TransformMatrix [3][4]float32
+
+// IdentityTransformMatrix returns the identity transform
+func IdentityTransformMatrix() TransformMatrix {
+	return TransformMatrix{
+		{1, 0, 0, 0},
+		{0, 1, 0, 0},
+		{0, 0, 1, 0},
+	}
+}
+
+// AccelerationStructureInstanceSize is the size in bytes of an encoded AccelerationStructureInstance
+const AccelerationStructureInstanceSize = 64
+
+// AccelerationStructureInstance describes one instance of a bottom-level structure in a
+// top-level structure. Use Encode to write it into an instance buffer.
+type AccelerationStructureInstance struct {
+	Transform                              TransformMatrix
+	InstanceCustomIndex                    uint32 // 24 bits
+	Mask                                   uint8
+	InstanceShaderBindingTableRecordOffset uint32 // 24 bits
+	Flags                                  GeometryInstanceFlags
+	AccelerationStructureReference         DeviceAddress
+}
+
+// Encode writes the instance in the VkAccelerationStructureInstanceKHR layout.
+// dst must be at least AccelerationStructureInstanceSize bytes long.
+func (i *AccelerationStructureInstance) Encode(dst []byte) {
+	_ = dst[AccelerationStructureInstanceSize-1]
+	offset := 0
+	for row := 0; row < 3; row++ {
+		for col := 0; col < 4; col++ {
+			binary.LittleEndian.PutUint32(dst[offset:], math.Float32bits(i.Transform[row][col]))
+			offset += 4
+		}
+	}
+	binary.LittleEndian.PutUint32(dst[48:], i.InstanceCustomIndex&0xFFFFFF|uint32(i.Mask)<<24)
+	binary.LittleEndian.PutUint32(dst[52:], i.InstanceShaderBindingTableRecordOffset&0xFFFFFF|uint32(i.Flags&0xFF)<<24)
+	binary.LittleEndian.PutUint64(dst[56:], uint64(i.AccelerationStructureReference))
+}
+
+// RayTracingShaderGroupCreateInfo describes a ray tracing shader group. Unused
+// shader slots must be set to ShaderUnused.
+type RayTracingShaderGroupCreateInfo struct {
+	Type               RayTracingShaderGroupType
+	GeneralShader      uint32
+	ClosestHitShader   uint32
+	AnyHitShader       uint32
+	IntersectionShader uint32
+}
+
+// RayTracingPipelineCreateInfo contains ray tracing pipeline creation information
+type RayTracingPipelineCreateInfo struct {
+	// Flags may include PipelineCreateLibraryBit to build a library for linking into other
+	// ray tracing pipelines
+	Flags                        PipelineCreateFlags
+	Stages                       []PipelineShaderStageCreateInfo
+	Groups                       []RayTracingShaderGroupCreateInfo
+	MaxPipelineRayRecursionDepth uint32
+	Layout                       PipelineLayout
+	// Libraries are pipeline libraries linked into this pipeline. Their shader groups follow
+	// Groups, in order. Requires the VK_KHR_pipeline_library extension.
+	Libraries []Pipeline
+	// LibraryInterface is required when building or linking libraries
+	LibraryInterface *RayTracingPipelineInterfaceCreateInfo
+}
+
+// RayTracingPipelineInterfaceCreateInfo declares the shader interface limits that must match
+// between a ray tracing pipeline and the libraries it links
+type RayTracingPipelineInterfaceCreateInfo struct {
+	MaxPipelineRayPayloadSize      uint32
+	MaxPipelineRayHitAttributeSize uint32
+}
+
+// StridedDeviceAddressRegion describes a region of a shader binding table
+type StridedDeviceAddressRegion struct {
+	DeviceAddress DeviceAddress
+	Stride        DeviceSize
+	Size          DeviceSize
+}
+
+// LoadRayTracingFunctions loads the acceleration structure and ray tracing pipeline
+// extension functions for a device.
+//
+// This function MUST be called after creating a logical device with the
+// VK_KHR_acceleration_structure and VK_KHR_ray_tracing_pipeline extensions enabled
+// and before using any ray tracing functionality.
+//
+// IMPORTANT: This function is NOT thread-safe. Only one device is supported at a time;
+// calling this function again will overwrite previously loaded function pointers.
+//
+// Returns false if any ray tracing function could not be loaded.
+func LoadRayTracingFunctions(device Device) bool {
+	return C.loadRayTracingDeviceFunctions(C.VkDevice(device)) != 0
+}
+
+// GetPhysicalDeviceRayTracingFeatures queries ray tracing feature support
+func GetPhysicalDeviceRayTracingFeatures(physicalDevice PhysicalDevice) PhysicalDeviceRayTracingFeatures {
+	// The chain must live in C memory since it links structs by pointer
+	cFeatures2 := (*C.VkPhysicalDeviceFeatures2)(C.calloc(1, C.sizeof_VkPhysicalDeviceFeatures2))
+	cRayTracing := (*C.VkPhysicalDeviceRayTracingPipelineFeaturesKHR)(C.calloc(1, C.sizeof_VkPhysicalDeviceRayTracingPipelineFeaturesKHR))
+	cAccel := (*C.VkPhysicalDeviceAccelerationStructureFeaturesKHR)(C.calloc(1, C.sizeof_VkPhysicalDeviceAccelerationStructureFeaturesKHR))
+	cAddress := (*C.VkPhysicalDeviceBufferDeviceAddressFeatures)(C.calloc(1, C.sizeof_VkPhysicalDeviceBufferDeviceAddressFeatures))
+	defer C.free(unsafe.Pointer(cFeatures2))
+	defer C.free(unsafe.Pointer(cRayTracing))
+	defer C.free(unsafe.Pointer(cAccel))
+	defer C.free(unsafe.Pointer(cAddress))
+	if cFeatures2 == nil || cRayTracing == nil || cAccel == nil || cAddress == nil {
+		return PhysicalDeviceRayTracingFeatures{}
+	}
+
+	cFeatures2.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_FEATURES_2
+	cFeatures2.pNext = unsafe.Pointer(cRayTracing)
+	cRayTracing.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_RAY_TRACING_PIPELINE_FEATURES_KHR
+	cRayTracing.pNext = unsafe.Pointer(cAccel)
+	cAccel.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_ACCELERATION_STRUCTURE_FEATURES_KHR
+	cAccel.pNext = unsafe.Pointer(cAddress)
+	cAddress.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_BUFFER_DEVICE_ADDRESS_FEATURES
+
+	C.vkGetPhysicalDeviceFeatures2(C.VkPhysicalDevice(physicalDevice), cFeatures2)
+
+	return PhysicalDeviceRayTracingFeatures{
+		BufferDeviceAddress:   vkBool32ToBool(cAddress.bufferDeviceAddress),
+		AccelerationStructure: vkBool32ToBool(cAccel.accelerationStructure),
+		RayTracingPipeline:    vkBool32ToBool(cRayTracing.rayTracingPipeline),
+	}
+}
+
+// rayTracingFeaturesToC prepends structs enabling the requested ray tracing features to the
+// pNext chain next. The structs are allocated in C memory and appended to allocations, which
+// the caller must free.
+func rayTracingFeaturesToC(features *PhysicalDeviceRayTracingFeatures, next unsafe.Pointer, allocations *[]unsafe.Pointer) (unsafe.Pointer, error) {
+	cRayTracing := (*C.VkPhysicalDeviceRayTracingPipelineFeaturesKHR)(C.calloc(1, C.sizeof_VkPhysicalDeviceRayTracingPipelineFeaturesKHR))
+	cAccel := (*C.VkPhysicalDeviceAccelerationStructureFeaturesKHR)(C.calloc(1, C.sizeof_VkPhysicalDeviceAccelerationStructureFeaturesKHR))
+	cAddress := (*C.VkPhysicalDeviceBufferDeviceAddressFeatures)(C.calloc(1, C.sizeof_VkPhysicalDeviceBufferDeviceAddressFeatures))
+	*allocations = append(*allocations, unsafe.Pointer(cRayTracing), unsafe.Pointer(cAccel), unsafe.Pointer(cAddress))
+	if cRayTracing == nil || cAccel == nil || cAddress == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "CreateDevice", "failed to allocate memory for ray tracing features")
+	}
+
+	cRayTracing.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_RAY_TRACING_PIPELINE_FEATURES_KHR
+	cRayTracing.pNext = unsafe.Pointer(cAccel)
+	cRayTracing.rayTracingPipeline = boolToVkBool32(features.RayTracingPipeline)
+	cAccel.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_ACCELERATION_STRUCTURE_FEATURES_KHR
+	cAccel.pNext = unsafe.Pointer(cAddress)
+	cAccel.accelerationStructure = boolToVkBool32(features.AccelerationStructure)
+	cAddress.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_BUFFER_DEVICE_ADDRESS_FEATURES
+	cAddress.pNext = next
+	cAddress.bufferDeviceAddress = boolToVkBool32(features.BufferDeviceAddress)
+
+	return unsafe.Pointer(cRayTracing), nil
+}
+
+// GetPhysicalDeviceRayTracingPipelineProperties queries ray tracing pipeline limits,
+// which are required to lay out a shader binding table
+func GetPhysicalDeviceRayTracingPipelineProperties(physicalDevice PhysicalDevice) PhysicalDeviceRayTracingPipelineProperties {
+	cProps2 := (*C.VkPhysicalDeviceProperties2)(C.calloc(1, C.sizeof_VkPhysicalDeviceProperties2))
+	cRayTracing := (*C.VkPhysicalDeviceRayTracingPipelinePropertiesKHR)(C.calloc(1, C.sizeof_VkPhysicalDeviceRayTracingPipelinePropertiesKHR))
+	defer C.free(unsafe.Pointer(cProps2))
+	defer C.free(unsafe.Pointer(cRayTracing))
+	if cProps2 == nil || cRayTracing == nil {
+		return PhysicalDeviceRayTracingPipelineProperties{}
+	}
+
+	cRayTracing.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_RAY_TRACING_PIPELINE_PROPERTIES_KHR
+	cProps2.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_PROPERTIES_2
+	cProps2.pNext = unsafe.Pointer(cRayTracing)
+
+	C.vkGetPhysicalDeviceProperties2(C.VkPhysicalDevice(physicalDevice), cProps2)
+
+	return PhysicalDeviceRayTracingPipelineProperties{
+		ShaderGroupHandleSize:              uint32(cRayTracing.shaderGroupHandleSize),
+		MaxRayRecursionDepth:               uint32(cRayTracing.maxRayRecursionDepth),
+		MaxShaderGroupStride:               uint32(cRayTracing.maxShaderGroupStride),
+		ShaderGroupBaseAlignment:           uint32(cRayTracing.shaderGroupBaseAlignment),
+		ShaderGroupHandleCaptureReplaySize: uint32(cRayTracing.shaderGroupHandleCaptureReplaySize),
+		MaxRayDispatchInvocationCount:      uint32(cRayTracing.maxRayDispatchInvocationCount),
+		ShaderGroupHandleAlignment:         uint32(cRayTracing.shaderGroupHandleAlignment),
+		MaxRayHitAttributeSize:             uint32(cRayTracing.maxRayHitAttributeSize),
+	}
+}
+
+// CreateAccelerationStructureKHR creates an acceleration structure backed by a buffer
+// created with BufferUsageAccelerationStructureStorageBitKHR
+func CreateAccelerationStructureKHR(device Device, createInfo *AccelerationStructureCreateInfo) (AccelerationStructure, error) {
+	if device == nil {
+		return nil, NewValidationError("device", "cannot be nil")
+	}
+	if createInfo == nil {
+		return nil, NewValidationError("createInfo", "cannot be nil")
+	}
+	if createInfo.Buffer == nil {
+		return nil, NewValidationError("createInfo.Buffer", "cannot be nil")
+	}
+	if createInfo.Offset%256 != 0 {
+		return nil, NewValidationError("createInfo.Offset", "must be a multiple of 256")
+	}
+
+	var cCreateInfo C.VkAccelerationStructureCreateInfoKHR
+	cCreateInfo.sType = C.VK_STRUCTURE_TYPE_ACCELERATION_STRUCTURE_CREATE_INFO_KHR
+	cCreateInfo.pNext = nil
+	cCreateInfo.createFlags = 0
+	cCreateInfo.buffer = C.VkBuffer(createInfo.Buffer)
+	cCreateInfo.offset = C.VkDeviceSize(createInfo.Offset)
+	cCreateInfo.size = C.VkDeviceSize(createInfo.Size)
+	cCreateInfo._type = C.VkAccelerationStructureTypeKHR(createInfo.Type)
+	cCreateInfo.deviceAddress = 0
+
+	var accelerationStructure C.VkAccelerationStructureKHR
+	result := Result(C.call_vkCreateAccelerationStructureKHR(C.VkDevice(device), &cCreateInfo, &accelerationStructure))
+	traceResult("vkCreateAccelerationStructureKHR", result)
+	if result != Success {
+		return nil, NewVulkanError(result, "CreateAccelerationStructureKHR", "failed to create acceleration structure")
+	}
+
+	return AccelerationStructure(accelerationStructure), nil
+}
+
+// DestroyAccelerationStructureKHR destroys an acceleration structure
+func DestroyAccelerationStructureKHR(device Device, accelerationStructure AccelerationStructure) {
+	if device == nil || accelerationStructure == nil {
+		return
+	}
+	C.call_vkDestroyAccelerationStructureKHR(C.VkDevice(device), C.VkAccelerationStructureKHR(accelerationStructure))
+}
+
+// GetAccelerationStructureDeviceAddressKHR returns the device address of an acceleration
+// structure, used as AccelerationStructureInstance.AccelerationStructureReference.
+// Returns 0 if LoadRayTracingFunctions was not called.
+func GetAccelerationStructureDeviceAddressKHR(device Device, accelerationStructure AccelerationStructure) DeviceAddress {
+	return DeviceAddress(C.call_vkGetAccelerationStructureDeviceAddressKHR(C.VkDevice(device), C.VkAccelerationStructureKHR(accelerationStructure)))
+}
+
+// buildGeometryInfoToC fills a C build geometry info. The geometry array is allocated in
+// C memory and appended to allocations, which the caller must free.
+func buildGeometryInfoToC(info *AccelerationStructureBuildGeometryInfo, cInfo *C.VkAccelerationStructureBuildGeometryInfoKHR, allocations *[]unsafe.Pointer) error {
+	cInfo.sType = C.VK_STRUCTURE_TYPE_ACCELERATION_STRUCTURE_BUILD_GEOMETRY_INFO_KHR
+	cInfo.pNext = nil
+	cInfo._type = C.VkAccelerationStructureTypeKHR(info.Type)
+	cInfo.flags = C.VkBuildAccelerationStructureFlagsKHR(info.Flags)
+	cInfo.mode = C.VkBuildAccelerationStructureModeKHR(info.Mode)
+	cInfo.srcAccelerationStructure = C.VkAccelerationStructureKHR(info.SrcAccelerationStructure)
+	cInfo.dstAccelerationStructure = C.VkAccelerationStructureKHR(info.DstAccelerationStructure)
+	C.setBuildScratchData(cInfo, C.VkDeviceAddress(info.ScratchData))
+
+	if len(info.Geometries) == 0 {
+		return nil
+	}
+
+	cGeometries := (*C.VkAccelerationStructureGeometryKHR)(C.calloc(C.size_t(len(info.Geometries)), C.sizeof_VkAccelerationStructureGeometryKHR))
+	if cGeometries == nil {
+		return NewVulkanError(ErrorOutOfHostMemory, "BuildAccelerationStructure", "failed to allocate memory for geometries")
+	}
+	*allocations = append(*allocations, unsafe.Pointer(cGeometries))
+
+	geometries := unsafe.Slice(cGeometries, len(info.Geometries))
+	for i, geometry := range info.Geometries {
+		cGeometry := &geometries[i]
+		cGeometry.sType = C.VK_STRUCTURE_TYPE_ACCELERATION_STRUCTURE_GEOMETRY_KHR
+		cGeometry.pNext = nil
+		cGeometry.geometryType = C.VkGeometryTypeKHR(geometry.GeometryType)
+		cGeometry.flags = C.VkGeometryFlagsKHR(geometry.Flags)
+
+		switch geometry.GeometryType {
+		case GeometryTypeTriangles:
+			t := geometry.Triangles
+			C.setGeometryTriangles(cGeometry,
+				C.VkFormat(t.VertexFormat),
+				C.VkDeviceAddress(t.VertexData),
+				C.VkDeviceSize(t.VertexStride),
+				C.uint32_t(t.MaxVertex),
+				C.VkIndexType(t.IndexType),
+				C.VkDeviceAddress(t.IndexData),
+				C.VkDeviceAddress(t.TransformData))
+		case GeometryTypeAabbs:
+			C.setGeometryAabbs(cGeometry, C.VkDeviceAddress(geometry.Aabbs.Data), C.VkDeviceSize(geometry.Aabbs.Stride))
+		case GeometryTypeInstances:
+			C.setGeometryInstances(cGeometry, boolToVkBool32(geometry.Instances.ArrayOfPointers), C.VkDeviceAddress(geometry.Instances.Data))
+		default:
+			return NewValidationError("Geometries", "unsupported geometry type")
+		}
+	}
+
+	cInfo.geometryCount = C.uint32_t(len(info.Geometries))
+	cInfo.pGeometries = cGeometries
+	cInfo.ppGeometries = nil
+	return nil
+}
+
+// freeAllocations frees C memory collected while marshaling
+func freeAllocations(allocations []unsafe.Pointer) {
+	for _, ptr := range allocations {
+		C.free(ptr)
+	}
+}
+
+// GetAccelerationStructureBuildSizesKHR returns the acceleration structure and scratch
+// buffer sizes required to build buildInfo. maxPrimitiveCounts holds one entry per geometry.
+func GetAccelerationStructureBuildSizesKHR(device Device, buildType AccelerationStructureBuildType, buildInfo *AccelerationStructureBuildGeometryInfo, maxPrimitiveCounts []uint32) (AccelerationStructureBuildSizesInfo, error) {
+	if device == nil {
+		return AccelerationStructureBuildSizesInfo{}, NewValidationError("device", "cannot be nil")
+	}
+	if buildInfo == nil {
+		return AccelerationStructureBuildSizesInfo{}, NewValidationError("buildInfo", "cannot be nil")
+	}
+	if len(maxPrimitiveCounts) != len(buildInfo.Geometries) {
+		return AccelerationStructureBuildSizesInfo{}, NewValidationError("maxPrimitiveCounts", "must have one entry per geometry")
+	}
+
+	var allocations []unsafe.Pointer
+	defer func() { freeAllocations(allocations) }()
+
+	var cBuildInfo C.VkAccelerationStructureBuildGeometryInfoKHR
+	if err := buildGeometryInfoToC(buildInfo, &cBuildInfo, &allocations); err != nil {
+		return AccelerationStructureBuildSizesInfo{}, err
+	}
+
+	var cCounts *C.uint32_t
+	if len(maxPrimitiveCounts) > 0 {
+		cCounts = (*C.uint32_t)(unsafe.Pointer(&maxPrimitiveCounts[0]))
+	}
+
+	var cSizes C.VkAccelerationStructureBuildSizesInfoKHR
+	cSizes.sType = C.VK_STRUCTURE_TYPE_ACCELERATION_STRUCTURE_BUILD_SIZES_INFO_KHR
+
+	if C.call_vkGetAccelerationStructureBuildSizesKHR(C.VkDevice(device), C.VkAccelerationStructureBuildTypeKHR(buildType), &cBuildInfo, cCounts, &cSizes) == 0 {
+		return AccelerationStructureBuildSizesInfo{}, NewVulkanError(ErrorExtensionNotPresent, "GetAccelerationStructureBuildSizesKHR", "ray tracing extension not loaded - call LoadRayTracingFunctions first")
+	}
+
+	return AccelerationStructureBuildSizesInfo{
+		AccelerationStructureSize: DeviceSize(cSizes.accelerationStructureSize),
+		UpdateScratchSize:         DeviceSize(cSizes.updateScratchSize),
+		BuildScratchSize:          DeviceSize(cSizes.buildScratchSize),
+	}, nil
+}
+
+// CmdBuildAccelerationStructuresKHR records acceleration structure builds. buildRangeInfos
+// holds, for each build info, one range per geometry.
+func CmdBuildAccelerationStructuresKHR(commandBuffer CommandBuffer, buildInfos []AccelerationStructureBuildGeometryInfo, buildRangeInfos [][]AccelerationStructureBuildRangeInfo) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+	if len(buildInfos) != len(buildRangeInfos) {
+		return NewValidationError("buildRangeInfos", "must have one entry per build info")
+	}
+	if len(buildInfos) == 0 {
+		return nil
+	}
+
+	var allocations []unsafe.Pointer
+	defer func() { freeAllocations(allocations) }()
+
+	cInfosPtr := (*C.VkAccelerationStructureBuildGeometryInfoKHR)(C.calloc(C.size_t(len(buildInfos)), C.sizeof_VkAccelerationStructureBuildGeometryInfoKHR))
+	if cInfosPtr == nil {
+		return NewVulkanError(ErrorOutOfHostMemory, "CmdBuildAccelerationStructuresKHR", "failed to allocate memory for build infos")
+	}
+	allocations = append(allocations, unsafe.Pointer(cInfosPtr))
+
+	cRangePtrs := (**C.VkAccelerationStructureBuildRangeInfoKHR)(C.calloc(C.size_t(len(buildInfos)), C.size_t(unsafe.Sizeof(uintptr(0)))))
+	if cRangePtrs == nil {
+		return NewVulkanError(ErrorOutOfHostMemory, "CmdBuildAccelerationStructuresKHR", "failed to allocate memory for build range pointers")
+	}
+	allocations = append(allocations, unsafe.Pointer(cRangePtrs))
+
+	cInfos := unsafe.Slice(cInfosPtr, len(buildInfos))
+	rangePtrs := unsafe.Slice(cRangePtrs, len(buildInfos))
+	for i := range buildInfos {
+		if len(buildRangeInfos[i]) != len(buildInfos[i].Geometries) {
+			return NewValidationError("buildRangeInfos", "must have one range per geometry")
+		}
+		if err := buildGeometryInfoToC(&buildInfos[i], &cInfos[i], &allocations); err != nil {
+			return err
+		}
+		if len(buildRangeInfos[i]) == 0 {
+			continue
+		}
+
+		cRanges := (*C.VkAccelerationStructureBuildRangeInfoKHR)(C.calloc(C.size_t(len(buildRangeInfos[i])), C.sizeof_VkAccelerationStructureBuildRangeInfoKHR))
+		if cRanges == nil {
+			return NewVulkanError(ErrorOutOfHostMemory, "CmdBuildAccelerationStructuresKHR", "failed to allocate memory for build ranges")
+		}
+		allocations = append(allocations, unsafe.Pointer(cRanges))
+
+		ranges := unsafe.Slice(cRanges, len(buildRangeInfos[i]))
+		for j, r := range buildRangeInfos[i] {
+			ranges[j].primitiveCount = C.uint32_t(r.PrimitiveCount)
+			ranges[j].primitiveOffset = C.uint32_t(r.PrimitiveOffset)
+			ranges[j].firstVertex = C.uint32_t(r.FirstVertex)
+			ranges[j].transformOffset = C.uint32_t(r.TransformOffset)
+		}
+		rangePtrs[i] = cRanges
+	}
+
+	if C.call_vkCmdBuildAccelerationStructuresKHR(C.VkCommandBuffer(commandBuffer), C.uint32_t(len(buildInfos)), cInfosPtr, cRangePtrs) == 0 {
+		return NewVulkanError(ErrorExtensionNotPresent, "CmdBuildAccelerationStructuresKHR", "ray tracing extension not loaded - call LoadRayTracingFunctions first")
+	}
+	return nil
+}
+
+// CreateRayTracingPipelinesKHR creates ray tracing pipelines
+func CreateRayTracingPipelinesKHR(device Device, pipelineCache PipelineCache, createInfos []RayTracingPipelineCreateInfo) ([]Pipeline, error) {
+	if device == nil {
+		return nil, NewValidationError("device", "cannot be nil")
+	}
+	if len(createInfos) == 0 {
+		return nil, nil
+	}
+
+	var allocations []unsafe.Pointer
+	defer func() { freeAllocations(allocations) }()
+
+	cCreateInfosPtr := (*C.VkRayTracingPipelineCreateInfoKHR)(C.calloc(C.size_t(len(createInfos)), C.sizeof_VkRayTracingPipelineCreateInfoKHR))
+	if cCreateInfosPtr == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "CreateRayTracingPipelinesKHR", "failed to allocate memory for create infos")
+	}
+	allocations = append(allocations, unsafe.Pointer(cCreateInfosPtr))
+	cCreateInfos := unsafe.Slice(cCreateInfosPtr, len(createInfos))
+
+	for i, info := range createInfos {
+		// A pipeline that links libraries may take all of its stages and groups from them
+		if len(info.Libraries) == 0 {
+			if len(info.Stages) == 0 {
+				return nil, NewValidationError("Stages", "ray tracing pipeline must have at least one shader stage")
+			}
+			if len(info.Groups) == 0 {
+				return nil, NewValidationError("Groups", "ray tracing pipeline must have at least one shader group")
+			}
+		}
+		usesLibraries := info.Flags&PipelineCreateLibraryBit != 0 || len(info.Libraries) > 0
+		if usesLibraries && info.LibraryInterface == nil {
+			return nil, NewValidationError("LibraryInterface", "required when building or linking pipeline libraries")
+		}
+		for _, library := range info.Libraries {
+			if library == nil {
+				return nil, NewValidationError("Libraries", "cannot contain nil pipelines")
+			}
+		}
+		for j, stage := range info.Stages {
+			if stage.SpecializationInfo != nil {
+				if err := validateSpecializationInfo(fmt.Sprintf("Stages[%d].SpecializationInfo", j), stage.SpecializationInfo); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		cStagesPtr := (*C.VkPipelineShaderStageCreateInfo)(C.calloc(C.size_t(len(info.Stages)), C.sizeof_VkPipelineShaderStageCreateInfo))
+		if cStagesPtr == nil {
+			return nil, NewVulkanError(ErrorOutOfHostMemory, "CreateRayTracingPipelinesKHR", "failed to allocate memory for shader stages")
+		}
+		allocations = append(allocations, unsafe.Pointer(cStagesPtr))
+
+		cStages := unsafe.Slice(cStagesPtr, len(info.Stages))
+		for j, stage := range info.Stages {
+			cName := C.CString(stage.Name)
+			allocations = append(allocations, unsafe.Pointer(cName))
+
+			cStages[j].sType = C.VK_STRUCTURE_TYPE_PIPELINE_SHADER_STAGE_CREATE_INFO
+			cStages[j].stage = C.VkShaderStageFlagBits(stage.Stage)
+			cStages[j].module = C.VkShaderModule(stage.Module)
+			cStages[j].pName = cName
+
+			cSpecialization, err := specializationInfoToC("CreateRayTracingPipelinesKHR", stage.SpecializationInfo, &allocations)
+			if err != nil {
+				return nil, err
+			}
+			cStages[j].pSpecializationInfo = cSpecialization
+		}
+
+		cGroupsPtr := (*C.VkRayTracingShaderGroupCreateInfoKHR)(C.calloc(C.size_t(len(info.Groups)), C.sizeof_VkRayTracingShaderGroupCreateInfoKHR))
+		if cGroupsPtr == nil {
+			return nil, NewVulkanError(ErrorOutOfHostMemory, "CreateRayTracingPipelinesKHR", "failed to allocate memory for shader groups")
+		}
+		allocations = append(allocations, unsafe.Pointer(cGroupsPtr))
+
+		cGroups := unsafe.Slice(cGroupsPtr, len(info.Groups))
+		for j, group := range info.Groups {
+			cGroups[j].sType = C.VK_STRUCTURE_TYPE_RAY_TRACING_SHADER_GROUP_CREATE_INFO_KHR
+			cGroups[j]._type = C.VkRayTracingShaderGroupTypeKHR(group.Type)
+			cGroups[j].generalShader = C.uint32_t(group.GeneralShader)
+			cGroups[j].closestHitShader = C.uint32_t(group.ClosestHitShader)
+			cGroups[j].anyHitShader = C.uint32_t(group.AnyHitShader)
+			cGroups[j].intersectionShader = C.uint32_t(group.IntersectionShader)
+		}
+
+		cLibraryInfo, err := pipelineLibraryInfoToC("CreateRayTracingPipelinesKHR", info.Libraries, &allocations)
+		if err != nil {
+			return nil, err
+		}
+		if info.LibraryInterface != nil {
+			cInterface := (*C.VkRayTracingPipelineInterfaceCreateInfoKHR)(C.calloc(1, C.sizeof_VkRayTracingPipelineInterfaceCreateInfoKHR))
+			if cInterface == nil {
+				return nil, NewVulkanError(ErrorOutOfHostMemory, "CreateRayTracingPipelinesKHR", "failed to allocate memory for library interface")
+			}
+			allocations = append(allocations, unsafe.Pointer(cInterface))
+
+			cInterface.sType = C.VK_STRUCTURE_TYPE_RAY_TRACING_PIPELINE_INTERFACE_CREATE_INFO_KHR
+			cInterface.maxPipelineRayPayloadSize = C.uint32_t(info.LibraryInterface.MaxPipelineRayPayloadSize)
+			cInterface.maxPipelineRayHitAttributeSize = C.uint32_t(info.LibraryInterface.MaxPipelineRayHitAttributeSize)
+			cCreateInfos[i].pLibraryInterface = cInterface
+		}
+
+		cCreateInfos[i].sType = C.VK_STRUCTURE_TYPE_RAY_TRACING_PIPELINE_CREATE_INFO_KHR
+		cCreateInfos[i].flags = C.VkPipelineCreateFlags(info.Flags)
+		cCreateInfos[i].pLibraryInfo = cLibraryInfo
+		cCreateInfos[i].stageCount = C.uint32_t(len(info.Stages))
+		cCreateInfos[i].pStages = cStagesPtr
+		cCreateInfos[i].groupCount = C.uint32_t(len(info.Groups))
+		cCreateInfos[i].pGroups = cGroupsPtr
+		cCreateInfos[i].maxPipelineRayRecursionDepth = C.uint32_t(info.MaxPipelineRayRecursionDepth)
+		cCreateInfos[i].layout = C.VkPipelineLayout(info.Layout)
+		cCreateInfos[i].basePipelineHandle = C.VkPipeline(nil)
+		cCreateInfos[i].basePipelineIndex = -1
+	}
+
+	cPipelines := make([]C.VkPipeline, len(createInfos))
+	result := Result(C.call_vkCreateRayTracingPipelinesKHR(
+		C.VkDevice(device),
+		C.VkPipelineCache(pipelineCache),
+		C.uint32_t(len(createInfos)),
+		cCreateInfosPtr,
+		&cPipelines[0],
+	))
+	traceResult("vkCreateRayTracingPipelinesKHR", result)
+	if result != Success {
+		return nil, NewVulkanError(result, "CreateRayTracingPipelinesKHR", "failed to create ray tracing pipelines")
+	}
+
+	pipelines := make([]Pipeline, len(cPipelines))
+	for i, pipeline := range cPipelines {
+		pipelines[i] = Pipeline(pipeline)
+	}
+	return pipelines, nil
+}
+
+// GetRayTracingShaderGroupHandlesKHR returns the opaque shader group handles of a ray
+// tracing pipeline for building a shader binding table. dataSize must be at least
+// groupCount * ShaderGroupHandleSize.
+func GetRayTracingShaderGroupHandlesKHR(device Device, pipeline Pipeline, firstGroup, groupCount uint32, dataSize int) ([]byte, error) {
+	if device == nil {
+		return nil, NewValidationError("device", "cannot be nil")
+	}
+	if pipeline == nil {
+		return nil, NewValidationError("pipeline", "cannot be nil")
+	}
+	if dataSize <= 0 {
+		return nil, NewValidationError("dataSize", "must be greater than zero")
+	}
+
+	data := make([]byte, dataSize)
+	result := Result(C.call_vkGetRayTracingShaderGroupHandlesKHR(
+		C.VkDevice(device),
+		C.VkPipeline(pipeline),
+		C.uint32_t(firstGroup),
+		C.uint32_t(groupCount),
+		C.size_t(dataSize),
+		unsafe.Pointer(&data[0]),
+	))
+	traceResult("vkGetRayTracingShaderGroupHandlesKHR", result)
+	if result != Success {
+		return nil, NewVulkanError(result, "GetRayTracingShaderGroupHandlesKHR", "failed to get shader group handles")
+	}
+	return data, nil
+}
+
+func stridedDeviceAddressRegionToC(region StridedDeviceAddressRegion) C.VkStridedDeviceAddressRegionKHR {
+	return C.VkStridedDeviceAddressRegionKHR{
+		deviceAddress: C.VkDeviceAddress(region.DeviceAddress),
+		stride:        C.VkDeviceSize(region.Stride),
+		size:          C.VkDeviceSize(region.Size),
+	}
+}
+
+// CmdTraceRaysKHR records a ray tracing dispatch using the given shader binding table regions.
+// Returns an error if LoadRayTracingFunctions was not called.
+func CmdTraceRaysKHR(commandBuffer CommandBuffer, raygen, miss, hit, callable StridedDeviceAddressRegion, width, height, depth uint32) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+
+	cRaygen := stridedDeviceAddressRegionToC(raygen)
+	cMiss := stridedDeviceAddressRegionToC(miss)
+	cHit := stridedDeviceAddressRegionToC(hit)
+	cCallable := stridedDeviceAddressRegionToC(callable)
+
+	if C.call_vkCmdTraceRaysKHR(C.VkCommandBuffer(commandBuffer), &cRaygen, &cMiss, &cHit, &cCallable, C.uint32_t(width), C.uint32_t(height), C.uint32_t(depth)) == 0 {
+		return NewVulkanError(ErrorExtensionNotPresent, "CmdTraceRaysKHR", "ray tracing extension not loaded - call LoadRayTracingFunctions first")
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+//go:build cgo
+
+package vulkan
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"testing"
+)
+
+// TestAccelerationStructureInstanceEncode tests the packed instance layout
+func TestAccelerationStructureInstanceEncode(t *testing.T) {
+	instance := AccelerationStructureInstance{
+		Transform:                              IdentityTransformMatrix(),
+		InstanceCustomIndex:                    0x123456,
+		Mask:                                   0xFF,
+		InstanceShaderBindingTableRecordOffset: 0x000002,
+		Flags:                                  GeometryInstanceTriangleFacingCullDisableBit,
+		AccelerationStructureReference:         0xDEADBEEF00,
+	}
+
+	buf := make([]byte, AccelerationStructureInstanceSize)
+	instance.Encode(buf)
+
+	// Diagonal of the identity transform
+	for _, offset := range []int{0, 20, 40} {
+		if got := math.Float32frombits(binary.LittleEndian.Uint32(buf[offset:])); got != 1 {
+			t.Errorf("Expected transform value 1 at offset %d, got %v", offset, got)
+		}
+	}
+
+	if got := binary.LittleEndian.Uint32(buf[48:]); got != 0xFF123456 {
+		t.Errorf("Expected custom index and mask 0xFF123456, got 0x%08X", got)
+	}
+
+	expectedOffsetAndFlags := uint32(0x000002) | uint32(GeometryInstanceTriangleFacingCullDisableBit)<<24
+	if got := binary.LittleEndian.Uint32(buf[52:]); got != expectedOffsetAndFlags {
+		t.Errorf("Expected SBT offset and flags 0x%08X, got 0x%08X", expectedOffsetAndFlags, got)
+	}
+
+	if got := binary.LittleEndian.Uint64(buf[56:]); got != 0xDEADBEEF00 {
+		t.Errorf("Expected reference 0xDEADBEEF00, got 0x%X", got)
+	}
+}
+
+// TestCreateAccelerationStructureValidation tests input validation for CreateAccelerationStructureKHR
+func TestCreateAccelerationStructureValidation(t *testing.T) {
+	fakeDevice := Device(uintptr(0x1234))
+	fakeBuffer := Buffer(uintptr(0x5678))
+
+	tests := []struct {
+		name       string
+		device     Device
+		createInfo *AccelerationStructureCreateInfo
+		errorParam string
+	}{
+		{
+			name:       "nil device",
+			device:     nil,
+			createInfo: &AccelerationStructureCreateInfo{Buffer: fakeBuffer},
+			errorParam: "device",
+		},
+		{
+			name:       "nil createInfo",
+			device:     fakeDevice,
+			createInfo: nil,
+			errorParam: "createInfo",
+		},
+		{
+			name:       "nil buffer",
+			device:     fakeDevice,
+			createInfo: &AccelerationStructureCreateInfo{},
+			errorParam: "createInfo.Buffer",
+		},
+		{
+			name:       "unaligned offset",
+			device:     fakeDevice,
+			createInfo: &AccelerationStructureCreateInfo{Buffer: fakeBuffer, Offset: 128},
+			errorParam: "createInfo.Offset",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CreateAccelerationStructureKHR(tt.device, tt.createInfo)
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+}
+
+// TestCmdBuildAccelerationStructuresValidation tests that build ranges must match the geometries
+func TestCmdBuildAccelerationStructuresValidation(t *testing.T) {
+	commandBuffer := CommandBuffer(uintptr(0x1234))
+	buildInfos := []AccelerationStructureBuildGeometryInfo{{
+		Type:       AccelerationStructureTypeBottomLevel,
+		Geometries: []AccelerationStructureGeometry{{GeometryType: GeometryTypeTriangles}},
+	}}
+
+	err := CmdBuildAccelerationStructuresKHR(commandBuffer, buildInfos, nil)
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Parameter != "buildRangeInfos" {
+		t.Errorf("Expected ValidationError for buildRangeInfos, got %v", err)
+	}
+
+	err = CmdBuildAccelerationStructuresKHR(commandBuffer, buildInfos, [][]AccelerationStructureBuildRangeInfo{{}})
+	if !errors.As(err, &validationErr) || validationErr.Parameter != "buildRangeInfos" {
+		t.Errorf("Expected ValidationError for buildRangeInfos, got %v", err)
+	}
+}
+
+// TestCreateRayTracingPipelinesLibraryValidation tests the pipeline library checks of CreateRayTracingPipelinesKHR
+func TestCreateRayTracingPipelinesLibraryValidation(t *testing.T) {
+	fakeDevice := Device(uintptr(0x1234))
+	stages := []PipelineShaderStageCreateInfo{{Stage: ShaderStageRaygenBitKHR, Module: ShaderModule(uintptr(0x5678)), Name: "main"}}
+	groups := []RayTracingShaderGroupCreateInfo{{Type: RayTracingShaderGroupTypeGeneral, GeneralShader: 0,
+		ClosestHitShader: ShaderUnused, AnyHitShader: ShaderUnused, IntersectionShader: ShaderUnused}}
+	libraryInterface := &RayTracingPipelineInterfaceCreateInfo{MaxPipelineRayPayloadSize: 16, MaxPipelineRayHitAttributeSize: 8}
+
+	tests := []struct {
+		name       string
+		createInfo RayTracingPipelineCreateInfo
+		errorParam string
+	}{
+		{
+			name:       "library without interface",
+			createInfo: RayTracingPipelineCreateInfo{Flags: PipelineCreateLibraryBit, Stages: stages, Groups: groups},
+			errorParam: "LibraryInterface",
+		},
+		{
+			name:       "linking without interface",
+			createInfo: RayTracingPipelineCreateInfo{Libraries: []Pipeline{Pipeline(uintptr(0x9abc))}},
+			errorParam: "LibraryInterface",
+		},
+		{
+			name:       "nil library",
+			createInfo: RayTracingPipelineCreateInfo{Libraries: []Pipeline{nil}, LibraryInterface: libraryInterface},
+			errorParam: "Libraries",
+		},
+		{
+			name:       "no stages without libraries",
+			createInfo: RayTracingPipelineCreateInfo{Groups: groups, LibraryInterface: libraryInterface},
+			errorParam: "Stages",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CreateRayTracingPipelinesKHR(fakeDevice, nil, []RayTracingPipelineCreateInfo{tt.createInfo})
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package vulkan
+
+/*
+#include <vulkan/vulkan.h>
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"fmt"
+	"image"
+	"unsafe"
+)
+
+// readbackBytesPerPixel is the texel size of the formats supported by ReadImagePixels
+const readbackBytesPerPixel = 4
+
+// ReadImagePixels copies the first mip level and array layer of a color image into a Go image.
+// It records the copy into a one-time command buffer from pool, submits it to queue and waits
+// for completion, so it must not be used inside a frame that is still being recorded.
+//
+// layout is the image's current layout; the image is transitioned to
+// ImageLayoutTransferSrcOptimal for the copy and back to layout afterwards. The image must have
+// been created with ImageUsageTransferSrcBit. Supported formats are the 8-bit RGBA and BGRA
+// UNORM and SRGB formats; SRGB values are returned unconverted, which is what image/png expects.
+func ReadImagePixels(device Device, physicalDevice PhysicalDevice, pool CommandPool, queue Queue, srcImage Image, layout ImageLayout, format Format, extent Extent2D) (*image.RGBA, error) {
+	if device == nil {
+		return nil, NewValidationError("device", "cannot be nil")
+	}
+	if physicalDevice == nil {
+		return nil, NewValidationError("physicalDevice", "cannot be nil")
+	}
+	if pool == nil {
+		return nil, NewValidationError("pool", "cannot be nil")
+	}
+	if queue == nil {
+		return nil, NewValidationError("queue", "cannot be nil")
+	}
+	if srcImage == nil {
+		return nil, NewValidationError("srcImage", "cannot be nil")
+	}
+	if layout == ImageLayoutUndefined {
+		return nil, NewValidationError("layout", "image contents are undefined in ImageLayoutUndefined")
+	}
+	if !isReadbackFormat(format) {
+		return nil, NewValidationError("format", fmt.Sprintf("unsupported readback format %d", format))
+	}
+	if extent.Width == 0 || extent.Height == 0 {
+		return nil, NewValidationError("extent", "width and height must be greater than 0")
+	}
+
+	size := DeviceSize(extent.Width) * DeviceSize(extent.Height) * readbackBytesPerPixel
+
+	buffer, err := CreateBuffer(device, &BufferCreateInfo{
+		Size:        size,
+		Usage:       BufferUsageTransferDstBit,
+		SharingMode: SharingModeExclusive,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer DestroyBuffer(device, buffer)
+
+	// Cached memory makes the CPU-side conversion much faster where it is available
+	reqs := GetBufferMemoryRequirements(device, buffer)
+	memoryTypeIndex, ok := FindMemoryTypeWithFallback(GetPhysicalDeviceMemoryProperties(physicalDevice), reqs.MemoryTypeBits,
+		MemoryPropertyHostVisibleBit|MemoryPropertyHostCoherentBit, MemoryPropertyHostCachedBit)
+	if !ok {
+		return nil, NewVulkanError(ErrorFeatureNotPresent, "ReadImagePixels", "no host-visible coherent memory type for staging buffer")
+	}
+
+	memory, err := AllocateMemory(device, &MemoryAllocateInfo{
+		AllocationSize:  reqs.Size,
+		MemoryTypeIndex: memoryTypeIndex,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer FreeMemory(device, memory)
+
+	if err := BindBufferMemory(device, buffer, memory, 0); err != nil {
+		return nil, err
+	}
+
+	err = RunOneTimeCommands(device, pool, queue, func(cb CommandBuffer) error {
+		recordImageReadback(cb, srcImage, layout, buffer, extent)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := MapMemory(device, memory, 0, size, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer UnmapMemory(device, memory)
+
+	return pixelsToRGBA(unsafe.Slice((*byte)(data), int(size)), format, extent)
+}
+
+// recordImageReadback records the layout transitions, the image-to-buffer copy and the
+// barrier that makes the copied data visible to the host
+func recordImageReadback(commandBuffer CommandBuffer, srcImage Image, layout ImageLayout, buffer Buffer, extent Extent2D) {
+	subresourceRange := C.VkImageSubresourceRange{
+		aspectMask:     C.VK_IMAGE_ASPECT_COLOR_BIT,
+		baseMipLevel:   0,
+		levelCount:     1,
+		baseArrayLayer: 0,
+		layerCount:     1,
+	}
+
+	// The previous use of the image is unknown, so wait for all prior work
+	toTransfer := C.VkImageMemoryBarrier{
+		sType:               C.VK_STRUCTURE_TYPE_IMAGE_MEMORY_BARRIER,
+		srcAccessMask:       C.VK_ACCESS_MEMORY_WRITE_BIT,
+		dstAccessMask:       C.VK_ACCESS_TRANSFER_READ_BIT,
+		oldLayout:           C.VkImageLayout(layout),
+		newLayout:           C.VK_IMAGE_LAYOUT_TRANSFER_SRC_OPTIMAL,
+		srcQueueFamilyIndex: C.VK_QUEUE_FAMILY_IGNORED,
+		dstQueueFamilyIndex: C.VK_QUEUE_FAMILY_IGNORED,
+		image:               C.VkImage(srcImage),
+		subresourceRange:    subresourceRange,
+	}
+	C.vkCmdPipelineBarrier(C.VkCommandBuffer(commandBuffer),
+		C.VK_PIPELINE_STAGE_ALL_COMMANDS_BIT, C.VK_PIPELINE_STAGE_TRANSFER_BIT, 0,
+		0, nil, 0, nil, 1, &toTransfer)
+
+	region := C.VkBufferImageCopy{
+		bufferOffset:      0,
+		bufferRowLength:   0,
+		bufferImageHeight: 0,
+		imageSubresource: C.VkImageSubresourceLayers{
+			aspectMask:     C.VK_IMAGE_ASPECT_COLOR_BIT,
+			mipLevel:       0,
+			baseArrayLayer: 0,
+			layerCount:     1,
+		},
+		imageExtent: C.VkExtent3D{width: C.uint32_t(extent.Width), height: C.uint32_t(extent.Height), depth: 1},
+	}
+	C.vkCmdCopyImageToBuffer(C.VkCommandBuffer(commandBuffer), C.VkImage(srcImage), C.VK_IMAGE_LAYOUT_TRANSFER_SRC_OPTIMAL,
+		C.VkBuffer(buffer), 1, &region)
+
+	toOriginal := toTransfer
+	toOriginal.srcAccessMask = C.VK_ACCESS_TRANSFER_READ_BIT
+	toOriginal.dstAccessMask = C.VK_ACCESS_MEMORY_READ_BIT | C.VK_ACCESS_MEMORY_WRITE_BIT
+	toOriginal.oldLayout = C.VK_IMAGE_LAYOUT_TRANSFER_SRC_OPTIMAL
+	toOriginal.newLayout = C.VkImageLayout(layout)
+
+	toHost := C.VkBufferMemoryBarrier{
+		sType:               C.VK_STRUCTURE_TYPE_BUFFER_MEMORY_BARRIER,
+		srcAccessMask:       C.VK_ACCESS_TRANSFER_WRITE_BIT,
+		dstAccessMask:       C.VK_ACCESS_HOST_READ_BIT,
+		srcQueueFamilyIndex: C.VK_QUEUE_FAMILY_IGNORED,
+		dstQueueFamilyIndex: C.VK_QUEUE_FAMILY_IGNORED,
+		buffer:              C.VkBuffer(buffer),
+		offset:              0,
+		size:                C.VK_WHOLE_SIZE,
+	}
+	C.vkCmdPipelineBarrier(C.VkCommandBuffer(commandBuffer),
+		C.VK_PIPELINE_STAGE_TRANSFER_BIT, C.VK_PIPELINE_STAGE_ALL_COMMANDS_BIT|C.VK_PIPELINE_STAGE_HOST_BIT, 0,
+		0, nil, 1, &toHost, 1, &toOriginal)
+}
+
+// isReadbackFormat reports whether ReadImagePixels can convert format
+func isReadbackFormat(format Format) bool {
+	switch format {
+	case FormatR8G8B8A8Unorm, FormatR8G8B8A8Srgb, FormatB8G8R8A8Unorm, FormatB8G8R8A8Srgb:
+		return true
+	default:
+		return false
+	}
+}
+
+// pixelsToRGBA converts tightly packed 4-byte texels into an image.RGBA, swizzling BGRA formats
+func pixelsToRGBA(data []byte, format Format, extent Extent2D) (*image.RGBA, error) {
+	if !isReadbackFormat(format) {
+		return nil, NewValidationError("format", fmt.Sprintf("unsupported readback format %d", format))
+	}
+	size := int(extent.Width) * int(extent.Height) * readbackBytesPerPixel
+	if len(data) < size {
+		return nil, NewValidationError("data", fmt.Sprintf("expected at least %d bytes, got %d", size, len(data)))
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, int(extent.Width), int(extent.Height)))
+	copy(img.Pix, data[:size])
+
+	if format == FormatB8G8R8A8Unorm || format == FormatB8G8R8A8Srgb {
+		for i := 0; i < size; i += readbackBytesPerPixel {
+			img.Pix[i], img.Pix[i+2] = img.Pix[i+2], img.Pix[i]
+		}
+	}
+	return img, nil
+}
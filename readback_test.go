@@ -0,0 +1,78 @@
+//go:build cgo
+
+package vulkan
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// TestPixelsToRGBA tests texel conversion for readback formats
+func TestPixelsToRGBA(t *testing.T) {
+	extent := Extent2D{Width: 2, Height: 1}
+	data := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+
+	tests := []struct {
+		format   Format
+		expected []byte
+	}{
+		{FormatR8G8B8A8Unorm, []byte{1, 2, 3, 4, 5, 6, 7, 8}},
+		{FormatR8G8B8A8Srgb, []byte{1, 2, 3, 4, 5, 6, 7, 8}},
+		{FormatB8G8R8A8Unorm, []byte{3, 2, 1, 4, 7, 6, 5, 8}},
+		{FormatB8G8R8A8Srgb, []byte{3, 2, 1, 4, 7, 6, 5, 8}},
+	}
+
+	for _, tt := range tests {
+		img, err := pixelsToRGBA(data, tt.format, extent)
+		if err != nil {
+			t.Fatalf("format %d: unexpected error: %v", tt.format, err)
+		}
+		if !bytes.Equal(img.Pix, tt.expected) {
+			t.Errorf("format %d: expected pixels %v, got %v", tt.format, tt.expected, img.Pix)
+		}
+	}
+
+	// The source buffer must not be modified by the BGRA swizzle
+	if !bytes.Equal(data, []byte{1, 2, 3, 4, 5, 6, 7, 8}) {
+		t.Errorf("Expected source data to be unchanged, got %v", data)
+	}
+}
+
+// TestReadImagePixelsValidation tests input validation for ReadImagePixels
+func TestReadImagePixelsValidation(t *testing.T) {
+	fakeDevice := Device(uintptr(0x1234))
+	fakePhysicalDevice := PhysicalDevice(uintptr(0x2345))
+	fakePool := CommandPool(uintptr(0x3456))
+	fakeQueue := Queue(uintptr(0x4567))
+	fakeImage := Image(uintptr(0x5678))
+	extent := Extent2D{Width: 64, Height: 64}
+
+	tests := []struct {
+		name       string
+		image      Image
+		layout     ImageLayout
+		format     Format
+		extent     Extent2D
+		errorParam string
+	}{
+		{"nil image", nil, ImageLayoutTransferSrcOptimal, FormatR8G8B8A8Unorm, extent, "srcImage"},
+		{"undefined layout", fakeImage, ImageLayoutUndefined, FormatR8G8B8A8Unorm, extent, "layout"},
+		{"unsupported format", fakeImage, ImageLayoutTransferSrcOptimal, FormatR8G8B8A8Uint, extent, "format"},
+		{"empty extent", fakeImage, ImageLayoutTransferSrcOptimal, FormatB8G8R8A8Srgb, Extent2D{Width: 64}, "extent"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadImagePixels(fakeDevice, fakePhysicalDevice, fakePool, fakeQueue, tt.image, tt.layout, tt.format, tt.extent)
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+}
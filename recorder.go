@@ -0,0 +1,195 @@
+package vulkan
+
+/*
+#include <vulkan/vulkan.h>
+#include <stdlib.h>
+
+#define RECORDED_CMD_BIND_PIPELINE      0
+#define RECORDED_CMD_BIND_VERTEX_BUFFER 1
+#define RECORDED_CMD_BIND_INDEX_BUFFER  2
+#define RECORDED_CMD_SET_VIEWPORT       3
+#define RECORDED_CMD_SET_SCISSOR        4
+#define RECORDED_CMD_DRAW               5
+#define RECORDED_CMD_DRAW_INDEXED       6
+#define RECORDED_CMD_DISPATCH           7
+
+// A single buffered command. Only the fields used by the opcode are meaningful.
+typedef struct {
+    uint32_t op;
+    uint32_t args[4];
+    int32_t vertexOffset;
+    VkPipeline pipeline;
+    VkBuffer buffer;
+    VkDeviceSize offset;
+    VkViewport viewport;
+    VkRect2D scissor;
+} RecordedCmd;
+
+// Replays a batch of buffered commands into a command buffer in a single cgo call
+static void replayRecordedCmds(VkCommandBuffer commandBuffer, const RecordedCmd* cmds, uint32_t count) {
+    for (uint32_t i = 0; i < count; i++) {
+        const RecordedCmd* c = &cmds[i];
+        switch (c->op) {
+        case RECORDED_CMD_BIND_PIPELINE:
+            vkCmdBindPipeline(commandBuffer, (VkPipelineBindPoint)c->args[0], c->pipeline);
+            break;
+        case RECORDED_CMD_BIND_VERTEX_BUFFER:
+            vkCmdBindVertexBuffers(commandBuffer, c->args[0], 1, &c->buffer, &c->offset);
+            break;
+        case RECORDED_CMD_BIND_INDEX_BUFFER:
+            vkCmdBindIndexBuffer(commandBuffer, c->buffer, c->offset, (VkIndexType)c->args[0]);
+            break;
+        case RECORDED_CMD_SET_VIEWPORT:
+            vkCmdSetViewport(commandBuffer, c->args[0], 1, &c->viewport);
+            break;
+        case RECORDED_CMD_SET_SCISSOR:
+            vkCmdSetScissor(commandBuffer, c->args[0], 1, &c->scissor);
+            break;
+        case RECORDED_CMD_DRAW:
+            vkCmdDraw(commandBuffer, c->args[0], c->args[1], c->args[2], c->args[3]);
+            break;
+        case RECORDED_CMD_DRAW_INDEXED:
+            vkCmdDrawIndexed(commandBuffer, c->args[0], c->args[1], c->args[2], c->vertexOffset, c->args[3]);
+            break;
+        case RECORDED_CMD_DISPATCH:
+            vkCmdDispatch(commandBuffer, c->args[0], c->args[1], c->args[2]);
+            break;
+        }
+    }
+}
+*/
+import "C"
+
+// CommandRecorder buffers a sequence of commands in Go memory and replays them
+// into a command buffer with a single cgo call. Recording thousands of draws
+// through the individual Cmd* functions is dominated by cgo transition cost;
+// the recorder pays that cost once per Flush instead of once per command.
+//
+// A CommandRecorder is not safe for concurrent use. Use one recorder per goroutine.
+type CommandRecorder struct {
+	cmds []C.RecordedCmd
+}
+
+// NewCommandRecorder creates a command recorder with room for capacity commands
+// before it needs to grow
+func NewCommandRecorder(capacity int) *CommandRecorder {
+	if capacity < 0 {
+		capacity = 0
+	}
+	return &CommandRecorder{
+		cmds: make([]C.RecordedCmd, 0, capacity),
+	}
+}
+
+// Len returns the number of buffered commands
+func (r *CommandRecorder) Len() int {
+	return len(r.cmds)
+}
+
+// Reset discards all buffered commands while keeping the allocated storage
+func (r *CommandRecorder) Reset() {
+	r.cmds = r.cmds[:0]
+}
+
+// BindPipeline buffers a vkCmdBindPipeline command
+func (r *CommandRecorder) BindPipeline(pipelineBindPoint PipelineBindPoint, pipeline Pipeline) {
+	var cmd C.RecordedCmd
+	cmd.op = C.RECORDED_CMD_BIND_PIPELINE
+	cmd.args[0] = C.uint32_t(pipelineBindPoint)
+	cmd.pipeline = C.VkPipeline(pipeline)
+	r.cmds = append(r.cmds, cmd)
+}
+
+// BindVertexBuffer buffers a vkCmdBindVertexBuffers command for a single binding
+func (r *CommandRecorder) BindVertexBuffer(binding uint32, buffer Buffer, offset DeviceSize) {
+	var cmd C.RecordedCmd
+	cmd.op = C.RECORDED_CMD_BIND_VERTEX_BUFFER
+	cmd.args[0] = C.uint32_t(binding)
+	cmd.buffer = C.VkBuffer(buffer)
+	cmd.offset = C.VkDeviceSize(offset)
+	r.cmds = append(r.cmds, cmd)
+}
+
+// BindIndexBuffer buffers a vkCmdBindIndexBuffer command
+func (r *CommandRecorder) BindIndexBuffer(buffer Buffer, offset DeviceSize, indexType IndexType) {
+	var cmd C.RecordedCmd
+	cmd.op = C.RECORDED_CMD_BIND_INDEX_BUFFER
+	cmd.args[0] = C.uint32_t(indexType)
+	cmd.buffer = C.VkBuffer(buffer)
+	cmd.offset = C.VkDeviceSize(offset)
+	r.cmds = append(r.cmds, cmd)
+}
+
+// SetViewport buffers a vkCmdSetViewport command for a single viewport
+func (r *CommandRecorder) SetViewport(index uint32, viewport Viewport) {
+	var cmd C.RecordedCmd
+	cmd.op = C.RECORDED_CMD_SET_VIEWPORT
+	cmd.args[0] = C.uint32_t(index)
+	cmd.viewport.x = C.float(viewport.X)
+	cmd.viewport.y = C.float(viewport.Y)
+	cmd.viewport.width = C.float(viewport.Width)
+	cmd.viewport.height = C.float(viewport.Height)
+	cmd.viewport.minDepth = C.float(viewport.MinDepth)
+	cmd.viewport.maxDepth = C.float(viewport.MaxDepth)
+	r.cmds = append(r.cmds, cmd)
+}
+
+// SetScissor buffers a vkCmdSetScissor command for a single scissor rectangle
+func (r *CommandRecorder) SetScissor(index uint32, scissor Rect2D) {
+	var cmd C.RecordedCmd
+	cmd.op = C.RECORDED_CMD_SET_SCISSOR
+	cmd.args[0] = C.uint32_t(index)
+	cmd.scissor.offset.x = C.int32_t(scissor.Offset.X)
+	cmd.scissor.offset.y = C.int32_t(scissor.Offset.Y)
+	cmd.scissor.extent.width = C.uint32_t(scissor.Extent.Width)
+	cmd.scissor.extent.height = C.uint32_t(scissor.Extent.Height)
+	r.cmds = append(r.cmds, cmd)
+}
+
+// Draw buffers a vkCmdDraw command
+func (r *CommandRecorder) Draw(vertexCount, instanceCount, firstVertex, firstInstance uint32) {
+	var cmd C.RecordedCmd
+	cmd.op = C.RECORDED_CMD_DRAW
+	cmd.args[0] = C.uint32_t(vertexCount)
+	cmd.args[1] = C.uint32_t(instanceCount)
+	cmd.args[2] = C.uint32_t(firstVertex)
+	cmd.args[3] = C.uint32_t(firstInstance)
+	r.cmds = append(r.cmds, cmd)
+}
+
+// DrawIndexed buffers a vkCmdDrawIndexed command
+func (r *CommandRecorder) DrawIndexed(indexCount, instanceCount, firstIndex uint32, vertexOffset int32, firstInstance uint32) {
+	var cmd C.RecordedCmd
+	cmd.op = C.RECORDED_CMD_DRAW_INDEXED
+	cmd.args[0] = C.uint32_t(indexCount)
+	cmd.args[1] = C.uint32_t(instanceCount)
+	cmd.args[2] = C.uint32_t(firstIndex)
+	cmd.args[3] = C.uint32_t(firstInstance)
+	cmd.vertexOffset = C.int32_t(vertexOffset)
+	r.cmds = append(r.cmds, cmd)
+}
+
+// Dispatch buffers a vkCmdDispatch command
+func (r *CommandRecorder) Dispatch(groupCountX, groupCountY, groupCountZ uint32) {
+	var cmd C.RecordedCmd
+	cmd.op = C.RECORDED_CMD_DISPATCH
+	cmd.args[0] = C.uint32_t(groupCountX)
+	cmd.args[1] = C.uint32_t(groupCountY)
+	cmd.args[2] = C.uint32_t(groupCountZ)
+	r.cmds = append(r.cmds, cmd)
+}
+
+// Flush replays all buffered commands into commandBuffer, which must be in the
+// recording state, and then resets the recorder
+func (r *CommandRecorder) Flush(commandBuffer CommandBuffer) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+	if len(r.cmds) == 0 {
+		return nil
+	}
+
+	C.replayRecordedCmds(C.VkCommandBuffer(commandBuffer), &r.cmds[0], C.uint32_t(len(r.cmds)))
+	r.Reset()
+	return nil
+}
@@ -0,0 +1,125 @@
+//go:build cgo
+
+package vulkan
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestCommandRecorderBuffering tests that commands are buffered and reset
+func TestCommandRecorderBuffering(t *testing.T) {
+	recorder := NewCommandRecorder(4)
+
+	recorder.SetViewport(0, Viewport{Width: 640, Height: 480, MaxDepth: 1})
+	recorder.SetScissor(0, Rect2D{Extent: Extent2D{Width: 640, Height: 480}})
+	recorder.Draw(3, 1, 0, 0)
+	recorder.DrawIndexed(6, 1, 0, 0, 0)
+	recorder.Dispatch(8, 8, 1)
+
+	if recorder.Len() != 5 {
+		t.Errorf("Expected 5 buffered commands, got %d", recorder.Len())
+	}
+
+	recorder.Reset()
+	if recorder.Len() != 0 {
+		t.Errorf("Expected 0 buffered commands after Reset, got %d", recorder.Len())
+	}
+}
+
+// TestCommandRecorderFlushValidation tests input validation for Flush
+func TestCommandRecorderFlushValidation(t *testing.T) {
+	recorder := NewCommandRecorder(1)
+	recorder.Draw(3, 1, 0, 0)
+
+	err := recorder.Flush(nil)
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+	}
+	if validationErr.Parameter != "commandBuffer" {
+		t.Errorf("Expected error for parameter 'commandBuffer', got '%s'", validationErr.Parameter)
+	}
+
+	// A failed flush must not drop the buffered commands
+	if recorder.Len() != 1 {
+		t.Errorf("Expected 1 buffered command after failed Flush, got %d", recorder.Len())
+	}
+}
+
+// recordingCommandBuffer creates a command buffer in the recording state on the
+// first available device, skipping the benchmark if no Vulkan driver is present
+func recordingCommandBuffer(b *testing.B) CommandBuffer {
+	b.Helper()
+
+	instance, err := CreateInstance(&InstanceCreateInfo{})
+	if err != nil {
+		b.Skipf("Vulkan not available: %v", err)
+	}
+	b.Cleanup(func() { DestroyInstance(instance) })
+
+	physicalDevices, err := EnumeratePhysicalDevices(instance)
+	if err != nil || len(physicalDevices) == 0 {
+		b.Skip("no physical devices available")
+	}
+
+	device, err := CreateDevice(physicalDevices[0], &DeviceCreateInfo{
+		QueueCreateInfos: []DeviceQueueCreateInfo{{QueueFamilyIndex: 0, QueuePriorities: []float32{1.0}}},
+	})
+	if err != nil {
+		b.Skipf("device creation failed: %v", err)
+	}
+	b.Cleanup(func() { DestroyDevice(device) })
+
+	pool, err := CreateCommandPool(device, &CommandPoolCreateInfo{QueueFamilyIndex: 0})
+	if err != nil {
+		b.Skipf("command pool creation failed: %v", err)
+	}
+	b.Cleanup(func() { DestroyCommandPool(device, pool) })
+
+	commandBuffers, err := AllocateCommandBuffers(device, &CommandBufferAllocateInfo{
+		CommandPool:        pool,
+		Level:              CommandBufferLevelPrimary,
+		CommandBufferCount: 1,
+	})
+	if err != nil {
+		b.Skipf("command buffer allocation failed: %v", err)
+	}
+
+	if err := BeginCommandBuffer(commandBuffers[0], &CommandBufferBeginInfo{}); err != nil {
+		b.Skipf("begin command buffer failed: %v", err)
+	}
+	b.Cleanup(func() { _ = EndCommandBuffer(commandBuffers[0]) })
+
+	return commandBuffers[0]
+}
+
+const benchmarkDrawsPerIteration = 1000
+
+// BenchmarkCmdDispatchDirect measures recording dispatches with one cgo call per command
+func BenchmarkCmdDispatchDirect(b *testing.B) {
+	commandBuffer := recordingCommandBuffer(b)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < benchmarkDrawsPerIteration; j++ {
+			CmdDispatch(commandBuffer, 1, 1, 1)
+		}
+	}
+}
+
+// BenchmarkCommandRecorderDispatch measures recording the same dispatches through a CommandRecorder
+func BenchmarkCommandRecorderDispatch(b *testing.B) {
+	commandBuffer := recordingCommandBuffer(b)
+	recorder := NewCommandRecorder(benchmarkDrawsPerIteration)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < benchmarkDrawsPerIteration; j++ {
+			recorder.Dispatch(1, 1, 1)
+		}
+		if err := recorder.Flush(commandBuffer); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
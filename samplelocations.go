@@ -0,0 +1,188 @@
+package vulkan
+
+/*
+#include <vulkan/vulkan.h>
+#include <stdlib.h>
+
+// Function pointers for VK_EXT_sample_locations functions.
+// Both are loaded through the instance so the multisample properties can be queried before
+// device creation.
+//
+// IMPORTANT: These are global static pointers and NOT thread-safe during loading.
+// LoadSampleLocationsFunctions must be called from a single thread during
+// initialization before any concurrent sample locations API usage.
+static PFN_vkCmdSetSampleLocationsEXT pfn_vkCmdSetSampleLocationsEXT = NULL;
+static PFN_vkGetPhysicalDeviceMultisamplePropertiesEXT pfn_vkGetPhysicalDeviceMultisamplePropertiesEXT = NULL;
+
+static int loadSampleLocationsInstanceFunctions(VkInstance instance) {
+    if (instance == VK_NULL_HANDLE) {
+        return 0;
+    }
+    pfn_vkCmdSetSampleLocationsEXT = (PFN_vkCmdSetSampleLocationsEXT)
+        vkGetInstanceProcAddr(instance, "vkCmdSetSampleLocationsEXT");
+    pfn_vkGetPhysicalDeviceMultisamplePropertiesEXT = (PFN_vkGetPhysicalDeviceMultisamplePropertiesEXT)
+        vkGetInstanceProcAddr(instance, "vkGetPhysicalDeviceMultisamplePropertiesEXT");
+
+    return pfn_vkCmdSetSampleLocationsEXT != NULL &&
+           pfn_vkGetPhysicalDeviceMultisamplePropertiesEXT != NULL;
+}
+
+// Command buffer wrapper functions return 1 on success, 0 if function pointer is NULL.
+static int call_vkCmdSetSampleLocationsEXT(VkCommandBuffer commandBuffer, const VkSampleLocationsInfoEXT* pSampleLocationsInfo) {
+    if (pfn_vkCmdSetSampleLocationsEXT == NULL) {
+        return 0;
+    }
+    pfn_vkCmdSetSampleLocationsEXT(commandBuffer, pSampleLocationsInfo);
+    return 1;
+}
+
+static int call_vkGetPhysicalDeviceMultisamplePropertiesEXT(
+    VkPhysicalDevice physicalDevice,
+    VkSampleCountFlagBits samples,
+    VkMultisamplePropertiesEXT* pMultisampleProperties) {
+    if (pfn_vkGetPhysicalDeviceMultisamplePropertiesEXT == NULL) {
+        return 0;
+    }
+    pfn_vkGetPhysicalDeviceMultisamplePropertiesEXT(physicalDevice, samples, pMultisampleProperties);
+    return 1;
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"math/bits"
+	"unsafe"
+)
+
+// ExtensionNameSampleLocations is the programmable sample locations device extension name
+const ExtensionNameSampleLocations = "VK_EXT_sample_locations"
+
+// SampleLocation is a sample position within a pixel, with (0, 0) at the top-left corner
+// and (1, 1) at the bottom-right corner
+type SampleLocation struct {
+	X float32
+	Y float32
+}
+
+// SampleLocationsInfo describes a custom sample pattern repeated over a grid of pixels
+type SampleLocationsInfo struct {
+	// SampleLocationsPerPixel is the single sample count the pattern is used for
+	SampleLocationsPerPixel SampleCountFlags
+	// SampleLocationGridSize is the size in pixels of the grid the pattern covers. It must
+	// divide the MaxSampleLocationGridSize reported for SampleLocationsPerPixel.
+	SampleLocationGridSize Extent2D
+	// SampleLocations holds SampleLocationsPerPixel locations for each pixel of the grid, in
+	// row-major pixel order
+	SampleLocations []SampleLocation
+}
+
+// MultisampleProperties reports the sample location limits for a sample count
+type MultisampleProperties struct {
+	MaxSampleLocationGridSize Extent2D
+}
+
+// LoadSampleLocationsFunctions loads VK_EXT_sample_locations functions for an instance.
+//
+// This function MUST be called after creating an instance and before querying multisample
+// properties or recording sample locations. The device the command buffers belong to must
+// have been created with the VK_EXT_sample_locations extension enabled.
+//
+// IMPORTANT: This function is NOT thread-safe. Only one instance is supported at a time;
+// calling this function again will overwrite previously loaded function pointers.
+//
+// Returns false if any sample locations function could not be loaded.
+func LoadSampleLocationsFunctions(instance Instance) bool {
+	return C.loadSampleLocationsInstanceFunctions(C.VkInstance(instance)) != 0
+}
+
+// validateSampleLocationsInfo checks that the pattern matches its sample count and grid size
+func validateSampleLocationsInfo(info *SampleLocationsInfo) error {
+	if info == nil {
+		return NewValidationError("sampleLocationsInfo", "cannot be nil")
+	}
+	if bits.OnesCount32(uint32(info.SampleLocationsPerPixel)) != 1 {
+		return NewValidationError("sampleLocationsInfo.SampleLocationsPerPixel", "must be a single sample count")
+	}
+	grid := info.SampleLocationGridSize
+	if grid.Width == 0 || grid.Height == 0 {
+		return NewValidationError("sampleLocationsInfo.SampleLocationGridSize", "width and height must be greater than 0")
+	}
+	expected := uint64(info.SampleLocationsPerPixel) * uint64(grid.Width) * uint64(grid.Height)
+	if uint64(len(info.SampleLocations)) != expected {
+		return NewValidationError("sampleLocationsInfo.SampleLocations",
+			fmt.Sprintf("must contain %d locations (samples per pixel times grid pixels), got %d", expected, len(info.SampleLocations)))
+	}
+	for i, location := range info.SampleLocations {
+		if location.X < 0 || location.X > 1 || location.Y < 0 || location.Y > 1 {
+			return NewValidationError(fmt.Sprintf("sampleLocationsInfo.SampleLocations[%d]", i), "coordinates must be between 0 and 1")
+		}
+	}
+	return nil
+}
+
+// CmdSetSampleLocationsEXT sets the sample pattern used by subsequent draws. The bound
+// pipeline must enable sample locations with the sample locations dynamic state.
+// Returns an error if LoadSampleLocationsFunctions was not called.
+func CmdSetSampleLocationsEXT(commandBuffer CommandBuffer, sampleLocationsInfo *SampleLocationsInfo) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+	if err := validateSampleLocationsInfo(sampleLocationsInfo); err != nil {
+		return err
+	}
+
+	count := len(sampleLocationsInfo.SampleLocations)
+	cLocationsPtr := (*C.VkSampleLocationEXT)(C.calloc(C.size_t(count), C.sizeof_VkSampleLocationEXT))
+	if cLocationsPtr == nil {
+		return NewVulkanError(ErrorOutOfHostMemory, "CmdSetSampleLocationsEXT", "failed to allocate memory for sample locations")
+	}
+	defer C.free(unsafe.Pointer(cLocationsPtr))
+
+	cLocations := unsafe.Slice(cLocationsPtr, count)
+	for i, location := range sampleLocationsInfo.SampleLocations {
+		cLocations[i] = C.VkSampleLocationEXT{x: C.float(location.X), y: C.float(location.Y)}
+	}
+
+	cInfo := C.VkSampleLocationsInfoEXT{
+		sType:                   C.VK_STRUCTURE_TYPE_SAMPLE_LOCATIONS_INFO_EXT,
+		sampleLocationsPerPixel: C.VkSampleCountFlagBits(sampleLocationsInfo.SampleLocationsPerPixel),
+		sampleLocationGridSize: C.VkExtent2D{
+			width:  C.uint32_t(sampleLocationsInfo.SampleLocationGridSize.Width),
+			height: C.uint32_t(sampleLocationsInfo.SampleLocationGridSize.Height),
+		},
+		sampleLocationsCount: C.uint32_t(count),
+		pSampleLocations:     cLocationsPtr,
+	}
+
+	if C.call_vkCmdSetSampleLocationsEXT(C.VkCommandBuffer(commandBuffer), &cInfo) == 0 {
+		return NewVulkanError(ErrorExtensionNotPresent, "CmdSetSampleLocationsEXT", "sample locations extension not loaded - call LoadSampleLocationsFunctions first")
+	}
+	return nil
+}
+
+// GetPhysicalDeviceMultisamplePropertiesEXT reports the largest sample location grid supported
+// for a single sample count. A zero grid size means custom sample locations are not
+// supported for that count.
+// Returns an error if LoadSampleLocationsFunctions was not called.
+func GetPhysicalDeviceMultisamplePropertiesEXT(physicalDevice PhysicalDevice, samples SampleCountFlags) (MultisampleProperties, error) {
+	if physicalDevice == nil {
+		return MultisampleProperties{}, NewValidationError("physicalDevice", "cannot be nil")
+	}
+	if bits.OnesCount32(uint32(samples)) != 1 {
+		return MultisampleProperties{}, NewValidationError("samples", "must be a single sample count")
+	}
+
+	var cProps C.VkMultisamplePropertiesEXT
+	cProps.sType = C.VK_STRUCTURE_TYPE_MULTISAMPLE_PROPERTIES_EXT
+	if C.call_vkGetPhysicalDeviceMultisamplePropertiesEXT(C.VkPhysicalDevice(physicalDevice), C.VkSampleCountFlagBits(samples), &cProps) == 0 {
+		return MultisampleProperties{}, NewVulkanError(ErrorExtensionNotPresent, "GetPhysicalDeviceMultisamplePropertiesEXT", "sample locations extension not loaded - call LoadSampleLocationsFunctions first")
+	}
+
+	return MultisampleProperties{
+		MaxSampleLocationGridSize: Extent2D{
+			Width:  uint32(cProps.maxSampleLocationGridSize.width),
+			Height: uint32(cProps.maxSampleLocationGridSize.height),
+		},
+	}, nil
+}
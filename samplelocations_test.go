@@ -0,0 +1,103 @@
+//go:build cgo
+
+package vulkan
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestSampleLocationsValidation tests input validation of the sample locations functions
+func TestSampleLocationsValidation(t *testing.T) {
+	fakeCommandBuffer := CommandBuffer(uintptr(0x1234))
+	fakePhysicalDevice := PhysicalDevice(uintptr(0x5678))
+	grid := Extent2D{Width: 1, Height: 1}
+
+	tests := []struct {
+		name       string
+		call       func() error
+		errorParam string
+	}{
+		{
+			name:       "nil info",
+			call:       func() error { return CmdSetSampleLocationsEXT(fakeCommandBuffer, nil) },
+			errorParam: "sampleLocationsInfo",
+		},
+		{
+			name: "multiple sample counts",
+			call: func() error {
+				return CmdSetSampleLocationsEXT(fakeCommandBuffer, &SampleLocationsInfo{
+					SampleLocationsPerPixel: SampleCount2Bit | SampleCount4Bit,
+					SampleLocationGridSize:  grid,
+				})
+			},
+			errorParam: "sampleLocationsInfo.SampleLocationsPerPixel",
+		},
+		{
+			name: "empty grid",
+			call: func() error {
+				return CmdSetSampleLocationsEXT(fakeCommandBuffer, &SampleLocationsInfo{SampleLocationsPerPixel: SampleCount2Bit})
+			},
+			errorParam: "sampleLocationsInfo.SampleLocationGridSize",
+		},
+		{
+			name: "location count does not match grid",
+			call: func() error {
+				return CmdSetSampleLocationsEXT(fakeCommandBuffer, &SampleLocationsInfo{
+					SampleLocationsPerPixel: SampleCount2Bit,
+					SampleLocationGridSize:  Extent2D{Width: 2, Height: 1},
+					SampleLocations:         []SampleLocation{{0.25, 0.25}, {0.75, 0.75}},
+				})
+			},
+			errorParam: "sampleLocationsInfo.SampleLocations",
+		},
+		{
+			name: "location outside pixel",
+			call: func() error {
+				return CmdSetSampleLocationsEXT(fakeCommandBuffer, &SampleLocationsInfo{
+					SampleLocationsPerPixel: SampleCount2Bit,
+					SampleLocationGridSize:  grid,
+					SampleLocations:         []SampleLocation{{0.25, 0.25}, {1.5, 0.75}},
+				})
+			},
+			errorParam: "sampleLocationsInfo.SampleLocations[1]",
+		},
+		{
+			name: "properties without sample count",
+			call: func() error {
+				_, err := GetPhysicalDeviceMultisamplePropertiesEXT(fakePhysicalDevice, 0)
+				return err
+			},
+			errorParam: "samples",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+}
+
+// TestSampleLocationsNotLoaded tests the unloaded-extension error of the sample locations functions
+func TestSampleLocationsNotLoaded(t *testing.T) {
+	info := &SampleLocationsInfo{
+		SampleLocationsPerPixel: SampleCount2Bit,
+		SampleLocationGridSize:  Extent2D{Width: 1, Height: 1},
+		SampleLocations:         []SampleLocation{{0.25, 0.25}, {0.75, 0.75}},
+	}
+	if err := CmdSetSampleLocationsEXT(CommandBuffer(uintptr(0x1234)), info); !errors.Is(err, ErrorExtensionNotPresent) {
+		t.Errorf("Expected ErrorExtensionNotPresent, got %v", err)
+	}
+	if _, err := GetPhysicalDeviceMultisamplePropertiesEXT(PhysicalDevice(uintptr(0x5678)), SampleCount4Bit); !errors.Is(err, ErrorExtensionNotPresent) {
+		t.Errorf("Expected ErrorExtensionNotPresent, got %v", err)
+	}
+}
@@ -0,0 +1,270 @@
+package vulkan
+
+import "sync"
+
+// ResourceScope tracks Vulkan objects and destroys them in reverse creation order when
+// Close is called. It is an opt-in alternative to pairing every Create call with a
+// deferred Destroy call:
+//
+//	scope := vulkan.NewResourceScope()
+//	defer scope.Close()
+//
+//	buffer, err := scope.CreateBuffer(device, &vulkan.BufferCreateInfo{...})
+//
+// Objects created through a scope must not be destroyed manually. A ResourceScope is
+// safe for concurrent use.
+type ResourceScope struct {
+	mu       sync.Mutex
+	cleanups []func()
+	closed   bool
+}
+
+// NewResourceScope creates an empty resource scope
+func NewResourceScope() *ResourceScope {
+	return &ResourceScope{}
+}
+
+// Defer registers a cleanup function that runs when the scope is closed. Cleanups run
+// in reverse registration order. Registering on a closed scope runs the cleanup immediately.
+func (s *ResourceScope) Defer(cleanup func()) {
+	if cleanup == nil {
+		return
+	}
+
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		cleanup()
+		return
+	}
+	s.cleanups = append(s.cleanups, cleanup)
+	s.mu.Unlock()
+}
+
+// Close destroys every object tracked by the scope in reverse creation order.
+// Calling Close more than once is a no-op.
+func (s *ResourceScope) Close() {
+	s.mu.Lock()
+	cleanups := s.cleanups
+	s.cleanups = nil
+	s.closed = true
+	s.mu.Unlock()
+
+	for i := len(cleanups) - 1; i >= 0; i-- {
+		cleanups[i]()
+	}
+}
+
+// CreateInstance creates an instance that is destroyed when the scope closes
+func (s *ResourceScope) CreateInstance(createInfo *InstanceCreateInfo) (Instance, error) {
+	instance, err := CreateInstance(createInfo)
+	if err != nil {
+		return instance, err
+	}
+	s.Defer(func() { DestroyInstance(instance) })
+	return instance, nil
+}
+
+// CreateDevice creates a logical device that is destroyed when the scope closes
+func (s *ResourceScope) CreateDevice(physicalDevice PhysicalDevice, createInfo *DeviceCreateInfo) (Device, error) {
+	device, err := CreateDevice(physicalDevice, createInfo)
+	if err != nil {
+		return device, err
+	}
+	s.Defer(func() { DestroyDevice(device) })
+	return device, nil
+}
+
+// CreateBuffer creates a buffer that is destroyed when the scope closes
+func (s *ResourceScope) CreateBuffer(device Device, createInfo *BufferCreateInfo) (Buffer, error) {
+	buffer, err := CreateBuffer(device, createInfo)
+	if err != nil {
+		return buffer, err
+	}
+	s.Defer(func() { DestroyBuffer(device, buffer) })
+	return buffer, nil
+}
+
+// AllocateMemory allocates device memory that is freed when the scope closes
+func (s *ResourceScope) AllocateMemory(device Device, allocateInfo *MemoryAllocateInfo) (DeviceMemory, error) {
+	memory, err := AllocateMemory(device, allocateInfo)
+	if err != nil {
+		return memory, err
+	}
+	s.Defer(func() { FreeMemory(device, memory) })
+	return memory, nil
+}
+
+// CreateImage creates an image that is destroyed when the scope closes
+func (s *ResourceScope) CreateImage(device Device, createInfo *ImageCreateInfo) (Image, error) {
+	image, err := CreateImage(device, createInfo)
+	if err != nil {
+		return image, err
+	}
+	s.Defer(func() { DestroyImage(device, image) })
+	return image, nil
+}
+
+// CreateImageView creates an image view that is destroyed when the scope closes
+func (s *ResourceScope) CreateImageView(device Device, createInfo *ImageViewCreateInfo) (ImageView, error) {
+	imageView, err := CreateImageView(device, createInfo)
+	if err != nil {
+		return imageView, err
+	}
+	s.Defer(func() { DestroyImageView(device, imageView) })
+	return imageView, nil
+}
+
+// CreateBufferView creates a buffer view that is destroyed when the scope closes
+func (s *ResourceScope) CreateBufferView(device Device, createInfo *BufferViewCreateInfo) (BufferView, error) {
+	bufferView, err := CreateBufferView(device, createInfo)
+	if err != nil {
+		return bufferView, err
+	}
+	s.Defer(func() { DestroyBufferView(device, bufferView) })
+	return bufferView, nil
+}
+
+// CreateSampler creates a sampler that is destroyed when the scope closes
+func (s *ResourceScope) CreateSampler(device Device, createInfo *SamplerCreateInfo) (Sampler, error) {
+	sampler, err := CreateSampler(device, createInfo)
+	if err != nil {
+		return sampler, err
+	}
+	s.Defer(func() { DestroySampler(device, sampler) })
+	return sampler, nil
+}
+
+// CreateShaderModule creates a shader module that is destroyed when the scope closes
+func (s *ResourceScope) CreateShaderModule(device Device, createInfo *ShaderModuleCreateInfo) (ShaderModule, error) {
+	shaderModule, err := CreateShaderModule(device, createInfo)
+	if err != nil {
+		return shaderModule, err
+	}
+	s.Defer(func() { DestroyShaderModule(device, shaderModule) })
+	return shaderModule, nil
+}
+
+// CreatePipelineLayout creates a pipeline layout that is destroyed when the scope closes
+func (s *ResourceScope) CreatePipelineLayout(device Device, createInfo *PipelineLayoutCreateInfo) (PipelineLayout, error) {
+	pipelineLayout, err := CreatePipelineLayout(device, createInfo)
+	if err != nil {
+		return pipelineLayout, err
+	}
+	s.Defer(func() { DestroyPipelineLayout(device, pipelineLayout) })
+	return pipelineLayout, nil
+}
+
+// CreateRenderPass creates a render pass that is destroyed when the scope closes
+func (s *ResourceScope) CreateRenderPass(device Device, createInfo *RenderPassCreateInfo) (RenderPass, error) {
+	renderPass, err := CreateRenderPass(device, createInfo)
+	if err != nil {
+		return renderPass, err
+	}
+	s.Defer(func() { DestroyRenderPass(device, renderPass) })
+	return renderPass, nil
+}
+
+// CreatePipelineCache creates a pipeline cache that is destroyed when the scope closes
+func (s *ResourceScope) CreatePipelineCache(device Device, initialData []byte) (PipelineCache, error) {
+	pipelineCache, err := CreatePipelineCache(device, initialData)
+	if err != nil {
+		return pipelineCache, err
+	}
+	s.Defer(func() { DestroyPipelineCache(device, pipelineCache) })
+	return pipelineCache, nil
+}
+
+// CreateQueryPool creates a query pool that is destroyed when the scope closes
+func (s *ResourceScope) CreateQueryPool(device Device, createInfo *QueryPoolCreateInfo) (QueryPool, error) {
+	queryPool, err := CreateQueryPool(device, createInfo)
+	if err != nil {
+		return queryPool, err
+	}
+	s.Defer(func() { DestroyQueryPool(device, queryPool) })
+	return queryPool, nil
+}
+
+// CreateComputePipelines creates compute pipelines that are destroyed when the scope closes
+func (s *ResourceScope) CreateComputePipelines(device Device, pipelineCache PipelineCache, createInfos []ComputePipelineCreateInfo) ([]Pipeline, error) {
+	pipelines, err := CreateComputePipelines(device, pipelineCache, createInfos)
+	if err != nil {
+		return pipelines, err
+	}
+	for _, pipeline := range pipelines {
+		pipeline := pipeline
+		s.Defer(func() { DestroyPipeline(device, pipeline) })
+	}
+	return pipelines, nil
+}
+
+// CreateDescriptorSetLayout creates a descriptor set layout that is destroyed when the scope closes
+func (s *ResourceScope) CreateDescriptorSetLayout(device Device, createInfo *DescriptorSetLayoutCreateInfo) (DescriptorSetLayout, error) {
+	layout, err := CreateDescriptorSetLayout(device, createInfo)
+	if err != nil {
+		return layout, err
+	}
+	s.Defer(func() { DestroyDescriptorSetLayout(device, layout) })
+	return layout, nil
+}
+
+// CreateDescriptorPool creates a descriptor pool that is destroyed when the scope closes
+func (s *ResourceScope) CreateDescriptorPool(device Device, createInfo *DescriptorPoolCreateInfo) (DescriptorPool, error) {
+	pool, err := CreateDescriptorPool(device, createInfo)
+	if err != nil {
+		return pool, err
+	}
+	s.Defer(func() { DestroyDescriptorPool(device, pool) })
+	return pool, nil
+}
+
+// CreateCommandPool creates a command pool that is destroyed when the scope closes
+func (s *ResourceScope) CreateCommandPool(device Device, createInfo *CommandPoolCreateInfo) (CommandPool, error) {
+	commandPool, err := CreateCommandPool(device, createInfo)
+	if err != nil {
+		return commandPool, err
+	}
+	s.Defer(func() { DestroyCommandPool(device, commandPool) })
+	return commandPool, nil
+}
+
+// CreateSemaphore creates a semaphore that is destroyed when the scope closes
+func (s *ResourceScope) CreateSemaphore(device Device, createInfo *SemaphoreCreateInfo) (Semaphore, error) {
+	semaphore, err := CreateSemaphore(device, createInfo)
+	if err != nil {
+		return semaphore, err
+	}
+	s.Defer(func() { DestroySemaphore(device, semaphore) })
+	return semaphore, nil
+}
+
+// CreateFence creates a fence that is destroyed when the scope closes
+func (s *ResourceScope) CreateFence(device Device, createInfo *FenceCreateInfo) (Fence, error) {
+	fence, err := CreateFence(device, createInfo)
+	if err != nil {
+		return fence, err
+	}
+	s.Defer(func() { DestroyFence(device, fence) })
+	return fence, nil
+}
+
+// NewFencePool creates a fence pool whose fences are destroyed when the scope closes
+func (s *ResourceScope) NewFencePool(device Device) (*FencePool, error) {
+	pool, err := NewFencePool(device)
+	if err != nil {
+		return nil, err
+	}
+	s.Defer(pool.Destroy)
+	return pool, nil
+}
+
+// NewAllocator creates a sub-allocator that is destroyed when the scope closes.
+// Allocations made from it should be freed before the scope closes.
+func (s *ResourceScope) NewAllocator(device Device, physicalDevice PhysicalDevice) (*Allocator, error) {
+	allocator, err := NewAllocator(device, physicalDevice)
+	if err != nil {
+		return nil, err
+	}
+	s.Defer(allocator.Destroy)
+	return allocator, nil
+}
@@ -0,0 +1,49 @@
+//go:build cgo
+
+package vulkan
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// TestResourceScopeCloseOrder tests that cleanups run once, in reverse registration order
+func TestResourceScopeCloseOrder(t *testing.T) {
+	scope := NewResourceScope()
+
+	var order []int
+	for i := 0; i < 3; i++ {
+		i := i
+		scope.Defer(func() { order = append(order, i) })
+	}
+
+	scope.Close()
+	scope.Close()
+
+	if expected := []int{2, 1, 0}; !reflect.DeepEqual(order, expected) {
+		t.Errorf("Expected cleanup order %v, got %v", expected, order)
+	}
+
+	// Cleanups registered after Close run immediately
+	scope.Defer(func() { order = append(order, 3) })
+	if len(order) != 4 || order[3] != 3 {
+		t.Errorf("Expected cleanup on closed scope to run immediately, got %v", order)
+	}
+}
+
+// TestResourceScopeFailedCreate tests that failed creations are not tracked
+func TestResourceScopeFailedCreate(t *testing.T) {
+	scope := NewResourceScope()
+
+	_, err := scope.CreateBuffer(nil, &BufferCreateInfo{Size: 1024})
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+	}
+
+	if len(scope.cleanups) != 0 {
+		t.Errorf("Expected no tracked objects after failed creation, got %d", len(scope.cleanups))
+	}
+	scope.Close()
+}
@@ -0,0 +1,274 @@
+package vulkan
+
+/*
+#include <vulkan/vulkan.h>
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"unsafe"
+)
+
+// SparseMemoryBindFlags represents sparse memory binding flags
+type SparseMemoryBindFlags uint32
+
+const (
+	SparseMemoryBindMetadataBit SparseMemoryBindFlags = C.VK_SPARSE_MEMORY_BIND_METADATA_BIT
+)
+
+// ImageSubresource identifies a single mip level and array layer of an image
+type ImageSubresource struct {
+	AspectMask ImageAspectFlags
+	MipLevel   uint32
+	ArrayLayer uint32
+}
+
+// SparseMemoryBind binds a range of device memory to a range of a sparse resource.
+// A nil Memory unbinds the range.
+type SparseMemoryBind struct {
+	ResourceOffset DeviceSize
+	Size           DeviceSize
+	Memory         DeviceMemory
+	MemoryOffset   DeviceSize
+	Flags          SparseMemoryBindFlags
+}
+
+// SparseBufferMemoryBindInfo contains the memory binds for a sparse buffer
+type SparseBufferMemoryBindInfo struct {
+	Buffer Buffer
+	Binds  []SparseMemoryBind
+}
+
+// SparseImageOpaqueMemoryBindInfo contains the opaque memory binds for a sparse image
+type SparseImageOpaqueMemoryBindInfo struct {
+	Image Image
+	Binds []SparseMemoryBind
+}
+
+// SparseImageMemoryBind binds device memory to a region of a sparse resident image
+type SparseImageMemoryBind struct {
+	Subresource  ImageSubresource
+	Offset       Offset3D
+	Extent       Extent3D
+	Memory       DeviceMemory
+	MemoryOffset DeviceSize
+	Flags        SparseMemoryBindFlags
+}
+
+// SparseImageMemoryBindInfo contains the region memory binds for a sparse resident image
+type SparseImageMemoryBindInfo struct {
+	Image Image
+	Binds []SparseImageMemoryBind
+}
+
+// BindSparseInfo describes one batch of sparse binding operations
+type BindSparseInfo struct {
+	WaitSemaphores   []Semaphore
+	BufferBinds      []SparseBufferMemoryBindInfo
+	ImageOpaqueBinds []SparseImageOpaqueMemoryBindInfo
+	ImageBinds       []SparseImageMemoryBindInfo
+	SignalSemaphores []Semaphore
+}
+
+// sparseMemoryBindsToC copies binds into C memory
+func sparseMemoryBindsToC(binds []SparseMemoryBind, allocations *[]unsafe.Pointer) (*C.VkSparseMemoryBind, error) {
+	cBindsPtr := (*C.VkSparseMemoryBind)(C.calloc(C.size_t(len(binds)), C.sizeof_VkSparseMemoryBind))
+	if cBindsPtr == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "QueueBindSparse", "failed to allocate memory for sparse memory binds")
+	}
+	*allocations = append(*allocations, unsafe.Pointer(cBindsPtr))
+
+	cBinds := unsafe.Slice(cBindsPtr, len(binds))
+	for i, bind := range binds {
+		if bind.Size == 0 {
+			return nil, NewValidationError("bindInfos", "sparse memory bind size must be greater than zero")
+		}
+		cBinds[i] = C.VkSparseMemoryBind{
+			resourceOffset: C.VkDeviceSize(bind.ResourceOffset),
+			size:           C.VkDeviceSize(bind.Size),
+			memory:         C.VkDeviceMemory(bind.Memory),
+			memoryOffset:   C.VkDeviceSize(bind.MemoryOffset),
+			flags:          C.VkSparseMemoryBindFlags(bind.Flags),
+		}
+	}
+	return cBindsPtr, nil
+}
+
+// semaphoresToC copies semaphore handles into C memory. operation names the calling command in
+// allocation errors.
+func semaphoresToC(operation string, semaphores []Semaphore, allocations *[]unsafe.Pointer) (*C.VkSemaphore, error) {
+	cSemaphoresPtr := (*C.VkSemaphore)(C.calloc(C.size_t(len(semaphores)), C.size_t(unsafe.Sizeof(C.VkSemaphore(nil)))))
+	if cSemaphoresPtr == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, operation, "failed to allocate memory for semaphores")
+	}
+	*allocations = append(*allocations, unsafe.Pointer(cSemaphoresPtr))
+
+	cSemaphores := unsafe.Slice(cSemaphoresPtr, len(semaphores))
+	for i, semaphore := range semaphores {
+		cSemaphores[i] = C.VkSemaphore(semaphore)
+	}
+	return cSemaphoresPtr, nil
+}
+
+// bindSparseInfoToC fills cInfo from info, allocating nested arrays in C memory
+func bindSparseInfoToC(info *BindSparseInfo, cInfo *C.VkBindSparseInfo, allocations *[]unsafe.Pointer) error {
+	cInfo.sType = C.VK_STRUCTURE_TYPE_BIND_SPARSE_INFO
+
+	if len(info.WaitSemaphores) > 0 {
+		cWaits, err := semaphoresToC("QueueBindSparse", info.WaitSemaphores, allocations)
+		if err != nil {
+			return err
+		}
+		cInfo.waitSemaphoreCount = C.uint32_t(len(info.WaitSemaphores))
+		cInfo.pWaitSemaphores = cWaits
+	}
+
+	if len(info.BufferBinds) > 0 {
+		cBufferBindsPtr := (*C.VkSparseBufferMemoryBindInfo)(C.calloc(C.size_t(len(info.BufferBinds)), C.sizeof_VkSparseBufferMemoryBindInfo))
+		if cBufferBindsPtr == nil {
+			return NewVulkanError(ErrorOutOfHostMemory, "QueueBindSparse", "failed to allocate memory for buffer binds")
+		}
+		*allocations = append(*allocations, unsafe.Pointer(cBufferBindsPtr))
+
+		cBufferBinds := unsafe.Slice(cBufferBindsPtr, len(info.BufferBinds))
+		for i, bufferBind := range info.BufferBinds {
+			if bufferBind.Buffer == nil {
+				return NewValidationError("bindInfos", "buffer bind buffer cannot be nil")
+			}
+			if len(bufferBind.Binds) == 0 {
+				return NewValidationError("bindInfos", "buffer bind must contain at least one memory bind")
+			}
+			cBinds, err := sparseMemoryBindsToC(bufferBind.Binds, allocations)
+			if err != nil {
+				return err
+			}
+			cBufferBinds[i].buffer = C.VkBuffer(bufferBind.Buffer)
+			cBufferBinds[i].bindCount = C.uint32_t(len(bufferBind.Binds))
+			cBufferBinds[i].pBinds = cBinds
+		}
+		cInfo.bufferBindCount = C.uint32_t(len(info.BufferBinds))
+		cInfo.pBufferBinds = cBufferBindsPtr
+	}
+
+	if len(info.ImageOpaqueBinds) > 0 {
+		cOpaqueBindsPtr := (*C.VkSparseImageOpaqueMemoryBindInfo)(C.calloc(C.size_t(len(info.ImageOpaqueBinds)), C.sizeof_VkSparseImageOpaqueMemoryBindInfo))
+		if cOpaqueBindsPtr == nil {
+			return NewVulkanError(ErrorOutOfHostMemory, "QueueBindSparse", "failed to allocate memory for image opaque binds")
+		}
+		*allocations = append(*allocations, unsafe.Pointer(cOpaqueBindsPtr))
+
+		cOpaqueBinds := unsafe.Slice(cOpaqueBindsPtr, len(info.ImageOpaqueBinds))
+		for i, opaqueBind := range info.ImageOpaqueBinds {
+			if opaqueBind.Image == nil {
+				return NewValidationError("bindInfos", "image opaque bind image cannot be nil")
+			}
+			if len(opaqueBind.Binds) == 0 {
+				return NewValidationError("bindInfos", "image opaque bind must contain at least one memory bind")
+			}
+			cBinds, err := sparseMemoryBindsToC(opaqueBind.Binds, allocations)
+			if err != nil {
+				return err
+			}
+			cOpaqueBinds[i].image = C.VkImage(opaqueBind.Image)
+			cOpaqueBinds[i].bindCount = C.uint32_t(len(opaqueBind.Binds))
+			cOpaqueBinds[i].pBinds = cBinds
+		}
+		cInfo.imageOpaqueBindCount = C.uint32_t(len(info.ImageOpaqueBinds))
+		cInfo.pImageOpaqueBinds = cOpaqueBindsPtr
+	}
+
+	if len(info.ImageBinds) > 0 {
+		cImageBindsPtr := (*C.VkSparseImageMemoryBindInfo)(C.calloc(C.size_t(len(info.ImageBinds)), C.sizeof_VkSparseImageMemoryBindInfo))
+		if cImageBindsPtr == nil {
+			return NewVulkanError(ErrorOutOfHostMemory, "QueueBindSparse", "failed to allocate memory for image binds")
+		}
+		*allocations = append(*allocations, unsafe.Pointer(cImageBindsPtr))
+
+		cImageBinds := unsafe.Slice(cImageBindsPtr, len(info.ImageBinds))
+		for i, imageBind := range info.ImageBinds {
+			if imageBind.Image == nil {
+				return NewValidationError("bindInfos", "image bind image cannot be nil")
+			}
+			if len(imageBind.Binds) == 0 {
+				return NewValidationError("bindInfos", "image bind must contain at least one memory bind")
+			}
+
+			cBindsPtr := (*C.VkSparseImageMemoryBind)(C.calloc(C.size_t(len(imageBind.Binds)), C.sizeof_VkSparseImageMemoryBind))
+			if cBindsPtr == nil {
+				return NewVulkanError(ErrorOutOfHostMemory, "QueueBindSparse", "failed to allocate memory for sparse image memory binds")
+			}
+			*allocations = append(*allocations, unsafe.Pointer(cBindsPtr))
+
+			cBinds := unsafe.Slice(cBindsPtr, len(imageBind.Binds))
+			for j, bind := range imageBind.Binds {
+				cBinds[j] = C.VkSparseImageMemoryBind{
+					subresource: C.VkImageSubresource{
+						aspectMask: C.VkImageAspectFlags(bind.Subresource.AspectMask),
+						mipLevel:   C.uint32_t(bind.Subresource.MipLevel),
+						arrayLayer: C.uint32_t(bind.Subresource.ArrayLayer),
+					},
+					offset:       bind.Offset.toC(),
+					extent:       bind.Extent.toC(),
+					memory:       C.VkDeviceMemory(bind.Memory),
+					memoryOffset: C.VkDeviceSize(bind.MemoryOffset),
+					flags:        C.VkSparseMemoryBindFlags(bind.Flags),
+				}
+			}
+			cImageBinds[i].image = C.VkImage(imageBind.Image)
+			cImageBinds[i].bindCount = C.uint32_t(len(imageBind.Binds))
+			cImageBinds[i].pBinds = cBindsPtr
+		}
+		cInfo.imageBindCount = C.uint32_t(len(info.ImageBinds))
+		cInfo.pImageBinds = cImageBindsPtr
+	}
+
+	if len(info.SignalSemaphores) > 0 {
+		cSignals, err := semaphoresToC("QueueBindSparse", info.SignalSemaphores, allocations)
+		if err != nil {
+			return err
+		}
+		cInfo.signalSemaphoreCount = C.uint32_t(len(info.SignalSemaphores))
+		cInfo.pSignalSemaphores = cSignals
+	}
+	return nil
+}
+
+// QueueBindSparse submits sparse binding operations to a queue. The queue must belong to a
+// family reporting QueueSparseBindingBit. An empty bindInfos with a fence only signals the fence.
+func QueueBindSparse(queue Queue, bindInfos []BindSparseInfo, fence Fence) error {
+	if queue == nil {
+		return NewValidationError("queue", "cannot be nil")
+	}
+	if len(bindInfos) == 0 {
+		result := Result(C.vkQueueBindSparse(C.VkQueue(queue), 0, nil, C.VkFence(fence)))
+		traceResult("vkQueueBindSparse", result)
+		if result != Success {
+			return NewVulkanError(result, "QueueBindSparse", "failed to signal fence")
+		}
+		return nil
+	}
+
+	var allocations []unsafe.Pointer
+	defer func() { freeAllocations(allocations) }()
+
+	cInfosPtr := (*C.VkBindSparseInfo)(C.calloc(C.size_t(len(bindInfos)), C.sizeof_VkBindSparseInfo))
+	if cInfosPtr == nil {
+		return NewVulkanError(ErrorOutOfHostMemory, "QueueBindSparse", "failed to allocate memory for bind infos")
+	}
+	allocations = append(allocations, unsafe.Pointer(cInfosPtr))
+
+	cInfos := unsafe.Slice(cInfosPtr, len(bindInfos))
+	for i := range bindInfos {
+		if err := bindSparseInfoToC(&bindInfos[i], &cInfos[i], &allocations); err != nil {
+			return err
+		}
+	}
+
+	result := Result(C.vkQueueBindSparse(C.VkQueue(queue), C.uint32_t(len(bindInfos)), cInfosPtr, C.VkFence(fence)))
+	traceResult("vkQueueBindSparse", result)
+	if result != Success {
+		return NewVulkanError(result, "QueueBindSparse", "failed to bind sparse memory")
+	}
+	return nil
+}
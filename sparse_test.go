@@ -0,0 +1,124 @@
+//go:build cgo
+
+package vulkan
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestQueueBindSparseValidation tests input validation for QueueBindSparse
+func TestQueueBindSparseValidation(t *testing.T) {
+	fakeQueue := Queue(uintptr(0x1234))
+	fakeBuffer := Buffer(uintptr(0x5678))
+
+	tests := []struct {
+		name       string
+		queue      Queue
+		bindInfos  []BindSparseInfo
+		errorParam string
+	}{
+		{
+			name:       "nil queue",
+			queue:      nil,
+			bindInfos:  nil,
+			errorParam: "queue",
+		},
+		{
+			name:  "nil buffer",
+			queue: fakeQueue,
+			bindInfos: []BindSparseInfo{{
+				BufferBinds: []SparseBufferMemoryBindInfo{{Binds: []SparseMemoryBind{{Size: 65536}}}},
+			}},
+			errorParam: "bindInfos",
+		},
+		{
+			name:  "empty buffer binds",
+			queue: fakeQueue,
+			bindInfos: []BindSparseInfo{{
+				BufferBinds: []SparseBufferMemoryBindInfo{{Buffer: fakeBuffer}},
+			}},
+			errorParam: "bindInfos",
+		},
+		{
+			name:  "zero size bind",
+			queue: fakeQueue,
+			bindInfos: []BindSparseInfo{{
+				BufferBinds: []SparseBufferMemoryBindInfo{{Buffer: fakeBuffer, Binds: []SparseMemoryBind{{}}}},
+			}},
+			errorParam: "bindInfos",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := QueueBindSparse(tt.queue, tt.bindInfos, nil)
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+}
+
+// TestCreateSparseBufferValidation tests that sparse buffer flags are checked against the enabled device features
+func TestCreateSparseBufferValidation(t *testing.T) {
+	fakeDevice := Device(uintptr(0x1234))
+	deviceEnabledFeatures.Store(fakeDevice, PhysicalDeviceFeatures{SparseBinding: true, SparseResidencyBuffer: true})
+	defer deviceEnabledFeatures.Delete(fakeDevice)
+	noSparseDevice := Device(uintptr(0x5678))
+	deviceEnabledFeatures.Store(noSparseDevice, PhysicalDeviceFeatures{})
+	defer deviceEnabledFeatures.Delete(noSparseDevice)
+
+	tests := []struct {
+		name   string
+		device Device
+		flags  BufferCreateFlags
+	}{
+		{
+			name:   "residency without sparse binding",
+			device: fakeDevice,
+			flags:  BufferCreateSparseResidencyBit,
+		},
+		{
+			name:   "sparse binding feature not enabled",
+			device: noSparseDevice,
+			flags:  BufferCreateSparseBindingBit,
+		},
+		{
+			name:   "aliased feature not enabled",
+			device: fakeDevice,
+			flags:  BufferCreateSparseBindingBit | BufferCreateSparseAliasedBit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CreateBuffer(tt.device, &BufferCreateInfo{
+				Flags: tt.flags,
+				Size:  65536,
+				Usage: BufferUsageStorageBufferBit,
+			})
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != "Flags" {
+				t.Errorf("Expected error for parameter 'Flags', got '%s'", validationErr.Parameter)
+			}
+		})
+	}
+
+	// Devices not created through CreateDevice only get the flag combination checked
+	if err := validateSparseBufferFlags(Device(uintptr(0x9abc)), BufferCreateSparseBindingBit|BufferCreateSparseAliasedBit); err != nil {
+		t.Errorf("Expected no error for an untracked device, got %v", err)
+	}
+	if err := validateSparseBufferFlags(fakeDevice, BufferCreateSparseBindingBit|BufferCreateSparseResidencyBit); err != nil {
+		t.Errorf("Expected no error with the sparse features enabled, got %v", err)
+	}
+}
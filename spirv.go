@@ -0,0 +1,494 @@
+package vulkan
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+)
+
+// SPIR-V constants used by shader reflection. Values are from the SPIR-V specification.
+const (
+	spirvMagic = 0x07230203
+
+	spirvOpEntryPoint                   = 15
+	spirvOpTypeInt                      = 21
+	spirvOpTypeFloat                    = 22
+	spirvOpTypeVector                   = 23
+	spirvOpTypeMatrix                   = 24
+	spirvOpTypeImage                    = 25
+	spirvOpTypeSampler                  = 26
+	spirvOpTypeSampledImage             = 27
+	spirvOpTypeArray                    = 28
+	spirvOpTypeRuntimeArray             = 29
+	spirvOpTypeStruct                   = 30
+	spirvOpTypePointer                  = 32
+	spirvOpConstant                     = 43
+	spirvOpSpecConstant                 = 50
+	spirvOpVariable                     = 59
+	spirvOpDecorate                     = 71
+	spirvOpMemberDecorate               = 72
+	spirvOpTypeAccelerationStructureKHR = 5341
+
+	spirvDecorationBlock         = 2
+	spirvDecorationBufferBlock   = 3
+	spirvDecorationRowMajor      = 4
+	spirvDecorationArrayStride   = 6
+	spirvDecorationMatrixStride  = 7
+	spirvDecorationBinding       = 33
+	spirvDecorationDescriptorSet = 34
+	spirvDecorationOffset        = 35
+
+	spirvStorageClassUniformConstant = 0
+	spirvStorageClassUniform         = 2
+	spirvStorageClassFunction        = 7
+	spirvStorageClassPushConstant    = 9
+	spirvStorageClassStorageBuffer   = 12
+
+	spirvDimBuffer      = 5
+	spirvDimSubpassData = 6
+)
+
+// spirvExecutionModelStages maps SPIR-V execution models to the shader stage they run in
+var spirvExecutionModelStages = map[uint32]ShaderStageFlags{
+	0:    ShaderStageVertexBit,
+	1:    ShaderStageTessellationControlBit,
+	2:    ShaderStageTessellationEvaluationBit,
+	3:    ShaderStageGeometryBit,
+	4:    ShaderStageFragmentBit,
+	5:    ShaderStageComputeBit,
+	5267: ShaderStageTaskBitEXT, // TaskNV
+	5268: ShaderStageMeshBitEXT, // MeshNV
+	5313: ShaderStageRaygenBitKHR,
+	5314: ShaderStageIntersectionBitKHR,
+	5315: ShaderStageAnyHitBitKHR,
+	5316: ShaderStageClosestHitBitKHR,
+	5317: ShaderStageMissBitKHR,
+	5318: ShaderStageCallableBitKHR,
+	5364: ShaderStageTaskBitEXT,
+	5365: ShaderStageMeshBitEXT,
+}
+
+// spirvType is a type declaration; operands holds the instruction words after the result id
+type spirvType struct {
+	opcode   uint32
+	operands []uint32
+}
+
+// spirvMemberDecorations holds the layout decorations of one struct member
+type spirvMemberDecorations struct {
+	offset       uint32
+	hasOffset    bool
+	matrixStride uint32
+	rowMajor     bool
+}
+
+// spirvVariable is a module-scope OpVariable
+type spirvVariable struct {
+	pointerType  uint32
+	storageClass uint32
+}
+
+// spirvModule holds the parts of a SPIR-V module needed to reflect its resource interface
+type spirvModule struct {
+	stages            ShaderStageFlags
+	types             map[uint32]spirvType
+	constants         map[uint32]uint32
+	decorations       map[uint32]map[uint32]uint32
+	memberDecorations map[uint32]map[uint32]*spirvMemberDecorations
+	variables         map[uint32]spirvVariable
+	variableOrder     []uint32
+	// structSizes caches computed struct sizes, so structs shared by several members are
+	// only walked once
+	structSizes map[uint32]uint32
+}
+
+// ReflectShaderBindings extracts the descriptor bindings and push constant range declared by
+// a SPIR-V module, ready for DescriptorSetLayoutCreateInfo and PipelineLayoutCreateInfo.
+//
+// Every binding and the push constant range use the stages of all entry points in the module.
+// The module must declare its descriptors in a single set; use ReflectShaderSetBindings for
+// shaders that use several sets. Runtime-sized descriptor arrays are reported with a
+// DescriptorCount of 0, which the caller must replace with the intended count.
+func ReflectShaderBindings(spirv []byte) ([]DescriptorSetLayoutBinding, []PushConstantRange, error) {
+	sets, pushConstants, err := ReflectShaderSetBindings(spirv)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(sets) > 1 {
+		return nil, nil, NewValidationError("spirv", fmt.Sprintf("shader uses %d descriptor sets; use ReflectShaderSetBindings", len(sets)))
+	}
+	for _, bindings := range sets {
+		return bindings, pushConstants, nil
+	}
+	return nil, pushConstants, nil
+}
+
+// ReflectShaderSetBindings is like ReflectShaderBindings but returns the descriptor bindings
+// of every set, keyed by set number. Bindings within a set are sorted by binding number.
+func ReflectShaderSetBindings(spirv []byte) (map[uint32][]DescriptorSetLayoutBinding, []PushConstantRange, error) {
+	module, err := parseSpirvModule(spirv)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sets := make(map[uint32][]DescriptorSetLayoutBinding)
+	var pushConstants []PushConstantRange
+	for _, id := range module.variableOrder {
+		variable := module.variables[id]
+		pointer, ok := module.types[variable.pointerType]
+		if !ok || pointer.opcode != spirvOpTypePointer || len(pointer.operands) < 2 {
+			return nil, nil, NewValidationError("spirv", fmt.Sprintf("variable %%%d does not have a pointer type", id))
+		}
+		pointee := pointer.operands[1]
+
+		if variable.storageClass == spirvStorageClassPushConstant {
+			offset, size, err := module.pushConstantRange(pointee)
+			if err != nil {
+				return nil, nil, err
+			}
+			pushConstants = mergePushConstantRange(pushConstants, PushConstantRange{
+				StageFlags: module.stages,
+				Offset:     offset,
+				Size:       size,
+			})
+			continue
+		}
+
+		set, hasSet := module.decorations[id][spirvDecorationDescriptorSet]
+		binding, hasBinding := module.decorations[id][spirvDecorationBinding]
+		if !hasSet || !hasBinding {
+			continue
+		}
+
+		descriptorType, count, err := module.descriptorType(pointee, variable.storageClass)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		duplicate := false
+		for i, existing := range sets[set] {
+			if existing.Binding != binding {
+				continue
+			}
+			if existing.DescriptorType != descriptorType {
+				return nil, nil, NewValidationError("spirv", fmt.Sprintf("set %d binding %d is declared with different descriptor types", set, binding))
+			}
+			sets[set][i].DescriptorCount = max(existing.DescriptorCount, count)
+			duplicate = true
+		}
+		if !duplicate {
+			sets[set] = append(sets[set], DescriptorSetLayoutBinding{
+				Binding:         binding,
+				DescriptorType:  descriptorType,
+				DescriptorCount: count,
+				StageFlags:      module.stages,
+			})
+		}
+	}
+
+	for _, bindings := range sets {
+		sort.Slice(bindings, func(i, j int) bool { return bindings[i].Binding < bindings[j].Binding })
+	}
+	return sets, pushConstants, nil
+}
+
+// mergePushConstantRange widens the single range in ranges to also cover r. A module has at
+// most one push constant block per entry point, and blocks of different entry points share
+// the same push constant memory.
+func mergePushConstantRange(ranges []PushConstantRange, r PushConstantRange) []PushConstantRange {
+	if len(ranges) == 0 {
+		return []PushConstantRange{r}
+	}
+	start := min(ranges[0].Offset, r.Offset)
+	end := max(ranges[0].Offset+ranges[0].Size, r.Offset+r.Size)
+	ranges[0].Offset = start
+	ranges[0].Size = end - start
+	return ranges
+}
+
+// parseSpirvModule decodes the instructions of a SPIR-V binary in either byte order
+func parseSpirvModule(spirv []byte) (*spirvModule, error) {
+	if len(spirv) < 20 || len(spirv)%4 != 0 {
+		return nil, NewValidationError("spirv", "must be a whole number of 32-bit words with a 5-word header")
+	}
+
+	var order binary.ByteOrder = binary.LittleEndian
+	if order.Uint32(spirv) != spirvMagic {
+		order = binary.BigEndian
+		if order.Uint32(spirv) != spirvMagic {
+			return nil, NewValidationError("spirv", "missing SPIR-V magic number")
+		}
+	}
+	words := make([]uint32, len(spirv)/4)
+	for i := range words {
+		words[i] = order.Uint32(spirv[i*4:])
+	}
+
+	module := &spirvModule{
+		types:             make(map[uint32]spirvType),
+		constants:         make(map[uint32]uint32),
+		decorations:       make(map[uint32]map[uint32]uint32),
+		memberDecorations: make(map[uint32]map[uint32]*spirvMemberDecorations),
+		variables:         make(map[uint32]spirvVariable),
+		structSizes:       make(map[uint32]uint32),
+	}
+
+	for pos := 5; pos < len(words); {
+		wordCount := int(words[pos] >> 16)
+		opcode := words[pos] & 0xffff
+		if wordCount == 0 || pos+wordCount > len(words) {
+			return nil, NewValidationError("spirv", fmt.Sprintf("malformed instruction at word %d", pos))
+		}
+		operands := words[pos+1 : pos+wordCount]
+		pos += wordCount
+
+		switch opcode {
+		case spirvOpEntryPoint:
+			if len(operands) >= 1 {
+				module.stages |= spirvExecutionModelStages[operands[0]]
+			}
+		case spirvOpDecorate:
+			if len(operands) >= 2 {
+				if module.decorations[operands[0]] == nil {
+					module.decorations[operands[0]] = make(map[uint32]uint32)
+				}
+				var value uint32
+				if len(operands) >= 3 {
+					value = operands[2]
+				}
+				module.decorations[operands[0]][operands[1]] = value
+			}
+		case spirvOpMemberDecorate:
+			if len(operands) >= 3 {
+				module.decorateMember(operands)
+			}
+		case spirvOpTypeInt, spirvOpTypeFloat, spirvOpTypeVector, spirvOpTypeMatrix,
+			spirvOpTypeImage, spirvOpTypeSampler, spirvOpTypeSampledImage, spirvOpTypeArray,
+			spirvOpTypeRuntimeArray, spirvOpTypeStruct, spirvOpTypePointer,
+			spirvOpTypeAccelerationStructureKHR:
+			if len(operands) >= 1 {
+				module.types[operands[0]] = spirvType{opcode: opcode, operands: operands[1:]}
+			}
+		case spirvOpConstant, spirvOpSpecConstant:
+			if len(operands) >= 3 {
+				module.constants[operands[1]] = operands[2]
+			}
+		case spirvOpVariable:
+			// Function-scope variables never appear in the resource interface
+			if len(operands) >= 3 && operands[2] != spirvStorageClassFunction {
+				module.variables[operands[1]] = spirvVariable{pointerType: operands[0], storageClass: operands[2]}
+				module.variableOrder = append(module.variableOrder, operands[1])
+			}
+		}
+	}
+
+	if module.stages == 0 {
+		return nil, NewValidationError("spirv", "module has no supported entry point")
+	}
+	return module, nil
+}
+
+// decorateMember records the layout decorations of an OpMemberDecorate instruction
+func (m *spirvModule) decorateMember(operands []uint32) {
+	structID, member, decoration := operands[0], operands[1], operands[2]
+	if m.memberDecorations[structID] == nil {
+		m.memberDecorations[structID] = make(map[uint32]*spirvMemberDecorations)
+	}
+	decorations := m.memberDecorations[structID][member]
+	if decorations == nil {
+		decorations = &spirvMemberDecorations{}
+		m.memberDecorations[structID][member] = decorations
+	}
+
+	switch decoration {
+	case spirvDecorationOffset:
+		if len(operands) >= 4 {
+			decorations.offset = operands[3]
+			decorations.hasOffset = true
+		}
+	case spirvDecorationMatrixStride:
+		if len(operands) >= 4 {
+			decorations.matrixStride = operands[3]
+		}
+	case spirvDecorationRowMajor:
+		decorations.rowMajor = true
+	}
+}
+
+// descriptorType returns the descriptor type and count of a resource variable whose pointer
+// points to typeID
+func (m *spirvModule) descriptorType(typeID, storageClass uint32) (DescriptorType, uint32, error) {
+	count := uint32(1)
+	visited := make(map[uint32]bool)
+	t, ok := m.types[typeID]
+	for ok && (t.opcode == spirvOpTypeArray || t.opcode == spirvOpTypeRuntimeArray) {
+		if visited[typeID] {
+			return 0, 0, NewValidationError("spirv", fmt.Sprintf("array type %%%d contains itself", typeID))
+		}
+		visited[typeID] = true
+
+		if t.opcode == spirvOpTypeRuntimeArray {
+			if len(t.operands) < 1 {
+				return 0, 0, NewValidationError("spirv", fmt.Sprintf("malformed runtime array type %%%d", typeID))
+			}
+			count = 0
+		} else {
+			if len(t.operands) < 2 {
+				return 0, 0, NewValidationError("spirv", fmt.Sprintf("malformed array type %%%d", typeID))
+			}
+			count *= m.constants[t.operands[1]]
+		}
+		typeID = t.operands[0]
+		t, ok = m.types[typeID]
+	}
+	if !ok {
+		return 0, 0, NewValidationError("spirv", fmt.Sprintf("undeclared type %%%d", typeID))
+	}
+
+	switch storageClass {
+	case spirvStorageClassUniform:
+		if _, bufferBlock := m.decorations[typeID][spirvDecorationBufferBlock]; bufferBlock {
+			return DescriptorTypeStorageBuffer, count, nil
+		}
+		return DescriptorTypeUniformBuffer, count, nil
+	case spirvStorageClassStorageBuffer:
+		return DescriptorTypeStorageBuffer, count, nil
+	case spirvStorageClassUniformConstant:
+		switch t.opcode {
+		case spirvOpTypeSampler:
+			return DescriptorTypeSampler, count, nil
+		case spirvOpTypeSampledImage:
+			return DescriptorTypeCombinedImageSampler, count, nil
+		case spirvOpTypeAccelerationStructureKHR:
+			return DescriptorTypeAccelerationStructureKHR, count, nil
+		case spirvOpTypeImage:
+			if len(t.operands) < 6 {
+				return 0, 0, NewValidationError("spirv", fmt.Sprintf("malformed image type %%%d", typeID))
+			}
+			dim, sampled := t.operands[1], t.operands[5]
+			switch {
+			case dim == spirvDimSubpassData:
+				return DescriptorTypeInputAttachment, count, nil
+			case dim == spirvDimBuffer && sampled == 2:
+				return DescriptorTypeStorageTexelBuffer, count, nil
+			case dim == spirvDimBuffer:
+				return DescriptorTypeUniformTexelBuffer, count, nil
+			case sampled == 2:
+				return DescriptorTypeStorageImage, count, nil
+			default:
+				return DescriptorTypeSampledImage, count, nil
+			}
+		}
+	}
+	return 0, 0, NewValidationError("spirv", fmt.Sprintf("unsupported resource type %%%d in storage class %d", typeID, storageClass))
+}
+
+// pushConstantRange returns the offset and size in bytes covered by the members of a push
+// constant block, rounded up to a multiple of 4
+func (m *spirvModule) pushConstantRange(structID uint32) (uint32, uint32, error) {
+	t, ok := m.types[structID]
+	if !ok || t.opcode != spirvOpTypeStruct {
+		return 0, 0, NewValidationError("spirv", fmt.Sprintf("push constant type %%%d is not a struct", structID))
+	}
+	if len(t.operands) == 0 {
+		return 0, 0, nil
+	}
+
+	start := ^uint32(0)
+	var end uint32
+	for member, memberType := range t.operands {
+		decorations := m.memberDecorations[structID][uint32(member)]
+		if decorations == nil || !decorations.hasOffset {
+			return 0, 0, NewValidationError("spirv", fmt.Sprintf("push constant member %d has no Offset decoration", member))
+		}
+		size, err := m.typeSize(memberType, decorations, map[uint32]bool{structID: true})
+		if err != nil {
+			return 0, 0, err
+		}
+		start = min(start, decorations.offset)
+		end = max(end, decorations.offset+size)
+	}
+	start &^= 3
+	end = (end + 3) &^ 3
+	return start, end - start, nil
+}
+
+// typeSize returns the size in bytes of an explicitly laid out type. decorations are the
+// member decorations when the type is a struct member, and carry the matrix layout. path
+// holds the types being sized that enclose typeID, so cyclic declarations in a malformed
+// module are reported instead of recursing forever.
+func (m *spirvModule) typeSize(typeID uint32, decorations *spirvMemberDecorations, path map[uint32]bool) (uint32, error) {
+	if path[typeID] {
+		return 0, NewValidationError("spirv", fmt.Sprintf("type %%%d contains itself", typeID))
+	}
+	path[typeID] = true
+	defer delete(path, typeID)
+
+	t, ok := m.types[typeID]
+	if !ok {
+		return 0, NewValidationError("spirv", fmt.Sprintf("undeclared type %%%d", typeID))
+	}
+	malformed := NewValidationError("spirv", fmt.Sprintf("malformed type %%%d", typeID))
+
+	switch t.opcode {
+	case spirvOpTypeInt, spirvOpTypeFloat:
+		if len(t.operands) < 1 {
+			return 0, malformed
+		}
+		return t.operands[0] / 8, nil
+	case spirvOpTypeVector:
+		if len(t.operands) < 2 {
+			return 0, malformed
+		}
+		componentSize, err := m.typeSize(t.operands[0], nil, path)
+		return t.operands[1] * componentSize, err
+	case spirvOpTypeMatrix:
+		if len(t.operands) < 2 {
+			return 0, malformed
+		}
+		columnType, columns := t.operands[0], t.operands[1]
+		if decorations != nil && decorations.matrixStride != 0 {
+			if decorations.rowMajor {
+				column, ok := m.types[columnType]
+				if !ok || len(column.operands) < 2 {
+					return 0, malformed
+				}
+				return column.operands[1] * decorations.matrixStride, nil
+			}
+			return columns * decorations.matrixStride, nil
+		}
+		columnSize, err := m.typeSize(columnType, nil, path)
+		return columns * columnSize, err
+	case spirvOpTypeArray:
+		if len(t.operands) < 2 {
+			return 0, malformed
+		}
+		length := m.constants[t.operands[1]]
+		if stride, ok := m.decorations[typeID][spirvDecorationArrayStride]; ok {
+			return length * stride, nil
+		}
+		elementSize, err := m.typeSize(t.operands[0], decorations, path)
+		return length * elementSize, err
+	case spirvOpTypeRuntimeArray:
+		return 0, nil
+	case spirvOpTypeStruct:
+		if size, ok := m.structSizes[typeID]; ok {
+			return size, nil
+		}
+		var size uint32
+		for member, memberType := range t.operands {
+			memberDecorations := m.memberDecorations[typeID][uint32(member)]
+			if memberDecorations == nil || !memberDecorations.hasOffset {
+				return 0, NewValidationError("spirv", fmt.Sprintf("struct %%%d member %d has no Offset decoration", typeID, member))
+			}
+			memberSize, err := m.typeSize(memberType, memberDecorations, path)
+			if err != nil {
+				return 0, err
+			}
+			size = max(size, memberDecorations.offset+memberSize)
+		}
+		m.structSizes[typeID] = size
+		return size, nil
+	}
+	return 0, NewValidationError("spirv", fmt.Sprintf("type %%%d cannot be used in a push constant block", typeID))
+}
@@ -0,0 +1,191 @@
+package vulkan
+
+import (
+	"encoding/binary"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// spirvInstruction encodes one SPIR-V instruction
+func spirvInstruction(opcode uint32, operands ...uint32) []uint32 {
+	return append([]uint32{uint32(len(operands)+1)<<16 | opcode}, operands...)
+}
+
+// spirvBinary encodes a module header followed by instructions as little-endian bytes
+func spirvBinary(instructions ...[]uint32) []byte {
+	words := []uint32{spirvMagic, 0x00010000, 0, 64, 0}
+	for _, instruction := range instructions {
+		words = append(words, instruction...)
+	}
+	spirv := make([]byte, len(words)*4)
+	for i, word := range words {
+		binary.LittleEndian.PutUint32(spirv[i*4:], word)
+	}
+	return spirv
+}
+
+// TestReflectShaderBindings tests descriptor and push constant reflection of a hand-built
+// module with a uniform buffer, a combined image sampler array, a storage buffer in a second
+// set and a push constant block
+func TestReflectShaderBindings(t *testing.T) {
+	const mainName = 0x6e69616d // "main"
+	spirv := spirvBinary(
+		spirvInstruction(spirvOpEntryPoint, 0, 1, mainName, 0),
+		spirvInstruction(spirvOpEntryPoint, 4, 1, mainName, 0),
+		spirvInstruction(spirvOpDecorate, 5, spirvDecorationBlock),
+		spirvInstruction(spirvOpMemberDecorate, 5, 0, spirvDecorationOffset, 0),
+		spirvInstruction(spirvOpMemberDecorate, 5, 0, spirvDecorationMatrixStride, 16),
+		spirvInstruction(spirvOpDecorate, 7, spirvDecorationDescriptorSet, 0),
+		spirvInstruction(spirvOpDecorate, 7, spirvDecorationBinding, 0),
+		spirvInstruction(spirvOpDecorate, 14, spirvDecorationDescriptorSet, 0),
+		spirvInstruction(spirvOpDecorate, 14, spirvDecorationBinding, 2),
+		spirvInstruction(spirvOpDecorate, 15, spirvDecorationBlock),
+		spirvInstruction(spirvOpMemberDecorate, 15, 0, spirvDecorationOffset, 0),
+		spirvInstruction(spirvOpMemberDecorate, 15, 1, spirvDecorationOffset, 16),
+		spirvInstruction(spirvOpDecorate, 18, spirvDecorationArrayStride, 4),
+		spirvInstruction(spirvOpDecorate, 19, spirvDecorationBlock),
+		spirvInstruction(spirvOpMemberDecorate, 19, 0, spirvDecorationOffset, 0),
+		spirvInstruction(spirvOpDecorate, 21, spirvDecorationDescriptorSet, 1),
+		spirvInstruction(spirvOpDecorate, 21, spirvDecorationBinding, 0),
+		spirvInstruction(spirvOpTypeFloat, 2, 32),
+		spirvInstruction(spirvOpTypeVector, 3, 2, 4),
+		spirvInstruction(spirvOpTypeMatrix, 4, 3, 4),
+		spirvInstruction(spirvOpTypeStruct, 5, 4),
+		spirvInstruction(spirvOpTypePointer, 6, spirvStorageClassUniform, 5),
+		spirvInstruction(spirvOpVariable, 6, 7, spirvStorageClassUniform),
+		spirvInstruction(spirvOpTypeImage, 8, 2, 1, 0, 0, 0, 1, 0),
+		spirvInstruction(spirvOpTypeSampledImage, 9, 8),
+		spirvInstruction(spirvOpTypeInt, 10, 32, 0),
+		spirvInstruction(spirvOpConstant, 10, 11, 4),
+		spirvInstruction(spirvOpTypeArray, 12, 9, 11),
+		spirvInstruction(spirvOpTypePointer, 13, spirvStorageClassUniformConstant, 12),
+		spirvInstruction(spirvOpVariable, 13, 14, spirvStorageClassUniformConstant),
+		spirvInstruction(spirvOpTypeStruct, 15, 3, 2),
+		spirvInstruction(spirvOpTypePointer, 16, spirvStorageClassPushConstant, 15),
+		spirvInstruction(spirvOpVariable, 16, 17, spirvStorageClassPushConstant),
+		spirvInstruction(spirvOpTypeRuntimeArray, 18, 2),
+		spirvInstruction(spirvOpTypeStruct, 19, 18),
+		spirvInstruction(spirvOpTypePointer, 20, spirvStorageClassStorageBuffer, 19),
+		spirvInstruction(spirvOpVariable, 20, 21, spirvStorageClassStorageBuffer),
+	)
+
+	stages := ShaderStageVertexBit | ShaderStageFragmentBit
+	sets, pushConstants, err := ReflectShaderSetBindings(spirv)
+	if err != nil {
+		t.Fatalf("ReflectShaderSetBindings failed: %v", err)
+	}
+
+	expectedSets := map[uint32][]DescriptorSetLayoutBinding{
+		0: {
+			{Binding: 0, DescriptorType: DescriptorTypeUniformBuffer, DescriptorCount: 1, StageFlags: stages},
+			{Binding: 2, DescriptorType: DescriptorTypeCombinedImageSampler, DescriptorCount: 4, StageFlags: stages},
+		},
+		1: {
+			{Binding: 0, DescriptorType: DescriptorTypeStorageBuffer, DescriptorCount: 1, StageFlags: stages},
+		},
+	}
+	if !reflect.DeepEqual(sets, expectedSets) {
+		t.Errorf("Expected sets %+v, got %+v", expectedSets, sets)
+	}
+
+	expectedPushConstants := []PushConstantRange{{StageFlags: stages, Offset: 0, Size: 20}}
+	if !reflect.DeepEqual(pushConstants, expectedPushConstants) {
+		t.Errorf("Expected push constants %+v, got %+v", expectedPushConstants, pushConstants)
+	}
+
+	// The single-set helper refuses to flatten several sets
+	var validationErr *ValidationError
+	if _, _, err := ReflectShaderBindings(spirv); !errors.As(err, &validationErr) || validationErr.Parameter != "spirv" {
+		t.Errorf("Expected ValidationError for multiple sets, got %v", err)
+	}
+}
+
+// TestReflectShaderBindingsValidation tests rejection of malformed modules
+func TestReflectShaderBindingsValidation(t *testing.T) {
+	badMagic := spirvBinary()
+	badMagic[0] = 0
+
+	// resource declares a descriptor variable %12 whose pointer points to type %10
+	entryPoint := spirvInstruction(spirvOpEntryPoint, 5, 1, 0x6e69616d, 0)
+	resource := func(types ...[]uint32) []byte {
+		instructions := [][]uint32{
+			entryPoint,
+			spirvInstruction(spirvOpDecorate, 12, spirvDecorationDescriptorSet, 0),
+			spirvInstruction(spirvOpDecorate, 12, spirvDecorationBinding, 0),
+			spirvInstruction(spirvOpTypeInt, 2, 32, 0),
+			spirvInstruction(spirvOpConstant, 2, 3, 4),
+		}
+		instructions = append(instructions, types...)
+		instructions = append(instructions,
+			spirvInstruction(spirvOpTypePointer, 11, spirvStorageClassUniformConstant, 10),
+			spirvInstruction(spirvOpVariable, 11, 12, spirvStorageClassUniformConstant),
+		)
+		return spirvBinary(instructions...)
+	}
+	// pushConstant declares a push constant block of struct type %10
+	pushConstant := func(types ...[]uint32) []byte {
+		instructions := [][]uint32{
+			entryPoint,
+			spirvInstruction(spirvOpMemberDecorate, 10, 0, spirvDecorationOffset, 0),
+			spirvInstruction(spirvOpMemberDecorate, 10, 1, spirvDecorationOffset, 16),
+			spirvInstruction(spirvOpMemberDecorate, 10, 2, spirvDecorationOffset, 32),
+			spirvInstruction(spirvOpMemberDecorate, 20, 0, spirvDecorationOffset, 0),
+		}
+		instructions = append(instructions, types...)
+		instructions = append(instructions,
+			spirvInstruction(spirvOpTypePointer, 11, spirvStorageClassPushConstant, 10),
+			spirvInstruction(spirvOpVariable, 11, 12, spirvStorageClassPushConstant),
+		)
+		return spirvBinary(instructions...)
+	}
+
+	tests := []struct {
+		name  string
+		spirv []byte
+	}{
+		{name: "empty", spirv: nil},
+		{name: "partial word", spirv: spirvBinary()[:18]},
+		{name: "bad magic", spirv: badMagic},
+		{name: "no entry point", spirv: spirvBinary(spirvInstruction(spirvOpTypeFloat, 2, 32))},
+		{name: "truncated instruction", spirv: spirvBinary([]uint32{4<<16 | spirvOpEntryPoint, 0})},
+		{name: "runtime array without element type", spirv: resource(spirvInstruction(spirvOpTypeRuntimeArray, 10))},
+		{name: "self-referential array", spirv: resource(spirvInstruction(spirvOpTypeArray, 10, 10, 3))},
+		{
+			name: "array cycle",
+			spirv: resource(
+				spirvInstruction(spirvOpTypeArray, 10, 13, 3),
+				spirvInstruction(spirvOpTypeRuntimeArray, 13, 10),
+			),
+		},
+		{name: "self-referential struct", spirv: pushConstant(spirvInstruction(spirvOpTypeStruct, 10, 10, 10, 10))},
+		{
+			name: "struct cycle",
+			spirv: pushConstant(
+				spirvInstruction(spirvOpTypeStruct, 10, 20),
+				spirvInstruction(spirvOpTypeStruct, 20, 10),
+			),
+		},
+		{
+			name: "array element cycle",
+			spirv: pushConstant(
+				spirvInstruction(spirvOpTypeStruct, 10, 21),
+				spirvInstruction(spirvOpTypeArray, 21, 21, 3),
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := ReflectShaderBindings(tt.spirv)
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != "spirv" {
+				t.Errorf("Expected error for parameter 'spirv', got '%s'", validationErr.Parameter)
+			}
+		})
+	}
+}
@@ -0,0 +1,301 @@
+package vulkan
+
+/*
+#include <vulkan/vulkan.h>
+#include <stdlib.h>
+
+// Function pointers for VK_KHR_get_surface_capabilities2.
+// They are loaded through the instance since the queries run before device creation.
+//
+// IMPORTANT: These are global static pointers and NOT thread-safe during loading.
+// LoadSurfaceCapabilities2Functions must be called from a single thread during
+// initialization before any concurrent surface query API usage.
+static PFN_vkGetPhysicalDeviceSurfaceCapabilities2KHR pfn_vkGetPhysicalDeviceSurfaceCapabilities2KHR = NULL;
+static PFN_vkGetPhysicalDeviceSurfaceFormats2KHR pfn_vkGetPhysicalDeviceSurfaceFormats2KHR = NULL;
+
+static int loadSurfaceCapabilities2InstanceFunctions(VkInstance instance) {
+    if (instance == VK_NULL_HANDLE) {
+        return 0;
+    }
+    pfn_vkGetPhysicalDeviceSurfaceCapabilities2KHR = (PFN_vkGetPhysicalDeviceSurfaceCapabilities2KHR)
+        vkGetInstanceProcAddr(instance, "vkGetPhysicalDeviceSurfaceCapabilities2KHR");
+    pfn_vkGetPhysicalDeviceSurfaceFormats2KHR = (PFN_vkGetPhysicalDeviceSurfaceFormats2KHR)
+        vkGetInstanceProcAddr(instance, "vkGetPhysicalDeviceSurfaceFormats2KHR");
+
+    return pfn_vkGetPhysicalDeviceSurfaceCapabilities2KHR != NULL &&
+           pfn_vkGetPhysicalDeviceSurfaceFormats2KHR != NULL;
+}
+
+// Wrappers return VK_ERROR_EXTENSION_NOT_PRESENT if the function pointer is NULL.
+static VkResult call_vkGetPhysicalDeviceSurfaceCapabilities2KHR(
+    VkPhysicalDevice physicalDevice,
+    const VkPhysicalDeviceSurfaceInfo2KHR* pSurfaceInfo,
+    VkSurfaceCapabilities2KHR* pSurfaceCapabilities) {
+    if (pfn_vkGetPhysicalDeviceSurfaceCapabilities2KHR == NULL) {
+        return VK_ERROR_EXTENSION_NOT_PRESENT;
+    }
+    return pfn_vkGetPhysicalDeviceSurfaceCapabilities2KHR(physicalDevice, pSurfaceInfo, pSurfaceCapabilities);
+}
+
+static VkResult call_vkGetPhysicalDeviceSurfaceFormats2KHR(
+    VkPhysicalDevice physicalDevice,
+    const VkPhysicalDeviceSurfaceInfo2KHR* pSurfaceInfo,
+    uint32_t* pSurfaceFormatCount,
+    VkSurfaceFormat2KHR* pSurfaceFormats) {
+    if (pfn_vkGetPhysicalDeviceSurfaceFormats2KHR == NULL) {
+        return VK_ERROR_EXTENSION_NOT_PRESENT;
+    }
+    return pfn_vkGetPhysicalDeviceSurfaceFormats2KHR(physicalDevice, pSurfaceInfo, pSurfaceFormatCount, pSurfaceFormats);
+}
+*/
+import "C"
+
+import "unsafe"
+
+// ExtensionNameGetSurfaceCapabilities2 is the extended surface query instance extension name
+const ExtensionNameGetSurfaceCapabilities2 = "VK_KHR_get_surface_capabilities2"
+
+// SurfaceTransformFlags represents the transforms applied to images before presentation
+type SurfaceTransformFlags uint32
+
+const (
+	SurfaceTransformIdentityBit                  SurfaceTransformFlags = C.VK_SURFACE_TRANSFORM_IDENTITY_BIT_KHR
+	SurfaceTransformRotate90Bit                  SurfaceTransformFlags = C.VK_SURFACE_TRANSFORM_ROTATE_90_BIT_KHR
+	SurfaceTransformRotate180Bit                 SurfaceTransformFlags = C.VK_SURFACE_TRANSFORM_ROTATE_180_BIT_KHR
+	SurfaceTransformRotate270Bit                 SurfaceTransformFlags = C.VK_SURFACE_TRANSFORM_ROTATE_270_BIT_KHR
+	SurfaceTransformHorizontalMirrorBit          SurfaceTransformFlags = C.VK_SURFACE_TRANSFORM_HORIZONTAL_MIRROR_BIT_KHR
+	SurfaceTransformHorizontalMirrorRotate90Bit  SurfaceTransformFlags = C.VK_SURFACE_TRANSFORM_HORIZONTAL_MIRROR_ROTATE_90_BIT_KHR
+	SurfaceTransformHorizontalMirrorRotate180Bit SurfaceTransformFlags = C.VK_SURFACE_TRANSFORM_HORIZONTAL_MIRROR_ROTATE_180_BIT_KHR
+	SurfaceTransformHorizontalMirrorRotate270Bit SurfaceTransformFlags = C.VK_SURFACE_TRANSFORM_HORIZONTAL_MIRROR_ROTATE_270_BIT_KHR
+	SurfaceTransformInheritBit                   SurfaceTransformFlags = C.VK_SURFACE_TRANSFORM_INHERIT_BIT_KHR
+)
+
+// CompositeAlphaFlags represents how presented images are blended with other surfaces
+type CompositeAlphaFlags uint32
+
+const (
+	CompositeAlphaOpaqueBit         CompositeAlphaFlags = C.VK_COMPOSITE_ALPHA_OPAQUE_BIT_KHR
+	CompositeAlphaPreMultipliedBit  CompositeAlphaFlags = C.VK_COMPOSITE_ALPHA_PRE_MULTIPLIED_BIT_KHR
+	CompositeAlphaPostMultipliedBit CompositeAlphaFlags = C.VK_COMPOSITE_ALPHA_POST_MULTIPLIED_BIT_KHR
+	CompositeAlphaInheritBit        CompositeAlphaFlags = C.VK_COMPOSITE_ALPHA_INHERIT_BIT_KHR
+)
+
+// ColorSpace represents the color space of presented images
+type ColorSpace int32
+
+const (
+	ColorSpaceSrgbNonlinear ColorSpace = C.VK_COLOR_SPACE_SRGB_NONLINEAR_KHR
+)
+
+// SurfaceCapabilities describes the swapchains a surface supports
+type SurfaceCapabilities struct {
+	MinImageCount uint32
+	// MaxImageCount is 0 when there is no limit
+	MaxImageCount uint32
+	// CurrentExtent is 0xFFFFFFFF x 0xFFFFFFFF when the swapchain extent decides the surface size
+	CurrentExtent           Extent2D
+	MinImageExtent          Extent2D
+	MaxImageExtent          Extent2D
+	MaxImageArrayLayers     uint32
+	SupportedTransforms     SurfaceTransformFlags
+	CurrentTransform        SurfaceTransformFlags
+	SupportedCompositeAlpha CompositeAlphaFlags
+	SupportedUsageFlags     ImageUsageFlags
+}
+
+// SurfaceFormat is a format and color space pair a surface can present
+type SurfaceFormat struct {
+	Format     Format
+	ColorSpace ColorSpace
+}
+
+// FullScreenExclusiveEXT selects how a swapchain interacts with exclusive full-screen mode.
+// Its values are only defined on Windows.
+type FullScreenExclusiveEXT int32
+
+// SurfaceFullScreenExclusiveInfo describes the full-screen exclusive mode a surface query or
+// swapchain is for. Only supported on Windows.
+type SurfaceFullScreenExclusiveInfo struct {
+	FullScreenExclusive FullScreenExclusiveEXT
+	// Monitor is the HMONITOR the surface will be full-screen on. Required for
+	// FullScreenExclusiveApplicationControlled.
+	Monitor uintptr
+}
+
+// PhysicalDeviceSurfaceInfo2 selects the surface, and the presentation mode, that
+// GetPhysicalDeviceSurfaceCapabilities2KHR and GetPhysicalDeviceSurfaceFormats2KHR query
+type PhysicalDeviceSurfaceInfo2 struct {
+	Surface Surface
+	// FullScreenExclusive queries the surface for full-screen exclusive presentation and
+	// fills SurfaceCapabilities2.FullScreenExclusiveSupported. Requires
+	// ExtensionNameFullScreenExclusive; only supported on Windows.
+	FullScreenExclusive *SurfaceFullScreenExclusiveInfo
+}
+
+// SurfaceCapabilities2 holds the surface capabilities and the results of chained queries
+type SurfaceCapabilities2 struct {
+	SurfaceCapabilities
+	// FullScreenExclusiveSupported reports whether the surface supports application
+	// controlled full-screen exclusive mode. Only filled in when
+	// PhysicalDeviceSurfaceInfo2.FullScreenExclusive is set.
+	FullScreenExclusiveSupported bool
+}
+
+// LoadSurfaceCapabilities2Functions loads VK_KHR_get_surface_capabilities2 functions for an
+// instance.
+//
+// This function MUST be called after creating an instance with the
+// VK_KHR_get_surface_capabilities2 extension enabled and before querying surfaces with it.
+//
+// IMPORTANT: This function is NOT thread-safe. Only one instance is supported at a time;
+// calling this function again will overwrite previously loaded function pointers.
+//
+// Returns false if any surface capabilities 2 function could not be loaded.
+func LoadSurfaceCapabilities2Functions(instance Instance) bool {
+	return C.loadSurfaceCapabilities2InstanceFunctions(C.VkInstance(instance)) != 0
+}
+
+// surfaceInfo2ToC converts a surface info to C memory, chaining the full-screen exclusive
+// info if set. It returns the full-screen exclusive capabilities struct to chain to the
+// output, or nil. Everything is appended to allocations, which the caller must free.
+func surfaceInfo2ToC(operation string, surfaceInfo *PhysicalDeviceSurfaceInfo2, allocations *[]unsafe.Pointer) (*C.VkPhysicalDeviceSurfaceInfo2KHR, unsafe.Pointer, error) {
+	cInfo := (*C.VkPhysicalDeviceSurfaceInfo2KHR)(C.calloc(1, C.sizeof_VkPhysicalDeviceSurfaceInfo2KHR))
+	if cInfo == nil {
+		return nil, nil, NewVulkanError(ErrorOutOfHostMemory, operation, "failed to allocate memory for surface info")
+	}
+	*allocations = append(*allocations, unsafe.Pointer(cInfo))
+
+	cInfo.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_SURFACE_INFO_2_KHR
+	cInfo.surface = C.VkSurfaceKHR(surfaceInfo.Surface)
+
+	var cCapabilitiesNext unsafe.Pointer
+	if surfaceInfo.FullScreenExclusive != nil {
+		var err error
+		cInfo.pNext, cCapabilitiesNext, err = fullScreenExclusiveSurfaceInfoToC(operation, surfaceInfo.FullScreenExclusive, cInfo.pNext, allocations)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return cInfo, cCapabilitiesNext, nil
+}
+
+// validateSurfaceInfo2 checks the arguments shared by the surface info 2 queries
+func validateSurfaceInfo2(physicalDevice PhysicalDevice, surfaceInfo *PhysicalDeviceSurfaceInfo2) error {
+	if physicalDevice == nil {
+		return NewValidationError("physicalDevice", "cannot be nil")
+	}
+	if surfaceInfo == nil {
+		return NewValidationError("surfaceInfo", "cannot be nil")
+	}
+	if surfaceInfo.Surface == nil {
+		return NewValidationError("surfaceInfo.Surface", "cannot be nil")
+	}
+	return nil
+}
+
+// GetPhysicalDeviceSurfaceCapabilities2KHR queries the capabilities of a surface, including
+// the chained queries selected in surfaceInfo. Set surfaceInfo.FullScreenExclusive to find
+// out whether a swapchain on the surface can use application controlled full-screen
+// exclusive mode before creating it.
+// Returns an error if LoadSurfaceCapabilities2Functions was not called.
+func GetPhysicalDeviceSurfaceCapabilities2KHR(physicalDevice PhysicalDevice, surfaceInfo *PhysicalDeviceSurfaceInfo2) (SurfaceCapabilities2, error) {
+	if err := validateSurfaceInfo2(physicalDevice, surfaceInfo); err != nil {
+		return SurfaceCapabilities2{}, err
+	}
+
+	var allocations []unsafe.Pointer
+	defer func() { freeAllocations(allocations) }()
+
+	cInfo, cCapabilitiesNext, err := surfaceInfo2ToC("GetPhysicalDeviceSurfaceCapabilities2KHR", surfaceInfo, &allocations)
+	if err != nil {
+		return SurfaceCapabilities2{}, err
+	}
+
+	cCaps := (*C.VkSurfaceCapabilities2KHR)(C.calloc(1, C.sizeof_VkSurfaceCapabilities2KHR))
+	if cCaps == nil {
+		return SurfaceCapabilities2{}, NewVulkanError(ErrorOutOfHostMemory, "GetPhysicalDeviceSurfaceCapabilities2KHR", "failed to allocate memory for surface capabilities")
+	}
+	allocations = append(allocations, unsafe.Pointer(cCaps))
+	cCaps.sType = C.VK_STRUCTURE_TYPE_SURFACE_CAPABILITIES_2_KHR
+	cCaps.pNext = cCapabilitiesNext
+
+	result := Result(C.call_vkGetPhysicalDeviceSurfaceCapabilities2KHR(C.VkPhysicalDevice(physicalDevice), cInfo, cCaps))
+	traceResult("vkGetPhysicalDeviceSurfaceCapabilities2KHR", result)
+	if result != Success {
+		return SurfaceCapabilities2{}, NewVulkanError(result, "GetPhysicalDeviceSurfaceCapabilities2KHR", "failed to query surface capabilities")
+	}
+
+	c := &cCaps.surfaceCapabilities
+	capabilities := SurfaceCapabilities2{
+		SurfaceCapabilities: SurfaceCapabilities{
+			MinImageCount:           uint32(c.minImageCount),
+			MaxImageCount:           uint32(c.maxImageCount),
+			CurrentExtent:           Extent2D{Width: uint32(c.currentExtent.width), Height: uint32(c.currentExtent.height)},
+			MinImageExtent:          Extent2D{Width: uint32(c.minImageExtent.width), Height: uint32(c.minImageExtent.height)},
+			MaxImageExtent:          Extent2D{Width: uint32(c.maxImageExtent.width), Height: uint32(c.maxImageExtent.height)},
+			MaxImageArrayLayers:     uint32(c.maxImageArrayLayers),
+			SupportedTransforms:     SurfaceTransformFlags(c.supportedTransforms),
+			CurrentTransform:        SurfaceTransformFlags(c.currentTransform),
+			SupportedCompositeAlpha: CompositeAlphaFlags(c.supportedCompositeAlpha),
+			SupportedUsageFlags:     ImageUsageFlags(c.supportedUsageFlags),
+		},
+	}
+	if cCapabilitiesNext != nil {
+		capabilities.FullScreenExclusiveSupported = fullScreenExclusiveSupportedFromC(cCapabilitiesNext)
+	}
+	return capabilities, nil
+}
+
+// GetPhysicalDeviceSurfaceFormats2KHR lists the format and color space pairs a surface can
+// present for the presentation mode selected in surfaceInfo.
+// Returns an error if LoadSurfaceCapabilities2Functions was not called.
+func GetPhysicalDeviceSurfaceFormats2KHR(physicalDevice PhysicalDevice, surfaceInfo *PhysicalDeviceSurfaceInfo2) ([]SurfaceFormat, error) {
+	if err := validateSurfaceInfo2(physicalDevice, surfaceInfo); err != nil {
+		return nil, err
+	}
+
+	var allocations []unsafe.Pointer
+	defer func() { freeAllocations(allocations) }()
+
+	// The capabilities struct is only chained to the capabilities query
+	cInfo, _, err := surfaceInfo2ToC("GetPhysicalDeviceSurfaceFormats2KHR", surfaceInfo, &allocations)
+	if err != nil {
+		return nil, err
+	}
+
+	var count C.uint32_t
+	result := Result(C.call_vkGetPhysicalDeviceSurfaceFormats2KHR(C.VkPhysicalDevice(physicalDevice), cInfo, &count, nil))
+	traceResult("vkGetPhysicalDeviceSurfaceFormats2KHR", result)
+	if result != Success {
+		return nil, NewVulkanError(result, "GetPhysicalDeviceSurfaceFormats2KHR", "failed to get surface format count")
+	}
+	if count == 0 {
+		return nil, nil
+	}
+
+	cFormatsPtr := (*C.VkSurfaceFormat2KHR)(C.calloc(C.size_t(count), C.sizeof_VkSurfaceFormat2KHR))
+	if cFormatsPtr == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "GetPhysicalDeviceSurfaceFormats2KHR", "failed to allocate memory for surface formats")
+	}
+	allocations = append(allocations, unsafe.Pointer(cFormatsPtr))
+
+	cFormats := unsafe.Slice(cFormatsPtr, count)
+	for i := range cFormats {
+		cFormats[i].sType = C.VK_STRUCTURE_TYPE_SURFACE_FORMAT_2_KHR
+	}
+
+	result = Result(C.call_vkGetPhysicalDeviceSurfaceFormats2KHR(C.VkPhysicalDevice(physicalDevice), cInfo, &count, cFormatsPtr))
+	traceResult("vkGetPhysicalDeviceSurfaceFormats2KHR", result)
+	if result != Success && result != Incomplete {
+		return nil, NewVulkanError(result, "GetPhysicalDeviceSurfaceFormats2KHR", "failed to get surface formats")
+	}
+
+	formats := make([]SurfaceFormat, count)
+	for i := range formats {
+		formats[i] = SurfaceFormat{
+			Format:     Format(cFormats[i].surfaceFormat.format),
+			ColorSpace: ColorSpace(cFormats[i].surfaceFormat.colorSpace),
+		}
+	}
+	return formats, nil
+}
@@ -0,0 +1,53 @@
+//go:build cgo
+
+package vulkan
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestSurfaceCapabilities2Validation tests input validation and the unloaded-extension error
+func TestSurfaceCapabilities2Validation(t *testing.T) {
+	fakePhysicalDevice := PhysicalDevice(uintptr(0x1234))
+	fakeSurface := Surface(uintptr(0x5678))
+
+	tests := []struct {
+		name           string
+		physicalDevice PhysicalDevice
+		surfaceInfo    *PhysicalDeviceSurfaceInfo2
+		errorParam     string
+	}{
+		{"nil physical device", nil, &PhysicalDeviceSurfaceInfo2{Surface: fakeSurface}, "physicalDevice"},
+		{"nil surface info", fakePhysicalDevice, nil, "surfaceInfo"},
+		{"nil surface", fakePhysicalDevice, &PhysicalDeviceSurfaceInfo2{}, "surfaceInfo.Surface"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := GetPhysicalDeviceSurfaceCapabilities2KHR(tt.physicalDevice, tt.surfaceInfo)
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+
+			_, err = GetPhysicalDeviceSurfaceFormats2KHR(tt.physicalDevice, tt.surfaceInfo)
+			if !errors.As(err, &validationErr) || validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected ValidationError for '%s' from GetPhysicalDeviceSurfaceFormats2KHR, got %v", tt.errorParam, err)
+			}
+		})
+	}
+
+	// Without LoadSurfaceCapabilities2Functions the queries report the missing extension
+	_, err := GetPhysicalDeviceSurfaceCapabilities2KHR(fakePhysicalDevice, &PhysicalDeviceSurfaceInfo2{Surface: fakeSurface})
+	if !errors.Is(err, ErrorExtensionNotPresent) {
+		t.Errorf("Expected ErrorExtensionNotPresent, got %v", err)
+	}
+	_, err = GetPhysicalDeviceSurfaceFormats2KHR(fakePhysicalDevice, &PhysicalDeviceSurfaceInfo2{Surface: fakeSurface})
+	if !errors.Is(err, ErrorExtensionNotPresent) {
+		t.Errorf("Expected ErrorExtensionNotPresent, got %v", err)
+	}
+}
@@ -0,0 +1,249 @@
+package vulkan
+
+/*
+#include <vulkan/vulkan.h>
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"time"
+	"unsafe"
+)
+
+// SemaphoreType selects between binary and timeline semaphores (Vulkan 1.2)
+type SemaphoreType int32
+
+const (
+	SemaphoreTypeBinary   SemaphoreType = C.VK_SEMAPHORE_TYPE_BINARY
+	SemaphoreTypeTimeline SemaphoreType = C.VK_SEMAPHORE_TYPE_TIMELINE
+)
+
+// PhysicalDeviceTimelineSemaphoreFeatures contains timeline semaphore features
+type PhysicalDeviceTimelineSemaphoreFeatures struct {
+	TimelineSemaphore bool
+}
+
+// timelineSemaphoreFeaturesToC prepends a struct enabling timeline semaphores to the pNext
+// chain next. The struct is allocated in C memory and appended to allocations, which the
+// caller must free.
+func timelineSemaphoreFeaturesToC(features *PhysicalDeviceTimelineSemaphoreFeatures, next unsafe.Pointer, allocations *[]unsafe.Pointer) (unsafe.Pointer, error) {
+	cTimeline := (*C.VkPhysicalDeviceTimelineSemaphoreFeatures)(C.calloc(1, C.sizeof_VkPhysicalDeviceTimelineSemaphoreFeatures))
+	if cTimeline == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "CreateDevice", "failed to allocate memory for timeline semaphore features")
+	}
+	*allocations = append(*allocations, unsafe.Pointer(cTimeline))
+
+	cTimeline.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_TIMELINE_SEMAPHORE_FEATURES
+	cTimeline.pNext = next
+	cTimeline.timelineSemaphore = boolToVkBool32(features.TimelineSemaphore)
+
+	return unsafe.Pointer(cTimeline), nil
+}
+
+// GetPhysicalDeviceTimelineSemaphoreFeatures queries timeline semaphore support
+func GetPhysicalDeviceTimelineSemaphoreFeatures(physicalDevice PhysicalDevice) PhysicalDeviceTimelineSemaphoreFeatures {
+	cFeatures2 := (*C.VkPhysicalDeviceFeatures2)(C.calloc(1, C.sizeof_VkPhysicalDeviceFeatures2))
+	cTimeline := (*C.VkPhysicalDeviceTimelineSemaphoreFeatures)(C.calloc(1, C.sizeof_VkPhysicalDeviceTimelineSemaphoreFeatures))
+	defer C.free(unsafe.Pointer(cFeatures2))
+	defer C.free(unsafe.Pointer(cTimeline))
+	if cFeatures2 == nil || cTimeline == nil {
+		return PhysicalDeviceTimelineSemaphoreFeatures{}
+	}
+
+	cFeatures2.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_FEATURES_2
+	cFeatures2.pNext = unsafe.Pointer(cTimeline)
+	cTimeline.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_TIMELINE_SEMAPHORE_FEATURES
+
+	C.vkGetPhysicalDeviceFeatures2(C.VkPhysicalDevice(physicalDevice), cFeatures2)
+
+	return PhysicalDeviceTimelineSemaphoreFeatures{
+		TimelineSemaphore: vkBool32ToBool(cTimeline.timelineSemaphore),
+	}
+}
+
+// WaitSemaphores waits on the host until timeline semaphores reach the given values. With
+// waitAny set it returns as soon as one of them does. It returns ErrTimeout if the values
+// were not reached in time; a zero timeout polls the semaphores.
+func WaitSemaphores(device Device, semaphores []Semaphore, values []uint64, waitAny bool, timeout time.Duration) error {
+	if device == nil {
+		return NewValidationError("device", "cannot be nil")
+	}
+	if len(semaphores) != len(values) {
+		return NewValidationError("values", "must have one value per semaphore")
+	}
+	if timeout < 0 {
+		return NewValidationError("timeout", "cannot be negative")
+	}
+	if len(semaphores) == 0 {
+		return nil
+	}
+
+	return waitSemaphores(device, semaphores, values, waitAny, C.uint64_t(timeout.Nanoseconds()))
+}
+
+// waitSemaphores calls vkWaitSemaphores with the semaphores and values copied into C memory
+func waitSemaphores(device Device, semaphores []Semaphore, values []uint64, waitAny bool, timeout C.uint64_t) error {
+	var allocations []unsafe.Pointer
+	defer func() { freeAllocations(allocations) }()
+
+	cSemaphores, err := semaphoresToC("WaitSemaphores", semaphores, &allocations)
+	if err != nil {
+		return err
+	}
+	cValues := (*C.uint64_t)(C.calloc(C.size_t(len(values)), C.sizeof_uint64_t))
+	if cValues == nil {
+		return NewVulkanError(ErrorOutOfHostMemory, "WaitSemaphores", "failed to allocate semaphore values")
+	}
+	allocations = append(allocations, unsafe.Pointer(cValues))
+	for i, value := range values {
+		unsafe.Slice(cValues, len(values))[i] = C.uint64_t(value)
+	}
+
+	var cWaitInfo C.VkSemaphoreWaitInfo
+	cWaitInfo.sType = C.VK_STRUCTURE_TYPE_SEMAPHORE_WAIT_INFO
+	cWaitInfo.pNext = nil
+	if waitAny {
+		cWaitInfo.flags = C.VK_SEMAPHORE_WAIT_ANY_BIT
+	}
+	cWaitInfo.semaphoreCount = C.uint32_t(len(semaphores))
+	cWaitInfo.pSemaphores = cSemaphores
+	cWaitInfo.pValues = cValues
+
+	result := Result(C.vkWaitSemaphores(C.VkDevice(device), &cWaitInfo, timeout))
+	traceResult("vkWaitSemaphores", result)
+	switch result {
+	case Success:
+		return nil
+	case Timeout:
+		return ErrTimeout
+	default:
+		return NewVulkanError(result, "WaitSemaphores", "failed to wait for semaphores")
+	}
+}
+
+// SignalSemaphore sets a timeline semaphore to value from the host. The value must be
+// greater than the semaphore's current value and any pending signal operations.
+func SignalSemaphore(device Device, semaphore Semaphore, value uint64) error {
+	if device == nil {
+		return NewValidationError("device", "cannot be nil")
+	}
+	if semaphore == nil {
+		return NewValidationError("semaphore", "cannot be nil")
+	}
+
+	var cSignalInfo C.VkSemaphoreSignalInfo
+	cSignalInfo.sType = C.VK_STRUCTURE_TYPE_SEMAPHORE_SIGNAL_INFO
+	cSignalInfo.pNext = nil
+	cSignalInfo.semaphore = C.VkSemaphore(semaphore)
+	cSignalInfo.value = C.uint64_t(value)
+
+	result := Result(C.vkSignalSemaphore(C.VkDevice(device), &cSignalInfo))
+	traceResult("vkSignalSemaphore", result)
+	if result != Success {
+		return NewVulkanError(result, "SignalSemaphore", "failed to signal semaphore")
+	}
+	return nil
+}
+
+// GetSemaphoreCounterValue returns the current value of a timeline semaphore
+func GetSemaphoreCounterValue(device Device, semaphore Semaphore) (uint64, error) {
+	if device == nil {
+		return 0, NewValidationError("device", "cannot be nil")
+	}
+	if semaphore == nil {
+		return 0, NewValidationError("semaphore", "cannot be nil")
+	}
+
+	var value C.uint64_t
+	result := Result(C.vkGetSemaphoreCounterValue(C.VkDevice(device), C.VkSemaphore(semaphore), &value))
+	traceResult("vkGetSemaphoreCounterValue", result)
+	if result != Success {
+		return 0, NewVulkanError(result, "GetSemaphoreCounterValue", "failed to get semaphore counter value")
+	}
+	return uint64(value), nil
+}
+
+// FrameTimeline paces CPU recording against GPU completion with a single timeline
+// semaphore. Frame i signals SignalValueForFrame(i) when its last submission finishes;
+// BeginFrame(i) blocks until frame i-FramesInFlight has finished, so the CPU never runs
+// more than FramesInFlight frames ahead of the GPU:
+//
+//	for frame := uint64(0); ; frame++ {
+//		if err := timeline.BeginFrame(frame); err != nil { ... }
+//		// record and submit, signaling timeline.SignalSubmitInfo(frame)
+//	}
+//
+// Requires the TimelineSemaphore feature.
+type FrameTimeline struct {
+	device         Device
+	semaphore      Semaphore
+	FramesInFlight uint64
+}
+
+// NewFrameTimeline creates a frame timeline that lets the CPU record up to framesInFlight
+// frames ahead of the GPU. Two frames in flight is the usual choice.
+func NewFrameTimeline(device Device, framesInFlight uint64) (*FrameTimeline, error) {
+	if device == nil {
+		return nil, NewValidationError("device", "cannot be nil")
+	}
+	if framesInFlight == 0 {
+		return nil, NewValidationError("framesInFlight", "must be at least 1")
+	}
+
+	semaphore, err := CreateSemaphore(device, &SemaphoreCreateInfo{SemaphoreType: SemaphoreTypeTimeline})
+	if err != nil {
+		return nil, err
+	}
+
+	return &FrameTimeline{
+		device:         device,
+		semaphore:      semaphore,
+		FramesInFlight: framesInFlight,
+	}, nil
+}
+
+// Semaphore returns the timeline semaphore, for use in custom submissions
+func (t *FrameTimeline) Semaphore() Semaphore {
+	return t.semaphore
+}
+
+// SignalValueForFrame returns the value the timeline reaches when frame index completes.
+// Values start at 1 because the semaphore is created with value 0.
+func (t *FrameTimeline) SignalValueForFrame(index uint64) uint64 {
+	return index + 1
+}
+
+// SignalSubmitInfo returns the semaphore signal to add to the last QueueSubmit2 of frame index
+func (t *FrameTimeline) SignalSubmitInfo(index uint64) SemaphoreSubmitInfo {
+	return SemaphoreSubmitInfo{
+		Semaphore: t.semaphore,
+		Value:     t.SignalValueForFrame(index),
+		StageMask: PipelineStage2AllCommands,
+	}
+}
+
+// WaitForFrame blocks until the GPU has completed frame index
+func (t *FrameTimeline) WaitForFrame(index uint64) error {
+	return waitSemaphores(t.device, []Semaphore{t.semaphore}, []uint64{t.SignalValueForFrame(index)}, false, C.UINT64_MAX)
+}
+
+// BeginFrame blocks until it is safe to record frame index, which is when frame
+// index-FramesInFlight has completed. The first FramesInFlight frames never wait.
+func (t *FrameTimeline) BeginFrame(index uint64) error {
+	if index < t.FramesInFlight {
+		return nil
+	}
+	return t.WaitForFrame(index - t.FramesInFlight)
+}
+
+// CompletedValue returns the value of the last frame signal the GPU has reached
+func (t *FrameTimeline) CompletedValue() (uint64, error) {
+	return GetSemaphoreCounterValue(t.device, t.semaphore)
+}
+
+// Destroy destroys the timeline semaphore. All frames must have completed.
+func (t *FrameTimeline) Destroy() {
+	DestroySemaphore(t.device, t.semaphore)
+	t.semaphore = nil
+}
@@ -0,0 +1,95 @@
+//go:build cgo
+
+package vulkan
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestTimelineSemaphoreValidation tests parameter validation of the timeline semaphore functions
+func TestTimelineSemaphoreValidation(t *testing.T) {
+	device := Device(uintptr(0x1234))
+	semaphore := Semaphore(uintptr(0x5678))
+
+	tests := []struct {
+		name      string
+		call      func() error
+		wantParam string
+	}{
+		{
+			name:      "WaitSemaphores nil device",
+			call:      func() error { return WaitSemaphores(nil, []Semaphore{semaphore}, []uint64{1}, false, time.Second) },
+			wantParam: "device",
+		},
+		{
+			name:      "WaitSemaphores mismatched values",
+			call:      func() error { return WaitSemaphores(device, []Semaphore{semaphore}, nil, false, time.Second) },
+			wantParam: "values",
+		},
+		{
+			name:      "WaitSemaphores negative timeout",
+			call:      func() error { return WaitSemaphores(device, []Semaphore{semaphore}, []uint64{1}, false, -time.Second) },
+			wantParam: "timeout",
+		},
+		{
+			name:      "SignalSemaphore nil semaphore",
+			call:      func() error { return SignalSemaphore(device, nil, 1) },
+			wantParam: "semaphore",
+		},
+		{
+			name: "GetSemaphoreCounterValue nil device",
+			call: func() error {
+				_, err := GetSemaphoreCounterValue(nil, semaphore)
+				return err
+			},
+			wantParam: "device",
+		},
+		{
+			name: "NewFrameTimeline zero frames in flight",
+			call: func() error {
+				_, err := NewFrameTimeline(device, 0)
+				return err
+			},
+			wantParam: "framesInFlight",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.wantParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.wantParam, validationErr.Parameter)
+			}
+		})
+	}
+}
+
+// TestFrameTimelineSignalValues tests the frame to timeline value mapping
+func TestFrameTimelineSignalValues(t *testing.T) {
+	semaphore := Semaphore(uintptr(0x5678))
+	timeline := &FrameTimeline{device: Device(uintptr(0x1234)), semaphore: semaphore, FramesInFlight: 2}
+
+	for frame := uint64(0); frame < 4; frame++ {
+		if got := timeline.SignalValueForFrame(frame); got != frame+1 {
+			t.Errorf("SignalValueForFrame(%d) = %d, expected %d", frame, got, frame+1)
+		}
+	}
+
+	info := timeline.SignalSubmitInfo(5)
+	if info.Semaphore != semaphore || info.Value != 6 || info.StageMask != PipelineStage2AllCommands {
+		t.Errorf("Unexpected signal submit info: %+v", info)
+	}
+
+	// The first FramesInFlight frames have nothing to wait for
+	for frame := uint64(0); frame < timeline.FramesInFlight; frame++ {
+		if err := timeline.BeginFrame(frame); err != nil {
+			t.Errorf("BeginFrame(%d) returned %v, expected no wait", frame, err)
+		}
+	}
+}
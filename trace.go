@@ -0,0 +1,32 @@
+//go:build vulkan_trace
+
+package vulkan
+
+import (
+	"sync/atomic"
+)
+
+// traceHook holds the hook installed with SetTraceHook, or nil
+var traceHook atomic.Pointer[func(call string, args ...any)]
+
+// SetTraceHook installs hook to be called after every wrapped Vulkan call that returns a
+// VkResult. call is the Vulkan command name, such as "vkCreateDevice", and args holds the
+// Result it returned. Pass nil to remove the hook. The hook may be called from several
+// goroutines at once.
+//
+// Tracing is only compiled in with the vulkan_trace build tag; without it SetTraceHook does
+// nothing and the calls carry no tracing overhead.
+func SetTraceHook(hook func(call string, args ...any)) {
+	if hook == nil {
+		traceHook.Store(nil)
+		return
+	}
+	traceHook.Store(&hook)
+}
+
+// traceResult reports the result of a Vulkan call to the trace hook, if one is installed
+func traceResult(call string, result Result) {
+	if hook := traceHook.Load(); hook != nil {
+		(*hook)(call, result)
+	}
+}
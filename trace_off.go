@@ -0,0 +1,10 @@
+//go:build !vulkan_trace
+
+package vulkan
+
+// SetTraceHook does nothing unless the package is built with the vulkan_trace build tag.
+// See the tagged implementation for the hook contract.
+func SetTraceHook(hook func(call string, args ...any)) {}
+
+// traceResult is a no-op that the compiler inlines away in builds without vulkan_trace
+func traceResult(call string, result Result) {}
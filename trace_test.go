@@ -0,0 +1,32 @@
+//go:build vulkan_trace
+
+package vulkan
+
+import (
+	"testing"
+)
+
+// TestSetTraceHook tests that installed hooks see call results and that removed hooks do not
+func TestSetTraceHook(t *testing.T) {
+	var calls []string
+	var results []any
+	SetTraceHook(func(call string, args ...any) {
+		calls = append(calls, call)
+		results = append(results, args...)
+	})
+	defer SetTraceHook(nil)
+
+	traceResult("vkCreateDevice", ErrorInitializationFailed)
+	if len(calls) != 1 || calls[0] != "vkCreateDevice" {
+		t.Fatalf("Expected one traced vkCreateDevice call, got %v", calls)
+	}
+	if len(results) != 1 || results[0] != ErrorInitializationFailed {
+		t.Errorf("Expected traced result %v, got %v", ErrorInitializationFailed, results)
+	}
+
+	SetTraceHook(nil)
+	traceResult("vkCreateDevice", Success)
+	if len(calls) != 1 {
+		t.Errorf("Expected no calls after removing the hook, got %v", calls)
+	}
+}
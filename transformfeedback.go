@@ -0,0 +1,428 @@
+package vulkan
+
+/*
+#include <vulkan/vulkan.h>
+#include <stdlib.h>
+
+// Function pointers for transform feedback EXT extension functions
+// These need to be loaded dynamically at runtime.
+//
+// IMPORTANT: These are global static pointers and NOT thread-safe during loading.
+// LoadTransformFeedbackFunctions must be called from a single thread during initialization
+// before any concurrent transform feedback API usage.
+static PFN_vkCmdBindTransformFeedbackBuffersEXT pfn_vkCmdBindTransformFeedbackBuffersEXT = NULL;
+static PFN_vkCmdBeginTransformFeedbackEXT pfn_vkCmdBeginTransformFeedbackEXT = NULL;
+static PFN_vkCmdEndTransformFeedbackEXT pfn_vkCmdEndTransformFeedbackEXT = NULL;
+static PFN_vkCmdDrawIndirectByteCountEXT pfn_vkCmdDrawIndirectByteCountEXT = NULL;
+static PFN_vkCmdBeginQueryIndexedEXT pfn_vkCmdBeginQueryIndexedEXT = NULL;
+static PFN_vkCmdEndQueryIndexedEXT pfn_vkCmdEndQueryIndexedEXT = NULL;
+
+static int loadTransformFeedbackDeviceFunctions(VkDevice device) {
+    if (device == VK_NULL_HANDLE) {
+        return 0;
+    }
+    pfn_vkCmdBindTransformFeedbackBuffersEXT = (PFN_vkCmdBindTransformFeedbackBuffersEXT)
+        vkGetDeviceProcAddr(device, "vkCmdBindTransformFeedbackBuffersEXT");
+    pfn_vkCmdBeginTransformFeedbackEXT = (PFN_vkCmdBeginTransformFeedbackEXT)
+        vkGetDeviceProcAddr(device, "vkCmdBeginTransformFeedbackEXT");
+    pfn_vkCmdEndTransformFeedbackEXT = (PFN_vkCmdEndTransformFeedbackEXT)
+        vkGetDeviceProcAddr(device, "vkCmdEndTransformFeedbackEXT");
+    pfn_vkCmdDrawIndirectByteCountEXT = (PFN_vkCmdDrawIndirectByteCountEXT)
+        vkGetDeviceProcAddr(device, "vkCmdDrawIndirectByteCountEXT");
+    pfn_vkCmdBeginQueryIndexedEXT = (PFN_vkCmdBeginQueryIndexedEXT)
+        vkGetDeviceProcAddr(device, "vkCmdBeginQueryIndexedEXT");
+    pfn_vkCmdEndQueryIndexedEXT = (PFN_vkCmdEndQueryIndexedEXT)
+        vkGetDeviceProcAddr(device, "vkCmdEndQueryIndexedEXT");
+
+    return pfn_vkCmdBindTransformFeedbackBuffersEXT != NULL &&
+           pfn_vkCmdBeginTransformFeedbackEXT != NULL &&
+           pfn_vkCmdEndTransformFeedbackEXT != NULL &&
+           pfn_vkCmdDrawIndirectByteCountEXT != NULL &&
+           pfn_vkCmdBeginQueryIndexedEXT != NULL &&
+           pfn_vkCmdEndQueryIndexedEXT != NULL;
+}
+
+// Command buffer wrapper functions return 1 on success, 0 if function pointer is NULL.
+static int call_vkCmdBindTransformFeedbackBuffersEXT(
+    VkCommandBuffer commandBuffer,
+    uint32_t firstBinding,
+    uint32_t bindingCount,
+    const VkBuffer* pBuffers,
+    const VkDeviceSize* pOffsets,
+    const VkDeviceSize* pSizes) {
+    if (pfn_vkCmdBindTransformFeedbackBuffersEXT == NULL) {
+        return 0;
+    }
+    pfn_vkCmdBindTransformFeedbackBuffersEXT(commandBuffer, firstBinding, bindingCount, pBuffers, pOffsets, pSizes);
+    return 1;
+}
+
+static int call_vkCmdBeginTransformFeedbackEXT(
+    VkCommandBuffer commandBuffer,
+    uint32_t firstCounterBuffer,
+    uint32_t counterBufferCount,
+    const VkBuffer* pCounterBuffers,
+    const VkDeviceSize* pCounterBufferOffsets) {
+    if (pfn_vkCmdBeginTransformFeedbackEXT == NULL) {
+        return 0;
+    }
+    pfn_vkCmdBeginTransformFeedbackEXT(commandBuffer, firstCounterBuffer, counterBufferCount, pCounterBuffers, pCounterBufferOffsets);
+    return 1;
+}
+
+static int call_vkCmdEndTransformFeedbackEXT(
+    VkCommandBuffer commandBuffer,
+    uint32_t firstCounterBuffer,
+    uint32_t counterBufferCount,
+    const VkBuffer* pCounterBuffers,
+    const VkDeviceSize* pCounterBufferOffsets) {
+    if (pfn_vkCmdEndTransformFeedbackEXT == NULL) {
+        return 0;
+    }
+    pfn_vkCmdEndTransformFeedbackEXT(commandBuffer, firstCounterBuffer, counterBufferCount, pCounterBuffers, pCounterBufferOffsets);
+    return 1;
+}
+
+static int call_vkCmdDrawIndirectByteCountEXT(
+    VkCommandBuffer commandBuffer,
+    uint32_t instanceCount,
+    uint32_t firstInstance,
+    VkBuffer counterBuffer,
+    VkDeviceSize counterBufferOffset,
+    uint32_t counterOffset,
+    uint32_t vertexStride) {
+    if (pfn_vkCmdDrawIndirectByteCountEXT == NULL) {
+        return 0;
+    }
+    pfn_vkCmdDrawIndirectByteCountEXT(commandBuffer, instanceCount, firstInstance, counterBuffer, counterBufferOffset, counterOffset, vertexStride);
+    return 1;
+}
+
+static int call_vkCmdBeginQueryIndexedEXT(
+    VkCommandBuffer commandBuffer,
+    VkQueryPool queryPool,
+    uint32_t query,
+    VkQueryControlFlags flags,
+    uint32_t index) {
+    if (pfn_vkCmdBeginQueryIndexedEXT == NULL) {
+        return 0;
+    }
+    pfn_vkCmdBeginQueryIndexedEXT(commandBuffer, queryPool, query, flags, index);
+    return 1;
+}
+
+static int call_vkCmdEndQueryIndexedEXT(
+    VkCommandBuffer commandBuffer,
+    VkQueryPool queryPool,
+    uint32_t query,
+    uint32_t index) {
+    if (pfn_vkCmdEndQueryIndexedEXT == NULL) {
+        return 0;
+    }
+    pfn_vkCmdEndQueryIndexedEXT(commandBuffer, queryPool, query, index);
+    return 1;
+}
+*/
+import "C"
+
+import (
+	"unsafe"
+)
+
+// ExtensionNameTransformFeedback is the transform feedback extension name
+const ExtensionNameTransformFeedback = "VK_EXT_transform_feedback"
+
+// Transform feedback buffer usage, pipeline stage, access flags and query type
+const (
+	BufferUsageTransformFeedbackBufferBitEXT        BufferUsageFlags   = C.VK_BUFFER_USAGE_TRANSFORM_FEEDBACK_BUFFER_BIT_EXT
+	BufferUsageTransformFeedbackCounterBufferBitEXT BufferUsageFlags   = C.VK_BUFFER_USAGE_TRANSFORM_FEEDBACK_COUNTER_BUFFER_BIT_EXT
+	PipelineStageTransformFeedbackBitEXT            PipelineStageFlags = C.VK_PIPELINE_STAGE_TRANSFORM_FEEDBACK_BIT_EXT
+	AccessTransformFeedbackWriteBitEXT              AccessFlags        = C.VK_ACCESS_TRANSFORM_FEEDBACK_WRITE_BIT_EXT
+	AccessTransformFeedbackCounterReadBitEXT        AccessFlags        = C.VK_ACCESS_TRANSFORM_FEEDBACK_COUNTER_READ_BIT_EXT
+	AccessTransformFeedbackCounterWriteBitEXT       AccessFlags        = C.VK_ACCESS_TRANSFORM_FEEDBACK_COUNTER_WRITE_BIT_EXT
+	// QueryTypeTransformFeedbackStreamEXT queries return two values per query: the number of
+	// primitives written to the transform feedback buffers and the number that would have been
+	// written had the buffers been large enough. Begin and end them with
+	// CmdBeginQueryIndexedEXT and CmdEndQueryIndexedEXT to select the vertex stream.
+	QueryTypeTransformFeedbackStreamEXT QueryType = C.VK_QUERY_TYPE_TRANSFORM_FEEDBACK_STREAM_EXT
+)
+
+// PhysicalDeviceTransformFeedbackFeatures contains transform feedback features
+type PhysicalDeviceTransformFeedbackFeatures struct {
+	TransformFeedback bool
+	GeometryStreams   bool
+}
+
+// PhysicalDeviceTransformFeedbackProperties contains transform feedback stream and buffer limits
+type PhysicalDeviceTransformFeedbackProperties struct {
+	MaxTransformFeedbackStreams                uint32
+	MaxTransformFeedbackBuffers                uint32
+	MaxTransformFeedbackBufferSize             DeviceSize
+	MaxTransformFeedbackStreamDataSize         uint32
+	MaxTransformFeedbackBufferDataSize         uint32
+	MaxTransformFeedbackBufferDataStride       uint32
+	TransformFeedbackQueries                   bool
+	TransformFeedbackStreamsLinesTriangles     bool
+	TransformFeedbackRasterizationStreamSelect bool
+	TransformFeedbackDraw                      bool
+}
+
+// LoadTransformFeedbackFunctions loads transform feedback extension functions for a device.
+//
+// This function MUST be called after creating a logical device with the
+// VK_EXT_transform_feedback extension enabled and before recording any transform
+// feedback commands.
+//
+// IMPORTANT: This function is NOT thread-safe. Only one device is supported at a time;
+// calling this function again will overwrite previously loaded function pointers.
+//
+// Returns false if any transform feedback function could not be loaded.
+func LoadTransformFeedbackFunctions(device Device) bool {
+	return C.loadTransformFeedbackDeviceFunctions(C.VkDevice(device)) != 0
+}
+
+// transformFeedbackFeaturesToC prepends a struct enabling the requested transform feedback
+// features to the pNext chain next. The struct is allocated in C memory and appended to
+// allocations, which the caller must free.
+func transformFeedbackFeaturesToC(features *PhysicalDeviceTransformFeedbackFeatures, next unsafe.Pointer, allocations *[]unsafe.Pointer) (unsafe.Pointer, error) {
+	cFeedback := (*C.VkPhysicalDeviceTransformFeedbackFeaturesEXT)(C.calloc(1, C.sizeof_VkPhysicalDeviceTransformFeedbackFeaturesEXT))
+	if cFeedback == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "CreateDevice", "failed to allocate memory for transform feedback features")
+	}
+	*allocations = append(*allocations, unsafe.Pointer(cFeedback))
+
+	cFeedback.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_TRANSFORM_FEEDBACK_FEATURES_EXT
+	cFeedback.pNext = next
+	cFeedback.transformFeedback = boolToVkBool32(features.TransformFeedback)
+	cFeedback.geometryStreams = boolToVkBool32(features.GeometryStreams)
+
+	return unsafe.Pointer(cFeedback), nil
+}
+
+// GetPhysicalDeviceTransformFeedbackFeaturesEXT queries transform feedback feature support
+func GetPhysicalDeviceTransformFeedbackFeaturesEXT(physicalDevice PhysicalDevice) PhysicalDeviceTransformFeedbackFeatures {
+	cFeatures2 := (*C.VkPhysicalDeviceFeatures2)(C.calloc(1, C.sizeof_VkPhysicalDeviceFeatures2))
+	cFeedback := (*C.VkPhysicalDeviceTransformFeedbackFeaturesEXT)(C.calloc(1, C.sizeof_VkPhysicalDeviceTransformFeedbackFeaturesEXT))
+	defer C.free(unsafe.Pointer(cFeatures2))
+	defer C.free(unsafe.Pointer(cFeedback))
+	if cFeatures2 == nil || cFeedback == nil {
+		return PhysicalDeviceTransformFeedbackFeatures{}
+	}
+
+	cFeatures2.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_FEATURES_2
+	cFeatures2.pNext = unsafe.Pointer(cFeedback)
+	cFeedback.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_TRANSFORM_FEEDBACK_FEATURES_EXT
+
+	C.vkGetPhysicalDeviceFeatures2(C.VkPhysicalDevice(physicalDevice), cFeatures2)
+
+	return PhysicalDeviceTransformFeedbackFeatures{
+		TransformFeedback: vkBool32ToBool(cFeedback.transformFeedback),
+		GeometryStreams:   vkBool32ToBool(cFeedback.geometryStreams),
+	}
+}
+
+// GetPhysicalDeviceTransformFeedbackPropertiesEXT queries transform feedback stream and buffer limits
+func GetPhysicalDeviceTransformFeedbackPropertiesEXT(physicalDevice PhysicalDevice) PhysicalDeviceTransformFeedbackProperties {
+	cProps2 := (*C.VkPhysicalDeviceProperties2)(C.calloc(1, C.sizeof_VkPhysicalDeviceProperties2))
+	cFeedback := (*C.VkPhysicalDeviceTransformFeedbackPropertiesEXT)(C.calloc(1, C.sizeof_VkPhysicalDeviceTransformFeedbackPropertiesEXT))
+	defer C.free(unsafe.Pointer(cProps2))
+	defer C.free(unsafe.Pointer(cFeedback))
+	if cProps2 == nil || cFeedback == nil {
+		return PhysicalDeviceTransformFeedbackProperties{}
+	}
+
+	cProps2.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_PROPERTIES_2
+	cProps2.pNext = unsafe.Pointer(cFeedback)
+	cFeedback.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_TRANSFORM_FEEDBACK_PROPERTIES_EXT
+
+	C.vkGetPhysicalDeviceProperties2(C.VkPhysicalDevice(physicalDevice), cProps2)
+
+	return PhysicalDeviceTransformFeedbackProperties{
+		MaxTransformFeedbackStreams:                uint32(cFeedback.maxTransformFeedbackStreams),
+		MaxTransformFeedbackBuffers:                uint32(cFeedback.maxTransformFeedbackBuffers),
+		MaxTransformFeedbackBufferSize:             DeviceSize(cFeedback.maxTransformFeedbackBufferSize),
+		MaxTransformFeedbackStreamDataSize:         uint32(cFeedback.maxTransformFeedbackStreamDataSize),
+		MaxTransformFeedbackBufferDataSize:         uint32(cFeedback.maxTransformFeedbackBufferDataSize),
+		MaxTransformFeedbackBufferDataStride:       uint32(cFeedback.maxTransformFeedbackBufferDataStride),
+		TransformFeedbackQueries:                   vkBool32ToBool(cFeedback.transformFeedbackQueries),
+		TransformFeedbackStreamsLinesTriangles:     vkBool32ToBool(cFeedback.transformFeedbackStreamsLinesTriangles),
+		TransformFeedbackRasterizationStreamSelect: vkBool32ToBool(cFeedback.transformFeedbackRasterizationStreamSelect),
+		TransformFeedbackDraw:                      vkBool32ToBool(cFeedback.transformFeedbackDraw),
+	}
+}
+
+// CmdBindTransformFeedbackBuffersEXT binds buffers that capture vertex processing output.
+// sizes may be nil to capture up to the end of each buffer; otherwise it must have one entry
+// per buffer, with WholeSize also meaning the rest of the buffer.
+// Returns an error if LoadTransformFeedbackFunctions was not called.
+func CmdBindTransformFeedbackBuffersEXT(commandBuffer CommandBuffer, firstBinding uint32, buffers []Buffer, offsets, sizes []DeviceSize) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+	if len(buffers) == 0 {
+		return NewValidationError("buffers", "cannot be empty")
+	}
+	if len(offsets) != len(buffers) {
+		return NewValidationError("offsets", "must have one offset per buffer")
+	}
+	if sizes != nil && len(sizes) != len(buffers) {
+		return NewValidationError("sizes", "must be nil or have one size per buffer")
+	}
+
+	cBuffers := make([]C.VkBuffer, len(buffers))
+	cOffsets := make([]C.VkDeviceSize, len(buffers))
+	for i, buffer := range buffers {
+		if buffer == nil {
+			return NewValidationError("buffers", "cannot contain nil buffers")
+		}
+		if offsets[i]%4 != 0 {
+			return NewValidationError("offsets", "must be multiples of 4")
+		}
+		cBuffers[i] = C.VkBuffer(buffer)
+		cOffsets[i] = C.VkDeviceSize(offsets[i])
+	}
+
+	var pSizes *C.VkDeviceSize
+	if sizes != nil {
+		cSizes := make([]C.VkDeviceSize, len(sizes))
+		for i, size := range sizes {
+			cSizes[i] = C.VkDeviceSize(size)
+		}
+		pSizes = &cSizes[0]
+	}
+
+	if C.call_vkCmdBindTransformFeedbackBuffersEXT(C.VkCommandBuffer(commandBuffer), C.uint32_t(firstBinding), C.uint32_t(len(buffers)),
+		&cBuffers[0], &cOffsets[0], pSizes) == 0 {
+		return NewVulkanError(ErrorExtensionNotPresent, "CmdBindTransformFeedbackBuffersEXT", "transform feedback extension not loaded - call LoadTransformFeedbackFunctions first")
+	}
+	return nil
+}
+
+// CmdBeginTransformFeedbackEXT starts capturing vertex output into the bound transform
+// feedback buffers. counterBuffers optionally hold the byte counts written by a previous
+// CmdEndTransformFeedbackEXT, so capture resumes where it stopped; nil entries or a nil
+// slice start from the binding offsets. counterBufferOffsets may be nil for offset 0.
+// Returns an error if LoadTransformFeedbackFunctions was not called.
+func CmdBeginTransformFeedbackEXT(commandBuffer CommandBuffer, firstCounterBuffer uint32, counterBuffers []Buffer, counterBufferOffsets []DeviceSize) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+	pBuffers, pOffsets, err := counterBuffersToC(counterBuffers, counterBufferOffsets)
+	if err != nil {
+		return err
+	}
+
+	if C.call_vkCmdBeginTransformFeedbackEXT(C.VkCommandBuffer(commandBuffer), C.uint32_t(firstCounterBuffer), C.uint32_t(len(counterBuffers)), pBuffers, pOffsets) == 0 {
+		return NewVulkanError(ErrorExtensionNotPresent, "CmdBeginTransformFeedbackEXT", "transform feedback extension not loaded - call LoadTransformFeedbackFunctions first")
+	}
+	return nil
+}
+
+// CmdEndTransformFeedbackEXT stops capturing vertex output. The byte count written to each
+// binding is stored in the matching counter buffer, if any, for a later
+// CmdBeginTransformFeedbackEXT or CmdDrawIndirectByteCountEXT.
+// Returns an error if LoadTransformFeedbackFunctions was not called.
+func CmdEndTransformFeedbackEXT(commandBuffer CommandBuffer, firstCounterBuffer uint32, counterBuffers []Buffer, counterBufferOffsets []DeviceSize) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+	pBuffers, pOffsets, err := counterBuffersToC(counterBuffers, counterBufferOffsets)
+	if err != nil {
+		return err
+	}
+
+	if C.call_vkCmdEndTransformFeedbackEXT(C.VkCommandBuffer(commandBuffer), C.uint32_t(firstCounterBuffer), C.uint32_t(len(counterBuffers)), pBuffers, pOffsets) == 0 {
+		return NewVulkanError(ErrorExtensionNotPresent, "CmdEndTransformFeedbackEXT", "transform feedback extension not loaded - call LoadTransformFeedbackFunctions first")
+	}
+	return nil
+}
+
+// CmdDrawIndirectByteCountEXT draws the vertices captured by transform feedback, taking the
+// vertex count from the byte count stored in counterBuffer divided by vertexStride.
+// counterOffset is subtracted from the byte count first.
+// Returns an error if LoadTransformFeedbackFunctions was not called.
+func CmdDrawIndirectByteCountEXT(commandBuffer CommandBuffer, instanceCount, firstInstance uint32, counterBuffer Buffer, counterBufferOffset DeviceSize, counterOffset, vertexStride uint32) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+	if counterBuffer == nil {
+		return NewValidationError("counterBuffer", "cannot be nil")
+	}
+	if counterBufferOffset%4 != 0 {
+		return NewValidationError("counterBufferOffset", "must be a multiple of 4")
+	}
+	if vertexStride == 0 {
+		return NewValidationError("vertexStride", "must be greater than 0")
+	}
+
+	if C.call_vkCmdDrawIndirectByteCountEXT(C.VkCommandBuffer(commandBuffer), C.uint32_t(instanceCount), C.uint32_t(firstInstance),
+		C.VkBuffer(counterBuffer), C.VkDeviceSize(counterBufferOffset), C.uint32_t(counterOffset), C.uint32_t(vertexStride)) == 0 {
+		return NewVulkanError(ErrorExtensionNotPresent, "CmdDrawIndirectByteCountEXT", "transform feedback extension not loaded - call LoadTransformFeedbackFunctions first")
+	}
+	return nil
+}
+
+// CmdBeginQueryIndexedEXT begins a query that counts the primitives of vertex stream index,
+// which must be less than PhysicalDeviceTransformFeedbackProperties.MaxTransformFeedbackStreams.
+// For query types other than QueryTypeTransformFeedbackStreamEXT index must be 0 and the call
+// behaves like CmdBeginQuery.
+// Returns an error if LoadTransformFeedbackFunctions was not called.
+func CmdBeginQueryIndexedEXT(commandBuffer CommandBuffer, queryPool QueryPool, query uint32, flags QueryControlFlags, index uint32) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+	if queryPool == nil {
+		return NewValidationError("queryPool", "cannot be nil")
+	}
+
+	if C.call_vkCmdBeginQueryIndexedEXT(C.VkCommandBuffer(commandBuffer), C.VkQueryPool(queryPool), C.uint32_t(query),
+		C.VkQueryControlFlags(flags), C.uint32_t(index)) == 0 {
+		return NewVulkanError(ErrorExtensionNotPresent, "CmdBeginQueryIndexedEXT", "transform feedback extension not loaded - call LoadTransformFeedbackFunctions first")
+	}
+	return nil
+}
+
+// CmdEndQueryIndexedEXT ends a query begun with CmdBeginQueryIndexedEXT; index must match the
+// one it was begun with.
+// Returns an error if LoadTransformFeedbackFunctions was not called.
+func CmdEndQueryIndexedEXT(commandBuffer CommandBuffer, queryPool QueryPool, query, index uint32) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+	if queryPool == nil {
+		return NewValidationError("queryPool", "cannot be nil")
+	}
+
+	if C.call_vkCmdEndQueryIndexedEXT(C.VkCommandBuffer(commandBuffer), C.VkQueryPool(queryPool), C.uint32_t(query), C.uint32_t(index)) == 0 {
+		return NewVulkanError(ErrorExtensionNotPresent, "CmdEndQueryIndexedEXT", "transform feedback extension not loaded - call LoadTransformFeedbackFunctions first")
+	}
+	return nil
+}
+
+// counterBuffersToC converts optional counter buffers and offsets for the begin and end
+// transform feedback commands. It returns nil pointers for empty inputs.
+func counterBuffersToC(counterBuffers []Buffer, counterBufferOffsets []DeviceSize) (*C.VkBuffer, *C.VkDeviceSize, error) {
+	if counterBufferOffsets != nil && len(counterBufferOffsets) != len(counterBuffers) {
+		return nil, nil, NewValidationError("counterBufferOffsets", "must be nil or have one offset per counter buffer")
+	}
+	if len(counterBuffers) == 0 {
+		return nil, nil, nil
+	}
+
+	cBuffers := make([]C.VkBuffer, len(counterBuffers))
+	for i, buffer := range counterBuffers {
+		cBuffers[i] = C.VkBuffer(buffer)
+	}
+	if counterBufferOffsets == nil {
+		return &cBuffers[0], nil, nil
+	}
+
+	cOffsets := make([]C.VkDeviceSize, len(counterBufferOffsets))
+	for i, offset := range counterBufferOffsets {
+		if offset%4 != 0 {
+			return nil, nil, NewValidationError("counterBufferOffsets", "must be multiples of 4")
+		}
+		cOffsets[i] = C.VkDeviceSize(offset)
+	}
+	return &cBuffers[0], &cOffsets[0], nil
+}
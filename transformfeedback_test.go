@@ -0,0 +1,102 @@
+//go:build cgo
+
+package vulkan
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestTransformFeedbackValidation tests input validation of the transform feedback commands
+func TestTransformFeedbackValidation(t *testing.T) {
+	fakeCommandBuffer := CommandBuffer(uintptr(0x1234))
+	fakeBuffer := Buffer(uintptr(0x5678))
+
+	tests := []struct {
+		name       string
+		call       func() error
+		errorParam string
+	}{
+		{
+			name:       "no buffers",
+			call:       func() error { return CmdBindTransformFeedbackBuffersEXT(fakeCommandBuffer, 0, nil, nil, nil) },
+			errorParam: "buffers",
+		},
+		{
+			name: "mismatched offsets",
+			call: func() error {
+				return CmdBindTransformFeedbackBuffersEXT(fakeCommandBuffer, 0, []Buffer{fakeBuffer}, nil, nil)
+			},
+			errorParam: "offsets",
+		},
+		{
+			name: "mismatched sizes",
+			call: func() error {
+				return CmdBindTransformFeedbackBuffersEXT(fakeCommandBuffer, 0, []Buffer{fakeBuffer}, []DeviceSize{0}, []DeviceSize{})
+			},
+			errorParam: "sizes",
+		},
+		{
+			name: "mismatched counter buffer offsets",
+			call: func() error {
+				return CmdBeginTransformFeedbackEXT(fakeCommandBuffer, 0, []Buffer{fakeBuffer}, []DeviceSize{0, 4})
+			},
+			errorParam: "counterBufferOffsets",
+		},
+		{
+			name: "unaligned counter buffer offset",
+			call: func() error {
+				return CmdEndTransformFeedbackEXT(fakeCommandBuffer, 0, []Buffer{fakeBuffer}, []DeviceSize{2})
+			},
+			errorParam: "counterBufferOffsets",
+		},
+		{
+			name: "zero vertex stride",
+			call: func() error {
+				return CmdDrawIndirectByteCountEXT(fakeCommandBuffer, 1, 0, fakeBuffer, 0, 0, 0)
+			},
+			errorParam: "vertexStride",
+		},
+		{
+			name: "nil indexed query pool",
+			call: func() error {
+				return CmdBeginQueryIndexedEXT(fakeCommandBuffer, nil, 0, 0, 1)
+			},
+			errorParam: "queryPool",
+		},
+		{
+			name: "nil indexed query command buffer",
+			call: func() error {
+				return CmdEndQueryIndexedEXT(nil, QueryPool(uintptr(0x9abc)), 0, 1)
+			},
+			errorParam: "commandBuffer",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+}
+
+// TestQueryIndexedExtensionNotLoaded tests the unloaded-extension error of the indexed queries
+func TestQueryIndexedExtensionNotLoaded(t *testing.T) {
+	fakeCommandBuffer := CommandBuffer(uintptr(0x1234))
+	fakeQueryPool := QueryPool(uintptr(0x5678))
+
+	if err := CmdBeginQueryIndexedEXT(fakeCommandBuffer, fakeQueryPool, 0, 0, 1); !errors.Is(err, ErrorExtensionNotPresent) {
+		t.Errorf("Expected ErrorExtensionNotPresent, got %v", err)
+	}
+	if err := CmdEndQueryIndexedEXT(fakeCommandBuffer, fakeQueryPool, 0, 1); !errors.Is(err, ErrorExtensionNotPresent) {
+		t.Errorf("Expected ErrorExtensionNotPresent, got %v", err)
+	}
+}
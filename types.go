@@ -7,6 +7,7 @@ package vulkan
 import "C"
 
 import (
+	"fmt"
 	"unsafe"
 )
 
@@ -43,6 +44,21 @@ func (v Version) Patch() uint32 {
 	return uint32(v & 0xFFF)
 }
 
+// Variant extracts the variant number stored in the top bits by VK_MAKE_API_VERSION.
+// It is 0 for the Vulkan API and non-zero for variants such as Vulkan SC.
+func (v Version) Variant() uint32 {
+	return uint32(v >> 29)
+}
+
+// String formats the version as "major.minor.patch", e.g. "1.3.0". A non-zero variant
+// is prepended as "variant:major.minor.patch".
+func (v Version) String() string {
+	if v.Variant() != 0 {
+		return fmt.Sprintf("%d:%d.%d.%d", v.Variant(), v.Major(), v.Minor(), v.Patch())
+	}
+	return fmt.Sprintf("%d.%d.%d", v.Major(), v.Minor(), v.Patch())
+}
+
 // Result represents Vulkan result codes
 type Result int32
 
@@ -86,10 +102,28 @@ const (
 	OperationDeferredKHR                        Result = C.VK_OPERATION_DEFERRED_KHR
 	OperationNotDeferredKHR                     Result = C.VK_OPERATION_NOT_DEFERRED_KHR
 	PipelineCompileRequiredEXT                  Result = C.VK_PIPELINE_COMPILE_REQUIRED_EXT
+	ErrorImageUsageNotSupportedKHR              Result = C.VK_ERROR_IMAGE_USAGE_NOT_SUPPORTED_KHR
+	ErrorVideoPictureLayoutNotSupportedKHR      Result = C.VK_ERROR_VIDEO_PICTURE_LAYOUT_NOT_SUPPORTED_KHR
+	ErrorVideoProfileOperationNotSupportedKHR   Result = C.VK_ERROR_VIDEO_PROFILE_OPERATION_NOT_SUPPORTED_KHR
+	ErrorVideoProfileFormatNotSupportedKHR      Result = C.VK_ERROR_VIDEO_PROFILE_FORMAT_NOT_SUPPORTED_KHR
+	ErrorVideoProfileCodecNotSupportedKHR       Result = C.VK_ERROR_VIDEO_PROFILE_CODEC_NOT_SUPPORTED_KHR
+	ErrorVideoStdVersionNotSupportedKHR         Result = C.VK_ERROR_VIDEO_STD_VERSION_NOT_SUPPORTED_KHR
+	ErrorInvalidVideoStdParametersKHR           Result = C.VK_ERROR_INVALID_VIDEO_STD_PARAMETERS_KHR
+	ErrorCompressionExhaustedEXT                Result = C.VK_ERROR_COMPRESSION_EXHAUSTED_EXT
+	IncompatibleShaderBinaryEXT                 Result = C.VK_INCOMPATIBLE_SHADER_BINARY_EXT
+
+	// PipelineCompileRequired is the Vulkan 1.3 core name of PipelineCompileRequiredEXT
+	PipelineCompileRequired = PipelineCompileRequiredEXT
 )
 
 // Error returns the error message for the result
 func (r Result) Error() string {
+	return r.String()
+}
+
+// String returns the VK_* name of the result code. Codes this package does not know
+// about are reported with their numeric value.
+func (r Result) String() string {
 	switch r {
 	case Success:
 		return "VK_SUCCESS"
@@ -167,17 +201,37 @@ func (r Result) Error() string {
 		return "VK_OPERATION_NOT_DEFERRED_KHR"
 	case PipelineCompileRequiredEXT:
 		return "VK_PIPELINE_COMPILE_REQUIRED_EXT"
+	case ErrorImageUsageNotSupportedKHR:
+		return "VK_ERROR_IMAGE_USAGE_NOT_SUPPORTED_KHR"
+	case ErrorVideoPictureLayoutNotSupportedKHR:
+		return "VK_ERROR_VIDEO_PICTURE_LAYOUT_NOT_SUPPORTED_KHR"
+	case ErrorVideoProfileOperationNotSupportedKHR:
+		return "VK_ERROR_VIDEO_PROFILE_OPERATION_NOT_SUPPORTED_KHR"
+	case ErrorVideoProfileFormatNotSupportedKHR:
+		return "VK_ERROR_VIDEO_PROFILE_FORMAT_NOT_SUPPORTED_KHR"
+	case ErrorVideoProfileCodecNotSupportedKHR:
+		return "VK_ERROR_VIDEO_PROFILE_CODEC_NOT_SUPPORTED_KHR"
+	case ErrorVideoStdVersionNotSupportedKHR:
+		return "VK_ERROR_VIDEO_STD_VERSION_NOT_SUPPORTED_KHR"
+	case ErrorInvalidVideoStdParametersKHR:
+		return "VK_ERROR_INVALID_VIDEO_STD_PARAMETERS_KHR"
+	case ErrorCompressionExhaustedEXT:
+		return "VK_ERROR_COMPRESSION_EXHAUSTED_EXT"
+	case IncompatibleShaderBinaryEXT:
+		return "VK_INCOMPATIBLE_SHADER_BINARY_EXT"
 	default:
-		return "Unknown Vulkan error"
+		return fmt.Sprintf("Unknown Vulkan error (%d)", int32(r))
 	}
 }
 
-// IsError returns true if the result represents an error condition
+// IsError returns true if the result represents an error condition. Error codes are
+// negative; positive codes such as NotReady, Timeout, EventSet, EventReset, Incomplete
+// and SuboptimalKHR are status codes, not errors.
 func (r Result) IsError() bool {
 	return r < 0
 }
 
-// IsSuccess returns true if the result represents success
+// IsSuccess returns true if the result is Success or a non-error status code
 func (r Result) IsSuccess() bool {
 	return r >= 0
 }
@@ -195,6 +249,11 @@ func (b Bool32) ToBool() bool {
 	return b == True
 }
 
+// Bool converts a Bool32 to a Go bool; it is a shorter spelling of ToBool
+func (b Bool32) Bool() bool {
+	return b.ToBool()
+}
+
 // FromBool converts a Go bool to Bool32
 func FromBool(b bool) Bool32 {
 	if b {
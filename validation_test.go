@@ -247,3 +247,48 @@ func BenchmarkErrorCreation(b *testing.B) {
 		}
 	})
 }
+
+// TestWrapError tests that helper errors keep the Result and the original error
+func TestWrapError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected Result
+	}{
+		{
+			name:     "vulkan error",
+			err:      NewVulkanError(ErrorOutOfHostMemory, "BindBufferMemory", "failed to bind buffer memory"),
+			expected: ErrorOutOfHostMemory,
+		},
+		{
+			name:     "bare result",
+			err:      ErrorDeviceLost,
+			expected: ErrorDeviceLost,
+		},
+		{
+			name:     "validation error",
+			err:      NewValidationError("memory", "cannot be nil"),
+			expected: ErrorOutOfDeviceMemory,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := wrapError(tt.err, ErrorOutOfDeviceMemory, "AllocateBuffer", "failed to bind buffer memory")
+
+			var vkErr *VulkanError
+			if !errors.As(err, &vkErr) {
+				t.Fatalf("Expected VulkanError, got %T: %v", err, err)
+			}
+			if vkErr.Operation != "AllocateBuffer" {
+				t.Errorf("Expected operation 'AllocateBuffer', got '%s'", vkErr.Operation)
+			}
+			if vkErr.Result != tt.expected {
+				t.Errorf("Expected result %v, got %v", tt.expected, vkErr.Result)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Expected wrapped error to match %v", tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package vulkan
+
+/*
+#include <vulkan/vulkan.h>
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// ExtensionNameValidationFeatures is the validation features instance extension name. It is
+// provided by the Khronos validation layer, which must be enabled alongside it.
+const ExtensionNameValidationFeatures = "VK_EXT_validation_features"
+
+// ValidationFeatureEnable selects an optional validation layer check to turn on
+type ValidationFeatureEnable int32
+
+const (
+	// ValidationFeatureEnableGPUAssisted instruments shaders to catch out-of-bounds
+	// descriptor and buffer accesses on the GPU
+	ValidationFeatureEnableGPUAssisted ValidationFeatureEnable = C.VK_VALIDATION_FEATURE_ENABLE_GPU_ASSISTED_EXT
+	// ValidationFeatureEnableGPUAssistedReserveBindingSlot reserves a descriptor set binding
+	// slot for GPU-assisted validation; requires ValidationFeatureEnableGPUAssisted
+	ValidationFeatureEnableGPUAssistedReserveBindingSlot ValidationFeatureEnable = C.VK_VALIDATION_FEATURE_ENABLE_GPU_ASSISTED_RESERVE_BINDING_SLOT_EXT
+	// ValidationFeatureEnableBestPractices reports API usage that is valid but likely slow
+	ValidationFeatureEnableBestPractices ValidationFeatureEnable = C.VK_VALIDATION_FEATURE_ENABLE_BEST_PRACTICES_EXT
+	// ValidationFeatureEnableDebugPrintf forwards debugPrintfEXT output from shaders; it cannot
+	// be combined with ValidationFeatureEnableGPUAssisted
+	ValidationFeatureEnableDebugPrintf ValidationFeatureEnable = C.VK_VALIDATION_FEATURE_ENABLE_DEBUG_PRINTF_EXT
+	// ValidationFeatureEnableSynchronizationValidation reports missing or wrong barriers
+	ValidationFeatureEnableSynchronizationValidation ValidationFeatureEnable = C.VK_VALIDATION_FEATURE_ENABLE_SYNCHRONIZATION_VALIDATION_EXT
+)
+
+// ValidationFeatureDisable selects a default validation layer check to turn off
+type ValidationFeatureDisable int32
+
+const (
+	ValidationFeatureDisableAll                   ValidationFeatureDisable = C.VK_VALIDATION_FEATURE_DISABLE_ALL_EXT
+	ValidationFeatureDisableShaders               ValidationFeatureDisable = C.VK_VALIDATION_FEATURE_DISABLE_SHADERS_EXT
+	ValidationFeatureDisableThreadSafety          ValidationFeatureDisable = C.VK_VALIDATION_FEATURE_DISABLE_THREAD_SAFETY_EXT
+	ValidationFeatureDisableAPIParameters         ValidationFeatureDisable = C.VK_VALIDATION_FEATURE_DISABLE_API_PARAMETERS_EXT
+	ValidationFeatureDisableObjectLifetimes       ValidationFeatureDisable = C.VK_VALIDATION_FEATURE_DISABLE_OBJECT_LIFETIMES_EXT
+	ValidationFeatureDisableCoreChecks            ValidationFeatureDisable = C.VK_VALIDATION_FEATURE_DISABLE_CORE_CHECKS_EXT
+	ValidationFeatureDisableUniqueHandles         ValidationFeatureDisable = C.VK_VALIDATION_FEATURE_DISABLE_UNIQUE_HANDLES_EXT
+	ValidationFeatureDisableShaderValidationCache ValidationFeatureDisable = C.VK_VALIDATION_FEATURE_DISABLE_SHADER_VALIDATION_CACHE_EXT
+)
+
+// ValidationFeatures turns validation layer checks on or off when creating an instance. The
+// instance must enable VK_LAYER_KHRONOS_validation and ExtensionNameValidationFeatures.
+type ValidationFeatures struct {
+	EnabledValidationFeatures  []ValidationFeatureEnable
+	DisabledValidationFeatures []ValidationFeatureDisable
+}
+
+// validateValidationFeatures checks feature combinations the validation layer rejects
+func validateValidationFeatures(features *ValidationFeatures) error {
+	enabled := make(map[ValidationFeatureEnable]bool, len(features.EnabledValidationFeatures))
+	for i, feature := range features.EnabledValidationFeatures {
+		if enabled[feature] {
+			return NewValidationError(fmt.Sprintf("ValidationFeatures.EnabledValidationFeatures[%d]", i), "feature is listed more than once")
+		}
+		enabled[feature] = true
+	}
+	if enabled[ValidationFeatureEnableGPUAssistedReserveBindingSlot] && !enabled[ValidationFeatureEnableGPUAssisted] {
+		return NewValidationError("ValidationFeatures.EnabledValidationFeatures", "GPU-assisted reserve binding slot requires GPU-assisted validation")
+	}
+	if enabled[ValidationFeatureEnableDebugPrintf] && enabled[ValidationFeatureEnableGPUAssisted] {
+		return NewValidationError("ValidationFeatures.EnabledValidationFeatures", "debug printf cannot be combined with GPU-assisted validation")
+	}
+	return nil
+}
+
+// validationFeaturesToC prepends a VkValidationFeaturesEXT struct to the pNext chain next.
+// The struct and its arrays are allocated in C memory and appended to allocations, which the
+// caller must free.
+func validationFeaturesToC(features *ValidationFeatures, next unsafe.Pointer, allocations *[]unsafe.Pointer) (unsafe.Pointer, error) {
+	cFeatures := (*C.VkValidationFeaturesEXT)(C.calloc(1, C.sizeof_VkValidationFeaturesEXT))
+	if cFeatures == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "CreateInstance", "failed to allocate memory for validation features")
+	}
+	*allocations = append(*allocations, unsafe.Pointer(cFeatures))
+
+	cFeatures.sType = C.VK_STRUCTURE_TYPE_VALIDATION_FEATURES_EXT
+	cFeatures.pNext = next
+
+	if n := len(features.EnabledValidationFeatures); n > 0 {
+		cEnabled := (*C.VkValidationFeatureEnableEXT)(C.calloc(C.size_t(n), C.sizeof_VkValidationFeatureEnableEXT))
+		if cEnabled == nil {
+			return nil, NewVulkanError(ErrorOutOfHostMemory, "CreateInstance", "failed to allocate memory for enabled validation features")
+		}
+		*allocations = append(*allocations, unsafe.Pointer(cEnabled))
+		cEnabledSlice := unsafe.Slice(cEnabled, n)
+		for i, feature := range features.EnabledValidationFeatures {
+			cEnabledSlice[i] = C.VkValidationFeatureEnableEXT(feature)
+		}
+		cFeatures.enabledValidationFeatureCount = C.uint32_t(n)
+		cFeatures.pEnabledValidationFeatures = cEnabled
+	}
+
+	if n := len(features.DisabledValidationFeatures); n > 0 {
+		cDisabled := (*C.VkValidationFeatureDisableEXT)(C.calloc(C.size_t(n), C.sizeof_VkValidationFeatureDisableEXT))
+		if cDisabled == nil {
+			return nil, NewVulkanError(ErrorOutOfHostMemory, "CreateInstance", "failed to allocate memory for disabled validation features")
+		}
+		*allocations = append(*allocations, unsafe.Pointer(cDisabled))
+		cDisabledSlice := unsafe.Slice(cDisabled, n)
+		for i, feature := range features.DisabledValidationFeatures {
+			cDisabledSlice[i] = C.VkValidationFeatureDisableEXT(feature)
+		}
+		cFeatures.disabledValidationFeatureCount = C.uint32_t(n)
+		cFeatures.pDisabledValidationFeatures = cDisabled
+	}
+
+	return unsafe.Pointer(cFeatures), nil
+}
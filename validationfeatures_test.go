@@ -0,0 +1,71 @@
+//go:build cgo
+
+package vulkan
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestValidationFeaturesValidation tests rejected validation feature combinations
+func TestValidationFeaturesValidation(t *testing.T) {
+	tests := []struct {
+		name       string
+		features   *ValidationFeatures
+		errorParam string
+	}{
+		{
+			name: "duplicate enabled feature",
+			features: &ValidationFeatures{
+				EnabledValidationFeatures: []ValidationFeatureEnable{
+					ValidationFeatureEnableBestPractices,
+					ValidationFeatureEnableBestPractices,
+				},
+			},
+			errorParam: "ValidationFeatures.EnabledValidationFeatures[1]",
+		},
+		{
+			name: "reserve binding slot without GPU-assisted",
+			features: &ValidationFeatures{
+				EnabledValidationFeatures: []ValidationFeatureEnable{ValidationFeatureEnableGPUAssistedReserveBindingSlot},
+			},
+			errorParam: "ValidationFeatures.EnabledValidationFeatures",
+		},
+		{
+			name: "debug printf with GPU-assisted",
+			features: &ValidationFeatures{
+				EnabledValidationFeatures: []ValidationFeatureEnable{
+					ValidationFeatureEnableGPUAssisted,
+					ValidationFeatureEnableDebugPrintf,
+				},
+			},
+			errorParam: "ValidationFeatures.EnabledValidationFeatures",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CreateInstance(&InstanceCreateInfo{ValidationFeatures: tt.features})
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+
+	valid := &ValidationFeatures{
+		EnabledValidationFeatures: []ValidationFeatureEnable{
+			ValidationFeatureEnableGPUAssisted,
+			ValidationFeatureEnableGPUAssistedReserveBindingSlot,
+			ValidationFeatureEnableBestPractices,
+		},
+		DisabledValidationFeatures: []ValidationFeatureDisable{ValidationFeatureDisableThreadSafety},
+	}
+	if err := validateValidationFeatures(valid); err != nil {
+		t.Errorf("Expected no error for valid features, got %v", err)
+	}
+}
@@ -0,0 +1,210 @@
+package vulkan
+
+/*
+#include <vulkan/vulkan.h>
+#include <stdlib.h>
+
+// Function pointer for VK_EXT_vertex_input_dynamic_state
+// It needs to be loaded dynamically at runtime.
+//
+// IMPORTANT: This is a global static pointer and NOT thread-safe during loading.
+// LoadVertexInputDynamicStateFunctions must be called from a single thread during
+// initialization before any concurrent vertex input dynamic state API usage.
+static PFN_vkCmdSetVertexInputEXT pfn_vkCmdSetVertexInputEXT = NULL;
+
+static int loadVertexInputDynamicStateDeviceFunctions(VkDevice device) {
+    if (device == VK_NULL_HANDLE) {
+        return 0;
+    }
+    pfn_vkCmdSetVertexInputEXT = (PFN_vkCmdSetVertexInputEXT)
+        vkGetDeviceProcAddr(device, "vkCmdSetVertexInputEXT");
+
+    return pfn_vkCmdSetVertexInputEXT != NULL;
+}
+
+// Command buffer wrapper functions return 1 on success, 0 if function pointer is NULL.
+static int call_vkCmdSetVertexInputEXT(
+    VkCommandBuffer commandBuffer,
+    uint32_t vertexBindingDescriptionCount,
+    const VkVertexInputBindingDescription2EXT* pVertexBindingDescriptions,
+    uint32_t vertexAttributeDescriptionCount,
+    const VkVertexInputAttributeDescription2EXT* pVertexAttributeDescriptions) {
+    if (pfn_vkCmdSetVertexInputEXT == NULL) {
+        return 0;
+    }
+    pfn_vkCmdSetVertexInputEXT(commandBuffer, vertexBindingDescriptionCount, pVertexBindingDescriptions,
+        vertexAttributeDescriptionCount, pVertexAttributeDescriptions);
+    return 1;
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// ExtensionNameVertexInputDynamicState is the vertex input dynamic state extension name
+const ExtensionNameVertexInputDynamicState = "VK_EXT_vertex_input_dynamic_state"
+
+// VertexInputRate selects whether a vertex binding advances per vertex or per instance
+type VertexInputRate int32
+
+const (
+	VertexInputRateVertex   VertexInputRate = C.VK_VERTEX_INPUT_RATE_VERTEX
+	VertexInputRateInstance VertexInputRate = C.VK_VERTEX_INPUT_RATE_INSTANCE
+)
+
+// VertexInputBindingDescription2 describes a vertex buffer binding set with CmdSetVertexInputEXT
+type VertexInputBindingDescription2 struct {
+	Binding   uint32
+	Stride    uint32
+	InputRate VertexInputRate
+	// Divisor is the number of instances that share each element of a per-instance binding.
+	// It must be 1 for VertexInputRateVertex. Other values require the
+	// vertexAttributeInstanceRateDivisor feature, and 0 the
+	// vertexAttributeInstanceRateZeroDivisor feature.
+	Divisor uint32
+}
+
+// VertexInputAttributeDescription2 describes a vertex attribute set with CmdSetVertexInputEXT
+type VertexInputAttributeDescription2 struct {
+	Location uint32
+	Binding  uint32
+	Format   Format
+	Offset   uint32
+}
+
+// PhysicalDeviceVertexInputDynamicStateFeatures contains the vertex input dynamic state feature
+type PhysicalDeviceVertexInputDynamicStateFeatures struct {
+	VertexInputDynamicState bool
+}
+
+// LoadVertexInputDynamicStateFunctions loads vertex input dynamic state functions for a device.
+//
+// This function MUST be called after creating a logical device with the
+// VK_EXT_vertex_input_dynamic_state extension enabled and before recording CmdSetVertexInputEXT.
+//
+// IMPORTANT: This function is NOT thread-safe. Only one device is supported at a time;
+// calling this function again will overwrite previously loaded function pointers.
+//
+// Returns false if any vertex input dynamic state function could not be loaded.
+func LoadVertexInputDynamicStateFunctions(device Device) bool {
+	return C.loadVertexInputDynamicStateDeviceFunctions(C.VkDevice(device)) != 0
+}
+
+// vertexInputDynamicStateFeaturesToC prepends a struct enabling the vertex input dynamic state
+// feature to the pNext chain next. The struct is allocated in C memory and appended to
+// allocations, which the caller must free.
+func vertexInputDynamicStateFeaturesToC(features *PhysicalDeviceVertexInputDynamicStateFeatures, next unsafe.Pointer, allocations *[]unsafe.Pointer) (unsafe.Pointer, error) {
+	cVertexInput := (*C.VkPhysicalDeviceVertexInputDynamicStateFeaturesEXT)(C.calloc(1, C.sizeof_VkPhysicalDeviceVertexInputDynamicStateFeaturesEXT))
+	if cVertexInput == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "CreateDevice", "failed to allocate memory for vertex input dynamic state features")
+	}
+	*allocations = append(*allocations, unsafe.Pointer(cVertexInput))
+
+	cVertexInput.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_VERTEX_INPUT_DYNAMIC_STATE_FEATURES_EXT
+	cVertexInput.pNext = next
+	cVertexInput.vertexInputDynamicState = boolToVkBool32(features.VertexInputDynamicState)
+
+	return unsafe.Pointer(cVertexInput), nil
+}
+
+// GetPhysicalDeviceVertexInputDynamicStateFeaturesEXT queries vertex input dynamic state support
+func GetPhysicalDeviceVertexInputDynamicStateFeaturesEXT(physicalDevice PhysicalDevice) PhysicalDeviceVertexInputDynamicStateFeatures {
+	cFeatures2 := (*C.VkPhysicalDeviceFeatures2)(C.calloc(1, C.sizeof_VkPhysicalDeviceFeatures2))
+	cVertexInput := (*C.VkPhysicalDeviceVertexInputDynamicStateFeaturesEXT)(C.calloc(1, C.sizeof_VkPhysicalDeviceVertexInputDynamicStateFeaturesEXT))
+	defer C.free(unsafe.Pointer(cFeatures2))
+	defer C.free(unsafe.Pointer(cVertexInput))
+	if cFeatures2 == nil || cVertexInput == nil {
+		return PhysicalDeviceVertexInputDynamicStateFeatures{}
+	}
+
+	cFeatures2.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_FEATURES_2
+	cFeatures2.pNext = unsafe.Pointer(cVertexInput)
+	cVertexInput.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_VERTEX_INPUT_DYNAMIC_STATE_FEATURES_EXT
+
+	C.vkGetPhysicalDeviceFeatures2(C.VkPhysicalDevice(physicalDevice), cFeatures2)
+
+	return PhysicalDeviceVertexInputDynamicStateFeatures{
+		VertexInputDynamicState: vkBool32ToBool(cVertexInput.vertexInputDynamicState),
+	}
+}
+
+// validateVertexInput checks that bindings and attribute locations are unique, that every
+// attribute reads from a described binding and that divisors match the input rate
+func validateVertexInput(bindings []VertexInputBindingDescription2, attributes []VertexInputAttributeDescription2) error {
+	described := make(map[uint32]bool, len(bindings))
+	for i, binding := range bindings {
+		if described[binding.Binding] {
+			return NewValidationError(fmt.Sprintf("bindings[%d].Binding", i), fmt.Sprintf("binding %d is described more than once", binding.Binding))
+		}
+		described[binding.Binding] = true
+		if binding.InputRate == VertexInputRateVertex && binding.Divisor != 1 {
+			return NewValidationError(fmt.Sprintf("bindings[%d].Divisor", i), "must be 1 for VertexInputRateVertex")
+		}
+	}
+
+	locations := make(map[uint32]bool, len(attributes))
+	for i, attribute := range attributes {
+		if locations[attribute.Location] {
+			return NewValidationError(fmt.Sprintf("attributes[%d].Location", i), fmt.Sprintf("location %d is described more than once", attribute.Location))
+		}
+		locations[attribute.Location] = true
+		if !described[attribute.Binding] {
+			return NewValidationError(fmt.Sprintf("attributes[%d].Binding", i), fmt.Sprintf("binding %d is not described", attribute.Binding))
+		}
+		if attribute.Format == FormatUndefined {
+			return NewValidationError(fmt.Sprintf("attributes[%d].Format", i), "cannot be FormatUndefined")
+		}
+	}
+	return nil
+}
+
+// CmdSetVertexInputEXT sets the vertex bindings and attributes for pipelines created with
+// dynamic vertex input state, so a single pipeline can draw meshes with different vertex
+// layouts. Bind the buffers themselves with CmdBindVertexBuffers or CmdBindVertexBuffers2.
+// Returns an error if LoadVertexInputDynamicStateFunctions was not called.
+func CmdSetVertexInputEXT(commandBuffer CommandBuffer, bindings []VertexInputBindingDescription2, attributes []VertexInputAttributeDescription2) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+	if err := validateVertexInput(bindings, attributes); err != nil {
+		return err
+	}
+
+	var pBindings *C.VkVertexInputBindingDescription2EXT
+	cBindings := make([]C.VkVertexInputBindingDescription2EXT, len(bindings))
+	for i, binding := range bindings {
+		cBindings[i] = C.VkVertexInputBindingDescription2EXT{
+			sType:     C.VK_STRUCTURE_TYPE_VERTEX_INPUT_BINDING_DESCRIPTION_2_EXT,
+			binding:   C.uint32_t(binding.Binding),
+			stride:    C.uint32_t(binding.Stride),
+			inputRate: C.VkVertexInputRate(binding.InputRate),
+			divisor:   C.uint32_t(binding.Divisor),
+		}
+	}
+	if len(cBindings) > 0 {
+		pBindings = &cBindings[0]
+	}
+
+	var pAttributes *C.VkVertexInputAttributeDescription2EXT
+	cAttributes := make([]C.VkVertexInputAttributeDescription2EXT, len(attributes))
+	for i, attribute := range attributes {
+		cAttributes[i] = C.VkVertexInputAttributeDescription2EXT{
+			sType:    C.VK_STRUCTURE_TYPE_VERTEX_INPUT_ATTRIBUTE_DESCRIPTION_2_EXT,
+			location: C.uint32_t(attribute.Location),
+			binding:  C.uint32_t(attribute.Binding),
+			format:   C.VkFormat(attribute.Format),
+			offset:   C.uint32_t(attribute.Offset),
+		}
+	}
+	if len(cAttributes) > 0 {
+		pAttributes = &cAttributes[0]
+	}
+
+	if C.call_vkCmdSetVertexInputEXT(C.VkCommandBuffer(commandBuffer), C.uint32_t(len(cBindings)), pBindings, C.uint32_t(len(cAttributes)), pAttributes) == 0 {
+		return NewVulkanError(ErrorExtensionNotPresent, "CmdSetVertexInputEXT", "vertex input dynamic state extension not loaded - call LoadVertexInputDynamicStateFunctions first")
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+//go:build cgo
+
+package vulkan
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestCmdSetVertexInputValidation tests binding and attribute validation of CmdSetVertexInputEXT
+func TestCmdSetVertexInputValidation(t *testing.T) {
+	fakeCommandBuffer := CommandBuffer(uintptr(0x1234))
+	position := VertexInputBindingDescription2{Binding: 0, Stride: 12, InputRate: VertexInputRateVertex, Divisor: 1}
+
+	tests := []struct {
+		name       string
+		bindings   []VertexInputBindingDescription2
+		attributes []VertexInputAttributeDescription2
+		errorParam string
+	}{
+		{
+			name:       "duplicate binding",
+			bindings:   []VertexInputBindingDescription2{position, position},
+			errorParam: "bindings[1].Binding",
+		},
+		{
+			name:       "per-vertex binding with zero divisor",
+			bindings:   []VertexInputBindingDescription2{{Binding: 0, Stride: 12, InputRate: VertexInputRateVertex}},
+			errorParam: "bindings[0].Divisor",
+		},
+		{
+			name:     "duplicate location",
+			bindings: []VertexInputBindingDescription2{position},
+			attributes: []VertexInputAttributeDescription2{
+				{Location: 0, Binding: 0, Format: FormatR32G32B32Sfloat},
+				{Location: 0, Binding: 0, Format: FormatR32G32Sfloat, Offset: 12},
+			},
+			errorParam: "attributes[1].Location",
+		},
+		{
+			name:       "attribute without binding",
+			bindings:   []VertexInputBindingDescription2{position},
+			attributes: []VertexInputAttributeDescription2{{Location: 0, Binding: 1, Format: FormatR32G32B32Sfloat}},
+			errorParam: "attributes[0].Binding",
+		},
+		{
+			name:       "undefined format",
+			bindings:   []VertexInputBindingDescription2{position},
+			attributes: []VertexInputAttributeDescription2{{Location: 0, Binding: 0}},
+			errorParam: "attributes[0].Format",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CmdSetVertexInputEXT(fakeCommandBuffer, tt.bindings, tt.attributes)
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+
+	// Per-instance bindings may use any divisor; without LoadVertexInputDynamicStateFunctions
+	// the call then reports the missing extension
+	err := CmdSetVertexInputEXT(fakeCommandBuffer,
+		[]VertexInputBindingDescription2{position, {Binding: 1, Stride: 64, InputRate: VertexInputRateInstance, Divisor: 4}},
+		[]VertexInputAttributeDescription2{
+			{Location: 0, Binding: 0, Format: FormatR32G32B32Sfloat},
+			{Location: 1, Binding: 1, Format: FormatR32G32B32A32Sfloat},
+		})
+	if !errors.Is(err, ErrorExtensionNotPresent) {
+		t.Errorf("Expected ErrorExtensionNotPresent, got %v", err)
+	}
+}
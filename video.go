@@ -8,20 +8,22 @@ package vulkan
 // Function pointers for video KHR extension functions
 // These need to be loaded dynamically at runtime.
 //
-// IMPORTANT: These are global static pointers and NOT thread-safe during loading.
-// LoadVideoInstanceFunctions/LoadVideoDeviceFunctions must be called from a single
-// thread during initialization before any concurrent video API usage.
+// IMPORTANT: These are global static pointers shared by every instance and device.
+// LoadVideoInstanceFunctions/LoadVideoDeviceFunctions serialize loading on the Go side,
+// but must not replace the pointers while other goroutines use the video API.
 //
 // NOTE: Only one Vulkan instance/device with video support is supported at a time.
-// Calling the load functions multiple times will overwrite previous function pointers.
+// Loading a different handle overwrites the previous function pointers.
 // Per-device function pointers are not currently supported.
 static PFN_vkGetPhysicalDeviceVideoCapabilitiesKHR pfn_vkGetPhysicalDeviceVideoCapabilitiesKHR = NULL;
+static PFN_vkGetPhysicalDeviceVideoFormatPropertiesKHR pfn_vkGetPhysicalDeviceVideoFormatPropertiesKHR = NULL;
 static PFN_vkCreateVideoSessionKHR pfn_vkCreateVideoSessionKHR = NULL;
 static PFN_vkDestroyVideoSessionKHR pfn_vkDestroyVideoSessionKHR = NULL;
 static PFN_vkGetVideoSessionMemoryRequirementsKHR pfn_vkGetVideoSessionMemoryRequirementsKHR = NULL;
 static PFN_vkBindVideoSessionMemoryKHR pfn_vkBindVideoSessionMemoryKHR = NULL;
 static PFN_vkCreateVideoSessionParametersKHR pfn_vkCreateVideoSessionParametersKHR = NULL;
 static PFN_vkDestroyVideoSessionParametersKHR pfn_vkDestroyVideoSessionParametersKHR = NULL;
+static PFN_vkUpdateVideoSessionParametersKHR pfn_vkUpdateVideoSessionParametersKHR = NULL;
 static PFN_vkCmdBeginVideoCodingKHR pfn_vkCmdBeginVideoCodingKHR = NULL;
 static PFN_vkCmdEndVideoCodingKHR pfn_vkCmdEndVideoCodingKHR = NULL;
 static PFN_vkCmdControlVideoCodingKHR pfn_vkCmdControlVideoCodingKHR = NULL;
@@ -35,7 +37,10 @@ static int loadVideoInstanceFunctions(VkInstance instance) {
     }
     pfn_vkGetPhysicalDeviceVideoCapabilitiesKHR = (PFN_vkGetPhysicalDeviceVideoCapabilitiesKHR)
         vkGetInstanceProcAddr(instance, "vkGetPhysicalDeviceVideoCapabilitiesKHR");
-    return pfn_vkGetPhysicalDeviceVideoCapabilitiesKHR != NULL;
+    pfn_vkGetPhysicalDeviceVideoFormatPropertiesKHR = (PFN_vkGetPhysicalDeviceVideoFormatPropertiesKHR)
+        vkGetInstanceProcAddr(instance, "vkGetPhysicalDeviceVideoFormatPropertiesKHR");
+    return pfn_vkGetPhysicalDeviceVideoCapabilitiesKHR != NULL &&
+           pfn_vkGetPhysicalDeviceVideoFormatPropertiesKHR != NULL;
 }
 
 static int loadVideoDeviceFunctions(VkDevice device) {
@@ -54,6 +59,8 @@ static int loadVideoDeviceFunctions(VkDevice device) {
         vkGetDeviceProcAddr(device, "vkCreateVideoSessionParametersKHR");
     pfn_vkDestroyVideoSessionParametersKHR = (PFN_vkDestroyVideoSessionParametersKHR)
         vkGetDeviceProcAddr(device, "vkDestroyVideoSessionParametersKHR");
+    pfn_vkUpdateVideoSessionParametersKHR = (PFN_vkUpdateVideoSessionParametersKHR)
+        vkGetDeviceProcAddr(device, "vkUpdateVideoSessionParametersKHR");
     pfn_vkCmdBeginVideoCodingKHR = (PFN_vkCmdBeginVideoCodingKHR)
         vkGetDeviceProcAddr(device, "vkCmdBeginVideoCodingKHR");
     pfn_vkCmdEndVideoCodingKHR = (PFN_vkCmdEndVideoCodingKHR)
@@ -73,6 +80,7 @@ static int loadVideoDeviceFunctions(VkDevice device) {
            pfn_vkBindVideoSessionMemoryKHR != NULL &&
            pfn_vkCreateVideoSessionParametersKHR != NULL &&
            pfn_vkDestroyVideoSessionParametersKHR != NULL &&
+           pfn_vkUpdateVideoSessionParametersKHR != NULL &&
            pfn_vkCmdBeginVideoCodingKHR != NULL &&
            pfn_vkCmdEndVideoCodingKHR != NULL &&
            pfn_vkCmdControlVideoCodingKHR != NULL &&
@@ -91,6 +99,17 @@ static VkResult call_vkGetPhysicalDeviceVideoCapabilitiesKHR(
     return pfn_vkGetPhysicalDeviceVideoCapabilitiesKHR(physicalDevice, pVideoProfile, pCapabilities);
 }
 
+static VkResult call_vkGetPhysicalDeviceVideoFormatPropertiesKHR(
+    VkPhysicalDevice physicalDevice,
+    const VkPhysicalDeviceVideoFormatInfoKHR* pVideoFormatInfo,
+    uint32_t* pVideoFormatPropertyCount,
+    VkVideoFormatPropertiesKHR* pVideoFormatProperties) {
+    if (pfn_vkGetPhysicalDeviceVideoFormatPropertiesKHR == NULL) {
+        return VK_ERROR_EXTENSION_NOT_PRESENT;
+    }
+    return pfn_vkGetPhysicalDeviceVideoFormatPropertiesKHR(physicalDevice, pVideoFormatInfo, pVideoFormatPropertyCount, pVideoFormatProperties);
+}
+
 static VkResult call_vkCreateVideoSessionKHR(
     VkDevice device,
     const VkVideoSessionCreateInfoKHR* pCreateInfo,
@@ -153,6 +172,16 @@ static void call_vkDestroyVideoSessionParametersKHR(
     }
 }
 
+static VkResult call_vkUpdateVideoSessionParametersKHR(
+    VkDevice device,
+    VkVideoSessionParametersKHR videoSessionParameters,
+    const VkVideoSessionParametersUpdateInfoKHR* pUpdateInfo) {
+    if (pfn_vkUpdateVideoSessionParametersKHR == NULL) {
+        return VK_ERROR_EXTENSION_NOT_PRESENT;
+    }
+    return pfn_vkUpdateVideoSessionParametersKHR(device, videoSessionParameters, pUpdateInfo);
+}
+
 // Command buffer wrapper functions return 1 on success, 0 if function pointer is NULL.
 // Callers should check return value to detect if LoadVideoDeviceFunctions was not called.
 static int call_vkCmdBeginVideoCodingKHR(
@@ -207,6 +236,12 @@ static int call_vkCmdEncodeVideoKHR(
 */
 import "C"
 
+import (
+	"fmt"
+	"sync"
+	"unsafe"
+)
+
 // Video codec extension name constants
 const (
 	// H.264 (AVC) extensions
@@ -241,6 +276,34 @@ const (
 	VideoCodecOperationEncodeAV1Bit  VideoCodecOperationFlags = 0x00040000
 )
 
+// Image usage flags for video pictures
+const (
+	ImageUsageVideoDecodeDstBitKHR ImageUsageFlags = C.VK_IMAGE_USAGE_VIDEO_DECODE_DST_BIT_KHR
+	ImageUsageVideoDecodeSrcBitKHR ImageUsageFlags = C.VK_IMAGE_USAGE_VIDEO_DECODE_SRC_BIT_KHR
+	ImageUsageVideoDecodeDpbBitKHR ImageUsageFlags = C.VK_IMAGE_USAGE_VIDEO_DECODE_DPB_BIT_KHR
+	ImageUsageVideoEncodeDstBitKHR ImageUsageFlags = C.VK_IMAGE_USAGE_VIDEO_ENCODE_DST_BIT_KHR
+	ImageUsageVideoEncodeSrcBitKHR ImageUsageFlags = C.VK_IMAGE_USAGE_VIDEO_ENCODE_SRC_BIT_KHR
+	ImageUsageVideoEncodeDpbBitKHR ImageUsageFlags = C.VK_IMAGE_USAGE_VIDEO_ENCODE_DPB_BIT_KHR
+)
+
+// Buffer usage flags for video bitstreams
+const (
+	BufferUsageVideoDecodeSrcBitKHR BufferUsageFlags = C.VK_BUFFER_USAGE_VIDEO_DECODE_SRC_BIT_KHR
+	BufferUsageVideoDecodeDstBitKHR BufferUsageFlags = C.VK_BUFFER_USAGE_VIDEO_DECODE_DST_BIT_KHR
+	BufferUsageVideoEncodeSrcBitKHR BufferUsageFlags = C.VK_BUFFER_USAGE_VIDEO_ENCODE_SRC_BIT_KHR
+	BufferUsageVideoEncodeDstBitKHR BufferUsageFlags = C.VK_BUFFER_USAGE_VIDEO_ENCODE_DST_BIT_KHR
+)
+
+// Multi-planar YCbCr formats used for video pictures
+const (
+	FormatG8B8R82Plane420Unorm                 Format = C.VK_FORMAT_G8_B8R8_2PLANE_420_UNORM
+	FormatG10X6B10X6R10X62Plane420Unorm3Pack16 Format = C.VK_FORMAT_G10X6_B10X6R10X6_2PLANE_420_UNORM_3PACK16
+	FormatG12X4B12X4R12X42Plane420Unorm3Pack16 Format = C.VK_FORMAT_G12X4_B12X4R12X4_2PLANE_420_UNORM_3PACK16
+	FormatG8B8R82Plane422Unorm                 Format = C.VK_FORMAT_G8_B8R8_2PLANE_422_UNORM
+	FormatG10X6B10X6R10X62Plane422Unorm3Pack16 Format = C.VK_FORMAT_G10X6_B10X6R10X6_2PLANE_422_UNORM_3PACK16
+	FormatG8B8R83Plane444Unorm                 Format = C.VK_FORMAT_G8_B8_R8_3PLANE_444_UNORM
+)
+
 // VideoChromaSubsampling represents video chroma subsampling formats
 type VideoChromaSubsampling uint32
 
@@ -282,6 +345,16 @@ type VideoCapabilities struct {
 	MaxActiveReferencePictures    uint32
 }
 
+// VideoFormatProperties describes an image format usable with a video profile
+type VideoFormatProperties struct {
+	Format           Format
+	ComponentMapping ComponentMapping
+	ImageCreateFlags ImageCreateFlags
+	ImageType        ImageType
+	ImageTiling      ImageTiling
+	ImageUsageFlags  ImageUsageFlags
+}
+
 // VideoSessionCreateInfo contains parameters for video session creation
 type VideoSessionCreateInfo struct {
 	QueueFamilyIndex       uint32
@@ -297,6 +370,36 @@ type VideoSessionCreateInfo struct {
 type VideoSessionParametersCreateInfo struct {
 	VideoSession           VideoSession
 	VideoSessionParameters VideoSessionParameters
+	// H264 or H265 must be set for sessions with the matching decode profile, even when no
+	// parameter sets are added yet. At most one may be set.
+	H264 *VideoDecodeH264SessionParametersCreateInfo
+	H265 *VideoDecodeH265SessionParametersCreateInfo
+}
+
+// VideoDecodeH264SessionParametersCreateInfo sets the H.264 parameter set capacity and
+// the initial parameter sets. Zero capacities default to the number of sets in AddInfo.
+type VideoDecodeH264SessionParametersCreateInfo struct {
+	MaxStdSPSCount uint32
+	MaxStdPPSCount uint32
+	AddInfo        *VideoDecodeH264SessionParametersAddInfo
+}
+
+// VideoDecodeH265SessionParametersCreateInfo sets the H.265 parameter set capacity and
+// the initial parameter sets. Zero capacities default to the number of sets in AddInfo.
+type VideoDecodeH265SessionParametersCreateInfo struct {
+	MaxStdVPSCount uint32
+	MaxStdSPSCount uint32
+	MaxStdPPSCount uint32
+	AddInfo        *VideoDecodeH265SessionParametersAddInfo
+}
+
+// VideoSessionParametersUpdateInfo adds parameter sets to existing video session parameters.
+// UpdateSequenceCount must be one greater than the previous update, starting at 1.
+// Exactly one of H264AddInfo or H265AddInfo must be set.
+type VideoSessionParametersUpdateInfo struct {
+	UpdateSequenceCount uint32
+	H264AddInfo         *VideoDecodeH264SessionParametersAddInfo
+	H265AddInfo         *VideoDecodeH265SessionParametersAddInfo
 }
 
 // VideoPictureResource contains video picture resource information
@@ -334,14 +437,36 @@ type VideoEncodeInfo struct {
 	}
 }
 
+// videoLoadState serializes loading of the global video function pointers and caches the
+// result per handle, so repeated or concurrent loads for the same handle don't reload.
+var videoLoadState struct {
+	mu             sync.Mutex
+	instance       Instance
+	instanceLoaded bool
+	device         Device
+	deviceLoaded   bool
+}
+
+// loadVideoInstance and loadVideoDevice call into the C loaders. They are variables so tests
+// can count the loads.
+var (
+	loadVideoInstance = func(instance Instance) bool {
+		return C.loadVideoInstanceFunctions(C.VkInstance(instance)) != 0
+	}
+	loadVideoDevice = func(device Device) bool {
+		return C.loadVideoDeviceFunctions(C.VkDevice(device)) != 0
+	}
+)
+
 // LoadVideoInstanceFunctions loads video extension functions that require a Vulkan instance.
 //
 // This function MUST be called after creating a Vulkan instance and before using any video-related
 // functionality. If this function is not called, all video API calls will fail.
 //
-// IMPORTANT: This function is NOT thread-safe. It must be called from a single thread during
-// initialization before any concurrent video API usage. Only one instance is supported at a time;
-// calling this function again will overwrite previously loaded function pointers.
+// Loading is safe to call from multiple goroutines: calls are serialized and a repeated call for
+// the already loaded instance returns the cached result. Only one instance is supported at a time;
+// loading a different instance replaces the function pointers, so it must not race with video API
+// calls that use the previous instance.
 //
 // Returns false if the video extension functions could not be loaded (e.g., if the Vulkan
 // implementation does not support the VK_KHR_video_queue extension).
@@ -353,7 +478,15 @@ type VideoEncodeInfo struct {
 //	    log.Fatal("Failed to load video instance functions - video extensions not supported")
 //	}
 func LoadVideoInstanceFunctions(instance Instance) bool {
-	return C.loadVideoInstanceFunctions(C.VkInstance(instance)) != 0
+	videoLoadState.mu.Lock()
+	defer videoLoadState.mu.Unlock()
+
+	if instance != nil && instance == videoLoadState.instance {
+		return videoLoadState.instanceLoaded
+	}
+	videoLoadState.instance = instance
+	videoLoadState.instanceLoaded = loadVideoInstance(instance)
+	return videoLoadState.instanceLoaded
 }
 
 // LoadVideoDeviceFunctions loads video extension functions that require a Vulkan device.
@@ -361,9 +494,10 @@ func LoadVideoInstanceFunctions(instance Instance) bool {
 // This function MUST be called after creating a logical device and before using any video-related
 // functionality. If this function is not called, all video API calls will fail.
 //
-// IMPORTANT: This function is NOT thread-safe. It must be called from a single thread during
-// initialization before any concurrent video API usage. Only one device is supported at a time;
-// calling this function again will overwrite previously loaded function pointers.
+// Loading is safe to call from multiple goroutines: calls are serialized and a repeated call for
+// the already loaded device returns the cached result. Only one device is supported at a time;
+// loading a different device replaces the function pointers, so it must not race with video
+// commands recorded for the previous device.
 //
 // Returns false if any video extension function could not be loaded. This indicates the device
 // does not fully support the VK_KHR_video_queue extension.
@@ -375,7 +509,23 @@ func LoadVideoInstanceFunctions(instance Instance) bool {
 //	    log.Fatal("Failed to load video device functions - video extensions not supported")
 //	}
 func LoadVideoDeviceFunctions(device Device) bool {
-	return C.loadVideoDeviceFunctions(C.VkDevice(device)) != 0
+	videoLoadState.mu.Lock()
+	defer videoLoadState.mu.Unlock()
+
+	if device != nil && device == videoLoadState.device {
+		return videoLoadState.deviceLoaded
+	}
+	videoLoadState.device = device
+	videoLoadState.deviceLoaded = loadVideoDevice(device)
+	return videoLoadState.deviceLoaded
+}
+
+// VideoFunctionsLoaded reports whether the video device functions were loaded successfully,
+// which is required before creating video sessions or recording video commands
+func VideoFunctionsLoaded() bool {
+	videoLoadState.mu.Lock()
+	defer videoLoadState.mu.Unlock()
+	return videoLoadState.deviceLoaded
 }
 
 // GetVideoCapabilities retrieves video codec capabilities for a physical device
@@ -405,6 +555,7 @@ func GetVideoCapabilities(physicalDevice PhysicalDevice, videoProfile *VideoProf
 		&cVideoProfile,
 		&cCaps,
 	))
+	traceResult("vkGetPhysicalDeviceVideoCapabilitiesKHR", result)
 
 	if result != Success {
 		return nil, NewVulkanError(result, "GetVideoCapabilities", "failed to get video capabilities")
@@ -433,6 +584,264 @@ func GetVideoCapabilities(physicalDevice PhysicalDevice, videoProfile *VideoProf
 	return caps, nil
 }
 
+// GetPhysicalDeviceVideoFormatPropertiesKHR lists the image formats that can be used with a
+// video profile for the given image usage. Query with ImageUsageVideoDecodeDstBitKHR or
+// ImageUsageVideoDecodeDpbBitKHR to choose VideoSessionCreateInfo.PictureFormat and
+// ReferencePictureFormat. LoadVideoInstanceFunctions must be called first.
+func GetPhysicalDeviceVideoFormatPropertiesKHR(physicalDevice PhysicalDevice, videoProfile *VideoProfileInfo, imageUsage ImageUsageFlags) ([]VideoFormatProperties, error) {
+	if physicalDevice == nil {
+		return nil, NewValidationError("physicalDevice", "cannot be nil")
+	}
+	if videoProfile == nil {
+		return nil, NewValidationError("videoProfile", "cannot be nil")
+	}
+	if imageUsage == 0 {
+		return nil, NewValidationError("imageUsage", "cannot be 0")
+	}
+
+	// The profile list and format info are read by the driver through pNext, so keep them in C memory
+	cVideoProfile := (*C.VkVideoProfileInfoKHR)(C.calloc(1, C.sizeof_VkVideoProfileInfoKHR))
+	cProfileList := (*C.VkVideoProfileListInfoKHR)(C.calloc(1, C.sizeof_VkVideoProfileListInfoKHR))
+	cFormatInfo := (*C.VkPhysicalDeviceVideoFormatInfoKHR)(C.calloc(1, C.sizeof_VkPhysicalDeviceVideoFormatInfoKHR))
+	defer C.free(unsafe.Pointer(cVideoProfile))
+	defer C.free(unsafe.Pointer(cProfileList))
+	defer C.free(unsafe.Pointer(cFormatInfo))
+	if cVideoProfile == nil || cProfileList == nil || cFormatInfo == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "GetPhysicalDeviceVideoFormatPropertiesKHR", "failed to allocate memory for format info")
+	}
+
+	cVideoProfile.sType = C.VK_STRUCTURE_TYPE_VIDEO_PROFILE_INFO_KHR
+	cVideoProfile.videoCodecOperation = C.VkVideoCodecOperationFlagBitsKHR(videoProfile.VideoCodecOperation)
+	cVideoProfile.chromaSubsampling = C.VkVideoChromaSubsamplingFlagsKHR(videoProfile.ChromaSubsampling)
+	cVideoProfile.lumaBitDepth = C.VkVideoComponentBitDepthFlagsKHR(videoProfile.LumaBitDepth)
+	cVideoProfile.chromaBitDepth = C.VkVideoComponentBitDepthFlagsKHR(videoProfile.ChromaBitDepth)
+
+	cProfileList.sType = C.VK_STRUCTURE_TYPE_VIDEO_PROFILE_LIST_INFO_KHR
+	cProfileList.profileCount = 1
+	cProfileList.pProfiles = cVideoProfile
+
+	cFormatInfo.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_VIDEO_FORMAT_INFO_KHR
+	cFormatInfo.pNext = unsafe.Pointer(cProfileList)
+	cFormatInfo.imageUsage = C.VkImageUsageFlags(imageUsage)
+
+	var count C.uint32_t
+	result := Result(C.call_vkGetPhysicalDeviceVideoFormatPropertiesKHR(C.VkPhysicalDevice(physicalDevice), cFormatInfo, &count, nil))
+	traceResult("vkGetPhysicalDeviceVideoFormatPropertiesKHR", result)
+	if result != Success {
+		return nil, NewVulkanError(result, "GetPhysicalDeviceVideoFormatPropertiesKHR", "failed to get video format count")
+	}
+	if count == 0 {
+		return nil, nil
+	}
+
+	cProps := (*C.VkVideoFormatPropertiesKHR)(C.calloc(C.size_t(count), C.sizeof_VkVideoFormatPropertiesKHR))
+	if cProps == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "GetPhysicalDeviceVideoFormatPropertiesKHR", "failed to allocate memory for format properties")
+	}
+	defer C.free(unsafe.Pointer(cProps))
+	cPropsSlice := unsafe.Slice(cProps, count)
+	for i := range cPropsSlice {
+		cPropsSlice[i].sType = C.VK_STRUCTURE_TYPE_VIDEO_FORMAT_PROPERTIES_KHR
+	}
+
+	result = Result(C.call_vkGetPhysicalDeviceVideoFormatPropertiesKHR(C.VkPhysicalDevice(physicalDevice), cFormatInfo, &count, cProps))
+	traceResult("vkGetPhysicalDeviceVideoFormatPropertiesKHR", result)
+	if result != Success && result != Incomplete {
+		return nil, NewVulkanError(result, "GetPhysicalDeviceVideoFormatPropertiesKHR", "failed to get video format properties")
+	}
+
+	properties := make([]VideoFormatProperties, count)
+	for i := range properties {
+		p := &cPropsSlice[i]
+		properties[i] = VideoFormatProperties{
+			Format: Format(p.format),
+			ComponentMapping: ComponentMapping{
+				R: ComponentSwizzle(p.componentMapping.r),
+				G: ComponentSwizzle(p.componentMapping.g),
+				B: ComponentSwizzle(p.componentMapping.b),
+				A: ComponentSwizzle(p.componentMapping.a),
+			},
+			ImageCreateFlags: ImageCreateFlags(p.imageCreateFlags),
+			ImageType:        ImageType(p.imageType),
+			ImageTiling:      ImageTiling(p.imageTiling),
+			ImageUsageFlags:  ImageUsageFlags(p.imageUsageFlags),
+		}
+	}
+	return properties, nil
+}
+
+// AlignBitstreamOffset rounds offset up to MinBitstreamBufferOffsetAlign
+func (c *VideoCapabilities) AlignBitstreamOffset(offset DeviceSize) DeviceSize {
+	return alignVideoSize(offset, c.MinBitstreamBufferOffsetAlign)
+}
+
+// AlignBitstreamSize rounds size up to MinBitstreamBufferSizeAlign
+func (c *VideoCapabilities) AlignBitstreamSize(size DeviceSize) DeviceSize {
+	return alignVideoSize(size, c.MinBitstreamBufferSizeAlign)
+}
+
+func alignVideoSize(value, alignment DeviceSize) DeviceSize {
+	if alignment <= 1 {
+		return value
+	}
+	return (value + alignment - 1) / alignment * alignment
+}
+
+// VideoPictureFormat returns the multi-planar format conventionally used for pictures of a
+// profile, such as NV12 for 8-bit 4:2:0. Drivers may support other formats; confirm with
+// GetPhysicalDeviceVideoFormatPropertiesKHR.
+func VideoPictureFormat(profile *VideoProfileInfo) (Format, bool) {
+	if profile == nil || profile.LumaBitDepth != profile.ChromaBitDepth {
+		return FormatUndefined, false
+	}
+
+	switch profile.ChromaSubsampling {
+	case VideoChromaSubsampling420:
+		switch profile.LumaBitDepth {
+		case VideoComponentBitDepth8:
+			return FormatG8B8R82Plane420Unorm, true
+		case VideoComponentBitDepth10:
+			return FormatG10X6B10X6R10X62Plane420Unorm3Pack16, true
+		case VideoComponentBitDepth12:
+			return FormatG12X4B12X4R12X42Plane420Unorm3Pack16, true
+		}
+	case VideoChromaSubsampling422:
+		switch profile.LumaBitDepth {
+		case VideoComponentBitDepth8:
+			return FormatG8B8R82Plane422Unorm, true
+		case VideoComponentBitDepth10:
+			return FormatG10X6B10X6R10X62Plane422Unorm3Pack16, true
+		}
+	case VideoChromaSubsampling444:
+		if profile.LumaBitDepth == VideoComponentBitDepth8 {
+			return FormatG8B8R83Plane444Unorm, true
+		}
+	}
+	return FormatUndefined, false
+}
+
+// VideoDecodeResources holds the buffer and images a decoder feeds to CmdDecodeVideo
+type VideoDecodeResources struct {
+	// BitstreamBuffer is the decode source buffer, sized for one uncompressed frame at
+	// MaxCodedExtent and rounded up to MinBitstreamBufferSizeAlign. Place slices at offsets
+	// returned by VideoCapabilities.AlignBitstreamOffset.
+	BitstreamBuffer     Buffer
+	BitstreamBufferSize DeviceSize
+	// DpbImage is the decoded picture buffer, one array layer per DPB slot
+	DpbImage  Image
+	DpbFormat Format
+	DpbSlots  uint32
+}
+
+// CreateVideoDecodeResources creates a bitstream buffer and a DPB image array for a decode
+// profile. Both are created with the profile attached, as the video usages require. Memory
+// is not bound; use GetBufferMemoryRequirements and GetImageMemoryRequirements, then bind
+// before recording decode commands.
+func CreateVideoDecodeResources(device Device, caps *VideoCapabilities, profile *VideoProfileInfo, numSlots uint32) (*VideoDecodeResources, error) {
+	if device == nil {
+		return nil, NewValidationError("device", "cannot be nil")
+	}
+	if caps == nil {
+		return nil, NewValidationError("caps", "cannot be nil")
+	}
+	if profile == nil {
+		return nil, NewValidationError("profile", "cannot be nil")
+	}
+	if numSlots == 0 || numSlots > caps.MaxDpbSlots {
+		return nil, NewValidationError("numSlots", fmt.Sprintf("must be between 1 and MaxDpbSlots (%d)", caps.MaxDpbSlots))
+	}
+	if caps.MaxCodedExtent.Width == 0 || caps.MaxCodedExtent.Height == 0 {
+		return nil, NewValidationError("caps.MaxCodedExtent", "must be non-zero")
+	}
+	dpbFormat, ok := VideoPictureFormat(profile)
+	if !ok {
+		return nil, NewValidationError("profile", "no picture format for this chroma subsampling and bit depth")
+	}
+
+	cVideoProfile := (*C.VkVideoProfileInfoKHR)(C.calloc(1, C.sizeof_VkVideoProfileInfoKHR))
+	cProfileList := (*C.VkVideoProfileListInfoKHR)(C.calloc(1, C.sizeof_VkVideoProfileListInfoKHR))
+	defer C.free(unsafe.Pointer(cVideoProfile))
+	defer C.free(unsafe.Pointer(cProfileList))
+	if cVideoProfile == nil || cProfileList == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "CreateVideoDecodeResources", "failed to allocate memory for video profile")
+	}
+
+	cVideoProfile.sType = C.VK_STRUCTURE_TYPE_VIDEO_PROFILE_INFO_KHR
+	cVideoProfile.videoCodecOperation = C.VkVideoCodecOperationFlagBitsKHR(profile.VideoCodecOperation)
+	cVideoProfile.chromaSubsampling = C.VkVideoChromaSubsamplingFlagsKHR(profile.ChromaSubsampling)
+	cVideoProfile.lumaBitDepth = C.VkVideoComponentBitDepthFlagsKHR(profile.LumaBitDepth)
+	cVideoProfile.chromaBitDepth = C.VkVideoComponentBitDepthFlagsKHR(profile.ChromaBitDepth)
+
+	cProfileList.sType = C.VK_STRUCTURE_TYPE_VIDEO_PROFILE_LIST_INFO_KHR
+	cProfileList.profileCount = 1
+	cProfileList.pProfiles = cVideoProfile
+
+	// An uncompressed 4:2:0 frame bounds the size of any single compressed frame
+	frameSize := DeviceSize(caps.MaxCodedExtent.Width) * DeviceSize(caps.MaxCodedExtent.Height) * 3 / 2
+	resources := &VideoDecodeResources{
+		BitstreamBufferSize: caps.AlignBitstreamSize(frameSize),
+		DpbFormat:           dpbFormat,
+		DpbSlots:            numSlots,
+	}
+
+	var cBufferInfo C.VkBufferCreateInfo
+	cBufferInfo.sType = C.VK_STRUCTURE_TYPE_BUFFER_CREATE_INFO
+	cBufferInfo.pNext = unsafe.Pointer(cProfileList)
+	cBufferInfo.size = C.VkDeviceSize(resources.BitstreamBufferSize)
+	cBufferInfo.usage = C.VkBufferUsageFlags(BufferUsageVideoDecodeSrcBitKHR)
+	cBufferInfo.sharingMode = C.VK_SHARING_MODE_EXCLUSIVE
+
+	var buffer C.VkBuffer
+	result := Result(C.vkCreateBuffer(C.VkDevice(device), &cBufferInfo, nil, &buffer))
+	traceResult("vkCreateBuffer", result)
+	if result != Success {
+		return nil, NewVulkanError(result, "CreateVideoDecodeResources", "failed to create bitstream buffer")
+	}
+	resources.BitstreamBuffer = Buffer(buffer)
+
+	var cImageInfo C.VkImageCreateInfo
+	cImageInfo.sType = C.VK_STRUCTURE_TYPE_IMAGE_CREATE_INFO
+	cImageInfo.pNext = unsafe.Pointer(cProfileList)
+	cImageInfo.imageType = C.VK_IMAGE_TYPE_2D
+	cImageInfo.format = C.VkFormat(dpbFormat)
+	cImageInfo.extent.width = C.uint32_t(caps.MaxCodedExtent.Width)
+	cImageInfo.extent.height = C.uint32_t(caps.MaxCodedExtent.Height)
+	cImageInfo.extent.depth = 1
+	cImageInfo.mipLevels = 1
+	cImageInfo.arrayLayers = C.uint32_t(numSlots)
+	cImageInfo.samples = C.VK_SAMPLE_COUNT_1_BIT
+	cImageInfo.tiling = C.VK_IMAGE_TILING_OPTIMAL
+	cImageInfo.usage = C.VkImageUsageFlags(ImageUsageVideoDecodeDpbBitKHR)
+	cImageInfo.sharingMode = C.VK_SHARING_MODE_EXCLUSIVE
+	cImageInfo.initialLayout = C.VK_IMAGE_LAYOUT_UNDEFINED
+
+	var image C.VkImage
+	result = Result(C.vkCreateImage(C.VkDevice(device), &cImageInfo, nil, &image))
+	traceResult("vkCreateImage", result)
+	if result != Success {
+		DestroyBuffer(device, resources.BitstreamBuffer)
+		return nil, NewVulkanError(result, "CreateVideoDecodeResources", "failed to create DPB image")
+	}
+	resources.DpbImage = Image(image)
+
+	return resources, nil
+}
+
+// Destroy destroys the bitstream buffer and DPB image. Bound memory must be freed separately.
+func (r *VideoDecodeResources) Destroy(device Device) {
+	if r == nil || device == nil {
+		return
+	}
+	if r.DpbImage != nil {
+		DestroyImage(device, r.DpbImage)
+		r.DpbImage = nil
+	}
+	if r.BitstreamBuffer != nil {
+		DestroyBuffer(device, r.BitstreamBuffer)
+		r.BitstreamBuffer = nil
+	}
+}
+
 // CreateVideoSession creates a video session for encoding or decoding
 func CreateVideoSession(device Device, createInfo *VideoSessionCreateInfo) (VideoSession, error) {
 	if device == nil {
@@ -475,6 +884,7 @@ func CreateVideoSession(device Device, createInfo *VideoSessionCreateInfo) (Vide
 		nil,
 		&videoSession,
 	))
+	traceResult("vkCreateVideoSessionKHR", result)
 
 	if result != Success {
 		return VideoSession(NullHandle), NewVulkanError(result, "CreateVideoSession", "failed to create video session")
@@ -507,6 +917,7 @@ func GetVideoSessionMemoryRequirements(device Device, videoSession VideoSession)
 		&memReqCount,
 		nil,
 	))
+	traceResult("vkGetVideoSessionMemoryRequirementsKHR", result)
 
 	if result != Success {
 		return nil, NewVulkanError(result, "GetVideoSessionMemoryRequirements", "failed to get memory requirements count")
@@ -528,6 +939,7 @@ func GetVideoSessionMemoryRequirements(device Device, videoSession VideoSession)
 		&memReqCount,
 		&cMemReqs[0],
 	))
+	traceResult("vkGetVideoSessionMemoryRequirementsKHR", result)
 
 	if result != Success {
 		return nil, NewVulkanError(result, "GetVideoSessionMemoryRequirements", "failed to get memory requirements")
@@ -573,6 +985,7 @@ func BindVideoSessionMemory(device Device, videoSession VideoSession, bindInfos
 		C.uint32_t(len(bindInfos)),
 		&cBindInfos[0],
 	))
+	traceResult("vkBindVideoSessionMemoryKHR", result)
 
 	if result != Success {
 		return NewVulkanError(result, "BindVideoSessionMemory", "failed to bind video session memory")
@@ -597,10 +1010,21 @@ func CreateVideoSessionParameters(device Device, createInfo *VideoSessionParamet
 	if createInfo == nil {
 		return VideoSessionParameters(NullHandle), NewValidationError("createInfo", "cannot be nil")
 	}
+	if createInfo.H264 != nil && createInfo.H265 != nil {
+		return VideoSessionParameters(NullHandle), NewValidationError("createInfo", "only one of H264 or H265 can be set")
+	}
+
+	var allocations []unsafe.Pointer
+	defer func() { freeAllocations(allocations) }()
+
+	codecInfo, err := videoSessionParametersCodecInfoToC(createInfo, &allocations)
+	if err != nil {
+		return VideoSessionParameters(NullHandle), err
+	}
 
 	var cCreateInfo C.VkVideoSessionParametersCreateInfoKHR
 	cCreateInfo.sType = C.VK_STRUCTURE_TYPE_VIDEO_SESSION_PARAMETERS_CREATE_INFO_KHR
-	cCreateInfo.pNext = nil
+	cCreateInfo.pNext = codecInfo
 	cCreateInfo.flags = 0
 	cCreateInfo.videoSessionParametersTemplate = C.VkVideoSessionParametersKHR(createInfo.VideoSessionParameters)
 	cCreateInfo.videoSession = C.VkVideoSessionKHR(createInfo.VideoSession)
@@ -612,6 +1036,7 @@ func CreateVideoSessionParameters(device Device, createInfo *VideoSessionParamet
 		nil,
 		&videoSessionParams,
 	))
+	traceResult("vkCreateVideoSessionParametersKHR", result)
 
 	if result != Success {
 		return VideoSessionParameters(NullHandle), NewVulkanError(result, "CreateVideoSessionParameters", "failed to create video session parameters")
@@ -620,6 +1045,114 @@ func CreateVideoSessionParameters(device Device, createInfo *VideoSessionParamet
 	return VideoSessionParameters(videoSessionParams), nil
 }
 
+// videoSessionParametersCodecInfoToC builds the codec-specific create info chain in C memory
+func videoSessionParametersCodecInfoToC(createInfo *VideoSessionParametersCreateInfo, allocations *[]unsafe.Pointer) (unsafe.Pointer, error) {
+	switch {
+	case createInfo.H264 != nil:
+		ptr, err := videoCalloc(1, C.sizeof_VkVideoDecodeH264SessionParametersCreateInfoKHR, allocations)
+		if err != nil {
+			return nil, err
+		}
+		cInfo := (*C.VkVideoDecodeH264SessionParametersCreateInfoKHR)(ptr)
+		cInfo.sType = C.VK_STRUCTURE_TYPE_VIDEO_DECODE_H264_SESSION_PARAMETERS_CREATE_INFO_KHR
+		cInfo.maxStdSPSCount = C.uint32_t(createInfo.H264.MaxStdSPSCount)
+		cInfo.maxStdPPSCount = C.uint32_t(createInfo.H264.MaxStdPPSCount)
+		if addInfo := createInfo.H264.AddInfo; addInfo != nil {
+			if cInfo.maxStdSPSCount == 0 {
+				cInfo.maxStdSPSCount = C.uint32_t(len(addInfo.SPSs))
+			}
+			if cInfo.maxStdPPSCount == 0 {
+				cInfo.maxStdPPSCount = C.uint32_t(len(addInfo.PPSs))
+			}
+			if cInfo.pParametersAddInfo, err = h264AddInfoToC(addInfo, allocations); err != nil {
+				return nil, err
+			}
+		}
+		return ptr, nil
+
+	case createInfo.H265 != nil:
+		ptr, err := videoCalloc(1, C.sizeof_VkVideoDecodeH265SessionParametersCreateInfoKHR, allocations)
+		if err != nil {
+			return nil, err
+		}
+		cInfo := (*C.VkVideoDecodeH265SessionParametersCreateInfoKHR)(ptr)
+		cInfo.sType = C.VK_STRUCTURE_TYPE_VIDEO_DECODE_H265_SESSION_PARAMETERS_CREATE_INFO_KHR
+		cInfo.maxStdVPSCount = C.uint32_t(createInfo.H265.MaxStdVPSCount)
+		cInfo.maxStdSPSCount = C.uint32_t(createInfo.H265.MaxStdSPSCount)
+		cInfo.maxStdPPSCount = C.uint32_t(createInfo.H265.MaxStdPPSCount)
+		if addInfo := createInfo.H265.AddInfo; addInfo != nil {
+			if cInfo.maxStdVPSCount == 0 {
+				cInfo.maxStdVPSCount = C.uint32_t(len(addInfo.VPSs))
+			}
+			if cInfo.maxStdSPSCount == 0 {
+				cInfo.maxStdSPSCount = C.uint32_t(len(addInfo.SPSs))
+			}
+			if cInfo.maxStdPPSCount == 0 {
+				cInfo.maxStdPPSCount = C.uint32_t(len(addInfo.PPSs))
+			}
+			if cInfo.pParametersAddInfo, err = h265AddInfoToC(addInfo, allocations); err != nil {
+				return nil, err
+			}
+		}
+		return ptr, nil
+	}
+	return nil, nil
+}
+
+// UpdateVideoSessionParameters adds H.264 or H.265 parameter sets to video session parameters.
+// LoadVideoDeviceFunctions must be called first.
+func UpdateVideoSessionParameters(device Device, params VideoSessionParameters, updateInfo *VideoSessionParametersUpdateInfo) error {
+	if device == nil {
+		return NewValidationError("device", "cannot be nil")
+	}
+	if params == VideoSessionParameters(NullHandle) {
+		return NewValidationError("params", "cannot be null")
+	}
+	if updateInfo == nil {
+		return NewValidationError("updateInfo", "cannot be nil")
+	}
+	if updateInfo.UpdateSequenceCount == 0 {
+		return NewValidationError("updateInfo.UpdateSequenceCount", "must be greater than 0")
+	}
+	if (updateInfo.H264AddInfo == nil) == (updateInfo.H265AddInfo == nil) {
+		return NewValidationError("updateInfo", "exactly one of H264AddInfo or H265AddInfo must be set")
+	}
+
+	var allocations []unsafe.Pointer
+	defer func() { freeAllocations(allocations) }()
+
+	var addInfo unsafe.Pointer
+	if updateInfo.H264AddInfo != nil {
+		cAddInfo, err := h264AddInfoToC(updateInfo.H264AddInfo, &allocations)
+		if err != nil {
+			return err
+		}
+		addInfo = unsafe.Pointer(cAddInfo)
+	} else {
+		cAddInfo, err := h265AddInfoToC(updateInfo.H265AddInfo, &allocations)
+		if err != nil {
+			return err
+		}
+		addInfo = unsafe.Pointer(cAddInfo)
+	}
+
+	var cUpdateInfo C.VkVideoSessionParametersUpdateInfoKHR
+	cUpdateInfo.sType = C.VK_STRUCTURE_TYPE_VIDEO_SESSION_PARAMETERS_UPDATE_INFO_KHR
+	cUpdateInfo.pNext = addInfo
+	cUpdateInfo.updateSequenceCount = C.uint32_t(updateInfo.UpdateSequenceCount)
+
+	result := Result(C.call_vkUpdateVideoSessionParametersKHR(
+		C.VkDevice(device),
+		C.VkVideoSessionParametersKHR(params),
+		&cUpdateInfo,
+	))
+	traceResult("vkUpdateVideoSessionParametersKHR", result)
+	if result != Success {
+		return NewVulkanError(result, "UpdateVideoSessionParameters", "failed to update video session parameters")
+	}
+	return nil
+}
+
 // DestroyVideoSessionParameters destroys video session parameters
 func DestroyVideoSessionParameters(device Device, videoSessionParameters VideoSessionParameters) {
 	if device == nil || videoSessionParameters == VideoSessionParameters(NullHandle) {
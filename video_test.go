@@ -1,7 +1,11 @@
+//go:build cgo
+
 package vulkan
 
 import (
 	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
 )
 
@@ -603,3 +607,322 @@ func BenchmarkVideoSessionCreateInfoCreation(b *testing.B) {
 		}
 	}
 }
+
+// TestFindVideoQueueFamily tests selecting a queue family by video codec operation
+func TestFindVideoQueueFamily(t *testing.T) {
+	properties := []QueueFamilyProperties2{
+		{QueueFamilyProperties: QueueFamilyProperties{QueueFlags: QueueGraphicsBit, QueueCount: 1}},
+		{
+			QueueFamilyProperties: QueueFamilyProperties{QueueFlags: QueueVideoDecodeBitKHR, QueueCount: 1},
+			VideoCodecOperations:  VideoCodecOperationDecodeH264Bit | VideoCodecOperationDecodeH265Bit,
+		},
+		{
+			QueueFamilyProperties: QueueFamilyProperties{QueueFlags: QueueVideoDecodeBitKHR, QueueCount: 1},
+			VideoCodecOperations:  VideoCodecOperationDecodeAV1Bit,
+		},
+	}
+
+	tests := []struct {
+		name      string
+		operation VideoCodecOperationFlags
+		index     uint32
+		found     bool
+	}{
+		{"H.265 decode", VideoCodecOperationDecodeH265Bit, 1, true},
+		{"AV1 decode", VideoCodecOperationDecodeAV1Bit, 2, true},
+		{"H.264 encode", VideoCodecOperationEncodeH264Bit, 0, false},
+		{"no operation", VideoCodecOperationNone, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			index, found := FindVideoQueueFamily(properties, tt.operation)
+			if found != tt.found || index != tt.index {
+				t.Errorf("Expected (%d, %v), got (%d, %v)", tt.index, tt.found, index, found)
+			}
+		})
+	}
+}
+
+// TestGetPhysicalDeviceVideoFormatPropertiesValidation tests input validation for GetPhysicalDeviceVideoFormatPropertiesKHR
+func TestGetPhysicalDeviceVideoFormatPropertiesValidation(t *testing.T) {
+	fakePhysicalDevice := PhysicalDevice(uintptr(0x1234))
+	profile := &VideoProfileInfo{
+		VideoCodecOperation: VideoCodecOperationDecodeH264Bit,
+		ChromaSubsampling:   VideoChromaSubsampling420,
+		LumaBitDepth:        VideoComponentBitDepth8,
+		ChromaBitDepth:      VideoComponentBitDepth8,
+	}
+
+	tests := []struct {
+		name           string
+		physicalDevice PhysicalDevice
+		videoProfile   *VideoProfileInfo
+		imageUsage     ImageUsageFlags
+		errorParam     string
+	}{
+		{"nil physical device", nil, profile, ImageUsageVideoDecodeDstBitKHR, "physicalDevice"},
+		{"nil video profile", fakePhysicalDevice, nil, ImageUsageVideoDecodeDstBitKHR, "videoProfile"},
+		{"zero image usage", fakePhysicalDevice, profile, 0, "imageUsage"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := GetPhysicalDeviceVideoFormatPropertiesKHR(tt.physicalDevice, tt.videoProfile, tt.imageUsage)
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+}
+
+// TestVideoBitstreamAlignment tests bitstream offset and size alignment helpers
+func TestVideoBitstreamAlignment(t *testing.T) {
+	caps := &VideoCapabilities{MinBitstreamBufferOffsetAlign: 256, MinBitstreamBufferSizeAlign: 4096}
+
+	if got := caps.AlignBitstreamOffset(300); got != 512 {
+		t.Errorf("Expected aligned offset 512, got %d", got)
+	}
+	if got := caps.AlignBitstreamOffset(512); got != 512 {
+		t.Errorf("Expected already aligned offset to stay 512, got %d", got)
+	}
+	if got := caps.AlignBitstreamSize(1); got != 4096 {
+		t.Errorf("Expected aligned size 4096, got %d", got)
+	}
+
+	unaligned := &VideoCapabilities{}
+	if got := unaligned.AlignBitstreamSize(123); got != 123 {
+		t.Errorf("Expected size unchanged without alignment, got %d", got)
+	}
+}
+
+// TestVideoPictureFormat tests the picture format chosen for video profiles
+func TestVideoPictureFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile *VideoProfileInfo
+		format  Format
+		ok      bool
+	}{
+		{"8-bit 4:2:0", &VideoProfileInfo{ChromaSubsampling: VideoChromaSubsampling420, LumaBitDepth: VideoComponentBitDepth8, ChromaBitDepth: VideoComponentBitDepth8}, FormatG8B8R82Plane420Unorm, true},
+		{"10-bit 4:2:0", &VideoProfileInfo{ChromaSubsampling: VideoChromaSubsampling420, LumaBitDepth: VideoComponentBitDepth10, ChromaBitDepth: VideoComponentBitDepth10}, FormatG10X6B10X6R10X62Plane420Unorm3Pack16, true},
+		{"8-bit 4:4:4", &VideoProfileInfo{ChromaSubsampling: VideoChromaSubsampling444, LumaBitDepth: VideoComponentBitDepth8, ChromaBitDepth: VideoComponentBitDepth8}, FormatG8B8R83Plane444Unorm, true},
+		{"mixed bit depths", &VideoProfileInfo{ChromaSubsampling: VideoChromaSubsampling420, LumaBitDepth: VideoComponentBitDepth8, ChromaBitDepth: VideoComponentBitDepth10}, FormatUndefined, false},
+		{"nil profile", nil, FormatUndefined, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, ok := VideoPictureFormat(tt.profile)
+			if format != tt.format || ok != tt.ok {
+				t.Errorf("Expected (%d, %v), got (%d, %v)", tt.format, tt.ok, format, ok)
+			}
+		})
+	}
+}
+
+// TestCreateVideoDecodeResourcesValidation tests input validation for CreateVideoDecodeResources
+func TestCreateVideoDecodeResourcesValidation(t *testing.T) {
+	fakeDevice := Device(uintptr(0x1234))
+	caps := &VideoCapabilities{MaxCodedExtent: Extent2D{Width: 1920, Height: 1088}, MaxDpbSlots: 17}
+	profile := &VideoProfileInfo{
+		VideoCodecOperation: VideoCodecOperationDecodeH264Bit,
+		ChromaSubsampling:   VideoChromaSubsampling420,
+		LumaBitDepth:        VideoComponentBitDepth8,
+		ChromaBitDepth:      VideoComponentBitDepth8,
+	}
+
+	tests := []struct {
+		name       string
+		device     Device
+		caps       *VideoCapabilities
+		profile    *VideoProfileInfo
+		numSlots   uint32
+		errorParam string
+	}{
+		{"nil device", nil, caps, profile, 4, "device"},
+		{"nil caps", fakeDevice, nil, profile, 4, "caps"},
+		{"nil profile", fakeDevice, caps, nil, 4, "profile"},
+		{"zero slots", fakeDevice, caps, profile, 0, "numSlots"},
+		{"too many slots", fakeDevice, caps, profile, 18, "numSlots"},
+		{"unsupported profile format", fakeDevice, caps, &VideoProfileInfo{ChromaSubsampling: VideoChromaSubsamplingMonochrome, LumaBitDepth: VideoComponentBitDepth8, ChromaBitDepth: VideoComponentBitDepth8}, 4, "profile"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CreateVideoDecodeResources(tt.device, tt.caps, tt.profile, tt.numSlots)
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+}
+
+// TestUpdateVideoSessionParametersValidation tests input validation for UpdateVideoSessionParameters
+func TestUpdateVideoSessionParametersValidation(t *testing.T) {
+	fakeDevice := Device(uintptr(0x1234))
+	fakeParams := VideoSessionParameters(uintptr(0x5678))
+	h264 := &VideoDecodeH264SessionParametersAddInfo{
+		SPSs: []H264SequenceParameterSet{{ProfileIdc: H264ProfileIdcHigh, LevelIdc: H264LevelIdc4_1, Flags: H264SpsFrameMbsOnlyBit}},
+		PPSs: []H264PictureParameterSet{{Flags: H264PpsEntropyCodingModeBit}},
+	}
+
+	tests := []struct {
+		name       string
+		device     Device
+		params     VideoSessionParameters
+		updateInfo *VideoSessionParametersUpdateInfo
+		errorParam string
+	}{
+		{"nil device", nil, fakeParams, &VideoSessionParametersUpdateInfo{UpdateSequenceCount: 1, H264AddInfo: h264}, "device"},
+		{"null params", fakeDevice, VideoSessionParameters(NullHandle), &VideoSessionParametersUpdateInfo{UpdateSequenceCount: 1, H264AddInfo: h264}, "params"},
+		{"nil update info", fakeDevice, fakeParams, nil, "updateInfo"},
+		{"zero sequence count", fakeDevice, fakeParams, &VideoSessionParametersUpdateInfo{H264AddInfo: h264}, "updateInfo.UpdateSequenceCount"},
+		{"no codec", fakeDevice, fakeParams, &VideoSessionParametersUpdateInfo{UpdateSequenceCount: 1}, "updateInfo"},
+		{"both codecs", fakeDevice, fakeParams, &VideoSessionParametersUpdateInfo{UpdateSequenceCount: 1, H264AddInfo: h264, H265AddInfo: &VideoDecodeH265SessionParametersAddInfo{}}, "updateInfo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := UpdateVideoSessionParameters(tt.device, tt.params, tt.updateInfo)
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+}
+
+// TestCreateVideoSessionParametersCodecValidation tests that only one codec add-info is accepted
+func TestCreateVideoSessionParametersCodecValidation(t *testing.T) {
+	_, err := CreateVideoSessionParameters(Device(uintptr(0x1234)), &VideoSessionParametersCreateInfo{
+		VideoSession: VideoSession(uintptr(0x5678)),
+		H264:         &VideoDecodeH264SessionParametersCreateInfo{},
+		H265:         &VideoDecodeH265SessionParametersCreateInfo{},
+	})
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Parameter != "createInfo" {
+		t.Errorf("Expected ValidationError for createInfo, got %v", err)
+	}
+}
+
+// TestLoadVideoFunctionsConcurrent tests that concurrent load attempts are serialized.
+// Run with -race to check the Go-side load state.
+func TestLoadVideoFunctionsConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if LoadVideoInstanceFunctions(nil) {
+				t.Error("Expected loading with nil instance to fail")
+			}
+			if LoadVideoDeviceFunctions(nil) {
+				t.Error("Expected loading with nil device to fail")
+			}
+			_ = VideoFunctionsLoaded()
+		}()
+	}
+	wg.Wait()
+
+	if VideoFunctionsLoaded() {
+		t.Error("Expected video functions not to be loaded after nil device load")
+	}
+}
+
+// createTestDevice creates an instance and a device with one queue on the first physical
+// device, skipping the test if no Vulkan implementation is available. Both are destroyed when
+// the test ends.
+func createTestDevice(t *testing.T) (Instance, Device) {
+	t.Helper()
+
+	instance, err := CreateInstance(&InstanceCreateInfo{
+		ApplicationInfo: &ApplicationInfo{
+			ApplicationName: "golang-vulkan-api test",
+			APIVersion:      Version11,
+		},
+	})
+	if err != nil {
+		t.Skipf("Vulkan instance unavailable: %v", err)
+	}
+	t.Cleanup(func() { DestroyInstance(instance) })
+
+	physicalDevices, err := EnumeratePhysicalDevices(instance)
+	if err != nil || len(physicalDevices) == 0 {
+		t.Skipf("No Vulkan physical device available: %v", err)
+	}
+
+	device, err := CreateDevice(physicalDevices[0], &DeviceCreateInfo{
+		QueueCreateInfos: []DeviceQueueCreateInfo{{QueueFamilyIndex: 0, QueuePriorities: []float32{1.0}}},
+	})
+	if err != nil {
+		t.Skipf("Vulkan device unavailable: %v", err)
+	}
+	t.Cleanup(func() { DestroyDevice(device) })
+	return instance, device
+}
+
+// TestLoadVideoFunctionsConcurrentDevice tests that concurrent loads for the same instance and
+// device resolve the function pointers exactly once and agree on the result
+func TestLoadVideoFunctionsConcurrentDevice(t *testing.T) {
+	instance, device := createTestDevice(t)
+	// Reset the cached handles so a later device reusing these handle values starts clean
+	t.Cleanup(func() {
+		LoadVideoDeviceFunctions(nil)
+		LoadVideoInstanceFunctions(nil)
+	})
+
+	var instanceLoads, deviceLoads atomic.Int32
+	origInstance, origDevice := loadVideoInstance, loadVideoDevice
+	loadVideoInstance = func(instance Instance) bool {
+		instanceLoads.Add(1)
+		return origInstance(instance)
+	}
+	loadVideoDevice = func(device Device) bool {
+		deviceLoads.Add(1)
+		return origDevice(device)
+	}
+	t.Cleanup(func() { loadVideoInstance, loadVideoDevice = origInstance, origDevice })
+
+	const goroutines = 16
+	instanceResults := make([]bool, goroutines)
+	deviceResults := make([]bool, goroutines)
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			instanceResults[i] = LoadVideoInstanceFunctions(instance)
+			deviceResults[i] = LoadVideoDeviceFunctions(device)
+		}(i)
+	}
+	wg.Wait()
+
+	if loads := instanceLoads.Load(); loads != 1 {
+		t.Errorf("Expected instance functions to be resolved once, got %d", loads)
+	}
+	if loads := deviceLoads.Load(); loads != 1 {
+		t.Errorf("Expected device functions to be resolved once, got %d", loads)
+	}
+	for i := 1; i < goroutines; i++ {
+		if instanceResults[i] != instanceResults[0] || deviceResults[i] != deviceResults[0] {
+			t.Fatalf("Expected every goroutine to get the cached result, goroutine %d got instance=%v device=%v, goroutine 0 got instance=%v device=%v",
+				i, instanceResults[i], deviceResults[i], instanceResults[0], deviceResults[0])
+		}
+	}
+}
@@ -0,0 +1,607 @@
+package vulkan
+
+/*
+#include <vulkan/vulkan.h>
+#include <stdlib.h>
+
+// The codec std structs use C bitfields for their flags, which cgo cannot address,
+// so flags are passed from Go as bit masks and expanded here.
+static void setH264SpsFlags(StdVideoH264SpsFlags* f, uint32_t m) {
+    f->constraint_set0_flag = (m >> 0) & 1;
+    f->constraint_set1_flag = (m >> 1) & 1;
+    f->constraint_set2_flag = (m >> 2) & 1;
+    f->constraint_set3_flag = (m >> 3) & 1;
+    f->constraint_set4_flag = (m >> 4) & 1;
+    f->constraint_set5_flag = (m >> 5) & 1;
+    f->direct_8x8_inference_flag = (m >> 6) & 1;
+    f->mb_adaptive_frame_field_flag = (m >> 7) & 1;
+    f->frame_mbs_only_flag = (m >> 8) & 1;
+    f->delta_pic_order_always_zero_flag = (m >> 9) & 1;
+    f->separate_colour_plane_flag = (m >> 10) & 1;
+    f->gaps_in_frame_num_value_allowed_flag = (m >> 11) & 1;
+    f->qpprime_y_zero_transform_bypass_flag = (m >> 12) & 1;
+    f->frame_cropping_flag = (m >> 13) & 1;
+}
+
+static void setH264PpsFlags(StdVideoH264PpsFlags* f, uint32_t m) {
+    f->transform_8x8_mode_flag = (m >> 0) & 1;
+    f->redundant_pic_cnt_present_flag = (m >> 1) & 1;
+    f->constrained_intra_pred_flag = (m >> 2) & 1;
+    f->deblocking_filter_control_present_flag = (m >> 3) & 1;
+    f->weighted_pred_flag = (m >> 4) & 1;
+    f->bottom_field_pic_order_in_frame_present_flag = (m >> 5) & 1;
+    f->entropy_coding_mode_flag = (m >> 6) & 1;
+}
+
+static void setH265VpsFlags(StdVideoH265VpsFlags* f, uint32_t m) {
+    f->vps_temporal_id_nesting_flag = (m >> 0) & 1;
+    f->vps_sub_layer_ordering_info_present_flag = (m >> 1) & 1;
+    f->vps_timing_info_present_flag = (m >> 2) & 1;
+    f->vps_poc_proportional_to_timing_flag = (m >> 3) & 1;
+}
+
+static void setH265ProfileTierLevelFlags(StdVideoH265ProfileTierLevelFlags* f, uint32_t m) {
+    f->general_tier_flag = (m >> 0) & 1;
+    f->general_progressive_source_flag = (m >> 1) & 1;
+    f->general_interlaced_source_flag = (m >> 2) & 1;
+    f->general_non_packed_constraint_flag = (m >> 3) & 1;
+    f->general_frame_only_constraint_flag = (m >> 4) & 1;
+}
+
+static void setH265SpsFlags(StdVideoH265SpsFlags* f, uint32_t m) {
+    f->sps_temporal_id_nesting_flag = (m >> 0) & 1;
+    f->separate_colour_plane_flag = (m >> 1) & 1;
+    f->conformance_window_flag = (m >> 2) & 1;
+    f->sps_sub_layer_ordering_info_present_flag = (m >> 3) & 1;
+    f->amp_enabled_flag = (m >> 4) & 1;
+    f->sample_adaptive_offset_enabled_flag = (m >> 5) & 1;
+    f->pcm_enabled_flag = (m >> 6) & 1;
+    f->pcm_loop_filter_disabled_flag = (m >> 7) & 1;
+    f->sps_temporal_mvp_enabled_flag = (m >> 8) & 1;
+    f->strong_intra_smoothing_enabled_flag = (m >> 9) & 1;
+}
+
+static void setH265PpsFlags(StdVideoH265PpsFlags* f, uint32_t m) {
+    f->dependent_slice_segments_enabled_flag = (m >> 0) & 1;
+    f->output_flag_present_flag = (m >> 1) & 1;
+    f->sign_data_hiding_enabled_flag = (m >> 2) & 1;
+    f->cabac_init_present_flag = (m >> 3) & 1;
+    f->constrained_intra_pred_flag = (m >> 4) & 1;
+    f->transform_skip_enabled_flag = (m >> 5) & 1;
+    f->cu_qp_delta_enabled_flag = (m >> 6) & 1;
+    f->pps_slice_chroma_qp_offsets_present_flag = (m >> 7) & 1;
+    f->weighted_pred_flag = (m >> 8) & 1;
+    f->weighted_bipred_flag = (m >> 9) & 1;
+    f->transquant_bypass_enabled_flag = (m >> 10) & 1;
+    f->tiles_enabled_flag = (m >> 11) & 1;
+    f->entropy_coding_sync_enabled_flag = (m >> 12) & 1;
+    f->uniform_spacing_flag = (m >> 13) & 1;
+    f->loop_filter_across_tiles_enabled_flag = (m >> 14) & 1;
+    f->pps_loop_filter_across_slices_enabled_flag = (m >> 15) & 1;
+    f->deblocking_filter_control_present_flag = (m >> 16) & 1;
+    f->deblocking_filter_override_enabled_flag = (m >> 17) & 1;
+    f->pps_deblocking_filter_disabled_flag = (m >> 18) & 1;
+    f->lists_modification_present_flag = (m >> 19) & 1;
+    f->slice_segment_header_extension_present_flag = (m >> 20) & 1;
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// Video Session Parameter Sets
+//
+// The structures below carry the H.264 and H.265 parameter sets a decoder parses from the
+// bitstream. Scaling lists, VUI and the range/screen-content extensions are not exposed and
+// are passed to the driver as absent.
+
+// H264ProfileIdc is an H.264 profile_idc value
+type H264ProfileIdc uint32
+
+const (
+	H264ProfileIdcBaseline          H264ProfileIdc = C.STD_VIDEO_H264_PROFILE_IDC_BASELINE
+	H264ProfileIdcMain              H264ProfileIdc = C.STD_VIDEO_H264_PROFILE_IDC_MAIN
+	H264ProfileIdcHigh              H264ProfileIdc = C.STD_VIDEO_H264_PROFILE_IDC_HIGH
+	H264ProfileIdcHigh444Predictive H264ProfileIdc = C.STD_VIDEO_H264_PROFILE_IDC_HIGH_444_PREDICTIVE
+)
+
+// H264LevelIdc is an H.264 level. The values are Vulkan enumerants, not level_idc from the
+// bitstream (level 4.1 is H264LevelIdc4_1, not 41).
+type H264LevelIdc uint32
+
+const (
+	H264LevelIdc1_0 H264LevelIdc = C.STD_VIDEO_H264_LEVEL_IDC_1_0
+	H264LevelIdc1_1 H264LevelIdc = C.STD_VIDEO_H264_LEVEL_IDC_1_1
+	H264LevelIdc1_2 H264LevelIdc = C.STD_VIDEO_H264_LEVEL_IDC_1_2
+	H264LevelIdc1_3 H264LevelIdc = C.STD_VIDEO_H264_LEVEL_IDC_1_3
+	H264LevelIdc2_0 H264LevelIdc = C.STD_VIDEO_H264_LEVEL_IDC_2_0
+	H264LevelIdc2_1 H264LevelIdc = C.STD_VIDEO_H264_LEVEL_IDC_2_1
+	H264LevelIdc2_2 H264LevelIdc = C.STD_VIDEO_H264_LEVEL_IDC_2_2
+	H264LevelIdc3_0 H264LevelIdc = C.STD_VIDEO_H264_LEVEL_IDC_3_0
+	H264LevelIdc3_1 H264LevelIdc = C.STD_VIDEO_H264_LEVEL_IDC_3_1
+	H264LevelIdc3_2 H264LevelIdc = C.STD_VIDEO_H264_LEVEL_IDC_3_2
+	H264LevelIdc4_0 H264LevelIdc = C.STD_VIDEO_H264_LEVEL_IDC_4_0
+	H264LevelIdc4_1 H264LevelIdc = C.STD_VIDEO_H264_LEVEL_IDC_4_1
+	H264LevelIdc4_2 H264LevelIdc = C.STD_VIDEO_H264_LEVEL_IDC_4_2
+	H264LevelIdc5_0 H264LevelIdc = C.STD_VIDEO_H264_LEVEL_IDC_5_0
+	H264LevelIdc5_1 H264LevelIdc = C.STD_VIDEO_H264_LEVEL_IDC_5_1
+	H264LevelIdc5_2 H264LevelIdc = C.STD_VIDEO_H264_LEVEL_IDC_5_2
+	H264LevelIdc6_0 H264LevelIdc = C.STD_VIDEO_H264_LEVEL_IDC_6_0
+	H264LevelIdc6_1 H264LevelIdc = C.STD_VIDEO_H264_LEVEL_IDC_6_1
+	H264LevelIdc6_2 H264LevelIdc = C.STD_VIDEO_H264_LEVEL_IDC_6_2
+)
+
+// H264SpsFlags holds the H.264 SPS flags
+type H264SpsFlags uint32
+
+const (
+	H264SpsConstraintSet0Bit H264SpsFlags = 1 << iota
+	H264SpsConstraintSet1Bit
+	H264SpsConstraintSet2Bit
+	H264SpsConstraintSet3Bit
+	H264SpsConstraintSet4Bit
+	H264SpsConstraintSet5Bit
+	H264SpsDirect8x8InferenceBit
+	H264SpsMbAdaptiveFrameFieldBit
+	H264SpsFrameMbsOnlyBit
+	H264SpsDeltaPicOrderAlwaysZeroBit
+	H264SpsSeparateColourPlaneBit
+	H264SpsGapsInFrameNumValueAllowedBit
+	H264SpsQpprimeYZeroTransformBypassBit
+	H264SpsFrameCroppingBit
+)
+
+// H264SequenceParameterSet is an H.264 sequence parameter set
+type H264SequenceParameterSet struct {
+	Flags                       H264SpsFlags
+	ProfileIdc                  H264ProfileIdc
+	LevelIdc                    H264LevelIdc
+	ChromaFormatIdc             uint32
+	SeqParameterSetID           uint8
+	BitDepthLumaMinus8          uint8
+	BitDepthChromaMinus8        uint8
+	Log2MaxFrameNumMinus4       uint8
+	PicOrderCntType             uint32
+	OffsetForNonRefPic          int32
+	OffsetForTopToBottomField   int32
+	Log2MaxPicOrderCntLsbMinus4 uint8
+	MaxNumRefFrames             uint8
+	PicWidthInMbsMinus1         uint32
+	PicHeightInMapUnitsMinus1   uint32
+	FrameCropLeftOffset         uint32
+	FrameCropRightOffset        uint32
+	FrameCropTopOffset          uint32
+	FrameCropBottomOffset       uint32
+	// OffsetForRefFrame holds offset_for_ref_frame when PicOrderCntType is 1
+	OffsetForRefFrame []int32
+}
+
+// H264PpsFlags holds the H.264 PPS flags
+type H264PpsFlags uint32
+
+const (
+	H264PpsTransform8x8ModeBit H264PpsFlags = 1 << iota
+	H264PpsRedundantPicCntPresentBit
+	H264PpsConstrainedIntraPredBit
+	H264PpsDeblockingFilterControlPresentBit
+	H264PpsWeightedPredBit
+	H264PpsBottomFieldPicOrderInFramePresentBit
+	H264PpsEntropyCodingModeBit
+)
+
+// H264PictureParameterSet is an H.264 picture parameter set
+type H264PictureParameterSet struct {
+	Flags                          H264PpsFlags
+	SeqParameterSetID              uint8
+	PicParameterSetID              uint8
+	NumRefIdxL0DefaultActiveMinus1 uint8
+	NumRefIdxL1DefaultActiveMinus1 uint8
+	WeightedBipredIdc              uint32
+	PicInitQpMinus26               int8
+	PicInitQsMinus26               int8
+	ChromaQpIndexOffset            int8
+	SecondChromaQpIndexOffset      int8
+}
+
+// VideoDecodeH264SessionParametersAddInfo lists H.264 parameter sets to add to a
+// video session parameters object
+type VideoDecodeH264SessionParametersAddInfo struct {
+	SPSs []H264SequenceParameterSet
+	PPSs []H264PictureParameterSet
+}
+
+// H265ProfileTierLevelFlags holds the H.265 general profile, tier and level flags
+type H265ProfileTierLevelFlags uint32
+
+const (
+	H265ProfileTierLevelTierBit H265ProfileTierLevelFlags = 1 << iota
+	H265ProfileTierLevelProgressiveSourceBit
+	H265ProfileTierLevelInterlacedSourceBit
+	H265ProfileTierLevelNonPackedConstraintBit
+	H265ProfileTierLevelFrameOnlyConstraintBit
+)
+
+// H265ProfileTierLevel is the general profile_tier_level of an H.265 VPS or SPS. Profile
+// and level use the Vulkan StdVideoH265ProfileIdc and StdVideoH265LevelIdc enumerants.
+type H265ProfileTierLevel struct {
+	Flags             H265ProfileTierLevelFlags
+	GeneralProfileIdc uint32
+	GeneralLevelIdc   uint32
+}
+
+// H265DecPicBufMgr holds the per-sub-layer decoded picture buffer limits
+type H265DecPicBufMgr struct {
+	MaxLatencyIncreasePlus1  [7]uint32
+	MaxDecPicBufferingMinus1 [7]uint8
+	MaxNumReorderPics        [7]uint8
+}
+
+// H265VpsFlags holds the H.265 VPS flags
+type H265VpsFlags uint32
+
+const (
+	H265VpsTemporalIDNestingBit H265VpsFlags = 1 << iota
+	H265VpsSubLayerOrderingInfoPresentBit
+	H265VpsTimingInfoPresentBit
+	H265VpsPocProportionalToTimingBit
+)
+
+// H265VideoParameterSet is an H.265 video parameter set
+type H265VideoParameterSet struct {
+	Flags                       H265VpsFlags
+	VpsVideoParameterSetID      uint8
+	VpsMaxSubLayersMinus1       uint8
+	VpsNumUnitsInTick           uint32
+	VpsTimeScale                uint32
+	VpsNumTicksPocDiffOneMinus1 uint32
+	DecPicBufMgr                H265DecPicBufMgr
+	ProfileTierLevel            H265ProfileTierLevel
+}
+
+// H265SpsFlags holds the H.265 SPS flags
+type H265SpsFlags uint32
+
+const (
+	H265SpsTemporalIDNestingBit H265SpsFlags = 1 << iota
+	H265SpsSeparateColourPlaneBit
+	H265SpsConformanceWindowBit
+	H265SpsSubLayerOrderingInfoPresentBit
+	H265SpsAmpEnabledBit
+	H265SpsSampleAdaptiveOffsetEnabledBit
+	H265SpsPcmEnabledBit
+	H265SpsPcmLoopFilterDisabledBit
+	H265SpsTemporalMvpEnabledBit
+	H265SpsStrongIntraSmoothingEnabledBit
+)
+
+// H265SequenceParameterSet is an H.265 sequence parameter set. Short-term reference
+// picture sets are not carried in the SPS; decoders pass them per slice instead.
+type H265SequenceParameterSet struct {
+	Flags                                H265SpsFlags
+	ChromaFormatIdc                      uint32
+	PicWidthInLumaSamples                uint32
+	PicHeightInLumaSamples               uint32
+	SpsVideoParameterSetID               uint8
+	SpsMaxSubLayersMinus1                uint8
+	SpsSeqParameterSetID                 uint8
+	BitDepthLumaMinus8                   uint8
+	BitDepthChromaMinus8                 uint8
+	Log2MaxPicOrderCntLsbMinus4          uint8
+	Log2MinLumaCodingBlockSizeMinus3     uint8
+	Log2DiffMaxMinLumaCodingBlockSize    uint8
+	Log2MinLumaTransformBlockSizeMinus2  uint8
+	Log2DiffMaxMinLumaTransformBlockSize uint8
+	MaxTransformHierarchyDepthInter      uint8
+	MaxTransformHierarchyDepthIntra      uint8
+	PcmSampleBitDepthLumaMinus1          uint8
+	PcmSampleBitDepthChromaMinus1        uint8
+	Log2MinPcmLumaCodingBlockSizeMinus3  uint8
+	Log2DiffMaxMinPcmLumaCodingBlockSize uint8
+	ConfWinLeftOffset                    uint32
+	ConfWinRightOffset                   uint32
+	ConfWinTopOffset                     uint32
+	ConfWinBottomOffset                  uint32
+	ProfileTierLevel                     H265ProfileTierLevel
+	DecPicBufMgr                         H265DecPicBufMgr
+}
+
+// H265PpsFlags holds the H.265 PPS flags
+type H265PpsFlags uint32
+
+const (
+	H265PpsDependentSliceSegmentsEnabledBit H265PpsFlags = 1 << iota
+	H265PpsOutputFlagPresentBit
+	H265PpsSignDataHidingEnabledBit
+	H265PpsCabacInitPresentBit
+	H265PpsConstrainedIntraPredBit
+	H265PpsTransformSkipEnabledBit
+	H265PpsCuQpDeltaEnabledBit
+	H265PpsSliceChromaQpOffsetsPresentBit
+	H265PpsWeightedPredBit
+	H265PpsWeightedBipredBit
+	H265PpsTransquantBypassEnabledBit
+	H265PpsTilesEnabledBit
+	H265PpsEntropyCodingSyncEnabledBit
+	H265PpsUniformSpacingBit
+	H265PpsLoopFilterAcrossTilesEnabledBit
+	H265PpsLoopFilterAcrossSlicesEnabledBit
+	H265PpsDeblockingFilterControlPresentBit
+	H265PpsDeblockingFilterOverrideEnabledBit
+	H265PpsDeblockingFilterDisabledBit
+	H265PpsListsModificationPresentBit
+	H265PpsSliceSegmentHeaderExtensionPresentBit
+)
+
+// H265PictureParameterSet is an H.265 picture parameter set
+type H265PictureParameterSet struct {
+	Flags                          H265PpsFlags
+	PpsPicParameterSetID           uint8
+	PpsSeqParameterSetID           uint8
+	SpsVideoParameterSetID         uint8
+	NumExtraSliceHeaderBits        uint8
+	NumRefIdxL0DefaultActiveMinus1 uint8
+	NumRefIdxL1DefaultActiveMinus1 uint8
+	InitQpMinus26                  int8
+	DiffCuQpDeltaDepth             uint8
+	PpsCbQpOffset                  int8
+	PpsCrQpOffset                  int8
+	PpsBetaOffsetDiv2              int8
+	PpsTcOffsetDiv2                int8
+	Log2ParallelMergeLevelMinus2   uint8
+	NumTileColumnsMinus1           uint8
+	NumTileRowsMinus1              uint8
+	ColumnWidthMinus1              [19]uint16
+	RowHeightMinus1                [21]uint16
+}
+
+// VideoDecodeH265SessionParametersAddInfo lists H.265 parameter sets to add to a
+// video session parameters object
+type VideoDecodeH265SessionParametersAddInfo struct {
+	VPSs []H265VideoParameterSet
+	SPSs []H265SequenceParameterSet
+	PPSs []H265PictureParameterSet
+}
+
+// videoCalloc allocates zeroed C memory and records it in allocations
+func videoCalloc(count int, size C.size_t, allocations *[]unsafe.Pointer) (unsafe.Pointer, error) {
+	ptr := C.calloc(C.size_t(count), size)
+	if ptr == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "VideoSessionParameters", "failed to allocate memory for parameter sets")
+	}
+	*allocations = append(*allocations, ptr)
+	return ptr, nil
+}
+
+// h264AddInfoToC converts H.264 parameter sets into a VkVideoDecodeH264SessionParametersAddInfoKHR in C memory
+func h264AddInfoToC(info *VideoDecodeH264SessionParametersAddInfo, allocations *[]unsafe.Pointer) (*C.VkVideoDecodeH264SessionParametersAddInfoKHR, error) {
+	ptr, err := videoCalloc(1, C.sizeof_VkVideoDecodeH264SessionParametersAddInfoKHR, allocations)
+	if err != nil {
+		return nil, err
+	}
+	cInfo := (*C.VkVideoDecodeH264SessionParametersAddInfoKHR)(ptr)
+	cInfo.sType = C.VK_STRUCTURE_TYPE_VIDEO_DECODE_H264_SESSION_PARAMETERS_ADD_INFO_KHR
+
+	if len(info.SPSs) > 0 {
+		ptr, err := videoCalloc(len(info.SPSs), C.sizeof_StdVideoH264SequenceParameterSet, allocations)
+		if err != nil {
+			return nil, err
+		}
+		cSPSs := unsafe.Slice((*C.StdVideoH264SequenceParameterSet)(ptr), len(info.SPSs))
+		for i := range info.SPSs {
+			sps := &info.SPSs[i]
+			if sps.PicOrderCntType == 1 && len(sps.OffsetForRefFrame) > 255 {
+				return nil, NewValidationError(fmt.Sprintf("SPSs[%d].OffsetForRefFrame", i), "cannot have more than 255 entries")
+			}
+			c := &cSPSs[i]
+			C.setH264SpsFlags(&c.flags, C.uint32_t(sps.Flags))
+			c.profile_idc = C.StdVideoH264ProfileIdc(sps.ProfileIdc)
+			c.level_idc = C.StdVideoH264LevelIdc(sps.LevelIdc)
+			c.chroma_format_idc = C.StdVideoH264ChromaFormatIdc(sps.ChromaFormatIdc)
+			c.seq_parameter_set_id = C.uint8_t(sps.SeqParameterSetID)
+			c.bit_depth_luma_minus8 = C.uint8_t(sps.BitDepthLumaMinus8)
+			c.bit_depth_chroma_minus8 = C.uint8_t(sps.BitDepthChromaMinus8)
+			c.log2_max_frame_num_minus4 = C.uint8_t(sps.Log2MaxFrameNumMinus4)
+			c.pic_order_cnt_type = C.StdVideoH264PocType(sps.PicOrderCntType)
+			c.offset_for_non_ref_pic = C.int32_t(sps.OffsetForNonRefPic)
+			c.offset_for_top_to_bottom_field = C.int32_t(sps.OffsetForTopToBottomField)
+			c.log2_max_pic_order_cnt_lsb_minus4 = C.uint8_t(sps.Log2MaxPicOrderCntLsbMinus4)
+			c.max_num_ref_frames = C.uint8_t(sps.MaxNumRefFrames)
+			c.pic_width_in_mbs_minus1 = C.uint32_t(sps.PicWidthInMbsMinus1)
+			c.pic_height_in_map_units_minus1 = C.uint32_t(sps.PicHeightInMapUnitsMinus1)
+			c.frame_crop_left_offset = C.uint32_t(sps.FrameCropLeftOffset)
+			c.frame_crop_right_offset = C.uint32_t(sps.FrameCropRightOffset)
+			c.frame_crop_top_offset = C.uint32_t(sps.FrameCropTopOffset)
+			c.frame_crop_bottom_offset = C.uint32_t(sps.FrameCropBottomOffset)
+
+			if sps.PicOrderCntType == 1 && len(sps.OffsetForRefFrame) > 0 {
+				ptr, err := videoCalloc(len(sps.OffsetForRefFrame), C.sizeof_int32_t, allocations)
+				if err != nil {
+					return nil, err
+				}
+				offsets := unsafe.Slice((*C.int32_t)(ptr), len(sps.OffsetForRefFrame))
+				for j, offset := range sps.OffsetForRefFrame {
+					offsets[j] = C.int32_t(offset)
+				}
+				c.num_ref_frames_in_pic_order_cnt_cycle = C.uint8_t(len(sps.OffsetForRefFrame))
+				c.pOffsetForRefFrame = (*C.int32_t)(ptr)
+			}
+		}
+		cInfo.stdSPSCount = C.uint32_t(len(info.SPSs))
+		cInfo.pStdSPSs = &cSPSs[0]
+	}
+
+	if len(info.PPSs) > 0 {
+		ptr, err := videoCalloc(len(info.PPSs), C.sizeof_StdVideoH264PictureParameterSet, allocations)
+		if err != nil {
+			return nil, err
+		}
+		cPPSs := unsafe.Slice((*C.StdVideoH264PictureParameterSet)(ptr), len(info.PPSs))
+		for i := range info.PPSs {
+			pps := &info.PPSs[i]
+			c := &cPPSs[i]
+			C.setH264PpsFlags(&c.flags, C.uint32_t(pps.Flags))
+			c.seq_parameter_set_id = C.uint8_t(pps.SeqParameterSetID)
+			c.pic_parameter_set_id = C.uint8_t(pps.PicParameterSetID)
+			c.num_ref_idx_l0_default_active_minus1 = C.uint8_t(pps.NumRefIdxL0DefaultActiveMinus1)
+			c.num_ref_idx_l1_default_active_minus1 = C.uint8_t(pps.NumRefIdxL1DefaultActiveMinus1)
+			c.weighted_bipred_idc = C.StdVideoH264WeightedBipredIdc(pps.WeightedBipredIdc)
+			c.pic_init_qp_minus26 = C.int8_t(pps.PicInitQpMinus26)
+			c.pic_init_qs_minus26 = C.int8_t(pps.PicInitQsMinus26)
+			c.chroma_qp_index_offset = C.int8_t(pps.ChromaQpIndexOffset)
+			c.second_chroma_qp_index_offset = C.int8_t(pps.SecondChromaQpIndexOffset)
+		}
+		cInfo.stdPPSCount = C.uint32_t(len(info.PPSs))
+		cInfo.pStdPPSs = &cPPSs[0]
+	}
+
+	return cInfo, nil
+}
+
+// h265ProfileTierLevelToC converts a profile_tier_level into C memory
+func h265ProfileTierLevelToC(ptl *H265ProfileTierLevel, allocations *[]unsafe.Pointer) (*C.StdVideoH265ProfileTierLevel, error) {
+	ptr, err := videoCalloc(1, C.sizeof_StdVideoH265ProfileTierLevel, allocations)
+	if err != nil {
+		return nil, err
+	}
+	c := (*C.StdVideoH265ProfileTierLevel)(ptr)
+	C.setH265ProfileTierLevelFlags(&c.flags, C.uint32_t(ptl.Flags))
+	c.general_profile_idc = C.StdVideoH265ProfileIdc(ptl.GeneralProfileIdc)
+	c.general_level_idc = C.StdVideoH265LevelIdc(ptl.GeneralLevelIdc)
+	return c, nil
+}
+
+// h265DecPicBufMgrToC converts decoded picture buffer limits into C memory
+func h265DecPicBufMgrToC(mgr *H265DecPicBufMgr, allocations *[]unsafe.Pointer) (*C.StdVideoH265DecPicBufMgr, error) {
+	ptr, err := videoCalloc(1, C.sizeof_StdVideoH265DecPicBufMgr, allocations)
+	if err != nil {
+		return nil, err
+	}
+	c := (*C.StdVideoH265DecPicBufMgr)(ptr)
+	for i := range mgr.MaxLatencyIncreasePlus1 {
+		c.max_latency_increase_plus1[i] = C.uint32_t(mgr.MaxLatencyIncreasePlus1[i])
+		c.max_dec_pic_buffering_minus1[i] = C.uint8_t(mgr.MaxDecPicBufferingMinus1[i])
+		c.max_num_reorder_pics[i] = C.uint8_t(mgr.MaxNumReorderPics[i])
+	}
+	return c, nil
+}
+
+// h265AddInfoToC converts H.265 parameter sets into a VkVideoDecodeH265SessionParametersAddInfoKHR in C memory
+func h265AddInfoToC(info *VideoDecodeH265SessionParametersAddInfo, allocations *[]unsafe.Pointer) (*C.VkVideoDecodeH265SessionParametersAddInfoKHR, error) {
+	ptr, err := videoCalloc(1, C.sizeof_VkVideoDecodeH265SessionParametersAddInfoKHR, allocations)
+	if err != nil {
+		return nil, err
+	}
+	cInfo := (*C.VkVideoDecodeH265SessionParametersAddInfoKHR)(ptr)
+	cInfo.sType = C.VK_STRUCTURE_TYPE_VIDEO_DECODE_H265_SESSION_PARAMETERS_ADD_INFO_KHR
+
+	if len(info.VPSs) > 0 {
+		ptr, err := videoCalloc(len(info.VPSs), C.sizeof_StdVideoH265VideoParameterSet, allocations)
+		if err != nil {
+			return nil, err
+		}
+		cVPSs := unsafe.Slice((*C.StdVideoH265VideoParameterSet)(ptr), len(info.VPSs))
+		for i := range info.VPSs {
+			vps := &info.VPSs[i]
+			c := &cVPSs[i]
+			C.setH265VpsFlags(&c.flags, C.uint32_t(vps.Flags))
+			c.vps_video_parameter_set_id = C.uint8_t(vps.VpsVideoParameterSetID)
+			c.vps_max_sub_layers_minus1 = C.uint8_t(vps.VpsMaxSubLayersMinus1)
+			c.vps_num_units_in_tick = C.uint32_t(vps.VpsNumUnitsInTick)
+			c.vps_time_scale = C.uint32_t(vps.VpsTimeScale)
+			c.vps_num_ticks_poc_diff_one_minus1 = C.uint32_t(vps.VpsNumTicksPocDiffOneMinus1)
+			if c.pDecPicBufMgr, err = h265DecPicBufMgrToC(&vps.DecPicBufMgr, allocations); err != nil {
+				return nil, err
+			}
+			if c.pProfileTierLevel, err = h265ProfileTierLevelToC(&vps.ProfileTierLevel, allocations); err != nil {
+				return nil, err
+			}
+		}
+		cInfo.stdVPSCount = C.uint32_t(len(info.VPSs))
+		cInfo.pStdVPSs = &cVPSs[0]
+	}
+
+	if len(info.SPSs) > 0 {
+		ptr, err := videoCalloc(len(info.SPSs), C.sizeof_StdVideoH265SequenceParameterSet, allocations)
+		if err != nil {
+			return nil, err
+		}
+		cSPSs := unsafe.Slice((*C.StdVideoH265SequenceParameterSet)(ptr), len(info.SPSs))
+		for i := range info.SPSs {
+			sps := &info.SPSs[i]
+			c := &cSPSs[i]
+			C.setH265SpsFlags(&c.flags, C.uint32_t(sps.Flags))
+			c.chroma_format_idc = C.StdVideoH265ChromaFormatIdc(sps.ChromaFormatIdc)
+			c.pic_width_in_luma_samples = C.uint32_t(sps.PicWidthInLumaSamples)
+			c.pic_height_in_luma_samples = C.uint32_t(sps.PicHeightInLumaSamples)
+			c.sps_video_parameter_set_id = C.uint8_t(sps.SpsVideoParameterSetID)
+			c.sps_max_sub_layers_minus1 = C.uint8_t(sps.SpsMaxSubLayersMinus1)
+			c.sps_seq_parameter_set_id = C.uint8_t(sps.SpsSeqParameterSetID)
+			c.bit_depth_luma_minus8 = C.uint8_t(sps.BitDepthLumaMinus8)
+			c.bit_depth_chroma_minus8 = C.uint8_t(sps.BitDepthChromaMinus8)
+			c.log2_max_pic_order_cnt_lsb_minus4 = C.uint8_t(sps.Log2MaxPicOrderCntLsbMinus4)
+			c.log2_min_luma_coding_block_size_minus3 = C.uint8_t(sps.Log2MinLumaCodingBlockSizeMinus3)
+			c.log2_diff_max_min_luma_coding_block_size = C.uint8_t(sps.Log2DiffMaxMinLumaCodingBlockSize)
+			c.log2_min_luma_transform_block_size_minus2 = C.uint8_t(sps.Log2MinLumaTransformBlockSizeMinus2)
+			c.log2_diff_max_min_luma_transform_block_size = C.uint8_t(sps.Log2DiffMaxMinLumaTransformBlockSize)
+			c.max_transform_hierarchy_depth_inter = C.uint8_t(sps.MaxTransformHierarchyDepthInter)
+			c.max_transform_hierarchy_depth_intra = C.uint8_t(sps.MaxTransformHierarchyDepthIntra)
+			c.pcm_sample_bit_depth_luma_minus1 = C.uint8_t(sps.PcmSampleBitDepthLumaMinus1)
+			c.pcm_sample_bit_depth_chroma_minus1 = C.uint8_t(sps.PcmSampleBitDepthChromaMinus1)
+			c.log2_min_pcm_luma_coding_block_size_minus3 = C.uint8_t(sps.Log2MinPcmLumaCodingBlockSizeMinus3)
+			c.log2_diff_max_min_pcm_luma_coding_block_size = C.uint8_t(sps.Log2DiffMaxMinPcmLumaCodingBlockSize)
+			c.conf_win_left_offset = C.uint32_t(sps.ConfWinLeftOffset)
+			c.conf_win_right_offset = C.uint32_t(sps.ConfWinRightOffset)
+			c.conf_win_top_offset = C.uint32_t(sps.ConfWinTopOffset)
+			c.conf_win_bottom_offset = C.uint32_t(sps.ConfWinBottomOffset)
+			if c.pProfileTierLevel, err = h265ProfileTierLevelToC(&sps.ProfileTierLevel, allocations); err != nil {
+				return nil, err
+			}
+			if c.pDecPicBufMgr, err = h265DecPicBufMgrToC(&sps.DecPicBufMgr, allocations); err != nil {
+				return nil, err
+			}
+		}
+		cInfo.stdSPSCount = C.uint32_t(len(info.SPSs))
+		cInfo.pStdSPSs = &cSPSs[0]
+	}
+
+	if len(info.PPSs) > 0 {
+		ptr, err := videoCalloc(len(info.PPSs), C.sizeof_StdVideoH265PictureParameterSet, allocations)
+		if err != nil {
+			return nil, err
+		}
+		cPPSs := unsafe.Slice((*C.StdVideoH265PictureParameterSet)(ptr), len(info.PPSs))
+		for i := range info.PPSs {
+			pps := &info.PPSs[i]
+			c := &cPPSs[i]
+			C.setH265PpsFlags(&c.flags, C.uint32_t(pps.Flags))
+			c.pps_pic_parameter_set_id = C.uint8_t(pps.PpsPicParameterSetID)
+			c.pps_seq_parameter_set_id = C.uint8_t(pps.PpsSeqParameterSetID)
+			c.sps_video_parameter_set_id = C.uint8_t(pps.SpsVideoParameterSetID)
+			c.num_extra_slice_header_bits = C.uint8_t(pps.NumExtraSliceHeaderBits)
+			c.num_ref_idx_l0_default_active_minus1 = C.uint8_t(pps.NumRefIdxL0DefaultActiveMinus1)
+			c.num_ref_idx_l1_default_active_minus1 = C.uint8_t(pps.NumRefIdxL1DefaultActiveMinus1)
+			c.init_qp_minus26 = C.int8_t(pps.InitQpMinus26)
+			c.diff_cu_qp_delta_depth = C.uint8_t(pps.DiffCuQpDeltaDepth)
+			c.pps_cb_qp_offset = C.int8_t(pps.PpsCbQpOffset)
+			c.pps_cr_qp_offset = C.int8_t(pps.PpsCrQpOffset)
+			c.pps_beta_offset_div2 = C.int8_t(pps.PpsBetaOffsetDiv2)
+			c.pps_tc_offset_div2 = C.int8_t(pps.PpsTcOffsetDiv2)
+			c.log2_parallel_merge_level_minus2 = C.uint8_t(pps.Log2ParallelMergeLevelMinus2)
+			c.num_tile_columns_minus1 = C.uint8_t(pps.NumTileColumnsMinus1)
+			c.num_tile_rows_minus1 = C.uint8_t(pps.NumTileRowsMinus1)
+			for j, width := range pps.ColumnWidthMinus1 {
+				c.column_width_minus1[j] = C.uint16_t(width)
+			}
+			for j, height := range pps.RowHeightMinus1 {
+				c.row_height_minus1[j] = C.uint16_t(height)
+			}
+		}
+		cInfo.stdPPSCount = C.uint32_t(len(info.PPSs))
+		cInfo.pStdPPSs = &cPPSs[0]
+	}
+
+	return cInfo, nil
+}
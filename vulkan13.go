@@ -3,10 +3,109 @@ package vulkan
 /*
 #include <vulkan/vulkan.h>
 #include <stdlib.h>
+
+// Function pointer for the VK_KHR_synchronization2 submit entry point, used on
+// devices that expose the extension without core Vulkan 1.3 support.
+//
+// IMPORTANT: This is a global static pointer and NOT thread-safe during loading.
+// LoadSynchronization2Functions must be called from a single thread during initialization.
+static PFN_vkQueueSubmit2KHR pfn_vkQueueSubmit2KHR = NULL;
+
+static int loadSynchronization2DeviceFunctions(VkDevice device) {
+    if (device == VK_NULL_HANDLE) {
+        return 0;
+    }
+    pfn_vkQueueSubmit2KHR = (PFN_vkQueueSubmit2KHR)
+        vkGetDeviceProcAddr(device, "vkQueueSubmit2KHR");
+    return pfn_vkQueueSubmit2KHR != NULL;
+}
+
+// Dynamic rendering entry points resolved for one device. begin and end are the commands
+// CmdBeginRendering and CmdEndRendering use: the core ones when the device exposes them,
+// otherwise the VK_KHR_dynamic_rendering ones.
+typedef struct {
+    PFN_vkCmdBeginRendering begin;
+    PFN_vkCmdEndRendering end;
+    PFN_vkCmdBeginRenderingKHR beginKHR;
+    PFN_vkCmdEndRenderingKHR endKHR;
+} DynamicRenderingFunctions;
+
+// Returns 1 if the core entry points were resolved, 2 if only the KHR ones were, 0 if neither.
+static int resolveDynamicRenderingFunctions(VkDevice device, DynamicRenderingFunctions* fns) {
+    fns->beginKHR = (PFN_vkCmdBeginRenderingKHR)
+        vkGetDeviceProcAddr(device, "vkCmdBeginRenderingKHR");
+    fns->endKHR = (PFN_vkCmdEndRenderingKHR)
+        vkGetDeviceProcAddr(device, "vkCmdEndRenderingKHR");
+
+    PFN_vkCmdBeginRendering coreBegin = (PFN_vkCmdBeginRendering)
+        vkGetDeviceProcAddr(device, "vkCmdBeginRendering");
+    PFN_vkCmdEndRendering coreEnd = (PFN_vkCmdEndRendering)
+        vkGetDeviceProcAddr(device, "vkCmdEndRendering");
+    if (coreBegin != NULL && coreEnd != NULL) {
+        fns->begin = coreBegin;
+        fns->end = coreEnd;
+        return 1;
+    }
+    if (fns->beginKHR != NULL && fns->endKHR != NULL) {
+        fns->begin = fns->beginKHR;
+        fns->end = fns->endKHR;
+        return 2;
+    }
+    return 0;
+}
+
+// Returns 1 on success, 0 if function pointer is NULL. The core symbols are never called
+// directly, as Vulkan 1.2 loaders don't export them.
+static int dispatch_vkCmdBeginRendering(PFN_vkCmdBeginRendering fn, VkCommandBuffer commandBuffer, const VkRenderingInfo* pRenderingInfo) {
+    if (fn == NULL) {
+        return 0;
+    }
+    fn(commandBuffer, pRenderingInfo);
+    return 1;
+}
+
+static int dispatch_vkCmdEndRendering(PFN_vkCmdEndRendering fn, VkCommandBuffer commandBuffer) {
+    if (fn == NULL) {
+        return 0;
+    }
+    fn(commandBuffer);
+    return 1;
+}
+
+static int call_vkCmdBeginRenderingKHR(PFN_vkCmdBeginRenderingKHR fn, VkCommandBuffer commandBuffer, const VkRenderingInfo* pRenderingInfo) {
+    if (fn == NULL) {
+        return 0;
+    }
+    fn(commandBuffer, pRenderingInfo);
+    return 1;
+}
+
+static int call_vkCmdEndRenderingKHR(PFN_vkCmdEndRenderingKHR fn, VkCommandBuffer commandBuffer) {
+    if (fn == NULL) {
+        return 0;
+    }
+    fn(commandBuffer);
+    return 1;
+}
+
+static VkResult call_vkQueueSubmit2KHR(
+    VkQueue queue,
+    uint32_t submitCount,
+    const VkSubmitInfo2* pSubmits,
+    VkFence fence) {
+    if (pfn_vkQueueSubmit2KHR == NULL) {
+        return VK_ERROR_EXTENSION_NOT_PRESENT;
+    }
+    return pfn_vkQueueSubmit2KHR(queue, submitCount, pSubmits, fence);
+}
 */
 import "C"
 
 import (
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"unsafe"
 )
 
@@ -48,80 +147,600 @@ type RenderingInfo struct {
 	StencilAttachment *RenderingAttachmentInfo
 }
 
-// CmdBeginRendering begins a render pass instance with dynamic rendering
-func CmdBeginRendering(commandBuffer CommandBuffer, renderingInfo *RenderingInfo) {
-	cRenderingInfo := C.VkRenderingInfo{
-		sType:      C.VK_STRUCTURE_TYPE_RENDERING_INFO,
-		pNext:      nil,
-		flags:      C.VkRenderingFlags(renderingInfo.Flags),
-		renderArea: *(*C.VkRect2D)(unsafe.Pointer(&renderingInfo.RenderArea)),
-		layerCount: C.uint32_t(renderingInfo.LayerCount),
-		viewMask:   C.uint32_t(renderingInfo.ViewMask),
+// ExtensionNameDynamicRendering is the dynamic rendering extension name, needed on Vulkan 1.2
+// devices
+const ExtensionNameDynamicRendering = "VK_KHR_dynamic_rendering"
+
+// PhysicalDeviceDynamicRenderingFeatures enables dynamic rendering on devices that expose it
+// through ExtensionNameDynamicRendering. On Vulkan 1.3 devices use
+// PhysicalDeviceVulkan13Features instead.
+type PhysicalDeviceDynamicRenderingFeatures struct {
+	DynamicRendering bool
+}
+
+// ExtensionNameSynchronization2 is the synchronization2 extension name, needed on Vulkan 1.2
+// devices
+const ExtensionNameSynchronization2 = "VK_KHR_synchronization2"
+
+// PhysicalDeviceSynchronization2Features enables synchronization2 on devices that expose it
+// through ExtensionNameSynchronization2. On Vulkan 1.3 devices use
+// PhysicalDeviceVulkan13Features instead. The feature is required by QueueSubmit2,
+// QueueSubmit2KHR, CmdPipelineBarrier2, CmdWriteTimestamp2 and ImageState.EnsureLayout.
+type PhysicalDeviceSynchronization2Features struct {
+	Synchronization2 bool
+}
+
+// PhysicalDeviceVulkan13Features enables the features promoted to core in Vulkan 1.3. It can
+// only be used on Vulkan 1.3 devices, and cannot be combined with the per-extension feature
+// structs it covers.
+type PhysicalDeviceVulkan13Features struct {
+	RobustImageAccess                                  bool
+	InlineUniformBlock                                 bool
+	DescriptorBindingInlineUniformBlockUpdateAfterBind bool
+	PipelineCreationCacheControl                       bool
+	PrivateData                                        bool
+	ShaderDemoteToHelperInvocation                     bool
+	ShaderTerminateInvocation                          bool
+	SubgroupSizeControl                                bool
+	ComputeFullSubgroups                               bool
+	Synchronization2                                   bool
+	TextureCompressionASTCHDR                          bool
+	ShaderZeroInitializeWorkgroupMemory                bool
+	DynamicRendering                                   bool
+	ShaderIntegerDotProduct                            bool
+	Maintenance4                                       bool
+}
+
+// dynamicRenderingFeaturesToC prepends a struct enabling dynamic rendering to the pNext chain
+// next. The struct is allocated in C memory and appended to allocations, which the caller must
+// free.
+func dynamicRenderingFeaturesToC(features *PhysicalDeviceDynamicRenderingFeatures, next unsafe.Pointer, allocations *[]unsafe.Pointer) (unsafe.Pointer, error) {
+	cFeatures := (*C.VkPhysicalDeviceDynamicRenderingFeatures)(C.calloc(1, C.sizeof_VkPhysicalDeviceDynamicRenderingFeatures))
+	if cFeatures == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "CreateDevice", "failed to allocate memory for dynamic rendering features")
+	}
+	*allocations = append(*allocations, unsafe.Pointer(cFeatures))
+
+	cFeatures.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_DYNAMIC_RENDERING_FEATURES
+	cFeatures.pNext = next
+	cFeatures.dynamicRendering = boolToVkBool32(features.DynamicRendering)
+
+	return unsafe.Pointer(cFeatures), nil
+}
+
+// synchronization2FeaturesToC prepends a struct enabling synchronization2 to the pNext chain
+// next. The struct is allocated in C memory and appended to allocations, which the caller must
+// free.
+func synchronization2FeaturesToC(features *PhysicalDeviceSynchronization2Features, next unsafe.Pointer, allocations *[]unsafe.Pointer) (unsafe.Pointer, error) {
+	cFeatures := (*C.VkPhysicalDeviceSynchronization2Features)(C.calloc(1, C.sizeof_VkPhysicalDeviceSynchronization2Features))
+	if cFeatures == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "CreateDevice", "failed to allocate memory for synchronization2 features")
+	}
+	*allocations = append(*allocations, unsafe.Pointer(cFeatures))
+
+	cFeatures.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_SYNCHRONIZATION_2_FEATURES
+	cFeatures.pNext = next
+	cFeatures.synchronization2 = boolToVkBool32(features.Synchronization2)
+
+	return unsafe.Pointer(cFeatures), nil
+}
+
+// vulkan13FeaturesToC prepends a struct enabling the Vulkan 1.3 features to the pNext chain
+// next. The struct is allocated in C memory and appended to allocations, which the caller must
+// free.
+func vulkan13FeaturesToC(features *PhysicalDeviceVulkan13Features, next unsafe.Pointer, allocations *[]unsafe.Pointer) (unsafe.Pointer, error) {
+	cFeatures := (*C.VkPhysicalDeviceVulkan13Features)(C.calloc(1, C.sizeof_VkPhysicalDeviceVulkan13Features))
+	if cFeatures == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "CreateDevice", "failed to allocate memory for Vulkan 1.3 features")
+	}
+	*allocations = append(*allocations, unsafe.Pointer(cFeatures))
+
+	cFeatures.sType = C.VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_VULKAN_1_3_FEATURES
+	cFeatures.pNext = next
+	cFeatures.robustImageAccess = boolToVkBool32(features.RobustImageAccess)
+	cFeatures.inlineUniformBlock = boolToVkBool32(features.InlineUniformBlock)
+	cFeatures.descriptorBindingInlineUniformBlockUpdateAfterBind = boolToVkBool32(features.DescriptorBindingInlineUniformBlockUpdateAfterBind)
+	cFeatures.pipelineCreationCacheControl = boolToVkBool32(features.PipelineCreationCacheControl)
+	cFeatures.privateData = boolToVkBool32(features.PrivateData)
+	cFeatures.shaderDemoteToHelperInvocation = boolToVkBool32(features.ShaderDemoteToHelperInvocation)
+	cFeatures.shaderTerminateInvocation = boolToVkBool32(features.ShaderTerminateInvocation)
+	cFeatures.subgroupSizeControl = boolToVkBool32(features.SubgroupSizeControl)
+	cFeatures.computeFullSubgroups = boolToVkBool32(features.ComputeFullSubgroups)
+	cFeatures.synchronization2 = boolToVkBool32(features.Synchronization2)
+	cFeatures.textureCompressionASTC_HDR = boolToVkBool32(features.TextureCompressionASTCHDR)
+	cFeatures.shaderZeroInitializeWorkgroupMemory = boolToVkBool32(features.ShaderZeroInitializeWorkgroupMemory)
+	cFeatures.dynamicRendering = boolToVkBool32(features.DynamicRendering)
+	cFeatures.shaderIntegerDotProduct = boolToVkBool32(features.ShaderIntegerDotProduct)
+	cFeatures.maintenance4 = boolToVkBool32(features.Maintenance4)
+
+	return unsafe.Pointer(cFeatures), nil
+}
+
+// DynamicRenderingPath identifies the entry points CmdBeginRendering and CmdEndRendering use
+type DynamicRenderingPath int
+
+const (
+	// DynamicRenderingPathNone means the device exposes neither the core nor the KHR commands
+	DynamicRenderingPathNone DynamicRenderingPath = iota
+	// DynamicRenderingPathCore uses vkCmdBeginRendering/vkCmdEndRendering from Vulkan 1.3
+	DynamicRenderingPathCore
+	// DynamicRenderingPathKHR uses vkCmdBeginRenderingKHR/vkCmdEndRenderingKHR from
+	// VK_KHR_dynamic_rendering, for Vulkan 1.2 devices
+	DynamicRenderingPathKHR
+)
+
+// String returns the name of the dynamic rendering path
+func (p DynamicRenderingPath) String() string {
+	switch p {
+	case DynamicRenderingPathCore:
+		return "core"
+	case DynamicRenderingPathKHR:
+		return ExtensionNameDynamicRendering
+	default:
+		return "unavailable"
+	}
+}
+
+// dynamicRenderingEntry holds the dynamic rendering entry points resolved for a device
+type dynamicRenderingEntry struct {
+	device Device
+	path   DynamicRenderingPath
+	fns    C.DynamicRenderingFunctions
+}
+
+var (
+	// dynamicRenderingDevices caches the resolved entry points per device so each device is
+	// only queried once. DestroyDevice removes its entry.
+	dynamicRenderingDevices sync.Map
+	// loadedDynamicRendering holds the device last passed to LoadDynamicRenderingFunctions.
+	// It serves command buffers that were not allocated through AllocateCommandBuffers.
+	loadedDynamicRendering atomic.Pointer[dynamicRenderingEntry]
+	// unresolvedDynamicRendering serves untracked command buffers when
+	// LoadDynamicRenderingFunctions was never called. Their device is unknown, so no entry
+	// points can be resolved for them.
+	unresolvedDynamicRendering = &dynamicRenderingEntry{path: DynamicRenderingPathNone}
+)
+
+// resolveDynamicRendering returns the dynamic rendering entry points of device, querying
+// them on first use
+func resolveDynamicRendering(device Device) *dynamicRenderingEntry {
+	if entry, ok := dynamicRenderingDevices.Load(device); ok {
+		return entry.(*dynamicRenderingEntry)
+	}
+
+	entry := &dynamicRenderingEntry{device: device}
+	switch C.resolveDynamicRenderingFunctions(C.VkDevice(device), &entry.fns) {
+	case 1:
+		entry.path = DynamicRenderingPathCore
+	case 2:
+		entry.path = DynamicRenderingPathKHR
+	default:
+		entry.path = DynamicRenderingPathNone
+	}
+	actual, _ := dynamicRenderingDevices.LoadOrStore(device, entry)
+	return actual.(*dynamicRenderingEntry)
+}
+
+// dynamicRenderingFor returns the entry points for the device commandBuffer was allocated
+// from. Command buffers allocated outside AllocateCommandBuffers use the device last passed
+// to LoadDynamicRenderingFunctions, or unresolvedDynamicRendering.
+func dynamicRenderingFor(commandBuffer CommandBuffer) *dynamicRenderingEntry {
+	if owner, ok := allocatedCommandBuffers.owner(commandBuffer); ok {
+		return resolveDynamicRendering(owner.device)
+	}
+	if entry := loadedDynamicRendering.Load(); entry != nil {
+		return entry
+	}
+	return unresolvedDynamicRendering
+}
+
+// dynamicRenderingCommands returns the entry points CmdBeginRendering and CmdEndRendering
+// use for commandBuffer, or an ErrorExtensionNotPresent error saying why there are none
+func dynamicRenderingCommands(operation string, commandBuffer CommandBuffer) (*dynamicRenderingEntry, error) {
+	entry := dynamicRenderingFor(commandBuffer)
+	if entry == unresolvedDynamicRendering {
+		return nil, NewVulkanError(ErrorExtensionNotPresent, operation, "command buffer was not allocated through AllocateCommandBuffers - call LoadDynamicRenderingFunctions with its device first")
+	}
+	if entry.path == DynamicRenderingPathNone {
+		return nil, NewVulkanError(ErrorExtensionNotPresent, operation, "device exposes neither Vulkan 1.3 nor VK_KHR_dynamic_rendering")
+	}
+	return entry, nil
+}
+
+// forgetDynamicRendering drops the entry points cached for a destroyed device
+func forgetDynamicRendering(device Device) {
+	dynamicRenderingDevices.Delete(device)
+	if entry := loadedDynamicRendering.Load(); entry != nil && entry.device == device {
+		loadedDynamicRendering.CompareAndSwap(entry, nil)
+	}
+}
+
+// LoadDynamicRenderingFunctions resolves the dynamic rendering entry points for a device and
+// returns the path CmdBeginRendering and CmdEndRendering will use. The core Vulkan 1.3
+// commands are preferred; on a Vulkan 1.2 device with VK_KHR_dynamic_rendering enabled the
+// KHR commands are used instead.
+//
+// CmdBeginRendering and CmdEndRendering resolve the entry points on first use for command
+// buffers allocated with AllocateCommandBuffers, so calling this function is only needed to
+// learn which path a device takes, or to select the device for command buffers allocated
+// elsewhere. Such command buffers fail with ErrorExtensionNotPresent until it has been called.
+// It is safe to call concurrently with recording.
+func LoadDynamicRenderingFunctions(device Device) DynamicRenderingPath {
+	if device == nil {
+		loadedDynamicRendering.Store(nil)
+		return DynamicRenderingPathNone
+	}
+	entry := resolveDynamicRendering(device)
+	loadedDynamicRendering.Store(entry)
+	return entry.path
+}
+
+// ClearValueCheck selects whether rendering validation rejects attachments that use
+// AttachmentLoadOpClear with a ClearValue the driver reads as all zeros. Such a value is
+// usually a forgotten field, and silently clears to black or a depth of 0.
+type ClearValueCheck int32
+
+const (
+	// ClearValueCheckOff disables the check
+	ClearValueCheckOff ClearValueCheck = iota
+	// ClearValueCheckError makes CmdBeginRendering return a ValidationError
+	ClearValueCheckError
+)
+
+var clearValueCheck atomic.Int32
+
+// SetClearValueCheck sets whether CmdBeginRendering rejects clearing attachments to an
+// all-zero ClearValue. The default is ClearValueCheckOff; leave it off if the application
+// deliberately clears to zero, for example to transparent black or to a reverse-Z depth of 0.
+// It is safe to call concurrently with recording.
+func SetClearValueCheck(check ClearValueCheck) {
+	clearValueCheck.Store(int32(check))
+}
+
+// checkClearValue rejects an attachment whose ClearValue sets more than one union member, and
+// one that clears to all zeros when ClearValueCheckError is configured. The zero check looks at
+// the encoded union words, so a set field holding only zeros counts as zero.
+func checkClearValue(attachment *RenderingAttachmentInfo, parameter string) error {
+	if err := validateClearValue(attachment.ClearValue, parameter+".ClearValue"); err != nil {
+		return err
+	}
+	if ClearValueCheck(clearValueCheck.Load()) != ClearValueCheckError || attachment.LoadOp != AttachmentLoadOpClear {
+		return nil
+	}
+	if words, _ := clearValueWords(attachment.ClearValue); words != ([4]uint32{}) {
+		return nil
+	}
+	return NewValidationError(parameter+".ClearValue", "LoadOp is AttachmentLoadOpClear but ClearValue is all zeros")
+}
+
+// validateRenderingInfo checks the parts of a RenderingInfo the driver would otherwise
+// only report through the validation layers
+func validateRenderingInfo(renderingInfo *RenderingInfo) error {
+	if renderingInfo == nil {
+		return NewValidationError("renderingInfo", "cannot be nil")
+	}
+	if renderingInfo.LayerCount == 0 && renderingInfo.ViewMask == 0 {
+		return NewValidationError("renderingInfo.LayerCount", "must be greater than 0 when ViewMask is 0")
+	}
+
+	for i := range renderingInfo.ColorAttachments {
+		if err := validateRenderingAttachment(&renderingInfo.ColorAttachments[i], fmt.Sprintf("renderingInfo.ColorAttachments[%d]", i)); err != nil {
+			return err
+		}
+	}
+	if renderingInfo.DepthAttachment != nil {
+		if err := validateRenderingAttachment(renderingInfo.DepthAttachment, "renderingInfo.DepthAttachment"); err != nil {
+			return err
+		}
+	}
+	if renderingInfo.StencilAttachment != nil {
+		if err := validateRenderingAttachment(renderingInfo.StencilAttachment, "renderingInfo.StencilAttachment"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateRenderingAttachment(attachment *RenderingAttachmentInfo, parameter string) error {
+	// A NullHandle image view is a legal unused slot; its other fields are ignored
+	if attachment.ImageView == ImageView(NullHandle) {
+		return nil
+	}
+	if attachment.ResolveImageView != ImageView(NullHandle) && attachment.ResolveMode == ResolveModeNone {
+		return NewValidationError(parameter, "ResolveImageView is set but ResolveMode is ResolveModeNone")
+	}
+	return checkClearValue(attachment, parameter)
+}
+
+// renderingAttachmentToC converts a rendering attachment to C. An attachment without an
+// image view is written as an empty slot so no stale resolve or clear state reaches the driver.
+func renderingAttachmentToC(attachment *RenderingAttachmentInfo) C.VkRenderingAttachmentInfo {
+	if attachment.ImageView == ImageView(NullHandle) {
+		return C.VkRenderingAttachmentInfo{
+			sType:     C.VK_STRUCTURE_TYPE_RENDERING_ATTACHMENT_INFO,
+			imageView: nil,
+		}
+	}
+	return C.VkRenderingAttachmentInfo{
+		sType:              C.VK_STRUCTURE_TYPE_RENDERING_ATTACHMENT_INFO,
+		pNext:              nil,
+		imageView:          C.VkImageView(attachment.ImageView),
+		imageLayout:        C.VkImageLayout(attachment.ImageLayout),
+		resolveMode:        C.VkResolveModeFlagBits(attachment.ResolveMode),
+		resolveImageView:   C.VkImageView(attachment.ResolveImageView),
+		resolveImageLayout: C.VkImageLayout(attachment.ResolveImageLayout),
+		loadOp:             C.VkAttachmentLoadOp(attachment.LoadOp),
+		storeOp:            C.VkAttachmentStoreOp(attachment.StoreOp),
+		clearValue:         clearValueToC(attachment.ClearValue),
+	}
+}
+
+// renderingInfoToC converts renderingInfo into C memory. The returned struct and its
+// attachment arrays are appended to allocations, which the caller must free.
+func renderingInfoToC(renderingInfo *RenderingInfo, allocations *[]unsafe.Pointer) (*C.VkRenderingInfo, error) {
+	cRenderingInfo := (*C.VkRenderingInfo)(C.calloc(1, C.sizeof_VkRenderingInfo))
+	if cRenderingInfo == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "CmdBeginRendering", "failed to allocate memory for rendering info")
 	}
+	*allocations = append(*allocations, unsafe.Pointer(cRenderingInfo))
+
+	cRenderingInfo.sType = C.VK_STRUCTURE_TYPE_RENDERING_INFO
+	cRenderingInfo.flags = C.VkRenderingFlags(renderingInfo.Flags)
+	cRenderingInfo.renderArea.offset.x = C.int32_t(renderingInfo.RenderArea.Offset.X)
+	cRenderingInfo.renderArea.offset.y = C.int32_t(renderingInfo.RenderArea.Offset.Y)
+	cRenderingInfo.renderArea.extent.width = C.uint32_t(renderingInfo.RenderArea.Extent.Width)
+	cRenderingInfo.renderArea.extent.height = C.uint32_t(renderingInfo.RenderArea.Extent.Height)
+	cRenderingInfo.layerCount = C.uint32_t(renderingInfo.LayerCount)
+	cRenderingInfo.viewMask = C.uint32_t(renderingInfo.ViewMask)
 
 	// Handle color attachments
 	if len(renderingInfo.ColorAttachments) > 0 {
-		cColorAttachments := make([]C.VkRenderingAttachmentInfo, len(renderingInfo.ColorAttachments))
-		for i, attachment := range renderingInfo.ColorAttachments {
-			cColorAttachments[i] = C.VkRenderingAttachmentInfo{
-				sType:              C.VK_STRUCTURE_TYPE_RENDERING_ATTACHMENT_INFO,
-				pNext:              nil,
-				imageView:          C.VkImageView(attachment.ImageView),
-				imageLayout:        C.VkImageLayout(attachment.ImageLayout),
-				resolveMode:        C.VkResolveModeFlagBits(attachment.ResolveMode),
-				resolveImageView:   C.VkImageView(attachment.ResolveImageView),
-				resolveImageLayout: C.VkImageLayout(attachment.ResolveImageLayout),
-				loadOp:             C.VkAttachmentLoadOp(attachment.LoadOp),
-				storeOp:            C.VkAttachmentStoreOp(attachment.StoreOp),
-				clearValue:         *(*C.VkClearValue)(unsafe.Pointer(&attachment.ClearValue)),
-			}
+		cColorAttachmentsPtr := (*C.VkRenderingAttachmentInfo)(C.calloc(C.size_t(len(renderingInfo.ColorAttachments)), C.sizeof_VkRenderingAttachmentInfo))
+		if cColorAttachmentsPtr == nil {
+			return nil, NewVulkanError(ErrorOutOfHostMemory, "CmdBeginRendering", "failed to allocate memory for color attachments")
 		}
-		cRenderingInfo.colorAttachmentCount = C.uint32_t(len(cColorAttachments))
-		if len(cColorAttachments) > 0 {
-			cRenderingInfo.pColorAttachments = &cColorAttachments[0]
+		*allocations = append(*allocations, unsafe.Pointer(cColorAttachmentsPtr))
+
+		cColorAttachments := unsafe.Slice(cColorAttachmentsPtr, len(renderingInfo.ColorAttachments))
+		for i := range renderingInfo.ColorAttachments {
+			cColorAttachments[i] = renderingAttachmentToC(&renderingInfo.ColorAttachments[i])
 		}
+		cRenderingInfo.colorAttachmentCount = C.uint32_t(len(renderingInfo.ColorAttachments))
+		cRenderingInfo.pColorAttachments = cColorAttachmentsPtr
 	}
 
-	// Handle depth attachment
-	if renderingInfo.DepthAttachment != nil {
-		cDepthAttachment := C.VkRenderingAttachmentInfo{
-			sType:              C.VK_STRUCTURE_TYPE_RENDERING_ATTACHMENT_INFO,
-			pNext:              nil,
-			imageView:          C.VkImageView(renderingInfo.DepthAttachment.ImageView),
-			imageLayout:        C.VkImageLayout(renderingInfo.DepthAttachment.ImageLayout),
-			resolveMode:        C.VkResolveModeFlagBits(renderingInfo.DepthAttachment.ResolveMode),
-			resolveImageView:   C.VkImageView(renderingInfo.DepthAttachment.ResolveImageView),
-			resolveImageLayout: C.VkImageLayout(renderingInfo.DepthAttachment.ResolveImageLayout),
-			loadOp:             C.VkAttachmentLoadOp(renderingInfo.DepthAttachment.LoadOp),
-			storeOp:            C.VkAttachmentStoreOp(renderingInfo.DepthAttachment.StoreOp),
-			clearValue:         *(*C.VkClearValue)(unsafe.Pointer(&renderingInfo.DepthAttachment.ClearValue)),
+	// Handle depth and stencil attachments
+	for _, attachment := range []struct {
+		info *RenderingAttachmentInfo
+		dst  **C.VkRenderingAttachmentInfo
+	}{
+		{renderingInfo.DepthAttachment, &cRenderingInfo.pDepthAttachment},
+		{renderingInfo.StencilAttachment, &cRenderingInfo.pStencilAttachment},
+	} {
+		if attachment.info == nil {
+			continue
 		}
-		cRenderingInfo.pDepthAttachment = &cDepthAttachment
+		cAttachment := (*C.VkRenderingAttachmentInfo)(C.calloc(1, C.sizeof_VkRenderingAttachmentInfo))
+		if cAttachment == nil {
+			return nil, NewVulkanError(ErrorOutOfHostMemory, "CmdBeginRendering", "failed to allocate memory for depth/stencil attachment")
+		}
+		*allocations = append(*allocations, unsafe.Pointer(cAttachment))
+		*cAttachment = renderingAttachmentToC(attachment.info)
+		*attachment.dst = cAttachment
 	}
 
-	// Handle stencil attachment
-	if renderingInfo.StencilAttachment != nil {
-		cStencilAttachment := C.VkRenderingAttachmentInfo{
-			sType:              C.VK_STRUCTURE_TYPE_RENDERING_ATTACHMENT_INFO,
-			pNext:              nil,
-			imageView:          C.VkImageView(renderingInfo.StencilAttachment.ImageView),
-			imageLayout:        C.VkImageLayout(renderingInfo.StencilAttachment.ImageLayout),
-			resolveMode:        C.VkResolveModeFlagBits(renderingInfo.StencilAttachment.ResolveMode),
-			resolveImageView:   C.VkImageView(renderingInfo.StencilAttachment.ResolveImageView),
-			resolveImageLayout: C.VkImageLayout(renderingInfo.StencilAttachment.ResolveImageLayout),
-			loadOp:             C.VkAttachmentLoadOp(renderingInfo.StencilAttachment.LoadOp),
-			storeOp:            C.VkAttachmentStoreOp(renderingInfo.StencilAttachment.StoreOp),
-			clearValue:         *(*C.VkClearValue)(unsafe.Pointer(&renderingInfo.StencilAttachment.ClearValue)),
-		}
-		cRenderingInfo.pStencilAttachment = &cStencilAttachment
+	return cRenderingInfo, nil
+}
+
+// CmdBeginRendering begins a render pass instance with dynamic rendering. It uses the core
+// command, or the VK_KHR_dynamic_rendering one on Vulkan 1.2 devices that enable the
+// extension, resolved for the device the command buffer was allocated from. The device must
+// enable the dynamicRendering feature through DeviceCreateInfo.Vulkan13Features or, on 1.2
+// devices, DeviceCreateInfo.DynamicRenderingFeatures.
+//
+// Returns ErrorExtensionNotPresent if the device exposes neither command, or if the command
+// buffer was not allocated through AllocateCommandBuffers and LoadDynamicRenderingFunctions
+// was not called with its device.
+func CmdBeginRendering(commandBuffer CommandBuffer, renderingInfo *RenderingInfo) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
 	}
+	if err := validateRenderingInfo(renderingInfo); err != nil {
+		return err
+	}
+	entry, err := dynamicRenderingCommands("CmdBeginRendering", commandBuffer)
+	if err != nil {
+		return err
+	}
+
+	var allocations []unsafe.Pointer
+	defer func() { freeAllocations(allocations) }()
 
-	C.vkCmdBeginRendering(C.VkCommandBuffer(commandBuffer), &cRenderingInfo)
+	cRenderingInfo, err := renderingInfoToC(renderingInfo, &allocations)
+	if err != nil {
+		return err
+	}
+
+	C.dispatch_vkCmdBeginRendering(entry.fns.begin, C.VkCommandBuffer(commandBuffer), cRenderingInfo)
+	return nil
 }
 
 // CmdEndRendering ends a render pass instance with dynamic rendering
-func CmdEndRendering(commandBuffer CommandBuffer) {
-	C.vkCmdEndRendering(C.VkCommandBuffer(commandBuffer))
+func CmdEndRendering(commandBuffer CommandBuffer) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+	entry, err := dynamicRenderingCommands("CmdEndRendering", commandBuffer)
+	if err != nil {
+		return err
+	}
+	C.dispatch_vkCmdEndRendering(entry.fns.end, C.VkCommandBuffer(commandBuffer))
+	return nil
+}
+
+// CmdBeginRenderingKHR begins a render pass instance through VK_KHR_dynamic_rendering.
+// Returns an error if the command buffer's device does not expose the extension.
+func CmdBeginRenderingKHR(commandBuffer CommandBuffer, renderingInfo *RenderingInfo) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+	if err := validateRenderingInfo(renderingInfo); err != nil {
+		return err
+	}
+
+	var allocations []unsafe.Pointer
+	defer func() { freeAllocations(allocations) }()
+
+	cRenderingInfo, err := renderingInfoToC(renderingInfo, &allocations)
+	if err != nil {
+		return err
+	}
+
+	if C.call_vkCmdBeginRenderingKHR(dynamicRenderingFor(commandBuffer).fns.beginKHR, C.VkCommandBuffer(commandBuffer), cRenderingInfo) == 0 {
+		return NewVulkanError(ErrorExtensionNotPresent, "CmdBeginRenderingKHR", "dynamic rendering extension not available - enable VK_KHR_dynamic_rendering on the device")
+	}
+	return nil
+}
+
+// CmdEndRenderingKHR ends a render pass instance begun with CmdBeginRenderingKHR
+func CmdEndRenderingKHR(commandBuffer CommandBuffer) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+	if C.call_vkCmdEndRenderingKHR(dynamicRenderingFor(commandBuffer).fns.endKHR, C.VkCommandBuffer(commandBuffer)) == 0 {
+		return NewVulkanError(ErrorExtensionNotPresent, "CmdEndRenderingKHR", "dynamic rendering extension not available - enable VK_KHR_dynamic_rendering on the device")
+	}
+	return nil
+}
+
+// ValidateRenderingResume checks that resumed can resume the render pass instance suspended
+// with suspended: suspended must set RenderingSuspending, resumed must set RenderingResuming,
+// and everything except those two flags must be identical.
+func ValidateRenderingResume(suspended, resumed *RenderingInfo) error {
+	if suspended == nil {
+		return NewValidationError("suspended", "cannot be nil")
+	}
+	if resumed == nil {
+		return NewValidationError("resumed", "cannot be nil")
+	}
+	if suspended.Flags&RenderingSuspending == 0 {
+		return NewValidationError("suspended.Flags", "must include RenderingSuspending")
+	}
+	if resumed.Flags&RenderingResuming == 0 {
+		return NewValidationError("resumed.Flags", "must include RenderingResuming")
+	}
+
+	const suspendResume = RenderingSuspending | RenderingResuming
+	if suspended.Flags&^suspendResume != resumed.Flags&^suspendResume {
+		return NewValidationError("resumed.Flags", "must match the suspended flags apart from RenderingSuspending and RenderingResuming")
+	}
+	if suspended.RenderArea != resumed.RenderArea {
+		return NewValidationError("resumed.RenderArea", "must match the suspended render area")
+	}
+	if suspended.LayerCount != resumed.LayerCount || suspended.ViewMask != resumed.ViewMask {
+		return NewValidationError("resumed.LayerCount", "layer count and view mask must match the suspended render pass instance")
+	}
+	if !slices.Equal(suspended.ColorAttachments, resumed.ColorAttachments) {
+		return NewValidationError("resumed.ColorAttachments", "must match the suspended color attachments")
+	}
+	if !equalRenderingAttachment(suspended.DepthAttachment, resumed.DepthAttachment) {
+		return NewValidationError("resumed.DepthAttachment", "must match the suspended depth attachment")
+	}
+	if !equalRenderingAttachment(suspended.StencilAttachment, resumed.StencilAttachment) {
+		return NewValidationError("resumed.StencilAttachment", "must match the suspended stencil attachment")
+	}
+	return nil
+}
+
+func equalRenderingAttachment(a, b *RenderingAttachmentInfo) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// SuspendableRendering records one dynamic render pass instance split across several primary
+// command buffers. Each command buffer records a segment between Begin and End; every segment
+// but the last is suspended and every segment but the first resumes the previous one, so
+// the attachments are loaded and stored only once.
+//
+// The command buffers must be submitted in recording order within a single QueueSubmit call,
+// with no other commands between the segments apart from those of other suspended render pass
+// instances.
+type SuspendableRendering struct {
+	info      RenderingInfo
+	segments  int
+	recording bool
+	finished  bool
+}
+
+// NewSuspendableRendering prepares a split render pass instance. renderingInfo is copied, so
+// every segment uses identical attachment state; it must not set RenderingSuspending or
+// RenderingResuming, which are managed per segment.
+func NewSuspendableRendering(renderingInfo *RenderingInfo) (*SuspendableRendering, error) {
+	if err := validateRenderingInfo(renderingInfo); err != nil {
+		return nil, err
+	}
+	if renderingInfo.Flags&(RenderingSuspending|RenderingResuming) != 0 {
+		return nil, NewValidationError("renderingInfo.Flags", "RenderingSuspending and RenderingResuming are set by SuspendableRendering")
+	}
+
+	info := *renderingInfo
+	info.ColorAttachments = slices.Clone(renderingInfo.ColorAttachments)
+	if renderingInfo.DepthAttachment != nil {
+		depth := *renderingInfo.DepthAttachment
+		info.DepthAttachment = &depth
+	}
+	if renderingInfo.StencilAttachment != nil {
+		stencil := *renderingInfo.StencilAttachment
+		info.StencilAttachment = &stencil
+	}
+	return &SuspendableRendering{info: info}, nil
+}
+
+// segmentInfo returns the rendering info for the next segment
+func (r *SuspendableRendering) segmentInfo(last bool) RenderingInfo {
+	info := r.info
+	if r.segments > 0 {
+		info.Flags |= RenderingResuming
+	}
+	if !last {
+		info.Flags |= RenderingSuspending
+	}
+	return info
+}
+
+// Begin begins the next segment in commandBuffer. Pass last for the final segment, which
+// completes the render pass instance instead of suspending it.
+func (r *SuspendableRendering) Begin(commandBuffer CommandBuffer, last bool) error {
+	if r.recording {
+		return NewValidationError("commandBuffer", "previous segment has not been ended")
+	}
+	if r.finished {
+		return NewValidationError("commandBuffer", "render pass instance is already complete")
+	}
+
+	info := r.segmentInfo(last)
+	if err := CmdBeginRendering(commandBuffer, &info); err != nil {
+		return err
+	}
+	r.segments++
+	r.recording = true
+	r.finished = last
+	return nil
+}
+
+// End ends the current segment in commandBuffer, suspending the render pass instance unless
+// the segment was begun as the last one
+func (r *SuspendableRendering) End(commandBuffer CommandBuffer) error {
+	if !r.recording {
+		return NewValidationError("commandBuffer", "no segment has been begun")
+	}
+	if err := CmdEndRendering(commandBuffer); err != nil {
+		return err
+	}
+	r.recording = false
+	return nil
 }
 
 // ============================================================================
@@ -137,16 +756,20 @@ const (
 
 // SemaphoreSubmitInfo describes a semaphore signal or wait operation
 type SemaphoreSubmitInfo struct {
-	Semaphore   Semaphore
-	Value       uint64
-	StageMask   PipelineStageFlags2
+	Semaphore Semaphore
+	Value     uint64
+	StageMask PipelineStageFlags2
+	// DeviceIndex selects the device of a device group that executes the operation. It is
+	// ignored unless the device was created with DeviceCreateInfo.DeviceGroup.
 	DeviceIndex uint32
 }
 
 // CommandBufferSubmitInfo describes a command buffer submit operation
 type CommandBufferSubmitInfo struct {
 	CommandBuffer CommandBuffer
-	DeviceMask    uint32
+	// DeviceMask selects the devices of a device group that execute the command buffer.
+	// 0 means all of them; bit i selects DeviceGroupDeviceCreateInfo.PhysicalDevices[i].
+	DeviceMask uint32
 }
 
 // SubmitInfo2 describes a queue submission operation with enhanced synchronization
@@ -188,92 +811,357 @@ const (
 	PipelineStage2PreRasterizationShaders      PipelineStageFlags2 = 0x4000000000
 )
 
-// QueueSubmit2 submits command buffers to a queue with enhanced synchronization
-func QueueSubmit2(queue Queue, submitInfos []SubmitInfo2, fence Fence) error {
-	var cSubmitInfos []C.VkSubmitInfo2
-	if len(submitInfos) > 0 {
-		cSubmitInfos = make([]C.VkSubmitInfo2, len(submitInfos))
-
-		for i, submitInfo := range submitInfos {
-			cSubmitInfos[i].sType = C.VK_STRUCTURE_TYPE_SUBMIT_INFO_2
-			cSubmitInfos[i].pNext = nil
-			cSubmitInfos[i].flags = C.VkSubmitFlags(submitInfo.Flags)
-
-			// Handle wait semaphores
-			if len(submitInfo.WaitSemaphoreInfos) > 0 {
-				cWaitSemaphoreInfos := make([]C.VkSemaphoreSubmitInfo, len(submitInfo.WaitSemaphoreInfos))
-				for j, waitInfo := range submitInfo.WaitSemaphoreInfos {
-					cWaitSemaphoreInfos[j] = C.VkSemaphoreSubmitInfo{
-						sType:       C.VK_STRUCTURE_TYPE_SEMAPHORE_SUBMIT_INFO,
-						pNext:       nil,
-						semaphore:   C.VkSemaphore(waitInfo.Semaphore),
-						value:       C.uint64_t(waitInfo.Value),
-						stageMask:   C.VkPipelineStageFlags2(waitInfo.StageMask),
-						deviceIndex: C.uint32_t(waitInfo.DeviceIndex),
-					}
-				}
-				cSubmitInfos[i].waitSemaphoreInfoCount = C.uint32_t(len(cWaitSemaphoreInfos))
-				if len(cWaitSemaphoreInfos) > 0 {
-					cSubmitInfos[i].pWaitSemaphoreInfos = &cWaitSemaphoreInfos[0]
-				}
+// semaphoreSubmitInfosToC copies semaphore submit infos into C memory
+func semaphoreSubmitInfosToC(infos []SemaphoreSubmitInfo, allocations *[]unsafe.Pointer) (*C.VkSemaphoreSubmitInfo, error) {
+	cInfosPtr := (*C.VkSemaphoreSubmitInfo)(C.calloc(C.size_t(len(infos)), C.sizeof_VkSemaphoreSubmitInfo))
+	if cInfosPtr == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "QueueSubmit2", "failed to allocate memory for semaphore submit infos")
+	}
+	*allocations = append(*allocations, unsafe.Pointer(cInfosPtr))
+
+	cInfos := unsafe.Slice(cInfosPtr, len(infos))
+	for i, info := range infos {
+		cInfos[i] = C.VkSemaphoreSubmitInfo{
+			sType:       C.VK_STRUCTURE_TYPE_SEMAPHORE_SUBMIT_INFO,
+			semaphore:   C.VkSemaphore(info.Semaphore),
+			value:       C.uint64_t(info.Value),
+			stageMask:   C.VkPipelineStageFlags2(info.StageMask),
+			deviceIndex: C.uint32_t(info.DeviceIndex),
+		}
+	}
+	return cInfosPtr, nil
+}
+
+// submitInfos2ToC converts submitInfos into a C array. It returns nil for an empty slice.
+func submitInfos2ToC(submitInfos []SubmitInfo2, allocations *[]unsafe.Pointer) (*C.VkSubmitInfo2, error) {
+	if len(submitInfos) == 0 {
+		return nil, nil
+	}
+
+	cSubmitInfosPtr := (*C.VkSubmitInfo2)(C.calloc(C.size_t(len(submitInfos)), C.sizeof_VkSubmitInfo2))
+	if cSubmitInfosPtr == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "QueueSubmit2", "failed to allocate memory for submit infos")
+	}
+	*allocations = append(*allocations, unsafe.Pointer(cSubmitInfosPtr))
+
+	cSubmitInfos := unsafe.Slice(cSubmitInfosPtr, len(submitInfos))
+	for i, submitInfo := range submitInfos {
+		cSubmitInfos[i].sType = C.VK_STRUCTURE_TYPE_SUBMIT_INFO_2
+		cSubmitInfos[i].flags = C.VkSubmitFlags(submitInfo.Flags)
+
+		// Handle wait semaphores
+		if len(submitInfo.WaitSemaphoreInfos) > 0 {
+			cWaits, err := semaphoreSubmitInfosToC(submitInfo.WaitSemaphoreInfos, allocations)
+			if err != nil {
+				return nil, err
 			}
+			cSubmitInfos[i].waitSemaphoreInfoCount = C.uint32_t(len(submitInfo.WaitSemaphoreInfos))
+			cSubmitInfos[i].pWaitSemaphoreInfos = cWaits
+		}
 
-			// Handle command buffers
-			if len(submitInfo.CommandBufferInfos) > 0 {
-				cCommandBufferInfos := make([]C.VkCommandBufferSubmitInfo, len(submitInfo.CommandBufferInfos))
-				for j, cmdInfo := range submitInfo.CommandBufferInfos {
-					cCommandBufferInfos[j] = C.VkCommandBufferSubmitInfo{
-						sType:         C.VK_STRUCTURE_TYPE_COMMAND_BUFFER_SUBMIT_INFO,
-						pNext:         nil,
-						commandBuffer: C.VkCommandBuffer(cmdInfo.CommandBuffer),
-						deviceMask:    C.uint32_t(cmdInfo.DeviceMask),
-					}
-				}
-				cSubmitInfos[i].commandBufferInfoCount = C.uint32_t(len(cCommandBufferInfos))
-				if len(cCommandBufferInfos) > 0 {
-					cSubmitInfos[i].pCommandBufferInfos = &cCommandBufferInfos[0]
+		// Handle command buffers
+		if len(submitInfo.CommandBufferInfos) > 0 {
+			cCommandBufferInfosPtr := (*C.VkCommandBufferSubmitInfo)(C.calloc(C.size_t(len(submitInfo.CommandBufferInfos)), C.sizeof_VkCommandBufferSubmitInfo))
+			if cCommandBufferInfosPtr == nil {
+				return nil, NewVulkanError(ErrorOutOfHostMemory, "QueueSubmit2", "failed to allocate memory for command buffer submit infos")
+			}
+			*allocations = append(*allocations, unsafe.Pointer(cCommandBufferInfosPtr))
+
+			cCommandBufferInfos := unsafe.Slice(cCommandBufferInfosPtr, len(submitInfo.CommandBufferInfos))
+			for j, cmdInfo := range submitInfo.CommandBufferInfos {
+				cCommandBufferInfos[j] = C.VkCommandBufferSubmitInfo{
+					sType:         C.VK_STRUCTURE_TYPE_COMMAND_BUFFER_SUBMIT_INFO,
+					commandBuffer: C.VkCommandBuffer(cmdInfo.CommandBuffer),
+					deviceMask:    C.uint32_t(cmdInfo.DeviceMask),
 				}
 			}
+			cSubmitInfos[i].commandBufferInfoCount = C.uint32_t(len(submitInfo.CommandBufferInfos))
+			cSubmitInfos[i].pCommandBufferInfos = cCommandBufferInfosPtr
+		}
 
-			// Handle signal semaphores
-			if len(submitInfo.SignalSemaphoreInfos) > 0 {
-				cSignalSemaphoreInfos := make([]C.VkSemaphoreSubmitInfo, len(submitInfo.SignalSemaphoreInfos))
-				for j, signalInfo := range submitInfo.SignalSemaphoreInfos {
-					cSignalSemaphoreInfos[j] = C.VkSemaphoreSubmitInfo{
-						sType:       C.VK_STRUCTURE_TYPE_SEMAPHORE_SUBMIT_INFO,
-						pNext:       nil,
-						semaphore:   C.VkSemaphore(signalInfo.Semaphore),
-						value:       C.uint64_t(signalInfo.Value),
-						stageMask:   C.VkPipelineStageFlags2(signalInfo.StageMask),
-						deviceIndex: C.uint32_t(signalInfo.DeviceIndex),
-					}
-				}
-				cSubmitInfos[i].signalSemaphoreInfoCount = C.uint32_t(len(cSignalSemaphoreInfos))
-				if len(cSignalSemaphoreInfos) > 0 {
-					cSubmitInfos[i].pSignalSemaphoreInfos = &cSignalSemaphoreInfos[0]
-				}
+		// Handle signal semaphores
+		if len(submitInfo.SignalSemaphoreInfos) > 0 {
+			cSignals, err := semaphoreSubmitInfosToC(submitInfo.SignalSemaphoreInfos, allocations)
+			if err != nil {
+				return nil, err
 			}
+			cSubmitInfos[i].signalSemaphoreInfoCount = C.uint32_t(len(submitInfo.SignalSemaphoreInfos))
+			cSubmitInfos[i].pSignalSemaphoreInfos = cSignals
 		}
 	}
+	return cSubmitInfosPtr, nil
+}
+
+// QueueSubmit2 submits command buffers to a queue with enhanced synchronization.
+// An empty submitInfos is a legal no-op submission that still signals fence. The device must
+// have been created with Vulkan13Features.Synchronization2 enabled.
+func QueueSubmit2(queue Queue, submitInfos []SubmitInfo2, fence Fence) error {
+	if queue == nil {
+		return NewValidationError("queue", "cannot be nil")
+	}
+
+	var allocations []unsafe.Pointer
+	defer func() { freeAllocations(allocations) }()
 
-	var pSubmitInfos *C.VkSubmitInfo2
-	if len(cSubmitInfos) > 0 {
-		pSubmitInfos = &cSubmitInfos[0]
+	pSubmitInfos, err := submitInfos2ToC(submitInfos, &allocations)
+	if err != nil {
+		return err
 	}
 
-	result := C.vkQueueSubmit2(
+	result := Result(C.vkQueueSubmit2(
 		C.VkQueue(queue),
-		C.uint32_t(len(cSubmitInfos)),
+		C.uint32_t(len(submitInfos)),
 		pSubmitInfos,
 		C.VkFence(fence),
-	)
+	))
+	traceResult("vkQueueSubmit2", result)
+	if result != Success {
+		return NewVulkanError(result, "QueueSubmit2", "failed to submit to queue")
+	}
+	return nil
+}
 
-	if result != C.VK_SUCCESS {
-		return Result(result)
+// LoadSynchronization2Functions loads vkQueueSubmit2KHR for a device that exposes
+// VK_KHR_synchronization2 without core Vulkan 1.3 support. The device must have been created
+// with ExtensionNameSynchronization2 and Synchronization2Features enabled.
+//
+// IMPORTANT: This function is NOT thread-safe. Only one device is supported at a time;
+// calling this function again will overwrite the previously loaded function pointer.
+func LoadSynchronization2Functions(device Device) bool {
+	return C.loadSynchronization2DeviceFunctions(C.VkDevice(device)) != 0
+}
+
+// QueueSubmit2KHR is the VK_KHR_synchronization2 form of QueueSubmit2. It requires the
+// synchronization2 feature, enabled through DeviceCreateInfo.Synchronization2Features.
+// Returns an error if LoadSynchronization2Functions was not called.
+func QueueSubmit2KHR(queue Queue, submitInfos []SubmitInfo2, fence Fence) error {
+	if queue == nil {
+		return NewValidationError("queue", "cannot be nil")
+	}
+
+	var allocations []unsafe.Pointer
+	defer func() { freeAllocations(allocations) }()
+
+	pSubmitInfos, err := submitInfos2ToC(submitInfos, &allocations)
+	if err != nil {
+		return err
+	}
+
+	result := Result(C.call_vkQueueSubmit2KHR(
+		C.VkQueue(queue),
+		C.uint32_t(len(submitInfos)),
+		pSubmitInfos,
+		C.VkFence(fence),
+	))
+	traceResult("vkQueueSubmit2KHR", result)
+	if result == ErrorExtensionNotPresent {
+		return NewVulkanError(result, "QueueSubmit2KHR", "synchronization2 extension not loaded - call LoadSynchronization2Functions first")
+	}
+	if result != Success {
+		return NewVulkanError(result, "QueueSubmit2KHR", "failed to submit to queue")
 	}
 	return nil
 }
 
+// AccessFlags2 represents enhanced memory access flags
+type AccessFlags2 uint64
+
+const (
+	Access2None                        AccessFlags2 = 0
+	Access2IndirectCommandRead         AccessFlags2 = 0x00000001
+	Access2IndexRead                   AccessFlags2 = 0x00000002
+	Access2VertexAttributeRead         AccessFlags2 = 0x00000004
+	Access2UniformRead                 AccessFlags2 = 0x00000008
+	Access2InputAttachmentRead         AccessFlags2 = 0x00000010
+	Access2ShaderRead                  AccessFlags2 = 0x00000020
+	Access2ShaderWrite                 AccessFlags2 = 0x00000040
+	Access2ColorAttachmentRead         AccessFlags2 = 0x00000080
+	Access2ColorAttachmentWrite        AccessFlags2 = 0x00000100
+	Access2DepthStencilAttachmentRead  AccessFlags2 = 0x00000200
+	Access2DepthStencilAttachmentWrite AccessFlags2 = 0x00000400
+	Access2TransferRead                AccessFlags2 = 0x00000800
+	Access2TransferWrite               AccessFlags2 = 0x00001000
+	Access2HostRead                    AccessFlags2 = 0x00002000
+	Access2HostWrite                   AccessFlags2 = 0x00004000
+	Access2MemoryRead                  AccessFlags2 = 0x00008000
+	Access2MemoryWrite                 AccessFlags2 = 0x00010000
+	Access2ShaderSampledRead           AccessFlags2 = 0x100000000
+	Access2ShaderStorageRead           AccessFlags2 = 0x200000000
+	Access2ShaderStorageWrite          AccessFlags2 = 0x400000000
+)
+
+// MemoryBarrier2 describes a global memory dependency
+type MemoryBarrier2 struct {
+	SrcStageMask  PipelineStageFlags2
+	SrcAccessMask AccessFlags2
+	DstStageMask  PipelineStageFlags2
+	DstAccessMask AccessFlags2
+}
+
+// BufferMemoryBarrier2 describes a memory dependency on a range of a buffer. Leave both queue
+// family indices equal, for example 0, unless ownership is transferred between queue families.
+type BufferMemoryBarrier2 struct {
+	SrcStageMask        PipelineStageFlags2
+	SrcAccessMask       AccessFlags2
+	DstStageMask        PipelineStageFlags2
+	DstAccessMask       AccessFlags2
+	SrcQueueFamilyIndex uint32
+	DstQueueFamilyIndex uint32
+	Buffer              Buffer
+	Offset              DeviceSize
+	Size                DeviceSize
+}
+
+// ImageMemoryBarrier2 describes a memory dependency and optional layout transition on an
+// image subresource range. Leave both queue family indices equal, for example 0, unless
+// ownership is transferred between queue families.
+type ImageMemoryBarrier2 struct {
+	SrcStageMask        PipelineStageFlags2
+	SrcAccessMask       AccessFlags2
+	DstStageMask        PipelineStageFlags2
+	DstAccessMask       AccessFlags2
+	OldLayout           ImageLayout
+	NewLayout           ImageLayout
+	SrcQueueFamilyIndex uint32
+	DstQueueFamilyIndex uint32
+	Image               Image
+	SubresourceRange    ImageSubresourceRange
+}
+
+// DependencyInfo groups the barriers recorded by one CmdPipelineBarrier2 call
+type DependencyInfo struct {
+	DependencyFlags      uint32
+	MemoryBarriers       []MemoryBarrier2
+	BufferMemoryBarriers []BufferMemoryBarrier2
+	ImageMemoryBarriers  []ImageMemoryBarrier2
+}
+
+// dependencyInfoToC copies dependencyInfo and its barrier arrays into C memory
+func dependencyInfoToC(dependencyInfo *DependencyInfo, allocations *[]unsafe.Pointer) (*C.VkDependencyInfo, error) {
+	cDependencyInfo := (*C.VkDependencyInfo)(C.calloc(1, C.sizeof_VkDependencyInfo))
+	if cDependencyInfo == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "CmdPipelineBarrier2", "failed to allocate memory for dependency info")
+	}
+	*allocations = append(*allocations, unsafe.Pointer(cDependencyInfo))
+	cDependencyInfo.sType = C.VK_STRUCTURE_TYPE_DEPENDENCY_INFO
+	cDependencyInfo.dependencyFlags = C.VkDependencyFlags(dependencyInfo.DependencyFlags)
+
+	if len(dependencyInfo.MemoryBarriers) > 0 {
+		cBarriersPtr := (*C.VkMemoryBarrier2)(C.calloc(C.size_t(len(dependencyInfo.MemoryBarriers)), C.sizeof_VkMemoryBarrier2))
+		if cBarriersPtr == nil {
+			return nil, NewVulkanError(ErrorOutOfHostMemory, "CmdPipelineBarrier2", "failed to allocate memory for memory barriers")
+		}
+		*allocations = append(*allocations, unsafe.Pointer(cBarriersPtr))
+
+		cBarriers := unsafe.Slice(cBarriersPtr, len(dependencyInfo.MemoryBarriers))
+		for i, barrier := range dependencyInfo.MemoryBarriers {
+			cBarriers[i] = C.VkMemoryBarrier2{
+				sType:         C.VK_STRUCTURE_TYPE_MEMORY_BARRIER_2,
+				srcStageMask:  C.VkPipelineStageFlags2(barrier.SrcStageMask),
+				srcAccessMask: C.VkAccessFlags2(barrier.SrcAccessMask),
+				dstStageMask:  C.VkPipelineStageFlags2(barrier.DstStageMask),
+				dstAccessMask: C.VkAccessFlags2(barrier.DstAccessMask),
+			}
+		}
+		cDependencyInfo.memoryBarrierCount = C.uint32_t(len(dependencyInfo.MemoryBarriers))
+		cDependencyInfo.pMemoryBarriers = cBarriersPtr
+	}
+
+	if len(dependencyInfo.BufferMemoryBarriers) > 0 {
+		cBarriersPtr := (*C.VkBufferMemoryBarrier2)(C.calloc(C.size_t(len(dependencyInfo.BufferMemoryBarriers)), C.sizeof_VkBufferMemoryBarrier2))
+		if cBarriersPtr == nil {
+			return nil, NewVulkanError(ErrorOutOfHostMemory, "CmdPipelineBarrier2", "failed to allocate memory for buffer memory barriers")
+		}
+		*allocations = append(*allocations, unsafe.Pointer(cBarriersPtr))
+
+		cBarriers := unsafe.Slice(cBarriersPtr, len(dependencyInfo.BufferMemoryBarriers))
+		for i, barrier := range dependencyInfo.BufferMemoryBarriers {
+			cBarriers[i] = C.VkBufferMemoryBarrier2{
+				sType:               C.VK_STRUCTURE_TYPE_BUFFER_MEMORY_BARRIER_2,
+				srcStageMask:        C.VkPipelineStageFlags2(barrier.SrcStageMask),
+				srcAccessMask:       C.VkAccessFlags2(barrier.SrcAccessMask),
+				dstStageMask:        C.VkPipelineStageFlags2(barrier.DstStageMask),
+				dstAccessMask:       C.VkAccessFlags2(barrier.DstAccessMask),
+				srcQueueFamilyIndex: C.uint32_t(barrier.SrcQueueFamilyIndex),
+				dstQueueFamilyIndex: C.uint32_t(barrier.DstQueueFamilyIndex),
+				buffer:              C.VkBuffer(barrier.Buffer),
+				offset:              C.VkDeviceSize(barrier.Offset),
+				size:                C.VkDeviceSize(barrier.Size),
+			}
+		}
+		cDependencyInfo.bufferMemoryBarrierCount = C.uint32_t(len(dependencyInfo.BufferMemoryBarriers))
+		cDependencyInfo.pBufferMemoryBarriers = cBarriersPtr
+	}
+
+	if len(dependencyInfo.ImageMemoryBarriers) > 0 {
+		cBarriersPtr := (*C.VkImageMemoryBarrier2)(C.calloc(C.size_t(len(dependencyInfo.ImageMemoryBarriers)), C.sizeof_VkImageMemoryBarrier2))
+		if cBarriersPtr == nil {
+			return nil, NewVulkanError(ErrorOutOfHostMemory, "CmdPipelineBarrier2", "failed to allocate memory for image memory barriers")
+		}
+		*allocations = append(*allocations, unsafe.Pointer(cBarriersPtr))
+
+		cBarriers := unsafe.Slice(cBarriersPtr, len(dependencyInfo.ImageMemoryBarriers))
+		for i, barrier := range dependencyInfo.ImageMemoryBarriers {
+			cBarriers[i] = C.VkImageMemoryBarrier2{
+				sType:               C.VK_STRUCTURE_TYPE_IMAGE_MEMORY_BARRIER_2,
+				srcStageMask:        C.VkPipelineStageFlags2(barrier.SrcStageMask),
+				srcAccessMask:       C.VkAccessFlags2(barrier.SrcAccessMask),
+				dstStageMask:        C.VkPipelineStageFlags2(barrier.DstStageMask),
+				dstAccessMask:       C.VkAccessFlags2(barrier.DstAccessMask),
+				oldLayout:           C.VkImageLayout(barrier.OldLayout),
+				newLayout:           C.VkImageLayout(barrier.NewLayout),
+				srcQueueFamilyIndex: C.uint32_t(barrier.SrcQueueFamilyIndex),
+				dstQueueFamilyIndex: C.uint32_t(barrier.DstQueueFamilyIndex),
+				image:               C.VkImage(barrier.Image),
+				subresourceRange: C.VkImageSubresourceRange{
+					aspectMask:     C.VkImageAspectFlags(barrier.SubresourceRange.AspectMask),
+					baseMipLevel:   C.uint32_t(barrier.SubresourceRange.BaseMipLevel),
+					levelCount:     C.uint32_t(barrier.SubresourceRange.LevelCount),
+					baseArrayLayer: C.uint32_t(barrier.SubresourceRange.BaseArrayLayer),
+					layerCount:     C.uint32_t(barrier.SubresourceRange.LayerCount),
+				},
+			}
+		}
+		cDependencyInfo.imageMemoryBarrierCount = C.uint32_t(len(dependencyInfo.ImageMemoryBarriers))
+		cDependencyInfo.pImageMemoryBarriers = cBarriersPtr
+	}
+	return cDependencyInfo, nil
+}
+
+// CmdPipelineBarrier2 records the memory, buffer and image barriers of dependencyInfo. Unlike
+// CmdPipelineBarrier, each barrier carries its own source and destination stages. The
+// synchronization2 feature must be enabled, through Vulkan13Features or
+// Synchronization2Features in DeviceCreateInfo.
+func CmdPipelineBarrier2(commandBuffer CommandBuffer, dependencyInfo *DependencyInfo) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+	if dependencyInfo == nil {
+		return NewValidationError("dependencyInfo", "cannot be nil")
+	}
+	for i, barrier := range dependencyInfo.BufferMemoryBarriers {
+		if barrier.Buffer == nil {
+			return NewValidationError(fmt.Sprintf("dependencyInfo.BufferMemoryBarriers[%d].Buffer", i), "cannot be nil")
+		}
+	}
+	for i, barrier := range dependencyInfo.ImageMemoryBarriers {
+		if barrier.Image == nil {
+			return NewValidationError(fmt.Sprintf("dependencyInfo.ImageMemoryBarriers[%d].Image", i), "cannot be nil")
+		}
+	}
+
+	var allocations []unsafe.Pointer
+	defer func() { freeAllocations(allocations) }()
+
+	cDependencyInfo, err := dependencyInfoToC(dependencyInfo, &allocations)
+	if err != nil {
+		return err
+	}
+
+	C.vkCmdPipelineBarrier2(C.VkCommandBuffer(commandBuffer), cDependencyInfo)
+	return nil
+}
+
 // ============================================================================
 // Extended Dynamic State (VK_EXT_extended_dynamic_state promoted to core)
 // ============================================================================
@@ -281,29 +1169,52 @@ func QueueSubmit2(queue Queue, submitInfos []SubmitInfo2, fence Fence) error {
 // Additional dynamic state commands that were promoted in Vulkan 1.3
 
 // CmdSetCullMode sets the cull mode dynamically
-func CmdSetCullMode(commandBuffer CommandBuffer, cullMode CullModeFlags) {
+func CmdSetCullMode(commandBuffer CommandBuffer, cullMode CullModeFlags) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+
 	C.vkCmdSetCullMode(C.VkCommandBuffer(commandBuffer), C.VkCullModeFlags(cullMode))
+	return nil
 }
 
 // CmdSetFrontFace sets the front face orientation dynamically
-func CmdSetFrontFace(commandBuffer CommandBuffer, frontFace FrontFace) {
+func CmdSetFrontFace(commandBuffer CommandBuffer, frontFace FrontFace) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+
 	C.vkCmdSetFrontFace(C.VkCommandBuffer(commandBuffer), C.VkFrontFace(frontFace))
+	return nil
 }
 
 // CmdSetPrimitiveTopology sets the primitive topology dynamically
-func CmdSetPrimitiveTopology(commandBuffer CommandBuffer, primitiveTopology PrimitiveTopology) {
+func CmdSetPrimitiveTopology(commandBuffer CommandBuffer, primitiveTopology PrimitiveTopology) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+
 	C.vkCmdSetPrimitiveTopology(C.VkCommandBuffer(commandBuffer), C.VkPrimitiveTopology(primitiveTopology))
+	return nil
 }
 
 // CmdSetViewportWithCount sets viewports with count dynamically
-func CmdSetViewportWithCount(commandBuffer CommandBuffer, viewports []Viewport) {
+func CmdSetViewportWithCount(commandBuffer CommandBuffer, viewports []Viewport) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
 	if len(viewports) == 0 {
-		return
+		return NewValidationError("viewports", "cannot be empty")
 	}
 
 	cViewports := make([]C.VkViewport, len(viewports))
 	for i, viewport := range viewports {
-		cViewports[i] = *(*C.VkViewport)(unsafe.Pointer(&viewport))
+		cViewports[i].x = C.float(viewport.X)
+		cViewports[i].y = C.float(viewport.Y)
+		cViewports[i].width = C.float(viewport.Width)
+		cViewports[i].height = C.float(viewport.Height)
+		cViewports[i].minDepth = C.float(viewport.MinDepth)
+		cViewports[i].maxDepth = C.float(viewport.MaxDepth)
 	}
 
 	C.vkCmdSetViewportWithCount(
@@ -311,17 +1222,24 @@ func CmdSetViewportWithCount(commandBuffer CommandBuffer, viewports []Viewport)
 		C.uint32_t(len(cViewports)),
 		&cViewports[0],
 	)
+	return nil
 }
 
 // CmdSetScissorWithCount sets scissor rectangles with count dynamically
-func CmdSetScissorWithCount(commandBuffer CommandBuffer, scissors []Rect2D) {
+func CmdSetScissorWithCount(commandBuffer CommandBuffer, scissors []Rect2D) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
 	if len(scissors) == 0 {
-		return
+		return NewValidationError("scissors", "cannot be empty")
 	}
 
 	cScissors := make([]C.VkRect2D, len(scissors))
 	for i, scissor := range scissors {
-		cScissors[i] = *(*C.VkRect2D)(unsafe.Pointer(&scissor))
+		cScissors[i].offset.x = C.int32_t(scissor.Offset.X)
+		cScissors[i].offset.y = C.int32_t(scissor.Offset.Y)
+		cScissors[i].extent.width = C.uint32_t(scissor.Extent.Width)
+		cScissors[i].extent.height = C.uint32_t(scissor.Extent.Height)
 	}
 
 	C.vkCmdSetScissorWithCount(
@@ -329,12 +1247,25 @@ func CmdSetScissorWithCount(commandBuffer CommandBuffer, scissors []Rect2D) {
 		C.uint32_t(len(cScissors)),
 		&cScissors[0],
 	)
+	return nil
 }
 
 // CmdBindVertexBuffers2 binds vertex buffers with extended parameters
-func CmdBindVertexBuffers2(commandBuffer CommandBuffer, firstBinding uint32, buffers []Buffer, offsets []DeviceSize, sizes []DeviceSize, strides []DeviceSize) {
+func CmdBindVertexBuffers2(commandBuffer CommandBuffer, firstBinding uint32, buffers []Buffer, offsets []DeviceSize, sizes []DeviceSize, strides []DeviceSize) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
 	if len(buffers) == 0 {
-		return
+		return NewValidationError("buffers", "cannot be empty")
+	}
+	if len(offsets) != len(buffers) {
+		return NewValidationError("offsets", "must have one entry per buffer")
+	}
+	if len(sizes) != 0 && len(sizes) != len(buffers) {
+		return NewValidationError("sizes", "must be empty or have one entry per buffer")
+	}
+	if len(strides) != 0 && len(strides) != len(buffers) {
+		return NewValidationError("strides", "must be empty or have one entry per buffer")
 	}
 
 	cBuffers := make([]C.VkBuffer, len(buffers))
@@ -390,35 +1321,65 @@ func CmdBindVertexBuffers2(commandBuffer CommandBuffer, firstBinding uint32, buf
 		pSizes,
 		pStrides,
 	)
+	return nil
 }
 
 // CmdSetDepthTestEnable sets depth test enable state dynamically
-func CmdSetDepthTestEnable(commandBuffer CommandBuffer, depthTestEnable bool) {
+func CmdSetDepthTestEnable(commandBuffer CommandBuffer, depthTestEnable bool) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+
 	C.vkCmdSetDepthTestEnable(C.VkCommandBuffer(commandBuffer), boolToVkBool32(depthTestEnable))
+	return nil
 }
 
 // CmdSetDepthWriteEnable sets depth write enable state dynamically
-func CmdSetDepthWriteEnable(commandBuffer CommandBuffer, depthWriteEnable bool) {
+func CmdSetDepthWriteEnable(commandBuffer CommandBuffer, depthWriteEnable bool) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+
 	C.vkCmdSetDepthWriteEnable(C.VkCommandBuffer(commandBuffer), boolToVkBool32(depthWriteEnable))
+	return nil
 }
 
 // CmdSetDepthCompareOp sets depth compare operation dynamically
-func CmdSetDepthCompareOp(commandBuffer CommandBuffer, depthCompareOp CompareOp) {
+func CmdSetDepthCompareOp(commandBuffer CommandBuffer, depthCompareOp CompareOp) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+
 	C.vkCmdSetDepthCompareOp(C.VkCommandBuffer(commandBuffer), C.VkCompareOp(depthCompareOp))
+	return nil
 }
 
 // CmdSetDepthBoundsTestEnable sets depth bounds test enable state dynamically
-func CmdSetDepthBoundsTestEnable(commandBuffer CommandBuffer, depthBoundsTestEnable bool) {
+func CmdSetDepthBoundsTestEnable(commandBuffer CommandBuffer, depthBoundsTestEnable bool) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+
 	C.vkCmdSetDepthBoundsTestEnable(C.VkCommandBuffer(commandBuffer), boolToVkBool32(depthBoundsTestEnable))
+	return nil
 }
 
 // CmdSetStencilTestEnable sets stencil test enable state dynamically
-func CmdSetStencilTestEnable(commandBuffer CommandBuffer, stencilTestEnable bool) {
+func CmdSetStencilTestEnable(commandBuffer CommandBuffer, stencilTestEnable bool) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+
 	C.vkCmdSetStencilTestEnable(C.VkCommandBuffer(commandBuffer), boolToVkBool32(stencilTestEnable))
+	return nil
 }
 
 // CmdSetStencilOp sets stencil operation dynamically
-func CmdSetStencilOp(commandBuffer CommandBuffer, faceMask StencilFaceFlags, failOp, passOp, depthFailOp StencilOp, compareOp CompareOp) {
+func CmdSetStencilOp(commandBuffer CommandBuffer, faceMask StencilFaceFlags, failOp, passOp, depthFailOp StencilOp, compareOp CompareOp) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+
 	C.vkCmdSetStencilOp(
 		C.VkCommandBuffer(commandBuffer),
 		C.VkStencilFaceFlags(faceMask),
@@ -427,6 +1388,37 @@ func CmdSetStencilOp(commandBuffer CommandBuffer, faceMask StencilFaceFlags, fai
 		C.VkStencilOp(depthFailOp),
 		C.VkCompareOp(compareOp),
 	)
+	return nil
+}
+
+// CmdSetPrimitiveRestartEnable sets primitive restart enable state dynamically
+func CmdSetPrimitiveRestartEnable(commandBuffer CommandBuffer, primitiveRestartEnable bool) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+
+	C.vkCmdSetPrimitiveRestartEnable(C.VkCommandBuffer(commandBuffer), boolToVkBool32(primitiveRestartEnable))
+	return nil
+}
+
+// CmdSetRasterizerDiscardEnable sets rasterizer discard enable state dynamically
+func CmdSetRasterizerDiscardEnable(commandBuffer CommandBuffer, rasterizerDiscardEnable bool) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+
+	C.vkCmdSetRasterizerDiscardEnable(C.VkCommandBuffer(commandBuffer), boolToVkBool32(rasterizerDiscardEnable))
+	return nil
+}
+
+// CmdSetDepthBiasEnable sets depth bias enable state dynamically
+func CmdSetDepthBiasEnable(commandBuffer CommandBuffer, depthBiasEnable bool) error {
+	if commandBuffer == nil {
+		return NewValidationError("commandBuffer", "cannot be nil")
+	}
+
+	C.vkCmdSetDepthBiasEnable(C.VkCommandBuffer(commandBuffer), boolToVkBool32(depthBiasEnable))
+	return nil
 }
 
 // ============================================================================
@@ -456,6 +1448,7 @@ func CreatePrivateDataSlot(device Device, createInfo *PrivateDataSlotCreateInfo)
 		nil,
 		&cPrivateDataSlot,
 	)
+	traceResult("vkCreatePrivateDataSlot", Result(result))
 
 	if result != C.VK_SUCCESS {
 		return PrivateDataSlot(nil), Result(result)
@@ -482,6 +1475,7 @@ func SetPrivateData(device Device, objectType ObjectType, objectHandle uint64, p
 		C.VkPrivateDataSlot(privateDataSlot),
 		C.uint64_t(data),
 	)
+	traceResult("vkSetPrivateData", Result(result))
 
 	if result != C.VK_SUCCESS {
 		return Result(result)
@@ -523,18 +1517,97 @@ type PipelineCreationFeedback struct {
 	Duration uint64
 }
 
-// PipelineCreationFeedbackCreateInfo contains pipeline creation feedback information
+// CacheHit reports whether the driver found the pipeline in the application-supplied pipeline cache
+func (f PipelineCreationFeedback) CacheHit() bool {
+	return f.Flags&PipelineCreationFeedbackValid != 0 && f.Flags&PipelineCreationFeedbackApplicationPipelineCacheHit != 0
+}
+
+// PipelineCreationFeedbackCreateInfo requests creation feedback for a pipeline. The driver
+// fills PipelineCreationFeedback and, if non-empty, one entry of PipelineStageCreationFeedbacks
+// per shader stage once the pipeline has been created. Durations are in nanoseconds.
 type PipelineCreationFeedbackCreateInfo struct {
 	PipelineCreationFeedback       *PipelineCreationFeedback
 	PipelineStageCreationFeedbacks []PipelineCreationFeedback
 }
 
+// validatePipelineCreationFeedback checks a feedback request against the pipeline's stage count
+func validatePipelineCreationFeedback(param string, info *PipelineCreationFeedbackCreateInfo, stageCount int) error {
+	if info.PipelineCreationFeedback == nil {
+		return NewValidationError(param+".PipelineCreationFeedback", "cannot be nil")
+	}
+	if n := len(info.PipelineStageCreationFeedbacks); n != 0 && n != stageCount {
+		return NewValidationError(param+".PipelineStageCreationFeedbacks",
+			fmt.Sprintf("must be empty or have one entry per shader stage (%d), got %d", stageCount, n))
+	}
+	return nil
+}
+
+// pipelineCreationFeedbackToC allocates a VkPipelineCreationFeedbackCreateInfo and its output
+// arrays in C memory so the driver can write to them
+func pipelineCreationFeedbackToC(info *PipelineCreationFeedbackCreateInfo, allocations *[]unsafe.Pointer) (*C.VkPipelineCreationFeedbackCreateInfo, error) {
+	cInfo := (*C.VkPipelineCreationFeedbackCreateInfo)(C.calloc(1, C.sizeof_VkPipelineCreationFeedbackCreateInfo))
+	if cInfo == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "pipelineCreationFeedbackToC", "failed to allocate feedback create info")
+	}
+	*allocations = append(*allocations, unsafe.Pointer(cInfo))
+	cInfo.sType = C.VK_STRUCTURE_TYPE_PIPELINE_CREATION_FEEDBACK_CREATE_INFO
+
+	cFeedback := (*C.VkPipelineCreationFeedback)(C.calloc(1, C.sizeof_VkPipelineCreationFeedback))
+	if cFeedback == nil {
+		return nil, NewVulkanError(ErrorOutOfHostMemory, "pipelineCreationFeedbackToC", "failed to allocate pipeline feedback")
+	}
+	*allocations = append(*allocations, unsafe.Pointer(cFeedback))
+	cInfo.pPipelineCreationFeedback = cFeedback
+
+	if n := len(info.PipelineStageCreationFeedbacks); n > 0 {
+		cStages := C.calloc(C.size_t(n), C.sizeof_VkPipelineCreationFeedback)
+		if cStages == nil {
+			return nil, NewVulkanError(ErrorOutOfHostMemory, "pipelineCreationFeedbackToC", "failed to allocate stage feedback")
+		}
+		*allocations = append(*allocations, cStages)
+		cInfo.pipelineStageCreationFeedbackCount = C.uint32_t(n)
+		cInfo.pPipelineStageCreationFeedbacks = (*C.VkPipelineCreationFeedback)(cStages)
+	}
+
+	return cInfo, nil
+}
+
+// copyPipelineCreationFeedback copies driver-written feedback back into the caller's structs
+func copyPipelineCreationFeedback(cInfo *C.VkPipelineCreationFeedbackCreateInfo, info *PipelineCreationFeedbackCreateInfo) {
+	info.PipelineCreationFeedback.Flags = PipelineCreationFeedbackFlags(cInfo.pPipelineCreationFeedback.flags)
+	info.PipelineCreationFeedback.Duration = uint64(cInfo.pPipelineCreationFeedback.duration)
+
+	if cInfo.pipelineStageCreationFeedbackCount == 0 {
+		return
+	}
+	cStages := unsafe.Slice(cInfo.pPipelineStageCreationFeedbacks, cInfo.pipelineStageCreationFeedbackCount)
+	for i := range info.PipelineStageCreationFeedbacks {
+		info.PipelineStageCreationFeedbacks[i].Flags = PipelineCreationFeedbackFlags(cStages[i].flags)
+		info.PipelineStageCreationFeedbacks[i].Duration = uint64(cStages[i].duration)
+	}
+}
+
 // ============================================================================
 // Maintenance4 (VK_KHR_maintenance4 promoted to core)
 // ============================================================================
 
 // GetDeviceBufferMemoryRequirements gets buffer memory requirements without creating a buffer (Vulkan 1.3)
-func GetDeviceBufferMemoryRequirements(device Device, bufferCreateInfo *BufferCreateInfo) MemoryRequirements {
+func GetDeviceBufferMemoryRequirements(device Device, bufferCreateInfo *BufferCreateInfo) (MemoryRequirements, error) {
+	if device == nil {
+		return MemoryRequirements{}, NewValidationError("device", "cannot be nil")
+	}
+	if bufferCreateInfo == nil {
+		return MemoryRequirements{}, NewValidationError("bufferCreateInfo", "cannot be nil")
+	}
+
+	cIndices, cIndexCount, err := queueFamilyIndicesToC(bufferCreateInfo.SharingMode, bufferCreateInfo.QueueFamilyIndices)
+	if err != nil {
+		return MemoryRequirements{}, err
+	}
+	if cIndices != nil {
+		defer C.free(unsafe.Pointer(cIndices))
+	}
+
 	cBufferCreateInfo := C.VkBufferCreateInfo{
 		sType:                 C.VK_STRUCTURE_TYPE_BUFFER_CREATE_INFO,
 		pNext:                 nil,
@@ -542,8 +1615,8 @@ func GetDeviceBufferMemoryRequirements(device Device, bufferCreateInfo *BufferCr
 		size:                  C.VkDeviceSize(bufferCreateInfo.Size),
 		usage:                 C.VkBufferUsageFlags(bufferCreateInfo.Usage),
 		sharingMode:           C.VkSharingMode(bufferCreateInfo.SharingMode),
-		queueFamilyIndexCount: 0,
-		pQueueFamilyIndices:   nil,
+		queueFamilyIndexCount: cIndexCount,
+		pQueueFamilyIndices:   cIndices,
 	}
 
 	cDeviceBufferMemoryRequirements := C.VkDeviceBufferMemoryRequirements{
@@ -566,11 +1639,26 @@ func GetDeviceBufferMemoryRequirements(device Device, bufferCreateInfo *BufferCr
 		Size:           DeviceSize(cMemoryRequirements.memoryRequirements.size),
 		Alignment:      DeviceSize(cMemoryRequirements.memoryRequirements.alignment),
 		MemoryTypeBits: uint32(cMemoryRequirements.memoryRequirements.memoryTypeBits),
-	}
+	}, nil
 }
 
 // GetDeviceImageMemoryRequirements gets image memory requirements without creating an image (Vulkan 1.3)
-func GetDeviceImageMemoryRequirements(device Device, imageCreateInfo *ImageCreateInfo) MemoryRequirements {
+func GetDeviceImageMemoryRequirements(device Device, imageCreateInfo *ImageCreateInfo) (MemoryRequirements, error) {
+	if device == nil {
+		return MemoryRequirements{}, NewValidationError("device", "cannot be nil")
+	}
+	if imageCreateInfo == nil {
+		return MemoryRequirements{}, NewValidationError("imageCreateInfo", "cannot be nil")
+	}
+
+	cIndices, cIndexCount, err := queueFamilyIndicesToC(imageCreateInfo.SharingMode, imageCreateInfo.QueueFamilyIndices)
+	if err != nil {
+		return MemoryRequirements{}, err
+	}
+	if cIndices != nil {
+		defer C.free(unsafe.Pointer(cIndices))
+	}
+
 	cImageCreateInfo := C.VkImageCreateInfo{
 		sType:                 C.VK_STRUCTURE_TYPE_IMAGE_CREATE_INFO,
 		pNext:                 nil,
@@ -583,8 +1671,8 @@ func GetDeviceImageMemoryRequirements(device Device, imageCreateInfo *ImageCreat
 		tiling:                C.VkImageTiling(imageCreateInfo.Tiling),
 		usage:                 C.VkImageUsageFlags(imageCreateInfo.Usage),
 		sharingMode:           C.VkSharingMode(imageCreateInfo.SharingMode),
-		queueFamilyIndexCount: 0,
-		pQueueFamilyIndices:   nil,
+		queueFamilyIndexCount: cIndexCount,
+		pQueueFamilyIndices:   cIndices,
 		initialLayout:         C.VkImageLayout(imageCreateInfo.InitialLayout),
 	}
 
@@ -614,5 +1702,5 @@ func GetDeviceImageMemoryRequirements(device Device, imageCreateInfo *ImageCreat
 		Size:           DeviceSize(cMemoryRequirements2.memoryRequirements.size),
 		Alignment:      DeviceSize(cMemoryRequirements2.memoryRequirements.alignment),
 		MemoryTypeBits: uint32(cMemoryRequirements2.memoryRequirements.memoryTypeBits),
-	}
+	}, nil
 }
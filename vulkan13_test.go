@@ -0,0 +1,522 @@
+//go:build cgo
+
+package vulkan
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestCmdBeginRenderingValidation tests RenderingInfo validation for CmdBeginRendering
+func TestCmdBeginRenderingValidation(t *testing.T) {
+	fakeCommandBuffer := CommandBuffer(uintptr(0x1234))
+	fakeImageView := ImageView(uintptr(0x5678))
+	fakeResolveView := ImageView(uintptr(0x9abc))
+
+	tests := []struct {
+		name          string
+		renderingInfo *RenderingInfo
+		errorParam    string
+	}{
+		{
+			name:          "nil rendering info",
+			renderingInfo: nil,
+			errorParam:    "renderingInfo",
+		},
+		{
+			name:          "zero layer count without view mask",
+			renderingInfo: &RenderingInfo{LayerCount: 0},
+			errorParam:    "renderingInfo.LayerCount",
+		},
+		{
+			name: "resolve view without resolve mode",
+			renderingInfo: &RenderingInfo{
+				LayerCount: 1,
+				ColorAttachments: []RenderingAttachmentInfo{
+					{ImageView: fakeImageView},
+					{ImageView: fakeImageView, ResolveImageView: fakeResolveView, ResolveMode: ResolveModeNone},
+				},
+			},
+			errorParam: "renderingInfo.ColorAttachments[1]",
+		},
+		{
+			name: "depth resolve view without resolve mode",
+			renderingInfo: &RenderingInfo{
+				LayerCount:      1,
+				DepthAttachment: &RenderingAttachmentInfo{ImageView: fakeImageView, ResolveImageView: fakeResolveView},
+			},
+			errorParam: "renderingInfo.DepthAttachment",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CmdBeginRendering(fakeCommandBuffer, tt.renderingInfo)
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+}
+
+// TestValidateRenderingInfoAcceptsLegalInfo tests configurations that must pass validation
+func TestValidateRenderingInfoAcceptsLegalInfo(t *testing.T) {
+	tests := []struct {
+		name          string
+		renderingInfo *RenderingInfo
+	}{
+		{
+			name:          "multiview with zero layer count",
+			renderingInfo: &RenderingInfo{LayerCount: 0, ViewMask: 0x3},
+		},
+		{
+			name: "unused color attachment slot",
+			renderingInfo: &RenderingInfo{
+				LayerCount: 1,
+				ColorAttachments: []RenderingAttachmentInfo{
+					{ImageView: ImageView(NullHandle), ResolveImageView: ImageView(uintptr(0x9abc))},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateRenderingInfo(tt.renderingInfo); err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		})
+	}
+}
+
+// TestDynamicRenderingPerDevice tests that dynamic rendering entry points follow the device a
+// command buffer was allocated from and are dropped with the device
+func TestDynamicRenderingPerDevice(t *testing.T) {
+	fakeDevice := Device(uintptr(0x4321))
+	fakePool := CommandPool(uintptr(0x8765))
+	tracked := CommandBuffer(uintptr(0x5000))
+	untracked := CommandBuffer(uintptr(0x6000))
+
+	if path := LoadDynamicRenderingFunctions(nil); path != DynamicRenderingPathNone {
+		t.Errorf("Expected DynamicRenderingPathNone for nil device, got %v", path)
+	}
+	if entry := dynamicRenderingFor(untracked); entry != unresolvedDynamicRendering {
+		t.Fatalf("Expected untracked command buffer to have no entry points, got path %v", entry.path)
+	}
+	var vkErr *VulkanError
+	if err := CmdEndRendering(untracked); !errors.As(err, &vkErr) || vkErr.Result != ErrorExtensionNotPresent {
+		t.Errorf("Expected ErrorExtensionNotPresent for an untracked command buffer, got %v", err)
+	}
+
+	// Seed the cache so no entry points are queried from the fake device
+	unsupported := &dynamicRenderingEntry{device: fakeDevice, path: DynamicRenderingPathNone}
+	dynamicRenderingDevices.Store(fakeDevice, unsupported)
+	allocatedCommandBuffers.add(fakeDevice, fakePool, []CommandBuffer{tracked}, false)
+	defer allocatedCommandBuffers.removeDevice(fakeDevice)
+	defer forgetDynamicRendering(fakeDevice)
+
+	if entry := dynamicRenderingFor(tracked); entry != unsupported {
+		t.Fatalf("Expected tracked command buffer to use its device's entry points, got path %v", entry.path)
+	}
+	if err := CmdEndRendering(tracked); !errors.As(err, &vkErr) || vkErr.Result != ErrorExtensionNotPresent {
+		t.Errorf("Expected ErrorExtensionNotPresent, got %v", err)
+	}
+
+	if path := LoadDynamicRenderingFunctions(fakeDevice); path != DynamicRenderingPathNone {
+		t.Errorf("Expected cached DynamicRenderingPathNone, got %v", path)
+	}
+	if entry := dynamicRenderingFor(untracked); entry != unsupported {
+		t.Errorf("Expected untracked command buffer to use the loaded device, got path %v", entry.path)
+	}
+
+	forgetDynamicRendering(fakeDevice)
+	if _, ok := dynamicRenderingDevices.Load(fakeDevice); ok {
+		t.Error("Expected entry points to be dropped with the device")
+	}
+	if entry := dynamicRenderingFor(untracked); entry != unresolvedDynamicRendering {
+		t.Errorf("Expected loaded device to be cleared with the device, got path %v", entry.path)
+	}
+}
+
+// TestClearValueCheck tests that clearing attachments to an all-zero ClearValue fails when the
+// check is enabled
+func TestClearValueCheck(t *testing.T) {
+	fakeImageView := ImageView(uintptr(0x5678))
+	newInfo := func(color, depth RenderingAttachmentInfo) *RenderingInfo {
+		color.ImageView = fakeImageView
+		depth.ImageView = fakeImageView
+		return &RenderingInfo{
+			LayerCount:       1,
+			ColorAttachments: []RenderingAttachmentInfo{color},
+			DepthAttachment:  &depth,
+		}
+	}
+
+	// The check is off by default, so a zero value is accepted
+	if err := validateRenderingInfo(newInfo(RenderingAttachmentInfo{LoadOp: AttachmentLoadOpClear}, RenderingAttachmentInfo{})); err != nil {
+		t.Errorf("Expected no error with the default check, got %v", err)
+	}
+
+	SetClearValueCheck(ClearValueCheckError)
+	defer SetClearValueCheck(ClearValueCheckOff)
+
+	tests := []struct {
+		name       string
+		info       *RenderingInfo
+		errorParam string
+	}{
+		{
+			name: "constructed clear values",
+			info: newInfo(
+				RenderingAttachmentInfo{LoadOp: AttachmentLoadOpClear, ClearValue: ClearColorValueFloat(0, 0, 0, 1)},
+				RenderingAttachmentInfo{LoadOp: AttachmentLoadOpClear, ClearValue: ClearDepthStencil(1, 0)},
+			),
+		},
+		{
+			name: "literal float clear value",
+			info: newInfo(
+				RenderingAttachmentInfo{LoadOp: AttachmentLoadOpClear, ClearValue: ClearValue{Color: ClearColorValue{Float32: [4]float32{0, 0, 0, 1}}}},
+				RenderingAttachmentInfo{LoadOp: AttachmentLoadOpClear, ClearValue: ClearDepthStencil(1, 0)},
+			),
+		},
+		{
+			name: "zero clear value with load",
+			info: newInfo(
+				RenderingAttachmentInfo{LoadOp: AttachmentLoadOpLoad},
+				RenderingAttachmentInfo{LoadOp: AttachmentLoadOpDontCare},
+			),
+		},
+		{
+			name: "zero color clear value",
+			info: newInfo(
+				RenderingAttachmentInfo{LoadOp: AttachmentLoadOpClear},
+				RenderingAttachmentInfo{LoadOp: AttachmentLoadOpClear, ClearValue: ClearDepthStencil(1, 0)},
+			),
+			errorParam: "renderingInfo.ColorAttachments[0].ClearValue",
+		},
+		{
+			name: "integer literal clear value",
+			info: newInfo(
+				RenderingAttachmentInfo{LoadOp: AttachmentLoadOpClear, ClearValue: ClearValue{Color: ClearColorValue{Int32: [4]int32{1, 2, 3, 4}}}},
+				RenderingAttachmentInfo{LoadOp: AttachmentLoadOpClear, ClearValue: ClearDepthStencil(1, 0)},
+			),
+		},
+		{
+			name: "literal depth clear value",
+			info: newInfo(
+				RenderingAttachmentInfo{LoadOp: AttachmentLoadOpClear, ClearValue: ClearColorValueFloat(0, 0, 0, 1)},
+				RenderingAttachmentInfo{LoadOp: AttachmentLoadOpClear, ClearValue: ClearValue{DepthStencil: ClearDepthStencilValue{Depth: 1}}},
+			),
+		},
+		{
+			name: "zero integer clear value",
+			info: newInfo(
+				RenderingAttachmentInfo{LoadOp: AttachmentLoadOpClear, ClearValue: ClearColorValueInt(0, 0, 0, 0)},
+				RenderingAttachmentInfo{LoadOp: AttachmentLoadOpClear, ClearValue: ClearDepthStencil(1, 0)},
+			),
+			errorParam: "renderingInfo.ColorAttachments[0].ClearValue",
+		},
+		{
+			name: "zero depth clear value",
+			info: newInfo(
+				RenderingAttachmentInfo{LoadOp: AttachmentLoadOpClear, ClearValue: ClearColorValueFloat(0, 0, 0, 1)},
+				RenderingAttachmentInfo{LoadOp: AttachmentLoadOpClear, ClearValue: ClearDepthStencil(0, 0)},
+			),
+			errorParam: "renderingInfo.DepthAttachment.ClearValue",
+		},
+		{
+			name: "color and depth members both set",
+			info: newInfo(
+				RenderingAttachmentInfo{LoadOp: AttachmentLoadOpClear, ClearValue: ClearValue{Color: ClearColorValue{Float32: [4]float32{0, 0, 0, 1}}, DepthStencil: ClearDepthStencilValue{Depth: 1}}},
+				RenderingAttachmentInfo{LoadOp: AttachmentLoadOpClear, ClearValue: ClearDepthStencil(1, 0)},
+			),
+			errorParam: "renderingInfo.ColorAttachments[0].ClearValue",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateRenderingInfo(tt.info)
+			if tt.errorParam == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+
+	// Disabling the check accepts the zero value
+	SetClearValueCheck(ClearValueCheckOff)
+	if err := validateRenderingInfo(newInfo(RenderingAttachmentInfo{LoadOp: AttachmentLoadOpClear}, RenderingAttachmentInfo{})); err != nil {
+		t.Errorf("Expected no error with the check disabled, got %v", err)
+	}
+}
+
+// TestCommandBufferNilHandle tests that recording with a nil command buffer returns an error instead of crashing
+func TestCommandBufferNilHandle(t *testing.T) {
+	calls := map[string]func() error{
+		"BeginCommandBuffer":            func() error { return BeginCommandBuffer(nil, &CommandBufferBeginInfo{}) },
+		"EndCommandBuffer":              func() error { return EndCommandBuffer(nil) },
+		"CmdEndRendering":               func() error { return CmdEndRendering(nil) },
+		"CmdSetCullMode":                func() error { return CmdSetCullMode(nil, CullModeBack) },
+		"CmdSetFrontFace":               func() error { return CmdSetFrontFace(nil, FrontFaceCounterClockwise) },
+		"CmdSetPrimitiveTopology":       func() error { return CmdSetPrimitiveTopology(nil, PrimitiveTopologyTriangleList) },
+		"CmdSetViewportWithCount":       func() error { return CmdSetViewportWithCount(nil, []Viewport{{Width: 1, Height: 1}}) },
+		"CmdSetScissorWithCount":        func() error { return CmdSetScissorWithCount(nil, []Rect2D{{}}) },
+		"CmdSetDepthTestEnable":         func() error { return CmdSetDepthTestEnable(nil, true) },
+		"CmdSetDepthWriteEnable":        func() error { return CmdSetDepthWriteEnable(nil, true) },
+		"CmdSetDepthCompareOp":          func() error { return CmdSetDepthCompareOp(nil, CompareOpLess) },
+		"CmdSetDepthBoundsTestEnable":   func() error { return CmdSetDepthBoundsTestEnable(nil, true) },
+		"CmdSetStencilTestEnable":       func() error { return CmdSetStencilTestEnable(nil, true) },
+		"CmdSetPrimitiveRestartEnable":  func() error { return CmdSetPrimitiveRestartEnable(nil, true) },
+		"CmdSetRasterizerDiscardEnable": func() error { return CmdSetRasterizerDiscardEnable(nil, true) },
+		"CmdSetDepthBiasEnable":         func() error { return CmdSetDepthBiasEnable(nil, true) },
+		"CmdSetStencilOp": func() error {
+			return CmdSetStencilOp(nil, StencilFaceFrontAndBack, StencilOpKeep, StencilOpKeep, StencilOpKeep, CompareOpAlways)
+		},
+		"CmdBindVertexBuffers2": func() error {
+			return CmdBindVertexBuffers2(nil, 0, []Buffer{Buffer(uintptr(0x5678))}, []DeviceSize{0}, nil, nil)
+		},
+	}
+
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			err := call()
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != "commandBuffer" {
+				t.Errorf("Expected error for parameter 'commandBuffer', got '%s'", validationErr.Parameter)
+			}
+		})
+	}
+}
+
+// TestCmdBindVertexBuffers2Validation tests array length validation of CmdBindVertexBuffers2
+func TestCmdBindVertexBuffers2Validation(t *testing.T) {
+	fakeCommandBuffer := CommandBuffer(uintptr(0x1234))
+	buffers := []Buffer{Buffer(uintptr(0x5678)), Buffer(uintptr(0x9abc))}
+
+	tests := []struct {
+		name       string
+		buffers    []Buffer
+		offsets    []DeviceSize
+		sizes      []DeviceSize
+		strides    []DeviceSize
+		errorParam string
+	}{
+		{name: "no buffers", errorParam: "buffers"},
+		{name: "missing offsets", buffers: buffers, errorParam: "offsets"},
+		{name: "short sizes", buffers: buffers, offsets: []DeviceSize{0, 0}, sizes: []DeviceSize{16}, errorParam: "sizes"},
+		{name: "long strides", buffers: buffers, offsets: []DeviceSize{0, 0}, strides: []DeviceSize{4, 4, 4}, errorParam: "strides"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CmdBindVertexBuffers2(fakeCommandBuffer, 0, tt.buffers, tt.offsets, tt.sizes, tt.strides)
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+}
+
+// TestValidateRenderingResume tests the consistency checks between suspended and resumed render pass instances
+func TestValidateRenderingResume(t *testing.T) {
+	fakeImageView := ImageView(uintptr(0x5678))
+	newInfo := func(flags RenderingFlags) *RenderingInfo {
+		return &RenderingInfo{
+			Flags:            flags,
+			RenderArea:       Rect2D{Extent: Extent2D{Width: 640, Height: 480}},
+			LayerCount:       1,
+			ColorAttachments: []RenderingAttachmentInfo{{ImageView: fakeImageView, LoadOp: AttachmentLoadOpClear}},
+		}
+	}
+
+	if err := ValidateRenderingResume(newInfo(RenderingSuspending), newInfo(RenderingResuming|RenderingSuspending)); err != nil {
+		t.Errorf("Expected matching render pass instances to validate, got %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		modify     func(resumed *RenderingInfo)
+		suspended  RenderingFlags
+		errorParam string
+	}{
+		{
+			name:       "suspended without suspending flag",
+			modify:     func(resumed *RenderingInfo) {},
+			suspended:  0,
+			errorParam: "suspended.Flags",
+		},
+		{
+			name:       "resumed without resuming flag",
+			modify:     func(resumed *RenderingInfo) { resumed.Flags = 0 },
+			suspended:  RenderingSuspending,
+			errorParam: "resumed.Flags",
+		},
+		{
+			name:       "different render area",
+			modify:     func(resumed *RenderingInfo) { resumed.RenderArea.Extent.Width = 320 },
+			suspended:  RenderingSuspending,
+			errorParam: "resumed.RenderArea",
+		},
+		{
+			name:       "different color attachment",
+			modify:     func(resumed *RenderingInfo) { resumed.ColorAttachments[0].LoadOp = AttachmentLoadOpLoad },
+			suspended:  RenderingSuspending,
+			errorParam: "resumed.ColorAttachments",
+		},
+		{
+			name: "added depth attachment",
+			modify: func(resumed *RenderingInfo) {
+				resumed.DepthAttachment = &RenderingAttachmentInfo{ImageView: fakeImageView}
+			},
+			suspended:  RenderingSuspending,
+			errorParam: "resumed.DepthAttachment",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resumed := newInfo(RenderingResuming)
+			tt.modify(resumed)
+			err := ValidateRenderingResume(newInfo(tt.suspended), resumed)
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+}
+
+// TestSuspendableRenderingSegments tests the per-segment flags of a split render pass instance
+func TestSuspendableRenderingSegments(t *testing.T) {
+	_, err := NewSuspendableRendering(&RenderingInfo{Flags: RenderingSuspending, LayerCount: 1})
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Parameter != "renderingInfo.Flags" {
+		t.Errorf("Expected ValidationError for renderingInfo.Flags, got %v", err)
+	}
+
+	info := &RenderingInfo{
+		LayerCount:       1,
+		ColorAttachments: []RenderingAttachmentInfo{{ImageView: ImageView(uintptr(0x5678))}},
+	}
+	rendering, err := NewSuspendableRendering(info)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	// Later changes to the caller's info must not leak into the segments
+	info.ColorAttachments[0].LoadOp = AttachmentLoadOpClear
+
+	first := rendering.segmentInfo(false)
+	if first.ColorAttachments[0].LoadOp != AttachmentLoadOpLoad {
+		t.Errorf("Expected segments to keep the original load op, got %v", first.ColorAttachments[0].LoadOp)
+	}
+	if first.Flags != RenderingSuspending {
+		t.Errorf("Expected first segment flags %v, got %v", RenderingSuspending, first.Flags)
+	}
+	rendering.segments = 1
+	middle := rendering.segmentInfo(false)
+	if middle.Flags != RenderingResuming|RenderingSuspending {
+		t.Errorf("Expected middle segment flags %v, got %v", RenderingResuming|RenderingSuspending, middle.Flags)
+	}
+	last := rendering.segmentInfo(true)
+	if last.Flags != RenderingResuming {
+		t.Errorf("Expected last segment flags %v, got %v", RenderingResuming, last.Flags)
+	}
+	if err := ValidateRenderingResume(&first, &middle); err != nil {
+		t.Errorf("Expected consecutive segments to be consistent, got %v", err)
+	}
+	if err := ValidateRenderingResume(&middle, &last); err != nil {
+		t.Errorf("Expected consecutive segments to be consistent, got %v", err)
+	}
+
+	if err := rendering.End(CommandBuffer(uintptr(0x1234))); !errors.As(err, &validationErr) {
+		t.Errorf("Expected ValidationError when ending without a segment, got %v", err)
+	}
+}
+
+// TestGetDeviceMemoryRequirementsValidation tests input validation of the Maintenance4 memory
+// requirement queries
+func TestGetDeviceMemoryRequirementsValidation(t *testing.T) {
+	fakeDevice := Device(uintptr(0x1234))
+
+	tests := []struct {
+		name       string
+		call       func() error
+		errorParam string
+	}{
+		{
+			name: "buffer with nil device",
+			call: func() error {
+				_, err := GetDeviceBufferMemoryRequirements(nil, &BufferCreateInfo{Size: 16, Usage: BufferUsageStorageBufferBit})
+				return err
+			},
+			errorParam: "device",
+		},
+		{
+			name: "buffer with nil create info",
+			call: func() error {
+				_, err := GetDeviceBufferMemoryRequirements(fakeDevice, nil)
+				return err
+			},
+			errorParam: "bufferCreateInfo",
+		},
+		{
+			name: "image with nil device",
+			call: func() error {
+				_, err := GetDeviceImageMemoryRequirements(nil, &ImageCreateInfo{ImageType: ImageType2D})
+				return err
+			},
+			errorParam: "device",
+		},
+		{
+			name: "image with nil create info",
+			call: func() error {
+				_, err := GetDeviceImageMemoryRequirements(fakeDevice, nil)
+				return err
+			},
+			errorParam: "imageCreateInfo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Parameter != tt.errorParam {
+				t.Errorf("Expected error for parameter '%s', got '%s'", tt.errorParam, validationErr.Parameter)
+			}
+		})
+	}
+}
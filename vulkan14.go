@@ -0,0 +1,152 @@
+package vulkan
+
+/*
+#include <vulkan/vulkan.h>
+#include <string.h>
+
+// Mirror of VkPhysicalDeviceVulkan14Features so this file also builds against
+// Vulkan headers that predate 1.4.
+typedef struct {
+    VkBool32 globalPriorityQuery;
+    VkBool32 shaderSubgroupRotate;
+    VkBool32 shaderSubgroupRotateClustered;
+    VkBool32 shaderFloatControls2;
+    VkBool32 shaderExpectAssume;
+    VkBool32 rectangularLines;
+    VkBool32 bresenhamLines;
+    VkBool32 smoothLines;
+    VkBool32 stippledRectangularLines;
+    VkBool32 stippledBresenhamLines;
+    VkBool32 stippledSmoothLines;
+    VkBool32 vertexAttributeInstanceRateDivisor;
+    VkBool32 vertexAttributeInstanceRateZeroDivisor;
+    VkBool32 indexTypeUint8;
+    VkBool32 dynamicRenderingLocalRead;
+    VkBool32 maintenance5;
+    VkBool32 maintenance6;
+    VkBool32 pipelineProtectedAccess;
+    VkBool32 pipelineRobustness;
+    VkBool32 hostImageCopy;
+    VkBool32 pushDescriptor;
+} GoVulkan14Features;
+
+// Returns 1 on success, 0 if the headers this was built against lack Vulkan 1.4.
+static int queryVulkan14Features(VkPhysicalDevice physicalDevice, GoVulkan14Features* out) {
+#ifdef VK_API_VERSION_1_4
+    VkPhysicalDeviceVulkan14Features features14;
+    memset(&features14, 0, sizeof(features14));
+    features14.sType = VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_VULKAN_1_4_FEATURES;
+
+    VkPhysicalDeviceFeatures2 features2;
+    memset(&features2, 0, sizeof(features2));
+    features2.sType = VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_FEATURES_2;
+    features2.pNext = &features14;
+
+    vkGetPhysicalDeviceFeatures2(physicalDevice, &features2);
+
+    out->globalPriorityQuery = features14.globalPriorityQuery;
+    out->shaderSubgroupRotate = features14.shaderSubgroupRotate;
+    out->shaderSubgroupRotateClustered = features14.shaderSubgroupRotateClustered;
+    out->shaderFloatControls2 = features14.shaderFloatControls2;
+    out->shaderExpectAssume = features14.shaderExpectAssume;
+    out->rectangularLines = features14.rectangularLines;
+    out->bresenhamLines = features14.bresenhamLines;
+    out->smoothLines = features14.smoothLines;
+    out->stippledRectangularLines = features14.stippledRectangularLines;
+    out->stippledBresenhamLines = features14.stippledBresenhamLines;
+    out->stippledSmoothLines = features14.stippledSmoothLines;
+    out->vertexAttributeInstanceRateDivisor = features14.vertexAttributeInstanceRateDivisor;
+    out->vertexAttributeInstanceRateZeroDivisor = features14.vertexAttributeInstanceRateZeroDivisor;
+    out->indexTypeUint8 = features14.indexTypeUint8;
+    out->dynamicRenderingLocalRead = features14.dynamicRenderingLocalRead;
+    out->maintenance5 = features14.maintenance5;
+    out->maintenance6 = features14.maintenance6;
+    out->pipelineProtectedAccess = features14.pipelineProtectedAccess;
+    out->pipelineRobustness = features14.pipelineRobustness;
+    out->hostImageCopy = features14.hostImageCopy;
+    out->pushDescriptor = features14.pushDescriptor;
+    return 1;
+#else
+    (void)physicalDevice;
+    (void)out;
+    return 0;
+#endif
+}
+*/
+import "C"
+
+// Vulkan 1.4 Features Implementation
+
+// PhysicalDeviceVulkan14Features reports the features promoted to core in Vulkan 1.4
+type PhysicalDeviceVulkan14Features struct {
+	GlobalPriorityQuery                    bool
+	ShaderSubgroupRotate                   bool
+	ShaderSubgroupRotateClustered          bool
+	ShaderFloatControls2                   bool
+	ShaderExpectAssume                     bool
+	RectangularLines                       bool
+	BresenhamLines                         bool
+	SmoothLines                            bool
+	StippledRectangularLines               bool
+	StippledBresenhamLines                 bool
+	StippledSmoothLines                    bool
+	VertexAttributeInstanceRateDivisor     bool
+	VertexAttributeInstanceRateZeroDivisor bool
+	IndexTypeUint8                         bool
+	DynamicRenderingLocalRead              bool
+	Maintenance5                           bool
+	Maintenance6                           bool
+	PipelineProtectedAccess                bool
+	PipelineRobustness                     bool
+	HostImageCopy                          bool
+	PushDescriptor                         bool
+}
+
+// SupportsVersion reports whether a physical device supports at least the given API version
+func SupportsVersion(physicalDevice PhysicalDevice, version Version) bool {
+	if physicalDevice == nil {
+		return false
+	}
+	return GetPhysicalDeviceProperties(physicalDevice).APIVersion >= version
+}
+
+// GetPhysicalDeviceVulkan14Features queries the Vulkan 1.4 core features of a physical device.
+// It returns ErrorFeatureNotPresent if the device is older than 1.4 or the library was built
+// against Vulkan headers without 1.4 support.
+func GetPhysicalDeviceVulkan14Features(physicalDevice PhysicalDevice) (*PhysicalDeviceVulkan14Features, error) {
+	if physicalDevice == nil {
+		return nil, NewValidationError("physicalDevice", "cannot be nil")
+	}
+	if !SupportsVersion(physicalDevice, Version14) {
+		return nil, NewVulkanError(ErrorFeatureNotPresent, "GetPhysicalDeviceVulkan14Features", "requires a Vulkan 1.4 device")
+	}
+
+	var cFeatures C.GoVulkan14Features
+	if C.queryVulkan14Features(C.VkPhysicalDevice(physicalDevice), &cFeatures) == 0 {
+		return nil, NewVulkanError(ErrorFeatureNotPresent, "GetPhysicalDeviceVulkan14Features", "built against Vulkan headers without 1.4 support")
+	}
+
+	return &PhysicalDeviceVulkan14Features{
+		GlobalPriorityQuery:                    vkBool32ToBool(cFeatures.globalPriorityQuery),
+		ShaderSubgroupRotate:                   vkBool32ToBool(cFeatures.shaderSubgroupRotate),
+		ShaderSubgroupRotateClustered:          vkBool32ToBool(cFeatures.shaderSubgroupRotateClustered),
+		ShaderFloatControls2:                   vkBool32ToBool(cFeatures.shaderFloatControls2),
+		ShaderExpectAssume:                     vkBool32ToBool(cFeatures.shaderExpectAssume),
+		RectangularLines:                       vkBool32ToBool(cFeatures.rectangularLines),
+		BresenhamLines:                         vkBool32ToBool(cFeatures.bresenhamLines),
+		SmoothLines:                            vkBool32ToBool(cFeatures.smoothLines),
+		StippledRectangularLines:               vkBool32ToBool(cFeatures.stippledRectangularLines),
+		StippledBresenhamLines:                 vkBool32ToBool(cFeatures.stippledBresenhamLines),
+		StippledSmoothLines:                    vkBool32ToBool(cFeatures.stippledSmoothLines),
+		VertexAttributeInstanceRateDivisor:     vkBool32ToBool(cFeatures.vertexAttributeInstanceRateDivisor),
+		VertexAttributeInstanceRateZeroDivisor: vkBool32ToBool(cFeatures.vertexAttributeInstanceRateZeroDivisor),
+		IndexTypeUint8:                         vkBool32ToBool(cFeatures.indexTypeUint8),
+		DynamicRenderingLocalRead:              vkBool32ToBool(cFeatures.dynamicRenderingLocalRead),
+		Maintenance5:                           vkBool32ToBool(cFeatures.maintenance5),
+		Maintenance6:                           vkBool32ToBool(cFeatures.maintenance6),
+		PipelineProtectedAccess:                vkBool32ToBool(cFeatures.pipelineProtectedAccess),
+		PipelineRobustness:                     vkBool32ToBool(cFeatures.pipelineRobustness),
+		HostImageCopy:                          vkBool32ToBool(cFeatures.hostImageCopy),
+		PushDescriptor:                         vkBool32ToBool(cFeatures.pushDescriptor),
+	}, nil
+}
@@ -0,0 +1,2688 @@
+//go:build !cgo
+
+// This file lets the package compile when cgo is disabled, for example with CGO_ENABLED=0
+// or on machines without the Vulkan SDK. It is a hand-maintained core subset, not the full
+// API: it covers what the package's pure-Go helpers (Allocator, FencePool, Scope) and the
+// bundled examples use: instance and device setup, resources, command recording and
+// submission, and the Vulkan 1.3 dynamic rendering, dynamic state, synchronization2 and
+// private data commands. Functions that would call into Vulkan return
+// ErrorInitializationFailed, or a zero value when they have no error result, so applications
+// can detect the missing driver and fall back.
+//
+// Helpers that only compute on Go values, such as FilterSupportedExtensions,
+// FindMemoryTypeWithFallback and AspectMaskForFormat, are mirrored with their real
+// implementations so they give the same results as in cgo builds. Larger pure-Go code, such
+// as the format tables in formatinfo.go, lives in untagged files shared by both builds.
+//
+// Extension APIs, and core functions outside that subset, are only available in cgo builds.
+// Tests and examples that use them carry a cgo build constraint. New core API that the
+// helpers or examples depend on must be mirrored here.
+//
+// Constant values mirror vulkan_core.h; keep them in sync with the cgo definitions.
+
+package vulkan
+
+import (
+	"fmt"
+	"math/bits"
+	"unsafe"
+)
+
+// Version represents Vulkan API version
+type Version uint32
+
+// Vulkan API versions
+const (
+	Version10 Version = 1 << 22
+	Version11 Version = (1 << 22) | (1 << 12)
+	Version12 Version = (1 << 22) | (2 << 12)
+	Version13 Version = (1 << 22) | (3 << 12)
+	Version14 Version = (1 << 22) | (4 << 12)
+)
+
+// Result represents Vulkan result codes
+type Result int32
+
+// Vulkan result codes
+const (
+	Success                                     Result = 0
+	NotReady                                    Result = 1
+	Timeout                                     Result = 2
+	EventSet                                    Result = 3
+	EventReset                                  Result = 4
+	Incomplete                                  Result = 5
+	ErrorOutOfHostMemory                        Result = -1
+	ErrorOutOfDeviceMemory                      Result = -2
+	ErrorInitializationFailed                   Result = -3
+	ErrorDeviceLost                             Result = -4
+	ErrorMemoryMapFailed                        Result = -5
+	ErrorLayerNotPresent                        Result = -6
+	ErrorExtensionNotPresent                    Result = -7
+	ErrorFeatureNotPresent                      Result = -8
+	ErrorIncompatibleDriver                     Result = -9
+	ErrorTooManyObjects                         Result = -10
+	ErrorFormatNotSupported                     Result = -11
+	ErrorFragmentedPool                         Result = -12
+	ErrorUnknown                                Result = -13
+	ErrorOutOfPoolMemory                        Result = -1000069000
+	ErrorInvalidExternalHandle                  Result = -1000072003
+	ErrorFragmentation                          Result = -1000161000
+	ErrorInvalidOpaqueCaptureAddress            Result = -1000257000
+	ErrorSurfaceLostKHR                         Result = -1000000000
+	ErrorNativeWindowInUseKHR                   Result = -1000000001
+	SuboptimalKHR                               Result = 1000001003
+	ErrorOutOfDateKHR                           Result = -1000001004
+	ErrorIncompatibleDisplayKHR                 Result = -1000003001
+	ErrorValidationFailedEXT                    Result = -1000011001
+	ErrorInvalidShaderNV                        Result = -1000012000
+	ErrorInvalidDrmFormatModifierPlaneLayoutEXT Result = -1000158000
+	ErrorNotPermittedEXT                        Result = -1000174001
+	ErrorFullScreenExclusiveModeLostEXT         Result = -1000255000
+	ThreadIdleKHR                               Result = 1000268000
+	ThreadDoneKHR                               Result = 1000268001
+	OperationDeferredKHR                        Result = 1000268002
+	OperationNotDeferredKHR                     Result = 1000268003
+	PipelineCompileRequiredEXT                  Result = 1000297000
+	ErrorImageUsageNotSupportedKHR              Result = -1000023000
+	ErrorVideoPictureLayoutNotSupportedKHR      Result = -1000023001
+	ErrorVideoProfileOperationNotSupportedKHR   Result = -1000023002
+	ErrorVideoProfileFormatNotSupportedKHR      Result = -1000023003
+	ErrorVideoProfileCodecNotSupportedKHR       Result = -1000023004
+	ErrorVideoStdVersionNotSupportedKHR         Result = -1000023005
+	ErrorInvalidVideoStdParametersKHR           Result = -1000299000
+	ErrorCompressionExhaustedEXT                Result = -1000338000
+	IncompatibleShaderBinaryEXT                 Result = 1000482000
+	PipelineCompileRequired                            = PipelineCompileRequiredEXT
+)
+
+// Bool type for Vulkan boolean values
+type Bool32 uint32
+
+const (
+	False Bool32 = 0
+	True  Bool32 = 1
+)
+
+// DeviceSize represents device memory size
+type DeviceSize uint64
+
+// SampleCount represents sample count flags
+type SampleCountFlags uint32
+
+const (
+	SampleCount1Bit  SampleCountFlags = 1
+	SampleCount2Bit  SampleCountFlags = 2
+	SampleCount4Bit  SampleCountFlags = 4
+	SampleCount8Bit  SampleCountFlags = 8
+	SampleCount16Bit SampleCountFlags = 16
+	SampleCount32Bit SampleCountFlags = 32
+	SampleCount64Bit SampleCountFlags = 64
+)
+
+// Handle types
+type (
+	Instance            unsafe.Pointer
+	PhysicalDevice      unsafe.Pointer
+	Device              unsafe.Pointer
+	Queue               unsafe.Pointer
+	Semaphore           unsafe.Pointer
+	CommandBuffer       unsafe.Pointer
+	Fence               unsafe.Pointer
+	DeviceMemory        unsafe.Pointer
+	Buffer              unsafe.Pointer
+	Image               unsafe.Pointer
+	QueryPool           unsafe.Pointer
+	BufferView          unsafe.Pointer
+	ImageView           unsafe.Pointer
+	ShaderModule        unsafe.Pointer
+	PipelineCache       unsafe.Pointer
+	PipelineLayout      unsafe.Pointer
+	RenderPass          unsafe.Pointer
+	Pipeline            unsafe.Pointer
+	DescriptorSetLayout unsafe.Pointer
+	Sampler             unsafe.Pointer
+	DescriptorPool      unsafe.Pointer
+	CommandPool         unsafe.Pointer
+	Surface             unsafe.Pointer
+	Framebuffer         unsafe.Pointer
+	PrivateDataSlot     unsafe.Pointer
+)
+
+// Constants
+const (
+	MaxMemoryTypes            = 32
+	MaxMemoryHeaps            = 16
+	MaxPhysicalDeviceNameSize = 256
+	UuidSize                  = 16
+	WholeSize                 = ^uint64(0)
+)
+
+// MakeVersion creates a version number from major, minor, and patch components
+func MakeVersion(major, minor, patch uint32) Version {
+	return Version((major << 22) | (minor << 12) | patch)
+}
+
+// VersionMajor extracts the major version number
+func (v Version) Major() uint32 {
+	return uint32((v >> 22) & 0x7F)
+}
+
+// VersionMinor extracts the minor version number
+func (v Version) Minor() uint32 {
+	return uint32((v >> 12) & 0x3FF)
+}
+
+// VersionPatch extracts the patch version number
+func (v Version) Patch() uint32 {
+	return uint32(v & 0xFFF)
+}
+
+// Variant extracts the variant number stored in the top bits by VK_MAKE_API_VERSION.
+// It is 0 for the Vulkan API and non-zero for variants such as Vulkan SC.
+func (v Version) Variant() uint32 {
+	return uint32(v >> 29)
+}
+
+// String formats the version as "major.minor.patch", e.g. "1.3.0". A non-zero variant
+// is prepended as "variant:major.minor.patch".
+func (v Version) String() string {
+	if v.Variant() != 0 {
+		return fmt.Sprintf("%d:%d.%d.%d", v.Variant(), v.Major(), v.Minor(), v.Patch())
+	}
+	return fmt.Sprintf("%d.%d.%d", v.Major(), v.Minor(), v.Patch())
+}
+
+// Error returns the error message for the result
+func (r Result) Error() string {
+	return r.String()
+}
+
+// String returns the VK_* name of the result code. Codes this package does not know
+// about are reported with their numeric value.
+func (r Result) String() string {
+	switch r {
+	case Success:
+		return "VK_SUCCESS"
+	case NotReady:
+		return "VK_NOT_READY"
+	case Timeout:
+		return "VK_TIMEOUT"
+	case EventSet:
+		return "VK_EVENT_SET"
+	case EventReset:
+		return "VK_EVENT_RESET"
+	case Incomplete:
+		return "VK_INCOMPLETE"
+	case ErrorOutOfHostMemory:
+		return "VK_ERROR_OUT_OF_HOST_MEMORY"
+	case ErrorOutOfDeviceMemory:
+		return "VK_ERROR_OUT_OF_DEVICE_MEMORY"
+	case ErrorInitializationFailed:
+		return "VK_ERROR_INITIALIZATION_FAILED"
+	case ErrorDeviceLost:
+		return "VK_ERROR_DEVICE_LOST"
+	case ErrorMemoryMapFailed:
+		return "VK_ERROR_MEMORY_MAP_FAILED"
+	case ErrorLayerNotPresent:
+		return "VK_ERROR_LAYER_NOT_PRESENT"
+	case ErrorExtensionNotPresent:
+		return "VK_ERROR_EXTENSION_NOT_PRESENT"
+	case ErrorFeatureNotPresent:
+		return "VK_ERROR_FEATURE_NOT_PRESENT"
+	case ErrorIncompatibleDriver:
+		return "VK_ERROR_INCOMPATIBLE_DRIVER"
+	case ErrorTooManyObjects:
+		return "VK_ERROR_TOO_MANY_OBJECTS"
+	case ErrorFormatNotSupported:
+		return "VK_ERROR_FORMAT_NOT_SUPPORTED"
+	case ErrorFragmentedPool:
+		return "VK_ERROR_FRAGMENTED_POOL"
+	case ErrorUnknown:
+		return "VK_ERROR_UNKNOWN"
+	case ErrorOutOfPoolMemory:
+		return "VK_ERROR_OUT_OF_POOL_MEMORY"
+	case ErrorInvalidExternalHandle:
+		return "VK_ERROR_INVALID_EXTERNAL_HANDLE"
+	case ErrorFragmentation:
+		return "VK_ERROR_FRAGMENTATION"
+	case ErrorInvalidOpaqueCaptureAddress:
+		return "VK_ERROR_INVALID_OPAQUE_CAPTURE_ADDRESS"
+	case ErrorSurfaceLostKHR:
+		return "VK_ERROR_SURFACE_LOST_KHR"
+	case ErrorNativeWindowInUseKHR:
+		return "VK_ERROR_NATIVE_WINDOW_IN_USE_KHR"
+	case SuboptimalKHR:
+		return "VK_SUBOPTIMAL_KHR"
+	case ErrorOutOfDateKHR:
+		return "VK_ERROR_OUT_OF_DATE_KHR"
+	case ErrorIncompatibleDisplayKHR:
+		return "VK_ERROR_INCOMPATIBLE_DISPLAY_KHR"
+	case ErrorValidationFailedEXT:
+		return "VK_ERROR_VALIDATION_FAILED_EXT"
+	case ErrorInvalidShaderNV:
+		return "VK_ERROR_INVALID_SHADER_NV"
+	case ErrorInvalidDrmFormatModifierPlaneLayoutEXT:
+		return "VK_ERROR_INVALID_DRM_FORMAT_MODIFIER_PLANE_LAYOUT_EXT"
+	case ErrorNotPermittedEXT:
+		return "VK_ERROR_NOT_PERMITTED_EXT"
+	case ErrorFullScreenExclusiveModeLostEXT:
+		return "VK_ERROR_FULL_SCREEN_EXCLUSIVE_MODE_LOST_EXT"
+	case ThreadIdleKHR:
+		return "VK_THREAD_IDLE_KHR"
+	case ThreadDoneKHR:
+		return "VK_THREAD_DONE_KHR"
+	case OperationDeferredKHR:
+		return "VK_OPERATION_DEFERRED_KHR"
+	case OperationNotDeferredKHR:
+		return "VK_OPERATION_NOT_DEFERRED_KHR"
+	case PipelineCompileRequiredEXT:
+		return "VK_PIPELINE_COMPILE_REQUIRED_EXT"
+	case ErrorImageUsageNotSupportedKHR:
+		return "VK_ERROR_IMAGE_USAGE_NOT_SUPPORTED_KHR"
+	case ErrorVideoPictureLayoutNotSupportedKHR:
+		return "VK_ERROR_VIDEO_PICTURE_LAYOUT_NOT_SUPPORTED_KHR"
+	case ErrorVideoProfileOperationNotSupportedKHR:
+		return "VK_ERROR_VIDEO_PROFILE_OPERATION_NOT_SUPPORTED_KHR"
+	case ErrorVideoProfileFormatNotSupportedKHR:
+		return "VK_ERROR_VIDEO_PROFILE_FORMAT_NOT_SUPPORTED_KHR"
+	case ErrorVideoProfileCodecNotSupportedKHR:
+		return "VK_ERROR_VIDEO_PROFILE_CODEC_NOT_SUPPORTED_KHR"
+	case ErrorVideoStdVersionNotSupportedKHR:
+		return "VK_ERROR_VIDEO_STD_VERSION_NOT_SUPPORTED_KHR"
+	case ErrorInvalidVideoStdParametersKHR:
+		return "VK_ERROR_INVALID_VIDEO_STD_PARAMETERS_KHR"
+	case ErrorCompressionExhaustedEXT:
+		return "VK_ERROR_COMPRESSION_EXHAUSTED_EXT"
+	case IncompatibleShaderBinaryEXT:
+		return "VK_INCOMPATIBLE_SHADER_BINARY_EXT"
+	default:
+		return fmt.Sprintf("Unknown Vulkan error (%d)", int32(r))
+	}
+}
+
+// IsError returns true if the result represents an error condition. Error codes are
+// negative; positive codes such as NotReady, Timeout, EventSet, EventReset, Incomplete
+// and SuboptimalKHR are status codes, not errors.
+func (r Result) IsError() bool {
+	return r < 0
+}
+
+// IsSuccess returns true if the result is Success or a non-error status code
+func (r Result) IsSuccess() bool {
+	return r >= 0
+}
+
+// ToBool converts a Bool32 to a Go bool
+func (b Bool32) ToBool() bool {
+	return b == True
+}
+
+// Bool converts a Bool32 to a Go bool; it is a shorter spelling of ToBool
+func (b Bool32) Bool() bool {
+	return b.ToBool()
+}
+
+// FromBool converts a Go bool to Bool32
+func FromBool(b bool) Bool32 {
+	if b {
+		return True
+	}
+	return False
+}
+
+// ApplicationInfo contains application information
+type ApplicationInfo struct {
+	ApplicationName    string
+	ApplicationVersion Version
+	EngineName         string
+	EngineVersion      Version
+	APIVersion         Version
+}
+
+// InstanceCreateFlags represents instance creation flags
+type InstanceCreateFlags uint32
+
+// InstanceCreateInfo contains instance creation information
+type InstanceCreateInfo struct {
+	Flags                 InstanceCreateFlags
+	ApplicationInfo       *ApplicationInfo
+	EnabledLayerNames     []string
+	EnabledExtensionNames []string
+	// ValidationFeatures enables or disables validation layer checks when set
+	ValidationFeatures *ValidationFeatures
+}
+
+// PhysicalDeviceType represents the type of physical device
+type PhysicalDeviceType int32
+
+// PhysicalDeviceProperties contains physical device properties
+type PhysicalDeviceProperties struct {
+	APIVersion        Version
+	DriverVersion     Version
+	VendorID          uint32
+	DeviceID          uint32
+	DeviceType        PhysicalDeviceType
+	DeviceName        string
+	PipelineCacheUUID [UuidSize]uint8
+	Limits            PhysicalDeviceLimits
+	SparseProperties  PhysicalDeviceSparseProperties
+}
+
+// PhysicalDeviceLimits contains physical device limits
+type PhysicalDeviceLimits struct {
+	MaxImageDimension1D                             uint32
+	MaxImageDimension2D                             uint32
+	MaxImageDimension3D                             uint32
+	MaxImageDimensionCube                           uint32
+	MaxImageArrayLayers                             uint32
+	MaxTexelBufferElements                          uint32
+	MaxUniformBufferRange                           uint32
+	MaxStorageBufferRange                           uint32
+	MaxPushConstantsSize                            uint32
+	MaxMemoryAllocationCount                        uint32
+	MaxSamplerAllocationCount                       uint32
+	BufferImageGranularity                          DeviceSize
+	SparseAddressSpaceSize                          DeviceSize
+	MaxBoundDescriptorSets                          uint32
+	MaxPerStageDescriptorSamplers                   uint32
+	MaxPerStageDescriptorUniformBuffers             uint32
+	MaxPerStageDescriptorStorageBuffers             uint32
+	MaxPerStageDescriptorSampledImages              uint32
+	MaxPerStageDescriptorStorageImages              uint32
+	MaxPerStageDescriptorInputAttachments           uint32
+	MaxPerStageResources                            uint32
+	MaxDescriptorSetSamplers                        uint32
+	MaxDescriptorSetUniformBuffers                  uint32
+	MaxDescriptorSetUniformBuffersDynamic           uint32
+	MaxDescriptorSetStorageBuffers                  uint32
+	MaxDescriptorSetStorageBuffersDynamic           uint32
+	MaxDescriptorSetSampledImages                   uint32
+	MaxDescriptorSetStorageImages                   uint32
+	MaxDescriptorSetInputAttachments                uint32
+	MaxVertexInputAttributes                        uint32
+	MaxVertexInputBindings                          uint32
+	MaxVertexInputAttributeOffset                   uint32
+	MaxVertexInputBindingStride                     uint32
+	MaxVertexOutputComponents                       uint32
+	MaxTessellationGenerationLevel                  uint32
+	MaxTessellationPatchSize                        uint32
+	MaxTessellationControlPerVertexInputComponents  uint32
+	MaxTessellationControlPerVertexOutputComponents uint32
+	MaxTessellationControlPerPatchOutputComponents  uint32
+	MaxTessellationControlTotalOutputComponents     uint32
+	MaxTessellationEvaluationInputComponents        uint32
+	MaxTessellationEvaluationOutputComponents       uint32
+	MaxGeometryShaderInvocations                    uint32
+	MaxGeometryInputComponents                      uint32
+	MaxGeometryOutputComponents                     uint32
+	MaxGeometryOutputVertices                       uint32
+	MaxGeometryTotalOutputComponents                uint32
+	MaxFragmentInputComponents                      uint32
+	MaxFragmentOutputAttachments                    uint32
+	MaxFragmentDualSrcAttachments                   uint32
+	MaxFragmentCombinedOutputResources              uint32
+	MaxComputeSharedMemorySize                      uint32
+	MaxComputeWorkGroupCount                        [3]uint32
+	MaxComputeWorkGroupInvocations                  uint32
+	MaxComputeWorkGroupSize                         [3]uint32
+	SubPixelPrecisionBits                           uint32
+	SubTexelPrecisionBits                           uint32
+	MipmapPrecisionBits                             uint32
+	MaxDrawIndexedIndexValue                        uint32
+	MaxDrawIndirectCount                            uint32
+	MaxSamplerLodBias                               float32
+	MaxSamplerAnisotropy                            float32
+	MaxViewports                                    uint32
+	MaxViewportDimensions                           [2]uint32
+	ViewportBoundsRange                             [2]float32
+	ViewportSubPixelBits                            uint32
+	MinMemoryMapAlignment                           uintptr
+	MinTexelBufferOffsetAlignment                   DeviceSize
+	MinUniformBufferOffsetAlignment                 DeviceSize
+	MinStorageBufferOffsetAlignment                 DeviceSize
+	MinTexelOffset                                  int32
+	MaxTexelOffset                                  uint32
+	MinTexelGatherOffset                            int32
+	MaxTexelGatherOffset                            uint32
+	MinInterpolationOffset                          float32
+	MaxInterpolationOffset                          float32
+	SubPixelInterpolationOffsetBits                 uint32
+	MaxFramebufferWidth                             uint32
+	MaxFramebufferHeight                            uint32
+	MaxFramebufferLayers                            uint32
+	FramebufferColorSampleCounts                    SampleCountFlags
+	FramebufferDepthSampleCounts                    SampleCountFlags
+	FramebufferStencilSampleCounts                  SampleCountFlags
+	FramebufferNoAttachmentsSampleCounts            SampleCountFlags
+	MaxColorAttachments                             uint32
+	SampledImageColorSampleCounts                   SampleCountFlags
+	SampledImageIntegerSampleCounts                 SampleCountFlags
+	SampledImageDepthSampleCounts                   SampleCountFlags
+	SampledImageStencilSampleCounts                 SampleCountFlags
+	StorageImageSampleCounts                        SampleCountFlags
+	MaxSampleMaskWords                              uint32
+	TimestampComputeAndGraphics                     Bool32
+	TimestampPeriod                                 float32
+	MaxClipDistances                                uint32
+	MaxCullDistances                                uint32
+	MaxCombinedClipAndCullDistances                 uint32
+	DiscreteQueuePriorities                         uint32
+	PointSizeRange                                  [2]float32
+	LineWidthRange                                  [2]float32
+	PointSizeGranularity                            float32
+	LineWidthGranularity                            float32
+	StrictLines                                     Bool32
+	StandardSampleLocations                         Bool32
+	OptimalBufferCopyOffsetAlignment                DeviceSize
+	OptimalBufferCopyRowPitchAlignment              DeviceSize
+	NonCoherentAtomSize                             DeviceSize
+}
+
+// PhysicalDeviceSparseProperties contains sparse resource properties
+type PhysicalDeviceSparseProperties struct {
+	ResidencyStandard2DBlockShape            Bool32
+	ResidencyStandard2DMultisampleBlockShape Bool32
+	ResidencyStandard3DBlockShape            Bool32
+	ResidencyAlignedMipSize                  Bool32
+	ResidencyNonResidentStrict               Bool32
+}
+
+// Extent3D represents a 3D extent
+type Extent3D struct {
+	Width  uint32
+	Height uint32
+	Depth  uint32
+}
+
+// CreateInstance creates a Vulkan instance
+func CreateInstance(createInfo *InstanceCreateInfo) (Instance, error) {
+	return nil, ErrorInitializationFailed
+}
+
+// DestroyInstance destroys a Vulkan instance
+func DestroyInstance(instance Instance) {}
+
+// EnumeratePhysicalDevices enumerates physical devices
+func EnumeratePhysicalDevices(instance Instance) ([]PhysicalDevice, error) {
+	return nil, ErrorInitializationFailed
+}
+
+// GetPhysicalDeviceProperties gets physical device properties
+func GetPhysicalDeviceProperties(physicalDevice PhysicalDevice) PhysicalDeviceProperties {
+	return PhysicalDeviceProperties{}
+}
+
+// GetMaxUsableSampleCount returns the highest MSAA sample count usable with both color and
+// depth attachments on physicalDevice
+func GetMaxUsableSampleCount(physicalDevice PhysicalDevice) SampleCountFlags {
+	return GetPhysicalDeviceProperties(physicalDevice).Limits.MaxUsableSampleCount()
+}
+
+// SupportsVersion reports whether a physical device supports at least the given API version
+func SupportsVersion(physicalDevice PhysicalDevice, version Version) bool {
+	if physicalDevice == nil {
+		return false
+	}
+	return GetPhysicalDeviceProperties(physicalDevice).APIVersion >= version
+}
+
+// ToolPurposeFlags describes what an active tool does (Vulkan 1.3)
+type ToolPurposeFlags uint32
+
+// PhysicalDeviceToolProperties describes a tool, such as a capture tool, profiler or
+// validation layer, that is active on a physical device
+type PhysicalDeviceToolProperties struct {
+	Name        string
+	Version     string
+	Purposes    ToolPurposeFlags
+	Description string
+	// Layer is the name of the layer implementing the tool, or empty if it is not a layer
+	Layer string
+}
+
+// GetPhysicalDeviceToolProperties lists the tools active on a physical device. Use it to
+// detect when a tool such as RenderDoc or the validation layers may be affecting
+// performance. Requires a Vulkan 1.3 device.
+func GetPhysicalDeviceToolProperties(physicalDevice PhysicalDevice) ([]PhysicalDeviceToolProperties, error) {
+	return nil, ErrorInitializationFailed
+}
+
+// QueueFamilyIndices holds the queue families an application typically needs. Each index
+// is only meaningful when the matching Has flag is set.
+type QueueFamilyIndices struct {
+	Graphics    uint32
+	HasGraphics bool
+	Compute     uint32
+	HasCompute  bool
+	Transfer    uint32
+	HasTransfer bool
+	Present     uint32
+	HasPresent  bool
+
+	// presentRequired is set when the indices were found for a surface
+	presentRequired bool
+}
+
+// FindQueueFamilies picks queue families for graphics, compute, transfer and, if surface is
+// not nil, presentation. Compute and transfer prefer dedicated families so that async work
+// does not compete with the graphics queue, and present prefers the graphics family.
+func FindQueueFamilies(physicalDevice PhysicalDevice, surface Surface) QueueFamilyIndices {
+	return QueueFamilyIndices{}
+}
+
+// IsComplete reports whether graphics, compute and transfer families were found, plus a
+// present family if a surface was passed to FindQueueFamilies.
+func (q QueueFamilyIndices) IsComplete() bool {
+	return q.HasGraphics && q.HasCompute && q.HasTransfer && (q.HasPresent || !q.presentRequired)
+}
+
+// UniqueIndices returns the distinct family indices that were found, in the order graphics,
+// compute, transfer, present. Use it to build one DeviceQueueCreateInfo per family.
+func (q QueueFamilyIndices) UniqueIndices() []uint32 {
+	var indices []uint32
+	add := func(index uint32, found bool) {
+		if !found {
+			return
+		}
+		for _, existing := range indices {
+			if existing == index {
+				return
+			}
+		}
+		indices = append(indices, index)
+	}
+
+	add(q.Graphics, q.HasGraphics)
+	add(q.Compute, q.HasCompute)
+	add(q.Transfer, q.HasTransfer)
+	add(q.Present, q.HasPresent)
+	return indices
+}
+
+// QueueFlags represents queue capability flags
+type QueueFlags uint32
+
+const (
+	QueueGraphicsBit       QueueFlags = 1
+	QueueComputeBit        QueueFlags = 2
+	QueueTransferBit       QueueFlags = 4
+	QueueSparseBindingBit  QueueFlags = 8
+	QueueProtectedBit      QueueFlags = 16
+	QueueVideoDecodeBitKHR QueueFlags = 32
+	QueueVideoEncodeBitKHR QueueFlags = 64
+)
+
+// DeviceSelectionOptions lists the requirements and preferences SelectPhysicalDevice uses
+// to pick a GPU
+type DeviceSelectionOptions struct {
+	RequiredExtensions  []string
+	PreferredExtensions []string
+	RequiredQueueFlags  QueueFlags
+	Surface             Surface
+	MinAPIVersion       Version
+}
+
+// SelectPhysicalDevice returns the physical device that best matches opts
+func SelectPhysicalDevice(instance Instance, opts DeviceSelectionOptions) (PhysicalDevice, error) {
+	return nil, ErrorInitializationFailed
+}
+
+// MaxUsableSampleCount returns the highest sample count supported by both color and depth
+// framebuffer attachments, which is the MSAA level to use for a color+depth render target.
+// It returns SampleCount1Bit when no multisampled count is shared.
+func (l PhysicalDeviceLimits) MaxUsableSampleCount() SampleCountFlags {
+	counts := uint32(l.FramebufferColorSampleCounts & l.FramebufferDepthSampleCounts)
+	if counts == 0 {
+		return SampleCount1Bit
+	}
+	return SampleCountFlags(1 << (bits.Len32(counts) - 1))
+}
+
+// DeviceQueueCreateInfo contains device queue creation information
+type DeviceQueueCreateInfo struct {
+	QueueFamilyIndex uint32
+	QueuePriorities  []float32
+}
+
+// DeviceCreateInfo contains device creation information
+type DeviceCreateInfo struct {
+	QueueCreateInfos      []DeviceQueueCreateInfo
+	EnabledLayerNames     []string
+	EnabledExtensionNames []string
+	EnabledFeatures       *PhysicalDeviceFeatures
+	// RayTracingFeatures enables the buffer device address, acceleration structure
+	// and ray tracing pipeline features when set
+	RayTracingFeatures *PhysicalDeviceRayTracingFeatures
+	// MeshShaderFeatures enables the task and mesh shader features when set
+	MeshShaderFeatures *PhysicalDeviceMeshShaderFeatures
+	// HostImageCopyFeatures enables the host image copy feature when set
+	HostImageCopyFeatures *PhysicalDeviceHostImageCopyFeatures
+	// TimelineSemaphoreFeatures enables timeline semaphores when set
+	TimelineSemaphoreFeatures *PhysicalDeviceTimelineSemaphoreFeatures
+	// ConditionalRenderingFeatures enables the conditional rendering features when set
+	ConditionalRenderingFeatures *PhysicalDeviceConditionalRenderingFeatures
+	// TransformFeedbackFeatures enables the transform feedback features when set
+	TransformFeedbackFeatures *PhysicalDeviceTransformFeedbackFeatures
+	// PresentWaitFeatures enables the present ID and present wait features when set
+	PresentWaitFeatures *PhysicalDevicePresentWaitFeatures
+	// DescriptorIndexingFeatures enables the descriptor indexing features when set
+	DescriptorIndexingFeatures *PhysicalDeviceDescriptorIndexingFeatures
+	// ExtendedDynamicState3Features enables the extended dynamic state 3 features when set
+	ExtendedDynamicState3Features *PhysicalDeviceExtendedDynamicState3Features
+	// CoherentMemoryFeatures enables the AMD device coherent memory feature when set
+	CoherentMemoryFeatures *PhysicalDeviceCoherentMemoryFeatures
+	// DeviceFaultFeatures enables the device fault reporting features when set
+	DeviceFaultFeatures *PhysicalDeviceFaultFeatures
+	// GraphicsPipelineLibraryFeatures enables the graphics pipeline library feature when set
+	GraphicsPipelineLibraryFeatures *PhysicalDeviceGraphicsPipelineLibraryFeatures
+	// CooperativeMatrixFeatures enables the cooperative matrix features when set
+	CooperativeMatrixFeatures *PhysicalDeviceCooperativeMatrixFeatures
+	// VertexInputDynamicStateFeatures enables the vertex input dynamic state feature when set
+	VertexInputDynamicStateFeatures *PhysicalDeviceVertexInputDynamicStateFeatures
+	// FragmentShadingRateFeatures enables the fragment shading rate features when set
+	FragmentShadingRateFeatures *PhysicalDeviceFragmentShadingRateFeatures
+	// ImageCompressionControlFeatures enables the image compression control feature when set
+	ImageCompressionControlFeatures *PhysicalDeviceImageCompressionControlFeatures
+	// DynamicRenderingFeatures enables dynamic rendering on Vulkan 1.2 devices that enable
+	// ExtensionNameDynamicRendering. Use Vulkan13Features on Vulkan 1.3 devices.
+	DynamicRenderingFeatures *PhysicalDeviceDynamicRenderingFeatures
+	// Synchronization2Features enables synchronization2 on Vulkan 1.2 devices that enable
+	// ExtensionNameSynchronization2. Use Vulkan13Features on Vulkan 1.3 devices.
+	Synchronization2Features *PhysicalDeviceSynchronization2Features
+	// Vulkan13Features enables the features promoted to core in Vulkan 1.3 when set. It
+	// cannot be combined with DynamicRenderingFeatures or Synchronization2Features.
+	Vulkan13Features *PhysicalDeviceVulkan13Features
+	// DeviceGroup creates the device across several physical devices of one group when set
+	DeviceGroup *DeviceGroupDeviceCreateInfo
+}
+
+// PhysicalDeviceFeatures contains physical device features
+type PhysicalDeviceFeatures struct {
+	RobustBufferAccess                      bool
+	FullDrawIndexUint32                     bool
+	ImageCubeArray                          bool
+	IndependentBlend                        bool
+	GeometryShader                          bool
+	TessellationShader                      bool
+	SampleRateShading                       bool
+	DualSrcBlend                            bool
+	LogicOp                                 bool
+	MultiDrawIndirect                       bool
+	DrawIndirectFirstInstance               bool
+	DepthClamp                              bool
+	DepthBiasClamp                          bool
+	FillModeNonSolid                        bool
+	DepthBounds                             bool
+	WideLines                               bool
+	LargePoints                             bool
+	AlphaToOne                              bool
+	MultiViewport                           bool
+	SamplerAnisotropy                       bool
+	TextureCompressionETC2                  bool
+	TextureCompressionASTC_LDR              bool
+	TextureCompressionBC                    bool
+	OcclusionQueryPrecise                   bool
+	PipelineStatisticsQuery                 bool
+	VertexPipelineStoresAndAtomics          bool
+	FragmentStoresAndAtomics                bool
+	ShaderTessellationAndGeometryPointSize  bool
+	ShaderImageGatherExtended               bool
+	ShaderStorageImageExtendedFormats       bool
+	ShaderStorageImageMultisample           bool
+	ShaderStorageImageReadWithoutFormat     bool
+	ShaderStorageImageWriteWithoutFormat    bool
+	ShaderUniformBufferArrayDynamicIndexing bool
+	ShaderSampledImageArrayDynamicIndexing  bool
+	ShaderStorageBufferArrayDynamicIndexing bool
+	ShaderStorageImageArrayDynamicIndexing  bool
+	ShaderClipDistance                      bool
+	ShaderCullDistance                      bool
+	ShaderFloat64                           bool
+	ShaderInt64                             bool
+	ShaderInt16                             bool
+	ShaderResourceResidency                 bool
+	ShaderResourceMinLod                    bool
+	SparseBinding                           bool
+	SparseResidencyBuffer                   bool
+	SparseResidencyImage2D                  bool
+	SparseResidencyImage3D                  bool
+	SparseResidency2Samples                 bool
+	SparseResidency4Samples                 bool
+	SparseResidency8Samples                 bool
+	SparseResidency16Samples                bool
+	SparseResidencyAliased                  bool
+	VariableMultisampleRate                 bool
+	InheritedQueries                        bool
+}
+
+// PhysicalDeviceMemoryProperties contains memory properties
+type PhysicalDeviceMemoryProperties struct {
+	MemoryTypeCount uint32
+	MemoryTypes     [MaxMemoryTypes]MemoryType
+	MemoryHeapCount uint32
+	MemoryHeaps     [MaxMemoryHeaps]MemoryHeap
+}
+
+// MemoryType contains memory type information
+type MemoryType struct {
+	PropertyFlags MemoryPropertyFlags
+	HeapIndex     uint32
+}
+
+// MemoryHeap contains memory heap information
+type MemoryHeap struct {
+	Size  DeviceSize
+	Flags MemoryHeapFlags
+}
+
+// MemoryPropertyFlags represents memory property flags
+type MemoryPropertyFlags uint32
+
+const (
+	MemoryPropertyDeviceLocalBit     MemoryPropertyFlags = 1
+	MemoryPropertyHostVisibleBit     MemoryPropertyFlags = 2
+	MemoryPropertyHostCoherentBit    MemoryPropertyFlags = 4
+	MemoryPropertyHostCachedBit      MemoryPropertyFlags = 8
+	MemoryPropertyLazilyAllocatedBit MemoryPropertyFlags = 16
+	MemoryPropertyProtectedBit       MemoryPropertyFlags = 32
+	MemoryPropertyDeviceCoherentBit  MemoryPropertyFlags = 64
+	MemoryPropertyDeviceUncachedBit  MemoryPropertyFlags = 128
+)
+
+// MemoryHeapFlags represents memory heap flags
+type MemoryHeapFlags uint32
+
+const (
+	MemoryHeapDeviceLocalBit MemoryHeapFlags = 1
+)
+
+// CreateDevice creates a logical device
+func CreateDevice(physicalDevice PhysicalDevice, createInfo *DeviceCreateInfo) (Device, error) {
+	return nil, ErrorInitializationFailed
+}
+
+// DestroyDevice destroys a logical device
+func DestroyDevice(device Device) {}
+
+// GetDeviceQueue gets a device queue
+func GetDeviceQueue(device Device, queueFamilyIndex, queueIndex uint32) Queue {
+	return nil
+}
+
+// DeviceWaitIdle waits for a device to become idle
+func DeviceWaitIdle(device Device) error {
+	return ErrorInitializationFailed
+}
+
+// GetPhysicalDeviceMemoryProperties gets physical device memory properties
+func GetPhysicalDeviceMemoryProperties(physicalDevice PhysicalDevice) PhysicalDeviceMemoryProperties {
+	return PhysicalDeviceMemoryProperties{}
+}
+
+// PhysicalDeviceMemoryProperties2 contains memory properties together with the
+// driver-reported per-heap budget and usage from VK_EXT_memory_budget
+type PhysicalDeviceMemoryProperties2 struct {
+	MemoryProperties PhysicalDeviceMemoryProperties
+	// HeapBudget is an estimate of how much memory the process can allocate from each heap
+	HeapBudget [MaxMemoryHeaps]DeviceSize
+	// HeapUsage is an estimate of how much memory the process currently uses in each heap
+	HeapUsage [MaxMemoryHeaps]DeviceSize
+}
+
+// GetPhysicalDeviceMemoryProperties2 gets memory properties and per-heap budgets.
+// The physical device must support VK_EXT_memory_budget; the values are snapshots and
+// change as memory is allocated by this and other processes.
+func GetPhysicalDeviceMemoryProperties2(physicalDevice PhysicalDevice) (*PhysicalDeviceMemoryProperties2, error) {
+	return nil, ErrorInitializationFailed
+}
+
+// BufferCreateInfo contains buffer creation information
+type BufferCreateInfo struct {
+	Flags       BufferCreateFlags
+	Size        DeviceSize
+	Usage       BufferUsageFlags
+	SharingMode SharingMode
+	// QueueFamilyIndices lists the queue families that access the buffer when SharingMode
+	// is SharingModeConcurrent. It must hold at least two distinct indices and is ignored
+	// for SharingModeExclusive.
+	QueueFamilyIndices []uint32
+	// OpaqueCaptureAddress requests a previously captured device address when
+	// replaying. Requires BufferCreateDeviceAddressCaptureReplayBit.
+	OpaqueCaptureAddress uint64
+	// ExternalMemoryHandleTypes lists the handle types the buffer's memory may be exported
+	// or imported as. Check support with GetPhysicalDeviceExternalBufferProperties.
+	ExternalMemoryHandleTypes ExternalMemoryHandleTypeFlags
+}
+
+// BufferCreateFlags represents buffer creation flags
+type BufferCreateFlags uint32
+
+// BufferUsageFlags represents buffer usage flags
+type BufferUsageFlags uint32
+
+const (
+	BufferUsageTransferSrcBit         BufferUsageFlags = 1
+	BufferUsageTransferDstBit         BufferUsageFlags = 2
+	BufferUsageUniformTexelBufferBit  BufferUsageFlags = 4
+	BufferUsageStorageTexelBufferBit  BufferUsageFlags = 8
+	BufferUsageUniformBufferBit       BufferUsageFlags = 16
+	BufferUsageStorageBufferBit       BufferUsageFlags = 32
+	BufferUsageIndexBufferBit         BufferUsageFlags = 64
+	BufferUsageVertexBufferBit        BufferUsageFlags = 128
+	BufferUsageIndirectBufferBit      BufferUsageFlags = 256
+	BufferUsageShaderDeviceAddressBit BufferUsageFlags = 131072
+)
+
+// SharingMode represents resource sharing mode
+type SharingMode int32
+
+const (
+	SharingModeExclusive SharingMode = 0
+)
+
+// ExternalMemoryHandleTypeFlags identify the kinds of OS handles memory can be shared through
+// (external memory, core in Vulkan 1.1)
+type ExternalMemoryHandleTypeFlags uint32
+
+// ImportMemoryFdInfo imports memory from a file descriptor when passed in
+// MemoryAllocateInfo.ImportMemoryFd
+type ImportMemoryFdInfo struct {
+	HandleType ExternalMemoryHandleTypeFlags
+	Fd         int
+}
+
+// ImportMemoryWin32HandleInfo imports memory from a Windows handle when passed in
+// MemoryAllocateInfo.ImportMemoryWin32Handle
+type ImportMemoryWin32HandleInfo struct {
+	HandleType ExternalMemoryHandleTypeFlags
+	Handle     uintptr
+}
+
+// MemoryAllocateInfo contains memory allocation information
+type MemoryAllocateInfo struct {
+	AllocationSize  DeviceSize
+	MemoryTypeIndex uint32
+	Flags           MemoryAllocateFlags
+	// DeviceMask selects the devices of a device group the memory is allocated on. Requires
+	// MemoryAllocateDeviceMaskBit; without it, memory is allocated on every device.
+	DeviceMask uint32
+	// OpaqueCaptureAddress requests a previously captured memory address when
+	// replaying. Requires MemoryAllocateDeviceAddressCaptureReplayBit.
+	OpaqueCaptureAddress uint64
+	// DedicatedImage or DedicatedBuffer makes this a dedicated allocation for a single
+	// resource, which must then be bound at offset 0. At most one may be set.
+	DedicatedImage  Image
+	DedicatedBuffer Buffer
+	// ExportHandleTypes makes the memory exportable as these handle types, e.g. with
+	// GetMemoryFdKHR
+	ExportHandleTypes ExternalMemoryHandleTypeFlags
+	// ImportMemoryFd or ImportMemoryWin32Handle imports the memory from a handle exported by
+	// another API or process instead of allocating new memory. At most one may be set.
+	ImportMemoryFd          *ImportMemoryFdInfo
+	ImportMemoryWin32Handle *ImportMemoryWin32HandleInfo
+}
+
+// MemoryAllocateFlags represents memory allocation flags
+type MemoryAllocateFlags uint32
+
+// MemoryRequirements contains memory requirements
+type MemoryRequirements struct {
+	Size           DeviceSize
+	Alignment      DeviceSize
+	MemoryTypeBits uint32
+}
+
+// MemoryDedicatedRequirements reports whether a resource should get an allocation of its own
+type MemoryDedicatedRequirements struct {
+	// PrefersDedicated indicates that a dedicated allocation may perform better
+	PrefersDedicated bool
+	// RequiresDedicated indicates that the resource must use a dedicated allocation
+	RequiresDedicated bool
+}
+
+// ImageCreateInfo contains image creation information
+type ImageCreateInfo struct {
+	Flags         ImageCreateFlags
+	ImageType     ImageType
+	Format        Format
+	Extent        Extent3D
+	MipLevels     uint32
+	ArrayLayers   uint32
+	Samples       SampleCountFlags
+	Tiling        ImageTiling
+	Usage         ImageUsageFlags
+	SharingMode   SharingMode
+	InitialLayout ImageLayout
+	// QueueFamilyIndices lists the queue families that access the image when SharingMode
+	// is SharingModeConcurrent. It must hold at least two distinct indices and is ignored
+	// for SharingModeExclusive.
+	QueueFamilyIndices []uint32
+	// ExternalMemoryHandleTypes lists the handle types the image's memory may be exported
+	// or imported as
+	ExternalMemoryHandleTypes ExternalMemoryHandleTypeFlags
+	// ImageCompressionControl requests fixed-rate, default or disabled compression when set.
+	// Requires ExtensionNameImageCompressionControl; GetImageSubresourceLayout2EXT reports
+	// the compression the image got.
+	ImageCompressionControl *ImageCompressionControlEXT
+}
+
+// ImageCompressionFlags selects the compression an image may use
+type ImageCompressionFlags uint32
+
+// ImageCompressionFixedRateFlags lists fixed compression rates in bits per component
+type ImageCompressionFixedRateFlags uint32
+
+// ImageCompressionControlEXT requests the compression of an image, or of the image a format
+// query describes
+type ImageCompressionControlEXT struct {
+	// Flags must be exactly one of the ImageCompression values
+	Flags ImageCompressionFlags
+	// FixedRateFlags lists the allowed fixed rates for each plane of the format when Flags
+	// is ImageCompressionFixedRateExplicit, and must be empty otherwise. The implementation
+	// picks the lowest supported rate in each entry.
+	FixedRateFlags []ImageCompressionFixedRateFlags
+}
+
+// ImageType represents image types
+type ImageType int32
+
+const (
+	ImageType1D ImageType = 0
+	ImageType2D ImageType = 1
+	ImageType3D ImageType = 2
+)
+
+// ImageCreateFlags represents image creation flags
+type ImageCreateFlags uint32
+
+// Format represents pixel formats
+type Format int32
+
+const (
+	FormatA8B8G8R8UnormPack32                  Format = 51
+	FormatA8B8G8R8SnormPack32                  Format = 52
+	FormatA8B8G8R8UscaledPack32                Format = 53
+	FormatA8B8G8R8SscaledPack32                Format = 54
+	FormatA8B8G8R8UintPack32                   Format = 55
+	FormatA8B8G8R8SintPack32                   Format = 56
+	FormatA8B8G8R8SrgbPack32                   Format = 57
+	FormatA2R10G10B10UnormPack32               Format = 58
+	FormatA2R10G10B10SnormPack32               Format = 59
+	FormatA2R10G10B10UscaledPack32             Format = 60
+	FormatA2R10G10B10SscaledPack32             Format = 61
+	FormatA2R10G10B10UintPack32                Format = 62
+	FormatA2R10G10B10SintPack32                Format = 63
+	FormatA2B10G10R10UnormPack32               Format = 64
+	FormatA2B10G10R10SnormPack32               Format = 65
+	FormatA2B10G10R10UscaledPack32             Format = 66
+	FormatA2B10G10R10SscaledPack32             Format = 67
+	FormatA2B10G10R10UintPack32                Format = 68
+	FormatA2B10G10R10SintPack32                Format = 69
+	FormatR16Unorm                             Format = 70
+	FormatR16Snorm                             Format = 71
+	FormatR16Uscaled                           Format = 72
+	FormatR16Sscaled                           Format = 73
+	FormatR16Uint                              Format = 74
+	FormatR16Sint                              Format = 75
+	FormatR16Sfloat                            Format = 76
+	FormatR16G16Unorm                          Format = 77
+	FormatR16G16Snorm                          Format = 78
+	FormatR16G16Uscaled                        Format = 79
+	FormatR16G16Sscaled                        Format = 80
+	FormatR16G16Uint                           Format = 81
+	FormatR16G16Sint                           Format = 82
+	FormatR16G16Sfloat                         Format = 83
+	FormatR16G16B16Unorm                       Format = 84
+	FormatR16G16B16Snorm                       Format = 85
+	FormatR16G16B16Uscaled                     Format = 86
+	FormatR16G16B16Sscaled                     Format = 87
+	FormatR16G16B16Uint                        Format = 88
+	FormatR16G16B16Sint                        Format = 89
+	FormatR16G16B16Sfloat                      Format = 90
+	FormatR16G16B16A16Unorm                    Format = 91
+	FormatR16G16B16A16Snorm                    Format = 92
+	FormatR16G16B16A16Uscaled                  Format = 93
+	FormatR16G16B16A16Sscaled                  Format = 94
+	FormatR16G16B16A16Uint                     Format = 95
+	FormatR16G16B16A16Sint                     Format = 96
+	FormatR16G16B16A16Sfloat                   Format = 97
+	FormatR32Uint                              Format = 98
+	FormatR32Sint                              Format = 99
+	FormatR32Sfloat                            Format = 100
+	FormatR32G32Uint                           Format = 101
+	FormatR32G32Sint                           Format = 102
+	FormatR32G32Sfloat                         Format = 103
+	FormatR32G32B32Uint                        Format = 104
+	FormatR32G32B32Sint                        Format = 105
+	FormatR32G32B32Sfloat                      Format = 106
+	FormatR32G32B32A32Uint                     Format = 107
+	FormatR32G32B32A32Sint                     Format = 108
+	FormatR32G32B32A32Sfloat                   Format = 109
+	FormatR64Uint                              Format = 110
+	FormatR64Sint                              Format = 111
+	FormatR64Sfloat                            Format = 112
+	FormatR64G64Uint                           Format = 113
+	FormatR64G64Sint                           Format = 114
+	FormatR64G64Sfloat                         Format = 115
+	FormatR64G64B64Uint                        Format = 116
+	FormatR64G64B64Sint                        Format = 117
+	FormatR64G64B64Sfloat                      Format = 118
+	FormatR64G64B64A64Uint                     Format = 119
+	FormatR64G64B64A64Sint                     Format = 120
+	FormatR64G64B64A64Sfloat                   Format = 121
+	FormatB10G11R11UfloatPack32                Format = 122
+	FormatE5B9G9R9UfloatPack32                 Format = 123
+	FormatBC1RGBUnormBlock                     Format = 131
+	FormatBC1RGBSrgbBlock                      Format = 132
+	FormatBC1RGBAUnormBlock                    Format = 133
+	FormatBC1RGBASrgbBlock                     Format = 134
+	FormatBC2UnormBlock                        Format = 135
+	FormatBC2SrgbBlock                         Format = 136
+	FormatBC3UnormBlock                        Format = 137
+	FormatBC3SrgbBlock                         Format = 138
+	FormatBC4UnormBlock                        Format = 139
+	FormatBC4SnormBlock                        Format = 140
+	FormatBC5UnormBlock                        Format = 141
+	FormatBC5SnormBlock                        Format = 142
+	FormatBC6HUfloatBlock                      Format = 143
+	FormatBC6HSfloatBlock                      Format = 144
+	FormatBC7UnormBlock                        Format = 145
+	FormatBC7SrgbBlock                         Format = 146
+	FormatETC2R8G8B8UnormBlock                 Format = 147
+	FormatETC2R8G8B8SrgbBlock                  Format = 148
+	FormatETC2R8G8B8A1UnormBlock               Format = 149
+	FormatETC2R8G8B8A1SrgbBlock                Format = 150
+	FormatETC2R8G8B8A8UnormBlock               Format = 151
+	FormatETC2R8G8B8A8SrgbBlock                Format = 152
+	FormatEACR11UnormBlock                     Format = 153
+	FormatEACR11SnormBlock                     Format = 154
+	FormatEACR11G11UnormBlock                  Format = 155
+	FormatEACR11G11SnormBlock                  Format = 156
+	FormatASTC4x4UnormBlock                    Format = 157
+	FormatASTC4x4SrgbBlock                     Format = 158
+	FormatASTC5x4UnormBlock                    Format = 159
+	FormatASTC5x4SrgbBlock                     Format = 160
+	FormatASTC5x5UnormBlock                    Format = 161
+	FormatASTC5x5SrgbBlock                     Format = 162
+	FormatASTC6x5UnormBlock                    Format = 163
+	FormatASTC6x5SrgbBlock                     Format = 164
+	FormatASTC6x6UnormBlock                    Format = 165
+	FormatASTC6x6SrgbBlock                     Format = 166
+	FormatASTC8x5UnormBlock                    Format = 167
+	FormatASTC8x5SrgbBlock                     Format = 168
+	FormatASTC8x6UnormBlock                    Format = 169
+	FormatASTC8x6SrgbBlock                     Format = 170
+	FormatASTC8x8UnormBlock                    Format = 171
+	FormatASTC8x8SrgbBlock                     Format = 172
+	FormatASTC10x5UnormBlock                   Format = 173
+	FormatASTC10x5SrgbBlock                    Format = 174
+	FormatASTC10x6UnormBlock                   Format = 175
+	FormatASTC10x6SrgbBlock                    Format = 176
+	FormatASTC10x8UnormBlock                   Format = 177
+	FormatASTC10x8SrgbBlock                    Format = 178
+	FormatASTC10x10UnormBlock                  Format = 179
+	FormatASTC10x10SrgbBlock                   Format = 180
+	FormatASTC12x10UnormBlock                  Format = 181
+	FormatASTC12x10SrgbBlock                   Format = 182
+	FormatASTC12x12UnormBlock                  Format = 183
+	FormatASTC12x12SrgbBlock                   Format = 184
+	FormatUndefined                            Format = 0
+	FormatR4G4UnormPack8                       Format = 1
+	FormatR4G4B4A4UnormPack16                  Format = 2
+	FormatB4G4R4A4UnormPack16                  Format = 3
+	FormatR5G6B5UnormPack16                    Format = 4
+	FormatB5G6R5UnormPack16                    Format = 5
+	FormatR5G5B5A1UnormPack16                  Format = 6
+	FormatB5G5R5A1UnormPack16                  Format = 7
+	FormatA1R5G5B5UnormPack16                  Format = 8
+	FormatR8Unorm                              Format = 9
+	FormatR8Snorm                              Format = 10
+	FormatR8Uscaled                            Format = 11
+	FormatR8Sscaled                            Format = 12
+	FormatR8Uint                               Format = 13
+	FormatR8Sint                               Format = 14
+	FormatR8Srgb                               Format = 15
+	FormatR8G8Unorm                            Format = 16
+	FormatR8G8Snorm                            Format = 17
+	FormatR8G8Uscaled                          Format = 18
+	FormatR8G8Sscaled                          Format = 19
+	FormatR8G8Uint                             Format = 20
+	FormatR8G8Sint                             Format = 21
+	FormatR8G8Srgb                             Format = 22
+	FormatR8G8B8Unorm                          Format = 23
+	FormatR8G8B8Snorm                          Format = 24
+	FormatR8G8B8Uscaled                        Format = 25
+	FormatR8G8B8Sscaled                        Format = 26
+	FormatR8G8B8Uint                           Format = 27
+	FormatR8G8B8Sint                           Format = 28
+	FormatR8G8B8Srgb                           Format = 29
+	FormatB8G8R8Unorm                          Format = 30
+	FormatB8G8R8Snorm                          Format = 31
+	FormatB8G8R8Uscaled                        Format = 32
+	FormatB8G8R8Sscaled                        Format = 33
+	FormatB8G8R8Uint                           Format = 34
+	FormatB8G8R8Sint                           Format = 35
+	FormatB8G8R8Srgb                           Format = 36
+	FormatR8G8B8A8Unorm                        Format = 37
+	FormatR8G8B8A8Snorm                        Format = 38
+	FormatR8G8B8A8Uscaled                      Format = 39
+	FormatR8G8B8A8Sscaled                      Format = 40
+	FormatR8G8B8A8Uint                         Format = 41
+	FormatR8G8B8A8Sint                         Format = 42
+	FormatR8G8B8A8Srgb                         Format = 43
+	FormatB8G8R8A8Unorm                        Format = 44
+	FormatB8G8R8A8Snorm                        Format = 45
+	FormatB8G8R8A8Uscaled                      Format = 46
+	FormatB8G8R8A8Sscaled                      Format = 47
+	FormatB8G8R8A8Uint                         Format = 48
+	FormatB8G8R8A8Sint                         Format = 49
+	FormatB8G8R8A8Srgb                         Format = 50
+	FormatD16Unorm                             Format = 124
+	FormatX8D24UnormPack32                     Format = 125
+	FormatD32Sfloat                            Format = 126
+	FormatS8Uint                               Format = 127
+	FormatD16UnormS8Uint                       Format = 128
+	FormatD24UnormS8Uint                       Format = 129
+	FormatD32SfloatS8Uint                      Format = 130
+	FormatG8B8R82Plane420Unorm                 Format = 1000156003
+	FormatG10X6B10X6R10X62Plane420Unorm3Pack16 Format = 1000156013
+	FormatG12X4B12X4R12X42Plane420Unorm3Pack16 Format = 1000156023
+	FormatG8B8R82Plane422Unorm                 Format = 1000156005
+	FormatG10X6B10X6R10X62Plane422Unorm3Pack16 Format = 1000156015
+	FormatG8B8R83Plane444Unorm                 Format = 1000156006
+)
+
+// ImageTiling represents image tiling modes
+type ImageTiling int32
+
+const (
+	ImageTilingOptimal ImageTiling = 0
+	ImageTilingLinear  ImageTiling = 1
+)
+
+// ImageUsageFlags represents image usage flags
+type ImageUsageFlags uint32
+
+const (
+	ImageUsageTransferSrcBit            ImageUsageFlags = 1
+	ImageUsageTransferDstBit            ImageUsageFlags = 2
+	ImageUsageSampledBit                ImageUsageFlags = 4
+	ImageUsageStorageBit                ImageUsageFlags = 8
+	ImageUsageColorAttachmentBit        ImageUsageFlags = 16
+	ImageUsageDepthStencilAttachmentBit ImageUsageFlags = 32
+	ImageUsageTransientAttachmentBit    ImageUsageFlags = 64
+	ImageUsageInputAttachmentBit        ImageUsageFlags = 128
+)
+
+// ImageLayout represents image layouts
+type ImageLayout int32
+
+const (
+	ImageLayoutUndefined                     ImageLayout = 0
+	ImageLayoutGeneral                       ImageLayout = 1
+	ImageLayoutColorAttachmentOptimal        ImageLayout = 2
+	ImageLayoutDepthStencilAttachmentOptimal ImageLayout = 3
+	ImageLayoutDepthStencilReadOnlyOptimal   ImageLayout = 4
+	ImageLayoutShaderReadOnlyOptimal         ImageLayout = 5
+	ImageLayoutTransferSrcOptimal            ImageLayout = 6
+	ImageLayoutTransferDstOptimal            ImageLayout = 7
+	ImageLayoutPreinitialized                ImageLayout = 8
+	ImageLayoutPresentSrcKHR                 ImageLayout = 1000001002
+)
+
+// CreateBuffer creates a buffer
+func CreateBuffer(device Device, createInfo *BufferCreateInfo) (Buffer, error) {
+	return nil, ErrorInitializationFailed
+}
+
+// DestroyBuffer destroys a buffer
+func DestroyBuffer(device Device, buffer Buffer) {}
+
+// AllocateMemory allocates device memory
+func AllocateMemory(device Device, allocateInfo *MemoryAllocateInfo) (DeviceMemory, error) {
+	return nil, ErrorInitializationFailed
+}
+
+// FreeMemory frees device memory
+func FreeMemory(device Device, memory DeviceMemory) {}
+
+// BindBufferMemory binds buffer memory
+func BindBufferMemory(device Device, buffer Buffer, memory DeviceMemory, memoryOffset DeviceSize) error {
+	return ErrorInitializationFailed
+}
+
+// MapMemory maps device memory
+func MapMemory(device Device, memory DeviceMemory, offset, size DeviceSize, flags uint32) (unsafe.Pointer, error) {
+	return nil, ErrorInitializationFailed
+}
+
+// UnmapMemory unmaps device memory
+func UnmapMemory(device Device, memory DeviceMemory) {}
+
+// CreateImage creates an image
+func CreateImage(device Device, createInfo *ImageCreateInfo) (Image, error) {
+	return nil, ErrorInitializationFailed
+}
+
+// DestroyImage destroys an image
+func DestroyImage(device Device, image Image) {}
+
+// MipLevelCount returns the number of levels in a full mip chain for a width x height image
+func MipLevelCount(width, height uint32) uint32 {
+	return uint32(bits.Len32(max(width, height, 1)))
+}
+
+// GetBufferMemoryRequirements2 gets buffer memory requirements along with whether the
+// buffer prefers or requires a dedicated allocation (Vulkan 1.1)
+func GetBufferMemoryRequirements2(device Device, buffer Buffer) (MemoryRequirements, MemoryDedicatedRequirements) {
+	return MemoryRequirements{}, MemoryDedicatedRequirements{}
+}
+
+// FindMemoryType finds a suitable memory type
+func FindMemoryType(memProperties PhysicalDeviceMemoryProperties, typeFilter uint32, properties MemoryPropertyFlags) (uint32, bool) {
+	return FindMemoryTypeWithFallback(memProperties, typeFilter, properties, 0)
+}
+
+// FindMemoryTypeWithFallback finds a memory type that has all of the required flags, preferring
+// one that also has the preferred flags. For example required HostVisible with preferred
+// DeviceLocal picks device-local host-visible memory on integrated GPUs and plain host-visible
+// memory elsewhere.
+func FindMemoryTypeWithFallback(memProperties PhysicalDeviceMemoryProperties, typeFilter uint32, required, preferred MemoryPropertyFlags) (uint32, bool) {
+	if preferred != 0 {
+		if index, ok := findMemoryTypeIndex(memProperties, typeFilter, required|preferred); ok {
+			return index, true
+		}
+	}
+	return findMemoryTypeIndex(memProperties, typeFilter, required)
+}
+
+// findMemoryTypeIndex returns the first allowed memory type that has all of the given flags
+func findMemoryTypeIndex(memProperties PhysicalDeviceMemoryProperties, typeFilter uint32, properties MemoryPropertyFlags) (uint32, bool) {
+	for i := uint32(0); i < memProperties.MemoryTypeCount; i++ {
+		if (typeFilter&(1<<i)) != 0 && (memProperties.MemoryTypes[i].PropertyFlags&properties) == properties {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// ImageViewCreateInfo contains image view creation information
+type ImageViewCreateInfo struct {
+	Image            Image
+	ViewType         ImageViewType
+	Format           Format
+	SubresourceRange ImageSubresourceRange
+}
+
+// ImageViewType represents image view types
+type ImageViewType int32
+
+// ImageSubresourceRange describes an image subresource range
+type ImageSubresourceRange struct {
+	AspectMask     ImageAspectFlags
+	BaseMipLevel   uint32
+	LevelCount     uint32
+	BaseArrayLayer uint32
+	LayerCount     uint32
+}
+
+// ImageAspectFlags represents image aspect flags
+type ImageAspectFlags uint32
+
+const (
+	ImageAspectColorBit   ImageAspectFlags = 1
+	ImageAspectDepthBit   ImageAspectFlags = 2
+	ImageAspectStencilBit ImageAspectFlags = 4
+)
+
+// AspectMaskForFormat returns the aspects an image of format has: depth and/or stencil for
+// depth-stencil formats and color for everything else. Barriers and views covering a whole
+// combined depth-stencil image must name both aspects.
+func AspectMaskForFormat(format Format) ImageAspectFlags {
+	switch format {
+	case FormatD16Unorm, FormatX8D24UnormPack32, FormatD32Sfloat:
+		return ImageAspectDepthBit
+	case FormatS8Uint:
+		return ImageAspectStencilBit
+	case FormatD16UnormS8Uint, FormatD24UnormS8Uint, FormatD32SfloatS8Uint:
+		return ImageAspectDepthBit | ImageAspectStencilBit
+	default:
+		return ImageAspectColorBit
+	}
+}
+
+// SamplerCreateInfo contains sampler creation information
+type SamplerCreateInfo struct {
+	MagFilter    Filter
+	MinFilter    Filter
+	AddressModeU SamplerAddressMode
+	AddressModeV SamplerAddressMode
+	AddressModeW SamplerAddressMode
+}
+
+// Filter represents texture filtering modes
+type Filter int32
+
+// SamplerAddressMode represents sampler address modes
+type SamplerAddressMode int32
+
+// DescriptorSetLayoutCreateInfo contains descriptor set layout creation information
+type DescriptorSetLayoutCreateInfo struct {
+	Flags    DescriptorSetLayoutCreateFlags
+	Bindings []DescriptorSetLayoutBinding
+	// BindingFlags optionally holds descriptor indexing flags, one entry per element of
+	// Bindings. Requires the matching PhysicalDeviceDescriptorIndexingFeatures.
+	BindingFlags []DescriptorBindingFlags
+}
+
+// DescriptorSetLayoutCreateFlags represents descriptor set layout creation flags
+type DescriptorSetLayoutCreateFlags uint32
+
+// DescriptorSetLayoutBinding describes a descriptor set layout binding
+type DescriptorSetLayoutBinding struct {
+	Binding         uint32
+	DescriptorType  DescriptorType
+	DescriptorCount uint32
+	StageFlags      ShaderStageFlags
+}
+
+// DescriptorType represents descriptor types
+type DescriptorType int32
+
+const (
+	DescriptorTypeSampler                  DescriptorType = 0
+	DescriptorTypeCombinedImageSampler     DescriptorType = 1
+	DescriptorTypeSampledImage             DescriptorType = 2
+	DescriptorTypeStorageImage             DescriptorType = 3
+	DescriptorTypeUniformTexelBuffer       DescriptorType = 4
+	DescriptorTypeStorageTexelBuffer       DescriptorType = 5
+	DescriptorTypeUniformBuffer            DescriptorType = 6
+	DescriptorTypeStorageBuffer            DescriptorType = 7
+	DescriptorTypeUniformBufferDynamic     DescriptorType = 8
+	DescriptorTypeStorageBufferDynamic     DescriptorType = 9
+	DescriptorTypeInputAttachment          DescriptorType = 10
+	DescriptorTypeAccelerationStructureKHR DescriptorType = 1000150000
+)
+
+// DescriptorPoolCreateInfo contains descriptor pool creation information
+type DescriptorPoolCreateInfo struct {
+	Flags     DescriptorPoolCreateFlags
+	MaxSets   uint32
+	PoolSizes []DescriptorPoolSize
+}
+
+// DescriptorPoolCreateFlags represents descriptor pool creation flags
+type DescriptorPoolCreateFlags uint32
+
+// DescriptorPoolSize describes a descriptor pool size
+type DescriptorPoolSize struct {
+	Type            DescriptorType
+	DescriptorCount uint32
+}
+
+// CreateImageView creates an image view
+func CreateImageView(device Device, createInfo *ImageViewCreateInfo) (ImageView, error) {
+	return nil, ErrorInitializationFailed
+}
+
+// DestroyImageView destroys an image view
+func DestroyImageView(device Device, imageView ImageView) {}
+
+// BufferViewCreateInfo contains buffer view creation information. Range is in bytes and
+// may be WholeSize to view the rest of the buffer after Offset.
+type BufferViewCreateInfo struct {
+	Buffer Buffer
+	Format Format
+	Offset DeviceSize
+	Range  DeviceSize
+}
+
+// CreateBufferView creates a buffer view for use as a uniform or storage texel buffer. The buffer
+// must have been created with BufferUsageUniformTexelBufferBit or BufferUsageStorageTexelBufferBit,
+// and Offset must be a multiple of PhysicalDeviceLimits.MinTexelBufferOffsetAlignment.
+func CreateBufferView(device Device, createInfo *BufferViewCreateInfo) (BufferView, error) {
+	return nil, ErrorInitializationFailed
+}
+
+// DestroyBufferView destroys a buffer view
+func DestroyBufferView(device Device, bufferView BufferView) {}
+
+// CreateSampler creates a sampler
+func CreateSampler(device Device, createInfo *SamplerCreateInfo) (Sampler, error) {
+	return nil, ErrorInitializationFailed
+}
+
+// DestroySampler destroys a sampler
+func DestroySampler(device Device, sampler Sampler) {}
+
+// CreateDescriptorSetLayout creates a descriptor set layout
+func CreateDescriptorSetLayout(device Device, createInfo *DescriptorSetLayoutCreateInfo) (DescriptorSetLayout, error) {
+	return nil, ErrorInitializationFailed
+}
+
+// DestroyDescriptorSetLayout destroys a descriptor set layout
+func DestroyDescriptorSetLayout(device Device, layout DescriptorSetLayout) {}
+
+// CreateDescriptorPool creates a descriptor pool
+func CreateDescriptorPool(device Device, createInfo *DescriptorPoolCreateInfo) (DescriptorPool, error) {
+	return nil, ErrorInitializationFailed
+}
+
+// DestroyDescriptorPool destroys a descriptor pool
+func DestroyDescriptorPool(device Device, pool DescriptorPool) {}
+
+// ShaderModuleCreateInfo contains shader module creation information
+type ShaderModuleCreateInfo struct {
+	CodeSize uint32
+	Code     []uint32
+}
+
+// PipelineShaderStageCreateInfo contains pipeline shader stage creation information
+type PipelineShaderStageCreateInfo struct {
+	Stage              ShaderStageFlags
+	Module             ShaderModule
+	Name               string
+	SpecializationInfo *SpecializationInfo
+}
+
+// SpecializationInfo supplies values for specialization constants
+type SpecializationInfo struct {
+	MapEntries []SpecializationMapEntry
+	Data       []byte
+}
+
+// SpecializationMapEntry maps a specialization constant ID to a range of
+// SpecializationInfo.Data
+type SpecializationMapEntry struct {
+	ConstantID uint32
+	Offset     uint32
+	Size       uint32
+}
+
+// ShaderStageFlags represents shader stage flags
+type ShaderStageFlags uint32
+
+const (
+	ShaderStageVertexBit                 ShaderStageFlags = 0x00000001
+	ShaderStageTessellationControlBit    ShaderStageFlags = 0x00000002
+	ShaderStageTessellationEvaluationBit ShaderStageFlags = 0x00000004
+	ShaderStageGeometryBit               ShaderStageFlags = 0x00000008
+	ShaderStageFragmentBit               ShaderStageFlags = 0x00000010
+	ShaderStageComputeBit                ShaderStageFlags = 0x00000020
+	ShaderStageAllGraphics               ShaderStageFlags = 0x0000001F
+	ShaderStageAll                       ShaderStageFlags = 0x7FFFFFFF
+	ShaderStageTaskBitEXT                ShaderStageFlags = 0x00000040
+	ShaderStageMeshBitEXT                ShaderStageFlags = 0x00000080
+	ShaderStageRaygenBitKHR              ShaderStageFlags = 0x00000100
+	ShaderStageAnyHitBitKHR              ShaderStageFlags = 0x00000200
+	ShaderStageClosestHitBitKHR          ShaderStageFlags = 0x00000400
+	ShaderStageMissBitKHR                ShaderStageFlags = 0x00000800
+	ShaderStageIntersectionBitKHR        ShaderStageFlags = 0x00001000
+	ShaderStageCallableBitKHR            ShaderStageFlags = 0x00002000
+)
+
+// PipelineLayoutCreateInfo contains pipeline layout creation information
+type PipelineLayoutCreateInfo struct {
+	SetLayouts    []DescriptorSetLayout
+	PushConstants []PushConstantRange
+}
+
+// PushConstantRange represents a push constant range
+type PushConstantRange struct {
+	StageFlags ShaderStageFlags
+	Offset     uint32
+	Size       uint32
+}
+
+// RenderPassCreateInfo contains render pass creation information
+type RenderPassCreateInfo struct {
+	Attachments  []AttachmentDescription
+	Subpasses    []SubpassDescription
+	Dependencies []SubpassDependency
+}
+
+// AttachmentDescription describes a render pass attachment
+type AttachmentDescription struct {
+	Format         Format
+	Samples        SampleCountFlags
+	LoadOp         AttachmentLoadOp
+	StoreOp        AttachmentStoreOp
+	StencilLoadOp  AttachmentLoadOp
+	StencilStoreOp AttachmentStoreOp
+	InitialLayout  ImageLayout
+	FinalLayout    ImageLayout
+}
+
+// AttachmentLoadOp represents attachment load operations
+type AttachmentLoadOp int32
+
+const (
+	AttachmentLoadOpLoad     AttachmentLoadOp = 0
+	AttachmentLoadOpClear    AttachmentLoadOp = 1
+	AttachmentLoadOpDontCare AttachmentLoadOp = 2
+)
+
+// AttachmentStoreOp represents attachment store operations
+type AttachmentStoreOp int32
+
+const (
+	AttachmentStoreOpStore    AttachmentStoreOp = 0
+	AttachmentStoreOpDontCare AttachmentStoreOp = 1
+)
+
+// SubpassDescription describes a subpass
+type SubpassDescription struct {
+	PipelineBindPoint      PipelineBindPoint
+	InputAttachments       []AttachmentReference
+	ColorAttachments       []AttachmentReference
+	ResolveAttachments     []AttachmentReference
+	DepthStencilAttachment *AttachmentReference
+	PreserveAttachments    []uint32
+}
+
+// PipelineBindPoint represents pipeline bind points
+type PipelineBindPoint int32
+
+const (
+	PipelineBindPointGraphics PipelineBindPoint = 0
+	PipelineBindPointCompute  PipelineBindPoint = 1
+)
+
+// AttachmentReference references an attachment
+type AttachmentReference struct {
+	Attachment uint32
+	Layout     ImageLayout
+}
+
+// SubpassDependency describes subpass dependencies
+type SubpassDependency struct {
+	SrcSubpass    uint32
+	DstSubpass    uint32
+	SrcStageMask  PipelineStageFlags
+	DstStageMask  PipelineStageFlags
+	SrcAccessMask AccessFlags
+	DstAccessMask AccessFlags
+}
+
+// AccessFlags represents memory access flags
+type AccessFlags uint32
+
+const (
+	AccessIndirectCommandReadBit         AccessFlags = 1
+	AccessIndexReadBit                   AccessFlags = 2
+	AccessVertexAttributeReadBit         AccessFlags = 4
+	AccessUniformReadBit                 AccessFlags = 8
+	AccessInputAttachmentReadBit         AccessFlags = 16
+	AccessShaderReadBit                  AccessFlags = 32
+	AccessShaderWriteBit                 AccessFlags = 64
+	AccessColorAttachmentReadBit         AccessFlags = 128
+	AccessColorAttachmentWriteBit        AccessFlags = 256
+	AccessDepthStencilAttachmentReadBit  AccessFlags = 512
+	AccessDepthStencilAttachmentWriteBit AccessFlags = 1024
+	AccessTransferReadBit                AccessFlags = 2048
+	AccessTransferWriteBit               AccessFlags = 4096
+	AccessHostReadBit                    AccessFlags = 8192
+	AccessHostWriteBit                   AccessFlags = 16384
+	AccessMemoryReadBit                  AccessFlags = 32768
+	AccessMemoryWriteBit                 AccessFlags = 65536
+)
+
+// CreateShaderModule creates a shader module
+func CreateShaderModule(device Device, createInfo *ShaderModuleCreateInfo) (ShaderModule, error) {
+	return nil, ErrorInitializationFailed
+}
+
+// DestroyShaderModule destroys a shader module
+func DestroyShaderModule(device Device, shaderModule ShaderModule) {}
+
+// CreatePipelineLayout creates a pipeline layout
+func CreatePipelineLayout(device Device, createInfo *PipelineLayoutCreateInfo) (PipelineLayout, error) {
+	return nil, ErrorInitializationFailed
+}
+
+// DestroyPipelineLayout destroys a pipeline layout
+func DestroyPipelineLayout(device Device, pipelineLayout PipelineLayout) {}
+
+// CreateRenderPass creates a render pass
+func CreateRenderPass(device Device, createInfo *RenderPassCreateInfo) (RenderPass, error) {
+	return nil, ErrorInitializationFailed
+}
+
+// DestroyRenderPass destroys a render pass
+func DestroyRenderPass(device Device, renderPass RenderPass) {}
+
+// PipelineCreateFlags represents pipeline creation flags
+type PipelineCreateFlags uint32
+
+// ComputePipelineCreateInfo contains compute pipeline creation information
+type ComputePipelineCreateInfo struct {
+	// Flags is optional, e.g. PipelineCreateDispatchBaseBit for use with CmdDispatchBase
+	Flags  PipelineCreateFlags
+	Stage  PipelineShaderStageCreateInfo
+	Layout PipelineLayout
+	// Feedback is optional. When set, it is filled with creation durations and
+	// whether the pipeline cache was hit after the pipeline has been created.
+	Feedback *PipelineCreationFeedbackCreateInfo
+}
+
+// CreateComputePipelines creates compute pipelines
+func CreateComputePipelines(device Device, pipelineCache PipelineCache, createInfos []ComputePipelineCreateInfo) ([]Pipeline, error) {
+	return nil, ErrorInitializationFailed
+}
+
+// DestroyPipeline destroys a pipeline
+func DestroyPipeline(device Device, pipeline Pipeline) {}
+
+// CreatePipelineCache creates a pipeline cache, optionally seeded with data previously
+// returned by GetPipelineCacheData. Drivers ignore initial data written by a different
+// driver or device, so stale cache files are safe to pass.
+func CreatePipelineCache(device Device, initialData []byte) (PipelineCache, error) {
+	return nil, ErrorInitializationFailed
+}
+
+// DestroyPipelineCache destroys a pipeline cache
+func DestroyPipelineCache(device Device, pipelineCache PipelineCache) {}
+
+// CommandPoolCreateInfo contains command pool creation information
+type CommandPoolCreateInfo struct {
+	Flags            CommandPoolCreateFlags
+	QueueFamilyIndex uint32
+}
+
+// CommandPoolCreateFlags represents command pool creation flags
+type CommandPoolCreateFlags uint32
+
+const (
+	CommandPoolCreateTransientBit          CommandPoolCreateFlags = 1
+	CommandPoolCreateResetCommandBufferBit CommandPoolCreateFlags = 2
+	CommandPoolCreateProtectedBit          CommandPoolCreateFlags = 4
+)
+
+// CommandPoolResetFlags represents command pool reset flags
+type CommandPoolResetFlags uint32
+
+const (
+	CommandPoolResetReleaseResourcesBit CommandPoolResetFlags = 1
+)
+
+// CommandBufferAllocateInfo contains command buffer allocation information
+type CommandBufferAllocateInfo struct {
+	CommandPool        CommandPool
+	Level              CommandBufferLevel
+	CommandBufferCount uint32
+}
+
+// CommandBufferLevel represents command buffer levels
+type CommandBufferLevel int32
+
+const (
+	CommandBufferLevelPrimary   CommandBufferLevel = 0
+	CommandBufferLevelSecondary CommandBufferLevel = 1
+)
+
+// QueryPipelineStatisticFlags represents pipeline statistics query counters
+type QueryPipelineStatisticFlags uint32
+
+const (
+	QueryPipelineStatisticInputAssemblyVerticesBit                   QueryPipelineStatisticFlags = 1 << 0
+	QueryPipelineStatisticInputAssemblyPrimitivesBit                 QueryPipelineStatisticFlags = 1 << 1
+	QueryPipelineStatisticVertexShaderInvocationsBit                 QueryPipelineStatisticFlags = 1 << 2
+	QueryPipelineStatisticGeometryShaderInvocationsBit               QueryPipelineStatisticFlags = 1 << 3
+	QueryPipelineStatisticGeometryShaderPrimitivesBit                QueryPipelineStatisticFlags = 1 << 4
+	QueryPipelineStatisticClippingInvocationsBit                     QueryPipelineStatisticFlags = 1 << 5
+	QueryPipelineStatisticClippingPrimitivesBit                      QueryPipelineStatisticFlags = 1 << 6
+	QueryPipelineStatisticFragmentShaderInvocationsBit               QueryPipelineStatisticFlags = 1 << 7
+	QueryPipelineStatisticTessellationControlShaderPatchesBit        QueryPipelineStatisticFlags = 1 << 8
+	QueryPipelineStatisticTessellationEvaluationShaderInvocationsBit QueryPipelineStatisticFlags = 1 << 9
+	QueryPipelineStatisticComputeShaderInvocationsBit                QueryPipelineStatisticFlags = 1 << 10
+)
+
+// Count returns the number of counters selected, which is the number of values each
+// pipeline statistics query returns
+func (f QueryPipelineStatisticFlags) Count() uint32 {
+	return uint32(bits.OnesCount32(uint32(f)))
+}
+
+// PipelineStatistics holds the decoded counters of one pipeline statistics query.
+// Counters that were not enabled in the pool are zero.
+type PipelineStatistics struct {
+	InputAssemblyVertices                   uint64
+	InputAssemblyPrimitives                 uint64
+	VertexShaderInvocations                 uint64
+	GeometryShaderInvocations               uint64
+	GeometryShaderPrimitives                uint64
+	ClippingInvocations                     uint64
+	ClippingPrimitives                      uint64
+	FragmentShaderInvocations               uint64
+	TessellationControlShaderPatches        uint64
+	TessellationEvaluationShaderInvocations uint64
+	ComputeShaderInvocations                uint64
+}
+
+// DecodePipelineStatistics maps the values of one pipeline statistics query to named
+// counters. flags must be the PipelineStatistics the pool was created with; results holds
+// the query's values in counter bit order, as returned by GetQueryPoolResults. Extra
+// values, such as an availability value, are ignored.
+func DecodePipelineStatistics(flags QueryPipelineStatisticFlags, results []uint64) PipelineStatistics {
+	var stats PipelineStatistics
+	counters := []struct {
+		bit   QueryPipelineStatisticFlags
+		field *uint64
+	}{
+		{QueryPipelineStatisticInputAssemblyVerticesBit, &stats.InputAssemblyVertices},
+		{QueryPipelineStatisticInputAssemblyPrimitivesBit, &stats.InputAssemblyPrimitives},
+		{QueryPipelineStatisticVertexShaderInvocationsBit, &stats.VertexShaderInvocations},
+		{QueryPipelineStatisticGeometryShaderInvocationsBit, &stats.GeometryShaderInvocations},
+		{QueryPipelineStatisticGeometryShaderPrimitivesBit, &stats.GeometryShaderPrimitives},
+		{QueryPipelineStatisticClippingInvocationsBit, &stats.ClippingInvocations},
+		{QueryPipelineStatisticClippingPrimitivesBit, &stats.ClippingPrimitives},
+		{QueryPipelineStatisticFragmentShaderInvocationsBit, &stats.FragmentShaderInvocations},
+		{QueryPipelineStatisticTessellationControlShaderPatchesBit, &stats.TessellationControlShaderPatches},
+		{QueryPipelineStatisticTessellationEvaluationShaderInvocationsBit, &stats.TessellationEvaluationShaderInvocations},
+		{QueryPipelineStatisticComputeShaderInvocationsBit, &stats.ComputeShaderInvocations},
+	}
+
+	i := 0
+	for _, counter := range counters {
+		if flags&counter.bit == 0 {
+			continue
+		}
+		if i >= len(results) {
+			break
+		}
+		*counter.field = results[i]
+		i++
+	}
+	return stats
+}
+
+// PipelineStageFlags represents pipeline stage flags
+type PipelineStageFlags uint32
+
+const (
+	PipelineStageTopOfPipeBit                    PipelineStageFlags = 1
+	PipelineStageDrawIndirectBit                 PipelineStageFlags = 2
+	PipelineStageVertexInputBit                  PipelineStageFlags = 4
+	PipelineStageVertexShaderBit                 PipelineStageFlags = 8
+	PipelineStageTessellationControlShaderBit    PipelineStageFlags = 16
+	PipelineStageTessellationEvaluationShaderBit PipelineStageFlags = 32
+	PipelineStageGeometryShaderBit               PipelineStageFlags = 64
+	PipelineStageFragmentShaderBit               PipelineStageFlags = 128
+	PipelineStageEarlyFragmentTestsBit           PipelineStageFlags = 256
+	PipelineStageLateFragmentTestsBit            PipelineStageFlags = 512
+	PipelineStageColorAttachmentOutputBit        PipelineStageFlags = 1024
+	PipelineStageComputeShaderBit                PipelineStageFlags = 2048
+	PipelineStageTransferBit                     PipelineStageFlags = 4096
+	PipelineStageBottomOfPipeBit                 PipelineStageFlags = 8192
+	PipelineStageHostBit                         PipelineStageFlags = 16384
+	PipelineStageAllGraphicsBit                  PipelineStageFlags = 32768
+	PipelineStageAllCommandsBit                  PipelineStageFlags = 65536
+)
+
+// ExternalSemaphoreHandleTypeFlags identify the kinds of OS handles a semaphore can be shared
+// through (external semaphores, core in Vulkan 1.1)
+type ExternalSemaphoreHandleTypeFlags uint32
+
+// ExternalFenceHandleTypeFlags identify the kinds of OS handles a fence can be shared through
+// (external fences, core in Vulkan 1.1)
+type ExternalFenceHandleTypeFlags uint32
+
+// SemaphoreCreateInfo contains semaphore creation information
+type SemaphoreCreateInfo struct {
+	// SemaphoreType defaults to SemaphoreTypeBinary; timeline semaphores require the
+	// TimelineSemaphore feature
+	SemaphoreType SemaphoreType
+	// InitialValue is the starting counter value of a timeline semaphore
+	InitialValue uint64
+	// ExportHandleTypes makes the semaphore exportable as these handle types, e.g. with
+	// GetSemaphoreFdKHR
+	ExportHandleTypes ExternalSemaphoreHandleTypeFlags
+}
+
+// FenceCreateInfo contains fence creation information
+type FenceCreateInfo struct {
+	Flags FenceCreateFlags
+	// ExportHandleTypes makes the fence exportable as these handle types, e.g. with
+	// GetFenceFdKHR
+	ExportHandleTypes ExternalFenceHandleTypeFlags
+}
+
+// FenceCreateFlags represents fence creation flags
+type FenceCreateFlags uint32
+
+// CreateCommandPool creates a command pool
+func CreateCommandPool(device Device, createInfo *CommandPoolCreateInfo) (CommandPool, error) {
+	return nil, ErrorInitializationFailed
+}
+
+// DestroyCommandPool destroys a command pool
+func DestroyCommandPool(device Device, commandPool CommandPool) {}
+
+// ResetCommandPool resets a command pool
+func ResetCommandPool(device Device, commandPool CommandPool, flags CommandPoolResetFlags) error {
+	return ErrorInitializationFailed
+}
+
+// AllocateCommandBuffers allocates command buffers
+func AllocateCommandBuffers(device Device, allocateInfo *CommandBufferAllocateInfo) ([]CommandBuffer, error) {
+	return nil, ErrorInitializationFailed
+}
+
+// FreeCommandBuffers frees command buffers
+func FreeCommandBuffers(device Device, commandPool CommandPool, commandBuffers []CommandBuffer) {}
+
+// CreateSemaphore creates a semaphore
+func CreateSemaphore(device Device, createInfo *SemaphoreCreateInfo) (Semaphore, error) {
+	return nil, ErrorInitializationFailed
+}
+
+// DestroySemaphore destroys a semaphore
+func DestroySemaphore(device Device, semaphore Semaphore) {}
+
+// CreateFence creates a fence
+func CreateFence(device Device, createInfo *FenceCreateInfo) (Fence, error) {
+	return nil, ErrorInitializationFailed
+}
+
+// DestroyFence destroys a fence
+func DestroyFence(device Device, fence Fence) {}
+
+// ResetFences resets fences
+func ResetFences(device Device, fences []Fence) error {
+	return ErrorInitializationFailed
+}
+
+// QueryType represents the kind of queries in a query pool
+type QueryType int32
+
+// QueryPoolCreateInfo contains query pool creation information
+type QueryPoolCreateInfo struct {
+	QueryType  QueryType
+	QueryCount uint32
+	// PipelineStatistics selects the counters of a QueryTypePipelineStatistics pool and
+	// requires the PipelineStatisticsQuery device feature. Ignored for other query types.
+	PipelineStatistics QueryPipelineStatisticFlags
+}
+
+// CreateQueryPool creates a query pool
+func CreateQueryPool(device Device, createInfo *QueryPoolCreateInfo) (QueryPool, error) {
+	return nil, ErrorInitializationFailed
+}
+
+// DestroyQueryPool destroys a query pool
+func DestroyQueryPool(device Device, queryPool QueryPool) {}
+
+// SemaphoreType selects between binary and timeline semaphores (Vulkan 1.2)
+type SemaphoreType int32
+
+// PhysicalDeviceTimelineSemaphoreFeatures contains timeline semaphore features
+type PhysicalDeviceTimelineSemaphoreFeatures struct {
+	TimelineSemaphore bool
+}
+
+// ValidationFeatureEnable selects an optional validation layer check to turn on
+type ValidationFeatureEnable int32
+
+const (
+	ValidationFeatureEnableGPUAssisted                   ValidationFeatureEnable = 0
+	ValidationFeatureEnableGPUAssistedReserveBindingSlot ValidationFeatureEnable = 1
+)
+
+// ValidationFeatureDisable selects a default validation layer check to turn off
+type ValidationFeatureDisable int32
+
+// ValidationFeatures turns validation layer checks on or off when creating an instance. The
+// instance must enable VK_LAYER_KHRONOS_validation and ExtensionNameValidationFeatures.
+type ValidationFeatures struct {
+	EnabledValidationFeatures  []ValidationFeatureEnable
+	DisabledValidationFeatures []ValidationFeatureDisable
+}
+
+// ExtensionNameValidationFeatures is the validation features instance extension name
+const ExtensionNameValidationFeatures = "VK_EXT_validation_features"
+
+// PhysicalDeviceConditionalRenderingFeatures contains conditional rendering features
+type PhysicalDeviceConditionalRenderingFeatures struct {
+	ConditionalRendering          bool
+	InheritedConditionalRendering bool
+}
+
+// DescriptorBindingFlags control how the descriptors of a binding may be updated and used
+// (descriptor indexing, core in Vulkan 1.2)
+type DescriptorBindingFlags uint32
+
+// PhysicalDeviceDescriptorIndexingFeatures contains descriptor indexing features
+type PhysicalDeviceDescriptorIndexingFeatures struct {
+	ShaderInputAttachmentArrayDynamicIndexing          bool
+	ShaderUniformTexelBufferArrayDynamicIndexing       bool
+	ShaderStorageTexelBufferArrayDynamicIndexing       bool
+	ShaderUniformBufferArrayNonUniformIndexing         bool
+	ShaderSampledImageArrayNonUniformIndexing          bool
+	ShaderStorageBufferArrayNonUniformIndexing         bool
+	ShaderStorageImageArrayNonUniformIndexing          bool
+	ShaderInputAttachmentArrayNonUniformIndexing       bool
+	ShaderUniformTexelBufferArrayNonUniformIndexing    bool
+	ShaderStorageTexelBufferArrayNonUniformIndexing    bool
+	DescriptorBindingUniformBufferUpdateAfterBind      bool
+	DescriptorBindingSampledImageUpdateAfterBind       bool
+	DescriptorBindingStorageImageUpdateAfterBind       bool
+	DescriptorBindingStorageBufferUpdateAfterBind      bool
+	DescriptorBindingUniformTexelBufferUpdateAfterBind bool
+	DescriptorBindingStorageTexelBufferUpdateAfterBind bool
+	DescriptorBindingUpdateUnusedWhilePending          bool
+	DescriptorBindingPartiallyBound                    bool
+	DescriptorBindingVariableDescriptorCount           bool
+	RuntimeDescriptorArray                             bool
+}
+
+// PhysicalDeviceHostImageCopyFeatures contains host image copy feature support
+type PhysicalDeviceHostImageCopyFeatures struct {
+	HostImageCopy bool
+}
+
+// PhysicalDeviceMeshShaderFeatures contains mesh shader features
+type PhysicalDeviceMeshShaderFeatures struct {
+	TaskShader bool
+	MeshShader bool
+}
+
+// PhysicalDeviceCoherentMemoryFeatures contains the AMD device coherent memory feature
+type PhysicalDeviceCoherentMemoryFeatures struct {
+	DeviceCoherentMemory bool
+}
+
+// PhysicalDeviceVertexInputDynamicStateFeatures contains the vertex input dynamic state feature
+type PhysicalDeviceVertexInputDynamicStateFeatures struct {
+	VertexInputDynamicState bool
+}
+
+// PhysicalDeviceFragmentShadingRateFeatures contains the fragment shading rate features
+type PhysicalDeviceFragmentShadingRateFeatures struct {
+	// PipelineFragmentShadingRate allows CmdSetFragmentShadingRateKHR to set a per-draw rate
+	PipelineFragmentShadingRate   bool
+	PrimitiveFragmentShadingRate  bool
+	AttachmentFragmentShadingRate bool
+}
+
+// PhysicalDeviceImageCompressionControlFeatures contains the image compression control feature
+type PhysicalDeviceImageCompressionControlFeatures struct {
+	// ImageCompressionControl allows ImageCompressionControlEXT to be chained to image
+	// creation and format queries
+	ImageCompressionControl bool
+}
+
+// DeviceGroupDeviceCreateInfo creates a logical device spanning several physical devices
+type DeviceGroupDeviceCreateInfo struct {
+	PhysicalDevices []PhysicalDevice
+}
+
+// PhysicalDeviceCooperativeMatrixFeatures contains the cooperative matrix features
+type PhysicalDeviceCooperativeMatrixFeatures struct {
+	CooperativeMatrix                   bool
+	CooperativeMatrixRobustBufferAccess bool
+}
+
+// PhysicalDeviceGraphicsPipelineLibraryFeatures contains the graphics pipeline library feature
+type PhysicalDeviceGraphicsPipelineLibraryFeatures struct {
+	GraphicsPipelineLibrary bool
+}
+
+// PhysicalDeviceFaultFeatures contains the device fault reporting features
+type PhysicalDeviceFaultFeatures struct {
+	DeviceFault             bool
+	DeviceFaultVendorBinary bool
+}
+
+// PhysicalDeviceExtendedDynamicState3Features contains the extended dynamic state 3 features
+type PhysicalDeviceExtendedDynamicState3Features struct {
+	ExtendedDynamicState3PolygonMode          bool
+	ExtendedDynamicState3RasterizationSamples bool
+	ExtendedDynamicState3LogicOpEnable        bool
+	ExtendedDynamicState3ColorBlendEnable     bool
+	ExtendedDynamicState3ColorBlendEquation   bool
+	ExtendedDynamicState3ColorWriteMask       bool
+}
+
+// PhysicalDevicePresentWaitFeatures contains the present ID and present wait features
+type PhysicalDevicePresentWaitFeatures struct {
+	// PresentID allows tagging presents with an application-chosen, increasing ID
+	PresentID bool
+	// PresentWait allows waiting for a tagged present with WaitForPresentKHR
+	PresentWait bool
+}
+
+// PhysicalDeviceRayTracingFeatures contains the features required for ray tracing
+type PhysicalDeviceRayTracingFeatures struct {
+	BufferDeviceAddress   bool
+	AccelerationStructure bool
+	RayTracingPipeline    bool
+}
+
+// PhysicalDeviceTransformFeedbackFeatures contains transform feedback features
+type PhysicalDeviceTransformFeedbackFeatures struct {
+	TransformFeedback bool
+	GeometryStreams   bool
+}
+
+// PipelineCreationFeedbackFlags represents pipeline creation feedback flags
+type PipelineCreationFeedbackFlags uint32
+
+// PipelineCreationFeedback provides feedback about pipeline creation
+type PipelineCreationFeedback struct {
+	Flags    PipelineCreationFeedbackFlags
+	Duration uint64
+}
+
+// PipelineCreationFeedbackCreateInfo requests creation feedback for a pipeline. The driver
+// fills PipelineCreationFeedback and, if non-empty, one entry of PipelineStageCreationFeedbacks
+// per shader stage once the pipeline has been created. Durations are in nanoseconds.
+type PipelineCreationFeedbackCreateInfo struct {
+	PipelineCreationFeedback       *PipelineCreationFeedback
+	PipelineStageCreationFeedbacks []PipelineCreationFeedback
+}
+
+// ExtensionProperties contains extension information
+type ExtensionProperties struct {
+	ExtensionName string
+	SpecVersion   uint32
+}
+
+// LayerProperties contains layer information
+type LayerProperties struct {
+	LayerName             string
+	SpecVersion           Version
+	ImplementationVersion Version
+	Description           string
+}
+
+// QueueFamilyProperties contains queue family properties
+type QueueFamilyProperties struct {
+	QueueFlags                  QueueFlags
+	QueueCount                  uint32
+	TimestampValidBits          uint32
+	MinImageTransferGranularity Extent3D
+}
+
+// EnumerateInstanceVersion returns the highest Vulkan version the loader supports for instances.
+// Requesting a higher ApplicationInfo.APIVersion makes CreateInstance fail on 1.0 loaders, so
+// clamp the requested version to this first. Version10 is returned for loaders that predate
+// the query.
+func EnumerateInstanceVersion() (Version, error) {
+	return 0, ErrorInitializationFailed
+}
+
+// EnumerateInstanceExtensionProperties enumerates available instance extensions
+func EnumerateInstanceExtensionProperties(layerName string) ([]ExtensionProperties, error) {
+	return nil, ErrorInitializationFailed
+}
+
+// EnumerateInstanceLayerProperties enumerates available instance layers
+func EnumerateInstanceLayerProperties() ([]LayerProperties, error) {
+	return nil, ErrorInitializationFailed
+}
+
+// EnumerateDeviceExtensionProperties enumerates device extension properties
+func EnumerateDeviceExtensionProperties(physicalDevice PhysicalDevice, layerName string) ([]ExtensionProperties, error) {
+	return nil, ErrorInitializationFailed
+}
+
+// GetPhysicalDeviceQueueFamilyProperties gets queue family properties
+func GetPhysicalDeviceQueueFamilyProperties(physicalDevice PhysicalDevice) []QueueFamilyProperties {
+	return nil
+}
+
+// GetPhysicalDeviceFeatures gets physical device features
+func GetPhysicalDeviceFeatures(physicalDevice PhysicalDevice) PhysicalDeviceFeatures {
+	return PhysicalDeviceFeatures{}
+}
+
+// GetBufferMemoryRequirements gets buffer memory requirements
+func GetBufferMemoryRequirements(device Device, buffer Buffer) MemoryRequirements {
+	return MemoryRequirements{}
+}
+
+// GetAPIVersion returns the supported Vulkan API version
+func GetAPIVersion() Version {
+	return Version13
+}
+
+// IsExtensionSupported checks if an extension is supported
+func IsExtensionSupported(extensionName string, availableExtensions []ExtensionProperties) bool {
+	for _, ext := range availableExtensions {
+		if ext.ExtensionName == extensionName {
+			return true
+		}
+	}
+	return false
+}
+
+// IsLayerSupported checks if a layer is supported
+func IsLayerSupported(layerName string, availableLayers []LayerProperties) bool {
+	for _, layer := range availableLayers {
+		if layer.LayerName == layerName {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterSupportedExtensions splits requested extension names into those found in
+// availableExtensions and those that are missing, preserving the requested order. Use it to
+// report every unavailable extension before CreateInstance or CreateDevice fails with
+// ErrorExtensionNotPresent.
+func FilterSupportedExtensions(requested []string, availableExtensions []ExtensionProperties) (supported, missing []string) {
+	for _, name := range requested {
+		if IsExtensionSupported(name, availableExtensions) {
+			supported = append(supported, name)
+		} else {
+			missing = append(missing, name)
+		}
+	}
+	return supported, missing
+}
+
+// CommandBufferUsageFlags represents command buffer usage flags
+type CommandBufferUsageFlags uint32
+
+const (
+	CommandBufferUsageOneTimeSubmitBit      CommandBufferUsageFlags = 1
+	CommandBufferUsageRenderPassContinueBit CommandBufferUsageFlags = 2
+	CommandBufferUsageSimultaneousUseBit    CommandBufferUsageFlags = 4
+)
+
+// QueryControlFlags represents query control flags
+type QueryControlFlags uint32
+
+// CommandBufferBeginInfo contains command buffer begin information
+type CommandBufferBeginInfo struct {
+	Flags CommandBufferUsageFlags
+	// InheritanceInfo is required for secondary command buffers and ignored for primary ones
+	InheritanceInfo *CommandBufferInheritanceInfo
+}
+
+// CommandBufferInheritanceInfo describes the state a secondary command buffer inherits
+// from the primary command buffer that executes it
+type CommandBufferInheritanceInfo struct {
+	RenderPass           RenderPass
+	Subpass              uint32
+	Framebuffer          Framebuffer
+	OcclusionQueryEnable bool
+	QueryFlags           QueryControlFlags
+	PipelineStatistics   QueryPipelineStatisticFlags
+	// Rendering describes the dynamic rendering scope the secondary is executed in.
+	// Set it together with CommandBufferUsageRenderPassContinueBit to record a secondary
+	// for use inside CmdBeginRendering with RenderingContentsSecondaryCommandBuffers.
+	Rendering *CommandBufferInheritanceRenderingInfo
+}
+
+// CommandBufferInheritanceRenderingInfo describes the dynamic rendering attachments a
+// secondary command buffer is recorded against
+type CommandBufferInheritanceRenderingInfo struct {
+	Flags                   RenderingFlags
+	ViewMask                uint32
+	ColorAttachmentFormats  []Format
+	DepthAttachmentFormat   Format
+	StencilAttachmentFormat Format
+	RasterizationSamples    SampleCountFlags
+}
+
+// SubmitInfo contains queue submit information
+type SubmitInfo struct {
+	WaitSemaphores   []Semaphore
+	WaitDstStageMask []PipelineStageFlags
+	CommandBuffers   []CommandBuffer
+	SignalSemaphores []Semaphore
+}
+
+// BeginCommandBuffer begins recording a command buffer
+func BeginCommandBuffer(commandBuffer CommandBuffer, beginInfo *CommandBufferBeginInfo) error {
+	return ErrorInitializationFailed
+}
+
+// EndCommandBuffer ends recording a command buffer
+func EndCommandBuffer(commandBuffer CommandBuffer) error {
+	return ErrorInitializationFailed
+}
+
+// QueueSubmit submits command buffers to a queue
+func QueueSubmit(queue Queue, submitInfos []SubmitInfo, fence Fence) error {
+	return ErrorInitializationFailed
+}
+
+// WaitForFences waits for fences to be signaled
+func WaitForFences(device Device, fences []Fence, waitAll bool, timeout uint64) error {
+	return ErrorInitializationFailed
+}
+
+// RunOneTimeCommands allocates a primary command buffer from pool, records it with
+// record, submits it to queue and waits for completion. The command buffer and fence
+// are released before returning, including when record or submission fails. If waiting
+// for the fence fails, the queue is idled before they are released; if that fails too,
+// they are left allocated, since the submission may still be executing, and the returned
+// error says so.
+func RunOneTimeCommands(device Device, pool CommandPool, queue Queue, record func(cb CommandBuffer) error) error {
+	return ErrorInitializationFailed
+}
+
+// BufferCopy describes a buffer copy region
+type BufferCopy struct {
+	SrcOffset DeviceSize
+	DstOffset DeviceSize
+	Size      DeviceSize
+}
+
+// CmdBindPipeline binds a pipeline
+func CmdBindPipeline(commandBuffer CommandBuffer, pipelineBindPoint PipelineBindPoint, pipeline Pipeline) {
+}
+
+// CmdDispatch dispatches compute work
+func CmdDispatch(commandBuffer CommandBuffer, groupCountX, groupCountY, groupCountZ uint32) {}
+
+// CmdCopyBuffer copies data between buffers
+func CmdCopyBuffer(commandBuffer CommandBuffer, srcBuffer, dstBuffer Buffer, regions []BufferCopy) {}
+
+// CmdPipelineBarrier inserts a pipeline barrier
+func CmdPipelineBarrier(commandBuffer CommandBuffer, srcStageMask, dstStageMask PipelineStageFlags, dependencyFlags uint32) {
+}
+
+// CmdExecuteCommands records the execution of secondary command buffers into a primary
+// command buffer
+func CmdExecuteCommands(primary CommandBuffer, secondaries []CommandBuffer) error {
+	return ErrorInitializationFailed
+}
+
+// Offset2D represents a 2D offset
+type Offset2D struct {
+	X int32
+	Y int32
+}
+
+// Extent2D represents a 2D extent
+type Extent2D struct {
+	Width  uint32
+	Height uint32
+}
+
+// Rect2D represents a 2D rectangle
+type Rect2D struct {
+	Offset Offset2D
+	Extent Extent2D
+}
+
+// ClearColorValue represents a clear color value
+type ClearColorValue struct {
+	Float32 [4]float32
+	Int32   [4]int32
+	Uint32  [4]uint32
+}
+
+// ClearDepthStencilValue represents a clear depth/stencil value
+type ClearDepthStencilValue struct {
+	Depth   float32
+	Stencil uint32
+}
+
+// ClearValue represents a clear value union. Set exactly one of Color.Float32, Color.Int32,
+// Color.Uint32 and DepthStencil, directly or with the ClearColorValue* and ClearDepthStencil
+// constructors; the set member is encoded into the VkClearValue union. Setting more than one
+// is rejected by the commands that validate clear values.
+type ClearValue struct {
+	Color        ClearColorValue
+	DepthStencil ClearDepthStencilValue
+}
+
+// ClearColorValueFloat returns a clear value for float, UNORM, SNORM and SRGB color attachments
+func ClearColorValueFloat(r, g, b, a float32) ClearValue {
+	var value ClearValue
+	value.Color.Float32 = [4]float32{r, g, b, a}
+	return value
+}
+
+// ClearColorValueInt returns a clear value for SINT color attachments
+func ClearColorValueInt(r, g, b, a int32) ClearValue {
+	var value ClearValue
+	value.Color.Int32 = [4]int32{r, g, b, a}
+	return value
+}
+
+// ClearColorValueUint returns a clear value for UINT color attachments
+func ClearColorValueUint(r, g, b, a uint32) ClearValue {
+	var value ClearValue
+	value.Color.Uint32 = [4]uint32{r, g, b, a}
+	return value
+}
+
+// ClearDepthStencil returns a clear value for depth/stencil attachments
+func ClearDepthStencil(depth float32, stencil uint32) ClearValue {
+	var value ClearValue
+	value.DepthStencil = ClearDepthStencilValue{Depth: depth, Stencil: stencil}
+	return value
+}
+
+// ResolveModeFlagBits represents multisample resolve modes
+type ResolveModeFlagBits uint32
+
+const (
+	ResolveModeNone       ResolveModeFlagBits = 0
+	ResolveModeSampleZero ResolveModeFlagBits = 1
+	ResolveModeAverage    ResolveModeFlagBits = 2
+	ResolveModeMin        ResolveModeFlagBits = 4
+	ResolveModeMax        ResolveModeFlagBits = 8
+)
+
+// RenderingFlags represents flags for dynamic rendering
+type RenderingFlags uint32
+
+const (
+	RenderingContentsSecondaryCommandBuffers RenderingFlags = 1
+	RenderingSuspending                      RenderingFlags = 2
+	RenderingResuming                        RenderingFlags = 4
+)
+
+// RenderingAttachmentInfo describes a single attachment for dynamic rendering
+type RenderingAttachmentInfo struct {
+	ImageView          ImageView
+	ImageLayout        ImageLayout
+	ResolveMode        ResolveModeFlagBits
+	ResolveImageView   ImageView
+	ResolveImageLayout ImageLayout
+	LoadOp             AttachmentLoadOp
+	StoreOp            AttachmentStoreOp
+	ClearValue         ClearValue
+}
+
+// RenderingInfo contains information to begin a render pass instance
+type RenderingInfo struct {
+	Flags             RenderingFlags
+	RenderArea        Rect2D
+	LayerCount        uint32
+	ViewMask          uint32
+	ColorAttachments  []RenderingAttachmentInfo
+	DepthAttachment   *RenderingAttachmentInfo
+	StencilAttachment *RenderingAttachmentInfo
+}
+
+// ExtensionNameDynamicRendering is the dynamic rendering extension name, needed on Vulkan 1.2
+// devices
+const ExtensionNameDynamicRendering = "VK_KHR_dynamic_rendering"
+
+// PhysicalDeviceDynamicRenderingFeatures enables dynamic rendering on devices that expose it
+// through ExtensionNameDynamicRendering. On Vulkan 1.3 devices use
+// PhysicalDeviceVulkan13Features instead.
+type PhysicalDeviceDynamicRenderingFeatures struct {
+	DynamicRendering bool
+}
+
+// ExtensionNameSynchronization2 is the synchronization2 extension name, needed on Vulkan 1.2
+// devices
+const ExtensionNameSynchronization2 = "VK_KHR_synchronization2"
+
+// PhysicalDeviceSynchronization2Features enables synchronization2 on devices that expose it
+// through ExtensionNameSynchronization2. On Vulkan 1.3 devices use
+// PhysicalDeviceVulkan13Features instead. The feature is required by QueueSubmit2,
+// QueueSubmit2KHR, CmdPipelineBarrier2, CmdWriteTimestamp2 and ImageState.EnsureLayout.
+type PhysicalDeviceSynchronization2Features struct {
+	Synchronization2 bool
+}
+
+// PhysicalDeviceVulkan13Features enables the features promoted to core in Vulkan 1.3. It can
+// only be used on Vulkan 1.3 devices, and cannot be combined with the per-extension feature
+// structs it covers.
+type PhysicalDeviceVulkan13Features struct {
+	RobustImageAccess                                  bool
+	InlineUniformBlock                                 bool
+	DescriptorBindingInlineUniformBlockUpdateAfterBind bool
+	PipelineCreationCacheControl                       bool
+	PrivateData                                        bool
+	ShaderDemoteToHelperInvocation                     bool
+	ShaderTerminateInvocation                          bool
+	SubgroupSizeControl                                bool
+	ComputeFullSubgroups                               bool
+	Synchronization2                                   bool
+	TextureCompressionASTCHDR                          bool
+	ShaderZeroInitializeWorkgroupMemory                bool
+	DynamicRendering                                   bool
+	ShaderIntegerDotProduct                            bool
+	Maintenance4                                       bool
+}
+
+// DynamicRenderingPath identifies the entry points CmdBeginRendering and CmdEndRendering use
+type DynamicRenderingPath int
+
+const (
+	// DynamicRenderingPathNone means the device exposes neither the core nor the KHR commands
+	DynamicRenderingPathNone DynamicRenderingPath = iota
+	// DynamicRenderingPathCore uses vkCmdBeginRendering/vkCmdEndRendering from Vulkan 1.3
+	DynamicRenderingPathCore
+	// DynamicRenderingPathKHR uses vkCmdBeginRenderingKHR/vkCmdEndRenderingKHR from
+	// VK_KHR_dynamic_rendering, for Vulkan 1.2 devices
+	DynamicRenderingPathKHR
+)
+
+// String returns the name of the dynamic rendering path
+func (p DynamicRenderingPath) String() string {
+	switch p {
+	case DynamicRenderingPathCore:
+		return "core"
+	case DynamicRenderingPathKHR:
+		return ExtensionNameDynamicRendering
+	default:
+		return "unavailable"
+	}
+}
+
+// LoadDynamicRenderingFunctions resolves the dynamic rendering entry points for a device and
+// returns the path CmdBeginRendering and CmdEndRendering will use. Calling it is optional;
+// they resolve the entry points on first use.
+func LoadDynamicRenderingFunctions(device Device) DynamicRenderingPath {
+	return DynamicRenderingPathNone
+}
+
+// CmdBeginRendering begins a render pass instance with dynamic rendering
+func CmdBeginRendering(commandBuffer CommandBuffer, renderingInfo *RenderingInfo) error {
+	return ErrorInitializationFailed
+}
+
+// CmdEndRendering ends a render pass instance with dynamic rendering
+func CmdEndRendering(commandBuffer CommandBuffer) error {
+	return ErrorInitializationFailed
+}
+
+// CullModeFlags represents face culling modes
+type CullModeFlags uint32
+
+const (
+	CullModeNone         CullModeFlags = 0
+	CullModeFront        CullModeFlags = 1
+	CullModeBack         CullModeFlags = 2
+	CullModeFrontAndBack CullModeFlags = 3
+)
+
+// FrontFace represents front-facing triangle orientation
+type FrontFace uint32
+
+const (
+	FrontFaceCounterClockwise FrontFace = 0
+	FrontFaceClockwise        FrontFace = 1
+)
+
+// PrimitiveTopology represents primitive topology
+type PrimitiveTopology uint32
+
+const (
+	PrimitiveTopologyPointList                  PrimitiveTopology = 0
+	PrimitiveTopologyLineList                   PrimitiveTopology = 1
+	PrimitiveTopologyLineStrip                  PrimitiveTopology = 2
+	PrimitiveTopologyTriangleList               PrimitiveTopology = 3
+	PrimitiveTopologyTriangleStrip              PrimitiveTopology = 4
+	PrimitiveTopologyTriangleFan                PrimitiveTopology = 5
+	PrimitiveTopologyLineListWithAdjacency      PrimitiveTopology = 6
+	PrimitiveTopologyLineStripWithAdjacency     PrimitiveTopology = 7
+	PrimitiveTopologyTriangleListWithAdjacency  PrimitiveTopology = 8
+	PrimitiveTopologyTriangleStripWithAdjacency PrimitiveTopology = 9
+	PrimitiveTopologyPatchList                  PrimitiveTopology = 10
+)
+
+// CompareOp represents comparison operations
+type CompareOp uint32
+
+const (
+	CompareOpNever          CompareOp = 0
+	CompareOpLess           CompareOp = 1
+	CompareOpEqual          CompareOp = 2
+	CompareOpLessOrEqual    CompareOp = 3
+	CompareOpGreater        CompareOp = 4
+	CompareOpNotEqual       CompareOp = 5
+	CompareOpGreaterOrEqual CompareOp = 6
+	CompareOpAlways         CompareOp = 7
+)
+
+// CmdSetCullMode sets the cull mode dynamically
+func CmdSetCullMode(commandBuffer CommandBuffer, cullMode CullModeFlags) error {
+	return ErrorInitializationFailed
+}
+
+// CmdSetFrontFace sets the front face orientation dynamically
+func CmdSetFrontFace(commandBuffer CommandBuffer, frontFace FrontFace) error {
+	return ErrorInitializationFailed
+}
+
+// CmdSetPrimitiveTopology sets the primitive topology dynamically
+func CmdSetPrimitiveTopology(commandBuffer CommandBuffer, primitiveTopology PrimitiveTopology) error {
+	return ErrorInitializationFailed
+}
+
+// CmdSetDepthTestEnable sets depth test enable state dynamically
+func CmdSetDepthTestEnable(commandBuffer CommandBuffer, depthTestEnable bool) error {
+	return ErrorInitializationFailed
+}
+
+// CmdSetDepthWriteEnable sets depth write enable state dynamically
+func CmdSetDepthWriteEnable(commandBuffer CommandBuffer, depthWriteEnable bool) error {
+	return ErrorInitializationFailed
+}
+
+// CmdSetDepthCompareOp sets depth compare operation dynamically
+func CmdSetDepthCompareOp(commandBuffer CommandBuffer, depthCompareOp CompareOp) error {
+	return ErrorInitializationFailed
+}
+
+// SubmitFlags represents flags for queue submission
+type SubmitFlags uint32
+
+const (
+	SubmitProtected SubmitFlags = 1
+)
+
+type PipelineStageFlags2 uint64
+
+const (
+	PipelineStage2None                         PipelineStageFlags2 = 0
+	PipelineStage2TopOfPipe                    PipelineStageFlags2 = 0x00000001
+	PipelineStage2DrawIndirect                 PipelineStageFlags2 = 0x00000002
+	PipelineStage2VertexInput                  PipelineStageFlags2 = 0x00000004
+	PipelineStage2VertexShader                 PipelineStageFlags2 = 0x00000008
+	PipelineStage2TessellationControlShader    PipelineStageFlags2 = 0x00000010
+	PipelineStage2TessellationEvaluationShader PipelineStageFlags2 = 0x00000020
+	PipelineStage2GeometryShader               PipelineStageFlags2 = 0x00000040
+	PipelineStage2FragmentShader               PipelineStageFlags2 = 0x00000080
+	PipelineStage2EarlyFragmentTests           PipelineStageFlags2 = 0x00000100
+	PipelineStage2LateFragmentTests            PipelineStageFlags2 = 0x00000200
+	PipelineStage2ColorAttachmentOutput        PipelineStageFlags2 = 0x00000400
+	PipelineStage2ComputeShader                PipelineStageFlags2 = 0x00000800
+	PipelineStage2AllTransfer                  PipelineStageFlags2 = 0x00001000
+	PipelineStage2BottomOfPipe                 PipelineStageFlags2 = 0x00002000
+	PipelineStage2Host                         PipelineStageFlags2 = 0x00004000
+	PipelineStage2AllGraphics                  PipelineStageFlags2 = 0x00008000
+	PipelineStage2AllCommands                  PipelineStageFlags2 = 0x00010000
+	PipelineStage2Copy                         PipelineStageFlags2 = 0x100000000
+	PipelineStage2Resolve                      PipelineStageFlags2 = 0x200000000
+	PipelineStage2Blit                         PipelineStageFlags2 = 0x400000000
+	PipelineStage2Clear                        PipelineStageFlags2 = 0x800000000
+	PipelineStage2IndexInput                   PipelineStageFlags2 = 0x1000000000
+	PipelineStage2VertexAttributeInput         PipelineStageFlags2 = 0x2000000000
+	PipelineStage2PreRasterizationShaders      PipelineStageFlags2 = 0x4000000000
+)
+
+// SemaphoreSubmitInfo describes a semaphore signal or wait operation
+type SemaphoreSubmitInfo struct {
+	Semaphore Semaphore
+	Value     uint64
+	StageMask PipelineStageFlags2
+	// DeviceIndex selects the device of a device group that executes the operation. It is
+	// ignored unless the device was created with DeviceCreateInfo.DeviceGroup.
+	DeviceIndex uint32
+}
+
+// CommandBufferSubmitInfo describes a command buffer submit operation
+type CommandBufferSubmitInfo struct {
+	CommandBuffer CommandBuffer
+	// DeviceMask selects the devices of a device group that execute the command buffer.
+	// 0 means all of them; bit i selects DeviceGroupDeviceCreateInfo.PhysicalDevices[i].
+	DeviceMask uint32
+}
+
+// SubmitInfo2 describes a queue submission operation with enhanced synchronization
+type SubmitInfo2 struct {
+	Flags                SubmitFlags
+	WaitSemaphoreInfos   []SemaphoreSubmitInfo
+	CommandBufferInfos   []CommandBufferSubmitInfo
+	SignalSemaphoreInfos []SemaphoreSubmitInfo
+}
+
+// QueueSubmit2 submits command buffers to a queue with enhanced synchronization
+func QueueSubmit2(queue Queue, submitInfos []SubmitInfo2, fence Fence) error {
+	return ErrorInitializationFailed
+}
+
+// ObjectType represents Vulkan object types
+type ObjectType uint32
+
+const (
+	ObjectTypeUnknown        ObjectType = 0
+	ObjectTypeInstance       ObjectType = 1
+	ObjectTypePhysicalDevice ObjectType = 2
+	ObjectTypeDevice         ObjectType = 3
+	ObjectTypeQueue          ObjectType = 4
+	ObjectTypeSemaphore      ObjectType = 5
+	ObjectTypeCommandBuffer  ObjectType = 6
+	ObjectTypeFence          ObjectType = 7
+	ObjectTypeDeviceMemory   ObjectType = 8
+	ObjectTypeBuffer         ObjectType = 9
+)
+
+// PrivateDataSlotCreateFlags represents flags for private data slot creation
+type PrivateDataSlotCreateFlags uint32
+
+// PrivateDataSlotCreateInfo contains information for creating a private data slot
+type PrivateDataSlotCreateInfo struct {
+	Flags PrivateDataSlotCreateFlags
+}
+
+// CreatePrivateDataSlot creates a private data slot
+func CreatePrivateDataSlot(device Device, createInfo *PrivateDataSlotCreateInfo) (PrivateDataSlot, error) {
+	return nil, ErrorInitializationFailed
+}
+
+// DestroyPrivateDataSlot destroys a private data slot
+func DestroyPrivateDataSlot(device Device, privateDataSlot PrivateDataSlot) {}
+
+// SetPrivateData associates data with a Vulkan object
+func SetPrivateData(device Device, objectType ObjectType, objectHandle uint64, privateDataSlot PrivateDataSlot, data uint64) error {
+	return ErrorInitializationFailed
+}
+
+// GetPrivateData retrieves data associated with a Vulkan object
+func GetPrivateData(device Device, objectType ObjectType, objectHandle uint64, privateDataSlot PrivateDataSlot) uint64 {
+	return 0
+}
+
+// GetDeviceBufferMemoryRequirements gets buffer memory requirements without creating a buffer (Vulkan 1.3)
+func GetDeviceBufferMemoryRequirements(device Device, bufferCreateInfo *BufferCreateInfo) (MemoryRequirements, error) {
+	return MemoryRequirements{}, ErrorInitializationFailed
+}
+
+// GetDeviceImageMemoryRequirements gets image memory requirements without creating an image (Vulkan 1.3)
+func GetDeviceImageMemoryRequirements(device Device, imageCreateInfo *ImageCreateInfo) (MemoryRequirements, error) {
+	return MemoryRequirements{}, ErrorInitializationFailed
+}
@@ -0,0 +1,58 @@
+//go:build !cgo
+
+package vulkan
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"unsafe"
+)
+
+// TestStubPureHelpers tests that the pure-Go helpers mirrored in the stub compute real results
+// instead of the zero values returned by the Vulkan calls
+func TestStubPureHelpers(t *testing.T) {
+	available := []ExtensionProperties{{ExtensionName: "VK_KHR_swapchain"}}
+	supported, missing := FilterSupportedExtensions([]string{"VK_KHR_swapchain", "VK_EXT_mesh_shader"}, available)
+	if !slices.Equal(supported, []string{"VK_KHR_swapchain"}) || !slices.Equal(missing, []string{"VK_EXT_mesh_shader"}) {
+		t.Errorf("Expected supported [VK_KHR_swapchain] and missing [VK_EXT_mesh_shader], got %v and %v", supported, missing)
+	}
+
+	var memProperties PhysicalDeviceMemoryProperties
+	memProperties.MemoryTypeCount = 2
+	memProperties.MemoryTypes[0].PropertyFlags = MemoryPropertyHostVisibleBit
+	memProperties.MemoryTypes[1].PropertyFlags = MemoryPropertyHostVisibleBit | MemoryPropertyDeviceLocalBit
+	if index, ok := FindMemoryTypeWithFallback(memProperties, 0b11, MemoryPropertyHostVisibleBit, MemoryPropertyDeviceLocalBit); !ok || index != 1 {
+		t.Errorf("Expected memory type 1, got %d (ok=%v)", index, ok)
+	}
+	if index, ok := FindMemoryType(memProperties, 0b01, MemoryPropertyHostVisibleBit); !ok || index != 0 {
+		t.Errorf("Expected memory type 0, got %d (ok=%v)", index, ok)
+	}
+
+	if aspect := AspectMaskForFormat(FormatD24UnormS8Uint); aspect != ImageAspectDepthBit|ImageAspectStencilBit {
+		t.Errorf("Expected depth and stencil aspects, got %d", aspect)
+	}
+	if levels := MipLevelCount(1024, 512); levels != 11 {
+		t.Errorf("Expected 11 mip levels, got %d", levels)
+	}
+	if count := (QueryPipelineStatisticVertexShaderInvocationsBit | QueryPipelineStatisticComputeShaderInvocationsBit).Count(); count != 2 {
+		t.Errorf("Expected 2 counters, got %d", count)
+	}
+
+	if _, err := EnumerateDeviceExtensionProperties(PhysicalDevice(nil), ""); !errors.Is(err, ErrorInitializationFailed) {
+		t.Errorf("Expected ErrorInitializationFailed from a Vulkan call, got %v", err)
+	}
+}
+
+// TestStubAllocatorFallsBackToVulkan10 tests that an allocator for a device that does not report
+// Vulkan 1.1 uses the Vulkan 1.0 memory requirement query
+func TestStubAllocatorFallsBackToVulkan10(t *testing.T) {
+	var fakeHandle byte
+	allocator, err := NewAllocator(Device(unsafe.Pointer(&fakeHandle)), PhysicalDevice(unsafe.Pointer(&fakeHandle)))
+	if err != nil {
+		t.Fatalf("Expected allocator, got %v", err)
+	}
+	if allocator.requirements2 {
+		t.Error("Expected the Vulkan 1.0 memory requirement query for a device without Vulkan 1.1")
+	}
+}
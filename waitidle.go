@@ -0,0 +1,33 @@
+package vulkan
+
+import "context"
+
+// DeviceWaitIdleContext waits for a device to become idle like DeviceWaitIdle, but returns
+// ctx.Err() as soon as ctx is cancelled or its deadline passes, so a hung GPU cannot block
+// the caller forever.
+//
+// vkDeviceWaitIdle itself cannot be interrupted. After a cancellation the wait keeps running
+// on a background goroutine until the device becomes idle or is lost, and the device may
+// still be executing work: do not destroy it or any resource it uses unless the process is
+// about to exit anyway.
+func DeviceWaitIdleContext(ctx context.Context, device Device) error {
+	if device == nil {
+		return NewValidationError("device", "cannot be nil")
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// Buffered so the goroutine can finish and exit even if nobody receives the result
+	done := make(chan error, 1)
+	go func() {
+		done <- DeviceWaitIdle(device)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,24 @@
+package vulkan
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"unsafe"
+)
+
+// TestDeviceWaitIdleContextValidation tests that invalid arguments and an already cancelled
+// context return without waiting on the device
+func TestDeviceWaitIdleContextValidation(t *testing.T) {
+	var validationErr *ValidationError
+	if err := DeviceWaitIdleContext(context.Background(), nil); !errors.As(err, &validationErr) || validationErr.Parameter != "device" {
+		t.Errorf("Expected ValidationError for device, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var fakeHandle byte
+	if err := DeviceWaitIdleContext(ctx, Device(unsafe.Pointer(&fakeHandle))); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}